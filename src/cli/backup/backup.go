@@ -46,6 +46,8 @@ var serviceCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addOneDriveCommands,
 	addSharePointCommands,
 	addGroupsCommands,
+	addChatsCommands,
+	addEntraIDCommands,
 }
 
 // AddCommands attaches all `corso backup * *` commands to the parent.
@@ -63,6 +65,10 @@ func AddCommands(cmd *cobra.Command) {
 			flags.AddAllStorageFlags(sc)
 		}
 	}
+
+	addPruneCommands(backupC)
+	addSearchCommands(backupC)
+	addDiffCommands(backupC)
 }
 
 // ---------------------------------------------------------------------------
@@ -390,7 +396,13 @@ func genericDetailsCore(
 
 	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
 
-	d, _, errs := bg.GetBackupDetails(ctx, backupID)
+	// only read the parts of the details that the selector can match.
+	filter := sel.DetailsFilter()
+	if opts.SkipReduce {
+		filter = details.ChunkFilter{}
+	}
+
+	d, _, errs := bg.GetFilteredBackupDetails(ctx, backupID, filter)
 	// TODO: log/track recoverable errors
 	if errs.Failure() != nil {
 		if errors.Is(errs.Failure(), data.ErrNotFound) {
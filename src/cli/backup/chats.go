@@ -0,0 +1,270 @@
+package backup
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// ------------------------------------------------------------------------------------------------
+// setup and globals
+// ------------------------------------------------------------------------------------------------
+
+const (
+	chatsServiceCommand                 = "chats"
+	chatsServiceCommandCreateUseSuffix  = "--user <email> | '" + flags.Wildcard + "'"
+	chatsServiceCommandDeleteUseSuffix  = "--backups <backupId>"
+	chatsServiceCommandDetailsUseSuffix = "--backup <backupId>"
+)
+
+const (
+	chatsServiceCommandCreateExamples = `# Backup all Teams chats for Alice
+corso backup create chats --user alice@example.com
+
+# Backup the Teams chats of all users
+corso backup create chats --user '*'`
+
+	chatsServiceCommandDeleteExamples = `# Delete chats backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
+and 1234abcd-12ab-cd34-56de-1234abce
+corso backup delete chats --backups 1234abcd-12ab-cd34-56de-1234abcd,1234abcd-12ab-cd34-56de-1234abce`
+
+	chatsServiceCommandDetailsExamples = `# Explore items in Alice's latest backup (1234abcd...)
+corso backup details chats --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Explore messages Alice exchanged with Bob after the start of 2024
+corso backup details chats --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --chat-member bob@example.com --message-created-after 2024-01-01T00:00:00`
+)
+
+// called by backup.go to map subcommands to provider-specific handling.
+func addChatsCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case createCommand:
+		c, _ = utils.AddCommand(cmd, chatsCreateCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + chatsServiceCommandCreateUseSuffix
+		c.Example = chatsServiceCommandCreateExamples
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		flags.AddUserFlag(c)
+		flags.AddFetchParallelismFlag(c)
+		flags.AddDisableDeltaFlag(c)
+		flags.AddGenericBackupFlags(c)
+
+	case listCommand:
+		c, _ = utils.AddCommand(cmd, chatsListCmd(), utils.MarkPreviewCommand())
+
+		flags.AddBackupIDFlag(c, false)
+		flags.AddAllBackupListFlags(c)
+
+	case detailsCommand:
+		c, _ = utils.AddCommand(cmd, chatsDetailsCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + chatsServiceCommandDetailsUseSuffix
+		c.Example = chatsServiceCommandDetailsExamples
+
+		flags.AddSkipReduceFlag(c)
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		// More generic (ex: --user) and more frequently used flags take precedence.
+		flags.AddBackupIDFlag(c, true)
+		flags.AddChatsDetailsAndRestoreFlags(c)
+
+	case deleteCommand:
+		c, _ = utils.AddCommand(cmd, chatsDeleteCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + chatsServiceCommandDeleteUseSuffix
+		c.Example = chatsServiceCommandDeleteExamples
+
+		flags.AddMultipleBackupIDsFlag(c, false)
+		flags.AddBackupIDFlag(c, false)
+	}
+
+	return c
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup create
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup create chats [<flag>...]`
+func chatsCreateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   chatsServiceCommand,
+		Short: "Backup M365 Teams chats",
+		RunE:  createChatsCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// processes a teams chats backup.
+func createChatsCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := validateChatsBackupCreateFlags(flags.UserFV); err != nil {
+		return err
+	}
+
+	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
+		ctx,
+		cmd,
+		path.TeamsChatsService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	sel := chatsBackupCreateSelectors(flags.UserFV)
+
+	ins, err := utils.UsersMap(
+		ctx,
+		*acct,
+		utils.Control(),
+		r.Counter(),
+		fault.New(true))
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to retrieve M365 users"))
+	}
+
+	selectorSet := []selectors.Selector{}
+
+	for _, discSel := range sel.SplitByResourceOwner(ins.IDs()) {
+		selectorSet = append(selectorSet, discSel.Selector)
+	}
+
+	return genericCreateCommand(
+		ctx,
+		r,
+		"Chats",
+		selectorSet,
+		ins)
+}
+
+func validateChatsBackupCreateFlags(users []string) error {
+	if len(users) == 0 {
+		return clues.New("requires one or more --user ids or the wildcard --user *")
+	}
+
+	return nil
+}
+
+func chatsBackupCreateSelectors(users []string) *selectors.TeamsChatsBackup {
+	sel := selectors.NewTeamsChatsBackup(users)
+	sel.Include(sel.AllData())
+
+	return sel
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup list
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup list chats [<flag>...]`
+func chatsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   chatsServiceCommand,
+		Short: "List the history of M365 Teams chats backups",
+		RunE:  listChatsCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// lists the history of backup operations
+func listChatsCmd(cmd *cobra.Command, args []string) error {
+	return genericListCommand(cmd, flags.BackupIDFV, path.TeamsChatsService, args)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup details
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup details chats [<flag>...]`
+func chatsDetailsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   chatsServiceCommand,
+		Short: "Shows the details of a M365 Teams chats backup",
+		RunE:  detailsChatsCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// processes a teams chats backup.
+func detailsChatsCmd(cmd *cobra.Command, args []string) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	return runDetailsChatsCmd(cmd)
+}
+
+func runDetailsChatsCmd(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	opts := utils.MakeChatsOpts(cmd)
+
+	sel := utils.IncludeChatsRestoreDataSelectors(ctx, opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterChatsRestoreInfoSelectors(sel, opts)
+
+	ds, err := genericDetailsCommand(cmd, flags.BackupIDFV, sel.Selector)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if len(ds.Entries) > 0 {
+		ds.PrintEntries(ctx)
+	} else {
+		Info(ctx, selectors.ErrorNoMatchingItems)
+	}
+
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup delete
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup delete chats [<flag>...]`
+func chatsDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   chatsServiceCommand,
+		Short: "Delete backed-up M365 Teams chats data",
+		RunE:  deleteChatsCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// deletes a teams chats backup.
+func deleteChatsCmd(cmd *cobra.Command, args []string) error {
+	backupIDValue := []string{}
+
+	if len(flags.BackupIDsFV) > 0 {
+		backupIDValue = flags.BackupIDsFV
+	} else if len(flags.BackupIDFV) > 0 {
+		backupIDValue = append(backupIDValue, flags.BackupIDFV)
+	} else {
+		return clues.New("either --backup or --backups flag is required")
+	}
+
+	return genericDeleteCommand(cmd, path.TeamsChatsService, "Chats", backupIDValue, args)
+}
@@ -0,0 +1,86 @@
+package backup
+
+import (
+	"errors"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const diffCommand = "diff"
+
+const diffCommandExamples = `# Show what changed in a mailbox between two of its backups
+corso backup diff 1234abcd-12ab-cd34-56de-1234abcd 4567cdef-45cd-ef67-89ab-4567cdef
+
+# Produce the differences as JSON
+corso backup diff 1234abcd-12ab-cd34-56de-1234abcd 4567cdef-45cd-ef67-89ab-4567cdef --json`
+
+// addDiffCommands attaches the `corso backup diff` command to the parent.
+func addDiffCommands(parent *cobra.Command) {
+	dc := diffCmd()
+	parent.AddCommand(dc)
+
+	flags.AddAllProviderFlags(dc)
+	flags.AddAllStorageFlags(dc)
+}
+
+// The backup diff subcommand.
+// `corso backup diff <backup> <other backup> [<flag>...]`
+func diffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   diffCommand + " <backup> <other backup>",
+		Short: "Show the differences between two backups",
+		Long: `List the items that were added, removed, modified, or moved between two ` +
+			`backups of the same protected resource, along with the counts per category. ` +
+			`The backups can be given in either order; changes are always reported from ` +
+			`the older backup to the newer one.`,
+		RunE:    handleDiffCmd,
+		Args:    cobra.ExactArgs(2),
+		Example: diffCommandExamples,
+	}
+}
+
+// Handler for calls to `corso backup diff`.
+func handleDiffCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if args[0] == args[1] {
+		return Only(ctx, clues.New("Two different backups are needed to produce a diff"))
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	// Need to give it a valid service so it won't error out on us even though
+	// we don't need the graph client.
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	report, errs := r.DiffBackups(ctx, args[0], args[1])
+	if errs.Failure() != nil {
+		if errors.Is(errs.Failure(), data.ErrNotFound) {
+			return Only(ctx, clues.New("no backup exists with one of the ids "+args[0]+", "+args[1]))
+		}
+
+		return Only(ctx, clues.Wrap(errs.Failure(), "Failed to compare the backups"))
+	}
+
+	if !DisplayJSONFormat() {
+		Infof(ctx, "Changes from backup %s to backup %s", report.FromBackupID, report.ToBackupID)
+	}
+
+	report.Print(ctx)
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type DiffUnitSuite struct {
+	tester.Suite
+}
+
+func TestDiffUnitSuite(t *testing.T) {
+	suite.Run(t, &DiffUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DiffUnitSuite) TestAddDiffCommands() {
+	t := suite.T()
+
+	parent := &cobra.Command{Use: "backup"}
+	addDiffCommands(parent)
+
+	c, _, err := parent.Find([]string{diffCommand})
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, diffCommand, c.Name())
+}
+
+func (suite *DiffUnitSuite) TestDiffCmd_args() {
+	t := suite.T()
+
+	parent := &cobra.Command{Use: "backup"}
+
+	cliTD.SetUpCmdHasFlags(
+		t,
+		parent,
+		func(cmd *cobra.Command) *cobra.Command {
+			c := diffCmd()
+			cmd.AddCommand(c)
+
+			return c
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			diffCommand,
+			[]string{
+				"backup-a", "backup-b",
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+}
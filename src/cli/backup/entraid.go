@@ -0,0 +1,264 @@
+package backup
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// ------------------------------------------------------------------------------------------------
+// setup and globals
+// ------------------------------------------------------------------------------------------------
+
+const (
+	entraIDServiceCommand                 = "entraid"
+	entraIDServiceCommandDeleteUseSuffix  = "--backups <backupId>"
+	entraIDServiceCommandDetailsUseSuffix = "--backup <backupId>"
+)
+
+const (
+	entraIDServiceCommandCreateExamples = `# Backup the users, groups, memberships, and applications of the tenant
+corso backup create entraid`
+
+	entraIDServiceCommandDeleteExamples = `# Delete entra id backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
+and 1234abcd-12ab-cd34-56de-1234abce
+corso backup delete entraid --backups 1234abcd-12ab-cd34-56de-1234abcd,1234abcd-12ab-cd34-56de-1234abce`
+
+	entraIDServiceCommandDetailsExamples = `# Explore the directory objects in the latest backup (1234abcd...)
+corso backup details entraid --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Explore the groups that Adele was a member of
+corso backup details entraid --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --object-type groups --member 8f3b2c1d-1a2b-3c4d-5e6f-7a8b9c0d1e2f
+
+# Show what changed in the directory between an older backup (4567cdef...) and the latest one
+corso backup details entraid --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --compare-backup 4567cdef-45cd-ef67-89ab-4567cdef`
+)
+
+// called by backup.go to map subcommands to provider-specific handling.
+func addEntraIDCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case createCommand:
+		c, _ = utils.AddCommand(cmd, entraIDCreateCmd(), utils.MarkPreviewCommand())
+
+		c.Example = entraIDServiceCommandCreateExamples
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		flags.AddFetchParallelismFlag(c)
+		flags.AddGenericBackupFlags(c)
+
+	case listCommand:
+		c, _ = utils.AddCommand(cmd, entraIDListCmd(), utils.MarkPreviewCommand())
+
+		flags.AddBackupIDFlag(c, false)
+		flags.AddAllBackupListFlags(c)
+
+	case detailsCommand:
+		c, _ = utils.AddCommand(cmd, entraIDDetailsCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + entraIDServiceCommandDetailsUseSuffix
+		c.Example = entraIDServiceCommandDetailsExamples
+
+		flags.AddSkipReduceFlag(c)
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		// More generic and more frequently used flags take precedence.
+		flags.AddBackupIDFlag(c, true)
+		flags.AddCompareBackupFlag(c)
+		flags.AddEntraIDDetailsAndRestoreFlags(c)
+
+	case deleteCommand:
+		c, _ = utils.AddCommand(cmd, entraIDDeleteCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + entraIDServiceCommandDeleteUseSuffix
+		c.Example = entraIDServiceCommandDeleteExamples
+
+		flags.AddMultipleBackupIDsFlag(c, false)
+		flags.AddBackupIDFlag(c, false)
+	}
+
+	return c
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup create
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup create entraid [<flag>...]`
+func entraIDCreateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   entraIDServiceCommand,
+		Short: "Backup the M365 tenant's Entra ID directory",
+		RunE:  createEntraIDCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// processes an entra id backup.  The directory belongs to the tenant, so
+// unlike other services there's no resource to choose.
+func createEntraIDCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
+		ctx,
+		cmd,
+		path.EntraIDService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	sel := entraIDBackupCreateSelectors(acct.ID())
+
+	selectorSet := []selectors.Selector{}
+
+	for _, discSel := range sel.SplitByResourceOwner([]string{acct.ID()}) {
+		selectorSet = append(selectorSet, discSel.Selector)
+	}
+
+	return genericCreateCommand(
+		ctx,
+		r,
+		"Entra ID",
+		selectorSet,
+		nil)
+}
+
+func entraIDBackupCreateSelectors(tenantID string) *selectors.EntraIDBackup {
+	sel := selectors.NewEntraIDBackup([]string{tenantID})
+	sel.Include(sel.AllData())
+
+	return sel
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup list
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup list entraid [<flag>...]`
+func entraIDListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   entraIDServiceCommand,
+		Short: "List the history of M365 Entra ID backups",
+		RunE:  listEntraIDCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// lists the history of backup operations
+func listEntraIDCmd(cmd *cobra.Command, args []string) error {
+	return genericListCommand(cmd, flags.BackupIDFV, path.EntraIDService, args)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup details
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup details entraid [<flag>...]`
+func entraIDDetailsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   entraIDServiceCommand,
+		Short: "Shows the details of a M365 Entra ID backup",
+		RunE:  detailsEntraIDCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// processes an entra id backup.
+func detailsEntraIDCmd(cmd *cobra.Command, args []string) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	return runDetailsEntraIDCmd(cmd)
+}
+
+func runDetailsEntraIDCmd(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	opts := utils.MakeEntraIDOpts(cmd)
+
+	if err := utils.ValidateEntraIDRestoreFlags(flags.BackupIDFV, opts); err != nil {
+		return Only(ctx, err)
+	}
+
+	sel := utils.IncludeEntraIDRestoreDataSelectors(ctx, opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterEntraIDRestoreInfoSelectors(sel, opts)
+
+	ds, err := genericDetailsCommand(cmd, flags.BackupIDFV, sel.Selector)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if len(opts.CompareBackup) > 0 {
+		prev, err := genericDetailsCommand(cmd, opts.CompareBackup, sel.Selector)
+		if err != nil {
+			return Only(ctx, clues.Wrap(err, "getting details of the compared backup"))
+		}
+
+		diffs := details.DiffEntraID(prev, ds)
+
+		if len(diffs) > 0 {
+			diffs.PrintEntries(ctx)
+		} else {
+			Info(ctx, "No changes between the backups")
+		}
+
+		return nil
+	}
+
+	if len(ds.Entries) > 0 {
+		ds.PrintEntries(ctx)
+	} else {
+		Info(ctx, selectors.ErrorNoMatchingItems)
+	}
+
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup delete
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup delete entraid [<flag>...]`
+func entraIDDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   entraIDServiceCommand,
+		Short: "Delete backed-up M365 Entra ID data",
+		RunE:  deleteEntraIDCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// deletes an entra id backup.
+func deleteEntraIDCmd(cmd *cobra.Command, args []string) error {
+	backupIDValue := []string{}
+
+	if len(flags.BackupIDsFV) > 0 {
+		backupIDValue = flags.BackupIDsFV
+	} else if len(flags.BackupIDFV) > 0 {
+		backupIDValue = append(backupIDValue, flags.BackupIDFV)
+	} else {
+		return clues.New("either --backup or --backups flag is required")
+	}
+
+	return genericDeleteCommand(cmd, path.EntraIDService, "Entra ID", backupIDValue, args)
+}
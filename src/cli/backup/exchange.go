@@ -20,6 +20,7 @@ const (
 	dataContacts = "contacts"
 	dataEmail    = "email"
 	dataEvents   = "events"
+	dataSettings = "settings"
 )
 
 const (
@@ -36,6 +37,13 @@ corso backup create exchange --mailbox alice@example.com
 # Backup only Exchange contacts for Alice and Bob
 corso backup create exchange --mailbox alice@example.com,bob@example.com --data contacts
 
+# Backup the mailbox settings and inbox rules for Alice, along with her email
+corso backup create exchange --mailbox alice@example.com --data email,settings
+
+# Backup Alice's email, including her online archive and Recoverable Items
+corso backup create exchange --mailbox alice@example.com --data email \
+    --include-archive --include-recoverable-items
+
 # Backup all Exchange data for all M365 users 
 corso backup create exchange --mailbox '*'`
 
@@ -56,7 +64,14 @@ corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Explore contacts named Andy
 corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --contact-name Andy`
+    --contact-name Andy
+
+# Explore the mail in Alice's online archive
+corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --email-folder '/Online Archive'
+
+# Explore the mailbox settings and inbox rules
+corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --settings`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -73,7 +88,8 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		// More generic (ex: --user) and more frequently used flags take precedence.
 		flags.AddMailBoxFlag(c)
-		flags.AddDataFlag(c, []string{dataEmail, dataContacts, dataEvents}, false)
+		flags.AddDataFlag(c, []string{dataEmail, dataContacts, dataEvents, dataSettings}, false)
+		flags.AddExchangeMailboxFlags(c)
 		flags.AddFetchParallelismFlag(c)
 		flags.AddDisableDeltaFlag(c)
 		flags.AddEnableImmutableIDFlag(c)
@@ -196,6 +212,8 @@ func exchangeBackupCreateSelectors(userIDs, cats []string) *selectors.ExchangeBa
 			sel.Include(sel.MailFolders(selectors.Any()))
 		case dataEvents:
 			sel.Include(sel.EventCalendars(selectors.Any()))
+		case dataSettings:
+			sel.Include(sel.Settings(selectors.Any()))
 		}
 	}
 
@@ -208,9 +226,10 @@ func validateExchangeBackupCreateFlags(userIDs, cats []string) error {
 	}
 
 	for _, d := range cats {
-		if d != dataContacts && d != dataEmail && d != dataEvents {
+		if d != dataContacts && d != dataEmail && d != dataEvents && d != dataSettings {
 			return clues.New(
-				d + " is an unrecognized data type; must be one of " + dataContacts + ", " + dataEmail + ", or " + dataEvents)
+				d + " is an unrecognized data type; must be one of " +
+					dataContacts + ", " + dataEmail + ", " + dataEvents + ", or " + dataSettings)
 		}
 	}
 
@@ -268,7 +287,7 @@ func runDetailsExchangeCmd(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 	opts := utils.MakeExchangeOpts(cmd)
 
-	sel := utils.IncludeExchangeRestoreDataSelectors(opts)
+	sel := utils.IncludeExchangeDetailsDataSelectors(opts)
 	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
 	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
 
@@ -109,6 +109,8 @@ func (suite *ExchangeUnitSuite) TestBackupCreateFlags() {
 				"--" + flags.DisableDeltaFN,
 				"--" + flags.EnableImmutableIDFN,
 				"--" + flags.DisableSlidingWindowLimiterFN,
+				"--" + flags.IncludeArchiveFN,
+				"--" + flags.IncludeRecoverableItemsFN,
 			},
 			flagsTD.PreparedGenericBackupFlags(),
 			flagsTD.PreparedProviderFlags(),
@@ -128,6 +130,8 @@ func (suite *ExchangeUnitSuite) TestBackupCreateFlags() {
 	assert.True(t, backupOpts.Incrementals.ForceItemDataRefresh)
 	assert.True(t, backupOpts.M365.DisableDeltaEndpoint)
 	assert.True(t, backupOpts.M365.ExchangeImmutableIDs)
+	assert.True(t, backupOpts.M365.ExchangeArchive)
+	assert.True(t, backupOpts.M365.ExchangeRecoverableItems)
 	assert.True(t, backupOpts.ServiceRateLimiter.DisableSlidingWindowLimiter)
 
 	assert.Equal(t, flagsTD.FetchParallelism, strconv.Itoa(co.Parallelism.ItemFetch))
@@ -137,6 +141,8 @@ func (suite *ExchangeUnitSuite) TestBackupCreateFlags() {
 	assert.True(t, co.ToggleFeatures.ForceItemDataDownload)
 	assert.True(t, co.ToggleFeatures.DisableDelta)
 	assert.True(t, co.ToggleFeatures.ExchangeImmutableIDs)
+	assert.True(t, co.ToggleFeatures.ExchangeArchive)
+	assert.True(t, co.ToggleFeatures.ExchangeRecoverableItems)
 	assert.True(t, co.ToggleFeatures.DisableSlidingWindowLimiter)
 
 	assert.ElementsMatch(t, flagsTD.MailboxInput, opts.Users)
@@ -252,6 +258,12 @@ func (suite *ExchangeUnitSuite) TestValidateBackupCreateFlags() {
 			user:   []string{"fnord"},
 			expect: assert.NoError,
 		},
+		{
+			name:   "settings",
+			user:   []string{"fnord"},
+			data:   []string{dataEmail, dataSettings},
+			expect: assert.NoError,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -366,6 +378,11 @@ func (suite *ExchangeUnitSuite) TestExchangeBackupCreateSelectors() {
 			user:             []string{"fnord", "smarf"},
 			data:             []string{dataEvents, dataContacts},
 			expectIncludeLen: 2,
+		}, {
+			name:             "single user, settings",
+			user:             []string{"fnord"},
+			data:             []string{dataSettings},
+			expectIncludeLen: 1,
 		},
 	}
 	for _, test := range table {
@@ -38,6 +38,9 @@ corso backup create groups --group Marketing
 # Backup only Teams conversations messages
 corso backup create groups --group Marketing --data messages
 
+# Backup only the group mailbox conversations
+corso backup create groups --group Marketing --data conversations
+
 # Backup all Groups and Teams data for all groups
 corso backup create groups --group '*'`
 
@@ -66,7 +69,10 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		flags.AddGroupFlag(c)
-		flags.AddDataFlag(c, []string{flags.DataLibraries, flags.DataMessages, flags.DataConversations}, false)
+		flags.AddDataFlag(
+			c,
+			[]string{flags.DataLibraries, flags.DataMessages, flags.DataConversations, flags.DataPlanner},
+			false)
 		flags.AddFetchParallelismFlag(c)
 		flags.AddDisableDeltaFlag(c)
 		flags.AddGenericBackupFlags(c)
@@ -283,15 +289,9 @@ func validateGroupsBackupCreateFlags(groups, cats []string) error {
 				flags.GroupFN + " *")
 	}
 
-	// TODO(keepers): release conversations support
-
 	msg := fmt.Sprintf(
-		" is an unrecognized data type; only %s and %s are supported",
-		flags.DataLibraries, flags.DataMessages)
-
-	// msg := fmt.Sprintf(
-	// 	" is an unrecognized data type; only %s, %s and %s are supported",
-	// 	flags.DataLibraries, flags.DataMessages, flags.DataConversations)
+		" is an unrecognized data type; only %s, %s, %s and %s are supported",
+		flags.DataLibraries, flags.DataMessages, flags.DataConversations, flags.DataPlanner)
 
 	allowedCats := utils.GroupsAllowedCategories()
 
@@ -111,12 +111,18 @@ func (suite *GroupsUnitSuite) TestValidateGroupsBackupCreateFlags() {
 			cats:   []string{flags.DataConversations},
 			expect: assert.NoError,
 		},
+		{
+			name:   "planner",
+			cats:   []string{flags.DataPlanner},
+			expect: assert.NoError,
+		},
 		{
 			name: "all allowed",
 			cats: []string{
 				flags.DataLibraries,
 				flags.DataMessages,
 				flags.DataConversations,
+				flags.DataPlanner,
 			},
 			expect: assert.NoError,
 		},
@@ -232,6 +238,7 @@ func (suite *GroupsUnitSuite) TestBackupDetailsFlags() {
 			},
 			flagsTD.PreparedChannelFlags(),
 			flagsTD.PreparedConversationFlags(),
+			flagsTD.PreparedPlannerFlags(),
 			flagsTD.PreparedProviderFlags(),
 			flagsTD.PreparedStorageFlags(),
 			flagsTD.PreparedLibraryFlags()))
@@ -244,6 +251,7 @@ func (suite *GroupsUnitSuite) TestBackupDetailsFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 	flagsTD.AssertChannelFlags(t, cmd)
 	flagsTD.AssertConversationFlags(t, cmd)
+	flagsTD.AssertPlannerFlags(t, cmd)
 	flagsTD.AssertLibraryFlags(t, cmd)
 }
 
@@ -88,6 +88,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddSkipReduceFlag(c)
 		flags.AddBackupIDFlag(c, true)
 		flags.AddOneDriveDetailsAndRestoreFlags(c)
+		flags.AddNotebookDetailsAndRestoreFlags(c)
 
 	case deleteCommand:
 		c, _ = utils.AddCommand(cmd, oneDriveDeleteCmd())
@@ -0,0 +1,289 @@
+package backup
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/backup/retention"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const (
+	pruneCommand           = "prune"
+	retentionCommand       = "retention"
+	retentionSetCommand    = "set"
+	retentionListCommand   = "list"
+	retentionDeleteCommand = "delete"
+)
+
+const (
+	pruneCommandExamples = `# Show which backups would be deleted by the current retention policies
+corso backup prune --dry-run
+
+# Delete all backups that are no longer retained
+corso backup prune`
+
+	retentionSetCommandExamples = `# Keep 7 daily, 4 weekly and 12 monthly backups of every protected resource
+corso backup retention set --keep-daily 7 --keep-weekly 4 --keep-monthly 12
+
+# Keep the last 3 exchange backups of Alice, and nothing older than 90 days
+corso backup retention set --service exchange --resource alice@example.com \
+    --keep-last 3 --max-age 2160h`
+
+	retentionDeleteCommandExamples = `# Remove the retention policy for Alice's exchange backups
+corso backup retention delete --service exchange --resource alice@example.com`
+)
+
+// addPruneCommands attaches the `corso backup prune` and
+// `corso backup retention` commands to the parent.
+func addPruneCommands(parent *cobra.Command) {
+	pc := pruneCmd()
+	parent.AddCommand(pc)
+
+	flags.AddDryRunFlag(pc)
+	flags.AddAllProviderFlags(pc)
+	flags.AddAllStorageFlags(pc)
+
+	rc := retentionCmd()
+	parent.AddCommand(rc)
+
+	for _, sc := range []*cobra.Command{
+		retentionSetCmd(),
+		retentionListCmd(),
+		retentionDeleteCmd(),
+	} {
+		rc.AddCommand(sc)
+
+		if sc.Use != retentionListCommand {
+			flags.AddRetentionPolicyScopeFlags(sc)
+		}
+
+		if sc.Use == retentionSetCommand {
+			flags.AddRetentionPolicyFlags(sc)
+		}
+
+		flags.AddAllProviderFlags(sc)
+		flags.AddAllStorageFlags(sc)
+	}
+}
+
+// The backup prune subcommand.
+// `corso backup prune [<flag>...]`
+func pruneCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   pruneCommand,
+		Short: "Delete backups that are no longer retained",
+		Long: `Apply the repository's retention policies to its backups and delete every ` +
+			`backup they no longer retain. The most recent complete backup of each ` +
+			`protected resource is never deleted.`,
+		RunE:    handlePruneCmd,
+		Args:    cobra.NoArgs,
+		Example: pruneCommandExamples,
+	}
+}
+
+// Handler for calls to `corso backup prune`.
+func handlePruneCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	// Need to give it a valid service so it won't error out on us even though
+	// we don't need the graph client.
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	report, err := r.PruneBackups(ctx, flags.DryRunFV)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to prune backups"))
+	}
+
+	report.Print(ctx)
+
+	if report.DryRun {
+		Infof(ctx, "Dry run: %d backups would be deleted", len(report.Pruned()))
+	} else {
+		Infof(ctx, "Deleted %d backups", len(report.Pruned()))
+	}
+
+	return nil
+}
+
+// The backup retention subcommand.
+// `corso backup retention [<subcommand>] [<flag>...]`
+func retentionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   retentionCommand,
+		Short: "Manage the backup retention policies",
+		Long: `Retention policies decide which backups are deleted by 'corso backup prune'. ` +
+			`A policy can apply to a service, a protected resource, both, or every backup; ` +
+			`the most specific policy matching a backup is the one used.`,
+		RunE: handleRetentionCmd,
+		Args: cobra.NoArgs,
+	}
+}
+
+// Handler for flat calls to `corso backup retention`.
+// Produces the same output as `corso backup retention --help`.
+func handleRetentionCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// `corso backup retention set [<flag>...]`
+func retentionSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     retentionSetCommand,
+		Short:   "Add or replace a retention policy",
+		RunE:    handleRetentionSetCmd,
+		Args:    cobra.NoArgs,
+		Example: retentionSetCommandExamples,
+	}
+}
+
+func handleRetentionSetCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	svc, err := retentionPolicyService()
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	p := retention.Policy{
+		Service:             svc,
+		ProtectedResourceID: flags.RetentionResourceFV,
+		KeepLast:            flags.KeepLastFV,
+		KeepDaily:           flags.KeepDailyFV,
+		KeepWeekly:          flags.KeepWeeklyFV,
+		KeepMonthly:         flags.KeepMonthlyFV,
+		MaxAge:              flags.MaxAgeFV,
+	}
+
+	if err := p.Validate(); err != nil {
+		return Only(ctx, err)
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	if err := r.SetRetentionPolicy(ctx, p); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to set the retention policy"))
+	}
+
+	p.Print(ctx)
+
+	return nil
+}
+
+// `corso backup retention list [<flag>...]`
+func retentionListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   retentionListCommand,
+		Short: "List the retention policies",
+		RunE:  handleRetentionListCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+func handleRetentionListCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	ps, err := r.RetentionPolicies(ctx)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to list the retention policies"))
+	}
+
+	retention.PrintAll(ctx, ps)
+
+	return nil
+}
+
+// `corso backup retention delete [<flag>...]`
+func retentionDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     retentionDeleteCommand,
+		Short:   "Delete a retention policy",
+		RunE:    handleRetentionDeleteCmd,
+		Args:    cobra.NoArgs,
+		Example: retentionDeleteCommandExamples,
+	}
+}
+
+func handleRetentionDeleteCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	svc, err := retentionPolicyService()
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	if err := r.DeleteRetentionPolicy(ctx, svc, flags.RetentionResourceFV); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to delete the retention policy"))
+	}
+
+	Info(ctx, "Deleted retention policy")
+
+	return nil
+}
+
+// retentionPolicyService parses the --service flag.  An empty flag
+// produces the UnknownService, which applies to all services.
+func retentionPolicyService() (path.ServiceType, error) {
+	return parseServiceFlag(flags.RetentionServiceFV)
+}
+
+// parseServiceFlag parses the value of a --service flag.  An empty value
+// produces the UnknownService.
+func parseServiceFlag(fv string) (path.ServiceType, error) {
+	if len(fv) == 0 {
+		return path.UnknownService, nil
+	}
+
+	svc := path.ToServiceType(fv)
+
+	switch svc {
+	case path.ExchangeService, path.OneDriveService, path.SharePointService, path.GroupsService,
+		path.TeamsChatsService, path.EntraIDService:
+		return svc, nil
+	default:
+		return path.UnknownService, clues.New("unrecognized service: " + fv)
+	}
+}
@@ -0,0 +1,134 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type PruneUnitSuite struct {
+	tester.Suite
+}
+
+func TestPruneUnitSuite(t *testing.T) {
+	suite.Run(t, &PruneUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PruneUnitSuite) TestAddPruneCommands() {
+	t := suite.T()
+
+	parent := &cobra.Command{Use: "backup"}
+	addPruneCommands(parent)
+
+	pc, _, err := parent.Find([]string{pruneCommand})
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, pruneCommand, pc.Use)
+	assert.NotNil(t, pc.Flags().Lookup(flags.DryRunFN))
+
+	sc, _, err := parent.Find([]string{retentionCommand, retentionSetCommand})
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, retentionSetCommand, sc.Use)
+
+	for _, fn := range []string{
+		flags.RetentionServiceFN,
+		flags.RetentionResourceFN,
+		flags.KeepLastFN,
+		flags.KeepDailyFN,
+		flags.KeepWeeklyFN,
+		flags.KeepMonthlyFN,
+		flags.MaxAgeFN,
+	} {
+		assert.NotNil(t, sc.Flags().Lookup(fn), fn)
+	}
+
+	dc, _, err := parent.Find([]string{retentionCommand, retentionDeleteCommand})
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.NotNil(t, dc.Flags().Lookup(flags.RetentionServiceFN))
+	assert.Nil(t, dc.Flags().Lookup(flags.KeepLastFN))
+}
+
+func (suite *PruneUnitSuite) TestRetentionSetCmd_flags() {
+	t := suite.T()
+
+	parent := &cobra.Command{Use: retentionCommand}
+
+	cliTD.SetUpCmdHasFlags(
+		t,
+		parent,
+		func(cmd *cobra.Command) *cobra.Command {
+			c := retentionSetCmd()
+			cmd.AddCommand(c)
+
+			flags.AddRetentionPolicyScopeFlags(c)
+			flags.AddRetentionPolicyFlags(c)
+
+			return c
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			retentionSetCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.RetentionServiceFN, "exchange",
+				"--" + flags.RetentionResourceFN, "alice",
+				"--" + flags.KeepLastFN, "1",
+				"--" + flags.KeepDailyFN, "7",
+				"--" + flags.KeepWeeklyFN, "4",
+				"--" + flags.KeepMonthlyFN, "12",
+				"--" + flags.MaxAgeFN, "8760h",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.Equal(t, "exchange", flags.RetentionServiceFV)
+	assert.Equal(t, "alice", flags.RetentionResourceFV)
+	assert.Equal(t, 1, flags.KeepLastFV)
+	assert.Equal(t, 7, flags.KeepDailyFV)
+	assert.Equal(t, 4, flags.KeepWeeklyFV)
+	assert.Equal(t, 12, flags.KeepMonthlyFV)
+	assert.Equal(t, 365*24*time.Hour, flags.MaxAgeFV)
+}
+
+func (suite *PruneUnitSuite) TestRetentionPolicyService() {
+	table := []struct {
+		input     string
+		expect    path.ServiceType
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{"", path.UnknownService, assert.NoError},
+		{"exchange", path.ExchangeService, assert.NoError},
+		{"OneDrive", path.OneDriveService, assert.NoError},
+		{"sharepoint", path.SharePointService, assert.NoError},
+		{"groups", path.GroupsService, assert.NoError},
+		{"exchangeMetadata", path.UnknownService, assert.Error},
+		{"mango", path.UnknownService, assert.Error},
+	}
+	for _, test := range table {
+		suite.Run(test.input, func() {
+			t := suite.T()
+
+			flags.RetentionServiceFV = test.input
+
+			defer func() {
+				flags.RetentionServiceFV = ""
+			}()
+
+			result, err := retentionPolicyService()
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/backup/search"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const searchCommand = "search"
+
+const searchCommandExamples = `# Find every item mentioning Project Falcon, in all indexed backups
+corso backup search "project falcon"
+
+# Find items with terms starting with "budg" in Alice's exchange backups
+corso backup search budg* --service exchange --resource alice@example.com
+
+# Restore a found item using the backup ID and item ID from the results
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --email 9a8b7c6d`
+
+// addSearchCommands attaches the `corso backup search` command to the parent.
+func addSearchCommands(parent *cobra.Command) {
+	sc := searchCmd()
+	parent.AddCommand(sc)
+
+	flags.AddSearchScopeFlags(sc)
+	flags.AddAllProviderFlags(sc)
+	flags.AddAllStorageFlags(sc)
+}
+
+// The backup search subcommand.
+// `corso backup search <query> [<flag>...]`
+func searchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   searchCommand + " <query>",
+		Short: "Find backed up items by their content",
+		Long: `Search the content of backed up items, along with their names, subjects ` +
+			`and senders. Items must contain every term in the query; a term ending ` +
+			`in '*' matches by prefix. Only text content is indexed, so binary files ` +
+			`such as Office documents and PDFs are only found by their names. Only ` +
+			`backups created with --search-index are searched.`,
+		RunE:    handleSearchCmd,
+		Args:    cobra.MinimumNArgs(1),
+		Example: searchCommandExamples,
+	}
+}
+
+// Handler for calls to `corso backup search`.
+func handleSearchCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	svc, err := parseServiceFlag(flags.SearchServiceFV)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	q := search.Query{
+		Text:               strings.Join(args, " "),
+		Service:            svc,
+		ProtectedResources: flags.SearchResourcesFV,
+		BackupIDs:          flags.BackupIDsFV,
+	}
+
+	if err := q.Validate(); err != nil {
+		return Only(ctx, err)
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	// Need to give it a valid service so it won't error out on us even though
+	// we don't need the graph client.
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	rs, errs := r.SearchBackups(ctx, q)
+	if errs.Failure() != nil {
+		return Only(ctx, clues.Wrap(errs.Failure(), "Failed to search backups"))
+	}
+
+	search.PrintAll(ctx, rs)
+
+	for _, err := range errs.Recovered() {
+		Err(ctx, err)
+	}
+
+	if len(rs) > 0 {
+		Info(ctx, "Use the backup ID with --backup, and the item ID, to restore or export an item")
+	}
+
+	return nil
+}
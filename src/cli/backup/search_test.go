@@ -0,0 +1,79 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type SearchUnitSuite struct {
+	tester.Suite
+}
+
+func TestSearchUnitSuite(t *testing.T) {
+	suite.Run(t, &SearchUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SearchUnitSuite) TestAddSearchCommands() {
+	t := suite.T()
+
+	parent := &cobra.Command{Use: "backup"}
+	addSearchCommands(parent)
+
+	c, _, err := parent.Find([]string{searchCommand})
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, searchCommand, c.Name())
+
+	for _, fn := range []string{
+		flags.SearchServiceFN,
+		flags.SearchResourcesFN,
+		flags.BackupIDsFN,
+	} {
+		assert.NotNil(t, c.Flags().Lookup(fn), fn)
+	}
+}
+
+func (suite *SearchUnitSuite) TestSearchCmd_flags() {
+	t := suite.T()
+
+	parent := &cobra.Command{Use: "backup"}
+
+	cliTD.SetUpCmdHasFlags(
+		t,
+		parent,
+		func(cmd *cobra.Command) *cobra.Command {
+			c := searchCmd()
+			cmd.AddCommand(c)
+
+			flags.AddSearchScopeFlags(c)
+
+			return c
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			searchCommand,
+			[]string{
+				"project", "falcon",
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.SearchServiceFN, "exchange",
+				"--" + flags.SearchResourcesFN, "alice,bob",
+				"--" + flags.BackupIDsFN, "b1,b2",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.Equal(t, "exchange", flags.SearchServiceFV)
+	assert.ElementsMatch(t, []string{"alice", "bob"}, flags.SearchResourcesFV)
+	assert.ElementsMatch(t, []string{"b1", "b2"}, flags.BackupIDsFV)
+}
@@ -73,7 +73,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddSiteFlag(c, true)
 		flags.AddSiteIDFlag(c, true)
-		flags.AddDataFlag(c, []string{flags.DataLibraries}, true)
+		flags.AddDataFlag(c, []string{flags.DataLibraries, flags.DataNotebooks}, false)
 		flags.AddGenericBackupFlags(c)
 
 	case listCommand:
@@ -91,6 +91,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddSkipReduceFlag(c)
 		flags.AddBackupIDFlag(c, true)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
+		flags.AddNotebookDetailsAndRestoreFlags(c)
 
 	case deleteCommand:
 		c, _ = utils.AddCommand(cmd, sharePointDeleteCmd())
@@ -191,7 +192,8 @@ func validateSharePointBackupCreateFlags(sites, weburls, cats []string) error {
 	for _, d := range cats {
 		if _, ok := allowedCats[d]; !ok {
 			return clues.New(
-				d + " is an unrecognized data type; only  " + flags.DataLibraries + " supported")
+				d + " is an unrecognized data type; only " + flags.DataLibraries +
+					" and " + flags.DataNotebooks + " are supported")
 		}
 	}
 
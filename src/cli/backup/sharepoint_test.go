@@ -247,6 +247,12 @@ func (suite *SharePointUnitSuite) TestValidateSharePointBackupCreateFlags() {
 			cats:   []string{flags.DataLibraries},
 			expect: assert.NoError,
 		},
+		{
+			name:   "site with notebooks category",
+			site:   []string{"smarf"},
+			cats:   []string{flags.DataNotebooks},
+			expect: assert.NoError,
+		},
 		{
 			name:   "site with invalid category",
 			site:   []string{"smarf"},
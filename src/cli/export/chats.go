@@ -0,0 +1,100 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+// called by export.go to map subcommands to provider-specific handling.
+func addChatsCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case exportCommand:
+		c, _ = utils.AddCommand(cmd, chatsExportCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + chatsServiceCommandUseSuffix
+
+		flags.AddBackupIDFlag(c, true)
+		flags.AddChatsDetailsAndRestoreFlags(c)
+		flags.AddExportConfigFlags(c)
+		flags.AddFailFastFlag(c)
+	}
+
+	return c
+}
+
+const (
+	chatsServiceCommand          = "chats"
+	chatsServiceCommandUseSuffix = "<destination> --backup <backupId>"
+
+	//nolint:lll
+	chatsServiceCommandExportExamples = `# Export all chats in Alice's last backup (1234abcd...) to /my-exports as html transcripts
+corso export chats my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Export the messages of the chat "Project Falcon" to the current directory as json
+corso export chats . --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --chat "Project Falcon" --format json
+
+# Export all chats with Bob created before 2024 to /my-exports
+corso export chats my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --chat-member bob@example.com --message-created-before 2024-01-01T00:00:00`
+)
+
+// `corso export chats [<flag>...] <destination>`
+func chatsExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   chatsServiceCommand,
+		Short: "Export M365 Teams chats",
+		RunE:  exportChatsCmd,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing export destination")
+			}
+
+			return nil
+		},
+		Example: chatsServiceCommandExportExamples,
+	}
+}
+
+// processes a teams chats export.
+func exportChatsCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.MakeChatsOpts(cmd)
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := utils.ValidateChatsRestoreFlags(flags.BackupIDFV, opts); err != nil {
+		return err
+	}
+
+	sel := utils.IncludeChatsRestoreDataSelectors(ctx, opts)
+	utils.FilterChatsRestoreInfoSelectors(sel, opts)
+
+	acceptedChatsFormatTypes := []string{
+		string(control.DefaultFormat),
+		string(control.JSONFormat),
+	}
+
+	return runExport(
+		ctx,
+		cmd,
+		args,
+		opts.ExportCfg,
+		sel.Selector,
+		flags.BackupIDFV,
+		"Chats",
+		acceptedChatsFormatTypes)
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+// called by export.go to map subcommands to provider-specific handling.
+func addEntraIDCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case exportCommand:
+		c, _ = utils.AddCommand(cmd, entraIDExportCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + entraIDServiceCommandUseSuffix
+
+		flags.AddBackupIDFlag(c, true)
+		flags.AddEntraIDDetailsAndRestoreFlags(c)
+		flags.AddExportConfigFlags(c)
+		flags.AddFailFastFlag(c)
+	}
+
+	return c
+}
+
+const (
+	entraIDServiceCommand          = "entraid"
+	entraIDServiceCommandUseSuffix = "<destination> --backup <backupId>"
+
+	//nolint:lll
+	entraIDServiceCommandExportExamples = `# Export every directory object in the last backup (1234abcd...) to /my-exports
+corso export entraid my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Export the applications and service principals to the current directory
+corso export entraid . --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --object-type applications,servicePrincipals`
+)
+
+// `corso export entraid [<flag>...] <destination>`
+func entraIDExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   entraIDServiceCommand,
+		Short: "Export M365 Entra ID directory objects",
+		RunE:  exportEntraIDCmd,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing export destination")
+			}
+
+			return nil
+		},
+		Example: entraIDServiceCommandExportExamples,
+	}
+}
+
+// processes an entra id export.
+func exportEntraIDCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.MakeEntraIDOpts(cmd)
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := utils.ValidateEntraIDRestoreFlags(flags.BackupIDFV, opts); err != nil {
+		return err
+	}
+
+	sel := utils.IncludeEntraIDRestoreDataSelectors(ctx, opts)
+	utils.FilterEntraIDRestoreInfoSelectors(sel, opts)
+
+	// directory objects are always exported as json.
+	acceptedEntraIDFormatTypes := []string{
+		string(control.DefaultFormat),
+		string(control.JSONFormat),
+	}
+
+	return runExport(
+		ctx,
+		cmd,
+		args,
+		opts.ExportCfg,
+		sel.Selector,
+		flags.BackupIDFV,
+		"Entra ID",
+		acceptedEntraIDFormatTypes)
+}
@@ -1,11 +1,14 @@
 package export
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 // called by export.go to map subcommands to provider-specific handling.
@@ -22,6 +25,12 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddExchangeDetailsAndRestoreFlags(c, true)
 		flags.AddExportConfigFlags(c)
 		flags.AddFailFastFlag(c)
+
+		// exchange supports mbox and pst exports in addition to the
+		// default eml/vcf/ics files, so the format flag gets surfaced.
+		fl := c.Flags().Lookup(flags.FormatFN)
+		fl.Hidden = false
+		fl.Usage = "Export file format: one of " + strings.Join(acceptedExchangeFormatTypes, ", ")
 	}
 
 	return c
@@ -40,7 +49,13 @@ corso export exchange my-folder --backup 1234abcd-12ab-cd34-56de-1234abcd --emai
 
 # Export emails with subject containing "Hello world" in the "Inbox" to my-folder
 corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --email-subject "Hello world" --email-folder Inbox my-folder`
+    --email-subject "Hello world" --email-folder Inbox my-folder
+
+# Export all of Alice's emails as one mbox file per folder to my-folder
+corso export exchange my-folder --backup 1234abcd-12ab-cd34-56de-1234abcd --email '*' --format mbox
+
+# Export Alice's mailbox, contacts and calendar as a single pst file to my-folder
+corso export exchange my-folder --backup 1234abcd-12ab-cd34-56de-1234abcd --format pst`
 
 // TODO(meain): Uncomment once support for these are added
 // 		`# Export an entire calendar to my-folder
@@ -51,6 +66,12 @@ corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
 // corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101 my-folder`
 )
 
+var acceptedExchangeFormatTypes = []string{
+	string(control.DefaultFormat),
+	string(control.MBOXFormat),
+	string(control.PSTFormat),
+}
+
 // `corso export exchange [<flag>...] <destination>`
 func exchangeExportCmd() *cobra.Command {
 	return &cobra.Command{
@@ -97,5 +118,5 @@ func exportExchangeCmd(cmd *cobra.Command, args []string) error {
 		sel.Selector,
 		flags.BackupIDFV,
 		"Exchange",
-		defaultAcceptedFormatTypes)
+		acceptedExchangeFormatTypes)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/cli/flags"
@@ -12,6 +13,7 @@ import (
 	cliTD "github.com/alcionai/corso/src/cli/testdata"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 type ExchangeUnitSuite struct {
@@ -76,3 +78,16 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 		})
 	}
 }
+
+func (suite *ExchangeUnitSuite) TestAddExchangeCommands_formatFlag() {
+	t := suite.T()
+
+	parent := &cobra.Command{Use: exportCommand}
+	c := addExchangeCommands(parent)
+
+	fl := c.Flags().Lookup(flags.FormatFN)
+	require.NotNil(t, fl)
+	assert.False(t, fl.Hidden, "format flag is visible")
+	assert.Contains(t, fl.Usage, string(control.MBOXFormat))
+	assert.Contains(t, fl.Usage, string(control.PSTFormat))
+}
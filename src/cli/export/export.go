@@ -25,6 +25,8 @@ var exportCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addSharePointCommands,
 	addGroupsCommands,
 	addExchangeCommands,
+	addChatsCommands,
+	addEntraIDCommands,
 }
 
 var defaultAcceptedFormatTypes = []string{string(control.DefaultFormat)}
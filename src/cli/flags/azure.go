@@ -0,0 +1,121 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// Azure storage flags
+const (
+	ContainerFN                = "container"
+	StorageAccountFN           = "storage-account"
+	AzureStorageKeyFN          = "azure-storage-key"
+	AzureStorageSASTokenFN     = "azure-storage-sas-token"
+	AzureStorageTenantIDFN     = "azure-storage-tenant-id"
+	AzureStorageClientIDFN     = "azure-storage-client-id"
+	AzureStorageClientSecretFN = "azure-storage-client-secret"
+)
+
+// Azure storage flag values
+var (
+	ContainerFV                string
+	StorageAccountFV           string
+	AzureStorageKeyFV          string
+	AzureStorageSASTokenFV     string
+	AzureStorageTenantIDFV     string
+	AzureStorageClientIDFV     string
+	AzureStorageClientSecretFV string
+)
+
+// AddAzureStorageFlags adds the azure blob container and account flags.
+// The prefix and endpoint flags are shared with s3.
+func AddAzureStorageFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	// Flags addition ordering should follow the order we want them to appear in help and docs:
+	// More generic and more frequently used flags take precedence.
+	fs.StringVar(&ContainerFV, ContainerFN, "", "Name of the Azure blob container for the repo. (required)")
+	fs.StringVar(&StorageAccountFV, StorageAccountFN, "", "Name of the Azure storage account. (required)")
+	fs.StringVar(&PrefixFV, PrefixFN, "", "Repo prefix within the container.")
+	fs.StringVar(
+		&EndpointFV,
+		EndpointFN, "",
+		"Blob service domain; defaults to blob.core.windows.net.")
+
+	// In general, we don't want to expose this flag to users and have them mistake it
+	// for a broad-scale idempotency solution.  We can un-hide it later the need arises.
+	fs.BoolVar(&SucceedIfExistsFV, SucceedIfExistsFN, false, "Exit with success if the repo has already been initialized.")
+	cobra.CheckErr(fs.MarkHidden("succeed-if-exists"))
+}
+
+// AddAzureStorageCredsFlags adds the azure storage account credential flags.
+// If neither a key nor a SAS token is provided, the storage account is
+// accessed as the service principal in the tenant id, client id and secret.
+func AddAzureStorageCredsFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(&AzureStorageKeyFV, AzureStorageKeyFN, "", "Azure storage account access key.")
+	fs.StringVar(&AzureStorageSASTokenFV, AzureStorageSASTokenFN, "", "Azure storage shared access signature token.")
+	fs.StringVar(
+		&AzureStorageTenantIDFV,
+		AzureStorageTenantIDFN, "",
+		"Tenant ID of a service principal with access to the container.")
+	fs.StringVar(
+		&AzureStorageClientIDFV,
+		AzureStorageClientIDFN, "",
+		"Client ID of a service principal with access to the container.")
+	fs.StringVar(
+		&AzureStorageClientSecretFV,
+		AzureStorageClientSecretFN, "",
+		"Client secret of a service principal with access to the container.")
+}
+
+func AzureFlagOverrides(cmd *cobra.Command) map[string]string {
+	fs := GetPopulatedFlags(cmd)
+	return PopulateAzureFlags(fs)
+}
+
+func PopulateAzureFlags(flagset PopulatedFlags) map[string]string {
+	azOverrides := map[string]string{
+		storage.StorageProviderTypeKey: storage.ProviderAzure.String(),
+	}
+
+	if _, ok := flagset[AzureStorageKeyFN]; ok {
+		azOverrides[credentials.AzureStorageKey] = AzureStorageKeyFV
+	}
+
+	if _, ok := flagset[AzureStorageSASTokenFN]; ok {
+		azOverrides[credentials.AzureStorageSASToken] = AzureStorageSASTokenFV
+	}
+
+	if _, ok := flagset[ContainerFN]; ok {
+		azOverrides[storage.Container] = ContainerFV
+	}
+
+	if _, ok := flagset[StorageAccountFN]; ok {
+		azOverrides[storage.StorageAccount] = StorageAccountFV
+	}
+
+	if _, ok := flagset[PrefixFN]; ok {
+		azOverrides[storage.Prefix] = PrefixFV
+	}
+
+	if _, ok := flagset[EndpointFN]; ok {
+		azOverrides[storage.Endpoint] = EndpointFV
+	}
+
+	if _, ok := flagset[AzureStorageTenantIDFN]; ok {
+		azOverrides[storage.AzureStorageTenant] = AzureStorageTenantIDFV
+	}
+
+	if _, ok := flagset[AzureStorageClientIDFN]; ok {
+		azOverrides[storage.AzureStorageClient] = AzureStorageClientIDFV
+	}
+
+	if _, ok := flagset[AzureStorageClientSecretFN]; ok {
+		azOverrides[credentials.AzureStorageClientSecret] = AzureStorageClientSecretFV
+	}
+
+	return azOverrides
+}
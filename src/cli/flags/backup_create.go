@@ -8,4 +8,6 @@ func AddGenericBackupFlags(cmd *cobra.Command) {
 	AddFailFastFlag(cmd)
 	AddDisableIncrementalsFlag(cmd)
 	AddForceItemDataDownloadFlag(cmd)
+	AddSearchIndexFlag(cmd)
+	AddAnomalyFlags(cmd)
 }
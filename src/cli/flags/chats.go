@@ -0,0 +1,54 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	ChatFN           = "chat"
+	ChatMemberFN     = "chat-member"
+	MessageCreatorFN = "message-creator"
+)
+
+var (
+	ChatFV           []string
+	ChatMemberFV     string
+	MessageCreatorFV string
+)
+
+// AddChatsDetailsAndRestoreFlags adds the flags that select chats and
+// chat messages.  The message and message-created flags are shared with
+// the groups commands.
+func AddChatsDetailsAndRestoreFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&ChatFV,
+		ChatFN, nil,
+		"Select data within a chat, by chat name or id.")
+
+	fs.StringSliceVar(
+		&MessageFV,
+		MessageFN, nil,
+		"Select messages by reference.")
+
+	fs.StringVar(
+		&ChatMemberFV,
+		ChatMemberFN, "",
+		"Select messages in chats that include this member.")
+
+	fs.StringVar(
+		&MessageCreatorFV,
+		MessageCreatorFN, "",
+		"Select messages sent by this user.")
+
+	fs.StringVar(
+		&MessageCreatedAfterFV,
+		MessageCreatedAfterFN, "",
+		"Select messages created after this datetime.")
+
+	fs.StringVar(
+		&MessageCreatedBeforeFV,
+		MessageCreatedBeforeFN, "",
+		"Select messages created before this datetime.")
+}
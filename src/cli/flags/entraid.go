@@ -0,0 +1,76 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	CompareBackupFN         = "compare-backup"
+	EntraMemberFN           = "member"
+	EntraDisplayNameFN      = "display-name"
+	EntraObjectTypeFN       = "object-type"
+	EntraApplicationFN      = "application"
+	EntraGroupFN            = "group"
+	EntraServicePrincipalFN = "service-principal"
+	EntraUserFN             = "user"
+)
+
+var (
+	CompareBackupFV         string
+	EntraMemberFV           string
+	EntraDisplayNameFV      string
+	EntraObjectTypeFV       []string
+	EntraApplicationFV      []string
+	EntraGroupFV            []string
+	EntraServicePrincipalFV []string
+	EntraUserFV             []string
+)
+
+// AddEntraIDDetailsAndRestoreFlags adds the flags that select directory
+// objects.
+func AddEntraIDDetailsAndRestoreFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&EntraObjectTypeFV,
+		EntraObjectTypeFN, nil,
+		"Select directory objects by kind: users, groups, applications, or servicePrincipals.")
+
+	fs.StringSliceVar(
+		&EntraUserFV,
+		EntraUserFN, nil,
+		"Select directory users by id, display name, or principal name.")
+
+	fs.StringSliceVar(
+		&EntraGroupFV,
+		EntraGroupFN, nil,
+		"Select groups by id, display name, or mail nickname.")
+
+	fs.StringSliceVar(
+		&EntraApplicationFV,
+		EntraApplicationFN, nil,
+		"Select applications by id, display name, or app id.")
+
+	fs.StringSliceVar(
+		&EntraServicePrincipalFV,
+		EntraServicePrincipalFN, nil,
+		"Select service principals by id, display name, or app id.")
+
+	fs.StringVar(
+		&EntraDisplayNameFV,
+		EntraDisplayNameFN, "",
+		"Select directory objects whose display name contains this value.")
+
+	fs.StringVar(
+		&EntraMemberFV,
+		EntraMemberFN, "",
+		"Select groups that include this member id.")
+}
+
+// AddCompareBackupFlag adds the --compare-backup flag.
+func AddCompareBackupFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&CompareBackupFV,
+		CompareBackupFN, "",
+		"Show the changes between this older backup and the one given by --backup.")
+}
@@ -2,6 +2,8 @@ package flags
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
 )
 
 const (
@@ -23,6 +25,11 @@ const (
 	EventStartsAfterFN  = "event-starts-after"
 	EventStartsBeforeFN = "event-starts-before"
 	EventSubjectFN      = "event-subject"
+
+	SettingsFN = "settings"
+
+	IncludeArchiveFN          = "include-archive"
+	IncludeRecoverableItemsFN = "include-recoverable-items"
 )
 
 // flag values (ie: FV)
@@ -45,8 +52,30 @@ var (
 	EventStartsAfterFV  string
 	EventStartsBeforeFV string
 	EventSubjectFV      string
+
+	SettingsFV bool
+
+	IncludeArchiveFV          bool
+	IncludeRecoverableItemsFV bool
 )
 
+// AddExchangeMailboxFlags adds the flags that extend exchange backups
+// beyond the primary mailbox's folders.
+func AddExchangeMailboxFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.BoolVar(
+		&IncludeArchiveFV,
+		IncludeArchiveFN, false,
+		"Include the online archive mailbox.  Archived mail is placed under the '"+
+			details.ExchangeArchiveFolder+"' folder.")
+	fs.BoolVar(
+		&IncludeRecoverableItemsFV,
+		IncludeRecoverableItemsFN, false,
+		"Include the Recoverable Items folders (deletions, purges, and held items).  "+
+			"Their mail is placed under the '"+details.ExchangeRecoverableItemsFolder+"' folder.")
+}
+
 // AddExchangeDetailsAndRestoreFlags adds flags that are common to both the
 // details and restore commands.
 func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command, emailOnly bool) {
@@ -78,6 +107,12 @@ func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command, emailOnly bool) {
 		EmailReceivedBeforeFN, "",
 		"Select emails received before this datetime.")
 
+	// settings flags
+	fs.BoolVar(
+		&SettingsFV,
+		SettingsFN, false,
+		"Select the mailbox settings: inbox rules, automatic replies, categories and general settings.")
+
 	// NOTE: Only temporary until we add support for exporting the
 	// others as well in exchange.
 	if emailOnly {
@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// GCS storage flags
+const (
+	GCSCredentialsFileFN = "gcs-credentials-file"
+)
+
+// GCS storage flag values
+var (
+	GCSCredentialsFileFV string
+)
+
+// AddGCSFlags adds the google cloud storage bucket flags.
+// The bucket and prefix flags are shared with s3.
+func AddGCSFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	// Flags addition ordering should follow the order we want them to appear in help and docs:
+	// More generic and more frequently used flags take precedence.
+	fs.StringVar(&BucketFV, BucketFN, "", "Name of the GCS bucket for the repo. (required)")
+	fs.StringVar(&PrefixFV, PrefixFN, "", "Repo prefix within bucket.")
+
+	// In general, we don't want to expose this flag to users and have them mistake it
+	// for a broad-scale idempotency solution.  We can un-hide it later the need arises.
+	fs.BoolVar(&SucceedIfExistsFV, SucceedIfExistsFN, false, "Exit with success if the repo has already been initialized.")
+	cobra.CheckErr(fs.MarkHidden("succeed-if-exists"))
+}
+
+// AddGCSCredsFlags adds the gcs service account flag.  If no service
+// account is provided, the application default credentials are used.
+func AddGCSCredsFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&GCSCredentialsFileFV,
+		GCSCredentialsFileFN, "",
+		"Path to a GCS service account key file.")
+}
+
+func GCSFlagOverrides(cmd *cobra.Command) map[string]string {
+	fs := GetPopulatedFlags(cmd)
+	return PopulateGCSFlags(fs)
+}
+
+func PopulateGCSFlags(flagset PopulatedFlags) map[string]string {
+	gcsOverrides := map[string]string{
+		storage.StorageProviderTypeKey: storage.ProviderGCS.String(),
+	}
+
+	if _, ok := flagset[GCSCredentialsFileFN]; ok {
+		gcsOverrides[credentials.GCSCredentialsFile] = GCSCredentialsFileFV
+	}
+
+	if _, ok := flagset[BucketFN]; ok {
+		gcsOverrides[storage.Bucket] = BucketFV
+	}
+
+	if _, ok := flagset[PrefixFN]; ok {
+		gcsOverrides[storage.Prefix] = PrefixFV
+	}
+
+	return gcsOverrides
+}
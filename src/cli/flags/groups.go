@@ -7,6 +7,7 @@ import (
 const (
 	DataMessages      = "messages"
 	DataConversations = "conversations"
+	DataPlanner       = "planner"
 )
 
 const (
@@ -20,6 +21,15 @@ const (
 	MessageCreatedBeforeFN   = "message-created-before"
 	MessageLastReplyAfterFN  = "message-last-reply-after"
 	MessageLastReplyBeforeFN = "message-last-reply-before"
+
+	ConversationTopicFN = "conversation-topic"
+	PostCreatedAfterFN  = "post-created-after"
+	PostCreatedBeforeFN = "post-created-before"
+
+	PlanFN          = "plan"
+	TaskFN          = "task"
+	PlannerBucketFN = "planner-bucket"
+	TaskTitleFN     = "task-title"
 )
 
 var (
@@ -33,6 +43,15 @@ var (
 	MessageCreatedBeforeFV   string
 	MessageLastReplyAfterFV  string
 	MessageLastReplyBeforeFV string
+
+	ConversationTopicFV string
+	PostCreatedAfterFV  string
+	PostCreatedBeforeFV string
+
+	PlanFV          []string
+	TaskFV          []string
+	PlannerBucketFV string
+	TaskTitleFV     string
 )
 
 func AddGroupDetailsAndRestoreFlags(cmd *cobra.Command) {
@@ -77,6 +96,41 @@ func AddGroupDetailsAndRestoreFlags(cmd *cobra.Command) {
 		&PostFV,
 		PostFN, nil,
 		"Select Conversation Posts by reference.")
+
+	fs.StringVar(
+		&ConversationTopicFV,
+		ConversationTopicFN, "",
+		"Select posts in conversations whose topic contains this value.")
+
+	fs.StringVar(
+		&PostCreatedAfterFV,
+		PostCreatedAfterFN, "",
+		"Select conversation posts created after this datetime.")
+
+	fs.StringVar(
+		&PostCreatedBeforeFV,
+		PostCreatedBeforeFN, "",
+		"Select conversation posts created before this datetime.")
+
+	fs.StringSliceVar(
+		&PlanFV,
+		PlanFN, nil,
+		"Select data within a Group's Planner plan.")
+
+	fs.StringSliceVar(
+		&TaskFV,
+		TaskFN, nil,
+		"Select Planner tasks by reference.")
+
+	fs.StringVar(
+		&PlannerBucketFV,
+		PlannerBucketFN, "",
+		"Select Planner tasks in the bucket with this name.")
+
+	fs.StringVar(
+		&TaskTitleFV,
+		TaskTitleFN, "",
+		"Select Planner tasks whose title contains this value.")
 }
 
 // AddGroupFlag adds the --group flag, which accepts either the id,
@@ -12,6 +12,10 @@ const (
 	AzureClientTenantFN = "azure-tenant-id"
 	AzureClientIDFN     = "azure-client-id"
 	AzureClientSecretFN = "azure-client-secret"
+
+	AzureClientCertFN         = "azure-client-cert"
+	AzureClientCertPasswordFN = "azure-client-cert-password"
+	AzureFederatedTokenFileFN = "azure-federated-token-file"
 )
 
 var (
@@ -19,6 +23,10 @@ var (
 	AzureClientTenantFV string
 	AzureClientIDFV     string
 	AzureClientSecretFV string
+
+	AzureClientCertFV         string
+	AzureClientCertPasswordFV string
+	AzureFederatedTokenFileFV string
 )
 
 // AddUserFlag adds the --user flag.
@@ -53,4 +61,13 @@ func AddAzureCredsFlags(cmd *cobra.Command) {
 	fs.StringVar(&AzureClientTenantFV, AzureClientTenantFN, "", "Azure tenant ID")
 	fs.StringVar(&AzureClientIDFV, AzureClientIDFN, "", "Azure app client ID")
 	fs.StringVar(&AzureClientSecretFV, AzureClientSecretFN, "", "Azure app client secret")
+	fs.StringVar(
+		&AzureClientCertFV,
+		AzureClientCertFN, "",
+		"Path to a PEM or PFX certificate for the Azure app; used in place of the client secret")
+	fs.StringVar(&AzureClientCertPasswordFV, AzureClientCertPasswordFN, "", "Password for the Azure app certificate")
+	fs.StringVar(
+		&AzureFederatedTokenFileFV,
+		AzureFederatedTokenFileFN, "",
+		"Path to a federated (workload identity) token file; used in place of the client secret")
 }
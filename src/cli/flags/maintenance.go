@@ -11,6 +11,7 @@ const (
 	ForceMaintenanceFN    = "force"
 	UserMaintenanceFN     = "user"
 	HostnameMaintenanceFN = "host"
+	MeasureStorageFN      = "measure-storage"
 )
 
 var (
@@ -18,6 +19,7 @@ var (
 	ForceMaintenanceFV    bool
 	UserMaintenanceFV     string
 	HostnameMaintenanceFV string
+	MeasureStorageFV      bool
 )
 
 func AddMaintenanceModeFlag(cmd *cobra.Command) {
@@ -41,6 +43,15 @@ func AddForceMaintenanceFlag(cmd *cobra.Command) {
 	cobra.CheckErr(fs.MarkHidden(ForceMaintenanceFN))
 }
 
+func AddMeasureStorageFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&MeasureStorageFV,
+		MeasureStorageFN,
+		false,
+		"Report the storage reclaimed by maintenance. Lists every blob in the repository, which can be slow for large repositories")
+}
+
 func AddMaintenanceUserFlag(cmd *cobra.Command) {
 	fs := cmd.Flags()
 	fs.StringVar(
@@ -0,0 +1,31 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	NotebookSectionFN = "notebook-section"
+	NotebookPageFN    = "notebook-page"
+)
+
+var (
+	NotebookSectionFV []string
+	NotebookPageFV    []string
+)
+
+// AddNotebookDetailsAndRestoreFlags adds the OneNote flags that are common
+// to both the details and restore commands of the services that own
+// notebooks.
+func AddNotebookDetailsAndRestoreFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&NotebookSectionFV,
+		NotebookSectionFN, nil,
+		"Select notebook pages by section, as notebook/section; accepts '"+Wildcard+"' to select all sections.")
+	fs.StringSliceVar(
+		&NotebookPageFV,
+		NotebookPageFN, nil,
+		"Select notebook pages by title.")
+}
@@ -8,6 +8,7 @@ const (
 	AlertsFN                      = "alerts"
 	ConfigFileFN                  = "config-file"
 	DeltaPageSizeFN               = "delta-page-size"
+	DetectAnomaliesFN             = "detect-anomalies"
 	DisableDeltaFN                = "disable-delta"
 	DisableIncrementalsFN         = "disable-incrementals"
 	DisableLazyItemReaderFN       = "disable-lazy-item-reader"
@@ -17,10 +18,13 @@ const (
 	FailFastFN                    = "fail-fast"
 	FailedItemsFN                 = "failed-items"
 	FetchParallelismFN            = "fetch-parallelism"
+	MarkSuspectFN                 = "mark-suspect"
 	NoPermissionsFN               = "no-permissions"
 	NoStatsFN                     = "no-stats"
 	RecoveredErrorsFN             = "recovered-errors"
 	RunModeFN                     = "run-mode"
+	SearchIndexFN                 = "search-index"
+	SecretSourceFN                = "secret-source"
 	SkippedItemsFN                = "skipped-items"
 	SkipReduceFN                  = "skip-reduce"
 )
@@ -28,6 +32,7 @@ const (
 var (
 	ConfigFileFV                  string
 	DeltaPageSizeFV               int
+	DetectAnomaliesFV             bool
 	DisableDeltaFV                bool
 	DisableIncrementalsFV         bool
 	DisableLazyItemReaderFV       bool
@@ -40,12 +45,15 @@ var (
 	ListAlertsFV                  string
 	ListSkippedItemsFV            string
 	ListRecoveredErrorsFV         string
+	MarkSuspectFV                 bool
 	NoPermissionsFV               bool
 	NoStatsFV                     bool
 	// RunMode describes the type of run, such as:
 	// flagtest, dry, run.  Should default to 'run'.
-	RunModeFV    string
-	SkipReduceFV bool
+	RunModeFV      string
+	SearchIndexFV  bool
+	SecretSourceFV string
+	SkipReduceFV   bool
 )
 
 // well-known flag values
@@ -131,6 +139,36 @@ func AddForceItemDataDownloadFlag(cmd *cobra.Command) {
 	cobra.CheckErr(fs.MarkHidden(ForceItemDataDownloadFN))
 }
 
+// AddSearchIndexFlag adds the '--search-index' flag, which indexes the
+// content of the backed up items for `corso backup search`.
+func AddSearchIndexFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&SearchIndexFV,
+		SearchIndexFN,
+		false,
+		"Index the content of backed up items so they can be found with 'corso backup search'.")
+}
+
+// AddAnomalyFlags adds the '--detect-anomalies' and '--mark-suspect' flags,
+// which compare each backup against the history of the protected resource
+// to spot mass changes, deletions, or signs of ransomware.
+func AddAnomalyFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&DetectAnomaliesFV,
+		DetectAnomaliesFN,
+		false,
+		"Alert when the changes in a backup depart sharply from prior backups, such as "+
+			"mass deletions or files encrypted by ransomware.")
+	fs.BoolVar(
+		&MarkSuspectFV,
+		MarkSuspectFN,
+		false,
+		"Mark backups with detected anomalies as suspect. Retention never prunes the "+
+			"newest backup that isn't suspect. Requires --"+DetectAnomaliesFN+".")
+}
+
 // Adds the hidden '--disable-delta' cli flag which, when set, disables
 // delta based backups.
 func AddDisableDeltaFlag(cmd *cobra.Command) {
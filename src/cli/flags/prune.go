@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	DryRunFN            = "dry-run"
+	KeepLastFN          = "keep-last"
+	KeepDailyFN         = "keep-daily"
+	KeepWeeklyFN        = "keep-weekly"
+	KeepMonthlyFN       = "keep-monthly"
+	MaxAgeFN            = "max-age"
+	RetentionServiceFN  = "service"
+	RetentionResourceFN = "resource"
+)
+
+var (
+	DryRunFV            bool
+	KeepLastFV          int
+	KeepDailyFV         int
+	KeepWeeklyFV        int
+	KeepMonthlyFV       int
+	MaxAgeFV            time.Duration
+	RetentionServiceFV  string
+	RetentionResourceFV string
+)
+
+// AddDryRunFlag adds the --dry-run flag.
+func AddDryRunFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&DryRunFV,
+		DryRunFN,
+		false,
+		"Report which backups would be deleted without deleting them")
+}
+
+// AddRetentionPolicyScopeFlags adds the flags that select which backups a
+// retention policy applies to.
+func AddRetentionPolicyScopeFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&RetentionServiceFV,
+		RetentionServiceFN,
+		"",
+		"Limit the policy to backups of the service: exchange, onedrive, sharepoint, or groups")
+	fs.StringVar(
+		&RetentionResourceFV,
+		RetentionResourceFN,
+		"",
+		"Limit the policy to backups of the protected resource, by ID")
+}
+
+// AddRetentionPolicyFlags adds the flags that define a retention policy.
+func AddRetentionPolicyFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.IntVar(&KeepLastFV, KeepLastFN, 0, "Keep the most recent N backups")
+	fs.IntVar(&KeepDailyFV, KeepDailyFN, 0, "Keep the most recent backup of each of the last N days")
+	fs.IntVar(&KeepWeeklyFV, KeepWeeklyFN, 0, "Keep the most recent backup of each of the last N weeks")
+	fs.IntVar(&KeepMonthlyFV, KeepMonthlyFN, 0, "Keep the most recent backup of each of the last N months")
+	fs.DurationVar(
+		&MaxAgeFV,
+		MaxAgeFN,
+		time.Duration(0),
+		"Delete backups older than this duration, regardless of the keep counts (eg: 8760h)")
+}
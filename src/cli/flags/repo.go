@@ -57,6 +57,10 @@ func AddAllStorageFlags(cmd *cobra.Command) {
 	AddCorsoPassphaseFlags(cmd)
 	// AddAzureCredsFlags is added by ProviderFlags
 	AddAWSCredsFlags(cmd)
+	AddAzureStorageCredsFlags(cmd)
+	AddGCSCredsFlags(cmd)
+	AddWebDAVCredsFlags(cmd)
+	AddSFTPCredsFlags(cmd)
 }
 
 func AddAWSCredsFlags(cmd *cobra.Command) {
@@ -10,12 +10,26 @@ const (
 	CollisionsFN  = "collisions"
 	DestinationFN = "destination"
 	ToResourceFN  = "to-resource"
+	ResourceMapFN = "resource-map"
+
+	ToAzureTenantFN             = "to-azure-tenant-id"
+	ToAzureClientIDFN           = "to-azure-client-id"
+	ToAzureClientSecretFN       = "to-azure-client-secret"
+	ToAzureClientCertFN         = "to-azure-client-cert"
+	ToAzureClientCertPasswordFN = "to-azure-client-cert-password"
 )
 
 var (
 	CollisionsFV  string
 	DestinationFV string
 	ToResourceFV  string
+	ResourceMapFV string
+
+	ToAzureTenantFV             string
+	ToAzureClientIDFV           string
+	ToAzureClientSecretFV       string
+	ToAzureClientCertFV         string
+	ToAzureClientCertPasswordFV string
 )
 
 // AddRestoreConfigFlags adds the restore config flag set.
@@ -33,5 +47,30 @@ func AddRestoreConfigFlags(cmd *cobra.Command, canRestoreToAlternate bool) {
 		fs.StringVar(
 			&ToResourceFV, ToResourceFN, "",
 			"Overrides the protected resource (mailbox, site, user, etc) where data gets restored")
+		fs.StringVar(
+			&ResourceMapFV, ResourceMapFN, "",
+			"Path to a CSV or JSON file that maps backed up users, groups and sites (by ID or name) "+
+				"to those of the restore tenant")
+
+		addRestoreTenantFlags(cmd)
 	}
 }
+
+// addRestoreTenantFlags adds the credentials of the tenant receiving the
+// restore, when it's not the tenant the backup was taken from.
+func addRestoreTenantFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&ToAzureTenantFV, ToAzureTenantFN, "",
+		"Restores into this Azure tenant, instead of the tenant the repository was connected with")
+	fs.StringVar(&ToAzureClientIDFV, ToAzureClientIDFN, "", "Azure app client ID of the restore tenant")
+	fs.StringVar(&ToAzureClientSecretFV, ToAzureClientSecretFN, "", "Azure app client secret of the restore tenant")
+	fs.StringVar(
+		&ToAzureClientCertFV,
+		ToAzureClientCertFN, "",
+		"Path to a PEM or PFX certificate for the Azure app of the restore tenant; used in place of the client secret")
+	fs.StringVar(
+		&ToAzureClientCertPasswordFV,
+		ToAzureClientCertPasswordFN, "",
+		"Password for the Azure app certificate of the restore tenant")
+}
@@ -0,0 +1,32 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	SearchServiceFN   = "service"
+	SearchResourcesFN = "resource"
+)
+
+var (
+	SearchServiceFV   string
+	SearchResourcesFV []string
+)
+
+// AddSearchScopeFlags adds the flags that limit which backups get searched.
+func AddSearchScopeFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&SearchServiceFV,
+		SearchServiceFN,
+		"",
+		"Only search backups of the service: exchange, onedrive, sharepoint, groups, teamsChats, or entraID")
+	fs.StringSliceVar(
+		&SearchResourcesFV,
+		SearchResourcesFN,
+		nil,
+		"',' separated IDs or names of the protected resources whose backups get searched")
+
+	AddMultipleBackupIDsFlag(cmd, false)
+}
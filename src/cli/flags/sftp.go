@@ -0,0 +1,100 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// SFTP storage flags
+const (
+	SFTPHostFN           = "host"
+	SFTPPortFN           = "port"
+	SFTPUsernameFN       = "username"
+	SFTPPathFN           = "path"
+	SFTPKeyFileFN        = "keyfile"
+	SFTPKnownHostsFileFN = "known-hosts"
+	SFTPPasswordFN       = "sftp-password"
+)
+
+// SFTP storage flag values
+var (
+	SFTPHostFV           string
+	SFTPPortFV           string
+	SFTPUsernameFV       string
+	SFTPPathFV           string
+	SFTPKeyFileFV        string
+	SFTPKnownHostsFileFV string
+	SFTPPasswordFV       string
+)
+
+// AddSFTPFlags adds the sftp server flags.
+func AddSFTPFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	// Flags addition ordering should follow the order we want them to appear in help and docs:
+	// More generic and more frequently used flags take precedence.
+	fs.StringVar(&SFTPHostFV, SFTPHostFN, "", "Hostname of the SFTP server. (required)")
+	fs.StringVar(&SFTPUsernameFV, SFTPUsernameFN, "", "SFTP username. (required)")
+	fs.StringVar(&SFTPPathFV, SFTPPathFN, "", "Path to the repo on the SFTP server. (required)")
+	fs.StringVar(&SFTPPortFV, SFTPPortFN, "", "SFTP server port; defaults to 22.")
+	fs.StringVar(
+		&SFTPKnownHostsFileFV,
+		SFTPKnownHostsFileFN, "",
+		"Path to a known_hosts file containing the server's host key. (required)")
+	fs.StringVar(&SFTPKeyFileFV, SFTPKeyFileFN, "", "Path to a private key used to authenticate.")
+
+	// In general, we don't want to expose this flag to users and have them mistake it
+	// for a broad-scale idempotency solution.  We can un-hide it later the need arises.
+	fs.BoolVar(&SucceedIfExistsFV, SucceedIfExistsFN, false, "Exit with success if the repo has already been initialized.")
+	cobra.CheckErr(fs.MarkHidden("succeed-if-exists"))
+}
+
+// AddSFTPCredsFlags adds the sftp password flag.  Private keys are
+// provided by file, or through the CORSO_SFTP_KEY_DATA env var.
+func AddSFTPCredsFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(&SFTPPasswordFV, SFTPPasswordFN, "", "SFTP server password.")
+}
+
+func SFTPFlagOverrides(cmd *cobra.Command) map[string]string {
+	fs := GetPopulatedFlags(cmd)
+	return PopulateSFTPFlags(fs)
+}
+
+func PopulateSFTPFlags(flagset PopulatedFlags) map[string]string {
+	sftpOverrides := map[string]string{
+		storage.StorageProviderTypeKey: storage.ProviderSFTP.String(),
+	}
+
+	if _, ok := flagset[SFTPPasswordFN]; ok {
+		sftpOverrides[credentials.SFTPPassword] = SFTPPasswordFV
+	}
+
+	if _, ok := flagset[SFTPHostFN]; ok {
+		sftpOverrides[storage.SFTPHost] = SFTPHostFV
+	}
+
+	if _, ok := flagset[SFTPPortFN]; ok {
+		sftpOverrides[storage.SFTPPort] = SFTPPortFV
+	}
+
+	if _, ok := flagset[SFTPUsernameFN]; ok {
+		sftpOverrides[storage.SFTPUsername] = SFTPUsernameFV
+	}
+
+	if _, ok := flagset[SFTPPathFN]; ok {
+		sftpOverrides[storage.SFTPPath] = SFTPPathFV
+	}
+
+	if _, ok := flagset[SFTPKeyFileFN]; ok {
+		sftpOverrides[storage.SFTPKeyFile] = SFTPKeyFileFV
+	}
+
+	if _, ok := flagset[SFTPKnownHostsFileFN]; ok {
+		sftpOverrides[storage.SFTPKnownHostsFile] = SFTPKnownHostsFileFV
+	}
+
+	return sftpOverrides
+}
@@ -8,6 +8,7 @@ const (
 	DataLibraries = "libraries"
 	DataPages     = "pages"
 	DataLists     = "lists"
+	DataNotebooks = "notebooks"
 )
 
 const (
@@ -33,8 +33,16 @@ var (
 	ContactFldInput  = []string{"contactFld1", "contactFld2"}
 	ContactNameInput = "contactName"
 
-	ConversationInput = []string{"conversation1", "conversation2"}
-	PostInput         = []string{"post1", "post2"}
+	ConversationInput      = []string{"conversation1", "conversation2"}
+	PostInput              = []string{"post1", "post2"}
+	ConversationTopicInput = "conversationTopic"
+	PostCreatedAfterInput  = "postCreatedAfter"
+	PostCreatedBeforeInput = "postCreatedBefore"
+
+	PlanInput          = []string{"plan1", "plan2"}
+	TaskInput          = []string{"task1", "task2"}
+	PlannerBucketInput = "plannerBucket"
+	TaskTitleInput     = "taskTitle"
 
 	EmailInput               = []string{"mail1", "mail2"}
 	EmailFldInput            = []string{"mailFld1", "mailFld2"}
@@ -72,6 +80,11 @@ var (
 	Destination     = "destination"
 	ToResource      = "toResource"
 	SkipPermissions = false
+	ResourceMap     = "resourceMap.csv"
+
+	ToAzureTenantID     = "testToAzureTenantId"
+	ToAzureClientID     = "testToAzureClientId"
+	ToAzureClientSecret = "testToAzureClientSecret"
 
 	DeltaPageSize = "7"
 
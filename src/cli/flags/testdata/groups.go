@@ -33,10 +33,32 @@ func PreparedConversationFlags() []string {
 	return []string{
 		"--" + flags.ConversationFN, FlgInputs(ConversationInput),
 		"--" + flags.PostFN, FlgInputs(PostInput),
+		"--" + flags.ConversationTopicFN, ConversationTopicInput,
+		"--" + flags.PostCreatedAfterFN, PostCreatedAfterInput,
+		"--" + flags.PostCreatedBeforeFN, PostCreatedBeforeInput,
 	}
 }
 
 func AssertConversationFlags(t *testing.T, cmd *cobra.Command) {
 	assert.Equal(t, ConversationInput, flags.ConversationFV)
 	assert.Equal(t, PostInput, flags.PostFV)
+	assert.Equal(t, ConversationTopicInput, flags.ConversationTopicFV)
+	assert.Equal(t, PostCreatedAfterInput, flags.PostCreatedAfterFV)
+	assert.Equal(t, PostCreatedBeforeInput, flags.PostCreatedBeforeFV)
+}
+
+func PreparedPlannerFlags() []string {
+	return []string{
+		"--" + flags.PlanFN, FlgInputs(PlanInput),
+		"--" + flags.TaskFN, FlgInputs(TaskInput),
+		"--" + flags.PlannerBucketFN, PlannerBucketInput,
+		"--" + flags.TaskTitleFN, TaskTitleInput,
+	}
+}
+
+func AssertPlannerFlags(t *testing.T, cmd *cobra.Command) {
+	assert.Equal(t, PlanInput, flags.PlanFV)
+	assert.Equal(t, TaskInput, flags.TaskFV)
+	assert.Equal(t, PlannerBucketInput, flags.PlannerBucketFV)
+	assert.Equal(t, TaskTitleInput, flags.TaskTitleFV)
 }
@@ -46,6 +46,9 @@ func PreparedGenericBackupFlags() []string {
 		"--" + flags.FailFastFN,
 		"--" + flags.DisableIncrementalsFN,
 		"--" + flags.ForceItemDataDownloadFN,
+		"--" + flags.SearchIndexFN,
+		"--" + flags.DetectAnomaliesFN,
+		"--" + flags.MarkSuspectFN,
 	}
 }
 
@@ -53,4 +56,7 @@ func AssertGenericBackupFlags(t *testing.T, cmd *cobra.Command) {
 	assert.True(t, flags.FailFastFV, "fail fast flag")
 	assert.True(t, flags.DisableIncrementalsFV, "disable incrementals flag")
 	assert.True(t, flags.ForceItemDataDownloadFV, "force item data download flag")
+	assert.True(t, flags.SearchIndexFV, "search index flag")
+	assert.True(t, flags.DetectAnomaliesFV, "detect anomalies flag")
+	assert.True(t, flags.MarkSuspectFV, "mark suspect flag")
 }
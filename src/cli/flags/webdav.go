@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// WebDAV storage flags
+const (
+	WebDAVURLFN                           = "url"
+	WebDAVUsernameFN                      = "webdav-username"
+	WebDAVPasswordFN                      = "webdav-password"
+	TrustedServerCertificateFingerprintFN = "trusted-server-certificate-fingerprint"
+)
+
+// WebDAV storage flag values
+var (
+	WebDAVURLFV                           string
+	WebDAVUsernameFV                      string
+	WebDAVPasswordFV                      string
+	TrustedServerCertificateFingerprintFV string
+)
+
+// AddWebDAVFlags adds the webdav server flags.
+func AddWebDAVFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	// Flags addition ordering should follow the order we want them to appear in help and docs:
+	// More generic and more frequently used flags take precedence.
+	fs.StringVar(&WebDAVURLFV, WebDAVURLFN, "", "Url of the WebDAV folder for the repo. (required)")
+	fs.StringVar(
+		&TrustedServerCertificateFingerprintFV,
+		TrustedServerCertificateFingerprintFN, "",
+		"SHA256 fingerprint of the server's self-signed TLS certificate.")
+
+	// In general, we don't want to expose this flag to users and have them mistake it
+	// for a broad-scale idempotency solution.  We can un-hide it later the need arises.
+	fs.BoolVar(&SucceedIfExistsFV, SucceedIfExistsFN, false, "Exit with success if the repo has already been initialized.")
+	cobra.CheckErr(fs.MarkHidden("succeed-if-exists"))
+}
+
+// AddWebDAVCredsFlags adds the webdav server credential flags.
+func AddWebDAVCredsFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(&WebDAVUsernameFV, WebDAVUsernameFN, "", "WebDAV server username.")
+	fs.StringVar(&WebDAVPasswordFV, WebDAVPasswordFN, "", "WebDAV server password.")
+}
+
+func WebDAVFlagOverrides(cmd *cobra.Command) map[string]string {
+	fs := GetPopulatedFlags(cmd)
+	return PopulateWebDAVFlags(fs)
+}
+
+func PopulateWebDAVFlags(flagset PopulatedFlags) map[string]string {
+	webdavOverrides := map[string]string{
+		storage.StorageProviderTypeKey: storage.ProviderWebDAV.String(),
+	}
+
+	if _, ok := flagset[WebDAVUsernameFN]; ok {
+		webdavOverrides[credentials.WebDAVUsername] = WebDAVUsernameFV
+	}
+
+	if _, ok := flagset[WebDAVPasswordFN]; ok {
+		webdavOverrides[credentials.WebDAVPassword] = WebDAVPasswordFV
+	}
+
+	if _, ok := flagset[WebDAVURLFN]; ok {
+		webdavOverrides[storage.WebDAVURL] = WebDAVURLFV
+	}
+
+	if _, ok := flagset[TrustedServerCertificateFingerprintFN]; ok {
+		webdavOverrides[storage.TrustedServerCertificateFingerprint] = TrustedServerCertificateFingerprintFV
+	}
+
+	return webdavOverrides
+}
@@ -0,0 +1,216 @@
+package repo
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/config"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// called by repo.go to map subcommands to provider-specific handling.
+func addAzureCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case initCommand:
+		c, _ = utils.AddCommand(cmd, azureInitCmd())
+
+	case connectCommand:
+		c, _ = utils.AddCommand(cmd, azureConnectCmd())
+	}
+
+	c.Use = c.Use + " " + azureProviderCommandUseSuffix
+	c.SetUsageTemplate(cmd.UsageTemplate())
+
+	flags.AddCorsoPassphaseFlags(c)
+	flags.AddAzureStorageCredsFlags(c)
+	flags.AddAzureStorageFlags(c)
+
+	return c
+}
+
+const (
+	azureProviderCommand          = "azure"
+	azureProviderCommandUseSuffix = "--container <container> --storage-account <account>"
+)
+
+const (
+	azureProviderCommandInitExamples = `# Create a new Corso repo in the Azure blob container "my-container"
+corso repo init azure --container my-container --storage-account myaccount
+
+# Create a new Corso repo in the Azure blob container "my-container" using a prefix
+corso repo init azure --container my-container --storage-account myaccount --prefix my-prefix
+
+# Create a new Corso repo using a SAS token instead of the account key
+corso repo init azure --container my-container --storage-account myaccount \
+    --azure-storage-sas-token 'sv=...'
+
+# Create a new Corso repo using a service principal
+corso repo init azure --container my-container --storage-account myaccount \
+    --azure-storage-tenant-id '...' --azure-storage-client-id '...' --azure-storage-client-secret '...'
+
+# Create a new Corso repo in the Azure US Government cloud
+corso repo init azure --container my-container --storage-account myaccount \
+    --endpoint blob.core.usgovcloudapi.net`
+
+	azureProviderCommandConnectExamples = `# Connect to a Corso repo in the Azure blob container "my-container"
+corso repo connect azure --container my-container --storage-account myaccount
+
+# Connect to a Corso repo in the Azure blob container "my-container" using a prefix
+corso repo connect azure --container my-container --storage-account myaccount --prefix my-prefix`
+)
+
+// ---------------------------------------------------------------------------------------------------------
+// Init
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo init azure [<flag>...]`
+func azureInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     azureProviderCommand,
+		Short:   "Initialize an Azure blob storage repository",
+		Long:    `Bootstraps a new Azure blob storage repository and connects it to your m365 account.`,
+		RunE:    initAzureCmd,
+		Args:    cobra.NoArgs,
+		Example: azureProviderCommandInitExamples,
+	}
+}
+
+// initializes an azure repo.
+func initAzureCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderAzure,
+		true,
+		false,
+		flags.AzureFlagOverrides(cmd))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	opt := utils.ControlWithConfig(cfg)
+	// Retention is not supported for azure repos.
+	retentionOpts := ctrlRepo.Retention{}
+
+	azCfg, err := cfg.Storage.ToAzureConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving azure configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opt,
+		repository.NewRepoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to construct the repository controller"))
+	}
+
+	ric := repository.InitConfig{RetentionOpts: retentionOpts}
+
+	if err = r.Initialize(ctx, ric); err != nil {
+		if flags.SucceedIfExistsFV && errors.Is(err, repository.ErrorRepoAlreadyExists) {
+			return nil
+		}
+
+		return Only(ctx, clues.Stack(ErrInitializingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Initialized an Azure repository within container %s.", azCfg.Container)
+
+	if err = config.WriteRepoConfig(ctx, azCfg, m365, opt.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------
+// Connect
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo connect azure [<flag>...]`
+func azureConnectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     azureProviderCommand,
+		Short:   "Connect to an Azure blob storage repository",
+		Long:    `Ensures a connection to an existing Azure blob storage repository.`,
+		RunE:    connectAzureCmd,
+		Args:    cobra.NoArgs,
+		Example: azureProviderCommandConnectExamples,
+	}
+}
+
+// connects to an existing azure repo.
+func connectAzureCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderAzure,
+		true,
+		true,
+		flags.AzureFlagOverrides(cmd))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	repoID := cfg.RepoID
+	if len(repoID) == 0 {
+		repoID = events.RepoIDNotFound
+	}
+
+	azCfg, err := cfg.Storage.ToAzureConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving azure configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	opts := utils.ControlWithConfig(cfg)
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opts,
+		repoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to create a repository controller"))
+	}
+
+	if err := r.Connect(ctx, repository.ConnConfig{}); err != nil {
+		return Only(ctx, clues.Stack(ErrConnectingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Connected to Azure container %s.", azCfg.Container)
+
+	if err = config.WriteRepoConfig(ctx, azCfg, m365, opts.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
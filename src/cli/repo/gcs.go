@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/config"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// called by repo.go to map subcommands to provider-specific handling.
+func addGCSCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case initCommand:
+		c, _ = utils.AddCommand(cmd, gcsInitCmd())
+
+	case connectCommand:
+		c, _ = utils.AddCommand(cmd, gcsConnectCmd())
+	}
+
+	c.Use = c.Use + " " + gcsProviderCommandUseSuffix
+	c.SetUsageTemplate(cmd.UsageTemplate())
+
+	flags.AddCorsoPassphaseFlags(c)
+	flags.AddGCSCredsFlags(c)
+	flags.AddGCSFlags(c)
+
+	return c
+}
+
+const (
+	gcsProviderCommand          = "gcs"
+	gcsProviderCommandUseSuffix = "--bucket <bucket>"
+)
+
+const (
+	gcsProviderCommandInitExamples = `# Create a new Corso repo in the GCS bucket "my-bucket", using the host's default credentials
+corso repo init gcs --bucket my-bucket
+
+# Create a new Corso repo in the GCS bucket "my-bucket" using a prefix
+corso repo init gcs --bucket my-bucket --prefix my-prefix
+
+# Create a new Corso repo using a service account key file
+corso repo init gcs --bucket my-bucket --gcs-credentials-file ~/keys/corso-sa.json`
+
+	gcsProviderCommandConnectExamples = `# Connect to a Corso repo in the GCS bucket "my-bucket"
+corso repo connect gcs --bucket my-bucket
+
+# Connect to a Corso repo in the GCS bucket "my-bucket" using a prefix
+corso repo connect gcs --bucket my-bucket --prefix my-prefix`
+)
+
+// ---------------------------------------------------------------------------------------------------------
+// Init
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo init gcs [<flag>...]`
+func gcsInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     gcsProviderCommand,
+		Short:   "Initialize a Google Cloud Storage repository",
+		Long:    `Bootstraps a new Google Cloud Storage repository and connects it to your m365 account.`,
+		RunE:    initGCSCmd,
+		Args:    cobra.NoArgs,
+		Example: gcsProviderCommandInitExamples,
+	}
+}
+
+// initializes a gcs repo.
+func initGCSCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderGCS,
+		true,
+		false,
+		flags.GCSFlagOverrides(cmd))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	opt := utils.ControlWithConfig(cfg)
+	// Retention is not supported for gcs repos.
+	retentionOpts := ctrlRepo.Retention{}
+
+	gcsCfg, err := cfg.Storage.ToGCSConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving gcs configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opt,
+		repository.NewRepoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to construct the repository controller"))
+	}
+
+	ric := repository.InitConfig{RetentionOpts: retentionOpts}
+
+	if err = r.Initialize(ctx, ric); err != nil {
+		if flags.SucceedIfExistsFV && errors.Is(err, repository.ErrorRepoAlreadyExists) {
+			return nil
+		}
+
+		return Only(ctx, clues.Stack(ErrInitializingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Initialized a GCS repository within bucket %s.", gcsCfg.Bucket)
+
+	if err = config.WriteRepoConfig(ctx, gcsCfg, m365, opt.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------
+// Connect
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo connect gcs [<flag>...]`
+func gcsConnectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     gcsProviderCommand,
+		Short:   "Connect to a Google Cloud Storage repository",
+		Long:    `Ensures a connection to an existing Google Cloud Storage repository.`,
+		RunE:    connectGCSCmd,
+		Args:    cobra.NoArgs,
+		Example: gcsProviderCommandConnectExamples,
+	}
+}
+
+// connects to an existing gcs repo.
+func connectGCSCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderGCS,
+		true,
+		true,
+		flags.GCSFlagOverrides(cmd))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	repoID := cfg.RepoID
+	if len(repoID) == 0 {
+		repoID = events.RepoIDNotFound
+	}
+
+	gcsCfg, err := cfg.Storage.ToGCSConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving gcs configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	opts := utils.ControlWithConfig(cfg)
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opts,
+		repoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to create a repository controller"))
+	}
+
+	if err := r.Connect(ctx, repository.ConnConfig{}); err != nil {
+		return Only(ctx, clues.Stack(ErrConnectingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Connected to GCS bucket %s.", gcsCfg.Bucket)
+
+	if err = config.WriteRepoConfig(ctx, gcsCfg, m365, opts.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/events"
 	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/maintenance"
 	"github.com/alcionai/corso/src/pkg/path"
 	repo "github.com/alcionai/corso/src/pkg/repository"
 )
@@ -21,9 +22,13 @@ const (
 	connectCommand          = "connect"
 	updatePassphraseCommand = "update-passphrase"
 	MaintenanceCommand      = "maintenance"
+	HistoryCommand          = "history"
 )
 
 const (
+	maintenanceHistoryExamples = `# List past maintenance runs of the connected repository
+corso repo maintenance history`
+
 	providerCommandUpdatePhasephraseExamples = `# Update the Corso repository passphrase"
 corso repo update-passphrase --new-passphrase 'newpass'`
 )
@@ -36,6 +41,10 @@ var (
 var repoCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addS3Commands,
 	addFilesystemCommands,
+	addAzureCommands,
+	addGCSCommands,
+	addWebDAVCommands,
+	addSFTPCommands,
 }
 
 // AddCommands attaches all `corso repo * *` commands to the parent.
@@ -47,6 +56,7 @@ func AddCommands(cmd *cobra.Command) {
 		initCmd             = initCmd()
 		connectCmd          = connectCmd()
 		maintenanceCmd      = maintenanceCmd()
+		historyCmd          = maintenanceHistoryCmd()
 		updatePassphraseCmd = updatePassphraseCmd()
 	)
 
@@ -55,9 +65,11 @@ func AddCommands(cmd *cobra.Command) {
 	repoCmd.AddCommand(connectCmd)
 	repoCmd.AddCommand(maintenanceCmd)
 	repoCmd.AddCommand(updatePassphraseCmd)
+	maintenanceCmd.AddCommand(historyCmd)
 
 	flags.AddMaintenanceModeFlag(maintenanceCmd)
 	flags.AddForceMaintenanceFlag(maintenanceCmd)
+	flags.AddMeasureStorageFlag(maintenanceCmd)
 	flags.AddMaintenanceUserFlag(maintenanceCmd)
 	flags.AddMaintenanceHostnameFlag(maintenanceCmd)
 
@@ -154,9 +166,10 @@ func handleMaintenanceCmd(cmd *cobra.Command, args []string) error {
 	m, err := r.NewMaintenance(
 		ctx,
 		repository.Maintenance{
-			Type:   t,
-			Safety: repository.FullMaintenanceSafety,
-			Force:  flags.ForceMaintenanceFV,
+			Type:           t,
+			Safety:         repository.FullMaintenanceSafety,
+			Force:          flags.ForceMaintenanceFV,
+			MeasureStorage: flags.MeasureStorageFV,
 		})
 	if err != nil {
 		return Only(ctx, err)
@@ -170,6 +183,42 @@ func handleMaintenanceCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// The maintenance history subcommand.
+// `corso repo maintenance history [<flag>...]`
+func maintenanceHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     HistoryCommand,
+		Short:   "List past maintenance runs",
+		Long:    `List the recorded maintenance runs of the repository, newest first.`,
+		RunE:    handleMaintenanceHistoryCmd,
+		Args:    cobra.NoArgs,
+		Example: maintenanceHistoryExamples,
+	}
+}
+
+// Handler for calls to `corso repo maintenance history`.
+func handleMaintenanceHistoryCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Need to give it a valid service so it won't error out on us even though
+	// we don't need the graph client.
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	rs, err := r.MaintenanceHistory(ctx)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to list maintenance history"))
+	}
+
+	maintenance.PrintAll(ctx, rs)
+
+	return nil
+}
+
 func getMaintenanceType(t string) (repository.MaintenanceType, error) {
 	res, ok := repository.StringToMaintenanceType[t]
 	if !ok {
@@ -34,19 +34,31 @@ func (suite *RepoUnitSuite) TestAddRepoCommands() {
 
 	repo.AddCommands(cmd)
 
-	var found bool
+	var (
+		found        bool
+		foundHistory bool
+	)
 
 	// This is the repo command.
 	repoCmds := cmd.Commands()
 	require.Len(t, repoCmds, 1)
 
 	for _, c := range repoCmds[0].Commands() {
-		if c.Use == repo.MaintenanceCommand {
-			found = true
+		if c.Use != repo.MaintenanceCommand {
+			continue
+		}
+
+		found = true
+
+		for _, sc := range c.Commands() {
+			if sc.Use == repo.HistoryCommand {
+				foundHistory = true
+			}
 		}
 	}
 
 	assert.True(t, found, "looking for maintenance command")
+	assert.True(t, foundHistory, "looking for maintenance history command")
 }
 
 type RepoE2ESuite struct {
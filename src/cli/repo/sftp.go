@@ -0,0 +1,236 @@
+package repo
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/config"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// called by repo.go to map subcommands to provider-specific handling.
+func addSFTPCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case initCommand:
+		c, _ = utils.AddCommand(cmd, sftpInitCmd())
+
+	case connectCommand:
+		c, _ = utils.AddCommand(cmd, sftpConnectCmd())
+	}
+
+	c.Use = c.Use + " " + sftpProviderCommandUseSuffix
+	c.SetUsageTemplate(cmd.UsageTemplate())
+
+	flags.AddCorsoPassphaseFlags(c)
+	flags.AddSFTPCredsFlags(c)
+	flags.AddSFTPFlags(c)
+
+	return c
+}
+
+const (
+	sftpProviderCommand          = "sftp"
+	sftpProviderCommandUseSuffix = "--host <host> --username <username> --path <path> --known-hosts <file>"
+)
+
+const (
+	sftpProviderCommandInitExamples = `# Create a new Corso repo on a NAS over SFTP, authenticating with a private key
+corso repo init sftp --host nas.local --username corso --path /volume1/corso \
+    --known-hosts ~/.ssh/known_hosts --keyfile ~/.ssh/id_ed25519
+
+# Create a new Corso repo on a server listening on a custom port, using a password
+corso repo init sftp --host nas.local --port 2222 --username corso --path /volume1/corso \
+    --known-hosts ~/.ssh/known_hosts --sftp-password '...'`
+
+	sftpProviderCommandConnectExamples = `# Connect to a Corso repo on a NAS over SFTP
+corso repo connect sftp --host nas.local --username corso --path /volume1/corso \
+    --known-hosts ~/.ssh/known_hosts --keyfile ~/.ssh/id_ed25519`
+)
+
+// ---------------------------------------------------------------------------------------------------------
+// Init
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo init sftp [<flag>...]`
+func sftpInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     sftpProviderCommand,
+		Short:   "Initialize an SFTP repository",
+		Long:    `Bootstraps a new SFTP repository and connects it to your m365 account.`,
+		RunE:    initSFTPCmd,
+		Args:    cobra.NoArgs,
+		Example: sftpProviderCommandInitExamples,
+	}
+}
+
+// initializes an sftp repo.
+func initSFTPCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	overrides, err := sftpFlagOverrides(cmd)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderSFTP,
+		true,
+		false,
+		overrides)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	opt := utils.ControlWithConfig(cfg)
+	// Retention is not supported for sftp repos.
+	retentionOpts := ctrlRepo.Retention{}
+
+	sftpCfg, err := cfg.Storage.ToSFTPConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving sftp configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opt,
+		repository.NewRepoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to construct the repository controller"))
+	}
+
+	ric := repository.InitConfig{RetentionOpts: retentionOpts}
+
+	if err = r.Initialize(ctx, ric); err != nil {
+		if flags.SucceedIfExistsFV && errors.Is(err, repository.ErrorRepoAlreadyExists) {
+			return nil
+		}
+
+		return Only(ctx, clues.Stack(ErrInitializingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Initialized an SFTP repository at %s:%s.", sftpCfg.Host, sftpCfg.Path)
+
+	if err = config.WriteRepoConfig(ctx, sftpCfg, m365, opt.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------
+// Connect
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo connect sftp [<flag>...]`
+func sftpConnectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     sftpProviderCommand,
+		Short:   "Connect to an SFTP repository",
+		Long:    `Ensures a connection to an existing SFTP repository.`,
+		RunE:    connectSFTPCmd,
+		Args:    cobra.NoArgs,
+		Example: sftpProviderCommandConnectExamples,
+	}
+}
+
+// connects to an existing sftp repo.
+func connectSFTPCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	overrides, err := sftpFlagOverrides(cmd)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderSFTP,
+		true,
+		true,
+		overrides)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	repoID := cfg.RepoID
+	if len(repoID) == 0 {
+		repoID = events.RepoIDNotFound
+	}
+
+	sftpCfg, err := cfg.Storage.ToSFTPConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving sftp configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	opts := utils.ControlWithConfig(cfg)
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opts,
+		repoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to create a repository controller"))
+	}
+
+	if err := r.Connect(ctx, repository.ConnConfig{}); err != nil {
+		return Only(ctx, clues.Stack(ErrConnectingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Connected to SFTP repository at %s:%s.", sftpCfg.Host, sftpCfg.Path)
+
+	if err = config.WriteRepoConfig(ctx, sftpCfg, m365, opts.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
+
+// sftpFlagOverrides converts the key and known hosts file flags to absolute
+// paths, since they're persisted in the config file and re-read later.
+func sftpFlagOverrides(cmd *cobra.Command) (map[string]string, error) {
+	overrides := flags.SFTPFlagOverrides(cmd)
+
+	for _, k := range []string{storage.SFTPKeyFile, storage.SFTPKnownHostsFile} {
+		p, ok := overrides[k]
+		if !ok || len(p) == 0 {
+			continue
+		}
+
+		abs, err := utils.MakeAbsoluteFilePath(p)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting absolute path").With("flag_key", k)
+		}
+
+		overrides[k] = abs
+	}
+
+	return overrides, nil
+}
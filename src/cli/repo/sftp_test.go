@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+type SFTPSuite struct {
+	tester.Suite
+}
+
+func TestSFTPSuite(t *testing.T) {
+	suite.Run(t, &SFTPSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SFTPSuite) TestAddSFTPCommands() {
+	expectUse := sftpProviderCommand + " " + sftpProviderCommandUseSuffix
+
+	table := []struct {
+		name        string
+		use         string
+		expectUse   string
+		expectShort string
+		expectRunE  func(*cobra.Command, []string) error
+	}{
+		{"init sftp", initCommand, expectUse, sftpInitCmd().Short, initSFTPCmd},
+		{"connect sftp", connectCommand, expectUse, sftpConnectCmd().Short, connectSFTPCmd},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			cmd := &cobra.Command{Use: test.use}
+
+			c := addSFTPCommands(cmd)
+			require.NotNil(t, c)
+
+			cmds := cmd.Commands()
+			require.Len(t, cmds, 1)
+
+			child := cmds[0]
+			assert.Equal(t, test.expectUse, child.Use)
+			assert.Equal(t, test.expectShort, child.Short)
+			tester.AreSameFunc(t, test.expectRunE, child.RunE)
+		})
+	}
+}
+
+func (suite *SFTPSuite) TestSFTPFlagOverrides_AbsolutePaths() {
+	t := suite.T()
+
+	cmd := &cobra.Command{Use: initCommand}
+	c := addSFTPCommands(cmd)
+
+	err := c.ParseFlags([]string{
+		"--" + flags.SFTPHostFN, "nas.local",
+		"--" + flags.SFTPKeyFileFN, "id_ed25519",
+		"--" + flags.SFTPKnownHostsFileFN, "/etc/ssh/known_hosts",
+	})
+	require.NoError(t, err)
+
+	overrides, err := sftpFlagOverrides(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "nas.local", overrides[storage.SFTPHost])
+	assert.True(t, filepath.IsAbs(overrides[storage.SFTPKeyFile]), overrides[storage.SFTPKeyFile])
+	assert.Equal(t, "/etc/ssh/known_hosts", overrides[storage.SFTPKnownHostsFile])
+}
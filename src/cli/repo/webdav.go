@@ -0,0 +1,203 @@
+package repo
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/config"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// called by repo.go to map subcommands to provider-specific handling.
+func addWebDAVCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case initCommand:
+		c, _ = utils.AddCommand(cmd, webdavInitCmd())
+
+	case connectCommand:
+		c, _ = utils.AddCommand(cmd, webdavConnectCmd())
+	}
+
+	c.Use = c.Use + " " + webdavProviderCommandUseSuffix
+	c.SetUsageTemplate(cmd.UsageTemplate())
+
+	flags.AddCorsoPassphaseFlags(c)
+	flags.AddWebDAVCredsFlags(c)
+	flags.AddWebDAVFlags(c)
+
+	return c
+}
+
+const (
+	webdavProviderCommand          = "webdav"
+	webdavProviderCommandUseSuffix = "--url <url>"
+)
+
+const (
+	webdavProviderCommandInitExamples = `# Create a new Corso repo in a WebDAV share on a NAS
+corso repo init webdav --url https://nas.local:5006/corso \
+    --webdav-username corso --webdav-password '...'
+
+# Create a new Corso repo on a server using a self-signed certificate
+corso repo init webdav --url https://nas.local:5006/corso \
+    --trusted-server-certificate-fingerprint 6f0a...e1`
+
+	webdavProviderCommandConnectExamples = `# Connect to a Corso repo in a WebDAV share on a NAS
+corso repo connect webdav --url https://nas.local:5006/corso --webdav-password '...'`
+)
+
+// ---------------------------------------------------------------------------------------------------------
+// Init
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo init webdav [<flag>...]`
+func webdavInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     webdavProviderCommand,
+		Short:   "Initialize a WebDAV repository",
+		Long:    `Bootstraps a new WebDAV repository and connects it to your m365 account.`,
+		RunE:    initWebDAVCmd,
+		Args:    cobra.NoArgs,
+		Example: webdavProviderCommandInitExamples,
+	}
+}
+
+// initializes a webdav repo.
+func initWebDAVCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderWebDAV,
+		true,
+		false,
+		flags.WebDAVFlagOverrides(cmd))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	opt := utils.ControlWithConfig(cfg)
+	// Retention is not supported for webdav repos.
+	retentionOpts := ctrlRepo.Retention{}
+
+	webdavCfg, err := cfg.Storage.ToWebDAVConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving webdav configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opt,
+		repository.NewRepoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to construct the repository controller"))
+	}
+
+	ric := repository.InitConfig{RetentionOpts: retentionOpts}
+
+	if err = r.Initialize(ctx, ric); err != nil {
+		if flags.SucceedIfExistsFV && errors.Is(err, repository.ErrorRepoAlreadyExists) {
+			return nil
+		}
+
+		return Only(ctx, clues.Stack(ErrInitializingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Initialized a WebDAV repository at %s.", webdavCfg.URL)
+
+	if err = config.WriteRepoConfig(ctx, webdavCfg, m365, opt.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------
+// Connect
+// ---------------------------------------------------------------------------------------------------------
+
+// `corso repo connect webdav [<flag>...]`
+func webdavConnectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     webdavProviderCommand,
+		Short:   "Connect to a WebDAV repository",
+		Long:    `Ensures a connection to an existing WebDAV repository.`,
+		RunE:    connectWebDAVCmd,
+		Args:    cobra.NoArgs,
+		Example: webdavProviderCommandConnectExamples,
+	}
+}
+
+// connects to an existing webdav repo.
+func connectWebDAVCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.ReadCorsoConfig(
+		ctx,
+		storage.ProviderWebDAV,
+		true,
+		true,
+		flags.WebDAVFlagOverrides(cmd))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	repoID := cfg.RepoID
+	if len(repoID) == 0 {
+		repoID = events.RepoIDNotFound
+	}
+
+	webdavCfg, err := cfg.Storage.ToWebDAVConfig()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Retrieving webdav configuration"))
+	}
+
+	m365, err := cfg.Account.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	opts := utils.ControlWithConfig(cfg)
+
+	r, err := repository.New(
+		ctx,
+		cfg.Account,
+		cfg.Storage,
+		opts,
+		repoID)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to create a repository controller"))
+	}
+
+	if err := r.Connect(ctx, repository.ConnConfig{}); err != nil {
+		return Only(ctx, clues.Stack(ErrConnectingRepo, err))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	Infof(ctx, "Connected to WebDAV repository at %s.", webdavCfg.URL)
+
+	if err = config.WriteRepoConfig(ctx, webdavCfg, m365, opts.Repo, r.GetID()); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write repository configuration"))
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type WebDAVSuite struct {
+	tester.Suite
+}
+
+func TestWebDAVSuite(t *testing.T) {
+	suite.Run(t, &WebDAVSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *WebDAVSuite) TestAddWebDAVCommands() {
+	expectUse := webdavProviderCommand + " " + webdavProviderCommandUseSuffix
+
+	table := []struct {
+		name        string
+		use         string
+		expectUse   string
+		expectShort string
+		expectRunE  func(*cobra.Command, []string) error
+	}{
+		{"init webdav", initCommand, expectUse, webdavInitCmd().Short, initWebDAVCmd},
+		{"connect webdav", connectCommand, expectUse, webdavConnectCmd().Short, connectWebDAVCmd},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			cmd := &cobra.Command{Use: test.use}
+
+			c := addWebDAVCommands(cmd)
+			require.NotNil(t, c)
+
+			cmds := cmd.Commands()
+			require.Len(t, cmds, 1)
+
+			child := cmds[0]
+			assert.Equal(t, test.expectUse, child.Use)
+			assert.Equal(t, test.expectShort, child.Short)
+			tester.AreSameFunc(t, test.expectRunE, child.RunE)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package restore
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+)
+
+// called by restore.go to map subcommands to provider-specific handling.
+func addEntraIDCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case restoreCommand:
+		c, _ = utils.AddCommand(cmd, entraIDRestoreCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + entraIDServiceCommandUseSuffix
+
+		// directory objects are always restored in place, so the
+		// restore config flags don't apply.
+		flags.AddBackupIDFlag(c, true)
+		flags.AddEntraIDDetailsAndRestoreFlags(c)
+		flags.AddFailFastFlag(c)
+	}
+
+	return c
+}
+
+const (
+	entraIDServiceCommand          = "entraid"
+	entraIDServiceCommandUseSuffix = "--backup <backupId>"
+
+	entraIDServiceCommandRestoreExamples = `# Restore every directory object and group membership in the last backup (1234abcd...)
+corso restore entraid --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Restore the group "Marketing" and re-add its missing members
+corso restore entraid --backup 1234abcd-12ab-cd34-56de-1234abcd --group Marketing
+
+# Restore the groups that Adele was a member of
+corso restore entraid --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --object-type groups --member 8f3b2c1d-1a2b-3c4d-5e6f-7a8b9c0d1e2f`
+)
+
+// `corso restore entraid [<flag>...]`
+func entraIDRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     entraIDServiceCommand,
+		Short:   "Restore M365 Entra ID directory objects and group memberships",
+		RunE:    restoreEntraIDCmd,
+		Args:    cobra.NoArgs,
+		Example: entraIDServiceCommandRestoreExamples,
+	}
+}
+
+// processes an entra id restore.
+func restoreEntraIDCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.MakeEntraIDOpts(cmd)
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := utils.ValidateEntraIDRestoreFlags(flags.BackupIDFV, opts); err != nil {
+		return err
+	}
+
+	sel := utils.IncludeEntraIDRestoreDataSelectors(ctx, opts)
+	utils.FilterEntraIDRestoreInfoSelectors(sel, opts)
+
+	return runRestore(
+		ctx,
+		cmd,
+		opts.RestoreCfg,
+		sel.Selector,
+		flags.BackupIDFV,
+		"Entra ID")
+}
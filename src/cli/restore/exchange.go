@@ -43,7 +43,10 @@ corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --event-calendar Calendar
 
 # Restore the contact with ID abdef0101
-corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101`
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101
+
+# Restore only the mailbox settings, inbox rules, automatic replies and categories
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --settings`
 )
 
 // `corso restore exchange [<flag>...]`
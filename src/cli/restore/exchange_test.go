@@ -68,6 +68,7 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 						"--" + flags.EventStartsAfterFN, flagsTD.EventStartsAfterInput,
 						"--" + flags.EventStartsBeforeFN, flagsTD.EventStartsBeforeInput,
 						"--" + flags.EventSubjectFN, flagsTD.EventSubjectInput,
+						"--" + flags.SettingsFN,
 						"--" + flags.CollisionsFN, flagsTD.Collisions,
 						"--" + flags.DestinationFN, flagsTD.Destination,
 						"--" + flags.ToResourceFN, flagsTD.ToResource,
@@ -102,6 +103,7 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			assert.Equal(t, flagsTD.EventStartsAfterInput, opts.EventStartsAfter)
 			assert.Equal(t, flagsTD.EventStartsBeforeInput, opts.EventStartsBefore)
 			assert.Equal(t, flagsTD.EventSubjectInput, opts.EventSubject)
+			assert.True(t, opts.Settings)
 			assert.Equal(t, flagsTD.Collisions, opts.RestoreCfg.Collisions)
 			assert.Equal(t, flagsTD.Destination, opts.RestoreCfg.Destination)
 			assert.Equal(t, flagsTD.ToResource, opts.RestoreCfg.ProtectedResource)
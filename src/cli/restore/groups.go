@@ -6,7 +6,6 @@ import (
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/pkg/dttm"
-	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
 // called by restore.go to map subcommands to provider-specific handling.
@@ -24,6 +23,7 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddSiteIDFlag(c, false)
 		flags.AddNoPermissionsFlag(c)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
+		flags.AddGroupDetailsAndRestoreFlags(c)
 		flags.AddRestoreConfigFlags(c, false)
 		flags.AddFailFastFlag(c)
 	}
@@ -47,7 +47,18 @@ corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --file "FY2021 Pl
 
 # Restore all files and folders in folder "Documents/Finance Reports" that were created before 2020
 corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00`
+    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00
+
+# Restore all messages in the channel "Marketing Announcements"
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --channel "Marketing Announcements"
+
+# Restore all channel messages created after 2023-06-01 into their original channels' names
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --channel '*' --message-created-after 2023-06-01T00:00:00 --destination /
+
+# Restore all posts in conversations about "Quarterly planning"
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --conversation '*' --conversation-topic "Quarterly planning"`
 )
 
 // `corso restore groups [<flag>...]`
@@ -84,10 +95,6 @@ func restoreGroupsCmd(cmd *cobra.Command, args []string) error {
 	sel := utils.IncludeGroupsRestoreDataSelectors(ctx, opts)
 	utils.FilterGroupsRestoreInfoSelectors(sel, opts)
 
-	// TODO(pandeyabs): Exclude conversations from restores since they are not
-	// supported yet.
-	sel.Exclude(sel.Conversation(selectors.Any()))
-
 	return runRestore(
 		ctx,
 		cmd,
@@ -68,6 +68,7 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 						// "--" + flags.ToResourceFN, flagsTD.ToResource,
 						"--" + flags.NoPermissionsFN,
 					},
+					flagsTD.PreparedChannelFlags(),
 					flagsTD.PreparedProviderFlags(),
 					flagsTD.PreparedStorageFlags()))
 
@@ -94,6 +95,7 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 			assert.ElementsMatch(t, flagsTD.ListsInput, opts.Lists)
 			// assert.Equal(t, flagsTD.ToResource, opts.RestoreCfg.ProtectedResource)
 			assert.True(t, flags.NoPermissionsFV)
+			flagsTD.AssertChannelFlags(t, cmd)
 			flagsTD.AssertProviderFlags(t, cmd)
 			flagsTD.AssertStorageFlags(t, cmd)
 		})
@@ -61,6 +61,10 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 						"--" + flags.CollisionsFN, flagsTD.Collisions,
 						"--" + flags.DestinationFN, flagsTD.Destination,
 						"--" + flags.ToResourceFN, flagsTD.ToResource,
+						"--" + flags.ResourceMapFN, flagsTD.ResourceMap,
+						"--" + flags.ToAzureTenantFN, flagsTD.ToAzureTenantID,
+						"--" + flags.ToAzureClientIDFN, flagsTD.ToAzureClientID,
+						"--" + flags.ToAzureClientSecretFN, flagsTD.ToAzureClientSecret,
 						"--" + flags.NoPermissionsFN,
 					},
 					flagsTD.PreparedProviderFlags(),
@@ -86,6 +90,10 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 			assert.Equal(t, flagsTD.Collisions, opts.RestoreCfg.Collisions)
 			assert.Equal(t, flagsTD.Destination, opts.RestoreCfg.Destination)
 			assert.Equal(t, flagsTD.ToResource, opts.RestoreCfg.ProtectedResource)
+			assert.Equal(t, flagsTD.ResourceMap, opts.RestoreCfg.ResourceMap)
+			assert.Equal(t, flagsTD.ToAzureTenantID, opts.RestoreCfg.RestoreTenant.AzureTenantID)
+			assert.Equal(t, flagsTD.ToAzureClientID, opts.RestoreCfg.RestoreTenant.AzureClientID)
+			assert.Equal(t, flagsTD.ToAzureClientSecret, opts.RestoreCfg.RestoreTenant.AzureClientSecret)
 			assert.True(t, flags.NoPermissionsFV)
 			flagsTD.AssertProviderFlags(t, cmd)
 			flagsTD.AssertStorageFlags(t, cmd)
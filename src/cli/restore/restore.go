@@ -11,6 +11,7 @@ import (
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/operations"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
@@ -20,6 +21,7 @@ var restoreCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addOneDriveCommands,
 	addSharePointCommands,
 	addGroupsCommands,
+	addEntraIDCommands,
 }
 
 // AddCommands attaches all `corso restore * *` commands to the parent.
@@ -59,6 +61,16 @@ corso restore exchange \
 	--event abdef0101 \
 	--collisions copy
 
+# Restore a user's OneDrive into another tenant, translating the users and
+# groups its files were shared with.
+corso restore onedrive \
+	--backup 1234abcd-12ab-cd34-56de-1234abcd \
+	--to-resource bruce@newtenant.example.com \
+	--resource-map ./users.csv \
+	--to-azure-tenant-id 2a8f1c3e-0000-0000-0000-000000000000 \
+	--to-azure-client-id 5b9e2d4f-0000-0000-0000-000000000000 \
+	--to-azure-client-secret "$RESTORE_TENANT_SECRET"
+
 # Restore a SharePoint library in-place, replacing any conflicting files.
 corso restore sharepoint \
 	--backup 1234abcd-12ab-cd34-56de-1234abcd \
@@ -107,7 +119,29 @@ func runRestore(
 
 	defer utils.CloseRepo(ctx, r)
 
-	ro, err := r.NewRestore(ctx, backupID, sel, utils.MakeRestoreConfig(ctx, urco))
+	restoreCfg := utils.MakeRestoreConfig(ctx, urco)
+
+	if len(urco.ResourceMap) > 0 {
+		restoreCfg.ResourceMapping, err = utils.ReadResourceMapping(urco.ResourceMap)
+		if err != nil {
+			return Only(ctx, clues.Wrap(err, "Failed to read the resource mapping"))
+		}
+	}
+
+	restoreAcct, toOtherTenant, err := utils.RestoreAccount(urco)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	var ro operations.RestoreOperation
+
+	if toOtherTenant {
+		Infof(ctx, "Restoring to tenant %s", restoreAcct.ID())
+		ro, err = r.NewRestoreToAccount(ctx, backupID, sel, restoreCfg, restoreAcct)
+	} else {
+		ro, err = r.NewRestore(ctx, backupID, sel, restoreCfg)
+	}
+
 	if err != nil {
 		return Only(ctx, clues.Wrap(err, "Failed to initialize "+serviceName+" restore"))
 	}
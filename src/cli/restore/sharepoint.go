@@ -21,6 +21,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddBackupIDFlag(c, true)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
+		flags.AddNotebookDetailsAndRestoreFlags(c)
 		flags.AddNoPermissionsFlag(c)
 		flags.AddRestoreConfigFlags(c, true)
 		flags.AddFailFastFlag(c)
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type ChatsOpts struct {
+	Users    []string
+	Chats    []string
+	Messages []string
+
+	ChatMember           string
+	MessageCreator       string
+	MessageCreatedAfter  string
+	MessageCreatedBefore string
+
+	ExportCfg ExportCfgOpts
+
+	Populated flags.PopulatedFlags
+}
+
+func MakeChatsOpts(cmd *cobra.Command) ChatsOpts {
+	return ChatsOpts{
+		Users:    flags.UserFV,
+		Chats:    flags.ChatFV,
+		Messages: flags.MessageFV,
+
+		ChatMember:           flags.ChatMemberFV,
+		MessageCreator:       flags.MessageCreatorFV,
+		MessageCreatedAfter:  flags.MessageCreatedAfterFV,
+		MessageCreatedBefore: flags.MessageCreatedBeforeFV,
+
+		ExportCfg: makeExportCfgOpts(cmd),
+
+		// populated contains the list of flags that appear in the
+		// command, according to pflags.  Use this to differentiate
+		// between an "empty" and a "missing" value.
+		Populated: flags.GetPopulatedFlags(cmd),
+	}
+}
+
+// ValidateChatsRestoreFlags checks common flags for correctness and interdependencies
+func ValidateChatsRestoreFlags(backupID string, opts ChatsOpts) error {
+	if len(backupID) == 0 {
+		return clues.New("a backup ID is required")
+	}
+
+	if _, ok := opts.Populated[flags.MessageCreatedAfterFN]; ok && !IsValidTimeFormat(opts.MessageCreatedAfter) {
+		return clues.New("invalid time format for " + flags.MessageCreatedAfterFN)
+	}
+
+	if _, ok := opts.Populated[flags.MessageCreatedBeforeFN]; ok && !IsValidTimeFormat(opts.MessageCreatedBefore) {
+		return clues.New("invalid time format for " + flags.MessageCreatedBeforeFN)
+	}
+
+	return nil
+}
+
+// AddChatsFilter adds the scope of the provided values to the selector's
+// filter set
+func AddChatsFilter(
+	sel *selectors.TeamsChatsRestore,
+	v string,
+	f func(string) []selectors.TeamsChatsScope,
+) {
+	if len(v) == 0 {
+		return
+	}
+
+	sel.Filter(f(v))
+}
+
+// IncludeChatsRestoreDataSelectors builds the common data-selector
+// inclusions for chats commands.
+func IncludeChatsRestoreDataSelectors(ctx context.Context, opts ChatsOpts) *selectors.TeamsChatsRestore {
+	var (
+		users       = opts.Users
+		chats, msgs = len(opts.Chats), len(opts.Messages)
+	)
+
+	if len(opts.Users) == 0 {
+		users = selectors.Any()
+	}
+
+	sel := selectors.NewTeamsChatsRestore(users)
+
+	if chats+msgs == 0 {
+		sel.Include(sel.AllData())
+		return sel
+	}
+
+	// if no chat is specified, include all chats
+	if chats == 0 {
+		opts.Chats = selectors.Any()
+	}
+
+	// if no message is specified, only select chats
+	// otherwise, look for chat/message pairs
+	if msgs == 0 {
+		sel.Include(sel.Chats(opts.Chats))
+	} else {
+		sel.Include(sel.ChatMessages(opts.Chats, opts.Messages))
+	}
+
+	return sel
+}
+
+// FilterChatsRestoreInfoSelectors builds the common info-selector filters.
+func FilterChatsRestoreInfoSelectors(
+	sel *selectors.TeamsChatsRestore,
+	opts ChatsOpts,
+) {
+	AddChatsFilter(sel, opts.ChatMember, sel.ChatMember)
+	AddChatsFilter(sel, opts.MessageCreator, sel.MessageCreator)
+	AddChatsFilter(sel, opts.MessageCreatedAfter, sel.MessageCreatedAfter)
+	AddChatsFilter(sel, opts.MessageCreatedBefore, sel.MessageCreatedBefore)
+}
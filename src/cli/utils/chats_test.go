@@ -0,0 +1,137 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+type ChatsUtilsSuite struct {
+	tester.Suite
+}
+
+func TestChatsUtilsSuite(t *testing.T) {
+	suite.Run(t, &ChatsUtilsSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ChatsUtilsSuite) TestIncludeChatsRestoreDataSelectors() {
+	var (
+		single = []string{"single"}
+		multi  = []string{"more", "than", "one"}
+	)
+
+	table := []struct {
+		name             string
+		opts             utils.ChatsOpts
+		expectIncludeLen int
+	}{
+		{
+			name:             "no inputs",
+			opts:             utils.ChatsOpts{},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "multi users",
+			opts: utils.ChatsOpts{
+				Users: multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "chats",
+			opts: utils.ChatsOpts{
+				Chats: multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "messages",
+			opts: utils.ChatsOpts{
+				Messages: single,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "chat messages",
+			opts: utils.ChatsOpts{
+				Chats:    single,
+				Messages: multi,
+			},
+			expectIncludeLen: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := utils.IncludeChatsRestoreDataSelectors(ctx, test.opts)
+			assert.Len(t, sel.Includes, test.expectIncludeLen)
+		})
+	}
+}
+
+func (suite *ChatsUtilsSuite) TestValidateChatsRestoreFlags() {
+	table := []struct {
+		name     string
+		backupID string
+		opts     utils.ChatsOpts
+		expect   assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "no backupID",
+			backupID: "",
+			opts:     utils.ChatsOpts{},
+			expect:   assert.Error,
+		},
+		{
+			name:     "all valid",
+			backupID: "id",
+			opts: utils.ChatsOpts{
+				MessageCreatedAfter:  dttm.Now(),
+				MessageCreatedBefore: dttm.Now(),
+				Populated: flags.PopulatedFlags{
+					flags.MessageCreatedAfterFN:  struct{}{},
+					flags.MessageCreatedBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "invalid message created after",
+			backupID: "id",
+			opts: utils.ChatsOpts{
+				MessageCreatedAfter: "1235",
+				Populated: flags.PopulatedFlags{
+					flags.MessageCreatedAfterFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "invalid message created before",
+			backupID: "id",
+			opts: utils.ChatsOpts{
+				MessageCreatedBefore: "1235",
+				Populated: flags.PopulatedFlags{
+					flags.MessageCreatedBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			test.expect(t, utils.ValidateChatsRestoreFlags(test.backupID, test.opts))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"context"
+	"slices"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// EntraIDObjectTypes are the values accepted by the --object-type flag.
+var EntraIDObjectTypes = []string{
+	details.EntraIDUsersFolder,
+	details.EntraIDGroupsFolder,
+	details.EntraIDApplicationsFolder,
+	details.EntraIDServicePrincipalsFolder,
+}
+
+type EntraIDOpts struct {
+	ObjectTypes       []string
+	Users             []string
+	Groups            []string
+	Applications      []string
+	ServicePrincipals []string
+
+	DisplayName string
+	Member      string
+
+	CompareBackup string
+
+	RestoreCfg RestoreCfgOpts
+	ExportCfg  ExportCfgOpts
+
+	Populated flags.PopulatedFlags
+}
+
+func MakeEntraIDOpts(cmd *cobra.Command) EntraIDOpts {
+	return EntraIDOpts{
+		ObjectTypes:       flags.EntraObjectTypeFV,
+		Users:             flags.EntraUserFV,
+		Groups:            flags.EntraGroupFV,
+		Applications:      flags.EntraApplicationFV,
+		ServicePrincipals: flags.EntraServicePrincipalFV,
+
+		DisplayName: flags.EntraDisplayNameFV,
+		Member:      flags.EntraMemberFV,
+
+		CompareBackup: flags.CompareBackupFV,
+
+		RestoreCfg: makeRestoreCfgOpts(cmd),
+		ExportCfg:  makeExportCfgOpts(cmd),
+
+		// populated contains the list of flags that appear in the
+		// command, according to pflags.  Use this to differentiate
+		// between an "empty" and a "missing" value.
+		Populated: flags.GetPopulatedFlags(cmd),
+	}
+}
+
+// ValidateEntraIDRestoreFlags checks common flags for correctness and interdependencies
+func ValidateEntraIDRestoreFlags(backupID string, opts EntraIDOpts) error {
+	if len(backupID) == 0 {
+		return clues.New("a backup ID is required")
+	}
+
+	for _, ot := range opts.ObjectTypes {
+		if !slices.Contains(EntraIDObjectTypes, ot) {
+			return clues.New("invalid " + flags.EntraObjectTypeFN + ": " + ot)
+		}
+	}
+
+	if opts.CompareBackup == backupID {
+		return clues.New(flags.CompareBackupFN + " must be a different backup")
+	}
+
+	return nil
+}
+
+// AddEntraIDFilter adds the scope of the provided values to the selector's
+// filter set
+func AddEntraIDFilter(
+	sel *selectors.EntraIDRestore,
+	v string,
+	f func(string) []selectors.EntraIDScope,
+) {
+	if len(v) == 0 {
+		return
+	}
+
+	sel.Filter(f(v))
+}
+
+// IncludeEntraIDRestoreDataSelectors builds the common data-selector
+// inclusions for entra id commands.
+func IncludeEntraIDRestoreDataSelectors(ctx context.Context, opts EntraIDOpts) *selectors.EntraIDRestore {
+	sel := selectors.NewEntraIDRestore(selectors.Any())
+
+	lt := len(opts.ObjectTypes)
+	lo := len(opts.Users) + len(opts.Groups) + len(opts.Applications) + len(opts.ServicePrincipals)
+
+	if lt+lo == 0 {
+		sel.Include(sel.AllData())
+		return sel
+	}
+
+	if lt > 0 {
+		sel.Include(sel.ObjectTypes(opts.ObjectTypes))
+	}
+
+	if len(opts.Users) > 0 {
+		sel.Include(sel.Users(opts.Users))
+	}
+
+	if len(opts.Groups) > 0 {
+		sel.Include(sel.Groups(opts.Groups))
+	}
+
+	if len(opts.Applications) > 0 {
+		sel.Include(sel.Applications(opts.Applications))
+	}
+
+	if len(opts.ServicePrincipals) > 0 {
+		sel.Include(sel.ServicePrincipals(opts.ServicePrincipals))
+	}
+
+	return sel
+}
+
+// FilterEntraIDRestoreInfoSelectors builds the common info-selector filters.
+func FilterEntraIDRestoreInfoSelectors(
+	sel *selectors.EntraIDRestore,
+	opts EntraIDOpts,
+) {
+	AddEntraIDFilter(sel, opts.DisplayName, sel.DisplayName)
+	AddEntraIDFilter(sel, opts.Member, sel.Member)
+}
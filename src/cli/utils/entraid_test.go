@@ -0,0 +1,128 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type EntraIDUtilsSuite struct {
+	tester.Suite
+}
+
+func TestEntraIDUtilsSuite(t *testing.T) {
+	suite.Run(t, &EntraIDUtilsSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *EntraIDUtilsSuite) TestIncludeEntraIDRestoreDataSelectors() {
+	var (
+		single = []string{"single"}
+		multi  = []string{"more", "than", "one"}
+	)
+
+	table := []struct {
+		name             string
+		opts             utils.EntraIDOpts
+		expectIncludeLen int
+	}{
+		{
+			name:             "no inputs",
+			opts:             utils.EntraIDOpts{},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "object types",
+			opts: utils.EntraIDOpts{
+				ObjectTypes: []string{"users", "groups"},
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "users",
+			opts: utils.EntraIDOpts{
+				Users: multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "users and groups",
+			opts: utils.EntraIDOpts{
+				Users:  single,
+				Groups: multi,
+			},
+			expectIncludeLen: 2,
+		},
+		{
+			name: "every kind",
+			opts: utils.EntraIDOpts{
+				Users:             single,
+				Groups:            single,
+				Applications:      single,
+				ServicePrincipals: single,
+			},
+			expectIncludeLen: 4,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := utils.IncludeEntraIDRestoreDataSelectors(ctx, test.opts)
+			assert.Len(t, sel.Includes, test.expectIncludeLen)
+		})
+	}
+}
+
+func (suite *EntraIDUtilsSuite) TestValidateEntraIDRestoreFlags() {
+	table := []struct {
+		name     string
+		backupID string
+		opts     utils.EntraIDOpts
+		expect   assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "no backupID",
+			backupID: "",
+			opts:     utils.EntraIDOpts{},
+			expect:   assert.Error,
+		},
+		{
+			name:     "all valid",
+			backupID: "id",
+			opts: utils.EntraIDOpts{
+				ObjectTypes:   []string{"users", "servicePrincipals"},
+				CompareBackup: "other",
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "invalid object type",
+			backupID: "id",
+			opts: utils.EntraIDOpts{
+				ObjectTypes: []string{"devices"},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "compared to itself",
+			backupID: "id",
+			opts: utils.EntraIDOpts{
+				CompareBackup: "id",
+			},
+			expect: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			test.expect(t, utils.ValidateEntraIDRestoreFlags(test.backupID, test.opts))
+		})
+	}
+}
@@ -30,6 +30,8 @@ type ExchangeOpts struct {
 	EventStartsBefore string
 	EventSubject      string
 
+	Settings bool
+
 	RestoreCfg RestoreCfgOpts
 	ExportCfg  ExportCfgOpts
 
@@ -60,6 +62,8 @@ func MakeExchangeOpts(cmd *cobra.Command) ExchangeOpts {
 		EventStartsBefore: flags.EventStartsBeforeFV,
 		EventSubject:      flags.EventSubjectFV,
 
+		Settings: flags.SettingsFV,
+
 		RestoreCfg: makeRestoreCfgOpts(cmd),
 		ExportCfg:  makeExportCfgOpts(cmd),
 
@@ -145,7 +149,8 @@ func ValidateExchangeRestoreFlags(backupID string, opts ExchangeOpts) error {
 }
 
 // IncludeExchangeRestoreDataSelectors builds the common data-selector
-// inclusions for exchange commands.
+// inclusions for exchange commands.  Mailbox settings are only included
+// when the settings flag is provided.
 func IncludeExchangeRestoreDataSelectors(opts ExchangeOpts) *selectors.ExchangeRestore {
 	users := opts.Users
 	if len(users) == 0 {
@@ -154,11 +159,17 @@ func IncludeExchangeRestoreDataSelectors(opts ExchangeOpts) *selectors.ExchangeR
 
 	sel := selectors.NewExchangeRestore(users)
 
-	lc, lcf := len(opts.Contact), len(opts.ContactFolder)
-	le, lef := len(opts.Email), len(opts.EmailFolder)
-	lev, lec := len(opts.Event), len(opts.EventCalendar)
+	if opts.Settings {
+		sel.Include(sel.Settings(selectors.Any()))
+
+		// settings on their own don't imply any other data.
+		if !hasExchangeItemFlags(opts) {
+			return sel
+		}
+	}
+
 	// either scope the request to a set of users
-	if lc+lcf+le+lef+lev+lec == 0 {
+	if !hasExchangeItemFlags(opts) {
 		sel.Include(sel.AllData())
 		return sel
 	}
@@ -173,6 +184,30 @@ func IncludeExchangeRestoreDataSelectors(opts ExchangeOpts) *selectors.ExchangeR
 	return sel
 }
 
+// IncludeExchangeDetailsDataSelectors builds the data-selector inclusions
+// for the details command.  Unlike restores, which only apply mailbox
+// settings on request, details show the settings alongside the rest of
+// the backup unless the selection is narrowed.
+func IncludeExchangeDetailsDataSelectors(opts ExchangeOpts) *selectors.ExchangeRestore {
+	sel := IncludeExchangeRestoreDataSelectors(opts)
+
+	if !opts.Settings && !hasExchangeItemFlags(opts) {
+		sel.Include(sel.Settings(selectors.Any()))
+	}
+
+	return sel
+}
+
+// hasExchangeItemFlags is true if any flag selecting exchange items or
+// their containers is populated.
+func hasExchangeItemFlags(opts ExchangeOpts) bool {
+	lc, lcf := len(opts.Contact), len(opts.ContactFolder)
+	le, lef := len(opts.Email), len(opts.EmailFolder)
+	lev, lec := len(opts.Event), len(opts.EventCalendar)
+
+	return lc+lcf+le+lef+lev+lec > 0
+}
+
 // FilterExchangeRestoreInfoSelectors builds the common info-selector filters.
 func FilterExchangeRestoreInfoSelectors(
 	sel *selectors.ExchangeRestore,
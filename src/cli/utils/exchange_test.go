@@ -302,6 +302,21 @@ func (suite *ExchangeUtilsSuite) TestIncludeExchangeRestoreDataSelectors() {
 			},
 			expectIncludeLen: 1,
 		},
+		{
+			name: "settings only",
+			opts: utils.ExchangeOpts{
+				Settings: true,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "settings and mail",
+			opts: utils.ExchangeOpts{
+				Email:    stub,
+				Settings: true,
+			},
+			expectIncludeLen: 2,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -311,6 +326,41 @@ func (suite *ExchangeUtilsSuite) TestIncludeExchangeRestoreDataSelectors() {
 	}
 }
 
+func (suite *ExchangeUtilsSuite) TestIncludeExchangeDetailsDataSelectors() {
+	stub := []string{"id-stub"}
+
+	table := []struct {
+		name             string
+		opts             utils.ExchangeOpts
+		expectIncludeLen int
+	}{
+		{
+			name:             "no selectors includes settings",
+			expectIncludeLen: 4,
+		},
+		{
+			name: "settings only",
+			opts: utils.ExchangeOpts{
+				Settings: true,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "mail only",
+			opts: utils.ExchangeOpts{
+				Email: stub,
+			},
+			expectIncludeLen: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			sel := utils.IncludeExchangeDetailsDataSelectors(test.opts)
+			assert.Len(suite.T(), sel.Includes, test.expectIncludeLen)
+		})
+	}
+}
+
 func (suite *ExchangeUtilsSuite) TestAddExchangeInclude() {
 	var (
 		empty             = []string{}
@@ -16,12 +16,21 @@ type GroupsOpts struct {
 	Messages      []string
 	Conversations []string
 	Posts         []string
+	Plans         []string
+	Tasks         []string
 
 	MessageCreatedAfter    string
 	MessageCreatedBefore   string
 	MessageLastReplyAfter  string
 	MessageLastReplyBefore string
 
+	ConversationTopic string
+	PostCreatedAfter  string
+	PostCreatedBefore string
+
+	PlannerBucket string
+	TaskTitle     string
+
 	SiteID             []string
 	WebURL             []string
 	Library            string
@@ -63,6 +72,7 @@ func GroupsAllowedCategories() map[string]struct{} {
 		flags.DataLibraries:     {},
 		flags.DataMessages:      {},
 		flags.DataConversations: {},
+		flags.DataPlanner:       {},
 	}
 }
 
@@ -79,6 +89,8 @@ func AddGroupsCategories(sel *selectors.GroupsBackup, cats []string) *selectors.
 			sel.Include(sel.ChannelMessages(selectors.Any(), selectors.Any()))
 		case flags.DataConversations:
 			sel.Include(sel.ConversationPosts(selectors.Any(), selectors.Any()))
+		case flags.DataPlanner:
+			sel.Include(sel.PlannerTasks(selectors.Any(), selectors.Any()))
 		}
 	}
 
@@ -92,6 +104,8 @@ func MakeGroupsOpts(cmd *cobra.Command) GroupsOpts {
 		Messages:      flags.MessageFV,
 		Conversations: flags.ConversationFV,
 		Posts:         flags.PostFV,
+		Plans:         flags.PlanFV,
+		Tasks:         flags.TaskFV,
 		WebURL:        flags.WebURLFV,
 		SiteID:        flags.SiteIDFV,
 
@@ -106,6 +120,11 @@ func MakeGroupsOpts(cmd *cobra.Command) GroupsOpts {
 		MessageCreatedBefore:   flags.MessageCreatedBeforeFV,
 		MessageLastReplyAfter:  flags.MessageLastReplyAfterFV,
 		MessageLastReplyBefore: flags.MessageLastReplyBeforeFV,
+		ConversationTopic:      flags.ConversationTopicFV,
+		PostCreatedAfter:       flags.PostCreatedAfterFV,
+		PostCreatedBefore:      flags.PostCreatedBeforeFV,
+		PlannerBucket:          flags.PlannerBucketFV,
+		TaskTitle:              flags.TaskTitleFV,
 
 		Lists: flags.ListFV,
 
@@ -155,6 +174,14 @@ func ValidateGroupsRestoreFlags(backupID string, opts GroupsOpts, isRestore bool
 		return clues.New("invalid time format for " + flags.MessageLastReplyBeforeFN)
 	}
 
+	if _, ok := opts.Populated[flags.PostCreatedAfterFN]; ok && !IsValidTimeFormat(opts.PostCreatedAfter) {
+		return clues.New("invalid time format for " + flags.PostCreatedAfterFN)
+	}
+
+	if _, ok := opts.Populated[flags.PostCreatedBeforeFN]; ok && !IsValidTimeFormat(opts.PostCreatedBefore) {
+		return clues.New("invalid time format for " + flags.PostCreatedBeforeFN)
+	}
+
 	return validateCommonTimeFlags(opts)
 }
 
@@ -182,6 +209,7 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		pageFolders, pageItems = len(opts.PageFolder), len(opts.Page)
 		chans, chanMsgs        = len(opts.Channels), len(opts.Messages)
 		convs, convPosts       = len(opts.Conversations), len(opts.Posts)
+		plans, tasks           = len(opts.Plans), len(opts.Tasks)
 	)
 
 	if len(opts.Groups) == 0 {
@@ -194,7 +222,8 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		lists+
 		pageFolders+pageItems+
 		chans+chanMsgs+
-		convs+convPosts == 0 {
+		convs+convPosts+
+		plans+tasks == 0 {
 		sel.Include(sel.AllData())
 		return sel
 	}
@@ -267,14 +296,31 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		}
 
 		// if no post is specified, only select conversations;
-		// otherwise, look for channel/message pairs
-		if chanMsgs == 0 {
+		// otherwise, look for conversation/post pairs
+		if convPosts == 0 {
 			sel.Include(sel.Conversation(opts.Conversations))
 		} else {
 			sel.Include(sel.ConversationPosts(opts.Conversations, opts.Posts))
 		}
 	}
 
+	// plan and task selectors
+
+	if plans+tasks > 0 {
+		// if no plan is specified, include all plans
+		if plans == 0 {
+			opts.Plans = selectors.Any()
+		}
+
+		// if no task is specified, only select plans;
+		// otherwise, look for plan/task pairs
+		if tasks == 0 {
+			sel.Include(sel.Plans(opts.Plans))
+		} else {
+			sel.Include(sel.PlannerTasks(opts.Plans, opts.Tasks))
+		}
+	}
+
 	return sel
 }
 
@@ -309,4 +355,9 @@ func FilterGroupsRestoreInfoSelectors(
 	AddGroupsFilter(sel, opts.MessageCreatedBefore, sel.MessageCreatedBefore)
 	AddGroupsFilter(sel, opts.MessageLastReplyAfter, sel.MessageLastReplyAfter)
 	AddGroupsFilter(sel, opts.MessageLastReplyBefore, sel.MessageLastReplyBefore)
+	AddGroupsFilter(sel, opts.ConversationTopic, sel.ConversationTopic)
+	AddGroupsFilter(sel, opts.PostCreatedAfter, sel.PostCreatedAfter)
+	AddGroupsFilter(sel, opts.PostCreatedBefore, sel.PostCreatedBefore)
+	AddGroupsFilter(sel, opts.PlannerBucket, sel.PlannerBucket)
+	AddGroupsFilter(sel, opts.TaskTitle, sel.PlannerTaskTitle)
 }
@@ -43,28 +43,28 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 		{
 			name:             "no inputs",
 			opts:             utils.GroupsOpts{},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "empty",
 			opts: utils.GroupsOpts{
 				Groups: empty,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "single inputs",
 			opts: utils.GroupsOpts{
 				Groups: single,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "multi inputs",
 			opts: utils.GroupsOpts{
 				Groups: multi,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		// sharepoint
 		{
@@ -215,6 +215,32 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 			},
 			expectIncludeLen: 1,
 		},
+		// planner
+		{
+			name: "multiple plans multiple tasks",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Plans:  multi,
+				Tasks:  multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "tasks only",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Tasks:  single,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "single plan only",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Plans:  single,
+			},
+			expectIncludeLen: 1,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -279,6 +305,8 @@ func (suite *GroupsUtilsSuite) TestValidateGroupsRestoreFlags() {
 				MessageCreatedBefore:   dttm.Now(),
 				MessageLastReplyAfter:  dttm.Now(),
 				MessageLastReplyBefore: dttm.Now(),
+				PostCreatedAfter:       dttm.Now(),
+				PostCreatedBefore:      dttm.Now(),
 				Populated: flags.PopulatedFlags{
 					flags.SiteFN:                   struct{}{},
 					flags.FileCreatedAfterFN:       struct{}{},
@@ -289,6 +317,8 @@ func (suite *GroupsUtilsSuite) TestValidateGroupsRestoreFlags() {
 					flags.MessageCreatedBeforeFN:   struct{}{},
 					flags.MessageLastReplyAfterFN:  struct{}{},
 					flags.MessageLastReplyBeforeFN: struct{}{},
+					flags.PostCreatedAfterFN:       struct{}{},
+					flags.PostCreatedBeforeFN:      struct{}{},
 				},
 			},
 			expect: assert.NoError,
@@ -383,6 +413,29 @@ func (suite *GroupsUtilsSuite) TestValidateGroupsRestoreFlags() {
 			},
 			expect: assert.Error,
 		},
+		// conversations
+		{
+			name:     "invalid post created before",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				PostCreatedBefore: "1235",
+				Populated: flags.PopulatedFlags{
+					flags.PostCreatedBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "invalid post created after",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				PostCreatedAfter: "1235",
+				Populated: flags.PopulatedFlags{
+					flags.PostCreatedAfterFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -401,7 +454,7 @@ func (suite *GroupsUtilsSuite) TestAddGroupsCategories() {
 		{
 			name:           "none",
 			cats:           []string{},
-			expectScopeLen: 3,
+			expectScopeLen: 4,
 		},
 		{
 			name:           "libraries",
@@ -418,14 +471,20 @@ func (suite *GroupsUtilsSuite) TestAddGroupsCategories() {
 			cats:           []string{flags.DataConversations},
 			expectScopeLen: 1,
 		},
+		{
+			name:           "planner",
+			cats:           []string{flags.DataPlanner},
+			expectScopeLen: 1,
+		},
 		{
 			name: "all allowed",
 			cats: []string{
 				flags.DataLibraries,
 				flags.DataMessages,
 				flags.DataConversations,
+				flags.DataPlanner,
 			},
-			expectScopeLen: 3,
+			expectScopeLen: 4,
 		},
 		{
 			name:           "bad inputs",
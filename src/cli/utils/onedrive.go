@@ -18,6 +18,9 @@ type OneDriveOpts struct {
 	FileModifiedAfter  string
 	FileModifiedBefore string
 
+	NotebookSection []string
+	NotebookPage    []string
+
 	RestoreCfg RestoreCfgOpts
 	ExportCfg  ExportCfgOpts
 
@@ -35,6 +38,9 @@ func MakeOneDriveOpts(cmd *cobra.Command) OneDriveOpts {
 		FileModifiedAfter:  flags.FileModifiedAfterFV,
 		FileModifiedBefore: flags.FileModifiedBeforeFV,
 
+		NotebookSection: flags.NotebookSectionFV,
+		NotebookPage:    flags.NotebookPageFV,
+
 		RestoreCfg: makeRestoreCfgOpts(cmd),
 		ExportCfg:  makeExportCfgOpts(cmd),
 
@@ -95,28 +101,48 @@ func IncludeOneDriveRestoreDataSelectors(opts OneDriveOpts) *selectors.OneDriveR
 	sel := selectors.NewOneDriveRestore(users)
 
 	lp, ln := len(opts.FolderPath), len(opts.FileName)
+	ns, np := len(opts.NotebookSection), len(opts.NotebookPage)
 
 	// only use the inclusion if either a path or item name
 	// is specified
-	if lp+ln == 0 {
+	if lp+ln+ns+np == 0 {
 		sel.Include(sel.AllData())
 		return sel
 	}
 
-	opts.FolderPath = trimFolderSlash(opts.FolderPath)
+	if lp+ln > 0 {
+		opts.FolderPath = trimFolderSlash(opts.FolderPath)
 
-	if ln == 0 {
-		opts.FileName = selectors.Any()
-	}
+		if ln == 0 {
+			opts.FileName = selectors.Any()
+		}
+
+		containsFolders, prefixFolders := splitFoldersIntoContainsAndPrefix(opts.FolderPath)
 
-	containsFolders, prefixFolders := splitFoldersIntoContainsAndPrefix(opts.FolderPath)
+		if len(containsFolders) > 0 {
+			sel.Include(sel.Items(containsFolders, opts.FileName))
+		}
 
-	if len(containsFolders) > 0 {
-		sel.Include(sel.Items(containsFolders, opts.FileName))
+		if len(prefixFolders) > 0 {
+			sel.Include(sel.Items(prefixFolders, opts.FileName, selectors.PrefixMatch()))
+		}
 	}
 
-	if len(prefixFolders) > 0 {
-		sel.Include(sel.Items(prefixFolders, opts.FileName, selectors.PrefixMatch()))
+	if ns+np > 0 {
+		if np == 0 {
+			opts.NotebookPage = selectors.Any()
+		}
+
+		opts.NotebookSection = trimFolderSlash(opts.NotebookSection)
+		containsSections, prefixSections := splitFoldersIntoContainsAndPrefix(opts.NotebookSection)
+
+		if len(containsSections) > 0 {
+			sel.Include(sel.NotebookPages(containsSections, opts.NotebookPage))
+		}
+
+		if len(prefixSections) > 0 {
+			sel.Include(sel.NotebookPages(prefixSections, opts.NotebookPage, selectors.PrefixMatch()))
+		}
 	}
 
 	return sel
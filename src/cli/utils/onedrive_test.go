@@ -42,7 +42,7 @@ func (suite *OneDriveUtilsSuite) TestIncludeOneDriveRestoreDataSelectors() {
 				FileName:   empty,
 				FolderPath: empty,
 			},
-			expectIncludeLen: 1,
+			expectIncludeLen: 2,
 		},
 		{
 			name: "single inputs",
@@ -98,6 +98,31 @@ func (suite *OneDriveUtilsSuite) TestIncludeOneDriveRestoreDataSelectors() {
 			},
 			expectIncludeLen: 1,
 		},
+		{
+			name: "notebook page",
+			opts: utils.OneDriveOpts{
+				Users:        empty,
+				NotebookPage: single,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "notebook section prefixes and contains",
+			opts: utils.OneDriveOpts{
+				Users:           empty,
+				NotebookSection: containsAndPrefix,
+			},
+			expectIncludeLen: 2,
+		},
+		{
+			name: "files and notebook pages",
+			opts: utils.OneDriveOpts{
+				Users:        empty,
+				FileName:     single,
+				NotebookPage: single,
+			},
+			expectIncludeLen: 2,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
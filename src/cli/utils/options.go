@@ -28,7 +28,12 @@ func Control() control.Options {
 	opt.ToggleFeatures.DisableSlidingWindowLimiter = flags.DisableSlidingWindowLimiterFV
 	opt.ToggleFeatures.DisableLazyItemReader = flags.DisableLazyItemReaderFV
 	opt.ToggleFeatures.ExchangeImmutableIDs = flags.EnableImmutableIDFV
+	opt.ToggleFeatures.ExchangeArchive = flags.IncludeArchiveFV
+	opt.ToggleFeatures.ExchangeRecoverableItems = flags.IncludeRecoverableItemsFV
 	opt.ToggleFeatures.UseOldDeltaProcess = flags.UseOldDeltaProcessFV
+	opt.ToggleFeatures.SearchIndex = flags.SearchIndexFV
+	opt.ToggleFeatures.DetectAnomalies = flags.DetectAnomaliesFV
+	opt.ToggleFeatures.MarkSuspectBackups = flags.MarkSuspectFV
 	opt.Parallelism.ItemFetch = flags.FetchParallelismFV
 
 	return opt
@@ -58,11 +63,16 @@ func ParseBackupOptions() control.BackupConfig {
 	opt.M365.DeltaPageSize = dps
 	opt.M365.DisableDeltaEndpoint = flags.DisableDeltaFV
 	opt.M365.ExchangeImmutableIDs = flags.EnableImmutableIDFV
+	opt.M365.ExchangeArchive = flags.IncludeArchiveFV
+	opt.M365.ExchangeRecoverableItems = flags.IncludeRecoverableItemsFV
 	opt.M365.UseOldDriveDeltaProcess = flags.UseOldDeltaProcessFV
 	opt.ServiceRateLimiter.DisableSlidingWindowLimiter = flags.DisableSlidingWindowLimiterFV
 	opt.Parallelism.ItemFetch = flags.FetchParallelismFV
 	opt.Incrementals.ForceFullEnumeration = flags.DisableIncrementalsFV
 	opt.Incrementals.ForceItemDataRefresh = flags.ForceItemDataDownloadFV
+	opt.SearchIndex = flags.SearchIndexFV
+	opt.DetectAnomalies = flags.DetectAnomaliesFV
+	opt.MarkSuspectBackups = flags.MarkSuspectFV
 
 	return opt
 }
@@ -2,14 +2,22 @@ package utils
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
 	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/credentials"
 	"github.com/alcionai/corso/src/pkg/dttm"
 )
 
@@ -22,6 +30,12 @@ type RestoreCfgOpts struct {
 	DTTMFormat        dttm.TimeFormat
 	ProtectedResource string
 	SkipPermissions   bool
+	// ResourceMap locates a csv or json file that maps the resources of
+	// the backed up tenant to those of the restore tenant.
+	ResourceMap string
+	// RestoreTenant holds the credentials of the tenant receiving the
+	// restore.  Left empty when restoring to the repository's tenant.
+	RestoreTenant account.M365Config
 
 	Populated flags.PopulatedFlags
 }
@@ -33,6 +47,16 @@ func makeRestoreCfgOpts(cmd *cobra.Command) RestoreCfgOpts {
 		DTTMFormat:        dttm.HumanReadable,
 		ProtectedResource: flags.ToResourceFV,
 		SkipPermissions:   flags.NoPermissionsFV,
+		ResourceMap:       flags.ResourceMapFV,
+		RestoreTenant: account.M365Config{
+			M365: credentials.M365{
+				AzureClientID:           flags.ToAzureClientIDFV,
+				AzureClientSecret:       flags.ToAzureClientSecretFV,
+				AzureClientCertPath:     flags.ToAzureClientCertFV,
+				AzureClientCertPassword: flags.ToAzureClientCertPasswordFV,
+			},
+			AzureTenantID: flags.ToAzureTenantFV,
+		},
 
 		// populated contains the list of flags that appear in the
 		// command, according to pflags.  Use this to differentiate
@@ -50,9 +74,88 @@ func ValidateRestoreConfigFlags(opts RestoreCfgOpts) error {
 		return clues.New(fmt.Sprintf("invalid collision policy: %s", flags.CollisionsFN))
 	}
 
+	rt := opts.RestoreTenant
+
+	if len(rt.AzureTenantID) == 0 && len(rt.AzureClientID) > 0 {
+		return clues.New(fmt.Sprintf("--%s requires --%s", flags.ToAzureClientIDFN, flags.ToAzureTenantFN))
+	}
+
+	if len(rt.AzureTenantID) > 0 {
+		if len(rt.AzureClientID) == 0 {
+			return clues.New(fmt.Sprintf("--%s requires --%s", flags.ToAzureTenantFN, flags.ToAzureClientIDFN))
+		}
+
+		if err := rt.M365.Validate(); err != nil {
+			return clues.Wrap(err, "validating restore tenant credentials")
+		}
+	}
+
 	return nil
 }
 
+// RestoreAccount produces the account of the tenant receiving the
+// restore.  Returns false if the restore isn't going to another tenant.
+func RestoreAccount(opts RestoreCfgOpts) (account.Account, bool, error) {
+	if len(opts.RestoreTenant.AzureTenantID) == 0 {
+		return account.Account{}, false, nil
+	}
+
+	acct, err := account.NewAccount(account.ProviderM365, opts.RestoreTenant)
+	if err != nil {
+		return account.Account{}, false, clues.Wrap(err, "building restore tenant account")
+	}
+
+	return acct, true, nil
+}
+
+// ReadResourceMapping reads the mapping of backed up resources to the
+// resources of the restore tenant.  JSON files hold a single object of
+// source-to-target pairs.  Any other file is read as a CSV with two
+// columns, source and target, and an optional header row.
+func ReadResourceMapping(filename string) (control.ResourceMapping, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, clues.Wrap(err, "opening resource mapping file")
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		rm := control.ResourceMapping{}
+
+		if err := json.NewDecoder(f).Decode(&rm); err != nil {
+			return nil, clues.Wrap(err, "decoding json resource mapping")
+		}
+
+		return rm, nil
+	}
+
+	return readCSVResourceMapping(f)
+}
+
+func readCSVResourceMapping(r io.Reader) (control.ResourceMapping, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, clues.Wrap(err, "reading csv resource mapping")
+	}
+
+	rm := make(control.ResourceMapping, len(records))
+
+	for i, rec := range records {
+		if i == 0 && strings.EqualFold(rec[0], "source") && strings.EqualFold(rec[1], "target") {
+			continue
+		}
+
+		rm[rec[0]] = rec[1]
+	}
+
+	return rm, nil
+}
+
 func MakeRestoreConfig(
 	ctx context.Context,
 	opts RestoreCfgOpts,
@@ -1,15 +1,20 @@
 package utils
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/credentials"
 )
 
 type RestoreCfgUnitSuite struct {
@@ -54,6 +59,42 @@ func (suite *RestoreCfgUnitSuite) TestValidateRestoreConfigFlags() {
 			},
 			expect: assert.Error,
 		},
+		{
+			name: "restore tenant",
+			opts: RestoreCfgOpts{
+				RestoreTenant: account.M365Config{
+					M365:          credentials.M365{AzureClientID: "cid", AzureClientSecret: "secret"},
+					AzureTenantID: "tid",
+				},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name: "restore tenant missing client id",
+			opts: RestoreCfgOpts{
+				RestoreTenant: account.M365Config{AzureTenantID: "tid"},
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "restore tenant missing secret",
+			opts: RestoreCfgOpts{
+				RestoreTenant: account.M365Config{
+					M365:          credentials.M365{AzureClientID: "cid"},
+					AzureTenantID: "tid",
+				},
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "restore tenant credentials without tenant",
+			opts: RestoreCfgOpts{
+				RestoreTenant: account.M365Config{
+					M365: credentials.M365{AzureClientID: "cid", AzureClientSecret: "secret"},
+				},
+			},
+			expect: assert.Error,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -159,3 +200,66 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 		})
 	}
 }
+
+func (suite *RestoreCfgUnitSuite) TestReadResourceMapping() {
+	expect := control.ResourceMapping{
+		"bruce@wayne.com": "batman@justice.org",
+		"oid":             "nid",
+	}
+
+	table := []struct {
+		name      string
+		filename  string
+		content   string
+		expect    control.ResourceMapping
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "json",
+			filename:  "map.json",
+			content:   `{"bruce@wayne.com": "batman@justice.org", "oid": "nid"}`,
+			expect:    expect,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "csv",
+			filename:  "map.csv",
+			content:   "bruce@wayne.com,batman@justice.org\noid,nid\n",
+			expect:    expect,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "csv with header and comments",
+			filename:  "map.txt",
+			content:   "source,target\n# users\nbruce@wayne.com, batman@justice.org\noid,nid\n",
+			expect:    expect,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "csv with extra columns",
+			filename:  "map.csv",
+			content:   "oid,nid,extra\n",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "bad json",
+			filename:  "map.json",
+			content:   `["oid", "nid"]`,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			fn := filepath.Join(t.TempDir(), test.filename)
+
+			err := os.WriteFile(fn, []byte(test.content), 0o600)
+			require.NoError(t, err, clues.ToCore(err))
+
+			result, err := ReadResourceMapping(fn)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
@@ -34,6 +34,9 @@ type SharePointOpts struct {
 	PageFolder []string
 	Page       []string
 
+	NotebookSection []string
+	NotebookPage    []string
+
 	RestoreCfg RestoreCfgOpts
 	ExportCfg  ExportCfgOpts
 
@@ -85,6 +88,9 @@ func MakeSharePointOpts(cmd *cobra.Command) SharePointOpts {
 		Page:       flags.PageFV,
 		PageFolder: flags.PageFolderFV,
 
+		NotebookSection: flags.NotebookSectionFV,
+		NotebookPage:    flags.NotebookPageFV,
+
 		RestoreCfg: makeRestoreCfgOpts(cmd),
 		ExportCfg:  makeExportCfgOpts(cmd),
 
@@ -98,6 +104,7 @@ func MakeSharePointOpts(cmd *cobra.Command) SharePointOpts {
 func SharePointAllowedCategories() map[string]struct{} {
 	return map[string]struct{}{
 		flags.DataLibraries: {},
+		flags.DataNotebooks: {},
 		// flags.DataLists:     {}, [TODO]: uncomment when lists are enabled
 	}
 }
@@ -106,7 +113,9 @@ func AddCategories(sel *selectors.SharePointBackup, cats []string) *selectors.Sh
 	if len(cats) == 0 {
 		// backup of sharepoint lists not enabled yet
 		// sel.Include(sel.LibraryFolders(selectors.Any()), sel.Lists(selectors.Any()))
-		sel.Include(sel.LibraryFolders(selectors.Any()))
+		sel.Include(
+			sel.LibraryFolders(selectors.Any()),
+			sel.NotebookSections(selectors.Any()))
 	}
 
 	for _, d := range cats {
@@ -116,6 +125,8 @@ func AddCategories(sel *selectors.SharePointBackup, cats []string) *selectors.Sh
 		// 	sel.Include(sel.Lists(selectors.Any()))
 		case flags.DataLibraries:
 			sel.Include(sel.LibraryFolders(selectors.Any()))
+		case flags.DataNotebooks:
+			sel.Include(sel.NotebookSections(selectors.Any()))
 		}
 	}
 
@@ -163,6 +174,7 @@ func IncludeSharePointRestoreDataSelectors(ctx context.Context, opts SharePointO
 	siteIDs, webUrls := len(opts.SiteID), len(opts.WebURL)
 	lists := len(opts.Lists)
 	pageFolders, pageItems := len(opts.PageFolder), len(opts.Page)
+	notebookSections, notebookPages := len(opts.NotebookSection), len(opts.NotebookPage)
 
 	if siteIDs == 0 {
 		sites = selectors.Any()
@@ -170,7 +182,7 @@ func IncludeSharePointRestoreDataSelectors(ctx context.Context, opts SharePointO
 
 	sel := selectors.NewSharePointRestore(sites)
 
-	if folderPaths+fileNames+webUrls+lists+pageFolders+pageItems == 0 {
+	if folderPaths+fileNames+webUrls+lists+pageFolders+pageItems+notebookSections+notebookPages == 0 {
 		sel.Include(sel.AllData())
 		return sel
 	}
@@ -215,6 +227,23 @@ func IncludeSharePointRestoreDataSelectors(ctx context.Context, opts SharePointO
 		}
 	}
 
+	if notebookSections+notebookPages > 0 {
+		if notebookPages == 0 {
+			opts.NotebookPage = selectors.Any()
+		}
+
+		opts.NotebookSection = trimFolderSlash(opts.NotebookSection)
+		containsSections, prefixSections := splitFoldersIntoContainsAndPrefix(opts.NotebookSection)
+
+		if len(containsSections) > 0 {
+			sel.Include(sel.NotebookPages(containsSections, opts.NotebookPage))
+		}
+
+		if len(prefixSections) > 0 {
+			sel.Include(sel.NotebookPages(prefixSections, opts.NotebookPage, selectors.PrefixMatch()))
+		}
+	}
+
 	if webUrls > 0 {
 		urls := make([]string, 0, len(opts.WebURL))
 
@@ -44,7 +44,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 		{
 			name:             "no inputs",
 			opts:             utils.SharePointOpts{},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "single inputs",
@@ -173,6 +173,21 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 			},
 			expectIncludeLen: 1,
 		},
+		{
+			name: "notebook section",
+			opts: utils.SharePointOpts{
+				NotebookSection: single,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "notebook pages & library files",
+			opts: utils.SharePointOpts{
+				NotebookPage: single,
+				FileName:     multi,
+			},
+			expectIncludeLen: 2,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -441,6 +456,11 @@ func (suite *SharePointUtilsSuite) TestAddSharepointCategories() {
 		// 	},
 		// 	expectScopeLen: 2,
 		// },
+		{
+			name:           "notebooks",
+			cats:           []string{flags.DataNotebooks},
+			expectScopeLen: 1,
+		},
 		{
 			name:           "bad inputs",
 			cats:           []string{"foo"},
@@ -1015,6 +1015,14 @@ func (bg *MockBackupGetter) GetBackupDetails(
 	return nil, nil, fault.New(false).Fail(clues.New("unexpected call to mock"))
 }
 
+func (bg *MockBackupGetter) GetFilteredBackupDetails(
+	ctx context.Context,
+	backupID string,
+	filter details.ChunkFilter,
+) (*details.Details, *backup.Backup, *fault.Bus) {
+	return nil, nil, fault.New(false).Fail(clues.New("unexpected call to mock"))
+}
+
 func (bg *MockBackupGetter) GetBackupErrors(
 	ctx context.Context,
 	backupID string,
@@ -1038,3 +1046,11 @@ func (bg VersionedBackupGetter) GetBackupDetails(
 ) (*details.Details, *backup.Backup, *fault.Bus) {
 	return bg.Details, nil, fault.New(true)
 }
+
+func (bg VersionedBackupGetter) GetFilteredBackupDetails(
+	ctx context.Context,
+	backupID string,
+	filter details.ChunkFilter,
+) (*details.Details, *backup.Backup, *fault.Bus) {
+	return bg.Details, nil, fault.New(true)
+}
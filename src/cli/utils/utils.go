@@ -266,6 +266,14 @@ func GetStorageProviderAndOverrides(
 		return provider, flags.S3FlagOverrides(cmd), nil
 	case storage.ProviderFilesystem:
 		return provider, flags.FilesystemFlagOverrides(cmd), nil
+	case storage.ProviderAzure:
+		return provider, flags.AzureFlagOverrides(cmd), nil
+	case storage.ProviderGCS:
+		return provider, flags.GCSFlagOverrides(cmd), nil
+	case storage.ProviderWebDAV:
+		return provider, flags.WebDAVFlagOverrides(cmd), nil
+	case storage.ProviderSFTP:
+		return provider, flags.SFTPFlagOverrides(cmd), nil
 	}
 
 	return provider, nil, clues.New("unknown storage provider: " + provider.String())
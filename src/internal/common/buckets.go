@@ -12,6 +12,12 @@ func NormalizeBucket(b string) string {
 	return strings.TrimPrefix(b, "s3://")
 }
 
+// NormalizeGCSBucket removes any gs:// url prefixing from a google
+// cloud storage bucket name, leaving only the bucket name.
+func NormalizeGCSBucket(b string) string {
+	return strings.TrimPrefix(b, "gs://")
+}
+
 // NormalizePrefix ensures that a bucket prefix is always treated as
 // object store folder prefix.
 func NormalizePrefix(p string) string {
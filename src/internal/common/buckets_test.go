@@ -29,6 +29,14 @@ func (suite *CommonBucketsSuite) TestBucketPrefix() {
 	}
 }
 
+func (suite *CommonBucketsSuite) TestGCSBucketPrefix() {
+	t := suite.T()
+
+	assert.Equal(t, "fnords", common.NormalizeGCSBucket("gs://fnords"))
+	assert.Equal(t, "smarf", common.NormalizeGCSBucket("smarf"))
+	assert.Equal(t, "s3://smarf", common.NormalizeGCSBucket("s3://smarf"))
+}
+
 func (suite *CommonBucketsSuite) TestPrefixSuffix() {
 	t := suite.T()
 
@@ -203,83 +203,10 @@ func FromJSON(ctx context.Context, body []byte) (string, error) {
 		email.SetDate(ptr.Val(data.GetSentDateTime()).Format(dateFormat))
 	}
 
-	if data.GetBody() != nil {
-		if data.GetBody().GetContentType() != nil {
-			var contentType mail.ContentType
-
-			switch data.GetBody().GetContentType().String() {
-			case "html":
-				contentType = mail.TextHTML
-			case "text":
-				contentType = mail.TextPlain
-			default:
-				// https://learn.microsoft.com/en-us/graph/api/resources/itembody?view=graph-rest-1.0#properties
-				// This should not be possible according to the documentation
-				logger.Ctx(ctx).
-					With("body_type", data.GetBody().GetContentType().String()).
-					Info("unknown body content type")
-
-				contentType = mail.TextPlain
-			}
+	setBody(ctx, email, data.GetBody())
 
-			email.SetBody(contentType, ptr.Val(data.GetBody().GetContent()))
-		}
-	}
-
-	if data.GetAttachments() != nil {
-		for _, attachment := range data.GetAttachments() {
-			kind := ptr.Val(attachment.GetContentType())
-
-			bytes, err := attachment.GetBackingStore().Get("contentBytes")
-			if err != nil {
-				return "", clues.WrapWC(ctx, err, "failed to get attachment bytes").
-					With("kind", kind)
-			}
-
-			if bytes == nil {
-				// Some attachments have an "item" field instead of
-				// "contentBytes". There are items like contacts, emails
-				// or calendar events which will not be a normal format
-				// and will have to be converted to a text format.
-				// TODO(meain): Handle custom attachments
-				// https://github.com/alcionai/corso/issues/4772
-				logger.Ctx(ctx).
-					With("attachment_id", ptr.Val(attachment.GetId())).
-					Info("unhandled attachment type")
-
-				continue
-			}
-
-			bts, ok := bytes.([]byte)
-			if !ok {
-				return "", clues.WrapWC(ctx, err, "invalid content bytes").
-					With("kind", kind).
-					With("interface_type", fmt.Sprintf("%T", bytes))
-			}
-
-			name := ptr.Val(attachment.GetName())
-
-			contentID, err := attachment.GetBackingStore().Get("contentId")
-			if err != nil {
-				return "", clues.WrapWC(ctx, err, "getting content id for attachment").
-					With("kind", kind)
-			}
-
-			if contentID != nil {
-				cids, _ := str.AnyToString(contentID)
-				if len(cids) > 0 {
-					name = cids
-				}
-			}
-
-			email.Attach(&mail.File{
-				// cannot use filename as inline attachment will not get mapped properly
-				Name:     name,
-				MimeType: kind,
-				Data:     bts,
-				Inline:   ptr.Val(attachment.GetIsInline()),
-			})
-		}
+	if err := addAttachments(ctx, email, data.GetAttachments()); err != nil {
+		return "", clues.Stack(err)
 	}
 
 	switch data.(type) {
@@ -306,3 +233,147 @@ func FromJSON(ctx context.Context, body []byte) (string, error) {
 
 	return email.GetMessage(), nil
 }
+
+// FromJSONPost converts a Postable (as json) from a group conversation to
+// .eml format.  Posts don't hold the conversation's topic or the group's
+// address, so callers need to provide both.
+func FromJSONPost(
+	ctx context.Context,
+	body []byte,
+	topic string,
+	recipients []string,
+) (string, error) {
+	ctx = clues.Add(ctx, "body_len", len(body))
+
+	data, err := api.BytesToPostable(body)
+	if err != nil {
+		return "", clues.WrapWC(ctx, err, "converting to postable")
+	}
+
+	ctx = clues.Add(ctx, "item_id", ptr.Val(data.GetId()))
+
+	email := mail.NewMSG()
+	email.Encoding = mail.EncodingBase64
+	email.AllowDuplicateAddress = true
+	email.AllowEmptyAttachments = true
+	email.UseProvidedAddress = true
+
+	if data.GetFrom() != nil {
+		email.SetFrom(formatAddress(data.GetFrom().GetEmailAddress()))
+	} else if data.GetSender() != nil {
+		email.SetFrom(formatAddress(data.GetSender().GetEmailAddress()))
+	}
+
+	for _, r := range recipients {
+		email.AddTo(r)
+	}
+
+	for _, r := range data.GetNewParticipants() {
+		email.AddCc(formatAddress(r.GetEmailAddress()))
+	}
+
+	email.SetSubject(topic)
+
+	if data.GetCreatedDateTime() != nil {
+		email.SetDate(ptr.Val(data.GetCreatedDateTime()).Format(dateFormat))
+	}
+
+	setBody(ctx, email, data.GetBody())
+
+	if err := addAttachments(ctx, email, data.GetAttachments()); err != nil {
+		return "", clues.Stack(err)
+	}
+
+	if err = email.GetError(); err != nil {
+		return "", clues.WrapWC(ctx, err, "converting to eml")
+	}
+
+	return email.GetMessage(), nil
+}
+
+func setBody(ctx context.Context, email *mail.Email, body models.ItemBodyable) {
+	if body == nil || body.GetContentType() == nil {
+		return
+	}
+
+	var contentType mail.ContentType
+
+	switch body.GetContentType().String() {
+	case "html":
+		contentType = mail.TextHTML
+	case "text":
+		contentType = mail.TextPlain
+	default:
+		// https://learn.microsoft.com/en-us/graph/api/resources/itembody?view=graph-rest-1.0#properties
+		// This should not be possible according to the documentation
+		logger.Ctx(ctx).
+			With("body_type", body.GetContentType().String()).
+			Info("unknown body content type")
+
+		contentType = mail.TextPlain
+	}
+
+	email.SetBody(contentType, ptr.Val(body.GetContent()))
+}
+
+func addAttachments(
+	ctx context.Context,
+	email *mail.Email,
+	attachments []models.Attachmentable,
+) error {
+	for _, attachment := range attachments {
+		kind := ptr.Val(attachment.GetContentType())
+
+		bytes, err := attachment.GetBackingStore().Get("contentBytes")
+		if err != nil {
+			return clues.WrapWC(ctx, err, "failed to get attachment bytes").
+				With("kind", kind)
+		}
+
+		if bytes == nil {
+			// Some attachments have an "item" field instead of
+			// "contentBytes". There are items like contacts, emails
+			// or calendar events which will not be a normal format
+			// and will have to be converted to a text format.
+			// TODO(meain): Handle custom attachments
+			// https://github.com/alcionai/corso/issues/4772
+			logger.Ctx(ctx).
+				With("attachment_id", ptr.Val(attachment.GetId())).
+				Info("unhandled attachment type")
+
+			continue
+		}
+
+		bts, ok := bytes.([]byte)
+		if !ok {
+			return clues.NewWC(ctx, "invalid content bytes").
+				With("kind", kind).
+				With("interface_type", fmt.Sprintf("%T", bytes))
+		}
+
+		name := ptr.Val(attachment.GetName())
+
+		contentID, err := attachment.GetBackingStore().Get("contentId")
+		if err != nil {
+			return clues.WrapWC(ctx, err, "getting content id for attachment").
+				With("kind", kind)
+		}
+
+		if contentID != nil {
+			cids, _ := str.AnyToString(contentID)
+			if len(cids) > 0 {
+				name = cids
+			}
+		}
+
+		email.Attach(&mail.File{
+			// cannot use filename as inline attachment will not get mapped properly
+			Name:     name,
+			MimeType: kind,
+			Data:     bts,
+			Inline:   ptr.Val(attachment.GetIsInline()),
+		})
+	}
+
+	return nil
+}
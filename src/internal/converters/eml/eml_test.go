@@ -325,3 +325,39 @@ func (suite *EMLUnitSuite) TestConvert_eml_ics_from_event_obj() {
 	assert.NotEqual(t, ptr.Val(msg.GetSubject()), event.GetProperty(ical.ComponentPropertySummary).Value)
 	assert.Equal(t, ptr.Val(evt.GetSubject()), event.GetProperty(ical.ComponentPropertySummary).Value)
 }
+
+func (suite *EMLUnitSuite) TestConvert_postable_to_eml() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		body       = []byte(testdata.GroupConversationPost)
+		topic      = "Quarterly planning"
+		recipients = []string{"marketing@contoso.onmicrosoft.com"}
+	)
+
+	out, err := FromJSONPost(ctx, body, topic, recipients)
+	require.NoError(t, err, "converting to eml")
+
+	post, err := api.BytesToPostable(body)
+	require.NoError(t, err, "creating post")
+
+	eml, err := enmime.ReadEnvelope(strings.NewReader(out))
+	require.NoError(t, err, "reading created eml")
+
+	assert.Equal(t, topic, eml.GetHeader("Subject"))
+	assert.Equal(t, post.GetCreatedDateTime().Format(time.RFC1123Z), eml.GetHeader("Date"))
+	assert.Equal(t, formatAddress(post.GetFrom().GetEmailAddress()), eml.GetHeader("From"))
+	assert.Equal(t, recipients[0], eml.GetHeader("To"))
+	assert.Equal(
+		t,
+		formatAddress(post.GetNewParticipants()[0].GetEmailAddress()),
+		eml.GetHeader("Cc"))
+	assert.Contains(t, eml.HTML, "Here are the numbers for the quarterly planning.")
+
+	require.Len(t, eml.Attachments, 1)
+	assert.Equal(t, "numbers.txt", eml.Attachments[0].FileName)
+	assert.Equal(t, "quarterly numbers\n", string(eml.Attachments[0].Content))
+}
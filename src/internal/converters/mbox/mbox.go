@@ -0,0 +1,98 @@
+package mbox
+
+// This package writes rfc822 messages into the mboxrd format, which
+// concatenates messages into a single file per folder.
+
+// Ref: https://datatracker.ietf.org/doc/html/rfc4155
+// mboxrd quoting: https://www.loc.gov/preservation/digital/formats/fdd/fdd000385.shtml
+
+import (
+	"bufio"
+	"io"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+)
+
+const (
+	// asctime, as used by the From_ separator line.
+	separatorDateFormat = "Mon Jan _2 15:04:05 2006"
+	// used when the message has no parsable sender.
+	defaultSender = "MAILER-DAEMON"
+)
+
+// fromLine matches lines which need quoting: "From " preceded by any
+// number of '>'.  In mboxrd, quoting adds another '>', which makes it
+// reversible.
+var fromLine = regexp.MustCompile(`^>*From `)
+
+type Writer struct {
+	w *bufio.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// WriteMessage appends an rfc822 message (such as the output of
+// eml.FromJSON) to the mbox.
+func (w *Writer) WriteMessage(msg string) error {
+	sender, date := envelope(msg)
+
+	if _, err := w.w.WriteString("From " + sender + " " + date.UTC().Format(separatorDateFormat) + "\n"); err != nil {
+		return clues.Wrap(err, "writing separator")
+	}
+
+	msg = strings.ReplaceAll(msg, "\r\n", "\n")
+	msg = strings.TrimSuffix(msg, "\n")
+
+	for _, line := range strings.Split(msg, "\n") {
+		if fromLine.MatchString(line) {
+			line = ">" + line
+		}
+
+		if _, err := w.w.WriteString(line + "\n"); err != nil {
+			return clues.Wrap(err, "writing message")
+		}
+	}
+
+	// messages are separated by an empty line.
+	_, err := w.w.WriteString("\n")
+
+	return clues.Wrap(err, "writing message").OrNil()
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *Writer) Flush() error {
+	return clues.Wrap(w.w.Flush(), "flushing mbox").OrNil()
+}
+
+// envelope extracts the sender address and date used in the separator
+// line, falling back to defaults if the headers can't be parsed.
+func envelope(msg string) (string, time.Time) {
+	var (
+		sender = defaultSender
+		date   = time.Unix(0, 0)
+	)
+
+	m, err := mail.ReadMessage(strings.NewReader(msg))
+	if err != nil {
+		return sender, date
+	}
+
+	if addrs, err := m.Header.AddressList("From"); err == nil && len(addrs) > 0 {
+		// the separator is space delimited, so the address can't hold any.
+		if a := strings.ReplaceAll(addrs[0].Address, " ", ""); len(a) > 0 {
+			sender = a
+		}
+	}
+
+	if d, err := m.Header.Date(); err == nil {
+		date = d
+	}
+
+	return sender, date
+}
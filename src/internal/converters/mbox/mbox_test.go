@@ -0,0 +1,99 @@
+package mbox
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type MBOXUnitSuite struct {
+	tester.Suite
+}
+
+func TestMBOXUnitSuite(t *testing.T) {
+	suite.Run(t, &MBOXUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *MBOXUnitSuite) TestWriteMessage() {
+	table := []struct {
+		name   string
+		input  []string
+		expect string
+	}{
+		{
+			name: "single message",
+			input: []string{
+				"From: \"Alice\" <alice@example.com>\r\n" +
+					"Date: Thu, 16 Nov 2023 05:42:46 +0000\r\n" +
+					"Subject: hi\r\n" +
+					"\r\n" +
+					"hello\r\n",
+			},
+			expect: "From alice@example.com Thu Nov 16 05:42:46 2023\n" +
+				"From: \"Alice\" <alice@example.com>\n" +
+				"Date: Thu, 16 Nov 2023 05:42:46 +0000\n" +
+				"Subject: hi\n" +
+				"\n" +
+				"hello\n" +
+				"\n",
+		},
+		{
+			name: "quoted from lines",
+			input: []string{
+				"From: bob@example.com\r\n" +
+					"Date: Fri, 1 Dec 2023 10:00:00 +0100\r\n" +
+					"\r\n" +
+					"From here\r\n" +
+					">From there\r\n" +
+					"Fromage",
+			},
+			expect: "From bob@example.com Fri Dec  1 09:00:00 2023\n" +
+				"From: bob@example.com\n" +
+				"Date: Fri, 1 Dec 2023 10:00:00 +0100\n" +
+				"\n" +
+				">From here\n" +
+				">>From there\n" +
+				"Fromage\n" +
+				"\n",
+		},
+		{
+			name: "unparsable headers",
+			input: []string{
+				"not a message",
+				"Subject: no sender\r\n\r\nbody",
+			},
+			expect: "From MAILER-DAEMON Thu Jan  1 00:00:00 1970\n" +
+				"not a message\n" +
+				"\n" +
+				"From MAILER-DAEMON Thu Jan  1 00:00:00 1970\n" +
+				"Subject: no sender\n" +
+				"\n" +
+				"body\n" +
+				"\n",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			buf := &bytes.Buffer{}
+			w := NewWriter(buf)
+
+			for _, msg := range test.input {
+				err := w.WriteMessage(msg)
+				require.NoError(t, err, clues.ToCore(err))
+			}
+
+			err := w.Flush()
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expect, buf.String())
+		})
+	}
+}
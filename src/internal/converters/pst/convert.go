@@ -0,0 +1,302 @@
+package pst
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/internal/converters/ics"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// This file converts the json responses received from Graph API into the
+// items accepted by the writer.
+
+func toAddress(entry models.EmailAddressable) Address {
+	if entry == nil {
+		return Address{}
+	}
+
+	return Address{
+		Name:  ptr.Val(entry.GetName()),
+		Email: ptr.Val(entry.GetAddress()),
+	}
+}
+
+func toAddresses(recipients []models.Recipientable) []Address {
+	result := make([]Address, 0, len(recipients))
+
+	for _, r := range recipients {
+		result = append(result, toAddress(r.GetEmailAddress()))
+	}
+
+	return result
+}
+
+func toPostalAddress(addr models.PhysicalAddressable) PostalAddress {
+	if addr == nil {
+		return PostalAddress{}
+	}
+
+	return PostalAddress{
+		Street:     ptr.Val(addr.GetStreet()),
+		City:       ptr.Val(addr.GetCity()),
+		State:      ptr.Val(addr.GetState()),
+		PostalCode: ptr.Val(addr.GetPostalCode()),
+		Country:    ptr.Val(addr.GetCountryOrRegion()),
+	}
+}
+
+func toImportance(i *models.Importance) Importance {
+	if i == nil {
+		return ImportanceNormal
+	}
+
+	switch i.String() {
+	case "low":
+		return ImportanceLow
+	case "high":
+		return ImportanceHigh
+	default:
+		return ImportanceNormal
+	}
+}
+
+// toBodies splits the item body into its plain text or html form.
+func toBodies(body models.ItemBodyable) (string, string) {
+	if body == nil {
+		return "", ""
+	}
+
+	content := ptr.Val(body.GetContent())
+
+	if ptr.Val(body.GetContentType()) == models.HTML_BODYTYPE {
+		return "", content
+	}
+
+	return content, ""
+}
+
+func toAttachments(
+	ctx context.Context,
+	attachments []models.Attachmentable,
+) ([]Attachment, error) {
+	result := make([]Attachment, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		kind := ptr.Val(attachment.GetContentType())
+
+		content, err := attachment.GetBackingStore().Get("contentBytes")
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "getting attachment bytes").
+				With("kind", kind)
+		}
+
+		if content == nil {
+			// item and reference attachments have no content bytes.
+			// TODO: convert item attachments into embedded messages.
+			logger.Ctx(ctx).
+				With("attachment_id", ptr.Val(attachment.GetId())).
+				Info("unhandled attachment type")
+
+			continue
+		}
+
+		bts, ok := content.([]byte)
+		if !ok {
+			return nil, clues.NewWC(ctx, "invalid content bytes").
+				With("kind", kind).
+				With("interface_type", fmt.Sprintf("%T", content))
+		}
+
+		cidv, err := attachment.GetBackingStore().Get("contentId")
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "getting attachment content id").
+				With("kind", kind)
+		}
+
+		// a missing content id is fine; only inline attachments need one.
+		cid, _ := str.AnyToString(cidv)
+
+		result = append(result, Attachment{
+			Name:        ptr.Val(attachment.GetName()),
+			ContentType: kind,
+			ContentID:   cid,
+			Inline:      ptr.Val(attachment.GetIsInline()),
+			Content:     bts,
+		})
+	}
+
+	return result, nil
+}
+
+// MessageFromJSON converts a Messageable (as json) into a pst message.
+func MessageFromJSON(ctx context.Context, body []byte) (Message, error) {
+	ctx = clues.Add(ctx, "body_len", len(body))
+
+	data, err := api.BytesToMessageable(body)
+	if err != nil {
+		return Message{}, clues.WrapWC(ctx, err, "converting to messageable")
+	}
+
+	ctx = clues.Add(ctx, "item_id", ptr.Val(data.GetId()))
+
+	attachments, err := toAttachments(ctx, data.GetAttachments())
+	if err != nil {
+		return Message{}, clues.Stack(err)
+	}
+
+	plain, html := toBodies(data.GetBody())
+
+	msg := Message{
+		InternetMessageID: ptr.Val(data.GetInternetMessageId()),
+		Subject:           ptr.Val(data.GetSubject()),
+		To:                toAddresses(data.GetToRecipients()),
+		Cc:                toAddresses(data.GetCcRecipients()),
+		Bcc:               toAddresses(data.GetBccRecipients()),
+		Created:           ptr.Val(data.GetCreatedDateTime()),
+		Modified:          ptr.Val(data.GetLastModifiedDateTime()),
+		Sent:              ptr.Val(data.GetSentDateTime()),
+		Received:          ptr.Val(data.GetReceivedDateTime()),
+		Importance:        toImportance(data.GetImportance()),
+		Read:              ptr.Val(data.GetIsRead()),
+		Draft:             ptr.Val(data.GetIsDraft()),
+		Body:              plain,
+		HTMLBody:          html,
+		Attachments:       attachments,
+	}
+
+	if data.GetFrom() != nil {
+		msg.From = toAddress(data.GetFrom().GetEmailAddress())
+	}
+
+	if data.GetSender() != nil {
+		msg.Sender = toAddress(data.GetSender().GetEmailAddress())
+	}
+
+	return msg, nil
+}
+
+// ContactFromJSON converts a Contactable (as json) into a pst contact.
+func ContactFromJSON(ctx context.Context, body []byte) (Contact, error) {
+	data, err := api.BytesToContactable(body)
+	if err != nil {
+		return Contact{}, clues.WrapWC(ctx, err, "converting to contactable").
+			With("body_len", len(body))
+	}
+
+	contact := Contact{
+		DisplayName:     ptr.Val(data.GetDisplayName()),
+		GivenName:       ptr.Val(data.GetGivenName()),
+		MiddleName:      ptr.Val(data.GetMiddleName()),
+		Surname:         ptr.Val(data.GetSurname()),
+		NickName:        ptr.Val(data.GetNickName()),
+		CompanyName:     ptr.Val(data.GetCompanyName()),
+		JobTitle:        ptr.Val(data.GetJobTitle()),
+		Department:      ptr.Val(data.GetDepartment()),
+		BusinessPhones:  data.GetBusinessPhones(),
+		HomePhones:      data.GetHomePhones(),
+		MobilePhone:     ptr.Val(data.GetMobilePhone()),
+		BusinessAddress: toPostalAddress(data.GetBusinessAddress()),
+		HomeAddress:     toPostalAddress(data.GetHomeAddress()),
+		Birthday:        ptr.Val(data.GetBirthday()),
+		Notes:           ptr.Val(data.GetPersonalNotes()),
+		Created:         ptr.Val(data.GetCreatedDateTime()),
+		Modified:        ptr.Val(data.GetLastModifiedDateTime()),
+	}
+
+	for _, e := range data.GetEmailAddresses() {
+		contact.Emails = append(contact.Emails, toAddress(e))
+	}
+
+	return contact, nil
+}
+
+// AppointmentFromJSON converts an Eventable (as json) into a pst
+// appointment.  Recurrences are not carried over; each instance which
+// was backed up is exported as its own appointment.
+func AppointmentFromJSON(ctx context.Context, body []byte) (Appointment, error) {
+	data, err := api.BytesToEventable(body)
+	if err != nil {
+		return Appointment{}, clues.WrapWC(ctx, err, "converting to eventable").
+			With("body_len", len(body))
+	}
+
+	ctx = clues.Add(ctx, "item_id", ptr.Val(data.GetId()))
+
+	attachments, err := toAttachments(ctx, data.GetAttachments())
+	if err != nil {
+		return Appointment{}, clues.Stack(err)
+	}
+
+	plain, html := toBodies(data.GetBody())
+
+	appt := Appointment{
+		Subject:     ptr.Val(data.GetSubject()),
+		AllDay:      ptr.Val(data.GetIsAllDay()),
+		BusyStatus:  toBusyStatus(data.GetShowAs()),
+		Importance:  toImportance(data.GetImportance()),
+		Body:        plain,
+		HTMLBody:    html,
+		Created:     ptr.Val(data.GetCreatedDateTime()),
+		Modified:    ptr.Val(data.GetLastModifiedDateTime()),
+		Attachments: attachments,
+	}
+
+	if data.GetLocation() != nil {
+		appt.Location = ptr.Val(data.GetLocation().GetDisplayName())
+	}
+
+	if data.GetOrganizer() != nil {
+		appt.Organizer = toAddress(data.GetOrganizer().GetEmailAddress())
+	}
+
+	if start := data.GetStart(); start != nil && start.GetDateTime() != nil {
+		appt.Start, err = ics.GetUTCTime(ptr.Val(start.GetDateTime()), ptr.Val(start.GetTimeZone()))
+		if err != nil {
+			return Appointment{}, clues.WrapWC(ctx, err, "parsing start time")
+		}
+	}
+
+	if end := data.GetEnd(); end != nil && end.GetDateTime() != nil {
+		appt.End, err = ics.GetUTCTime(ptr.Val(end.GetDateTime()), ptr.Val(end.GetTimeZone()))
+		if err != nil {
+			return Appointment{}, clues.WrapWC(ctx, err, "parsing end time")
+		}
+	}
+
+	for _, attendee := range data.GetAttendees() {
+		addr := toAddress(attendee.GetEmailAddress())
+
+		switch ptr.Val(attendee.GetTypeEscaped()) {
+		case models.OPTIONAL_ATTENDEETYPE:
+			appt.Optional = append(appt.Optional, addr)
+		case models.RESOURCE_ATTENDEETYPE:
+			appt.Resources = append(appt.Resources, addr)
+		default:
+			appt.Required = append(appt.Required, addr)
+		}
+	}
+
+	return appt, nil
+}
+
+func toBusyStatus(showAs *models.FreeBusyStatus) BusyStatus {
+	switch ptr.Val(showAs).String() {
+	case "tentative":
+		return BusyStatusTentative
+	case "busy":
+		return BusyStatusBusy
+	case "oof":
+		return BusyStatusOutOfOffice
+	case "workingElsewhere":
+		return BusyStatusWorkingElsewhere
+	default:
+		return BusyStatusFree
+	}
+}
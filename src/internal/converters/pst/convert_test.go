@@ -0,0 +1,131 @@
+package pst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	emlTD "github.com/alcionai/corso/src/internal/converters/eml/testdata"
+	vcfTD "github.com/alcionai/corso/src/internal/converters/vcf/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ConvertUnitSuite struct {
+	tester.Suite
+}
+
+func TestConvertUnitSuite(t *testing.T) {
+	suite.Run(t, &ConvertUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ConvertUnitSuite) TestMessageFromJSON() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	msg, err := MessageFromJSON(ctx, []byte(emlTD.EmailWithAttachments))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "Mail with everything", msg.Subject)
+	assert.Equal(t, "JohannaL@test-tenant.onmicrosoft.com", msg.From.Email)
+	assert.Equal(t, "Johanna Lorenz", msg.Sender.Name)
+	assert.Len(t, msg.To, 1)
+	assert.Len(t, msg.Cc, 2)
+	assert.Len(t, msg.Bcc, 2)
+	assert.Equal(t, time.Date(2023, 11, 16, 5, 42, 46, 0, time.UTC), msg.Sent.UTC())
+	assert.True(t, msg.Read)
+	assert.Empty(t, msg.Body)
+	assert.Contains(t, msg.HTMLBody, "<html")
+	assert.Equal(t, ImportanceNormal, msg.Importance)
+
+	require.Len(t, msg.Attachments, 2)
+	assert.True(t, msg.Attachments[0].Inline)
+	assert.Equal(t, "image/jpeg", msg.Attachments[0].ContentType)
+	assert.NotEmpty(t, msg.Attachments[0].Content)
+	assert.Equal(t, "qt.conf", msg.Attachments[1].Name)
+}
+
+func (suite *ConvertUnitSuite) TestContactFromJSON() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	contact, err := ContactFromJSON(ctx, []byte(vcfTD.ContactsInput))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "Random", contact.GivenName)
+	assert.Equal(t, "Person", contact.Surname)
+	assert.Equal(t, "00000111111", contact.MobilePhone)
+	require.Len(t, contact.Emails, 2)
+	assert.Equal(t, "mockemail@provider.com", contact.Emails[0].Email)
+}
+
+func (suite *ConvertUnitSuite) TestAppointmentFromJSON() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	e := models.NewEvent()
+	e.SetId(ptr.To("mango"))
+	e.SetSubject(ptr.To("Subject"))
+
+	start := models.NewDateTimeTimeZone()
+	start.SetDateTime(ptr.To("2021-01-01T12:00:00.0000000"))
+	start.SetTimeZone(ptr.To("Pacific Standard Time"))
+	e.SetStart(start)
+
+	end := models.NewDateTimeTimeZone()
+	end.SetDateTime(ptr.To("2021-01-01T13:00:00.0000000"))
+	end.SetTimeZone(ptr.To("Pacific Standard Time"))
+	e.SetEnd(end)
+
+	showAs := models.OOF_FREEBUSYSTATUS
+	e.SetShowAs(&showAs)
+
+	attendee := func(addr string, typ models.AttendeeType) models.Attendeeable {
+		a := models.NewAttendee()
+		a.SetTypeEscaped(&typ)
+
+		ea := models.NewEmailAddress()
+		ea.SetAddress(ptr.To(addr))
+		a.SetEmailAddress(ea)
+
+		return a
+	}
+
+	e.SetAttendees([]models.Attendeeable{
+		attendee("req@example.com", models.REQUIRED_ATTENDEETYPE),
+		attendee("opt@example.com", models.OPTIONAL_ATTENDEETYPE),
+		attendee("room@example.com", models.RESOURCE_ATTENDEETYPE),
+	})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", e)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bts, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	appt, err := AppointmentFromJSON(ctx, bts)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "Subject", appt.Subject)
+	assert.Equal(t, time.Date(2021, 1, 1, 20, 0, 0, 0, time.UTC), appt.Start)
+	assert.Equal(t, time.Hour, appt.End.Sub(appt.Start))
+	assert.Equal(t, BusyStatusOutOfOffice, appt.BusyStatus)
+	assert.Equal(t, []Address{{Email: "req@example.com"}}, appt.Required)
+	assert.Equal(t, []Address{{Email: "opt@example.com"}}, appt.Optional)
+	assert.Equal(t, []Address{{Email: "room@example.com"}}, appt.Resources)
+}
@@ -0,0 +1,509 @@
+package pst
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/alcionai/clues"
+)
+
+// This file holds the lists, tables and properties (LTP) layer: the heap
+// on node, btree on heap, property context and table context structures.
+
+const (
+	hnSignature = 0xEC
+
+	hnClientBTH = 0xB5
+	hnClientPC  = 0xBC
+	hnClientTC  = 0x7C
+
+	// maxHeapAlloc is the largest item the heap can hold.  Anything
+	// larger is stored in a subnode instead.
+	maxHeapAlloc = 3580
+	// hid indexes are stored in 11 bits, and start at 1.
+	maxHeapAllocsPerBlock = 2047
+
+	hnHeaderSize       = 12
+	hnPageHeaderSize   = 2
+	hnBitmapHeaderSize = 66
+)
+
+// ---------------------------------------------------------------------------
+// heap on node
+// ---------------------------------------------------------------------------
+
+type heapBlock struct {
+	allocs [][]byte
+	size   int
+}
+
+// heap is an append-only heap on node.  Allocations are packed into blocks
+// in order, and never freed.
+type heap struct {
+	clientSig byte
+	root      uint32
+	blocks    []*heapBlock
+}
+
+func newHeap(clientSig byte) *heap {
+	return &heap{
+		clientSig: clientSig,
+		blocks:    []*heapBlock{{}},
+	}
+}
+
+func heapBlockHeaderSize(idx int) int {
+	switch {
+	case idx == 0:
+		return hnHeaderSize
+	case idx%128 == 8:
+		return hnBitmapHeaderSize
+	default:
+		return hnPageHeaderSize
+	}
+}
+
+func heapPageMapSize(allocs int) int {
+	return 4 + 2*(allocs+1)
+}
+
+// fits reports whether an allocation of size bytes can be added to the
+// block at index idx.
+func (b heapBlock) fits(idx, size int) bool {
+	if len(b.allocs)+1 > maxHeapAllocsPerBlock {
+		return false
+	}
+
+	used := heapBlockHeaderSize(idx) + b.size + size
+	used += used % 2
+
+	return used+heapPageMapSize(len(b.allocs)+1) <= maxBlockData
+}
+
+// alloc copies the data into the heap, returning its hid.
+func (h *heap) alloc(data []byte) uint32 {
+	idx := len(h.blocks) - 1
+	b := h.blocks[idx]
+
+	if !b.fits(idx, len(data)) {
+		b = &heapBlock{}
+		h.blocks = append(h.blocks, b)
+		idx++
+	}
+
+	b.allocs = append(b.allocs, data)
+	b.size += len(data)
+
+	return uint32(idx)<<16 | uint32(len(b.allocs))<<5
+}
+
+// chunks serializes the heap, one chunk per data block.
+func (h *heap) chunks() [][]byte {
+	result := make([][]byte, 0, len(h.blocks))
+
+	for idx, b := range h.blocks {
+		var (
+			hdr   = heapBlockHeaderSize(idx)
+			ibMap = hdr + b.size
+		)
+
+		ibMap += ibMap % 2
+
+		buf := make([]byte, ibMap+heapPageMapSize(len(b.allocs)))
+		binary.LittleEndian.PutUint16(buf[0:], uint16(ibMap))
+
+		if idx == 0 {
+			buf[2] = hnSignature
+			buf[3] = h.clientSig
+			binary.LittleEndian.PutUint32(buf[4:], h.root)
+		}
+
+		pm := buf[ibMap:]
+		binary.LittleEndian.PutUint16(pm[0:], uint16(len(b.allocs)))
+
+		off := hdr
+
+		for i, a := range b.allocs {
+			binary.LittleEndian.PutUint16(pm[4+2*i:], uint16(off))
+			copy(buf[off:], a)
+			off += len(a)
+		}
+
+		binary.LittleEndian.PutUint16(pm[4+2*len(b.allocs):], uint16(off))
+
+		result = append(result, buf)
+	}
+
+	return result
+}
+
+// ---------------------------------------------------------------------------
+// node builder
+// ---------------------------------------------------------------------------
+
+// nodeBuilder collects the heap and subnodes of a single node until it
+// gets written to the ndb.
+type nodeBuilder struct {
+	db       *ndb
+	heap     *heap
+	subnodes []slEntry
+	// subnode nids only need to be unique within the node.
+	lastSubnode uint32
+}
+
+func newNodeBuilder(db *ndb, clientSig byte) *nodeBuilder {
+	return &nodeBuilder{
+		db:   db,
+		heap: newHeap(clientSig),
+	}
+}
+
+func (nb *nodeBuilder) newSubnodeNID(nidType uint32) uint32 {
+	nb.lastSubnode++
+	return makeNID(nb.lastSubnode, nidType)
+}
+
+// value stores variable length data, returning the hnid which references
+// it.  Small values are kept in the heap; large ones in a subnode.
+func (nb *nodeBuilder) value(data []byte) (uint32, error) {
+	if len(data) <= maxHeapAlloc {
+		return nb.heap.alloc(data), nil
+	}
+
+	bid, err := nb.db.writeData(data)
+	if err != nil {
+		return 0, clues.Wrap(err, "writing large value")
+	}
+
+	nid := nb.newSubnodeNID(nidTypeLTP)
+	nb.subnodes = append(nb.subnodes, slEntry{nid: nid, bidData: bid})
+
+	return nid, nil
+}
+
+// addSubnode attaches a separately built node, such as a message's
+// attachments or recipients, as a subnode of this node.
+func (nb *nodeBuilder) addSubnode(nid uint32, child *nodeBuilder) error {
+	bidData, bidSub, err := child.write()
+	if err != nil {
+		return err
+	}
+
+	nb.subnodes = append(nb.subnodes, slEntry{nid: nid, bidData: bidData, bidSub: bidSub})
+
+	return nil
+}
+
+// write stores the heap and the subnode tree.
+func (nb *nodeBuilder) write() (uint64, uint64, error) {
+	bidData, err := nb.db.writeBlocks(nb.heap.chunks())
+	if err != nil {
+		return 0, 0, clues.Wrap(err, "writing heap")
+	}
+
+	bidSub, err := nb.db.writeSubnodes(nb.subnodes)
+	if err != nil {
+		return 0, 0, clues.Wrap(err, "writing subnodes")
+	}
+
+	return bidData, bidSub, nil
+}
+
+// writeNode stores the node and adds it to the node btree.
+func (nb *nodeBuilder) writeNode(nid, parent uint32) error {
+	bidData, bidSub, err := nb.write()
+	if err != nil {
+		return clues.Stack(err).With("nid", nid)
+	}
+
+	nb.db.addNode(nbtEntry{
+		nid:       nid,
+		bidData:   bidData,
+		bidSub:    bidSub,
+		nidParent: parent,
+	})
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// btree on heap
+// ---------------------------------------------------------------------------
+
+// bth stores the records, which must be sorted by key, as a btree on the
+// heap.  Each record is the key followed by the data.  Returns the hid of
+// the btree header.
+func (nb *nodeBuilder) bth(cbKey, cbEnt int, records [][]byte) uint32 {
+	var (
+		hidRoot uint32
+		levels  byte
+		size    = cbKey + cbEnt
+	)
+
+	for len(records) > 0 {
+		var (
+			perAlloc = maxHeapAlloc / size
+			next     [][]byte
+		)
+
+		for i := 0; i < len(records); i += perAlloc {
+			end := min(i+perAlloc, len(records))
+
+			buf := make([]byte, 0, (end-i)*size)
+			for _, r := range records[i:end] {
+				buf = append(buf, r...)
+			}
+
+			hid := nb.heap.alloc(buf)
+
+			idx := make([]byte, cbKey+4)
+			copy(idx, records[i][:cbKey])
+			binary.LittleEndian.PutUint32(idx[cbKey:], hid)
+
+			next = append(next, idx)
+		}
+
+		if len(next) == 1 {
+			hidRoot = binary.LittleEndian.Uint32(next[0][cbKey:])
+			break
+		}
+
+		records = next
+		size = cbKey + 4
+		levels++
+	}
+
+	hdr := []byte{hnClientBTH, byte(cbKey), byte(cbEnt), levels, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint32(hdr[4:], hidRoot)
+
+	return nb.heap.alloc(hdr)
+}
+
+// ---------------------------------------------------------------------------
+// property context
+// ---------------------------------------------------------------------------
+
+// propertyContext builds a node holding a set of properties, as used by
+// the message store, folders, messages and attachments.
+func (nb *nodeBuilder) propertyContext(props propList) error {
+	sort.SliceStable(props, func(i, j int) bool { return props[i].tag < props[j].tag })
+
+	records := make([][]byte, 0, len(props))
+
+	for i, p := range props {
+		// later values overwrite earlier ones with the same tag.
+		if i+1 < len(props) && props[i+1].tag == p.tag {
+			continue
+		}
+
+		hnid, err := nb.encodeValue(p)
+		if err != nil {
+			return clues.Stack(err).With("prop_tag", p.tag)
+		}
+
+		r := make([]byte, 8)
+		binary.LittleEndian.PutUint16(r[0:], p.id())
+		binary.LittleEndian.PutUint16(r[2:], p.typ())
+		binary.LittleEndian.PutUint32(r[4:], hnid)
+
+		records = append(records, r)
+	}
+
+	nb.heap.root = nb.bth(2, 6, records)
+
+	return nil
+}
+
+// encodeValue produces the dwValueHnid of a property; fixed size values of
+// four bytes or less are stored inline.
+func (nb *nodeBuilder) encodeValue(p property) (uint32, error) {
+	switch fixedSize(p.typ()) {
+	case 1:
+		return uint32(p.value[0]), nil
+	case 2:
+		return uint32(binary.LittleEndian.Uint16(p.value)), nil
+	case 4:
+		return binary.LittleEndian.Uint32(p.value), nil
+	default:
+		return nb.value(p.value)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// table context
+// ---------------------------------------------------------------------------
+
+type tableRow struct {
+	id     uint32
+	values map[uint32][]byte
+}
+
+type columnDesc struct {
+	tag  uint32
+	ib   int
+	cb   int
+	iBit int
+}
+
+// tableLayout determines where each column lives within a row: the row id
+// and version first, then columns grouped by size from largest to smallest,
+// and finally the cell existence bitmap.
+func tableLayout(tags []uint32) ([]columnDesc, [4]int) {
+	var (
+		cols = []columnDesc{
+			{tag: tagLtpRowID, ib: 0, cb: 4, iBit: 0},
+			{tag: tagLtpRowVer, ib: 4, cb: 4, iBit: 1},
+		}
+		off  = 8
+		rgib [4]int
+	)
+
+	for _, size := range []int{8, 4, 2, 1} {
+		for _, tag := range tags {
+			if tag == tagLtpRowID || tag == tagLtpRowVer {
+				continue
+			}
+
+			if cellSize(propType(tag)) != size {
+				continue
+			}
+
+			cols = append(cols, columnDesc{tag: tag, ib: off, cb: size, iBit: len(cols)})
+			off += size
+		}
+
+		switch size {
+		case 4:
+			rgib[0] = off
+		case 2:
+			rgib[1] = off
+		case 1:
+			rgib[2] = off
+		}
+	}
+
+	rgib[3] = off + (len(cols)+7)/8
+
+	sort.Slice(cols, func(i, j int) bool { return cols[i].tag < cols[j].tag })
+
+	return cols, rgib
+}
+
+// tableContext builds a node holding a table with the given columns.  Row
+// ids must be unique.
+func (nb *nodeBuilder) tableContext(tags []uint32, rows []tableRow) error {
+	cols, rgib := tableLayout(tags)
+	rowSize := rgib[3]
+	ceb := rgib[2]
+
+	matrix := make([]byte, 0, rowSize*len(rows))
+	index := make([][]byte, 0, len(rows))
+
+	for i, row := range rows {
+		buf := make([]byte, rowSize)
+		binary.LittleEndian.PutUint32(buf[0:], row.id)
+
+		for _, c := range cols {
+			var v []byte
+
+			switch c.tag {
+			case tagLtpRowID:
+				v = buf[0:4]
+			case tagLtpRowVer:
+				v = []byte{0, 0, 0, 0}
+			default:
+				var ok bool
+				if v, ok = row.values[c.tag]; !ok {
+					continue
+				}
+			}
+
+			if fixedSize(propType(c.tag)) == 0 {
+				hnid, err := nb.value(v)
+				if err != nil {
+					return clues.Stack(err).With("prop_tag", c.tag)
+				}
+
+				binary.LittleEndian.PutUint32(buf[c.ib:], hnid)
+			} else {
+				copy(buf[c.ib:c.ib+c.cb], v)
+			}
+
+			buf[ceb+c.iBit/8] |= 0x80 >> (c.iBit % 8)
+		}
+
+		matrix = append(matrix, buf...)
+
+		rec := make([]byte, 8)
+		binary.LittleEndian.PutUint32(rec[0:], row.id)
+		binary.LittleEndian.PutUint32(rec[4:], uint32(i))
+		index = append(index, rec)
+	}
+
+	sort.Slice(index, func(i, j int) bool {
+		return binary.LittleEndian.Uint32(index[i]) < binary.LittleEndian.Uint32(index[j])
+	})
+
+	hidRowIndex := nb.bth(4, 4, index)
+
+	hnidRows, err := nb.rowMatrix(matrix, rowSize)
+	if err != nil {
+		return clues.Wrap(err, "writing row matrix")
+	}
+
+	info := make([]byte, 22+8*len(cols))
+	info[0] = hnClientTC
+	info[1] = byte(len(cols))
+
+	for i, ib := range rgib {
+		binary.LittleEndian.PutUint16(info[2+2*i:], uint16(ib))
+	}
+
+	binary.LittleEndian.PutUint32(info[10:], hidRowIndex)
+	binary.LittleEndian.PutUint32(info[14:], hnidRows)
+
+	for i, c := range cols {
+		d := info[22+8*i:]
+		binary.LittleEndian.PutUint32(d[0:], c.tag)
+		binary.LittleEndian.PutUint16(d[4:], uint16(c.ib))
+		d[6] = byte(c.cb)
+		d[7] = byte(c.iBit)
+	}
+
+	nb.heap.root = nb.heap.alloc(info)
+
+	return nil
+}
+
+// rowMatrix stores the rows in the heap when they're small enough.  Larger
+// matrices go in a subnode, where rows can't straddle a block boundary.
+func (nb *nodeBuilder) rowMatrix(matrix []byte, rowSize int) (uint32, error) {
+	if len(matrix) == 0 {
+		return 0, nil
+	}
+
+	if len(matrix) <= maxHeapAlloc {
+		return nb.heap.alloc(matrix), nil
+	}
+
+	var (
+		perBlock = maxBlockData / rowSize * rowSize
+		chunks   [][]byte
+	)
+
+	for len(matrix) > 0 {
+		end := min(perBlock, len(matrix))
+		chunks = append(chunks, matrix[:end])
+		matrix = matrix[end:]
+	}
+
+	bid, err := nb.db.writeBlocks(chunks)
+	if err != nil {
+		return 0, err
+	}
+
+	nid := nb.newSubnodeNID(nidTypeLTP)
+	nb.subnodes = append(nb.subnodes, slEntry{nid: nid, bidData: bid})
+
+	return nid, nil
+}
@@ -0,0 +1,735 @@
+package pst
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/alcionai/clues"
+)
+
+// This file holds the messaging layer: the message store, folders, and the
+// items within them.
+
+const (
+	msgFlagRead     = 0x01
+	msgFlagUnsent   = 0x08
+	msgFlagHasAttch = 0x10
+
+	recipientTo  = 1
+	recipientCc  = 2
+	recipientBcc = 3
+
+	// appointment attendees reuse the recipient types.
+	attendeeRequired = recipientTo
+	attendeeOptional = recipientCc
+	attendeeResource = recipientBcc
+
+	objectTypeMailUser   = 6
+	objectTypeAttachment = 7
+	attachByValue        = 1
+	codepageUTF8         = 65001
+	nameToIDBucketCount  = 251
+
+	// valid folder mask flags: the ipm subtree, wastebasket and finder
+	// entry ids are all populated.
+	validFolderMask = 0x01 | 0x08 | 0x80
+)
+
+var (
+	hierarchyColumns = []uint32{
+		idDisplayName<<16 | ptString,
+		idContentCount<<16 | ptInt32,
+		idContentUnreadCount<<16 | ptInt32,
+		idSubfolders<<16 | ptBoolean,
+		idContainerClass<<16 | ptString,
+	}
+
+	contentsColumns = []uint32{
+		idImportance<<16 | ptInt32,
+		idMessageClass<<16 | ptString,
+		idSubject<<16 | ptString,
+		idClientSubmitTime<<16 | ptTime,
+		idSentRepresentingName<<16 | ptString,
+		idDisplayCc<<16 | ptString,
+		idDisplayTo<<16 | ptString,
+		idMessageDeliveryTime<<16 | ptTime,
+		idMessageFlags<<16 | ptInt32,
+		idMessageSize<<16 | ptInt32,
+		idLastModificationTime<<16 | ptTime,
+		idHasAttachments<<16 | ptBoolean,
+	}
+
+	recipientColumns = []uint32{
+		idRecipientType<<16 | ptInt32,
+		idObjectType<<16 | ptInt32,
+		idDisplayType<<16 | ptInt32,
+		idDisplayName<<16 | ptString,
+		idAddressType<<16 | ptString,
+		idEmailAddress<<16 | ptString,
+		idSmtpAddress<<16 | ptString,
+		idRecipientDisplayName<<16 | ptString,
+	}
+
+	attachmentColumns = []uint32{
+		idAttachSize<<16 | ptInt32,
+		idAttachFilename<<16 | ptString,
+		idAttachLongFilename<<16 | ptString,
+		idAttachMethod<<16 | ptInt32,
+		idRenderingPosition<<16 | ptInt32,
+	}
+)
+
+// Writer produces a pst file.  Folders and items can be added in any
+// order, but nothing is readable until the writer is closed.
+type Writer struct {
+	db   *ndb
+	uid  [16]byte
+	name string
+
+	// folders lists every folder, in order of creation.
+	folders []*Folder
+	root    *Folder
+	ipm     *Folder
+	finder  *Folder
+	deleted *Folder
+	closed  bool
+}
+
+// NewWriter creates a pst containing an empty mailbox with the given
+// display name.
+func NewWriter(out io.WriterAt, displayName string) (*Writer, error) {
+	w := &Writer{
+		db:   newNDB(out),
+		name: displayName,
+	}
+
+	if _, err := rand.Read(w.uid[:]); err != nil {
+		return nil, clues.Wrap(err, "generating store id")
+	}
+
+	w.root = &Folder{w: w, nid: nidRootFolder}
+	w.root.parent = w.root
+	w.folders = append(w.folders, w.root)
+
+	// The order matters: the first three folder nids are expected to be
+	// the ipm subtree, the finder, and the deleted items.
+	w.ipm = w.root.addFolder("Top of Personal Folders", MailFolder)
+	w.finder = w.root.addFolder("Search Root", MailFolder)
+	w.deleted = w.ipm.addFolder("Deleted Items", MailFolder)
+
+	return w, nil
+}
+
+// Root returns the top of the mailbox's folder tree, under which all
+// user visible folders get created.
+func (w *Writer) Root() *Folder {
+	return w.ipm
+}
+
+// Close writes all folders and the remaining metadata.  The writer can't
+// be used afterward.
+func (w *Writer) Close() error {
+	if w.closed {
+		return clues.New("pst writer already closed")
+	}
+
+	w.closed = true
+
+	for _, f := range w.folders {
+		if err := f.write(); err != nil {
+			return clues.Wrap(err, "writing folder").With("folder_name", f.name)
+		}
+	}
+
+	if err := w.writeMessageStore(); err != nil {
+		return clues.Wrap(err, "writing message store")
+	}
+
+	if err := w.writeNameToIDMap(); err != nil {
+		return clues.Wrap(err, "writing named property map")
+	}
+
+	if err := w.writeTemplates(); err != nil {
+		return clues.Wrap(err, "writing table templates")
+	}
+
+	return clues.Wrap(w.db.finish(), "finalizing pst").OrNil()
+}
+
+func (w *Writer) entryID(nid uint32) []byte {
+	b := make([]byte, 24)
+	copy(b[4:], w.uid[:])
+	binary.LittleEndian.PutUint32(b[20:], nid)
+
+	return b
+}
+
+func (w *Writer) writeMessageStore() error {
+	props := propList{}
+	props.binary(idRecordKey, w.uid[:])
+	props.str(idDisplayName, w.name)
+	props.binary(idIpmSubTreeEntryID, w.entryID(w.ipm.nid))
+	props.binary(idIpmWastebasketEntryID, w.entryID(w.deleted.nid))
+	props.binary(idFinderEntryID, w.entryID(w.finder.nid))
+	props.int32(idPstPassword, 0)
+	props.int32(idValidFolderMask, validFolderMask)
+
+	nb := newNodeBuilder(w.db, hnClientPC)
+
+	if err := nb.propertyContext(props); err != nil {
+		return err
+	}
+
+	return nb.writeNode(nidMessageStore, 0)
+}
+
+// writeNameToIDMap records the named properties used by contacts and
+// appointments, so that readers can resolve their ids.
+func (w *Writer) writeNameToIDMap() error {
+	var (
+		guids   []byte
+		entries []byte
+		buckets = map[uint32][]byte{}
+	)
+
+	for _, g := range propertySets {
+		guids = append(guids, g[:]...)
+	}
+
+	for i, np := range namedProps {
+		e := make([]byte, 8)
+		binary.LittleEndian.PutUint32(e[0:], np.lid)
+		binary.LittleEndian.PutUint16(e[4:], np.guid<<1)
+		binary.LittleEndian.PutUint16(e[6:], uint16(i))
+
+		entries = append(entries, e...)
+
+		bucket := (np.lid ^ uint32(np.guid<<1)) % nameToIDBucketCount
+		buckets[bucket] = append(buckets[bucket], e...)
+	}
+
+	props := propList{}
+	props.int32(idNameidBucketCount, nameToIDBucketCount)
+	props.binary(idNameidStreamGUID, guids)
+	props.binary(idNameidStreamEntry, entries)
+	props.add(idNameidStreamString, ptBinary, []byte{})
+
+	for bucket, e := range buckets {
+		props.binary(uint16(idNameidBucketBase+bucket), e)
+	}
+
+	nb := newNodeBuilder(w.db, hnClientPC)
+
+	if err := nb.propertyContext(props); err != nil {
+		return err
+	}
+
+	return nb.writeNode(nidNameToIDMap, 0)
+}
+
+// writeTemplates writes the empty tables that outlook copies when creating
+// new folders and messages, along with the search queues.
+func (w *Writer) writeTemplates() error {
+	templates := []struct {
+		nid  uint32
+		cols []uint32
+	}{
+		{nidHierarchyTemplate, hierarchyColumns},
+		{nidContentsTemplate, contentsColumns},
+		{nidAssocContentsTemplate, contentsColumns},
+		{nidSearchTemplate, contentsColumns},
+		{nidAttachmentTable, attachmentColumns},
+		{nidRecipientTable, recipientColumns},
+	}
+
+	for _, t := range templates {
+		nb := newNodeBuilder(w.db, hnClientTC)
+
+		if err := nb.tableContext(t.cols, nil); err != nil {
+			return err
+		}
+
+		if err := nb.writeNode(t.nid, 0); err != nil {
+			return err
+		}
+	}
+
+	for _, nid := range []uint32{nidSearchManagementQueue, nidSearchActivityList} {
+		bid, err := w.db.writeBlocks([][]byte{{}})
+		if err != nil {
+			return err
+		}
+
+		w.db.addNode(nbtEntry{nid: nid, bidData: bid})
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// folders
+// ---------------------------------------------------------------------------
+
+type Folder struct {
+	w        *Writer
+	nid      uint32
+	parent   *Folder
+	name     string
+	kind     FolderKind
+	children []*Folder
+	contents []tableRow
+	unread   int
+}
+
+// Folder returns the subfolder with the given name, creating it if it
+// doesn't exist yet.
+func (f *Folder) Folder(name string, kind FolderKind) *Folder {
+	for _, c := range f.children {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return f.addFolder(name, kind)
+}
+
+// FolderPath walks down the tree of folder names, creating any folders
+// which don't exist yet.
+func (f *Folder) FolderPath(kind FolderKind, elems ...string) *Folder {
+	result := f
+
+	for _, e := range elems {
+		result = result.Folder(e, kind)
+	}
+
+	return result
+}
+
+func (f *Folder) addFolder(name string, kind FolderKind) *Folder {
+	c := &Folder{
+		w:      f.w,
+		nid:    f.w.db.newNID(nidTypeNormalFolder),
+		parent: f,
+		name:   name,
+		kind:   kind,
+	}
+
+	f.children = append(f.children, c)
+	f.w.folders = append(f.w.folders, c)
+
+	return c
+}
+
+func (f *Folder) props() propList {
+	props := propList{}
+	props.str(idDisplayName, f.name)
+	props.int32(idContentCount, int32(len(f.contents)))
+	props.int32(idContentUnreadCount, int32(f.unread))
+	props.bool(idSubfolders, len(f.children) > 0)
+
+	if f != f.w.root {
+		props.str(idContainerClass, f.kind.containerClass())
+	}
+
+	return props
+}
+
+// write stores the folder's properties along with its hierarchy, contents
+// and associated contents tables.
+func (f *Folder) write() error {
+	nb := newNodeBuilder(f.w.db, hnClientPC)
+
+	if err := nb.propertyContext(f.props()); err != nil {
+		return err
+	}
+
+	if err := nb.writeNode(f.nid, f.parent.nid); err != nil {
+		return err
+	}
+
+	hierarchy := make([]tableRow, 0, len(f.children))
+
+	for _, c := range f.children {
+		hierarchy = append(hierarchy, tableRow{
+			id:     c.nid,
+			values: c.props().values(hierarchyColumns),
+		})
+	}
+
+	tables := []struct {
+		nidType uint32
+		cols    []uint32
+		rows    []tableRow
+	}{
+		{nidTypeHierarchyTable, hierarchyColumns, hierarchy},
+		{nidTypeContentsTable, contentsColumns, f.contents},
+		{nidTypeAssocContentsTable, contentsColumns, nil},
+	}
+
+	for _, t := range tables {
+		nb := newNodeBuilder(f.w.db, hnClientTC)
+
+		if err := nb.tableContext(t.cols, t.rows); err != nil {
+			return err
+		}
+
+		if err := nb.writeNode(tableNID(f.nid, t.nidType), 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// items
+// ---------------------------------------------------------------------------
+
+type recipient struct {
+	Address
+	typ int32
+}
+
+func recipientsOf(typ int32, addrs []Address) []recipient {
+	result := make([]recipient, 0, len(addrs))
+
+	for _, a := range addrs {
+		result = append(result, recipient{Address: a, typ: typ})
+	}
+
+	return result
+}
+
+// displayList joins the names of the addresses the way outlook does in
+// the To, Cc and Bcc display properties.
+func displayList(addrs []Address) string {
+	names := make([]string, 0, len(addrs))
+
+	for _, a := range addrs {
+		names = append(names, addressName(a))
+	}
+
+	return strings.Join(names, "; ")
+}
+
+func addressName(a Address) string {
+	if len(a.Name) > 0 {
+		return a.Name
+	}
+
+	return a.Email
+}
+
+func addressProps(props *propList, name, addrType, email, smtp uint16, a Address) {
+	props.str(name, addressName(a))
+	props.str(email, a.Email)
+	props.str(smtp, a.Email)
+
+	if len(a.Email) > 0 {
+		props.str(addrType, "SMTP")
+	}
+}
+
+// AddMessage writes an email into the folder.
+func (f *Folder) AddMessage(m Message) error {
+	props := propList{}
+	props.str(idMessageClass, "IPM.Note")
+	props.str(idSubject, m.Subject)
+	props.str(idConversationTopic, m.Subject)
+	props.str(idInternetMessageID, m.InternetMessageID)
+	props.int32(idImportance, int32(m.Importance))
+	props.time(idCreationTime, m.Created)
+	props.time(idLastModificationTime, m.Modified)
+	props.time(idClientSubmitTime, m.Sent)
+	props.time(idMessageDeliveryTime, m.Received)
+	props.str(idDisplayTo, displayList(m.To))
+	props.str(idDisplayCc, displayList(m.Cc))
+	props.str(idDisplayBcc, displayList(m.Bcc))
+
+	sender := m.Sender
+	if len(sender.Email) == 0 {
+		sender = m.From
+	}
+
+	addressProps(
+		&props,
+		idSentRepresentingName,
+		idSentRepresentingAddressType,
+		idSentRepresentingEmailAddress,
+		idSentRepresentingSmtpAddress,
+		m.From)
+	addressProps(
+		&props,
+		idSenderName,
+		idSenderAddressType,
+		idSenderEmailAddress,
+		idSenderSmtpAddress,
+		sender)
+
+	var flags int32
+
+	if m.Read {
+		flags |= msgFlagRead
+	}
+
+	if m.Draft {
+		flags |= msgFlagUnsent
+	}
+
+	recipients := recipientsOf(recipientTo, m.To)
+	recipients = append(recipients, recipientsOf(recipientCc, m.Cc)...)
+	recipients = append(recipients, recipientsOf(recipientBcc, m.Bcc)...)
+
+	return f.addItem(props, flags, m.Body, m.HTMLBody, recipients, m.Attachments)
+}
+
+// AddContact writes a contact into the folder.
+func (f *Folder) AddContact(c Contact) error {
+	props := propList{}
+	props.str(idMessageClass, "IPM.Contact")
+	props.str(idSubject, c.DisplayName)
+	props.str(idDisplayName, c.DisplayName)
+	props.str(idGivenName, c.GivenName)
+	props.str(idMiddleName, c.MiddleName)
+	props.str(idSurname, c.Surname)
+	props.str(idNickname, c.NickName)
+	props.str(idCompanyName, c.CompanyName)
+	props.str(idTitle, c.JobTitle)
+	props.str(idDepartmentName, c.Department)
+	props.str(idMobileTelephoneNumber, c.MobilePhone)
+	props.time(idBirthday, c.Birthday)
+	props.time(idCreationTime, c.Created)
+	props.time(idLastModificationTime, c.Modified)
+	props.int32(idImportance, int32(ImportanceNormal))
+
+	fileUnder := c.DisplayName
+	if len(c.Surname) > 0 && len(c.GivenName) > 0 {
+		fileUnder = c.Surname + ", " + c.GivenName
+	}
+
+	props.str(namedFileUnder, fileUnder)
+
+	phones := []struct {
+		ids    []uint16
+		values []string
+	}{
+		{[]uint16{idBusinessTelephoneNumber, idBusiness2TelephoneNumber}, c.BusinessPhones},
+		{[]uint16{idHomeTelephoneNumber, idHome2TelephoneNumber}, c.HomePhones},
+	}
+
+	for _, p := range phones {
+		for i, v := range p.values {
+			if i < len(p.ids) {
+				props.str(p.ids[i], v)
+			}
+		}
+	}
+
+	for i, e := range c.Emails {
+		if i >= len(emailNamedProps) {
+			break
+		}
+
+		ids := emailNamedProps[i]
+		name := addressName(e)
+
+		props.str(ids[0], name)
+		props.str(ids[1], "SMTP")
+		props.str(ids[2], e.Email)
+		props.str(ids[3], name)
+	}
+
+	props.str(idBusinessAddressStreet, c.BusinessAddress.Street)
+	props.str(idBusinessAddressCity, c.BusinessAddress.City)
+	props.str(idBusinessAddressState, c.BusinessAddress.State)
+	props.str(idBusinessPostalCode, c.BusinessAddress.PostalCode)
+	props.str(idBusinessAddressCountry, c.BusinessAddress.Country)
+	props.str(idHomeAddressStreet, c.HomeAddress.Street)
+	props.str(idHomeAddressCity, c.HomeAddress.City)
+	props.str(idHomeAddressState, c.HomeAddress.State)
+	props.str(idHomeAddressPostalCode, c.HomeAddress.PostalCode)
+	props.str(idHomeAddressCountry, c.HomeAddress.Country)
+
+	return f.addItem(props, msgFlagRead, c.Notes, "", nil, nil)
+}
+
+// AddAppointment writes a calendar event into the folder.
+func (f *Folder) AddAppointment(a Appointment) error {
+	props := propList{}
+	props.str(idMessageClass, "IPM.Appointment")
+	props.str(idSubject, a.Subject)
+	props.str(idConversationTopic, a.Subject)
+	props.int32(idImportance, int32(a.Importance))
+	props.time(idCreationTime, a.Created)
+	props.time(idLastModificationTime, a.Modified)
+	props.time(idStartDate, a.Start)
+	props.time(idEndDate, a.End)
+	props.time(namedAppointmentStartWhole, a.Start)
+	props.time(namedAppointmentEndWhole, a.End)
+	props.int32(namedAppointmentDuration, int32(a.End.Sub(a.Start).Minutes()))
+	props.bool(namedAppointmentSubType, a.AllDay)
+	props.int32(namedBusyStatus, int32(a.BusyStatus))
+	props.str(namedLocation, a.Location)
+	props.str(idDisplayTo, displayList(a.Required))
+	props.str(idDisplayCc, displayList(a.Optional))
+
+	addressProps(
+		&props,
+		idSentRepresentingName,
+		idSentRepresentingAddressType,
+		idSentRepresentingEmailAddress,
+		idSentRepresentingSmtpAddress,
+		a.Organizer)
+	addressProps(
+		&props,
+		idSenderName,
+		idSenderAddressType,
+		idSenderEmailAddress,
+		idSenderSmtpAddress,
+		a.Organizer)
+
+	recipients := recipientsOf(attendeeRequired, a.Required)
+	recipients = append(recipients, recipientsOf(attendeeOptional, a.Optional)...)
+	recipients = append(recipients, recipientsOf(attendeeResource, a.Resources)...)
+
+	return f.addItem(props, msgFlagRead, a.Body, a.HTMLBody, recipients, a.Attachments)
+}
+
+// addItem completes the properties shared by all items, and writes the
+// item along with its recipients and attachments.
+func (f *Folder) addItem(
+	props propList,
+	flags int32,
+	body, html string,
+	recipients []recipient,
+	attachments []Attachment,
+) error {
+	if f.w.closed {
+		return clues.New("pst writer already closed")
+	}
+
+	var (
+		nid = f.w.db.newNID(nidTypeNormalMessage)
+		nb  = newNodeBuilder(f.w.db, hnClientPC)
+	)
+
+	props.str(idBody, body)
+
+	if len(html) > 0 {
+		props.binary(idHTML, []byte(html))
+		props.int32(idInternetCodepage, codepageUTF8)
+	}
+
+	if err := writeRecipients(nb, recipients); err != nil {
+		return clues.Wrap(err, "writing recipients")
+	}
+
+	if len(attachments) > 0 {
+		flags |= msgFlagHasAttch
+
+		if err := writeAttachments(nb, attachments); err != nil {
+			return clues.Wrap(err, "writing attachments")
+		}
+	}
+
+	props.bool(idHasAttachments, len(attachments) > 0)
+	props.int32(idMessageFlags, flags)
+
+	var size int
+	for _, p := range props {
+		size += len(p.value)
+	}
+
+	for _, a := range attachments {
+		size += len(a.Content)
+	}
+
+	props.int32(idMessageSize, int32(size))
+
+	if err := nb.propertyContext(props); err != nil {
+		return err
+	}
+
+	if err := nb.writeNode(nid, f.nid); err != nil {
+		return err
+	}
+
+	f.contents = append(f.contents, tableRow{id: nid, values: props.values(contentsColumns)})
+
+	if flags&msgFlagRead == 0 {
+		f.unread++
+	}
+
+	return nil
+}
+
+func writeRecipients(nb *nodeBuilder, recipients []recipient) error {
+	rows := make([]tableRow, 0, len(recipients))
+
+	for i, r := range recipients {
+		props := propList{}
+		props.int32(idRecipientType, r.typ)
+		props.int32(idObjectType, objectTypeMailUser)
+		props.int32(idDisplayType, 0)
+		props.str(idDisplayName, addressName(r.Address))
+		props.str(idRecipientDisplayName, addressName(r.Address))
+		props.str(idAddressType, "SMTP")
+		props.str(idEmailAddress, r.Email)
+		props.str(idSmtpAddress, r.Email)
+
+		rows = append(rows, tableRow{id: uint32(i), values: props.values(recipientColumns)})
+	}
+
+	table := newNodeBuilder(nb.db, hnClientTC)
+
+	if err := table.tableContext(recipientColumns, rows); err != nil {
+		return err
+	}
+
+	return nb.addSubnode(nidRecipientTable, table)
+}
+
+func writeAttachments(nb *nodeBuilder, attachments []Attachment) error {
+	rows := make([]tableRow, 0, len(attachments))
+
+	for _, a := range attachments {
+		props := propList{}
+		props.int32(idObjectType, objectTypeAttachment)
+		props.int32(idAttachMethod, attachByValue)
+		props.int32(idAttachSize, int32(len(a.Content)))
+		props.int32(idRenderingPosition, -1)
+		props.str(idDisplayName, a.Name)
+		props.str(idAttachFilename, a.Name)
+		props.str(idAttachLongFilename, a.Name)
+		props.str(idAttachExtension, path.Ext(a.Name))
+		props.str(idAttachMimeTag, a.ContentType)
+		props.str(idAttachContentID, a.ContentID)
+		props.bool(idAttachmentHidden, a.Inline)
+		props.add(idAttachDataBinary, ptBinary, a.Content)
+
+		child := newNodeBuilder(nb.db, hnClientPC)
+
+		if err := child.propertyContext(props); err != nil {
+			return clues.Stack(err).With("attachment_name", a.Name)
+		}
+
+		nid := nb.newSubnodeNID(nidTypeAttachment)
+
+		if err := nb.addSubnode(nid, child); err != nil {
+			return clues.Stack(err).With("attachment_name", a.Name)
+		}
+
+		rows = append(rows, tableRow{id: nid, values: props.values(attachmentColumns)})
+	}
+
+	table := newNodeBuilder(nb.db, hnClientTC)
+
+	if err := table.tableContext(attachmentColumns, rows); err != nil {
+		return err
+	}
+
+	return nb.addSubnode(nidAttachmentTable, table)
+}
@@ -0,0 +1,684 @@
+package pst
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"sort"
+
+	"github.com/alcionai/clues"
+)
+
+// This file holds the node database (NDB) layer of the pst format: blocks,
+// data and subnode trees, the node and block btrees, allocation maps and
+// the file header.  Only the unicode (64 bit) flavor of the format is
+// written, without any encoding of the block contents.
+// Ref: https://learn.microsoft.com/en-us/openspecs/office_file_formats/ms-pst
+
+const (
+	headerSize = 564
+
+	// the first allocation map page, and the first byte available to
+	// blocks and pages.
+	firstAMapOffset = 0x4400
+	// each allocation map covers 496 bytes of bitmap, one bit per 64 bytes.
+	amapRegionSize = 496 * 8 * 64
+	pageSize       = 512
+	blockAlign     = 64
+
+	blockTrailerSize = 16
+	maxBlockSize     = 8192
+	// maxBlockData is the largest payload held by a single data block.
+	maxBlockData = maxBlockSize - blockTrailerSize
+
+	// xblocks and slblocks share an 8 byte header.
+	internalBlockHeaderSize = 8
+	maxXBlockEntries        = (maxBlockData - internalBlockHeaderSize) / 8
+	maxSLBlockEntries       = (maxBlockData - internalBlockHeaderSize) / 24
+	maxSIBlockEntries       = (maxBlockData - internalBlockHeaderSize) / 16
+
+	btPageEntriesSize = 488
+	bbtEntrySize      = 24
+	nbtEntrySize      = 32
+	btEntrySize       = 24
+)
+
+// page types
+const (
+	ptypeBBT   = 0x80
+	ptypeNBT   = 0x81
+	ptypeFMap  = 0x82
+	ptypePMap  = 0x83
+	ptypeAMap  = 0x84
+	ptypeFPMap = 0x85
+)
+
+// internal block types
+const (
+	btypeXBlock  = 0x01
+	btypeSLBlock = 0x02
+)
+
+// bids are allocated in increments of 4.  The second bit marks blocks
+// which hold ndb metadata (xblocks, slblocks) instead of node data.
+const (
+	bidIncrement    = 4
+	bidInternalFlag = 0x2
+)
+
+var crcTable = crc32.MakeTable(crc32.IEEE)
+
+// computeCRC implements the pst crc, which is the standard crc32 without
+// the initial and final bit inversions.
+func computeCRC(b []byte) uint32 {
+	return ^crc32.Update(^uint32(0), crcTable, b)
+}
+
+// computeSig produces the signature stored in block and page trailers.
+func computeSig(ib, bid uint64) uint16 {
+	v := uint32(ib ^ bid)
+	return uint16(v>>16) ^ uint16(v)
+}
+
+type bbtEntry struct {
+	bid uint64
+	ib  uint64
+	cb  uint16
+}
+
+type nbtEntry struct {
+	nid       uint32
+	bidData   uint64
+	bidSub    uint64
+	nidParent uint32
+}
+
+// slEntry describes a node within a subnode tree.
+type slEntry struct {
+	nid     uint32
+	bidData uint64
+	bidSub  uint64
+}
+
+type bref struct {
+	bid uint64
+	ib  uint64
+}
+
+// ndb writes blocks and pages to the output as they're produced, and keeps
+// only the btree entries in memory until the file is finalized.
+type ndb struct {
+	out io.WriterAt
+	eof uint64
+
+	nextBID  uint64
+	nextPage uint64
+
+	blocks []bbtEntry
+	nodes  map[uint32]nbtEntry
+
+	// one allocation bitmap for each region covered by an amap page.
+	amaps [][]byte
+
+	// rgnid tracks the last index assigned to each nid type.
+	rgnid [32]uint32
+}
+
+func newNDB(out io.WriterAt) *ndb {
+	db := &ndb{
+		out:      out,
+		eof:      firstAMapOffset,
+		nextBID:  bidIncrement,
+		nextPage: bidIncrement,
+		nodes:    map[uint32]nbtEntry{},
+	}
+
+	// initial values are dictated by the spec.
+	for i := range db.rgnid {
+		db.rgnid[i] = 0x400
+	}
+
+	db.rgnid[nidTypeSearchFolder] = 0x4000
+	db.rgnid[nidTypeNormalMessage] = 0x10000
+	db.rgnid[nidTypeAssocMessage] = 0x8000
+
+	return db
+}
+
+// newNID produces the next unused nid of the given type.
+func (db *ndb) newNID(nidType uint32) uint32 {
+	db.rgnid[nidType]++
+	return makeNID(db.rgnid[nidType], nidType)
+}
+
+// ---------------------------------------------------------------------------
+// allocation
+// ---------------------------------------------------------------------------
+
+// regionReserved returns the number of bytes at the start of an amap region
+// that are held by the allocation map pages.
+func regionReserved(region uint64) uint64 {
+	reserved := uint64(pageSize) // amap
+
+	if region%8 == 0 {
+		reserved += pageSize // pmap
+	}
+
+	if region >= 128 && (region-128)%496 == 0 {
+		reserved = 3 * pageSize // fmap
+	}
+
+	if region >= 1024 && (region-1024)%(496*8) == 0 {
+		reserved = 4 * pageSize // fpmap
+	}
+
+	return reserved
+}
+
+func regionStart(region uint64) uint64 {
+	return firstAMapOffset + region*amapRegionSize
+}
+
+// alloc reserves size bytes at the given alignment, skipping over the
+// allocation map pages at the start of every region.
+func (db *ndb) alloc(size, align uint64) uint64 {
+	off := alignUp(db.eof, align)
+
+	for {
+		region := (off - firstAMapOffset) / amapRegionSize
+		start := regionStart(region)
+
+		if reserved := start + regionReserved(region); off < reserved {
+			off = alignUp(reserved, align)
+		}
+
+		if off+size <= start+amapRegionSize {
+			break
+		}
+
+		off = regionStart(region + 1)
+	}
+
+	db.markAllocated(off, size)
+	db.eof = off + size
+
+	return off
+}
+
+func (db *ndb) markAllocated(off, size uint64) {
+	for slot := off / blockAlign; slot < alignUp(off+size, blockAlign)/blockAlign; slot++ {
+		rel := slot*blockAlign - firstAMapOffset
+		region := rel / amapRegionSize
+		bit := (rel % amapRegionSize) / blockAlign
+
+		for uint64(len(db.amaps)) <= region {
+			db.amaps = append(db.amaps, make([]byte, 496))
+		}
+
+		db.amaps[region][bit/8] |= 0x80 >> (bit % 8)
+	}
+}
+
+func alignUp(v, align uint64) uint64 {
+	return (v + align - 1) / align * align
+}
+
+// ---------------------------------------------------------------------------
+// blocks
+// ---------------------------------------------------------------------------
+
+func (db *ndb) writeBlock(data []byte, internal bool) (uint64, error) {
+	if len(data) > maxBlockData {
+		return 0, clues.New("block exceeds maximum size").With("block_size", len(data))
+	}
+
+	bid := db.nextBID
+	db.nextBID += bidIncrement
+
+	if internal {
+		bid |= bidInternalFlag
+	}
+
+	size := alignUp(uint64(len(data))+blockTrailerSize, blockAlign)
+	ib := db.alloc(size, blockAlign)
+
+	buf := make([]byte, size)
+	copy(buf, data)
+
+	trailer := buf[size-blockTrailerSize:]
+	binary.LittleEndian.PutUint16(trailer[0:], uint16(len(data)))
+	binary.LittleEndian.PutUint16(trailer[2:], computeSig(ib, bid))
+	binary.LittleEndian.PutUint32(trailer[4:], computeCRC(data))
+	binary.LittleEndian.PutUint64(trailer[8:], bid)
+
+	if _, err := db.out.WriteAt(buf, int64(ib)); err != nil {
+		return 0, clues.Wrap(err, "writing block")
+	}
+
+	db.blocks = append(db.blocks, bbtEntry{bid: bid, ib: ib, cb: uint16(len(data))})
+
+	return bid, nil
+}
+
+// writeData stores data of any length, splitting it into as many blocks
+// as needed.
+func (db *ndb) writeData(data []byte) (uint64, error) {
+	chunks := [][]byte{}
+
+	for len(data) > maxBlockData {
+		chunks = append(chunks, data[:maxBlockData])
+		data = data[maxBlockData:]
+	}
+
+	return db.writeBlocks(append(chunks, data))
+}
+
+// writeBlocks stores pre-chunked node data.  Callers chunk the data
+// themselves when the contents of each block need to be self-contained,
+// such as heap pages and table rows.
+func (db *ndb) writeBlocks(chunks [][]byte) (uint64, error) {
+	var (
+		bids  = make([]uint64, 0, len(chunks))
+		total uint32
+	)
+
+	for _, c := range chunks {
+		bid, err := db.writeBlock(c, false)
+		if err != nil {
+			return 0, err
+		}
+
+		bids = append(bids, bid)
+		total += uint32(len(c))
+	}
+
+	if len(bids) == 1 {
+		return bids[0], nil
+	}
+
+	// one level of xblocks covers a little over 8MB of data.  Anything
+	// larger gets a second level (xxblock) referencing the xblocks.
+	level := byte(1)
+
+	for len(bids) > 1 || level == 1 {
+		next := []uint64{}
+
+		for i := 0; i < len(bids); i += maxXBlockEntries {
+			end := min(i+maxXBlockEntries, len(bids))
+
+			bid, err := db.writeXBlock(level, bids[i:end], total)
+			if err != nil {
+				return 0, err
+			}
+
+			next = append(next, bid)
+		}
+
+		if level == 2 && len(next) > 1 {
+			return 0, clues.New("node data exceeds maximum size").With("data_size", total)
+		}
+
+		bids = next
+		level++
+	}
+
+	return bids[0], nil
+}
+
+func (db *ndb) writeXBlock(level byte, bids []uint64, total uint32) (uint64, error) {
+	buf := make([]byte, internalBlockHeaderSize+8*len(bids))
+	buf[0] = btypeXBlock
+	buf[1] = level
+	binary.LittleEndian.PutUint16(buf[2:], uint16(len(bids)))
+	binary.LittleEndian.PutUint32(buf[4:], total)
+
+	for i, bid := range bids {
+		binary.LittleEndian.PutUint64(buf[internalBlockHeaderSize+8*i:], bid)
+	}
+
+	return db.writeBlock(buf, true)
+}
+
+// writeSubnodes stores the subnode tree of a node, returning the bid of
+// the tree's root, or 0 if there are no subnodes.
+func (db *ndb) writeSubnodes(entries []slEntry) (uint64, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].nid < entries[j].nid })
+
+	var (
+		leaves   = []uint64{}
+		leafNIDs = []uint32{}
+	)
+
+	for i := 0; i < len(entries); i += maxSLBlockEntries {
+		end := min(i+maxSLBlockEntries, len(entries))
+		page := entries[i:end]
+
+		buf := make([]byte, internalBlockHeaderSize+24*len(page))
+		buf[0] = btypeSLBlock
+		buf[1] = 0
+		binary.LittleEndian.PutUint16(buf[2:], uint16(len(page)))
+
+		for j, e := range page {
+			b := buf[internalBlockHeaderSize+24*j:]
+			binary.LittleEndian.PutUint64(b[0:], uint64(e.nid))
+			binary.LittleEndian.PutUint64(b[8:], e.bidData)
+			binary.LittleEndian.PutUint64(b[16:], e.bidSub)
+		}
+
+		bid, err := db.writeBlock(buf, true)
+		if err != nil {
+			return 0, err
+		}
+
+		leaves = append(leaves, bid)
+		leafNIDs = append(leafNIDs, page[0].nid)
+	}
+
+	if len(leaves) == 1 {
+		return leaves[0], nil
+	}
+
+	if len(leaves) > maxSIBlockEntries {
+		return 0, clues.New("too many subnodes").With("subnode_count", len(entries))
+	}
+
+	buf := make([]byte, internalBlockHeaderSize+16*len(leaves))
+	buf[0] = btypeSLBlock
+	buf[1] = 1
+	binary.LittleEndian.PutUint16(buf[2:], uint16(len(leaves)))
+
+	for i, bid := range leaves {
+		b := buf[internalBlockHeaderSize+16*i:]
+		binary.LittleEndian.PutUint64(b[0:], uint64(leafNIDs[i]))
+		binary.LittleEndian.PutUint64(b[8:], bid)
+	}
+
+	return db.writeBlock(buf, true)
+}
+
+func (db *ndb) addNode(e nbtEntry) {
+	db.nodes[e.nid] = e
+}
+
+// ---------------------------------------------------------------------------
+// pages
+// ---------------------------------------------------------------------------
+
+func (db *ndb) writePage(ib uint64, content []byte, ptype byte, bid uint64, signed bool) error {
+	buf := make([]byte, pageSize)
+	copy(buf, content)
+
+	trailer := buf[pageSize-16:]
+	trailer[0] = ptype
+	trailer[1] = ptype
+
+	if signed {
+		binary.LittleEndian.PutUint16(trailer[2:], computeSig(ib, bid))
+	}
+
+	binary.LittleEndian.PutUint32(trailer[4:], computeCRC(buf[:pageSize-16]))
+	binary.LittleEndian.PutUint64(trailer[8:], bid)
+
+	_, err := db.out.WriteAt(buf, int64(ib))
+
+	return clues.Wrap(err, "writing page").OrNil()
+}
+
+// writeBTree writes the leaf entries into btree pages, adding levels of
+// intermediate pages until a single root remains.
+func (db *ndb) writeBTree(
+	ptype byte,
+	keys []uint64,
+	leafEntries [][]byte,
+	leafEntrySize int,
+) (bref, error) {
+	level := byte(0)
+	entrySize := leafEntrySize
+
+	for {
+		maxEntries := btPageEntriesSize / entrySize
+
+		var (
+			nextKeys    []uint64
+			nextEntries [][]byte
+		)
+
+		for i := 0; i < len(leafEntries) || i == 0; i += maxEntries {
+			end := min(i+maxEntries, len(leafEntries))
+			page := leafEntries[i:end]
+
+			content := make([]byte, pageSize-16)
+			for j, e := range page {
+				copy(content[j*entrySize:], e)
+			}
+
+			content[btPageEntriesSize] = byte(len(page))
+			content[btPageEntriesSize+1] = byte(maxEntries)
+			content[btPageEntriesSize+2] = byte(entrySize)
+			content[btPageEntriesSize+3] = level
+
+			ib := db.alloc(pageSize, pageSize)
+			bid := db.nextPage
+			db.nextPage += bidIncrement
+
+			if err := db.writePage(ib, content, ptype, bid, true); err != nil {
+				return bref{}, err
+			}
+
+			var key uint64
+			if len(page) > 0 {
+				key = keys[i]
+			}
+
+			entry := make([]byte, btEntrySize)
+			binary.LittleEndian.PutUint64(entry[0:], key)
+			binary.LittleEndian.PutUint64(entry[8:], bid)
+			binary.LittleEndian.PutUint64(entry[16:], ib)
+
+			nextKeys = append(nextKeys, key)
+			nextEntries = append(nextEntries, entry)
+		}
+
+		if len(nextEntries) == 1 {
+			return bref{
+				bid: binary.LittleEndian.Uint64(nextEntries[0][8:]),
+				ib:  binary.LittleEndian.Uint64(nextEntries[0][16:]),
+			}, nil
+		}
+
+		keys = nextKeys
+		leafEntries = nextEntries
+		entrySize = btEntrySize
+		level++
+	}
+}
+
+func (db *ndb) writeBBT() (bref, error) {
+	sort.Slice(db.blocks, func(i, j int) bool { return db.blocks[i].bid < db.blocks[j].bid })
+
+	var (
+		keys    = make([]uint64, 0, len(db.blocks))
+		entries = make([][]byte, 0, len(db.blocks))
+	)
+
+	for _, b := range db.blocks {
+		e := make([]byte, bbtEntrySize)
+		binary.LittleEndian.PutUint64(e[0:], b.bid)
+		binary.LittleEndian.PutUint64(e[8:], b.ib)
+		binary.LittleEndian.PutUint16(e[16:], b.cb)
+		// reference count: the node referencing the block, and the bbt.
+		binary.LittleEndian.PutUint16(e[18:], 2)
+
+		keys = append(keys, b.bid)
+		entries = append(entries, e)
+	}
+
+	return db.writeBTree(ptypeBBT, keys, entries, bbtEntrySize)
+}
+
+func (db *ndb) writeNBT() (bref, error) {
+	nodes := make([]nbtEntry, 0, len(db.nodes))
+	for _, n := range db.nodes {
+		nodes = append(nodes, n)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].nid < nodes[j].nid })
+
+	var (
+		keys    = make([]uint64, 0, len(nodes))
+		entries = make([][]byte, 0, len(nodes))
+	)
+
+	for _, n := range nodes {
+		e := make([]byte, nbtEntrySize)
+		binary.LittleEndian.PutUint64(e[0:], uint64(n.nid))
+		binary.LittleEndian.PutUint64(e[8:], n.bidData)
+		binary.LittleEndian.PutUint64(e[16:], n.bidSub)
+		binary.LittleEndian.PutUint32(e[24:], n.nidParent)
+
+		keys = append(keys, uint64(n.nid))
+		entries = append(entries, e)
+	}
+
+	return db.writeBTree(ptypeNBT, keys, entries, nbtEntrySize)
+}
+
+// ---------------------------------------------------------------------------
+// finalization
+// ---------------------------------------------------------------------------
+
+// finish writes the btrees, allocation maps and header.  No other writes
+// may happen afterward.
+func (db *ndb) finish() error {
+	nbt, err := db.writeNBT()
+	if err != nil {
+		return clues.Wrap(err, "writing node btree")
+	}
+
+	bbt, err := db.writeBBT()
+	if err != nil {
+		return clues.Wrap(err, "writing block btree")
+	}
+
+	// the map pages must be marked in every region, including the last.
+	regions := (db.eof-firstAMapOffset)/amapRegionSize + 1
+
+	for r := uint64(0); r < regions; r++ {
+		db.markAllocated(regionStart(r), regionReserved(r))
+	}
+
+	var free uint64
+
+	for r := uint64(0); r < regions; r++ {
+		start := regionStart(r)
+
+		for _, b := range db.amaps[r] {
+			for bit := 0; bit < 8; bit++ {
+				if b&(0x80>>bit) == 0 {
+					free += blockAlign
+				}
+			}
+		}
+
+		if err := db.writePage(start, db.amaps[r], ptypeAMap, start, false); err != nil {
+			return clues.Wrap(err, "writing amap")
+		}
+
+		if err := db.writeDeprecatedMaps(r, start); err != nil {
+			return err
+		}
+	}
+
+	// pst files always extend to the end of the last amap region.
+	fileEOF := regionStart(regions)
+
+	if _, err := db.out.WriteAt([]byte{0}, int64(fileEOF-1)); err != nil {
+		return clues.Wrap(err, "extending file")
+	}
+
+	header := db.header(fileEOF, regionStart(regions-1), free, nbt, bbt)
+
+	_, err = db.out.WriteAt(header, 0)
+
+	return clues.Wrap(err, "writing header").OrNil()
+}
+
+// writeDeprecatedMaps fills the pmap, fmap and fpmap pages.  They're no
+// longer used by readers, but the space they occupy is still reserved.
+func (db *ndb) writeDeprecatedMaps(region, start uint64) error {
+	full := make([]byte, pageSize-16)
+	for i := range full {
+		full[i] = 0xFF
+	}
+
+	pages := []struct {
+		present bool
+		offset  uint64
+		ptype   byte
+	}{
+		{region%8 == 0, pageSize, ptypePMap},
+		{region >= 128 && (region-128)%496 == 0, 2 * pageSize, ptypeFMap},
+		{region >= 1024 && (region-1024)%(496*8) == 0, 3 * pageSize, ptypeFPMap},
+	}
+
+	for _, p := range pages {
+		if !p.present {
+			continue
+		}
+
+		ib := start + p.offset
+
+		if err := db.writePage(ib, full, p.ptype, ib, false); err != nil {
+			return clues.Wrap(err, "writing allocation map")
+		}
+	}
+
+	return nil
+}
+
+func (db *ndb) header(fileEOF, lastAMap, amapFree uint64, nbt, bbt bref) []byte {
+	h := make([]byte, headerSize)
+
+	copy(h[0:], "!BDN")
+	binary.LittleEndian.PutUint16(h[8:], 0x4D53) // wMagicClient
+	binary.LittleEndian.PutUint16(h[10:], 23)    // wVer: unicode
+	binary.LittleEndian.PutUint16(h[12:], 19)    // wVerClient
+	h[14] = 0x01                                 // bPlatformCreate
+	h[15] = 0x01                                 // bPlatformAccess
+	binary.LittleEndian.PutUint64(h[32:], db.nextPage)
+	binary.LittleEndian.PutUint32(h[40:], 1) // dwUnique
+
+	for i, nid := range db.rgnid {
+		binary.LittleEndian.PutUint32(h[44+4*i:], nid)
+	}
+
+	// ROOT
+	root := h[180:]
+	binary.LittleEndian.PutUint64(root[4:], fileEOF)
+	binary.LittleEndian.PutUint64(root[12:], lastAMap)
+	binary.LittleEndian.PutUint64(root[20:], amapFree)
+	binary.LittleEndian.PutUint64(root[28:], 0) // cbPMapFree
+	binary.LittleEndian.PutUint64(root[36:], nbt.bid)
+	binary.LittleEndian.PutUint64(root[44:], nbt.ib)
+	binary.LittleEndian.PutUint64(root[52:], bbt.bid)
+	binary.LittleEndian.PutUint64(root[60:], bbt.ib)
+	root[68] = 0x02 // fAMapValid: VALID_AMAP2
+
+	// rgbFM and rgbFP are deprecated, and filled with 0xFF.
+	for i := 256; i < 512; i++ {
+		h[i] = 0xFF
+	}
+
+	h[512] = 0x80 // bSentinel
+	h[513] = 0x00 // bCryptMethod: NDB_CRYPT_NONE
+	binary.LittleEndian.PutUint64(h[516:], db.nextBID)
+
+	binary.LittleEndian.PutUint32(h[4:], computeCRC(h[8:8+471]))
+	binary.LittleEndian.PutUint32(h[524:], computeCRC(h[8:8+516]))
+
+	return h
+}
@@ -0,0 +1,309 @@
+package pst
+
+import (
+	"encoding/binary"
+	"time"
+	"unicode/utf16"
+)
+
+// property types
+const (
+	ptInt16   = 0x0002
+	ptInt32   = 0x0003
+	ptBoolean = 0x000B
+	ptInt64   = 0x0014
+	ptString  = 0x001F
+	ptTime    = 0x0040
+	ptBinary  = 0x0102
+)
+
+// property ids
+// Ref: https://learn.microsoft.com/en-us/openspecs/exchange_server_protocols/ms-oxprops
+const (
+	idImportance                   = 0x0017
+	idMessageClass                 = 0x001A
+	idSubject                      = 0x0037
+	idClientSubmitTime             = 0x0039
+	idSentRepresentingName         = 0x0042
+	idStartDate                    = 0x0060
+	idEndDate                      = 0x0061
+	idSentRepresentingAddressType  = 0x0064
+	idSentRepresentingEmailAddress = 0x0065
+	idConversationTopic            = 0x0070
+	idRecipientType                = 0x0C15
+	idSenderName                   = 0x0C1A
+	idSenderAddressType            = 0x0C1E
+	idSenderEmailAddress           = 0x0C1F
+	idDisplayBcc                   = 0x0E02
+	idDisplayCc                    = 0x0E03
+	idDisplayTo                    = 0x0E04
+	idMessageDeliveryTime          = 0x0E06
+	idMessageFlags                 = 0x0E07
+	idMessageSize                  = 0x0E08
+	idHasAttachments               = 0x0E1B
+	idAttachSize                   = 0x0E20
+	idRecordKey                    = 0x0FF9
+	idObjectType                   = 0x0FFE
+	idBody                         = 0x1000
+	idHTML                         = 0x1013
+	idInternetMessageID            = 0x1035
+	idDisplayName                  = 0x3001
+	idAddressType                  = 0x3002
+	idEmailAddress                 = 0x3003
+	idCreationTime                 = 0x3007
+	idLastModificationTime         = 0x3008
+	idValidFolderMask              = 0x35DF
+	idIpmSubTreeEntryID            = 0x35E0
+	idIpmWastebasketEntryID        = 0x35E3
+	idFinderEntryID                = 0x35E7
+	idContentCount                 = 0x3602
+	idContentUnreadCount           = 0x3603
+	idSubfolders                   = 0x360A
+	idContainerClass               = 0x3613
+	idAttachDataBinary             = 0x3701
+	idAttachExtension              = 0x3703
+	idAttachFilename               = 0x3704
+	idAttachMethod                 = 0x3705
+	idAttachLongFilename           = 0x3707
+	idRenderingPosition            = 0x370B
+	idAttachMimeTag                = 0x370E
+	idAttachContentID              = 0x3712
+	idDisplayType                  = 0x3900
+	idSmtpAddress                  = 0x39FE
+	idGivenName                    = 0x3A06
+	idBusinessTelephoneNumber      = 0x3A08
+	idHomeTelephoneNumber          = 0x3A09
+	idSurname                      = 0x3A11
+	idCompanyName                  = 0x3A16
+	idTitle                        = 0x3A17
+	idDepartmentName               = 0x3A18
+	idMobileTelephoneNumber        = 0x3A1C
+	idBusiness2TelephoneNumber     = 0x3A1B
+	idBusinessAddressCountry       = 0x3A26
+	idBusinessAddressCity          = 0x3A27
+	idBusinessAddressState         = 0x3A28
+	idBusinessAddressStreet        = 0x3A29
+	idBusinessPostalCode           = 0x3A2A
+	idHome2TelephoneNumber         = 0x3A2F
+	idBirthday                     = 0x3A42
+	idMiddleName                   = 0x3A44
+	idNickname                     = 0x3A4F
+	idHomeAddressCity              = 0x3A59
+	idHomeAddressCountry           = 0x3A5A
+	idHomeAddressPostalCode        = 0x3A5B
+	idHomeAddressState             = 0x3A5C
+	idHomeAddressStreet            = 0x3A5D
+	idInternetCodepage             = 0x3FDE
+	idSenderSmtpAddress            = 0x5D01
+	idSentRepresentingSmtpAddress  = 0x5D02
+	idRecipientDisplayName         = 0x5FF6
+	idLtpRowID                     = 0x67F2
+	idLtpRowVer                    = 0x67F3
+	idPstPassword                  = 0x67FF
+	idAttachmentHidden             = 0x7FFE
+
+	idNameidBucketCount  = 0x0001
+	idNameidStreamGUID   = 0x0002
+	idNameidStreamEntry  = 0x0003
+	idNameidStreamString = 0x0004
+	idNameidBucketBase   = 0x1000
+)
+
+const (
+	tagLtpRowID  = idLtpRowID<<16 | ptInt32
+	tagLtpRowVer = idLtpRowVer<<16 | ptInt32
+)
+
+// named properties are assigned ids starting at 0x8000, in the order they
+// appear in namedProps.
+const (
+	namedFileUnder uint16 = 0x8000 + iota
+	namedEmail1DisplayName
+	namedEmail1AddressType
+	namedEmail1EmailAddress
+	namedEmail1OriginalDisplayName
+	namedEmail2DisplayName
+	namedEmail2AddressType
+	namedEmail2EmailAddress
+	namedEmail2OriginalDisplayName
+	namedEmail3DisplayName
+	namedEmail3AddressType
+	namedEmail3EmailAddress
+	namedEmail3OriginalDisplayName
+	namedBusyStatus
+	namedLocation
+	namedAppointmentStartWhole
+	namedAppointmentEndWhole
+	namedAppointmentDuration
+	namedAppointmentSubType
+)
+
+const namedBase = 0x8000
+
+// property set guids, as stored on disk.  Indexes in the name to id map
+// start at 3; 1 and 2 are reserved for PS_MAPI and PS_PUBLIC_STRINGS.
+var propertySets = [][16]byte{
+	// PSETID_Address {00062004-0000-0000-C000-000000000046}
+	{0x04, 0x20, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46},
+	// PSETID_Appointment {00062002-0000-0000-C000-000000000046}
+	{0x02, 0x20, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46},
+}
+
+const (
+	psetidAddress = iota + 3
+	psetidAppointment
+)
+
+type namedProp struct {
+	guid uint16
+	lid  uint32
+}
+
+var namedProps = [...]namedProp{
+	namedFileUnder - namedBase:                 {psetidAddress, 0x8005},
+	namedEmail1DisplayName - namedBase:         {psetidAddress, 0x8080},
+	namedEmail1AddressType - namedBase:         {psetidAddress, 0x8082},
+	namedEmail1EmailAddress - namedBase:        {psetidAddress, 0x8083},
+	namedEmail1OriginalDisplayName - namedBase: {psetidAddress, 0x8084},
+	namedEmail2DisplayName - namedBase:         {psetidAddress, 0x8090},
+	namedEmail2AddressType - namedBase:         {psetidAddress, 0x8092},
+	namedEmail2EmailAddress - namedBase:        {psetidAddress, 0x8093},
+	namedEmail2OriginalDisplayName - namedBase: {psetidAddress, 0x8094},
+	namedEmail3DisplayName - namedBase:         {psetidAddress, 0x80A0},
+	namedEmail3AddressType - namedBase:         {psetidAddress, 0x80A2},
+	namedEmail3EmailAddress - namedBase:        {psetidAddress, 0x80A3},
+	namedEmail3OriginalDisplayName - namedBase: {psetidAddress, 0x80A4},
+	namedBusyStatus - namedBase:                {psetidAppointment, 0x8205},
+	namedLocation - namedBase:                  {psetidAppointment, 0x8208},
+	namedAppointmentStartWhole - namedBase:     {psetidAppointment, 0x820D},
+	namedAppointmentEndWhole - namedBase:       {psetidAppointment, 0x820E},
+	namedAppointmentDuration - namedBase:       {psetidAppointment, 0x8213},
+	namedAppointmentSubType - namedBase:        {psetidAppointment, 0x8215},
+}
+
+// emailNamedProps lists the display name, address type, address and
+// original display name ids for each of a contact's three email slots.
+var emailNamedProps = [3][4]uint16{
+	{namedEmail1DisplayName, namedEmail1AddressType, namedEmail1EmailAddress, namedEmail1OriginalDisplayName},
+	{namedEmail2DisplayName, namedEmail2AddressType, namedEmail2EmailAddress, namedEmail2OriginalDisplayName},
+	{namedEmail3DisplayName, namedEmail3AddressType, namedEmail3EmailAddress, namedEmail3OriginalDisplayName},
+}
+
+// ---------------------------------------------------------------------------
+// values
+// ---------------------------------------------------------------------------
+
+type property struct {
+	tag   uint32
+	value []byte
+}
+
+func (p property) id() uint16  { return uint16(p.tag >> 16) }
+func (p property) typ() uint16 { return uint16(p.tag) }
+
+func propType(tag uint32) uint16 { return uint16(tag) }
+
+// fixedSize returns the size of fixed length property types, or 0 for
+// variable length types.
+func fixedSize(typ uint16) int {
+	switch typ {
+	case ptBoolean:
+		return 1
+	case ptInt16:
+		return 2
+	case ptInt32:
+		return 4
+	case ptInt64, ptTime:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// cellSize returns the size of a table column of the given type.  Variable
+// length values are referenced by a four byte hnid.
+func cellSize(typ uint16) int {
+	if n := fixedSize(typ); n > 0 {
+		return n
+	}
+
+	return 4
+}
+
+// propList accumulates properties.  Empty strings, binaries and times are
+// skipped, so that callers can add optional values unconditionally.
+type propList []property
+
+func (pl *propList) add(id, typ uint16, v []byte) {
+	*pl = append(*pl, property{tag: uint32(id)<<16 | uint32(typ), value: v})
+}
+
+func (pl *propList) str(id uint16, v string) {
+	if len(v) > 0 {
+		pl.add(id, ptString, encodeString(v))
+	}
+}
+
+func (pl *propList) binary(id uint16, v []byte) {
+	if len(v) > 0 {
+		pl.add(id, ptBinary, v)
+	}
+}
+
+func (pl *propList) int32(id uint16, v int32) {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(v))
+	pl.add(id, ptInt32, b)
+}
+
+func (pl *propList) bool(id uint16, v bool) {
+	b := []byte{0}
+	if v {
+		b[0] = 1
+	}
+
+	pl.add(id, ptBoolean, b)
+}
+
+func (pl *propList) time(id uint16, v time.Time) {
+	if !v.IsZero() {
+		pl.add(id, ptTime, encodeTime(v))
+	}
+}
+
+// values returns the values of the listed tags, for use as a table row.
+func (pl propList) values(tags []uint32) map[uint32][]byte {
+	result := map[uint32][]byte{}
+
+	for _, p := range pl {
+		for _, t := range tags {
+			if p.tag == t {
+				result[t] = p.value
+			}
+		}
+	}
+
+	return result
+}
+
+func encodeString(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u))
+
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+
+	return b
+}
+
+// filetimeEpochDelta is the number of 100ns intervals between 1601-01-01
+// and the unix epoch.
+const filetimeEpochDelta = 116444736000000000
+
+func encodeTime(t time.Time) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(t.UnixNano()/100+filetimeEpochDelta))
+
+	return b
+}
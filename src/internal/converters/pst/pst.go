@@ -0,0 +1,182 @@
+package pst
+
+// This package writes mailbox data into an Outlook data file (.pst), so
+// that exports can be ingested by eDiscovery and compliance tooling which
+// only understands pst.  Only writing is supported; files are produced
+// from scratch in a single pass, and cannot be reopened for appending.
+
+// The format is layered.  The node database (ndb.go) stores opaque nodes
+// in blocks, indexed by two btrees.  The lists, tables and properties
+// layer (ltp.go) builds heaps, property contexts and table contexts on
+// top of those nodes.  The messaging layer (messaging.go) arranges the
+// property and table contexts into a message store, folders, messages,
+// contacts and appointments.
+// Ref: https://learn.microsoft.com/en-us/openspecs/office_file_formats/ms-pst
+
+import "time"
+
+// nid types, stored in the low five bits of every node id.
+const (
+	nidTypeHID                = 0x00
+	nidTypeInternal           = 0x01
+	nidTypeNormalFolder       = 0x02
+	nidTypeSearchFolder       = 0x03
+	nidTypeNormalMessage      = 0x04
+	nidTypeAttachment         = 0x05
+	nidTypeAssocMessage       = 0x08
+	nidTypeHierarchyTable     = 0x0D
+	nidTypeContentsTable      = 0x0E
+	nidTypeAssocContentsTable = 0x0F
+	nidTypeSearchContents     = 0x10
+	nidTypeAttachmentTable    = 0x11
+	nidTypeRecipientTable     = 0x12
+	nidTypeLTP                = 0x1F
+)
+
+// well known nids
+const (
+	nidMessageStore          = 0x21
+	nidNameToIDMap           = 0x61
+	nidRootFolder            = 0x122
+	nidSearchManagementQueue = 0x1E1
+	nidSearchActivityList    = 0x201
+	nidHierarchyTemplate     = 0x60D
+	nidContentsTemplate      = 0x60E
+	nidAssocContentsTemplate = 0x60F
+	nidSearchTemplate        = 0x610
+	nidAttachmentTable       = 0x671
+	nidRecipientTable        = 0x692
+)
+
+func makeNID(index, nidType uint32) uint32 {
+	return index<<5 | nidType
+}
+
+// tableNID produces the nid of one of the tables belonging to a folder.
+func tableNID(folder, nidType uint32) uint32 {
+	return folder&^0x1F | nidType
+}
+
+// FolderKind determines which type of items a folder holds.  Outlook uses
+// the kind to decide how the folder's contents are displayed.
+type FolderKind int
+
+const (
+	MailFolder FolderKind = iota
+	ContactFolder
+	CalendarFolder
+)
+
+func (k FolderKind) containerClass() string {
+	switch k {
+	case ContactFolder:
+		return "IPF.Contact"
+	case CalendarFolder:
+		return "IPF.Appointment"
+	default:
+		return "IPF.Note"
+	}
+}
+
+// Importance mirrors the values of PidTagImportance.
+type Importance int32
+
+const (
+	ImportanceLow Importance = iota
+	ImportanceNormal
+	ImportanceHigh
+)
+
+// BusyStatus mirrors the values of PidLidBusyStatus.
+type BusyStatus int32
+
+const (
+	BusyStatusFree BusyStatus = iota
+	BusyStatusTentative
+	BusyStatusBusy
+	BusyStatusOutOfOffice
+	BusyStatusWorkingElsewhere
+)
+
+type Address struct {
+	Name  string
+	Email string
+}
+
+type Attachment struct {
+	Name        string
+	ContentType string
+	ContentID   string
+	// Inline attachments are referenced from the html body, and are
+	// hidden from the attachment list.
+	Inline  bool
+	Content []byte
+}
+
+type Message struct {
+	InternetMessageID string
+	Subject           string
+	From              Address
+	Sender            Address
+	To                []Address
+	Cc                []Address
+	Bcc               []Address
+	Created           time.Time
+	Modified          time.Time
+	Sent              time.Time
+	Received          time.Time
+	Importance        Importance
+	Read              bool
+	Draft             bool
+	Body              string
+	HTMLBody          string
+	Attachments       []Attachment
+}
+
+type PostalAddress struct {
+	Street     string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
+type Contact struct {
+	DisplayName     string
+	GivenName       string
+	MiddleName      string
+	Surname         string
+	NickName        string
+	CompanyName     string
+	JobTitle        string
+	Department      string
+	Emails          []Address
+	BusinessPhones  []string
+	HomePhones      []string
+	MobilePhone     string
+	BusinessAddress PostalAddress
+	HomeAddress     PostalAddress
+	Birthday        time.Time
+	Notes           string
+	Created         time.Time
+	Modified        time.Time
+}
+
+type Appointment struct {
+	Subject     string
+	Location    string
+	Organizer   Address
+	Required    []Address
+	Optional    []Address
+	Resources   []Address
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	BusyStatus  BusyStatus
+	Importance  Importance
+	Body        string
+	HTMLBody    string
+	Created     time.Time
+	Modified    time.Time
+	Attachments []Attachment
+}
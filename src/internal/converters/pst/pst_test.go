@@ -0,0 +1,380 @@
+package pst
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type PSTUnitSuite struct {
+	tester.Suite
+}
+
+func TestPSTUnitSuite(t *testing.T) {
+	suite.Run(t, &PSTUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// writePST runs fn against a new writer, and returns the parsed result.
+func writePST(t *testing.T, fn func(w *Writer)) *reader {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.pst"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer f.Close()
+
+	w, err := NewWriter(f, "mailbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	fn(w)
+
+	err = w.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err, clues.ToCore(err))
+
+	r, err := readPST(data)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return r
+}
+
+func nodeProps(t *testing.T, r *reader, nid uint32) map[uint32][]byte {
+	h, err := r.node(nid)
+	require.NoError(t, err, clues.ToCore(err))
+
+	props, err := h.props()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return props
+}
+
+func tableRows(t *testing.T, r *reader, nid uint32) map[uint32]map[uint32][]byte {
+	h, err := r.node(nid)
+	require.NoError(t, err, clues.ToCore(err))
+
+	rows, err := h.rows()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return rows
+}
+
+// childFolder finds a folder by name within the hierarchy table of parent.
+func childFolder(t *testing.T, r *reader, parent uint32, name string) uint32 {
+	rows := tableRows(t, r, tableNID(parent, nidTypeHierarchyTable))
+
+	for id, row := range rows {
+		if decodeString(row[strTag(idDisplayName)]) == name {
+			assert.Equal(t, parent, r.nodes[id].nidParent, "folder parent")
+			return id
+		}
+	}
+
+	require.Failf(t, "folder not found", "folder: %s", name)
+
+	return 0
+}
+
+func (suite *PSTUnitSuite) TestWriter_emptyStore() {
+	t := suite.T()
+
+	r := writePST(t, func(w *Writer) {})
+
+	store := nodeProps(t, r, nidMessageStore)
+	assert.Equal(t, "mailbox", decodeString(store[strTag(idDisplayName)]))
+
+	ipmEntryID := store[idIpmSubTreeEntryID<<16|ptBinary]
+	require.Len(t, ipmEntryID, 24)
+	assert.Equal(t, store[idRecordKey<<16|ptBinary], ipmEntryID[4:20])
+
+	ipm := binary.LittleEndian.Uint32(ipmEntryID[20:])
+	assert.Equal(t, ipm, childFolder(t, r, nidRootFolder, "Top of Personal Folders"))
+	childFolder(t, r, nidRootFolder, "Search Root")
+	childFolder(t, r, ipm, "Deleted Items")
+
+	for _, nid := range []uint32{
+		nidNameToIDMap,
+		nidSearchManagementQueue,
+		nidSearchActivityList,
+		nidHierarchyTemplate,
+		nidContentsTemplate,
+		nidAssocContentsTemplate,
+		nidSearchTemplate,
+		nidAttachmentTable,
+		nidRecipientTable,
+	} {
+		assert.Contains(t, r.nodes, nid)
+	}
+
+	nameToID := nodeProps(t, r, nidNameToIDMap)
+	assert.Len(t, nameToID[idNameidStreamEntry<<16|ptBinary], 8*len(namedProps))
+}
+
+func (suite *PSTUnitSuite) TestWriter_message() {
+	var (
+		t        = suite.T()
+		sent     = time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+		inline   = bytes.Repeat([]byte("png"), 100)
+		largeAtt = bytes.Repeat([]byte("0123456789"), 20000)
+		html     = "<html><body>" + strings.Repeat("hello ", 1000) + "</body></html>"
+		inbox    uint32
+	)
+
+	r := writePST(t, func(w *Writer) {
+		f := w.Root().FolderPath(MailFolder, "Inbox", "Projects")
+		inbox = f.nid
+
+		err := f.AddMessage(Message{
+			InternetMessageID: "<id@example.com>",
+			Subject:           "quarterly report",
+			From:              Address{Name: "Alice", Email: "alice@example.com"},
+			To:                []Address{{Name: "Bob", Email: "bob@example.com"}},
+			Cc:                []Address{{Email: "carol@example.com"}},
+			Sent:              sent,
+			Received:          sent,
+			Importance:        ImportanceHigh,
+			Body:              "hello",
+			HTMLBody:          html,
+			Attachments: []Attachment{
+				{Name: "image.png", ContentType: "image/png", ContentID: "img", Inline: true, Content: inline},
+				{Name: "report.txt", ContentType: "text/plain", Content: largeAtt},
+			},
+		})
+		require.NoError(t, err, clues.ToCore(err))
+	})
+
+	childFolder(t, r, childFolder(t, r, r.ipmSubtree(t), "Inbox"), "Projects")
+
+	folder := nodeProps(t, r, inbox)
+	assert.Equal(t, "IPF.Note", decodeString(folder[strTag(idContainerClass)]))
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(folder[idContentCount<<16|ptInt32]))
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(folder[idContentUnreadCount<<16|ptInt32]))
+
+	contents := tableRows(t, r, tableNID(inbox, nidTypeContentsTable))
+	require.Len(t, contents, 1)
+
+	var msgNID uint32
+
+	for id, row := range contents {
+		msgNID = id
+
+		assert.Equal(t, "quarterly report", decodeString(row[strTag(idSubject)]))
+		assert.Equal(t, "Bob", decodeString(row[strTag(idDisplayTo)]))
+		assert.Equal(t, encodeTime(sent), row[idClientSubmitTime<<16|ptTime])
+	}
+
+	assert.Equal(t, inbox, r.nodes[msgNID].nidParent)
+
+	h, err := r.node(msgNID)
+	require.NoError(t, err, clues.ToCore(err))
+
+	props, err := h.props()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "IPM.Note", decodeString(props[strTag(idMessageClass)]))
+	assert.Equal(t, "hello", decodeString(props[strTag(idBody)]))
+	assert.Equal(t, html, string(props[idHTML<<16|ptBinary]), "html stored in a subnode")
+	assert.Equal(t, "alice@example.com", decodeString(props[strTag(idSenderEmailAddress)]))
+	assert.Equal(t, "Alice", decodeString(props[strTag(idSentRepresentingName)]))
+	assert.Equal(t, "<id@example.com>", decodeString(props[strTag(idInternetMessageID)]))
+	assert.Equal(t, uint32(ImportanceHigh), binary.LittleEndian.Uint32(props[idImportance<<16|ptInt32]))
+	assert.Equal(t, uint32(msgFlagHasAttch), binary.LittleEndian.Uint32(props[idMessageFlags<<16|ptInt32]))
+
+	recipTable, err := h.subnode(nidRecipientTable)
+	require.NoError(t, err, clues.ToCore(err))
+
+	recips, err := recipTable.rows()
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, recips, 2)
+	assert.Equal(t, "bob@example.com", decodeString(recips[0][strTag(idSmtpAddress)]))
+	assert.Equal(t, uint32(recipientCc), binary.LittleEndian.Uint32(recips[1][idRecipientType<<16|ptInt32]))
+
+	attTable, err := h.subnode(nidAttachmentTable)
+	require.NoError(t, err, clues.ToCore(err))
+
+	atts, err := attTable.rows()
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, atts, 2)
+
+	found := map[string][]byte{}
+
+	for id, row := range atts {
+		att, err := h.subnode(id)
+		require.NoError(t, err, clues.ToCore(err))
+
+		attProps, err := att.props()
+		require.NoError(t, err, clues.ToCore(err))
+
+		name := decodeString(row[strTag(idAttachLongFilename)])
+		assert.Equal(t, name, decodeString(attProps[strTag(idAttachLongFilename)]))
+
+		found[name] = attProps[idAttachDataBinary<<16|ptBinary]
+	}
+
+	assert.Equal(t, inline, found["image.png"])
+	assert.Equal(t, largeAtt, found["report.txt"])
+}
+
+func (suite *PSTUnitSuite) TestWriter_contactsAndAppointments() {
+	var (
+		t                   = suite.T()
+		start               = time.Date(2024, 2, 3, 9, 0, 0, 0, time.UTC)
+		contacts, calendars uint32
+	)
+
+	r := writePST(t, func(w *Writer) {
+		cf := w.Root().Folder("Contacts", ContactFolder)
+		contacts = cf.nid
+
+		err := cf.AddContact(Contact{
+			DisplayName:    "Dana Scully",
+			GivenName:      "Dana",
+			Surname:        "Scully",
+			Emails:         []Address{{Email: "dana@fbi.gov"}, {Email: "ds@example.com"}},
+			BusinessPhones: []string{"555-0100"},
+			MobilePhone:    "555-0101",
+		})
+		require.NoError(t, err, clues.ToCore(err))
+
+		ef := w.Root().Folder("Calendar", CalendarFolder)
+		calendars = ef.nid
+
+		err = ef.AddAppointment(Appointment{
+			Subject:    "standup",
+			Location:   "room 1",
+			Organizer:  Address{Name: "Alice", Email: "alice@example.com"},
+			Required:   []Address{{Name: "Bob", Email: "bob@example.com"}},
+			Resources:  []Address{{Name: "Room 1", Email: "room1@example.com"}},
+			Start:      start,
+			End:        start.Add(30 * time.Minute),
+			BusyStatus: BusyStatusBusy,
+		})
+		require.NoError(t, err, clues.ToCore(err))
+	})
+
+	assert.Equal(
+		t,
+		"IPF.Contact",
+		decodeString(nodeProps(t, r, contacts)[strTag(idContainerClass)]))
+
+	for id := range tableRows(t, r, tableNID(contacts, nidTypeContentsTable)) {
+		props := nodeProps(t, r, id)
+
+		assert.Equal(t, "IPM.Contact", decodeString(props[strTag(idMessageClass)]))
+		assert.Equal(t, "Dana", decodeString(props[strTag(idGivenName)]))
+		assert.Equal(t, "555-0101", decodeString(props[strTag(idMobileTelephoneNumber)]))
+		assert.Equal(t, "dana@fbi.gov", decodeString(props[strTag(namedEmail1EmailAddress)]))
+		assert.Equal(t, "ds@example.com", decodeString(props[strTag(namedEmail2EmailAddress)]))
+		assert.Equal(t, "Scully, Dana", decodeString(props[strTag(namedFileUnder)]))
+	}
+
+	assert.Equal(
+		t,
+		"IPF.Appointment",
+		decodeString(nodeProps(t, r, calendars)[strTag(idContainerClass)]))
+
+	for id := range tableRows(t, r, tableNID(calendars, nidTypeContentsTable)) {
+		h, err := r.node(id)
+		require.NoError(t, err, clues.ToCore(err))
+
+		props, err := h.props()
+		require.NoError(t, err, clues.ToCore(err))
+
+		assert.Equal(t, "IPM.Appointment", decodeString(props[strTag(idMessageClass)]))
+		assert.Equal(t, "room 1", decodeString(props[strTag(namedLocation)]))
+		assert.Equal(t, encodeTime(start), props[uint32(namedAppointmentStartWhole)<<16|ptTime])
+		assert.Equal(t, uint32(30), binary.LittleEndian.Uint32(props[uint32(namedAppointmentDuration)<<16|ptInt32]))
+		assert.Equal(t, uint32(BusyStatusBusy), binary.LittleEndian.Uint32(props[uint32(namedBusyStatus)<<16|ptInt32]))
+
+		recipTable, err := h.subnode(nidRecipientTable)
+		require.NoError(t, err, clues.ToCore(err))
+
+		recips, err := recipTable.rows()
+		require.NoError(t, err, clues.ToCore(err))
+		require.Len(t, recips, 2)
+		assert.Equal(t, uint32(attendeeResource), binary.LittleEndian.Uint32(recips[1][idRecipientType<<16|ptInt32]))
+	}
+}
+
+// TestWriter_manyItems produces enough data to span multiple allocation
+// map regions, multi-level btrees, and tables stored outside the heap.
+func (suite *PSTUnitSuite) TestWriter_manyItems() {
+	var (
+		t     = suite.T()
+		count = 2000
+		body  = strings.Repeat("lorem ipsum ", 100)
+		inbox uint32
+	)
+
+	r := writePST(t, func(w *Writer) {
+		f := w.Root().Folder("Inbox", MailFolder)
+		inbox = f.nid
+
+		for i := 0; i < count; i++ {
+			err := f.AddMessage(Message{
+				Subject: strings.Repeat("x", i%50),
+				Body:    body,
+				Read:    i%2 == 0,
+			})
+			require.NoError(t, err, clues.ToCore(err))
+		}
+	})
+
+	folder := nodeProps(t, r, inbox)
+	assert.Equal(t, uint32(count), binary.LittleEndian.Uint32(folder[idContentCount<<16|ptInt32]))
+	assert.Equal(t, uint32(count/2), binary.LittleEndian.Uint32(folder[idContentUnreadCount<<16|ptInt32]))
+
+	contents := tableRows(t, r, tableNID(inbox, nidTypeContentsTable))
+	assert.Len(t, contents, count)
+
+	for id := range contents {
+		assert.Equal(t, body, decodeString(nodeProps(t, r, id)[strTag(idBody)]))
+	}
+}
+
+func (suite *PSTUnitSuite) TestWriter_closeTwice() {
+	t := suite.T()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.pst"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer f.Close()
+
+	w, err := NewWriter(f, "mailbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = w.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = w.Close()
+	assert.Error(t, err, clues.ToCore(err))
+
+	err = w.Root().AddMessage(Message{})
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *PSTUnitSuite) TestNDB_allocSkipsMapPages() {
+	t := suite.T()
+	db := newNDB(nil)
+
+	// the first region starts with an amap and a pmap page.
+	assert.Equal(t, uint64(firstAMapOffset+2*pageSize), db.alloc(blockAlign, blockAlign))
+
+	// allocations never straddle regions, and skip the next region's amap.
+	db.eof = regionStart(1) - blockAlign
+	assert.Equal(t, uint64(regionStart(1)+pageSize), db.alloc(2*blockAlign, blockAlign))
+}
+
+func (r *reader) ipmSubtree(t *testing.T) uint32 {
+	return childFolder(t, r, nidRootFolder, "Top of Personal Folders")
+}
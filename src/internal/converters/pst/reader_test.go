@@ -0,0 +1,518 @@
+package pst
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+)
+
+// This file holds a minimal pst reader, used by the tests to verify the
+// output of the writer.  Every structure which gets read is validated
+// against its signature and crc, so that a mismatch fails loudly.
+
+type reader struct {
+	data   []byte
+	blocks map[uint64]bbtEntry
+	nodes  map[uint32]nbtEntry
+}
+
+func readPST(data []byte) (*reader, error) {
+	if len(data) < headerSize || string(data[:4]) != "!BDN" {
+		return nil, fmt.Errorf("missing pst magic")
+	}
+
+	if binary.LittleEndian.Uint16(data[10:]) != 23 {
+		return nil, fmt.Errorf("not a unicode pst")
+	}
+
+	if crc := computeCRC(data[8 : 8+471]); crc != binary.LittleEndian.Uint32(data[4:]) {
+		return nil, fmt.Errorf("partial header crc mismatch")
+	}
+
+	if crc := computeCRC(data[8 : 8+516]); crc != binary.LittleEndian.Uint32(data[524:]) {
+		return nil, fmt.Errorf("full header crc mismatch")
+	}
+
+	root := data[180:]
+
+	if eof := binary.LittleEndian.Uint64(root[4:]); eof != uint64(len(data)) {
+		return nil, fmt.Errorf("file size %d doesn't match eof %d", len(data), eof)
+	}
+
+	r := &reader{
+		data:   data,
+		blocks: map[uint64]bbtEntry{},
+		nodes:  map[uint32]nbtEntry{},
+	}
+
+	err := r.walkBTree(ptypeNBT, binary.LittleEndian.Uint64(root[44:]), func(e []byte) {
+		n := nbtEntry{
+			nid:       uint32(binary.LittleEndian.Uint64(e[0:])),
+			bidData:   binary.LittleEndian.Uint64(e[8:]),
+			bidSub:    binary.LittleEndian.Uint64(e[16:]),
+			nidParent: binary.LittleEndian.Uint32(e[24:]),
+		}
+		r.nodes[n.nid] = n
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.walkBTree(ptypeBBT, binary.LittleEndian.Uint64(root[60:]), func(e []byte) {
+		b := bbtEntry{
+			bid: binary.LittleEndian.Uint64(e[0:]),
+			ib:  binary.LittleEndian.Uint64(e[8:]),
+			cb:  binary.LittleEndian.Uint16(e[16:]),
+		}
+		r.blocks[b.bid] = b
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, r.checkAMaps()
+}
+
+func (r *reader) page(ib uint64, ptype byte) ([]byte, error) {
+	if ib+pageSize > uint64(len(r.data)) {
+		return nil, fmt.Errorf("page %d out of bounds", ib)
+	}
+
+	p := r.data[ib : ib+pageSize]
+	trailer := p[pageSize-16:]
+
+	if trailer[0] != ptype || trailer[1] != ptype {
+		return nil, fmt.Errorf("page %d has type %x, expected %x", ib, trailer[0], ptype)
+	}
+
+	if computeCRC(p[:pageSize-16]) != binary.LittleEndian.Uint32(trailer[4:]) {
+		return nil, fmt.Errorf("page %d crc mismatch", ib)
+	}
+
+	return p, nil
+}
+
+func (r *reader) walkBTree(ptype byte, ib uint64, leaf func([]byte)) error {
+	p, err := r.page(ib, ptype)
+	if err != nil {
+		return err
+	}
+
+	var (
+		cEnt  = int(p[btPageEntriesSize])
+		cbEnt = int(p[btPageEntriesSize+2])
+		level = p[btPageEntriesSize+3]
+	)
+
+	for i := 0; i < cEnt; i++ {
+		e := p[i*cbEnt : (i+1)*cbEnt]
+
+		if level == 0 {
+			leaf(e)
+			continue
+		}
+
+		if err := r.walkBTree(ptype, binary.LittleEndian.Uint64(e[16:]), leaf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkAMaps ensures every block is marked as allocated.
+func (r *reader) checkAMaps() error {
+	for _, b := range r.blocks {
+		size := alignUp(uint64(b.cb)+blockTrailerSize, blockAlign)
+
+		for off := b.ib; off < b.ib+size; off += blockAlign {
+			rel := off - firstAMapOffset
+			region := rel / amapRegionSize
+			bit := (rel % amapRegionSize) / blockAlign
+
+			amap, err := r.page(regionStart(region), ptypeAMap)
+			if err != nil {
+				return err
+			}
+
+			if amap[bit/8]&(0x80>>(bit%8)) == 0 {
+				return fmt.Errorf("block %d at %d not marked in the amap", b.bid, off)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *reader) block(bid uint64) ([]byte, error) {
+	b, ok := r.blocks[bid&^1]
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", bid)
+	}
+
+	size := alignUp(uint64(b.cb)+blockTrailerSize, blockAlign)
+	raw := r.data[b.ib : b.ib+size]
+	trailer := raw[size-blockTrailerSize:]
+	data := raw[:b.cb]
+
+	switch {
+	case binary.LittleEndian.Uint16(trailer[0:]) != b.cb:
+		return nil, fmt.Errorf("block %d size mismatch", bid)
+	case binary.LittleEndian.Uint16(trailer[2:]) != computeSig(b.ib, b.bid):
+		return nil, fmt.Errorf("block %d signature mismatch", bid)
+	case binary.LittleEndian.Uint32(trailer[4:]) != computeCRC(data):
+		return nil, fmt.Errorf("block %d crc mismatch", bid)
+	case binary.LittleEndian.Uint64(trailer[8:]) != b.bid:
+		return nil, fmt.Errorf("block %d id mismatch", bid)
+	}
+
+	return data, nil
+}
+
+// chunks returns the data blocks of a node, following any xblocks.
+func (r *reader) chunks(bid uint64) ([][]byte, error) {
+	data, err := r.block(bid)
+	if err != nil {
+		return nil, err
+	}
+
+	if bid&bidInternalFlag == 0 {
+		return [][]byte{data}, nil
+	}
+
+	if data[0] != btypeXBlock {
+		return nil, fmt.Errorf("block %d isn't an xblock", bid)
+	}
+
+	var (
+		result [][]byte
+		total  int
+		cEnt   = int(binary.LittleEndian.Uint16(data[2:]))
+	)
+
+	for i := 0; i < cEnt; i++ {
+		c, err := r.chunks(binary.LittleEndian.Uint64(data[internalBlockHeaderSize+8*i:]))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range c {
+			total += len(b)
+		}
+
+		result = append(result, c...)
+	}
+
+	if total != int(binary.LittleEndian.Uint32(data[4:])) {
+		return nil, fmt.Errorf("xblock %d total size mismatch", bid)
+	}
+
+	return result, nil
+}
+
+func (r *reader) subnodes(bid uint64) (map[uint32]slEntry, error) {
+	result := map[uint32]slEntry{}
+
+	if bid == 0 {
+		return result, nil
+	}
+
+	data, err := r.block(bid)
+	if err != nil {
+		return nil, err
+	}
+
+	if data[0] != btypeSLBlock {
+		return nil, fmt.Errorf("block %d isn't a subnode block", bid)
+	}
+
+	cEnt := int(binary.LittleEndian.Uint16(data[2:]))
+
+	for i := 0; i < cEnt; i++ {
+		if data[1] == 0 {
+			e := data[internalBlockHeaderSize+24*i:]
+			sl := slEntry{
+				nid:     uint32(binary.LittleEndian.Uint64(e[0:])),
+				bidData: binary.LittleEndian.Uint64(e[8:]),
+				bidSub:  binary.LittleEndian.Uint64(e[16:]),
+			}
+			result[sl.nid] = sl
+
+			continue
+		}
+
+		e := data[internalBlockHeaderSize+16*i:]
+
+		child, err := r.subnodes(binary.LittleEndian.Uint64(e[8:]))
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range child {
+			result[k] = v
+		}
+	}
+
+	return result, nil
+}
+
+// ---------------------------------------------------------------------------
+// ltp
+// ---------------------------------------------------------------------------
+
+type hn struct {
+	r      *reader
+	blocks [][]byte
+	subs   map[uint32]slEntry
+}
+
+func (r *reader) node(nid uint32) (*hn, error) {
+	n, ok := r.nodes[nid]
+	if !ok {
+		return nil, fmt.Errorf("node %x not found", nid)
+	}
+
+	return r.heapOn(n.bidData, n.bidSub)
+}
+
+func (r *reader) heapOn(bidData, bidSub uint64) (*hn, error) {
+	blocks, err := r.chunks(bidData)
+	if err != nil {
+		return nil, err
+	}
+
+	if blocks[0][2] != hnSignature {
+		return nil, fmt.Errorf("missing heap signature")
+	}
+
+	subs, err := r.subnodes(bidSub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hn{r: r, blocks: blocks, subs: subs}, nil
+}
+
+// subnode opens the heap of one of the node's subnodes.
+func (h *hn) subnode(nid uint32) (*hn, error) {
+	s, ok := h.subs[nid]
+	if !ok {
+		return nil, fmt.Errorf("subnode %x not found", nid)
+	}
+
+	return h.r.heapOn(s.bidData, s.bidSub)
+}
+
+func (h *hn) alloc(hid uint32) []byte {
+	var (
+		b     = h.blocks[hid>>16]
+		idx   = int(hid>>5) & 0x7FF
+		ibMap = binary.LittleEndian.Uint16(b)
+		pm    = b[ibMap:]
+		start = binary.LittleEndian.Uint16(pm[4+2*(idx-1):])
+		end   = binary.LittleEndian.Uint16(pm[4+2*idx:])
+	)
+
+	return b[start:end]
+}
+
+func (h *hn) value(hnid uint32) ([]byte, error) {
+	if hnid&0x1F == nidTypeHID {
+		return h.alloc(hnid), nil
+	}
+
+	s, ok := h.subs[hnid]
+	if !ok {
+		return nil, fmt.Errorf("value subnode %x not found", hnid)
+	}
+
+	chunks, err := h.r.chunks(s.bidData)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	for _, c := range chunks {
+		result = append(result, c...)
+	}
+
+	return result, nil
+}
+
+func (h *hn) bth(hid uint32) ([][]byte, int, error) {
+	hdr := h.alloc(hid)
+
+	if hdr[0] != hnClientBTH {
+		return nil, 0, fmt.Errorf("missing bth signature")
+	}
+
+	var (
+		cbKey  = int(hdr[1])
+		cbEnt  = int(hdr[2])
+		levels = int(hdr[3])
+		root   = binary.LittleEndian.Uint32(hdr[4:])
+	)
+
+	if root == 0 {
+		return nil, cbKey, nil
+	}
+
+	var walk func(hid uint32, level int) [][]byte
+
+	walk = func(hid uint32, level int) [][]byte {
+		var (
+			data   = h.alloc(hid)
+			result [][]byte
+		)
+
+		if level == 0 {
+			for i := 0; i < len(data); i += cbKey + cbEnt {
+				result = append(result, data[i:i+cbKey+cbEnt])
+			}
+
+			return result
+		}
+
+		for i := 0; i < len(data); i += cbKey + 4 {
+			child := binary.LittleEndian.Uint32(data[i+cbKey:])
+			result = append(result, walk(child, level-1)...)
+		}
+
+		return result
+	}
+
+	return walk(root, levels), cbKey, nil
+}
+
+// props reads a property context, returning the raw value of each tag.
+// Inline values are returned as four bytes.
+func (h *hn) props() (map[uint32][]byte, error) {
+	if h.blocks[0][3] != hnClientPC {
+		return nil, fmt.Errorf("not a property context")
+	}
+
+	records, _, err := h.bth(binary.LittleEndian.Uint32(h.blocks[0][4:]))
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[uint32][]byte{}
+
+	for _, rec := range records {
+		var (
+			tag  = uint32(binary.LittleEndian.Uint16(rec[0:]))<<16 | uint32(binary.LittleEndian.Uint16(rec[2:]))
+			hnid = binary.LittleEndian.Uint32(rec[4:])
+		)
+
+		if n := fixedSize(propType(tag)); n > 0 && n <= 4 {
+			result[tag] = rec[4:8]
+			continue
+		}
+
+		v, err := h.value(hnid)
+		if err != nil {
+			return nil, err
+		}
+
+		result[tag] = v
+	}
+
+	return result, nil
+}
+
+// rows reads a table context, returning the raw value of each present
+// cell, keyed by row id.
+func (h *hn) rows() (map[uint32]map[uint32][]byte, error) {
+	if h.blocks[0][3] != hnClientTC {
+		return nil, fmt.Errorf("not a table context")
+	}
+
+	var (
+		info     = h.alloc(binary.LittleEndian.Uint32(h.blocks[0][4:]))
+		cCols    = int(info[1])
+		rowSize  = int(binary.LittleEndian.Uint16(info[8:]))
+		cebStart = int(binary.LittleEndian.Uint16(info[6:]))
+		hnidRows = binary.LittleEndian.Uint32(info[14:])
+	)
+
+	index, _, err := h.bth(binary.LittleEndian.Uint32(info[10:]))
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks [][]byte
+
+	switch {
+	case hnidRows == 0:
+	case hnidRows&0x1F == nidTypeHID:
+		blocks = [][]byte{h.alloc(hnidRows)}
+	default:
+		s, ok := h.subs[hnidRows]
+		if !ok {
+			return nil, fmt.Errorf("row matrix subnode not found")
+		}
+
+		if blocks, err = h.r.chunks(s.bidData); err != nil {
+			return nil, err
+		}
+	}
+
+	perBlock := maxBlockData / rowSize
+	result := map[uint32]map[uint32][]byte{}
+
+	for _, rec := range index {
+		var (
+			id  = binary.LittleEndian.Uint32(rec[0:])
+			idx = int(binary.LittleEndian.Uint32(rec[4:]))
+			b   = blocks[idx/perBlock]
+			row = b[(idx%perBlock)*rowSize : (idx%perBlock+1)*rowSize]
+		)
+
+		if binary.LittleEndian.Uint32(row) != id {
+			return nil, fmt.Errorf("row %d has id %d, expected %d", idx, binary.LittleEndian.Uint32(row), id)
+		}
+
+		values := map[uint32][]byte{}
+
+		for i := 0; i < cCols; i++ {
+			var (
+				d    = info[22+8*i:]
+				tag  = binary.LittleEndian.Uint32(d[0:])
+				ib   = int(binary.LittleEndian.Uint16(d[4:]))
+				cb   = int(d[6])
+				iBit = int(d[7])
+			)
+
+			if row[cebStart+iBit/8]&(0x80>>(iBit%8)) == 0 {
+				continue
+			}
+
+			v := row[ib : ib+cb]
+
+			if fixedSize(propType(tag)) == 0 {
+				if v, err = h.value(binary.LittleEndian.Uint32(v)); err != nil {
+					return nil, err
+				}
+			}
+
+			values[tag] = v
+		}
+
+		result[id] = values
+	}
+
+	return result, nil
+}
+
+func decodeString(b []byte) string {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+
+	return string(utf16.Decode(u))
+}
+
+func strTag(id uint16) uint32 {
+	return uint32(id)<<16 | ptString
+}
@@ -60,6 +60,13 @@ type RestoreCollection interface {
 	FetchItemByNamer
 }
 
+// DeltaTokener is implemented by collections whose items were enumerated
+// with a delta query.  DeltaToken returns the token which continues that
+// enumeration, or an empty string if there is none.
+type DeltaTokener interface {
+	DeltaToken() string
+}
+
 // ---------------------------------------------------------------------------
 // Items
 // ---------------------------------------------------------------------------
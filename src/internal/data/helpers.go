@@ -1,6 +1,10 @@
 package data
 
-import "sort"
+import (
+	"sort"
+
+	"github.com/alcionai/corso/src/pkg/path"
+)
 
 // SortRestoreCollections performs an in-place sort on the provided collection.
 func SortRestoreCollections(rcs []RestoreCollection) {
@@ -8,3 +12,17 @@ func SortRestoreCollections(rcs []RestoreCollection) {
 		return rcs[i].FullPath().String() < rcs[j].FullPath().String()
 	})
 }
+
+// FilterByCategory produces the subset of collections whose full path is
+// in the provided category.
+func FilterByCategory(rcs []RestoreCollection, cat path.CategoryType) []RestoreCollection {
+	result := make([]RestoreCollection, 0, len(rcs))
+
+	for _, rc := range rcs {
+		if rc.FullPath().Category() == cat {
+			result = append(result, rc)
+		}
+	}
+
+	return result
+}
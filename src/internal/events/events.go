@@ -30,12 +30,14 @@ const (
 	// Event Keys
 	RepoInit       = "Repo Init"
 	RepoConnect    = "Repo Connect"
+	BackupAnomaly  = "Backup Anomaly"
 	BackupEnd      = "Backup End"
 	RestoreEnd     = "Restore End"
 	ExportEnd      = "Export End"
 	MaintenanceEnd = "Maintenance End"
 
 	// Event Data Keys
+	AnomalyKind      = "anomaly_kind"
 	BackupCreateTime = "backup_creation_time"
 	BackupID         = "backup_id"
 	DataRetrieved    = "data_retrieved"
@@ -0,0 +1,107 @@
+package kopia
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/blob/azure"
+
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+func azureBlobStorage(
+	ctx context.Context,
+	repoOpts repository.Options,
+	s storage.Storage,
+) (blob.Storage, error) {
+	cfg, err := s.ToAzureConfig()
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	if repoOpts.ViewTimestamp != nil {
+		return nil, clues.NewWC(ctx, "point in time repository views are not supported in azure storage")
+	}
+
+	ctx = clues.Add(
+		ctx,
+		"azure_storage_account", cfg.StorageAccount,
+		"azure_container", cfg.Container)
+
+	opts, err := azureOptions(*cfg)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	// kopia builds the client from the account key, SAS token, or client
+	// secret, in that order of preference.
+	store, err := azure.New(ctx, &opts, false)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	return store, nil
+}
+
+// azureOptions produces kopia's azure options for the config.
+func azureOptions(cfg storage.AzureConfig) (azure.Options, error) {
+	domain, err := azureStorageDomain(cfg)
+	if err != nil {
+		return azure.Options{}, err
+	}
+
+	opts := azure.Options{
+		Container:      cfg.Container,
+		Prefix:         cfg.Prefix,
+		StorageAccount: cfg.StorageAccount,
+		StorageDomain:  domain,
+	}
+
+	switch {
+	case len(cfg.AccountKey) > 0:
+		opts.StorageKey = cfg.AccountKey
+	case len(cfg.SASToken) > 0:
+		opts.SASToken = cfg.SASToken
+	case len(cfg.TenantID) > 0 && len(cfg.ClientID) > 0 && len(cfg.ClientSecret) > 0:
+		opts.TenantID = cfg.TenantID
+		opts.ClientID = cfg.ClientID
+		opts.ClientSecret = cfg.ClientSecret
+	default:
+		return azure.Options{}, clues.New(
+			"azure storage requires an account key, a SAS token, or a service principal's tenant id, client id and secret")
+	}
+
+	return opts, nil
+}
+
+// azureStorageDomain produces the blob service domain of the endpoint.
+// kopia always reaches the account at https://<account>.<domain>, so an
+// endpoint given as a url must follow that form.  An empty domain leaves
+// kopia to use its default (blob.core.windows.net).
+func azureStorageDomain(cfg storage.AzureConfig) (string, error) {
+	if len(cfg.Endpoint) == 0 {
+		return "", nil
+	}
+
+	if !strings.Contains(cfg.Endpoint, "://") {
+		return strings.Trim(cfg.Endpoint, "/"), nil
+	}
+
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return "", clues.Wrap(err, "parsing azure endpoint")
+	}
+
+	domain, ok := strings.CutPrefix(u.Host, cfg.StorageAccount+".")
+
+	if u.Scheme != "https" || !ok || len(domain) == 0 || strings.Trim(u.Path, "/") != "" {
+		return "", clues.New("azure endpoint must be https://<storage account>.<domain>").
+			With("endpoint", cfg.Endpoint)
+	}
+
+	return domain, nil
+}
@@ -0,0 +1,214 @@
+package kopia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/blob/azure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	strTD "github.com/alcionai/corso/src/internal/common/str/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+	storeTD "github.com/alcionai/corso/src/pkg/storage/testdata"
+)
+
+type AzureUnitSuite struct {
+	tester.Suite
+}
+
+func TestAzureUnitSuite(t *testing.T) {
+	suite.Run(t, &AzureUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *AzureUnitSuite) TestAzureStorageDomain() {
+	table := []struct {
+		name      string
+		endpoint  string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "default",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "domain",
+			endpoint:  "blob.core.usgovcloudapi.net",
+			expect:    "blob.core.usgovcloudapi.net",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "account url",
+			endpoint:  "https://acct.blob.core.usgovcloudapi.net/",
+			expect:    "blob.core.usgovcloudapi.net",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "http url",
+			endpoint:  "http://acct.blob.core.windows.net",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "url for another account",
+			endpoint:  "https://other.blob.core.windows.net",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "url with a path",
+			endpoint:  "http://127.0.0.1:10000/devstoreaccount1",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := azureStorageDomain(storage.AzureConfig{
+				StorageAccount: "acct",
+				Endpoint:       test.endpoint,
+			})
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *AzureUnitSuite) TestAzureOptions() {
+	table := []struct {
+		name      string
+		creds     credentials.AzureStorage
+		tenantID  string
+		expect    azure.Options
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "account key",
+			creds:     credentials.AzureStorage{AccountKey: "a2V5", SASToken: "sv=sas", ClientSecret: "secret"},
+			tenantID:  "tid",
+			expect:    azure.Options{StorageKey: "a2V5"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "sas token",
+			creds:     credentials.AzureStorage{SASToken: "sv=sas", ClientSecret: "secret"},
+			tenantID:  "tid",
+			expect:    azure.Options{SASToken: "sv=sas"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "service principal",
+			creds:     credentials.AzureStorage{ClientSecret: "secret"},
+			tenantID:  "tid",
+			expect:    azure.Options{TenantID: "tid", ClientID: "cid", ClientSecret: "secret"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "incomplete service principal",
+			creds:     credentials.AzureStorage{ClientSecret: "secret"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no credentials",
+			tenantID:  "tid",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := azureOptions(storage.AzureConfig{
+				AzureStorage:   test.creds,
+				Container:      "ctr",
+				StorageAccount: "acct",
+				Prefix:         "pre/",
+				TenantID:       test.tenantID,
+				ClientID:       "cid",
+			})
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			expect := test.expect
+			expect.Container = "ctr"
+			expect.StorageAccount = "acct"
+			expect.Prefix = "pre/"
+
+			assert.Equal(t, expect, result)
+		})
+	}
+}
+
+func (suite *AzureUnitSuite) TestAzureBlobStorage_PointInTime() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st, err := storage.NewStorage(
+		storage.ProviderAzure,
+		&storage.AzureConfig{
+			AzureStorage:   credentials.AzureStorage{AccountKey: "a2V5"},
+			Container:      "ctr",
+			StorageAccount: "acct",
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	now := time.Now()
+
+	_, err = azureBlobStorage(ctx, repository.Options{ViewTimestamp: &now}, st)
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+// ---------------
+// integration tests
+// ---------------
+
+type AzureIntegrationSuite struct {
+	tester.Suite
+}
+
+// runs against the container in $CORSO_AZURE_CONTAINER of the storage
+// account in $CORSO_AZURE_STORAGE_ACCOUNT.  Azurite can't be used, since
+// kopia only reaches storage accounts at https://<account>.<domain>.
+func TestAzureIntegrationSuite(t *testing.T) {
+	suite.Run(t, &AzureIntegrationSuite{
+		Suite: tester.NewIntegrationSuite(
+			t,
+			[][]string{storeTD.AzureStorageEnvs}),
+	})
+}
+
+func (suite *AzureIntegrationSuite) TestInitializeAndConnect() {
+	t := suite.T()
+	repoNameHash := strTD.NewHashForRepoConfigName()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st := storeTD.NewAzureStorage(t)
+	k := NewConn(st)
+
+	err := k.Initialize(ctx, repository.Options{}, repository.Retention{}, repoNameHash)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Close(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Initialize(ctx, repository.Options{}, repository.Retention{}, repoNameHash)
+	assert.ErrorIs(t, err, ErrorRepoAlreadyExists, clues.ToCore(err))
+
+	err = k.Connect(ctx, repository.Options{}, repoNameHash)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Close(ctx)
+	assert.NoError(t, err, clues.ToCore(err))
+}
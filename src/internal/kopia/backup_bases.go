@@ -20,6 +20,11 @@ type BackupBases interface {
 	// ConvertToAssistBase converts the base with the given backup ID from a merge
 	// base to an assist base.
 	ConvertToAssistBase(backupID model.StableID)
+	// ConvertToMergeBase converts the base with the given backup ID from an
+	// assist base to a merge base.  Merge bases which share a Reason with it
+	// get converted to assist bases, since only one merge base is allowed per
+	// Reason.
+	ConvertToMergeBase(backupID model.StableID)
 	// MergeBases returns a []BackupBase that corresponds to all the bases that
 	// will source unchanged information for this backup during hierarchy merging,
 	// snapshot creation, and details merging.
@@ -106,6 +111,46 @@ func (bb *backupBases) ConvertToAssistBase(backupID model.StableID) {
 	}
 }
 
+func (bb *backupBases) ConvertToMergeBase(backupID model.StableID) {
+	idx := slices.IndexFunc(
+		bb.assistBases,
+		func(base BackupBase) bool {
+			return base.Backup.ID == backupID
+		})
+	if idx < 0 {
+		return
+	}
+
+	base := bb.assistBases[idx]
+	bb.assistBases = slices.Delete(bb.assistBases, idx, idx+1)
+
+	reasons := map[string]struct{}{}
+
+	for _, r := range base.Reasons {
+		reasons[reasonKey(r)] = struct{}{}
+	}
+
+	merge := bb.mergeBases
+	bb.mergeBases = nil
+
+	for _, mb := range merge {
+		overlaps := slices.ContainsFunc(
+			mb.Reasons,
+			func(r identity.Reasoner) bool {
+				_, ok := reasons[reasonKey(r)]
+				return ok
+			})
+
+		if overlaps {
+			bb.assistBases = append(bb.assistBases, mb)
+		} else {
+			bb.mergeBases = append(bb.mergeBases, mb)
+		}
+	}
+
+	bb.mergeBases = append(bb.mergeBases, base)
+}
+
 func (bb *backupBases) MinBackupVersion() int {
 	min := version.NoBackup
 
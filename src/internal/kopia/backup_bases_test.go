@@ -357,6 +357,97 @@ func (suite *BackupBasesUnitSuite) TestConvertToAssistBase() {
 	}
 }
 
+func (suite *BackupBasesUnitSuite) TestConvertToMergeBase() {
+	var (
+		mail     = identity.NewReason("t", "u", path.ExchangeService, path.EmailCategory)
+		contacts = identity.NewReason("t", "u", path.ExchangeService, path.ContactsCategory)
+		makeBase = func(id string, reasons ...identity.Reasoner) BackupBase {
+			return BackupBase{
+				Backup: &backup.Backup{
+					BaseModel:  model.BaseModel{ID: model.StableID(id)},
+					SnapshotID: "its" + id,
+				},
+				ItemDataSnapshot: makeManifest("its"+id, "", ""),
+				Reasons:          reasons,
+			}
+		}
+	)
+
+	bases := []BackupBase{
+		makeBase("1", mail),
+		makeBase("2", contacts),
+		makeBase("3", mail),
+	}
+
+	promoteID := model.StableID("3")
+
+	table := []struct {
+		name string
+		// Below indices specify which items to add from the defined sets above.
+		merge        []int
+		assist       []int
+		expectMerge  []int
+		expectAssist []int
+	}{
+		{
+			name:         "Not In Bases",
+			merge:        []int{0, 1},
+			assist:       []int{0, 1},
+			expectMerge:  []int{0, 1},
+			expectAssist: []int{0, 1},
+		},
+		{
+			name:         "Only In Merges Noops",
+			merge:        []int{1, 2},
+			assist:       []int{1},
+			expectMerge:  []int{1, 2},
+			expectAssist: []int{1},
+		},
+		{
+			name:         "Replaces Merge Base With Same Reason",
+			merge:        []int{0, 1},
+			assist:       []int{2},
+			expectMerge:  []int{1, 2},
+			expectAssist: []int{0},
+		},
+		{
+			name:         "No Merge Base With Same Reason",
+			merge:        []int{1},
+			assist:       []int{0, 2},
+			expectMerge:  []int{1, 2},
+			expectAssist: []int{0},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			bb := &backupBases{}
+
+			for _, i := range test.merge {
+				bb.mergeBases = append(bb.mergeBases, bases[i])
+			}
+
+			for _, i := range test.assist {
+				bb.assistBases = append(bb.assistBases, bases[i])
+			}
+
+			expected := &backupBases{}
+
+			for _, i := range test.expectMerge {
+				expected.mergeBases = append(expected.mergeBases, bases[i])
+			}
+
+			for _, i := range test.expectAssist {
+				expected.assistBases = append(expected.assistBases, bases[i])
+			}
+
+			bb.ConvertToMergeBase(promoteID)
+			AssertBackupBasesEqual(t, expected, bb)
+		})
+	}
+}
+
 func (suite *BackupBasesUnitSuite) TestDisableMergeBases() {
 	t := suite.T()
 
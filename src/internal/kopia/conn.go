@@ -249,6 +249,14 @@ func blobStoreByProvider(
 		return s3BlobStorage(ctx, opts, s)
 	case storage.ProviderFilesystem:
 		return filesystemStorage(ctx, opts, s)
+	case storage.ProviderAzure:
+		return azureBlobStorage(ctx, opts, s)
+	case storage.ProviderGCS:
+		return gcsBlobStorage(ctx, opts, s)
+	case storage.ProviderWebDAV:
+		return webdavStorage(ctx, opts, s)
+	case storage.ProviderSFTP:
+		return sftpStorage(ctx, opts, s)
 	default:
 		return nil, clues.NewWC(ctx, "storage provider details are required")
 	}
@@ -0,0 +1,66 @@
+package kopia
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/blob/gcs"
+
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+func gcsBlobStorage(
+	ctx context.Context,
+	repoOpts repository.Options,
+	s storage.Storage,
+) (blob.Storage, error) {
+	cfg, err := s.ToGCSConfig()
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	ctx = clues.Add(ctx, "gcs_bucket", cfg.Bucket)
+
+	opts, err := gcsOptions(*cfg, repoOpts)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	store, err := gcs.New(ctx, &opts, false)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	return store, nil
+}
+
+// gcsOptions maps the corso config onto kopia's gcs options.  When no
+// service account is provided, kopia falls back to the application
+// default credentials of the host.
+func gcsOptions(
+	cfg storage.GCSConfig,
+	repoOpts repository.Options,
+) (gcs.Options, error) {
+	if repoOpts.ViewTimestamp != nil {
+		return gcs.Options{}, clues.New("point in time repository views are not supported in gcs storage")
+	}
+
+	opts := gcs.Options{
+		BucketName:                    cfg.Bucket,
+		Prefix:                        cfg.Prefix,
+		ServiceAccountCredentialsFile: cfg.CredentialsFile,
+	}
+
+	if len(cfg.CredentialsJSON) > 0 {
+		if !json.Valid([]byte(cfg.CredentialsJSON)) {
+			return gcs.Options{}, clues.New("gcs credentials are not valid json")
+		}
+
+		opts.ServiceAccountCredentialJSON = json.RawMessage(cfg.CredentialsJSON)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,133 @@
+package kopia
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	strTD "github.com/alcionai/corso/src/internal/common/str/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+	storeTD "github.com/alcionai/corso/src/pkg/storage/testdata"
+)
+
+type GCSUnitSuite struct {
+	tester.Suite
+}
+
+func TestGCSUnitSuite(t *testing.T) {
+	suite.Run(t, &GCSUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *GCSUnitSuite) TestGCSOptions() {
+	now := time.Now()
+
+	table := []struct {
+		name       string
+		creds      credentials.GCS
+		repoOpts   repository.Options
+		expectJSON json.RawMessage
+		expectErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "application default credentials",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "credentials file",
+			creds:     credentials.GCS{CredentialsFile: "/keys/sa.json"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:       "credentials json",
+			creds:      credentials.GCS{CredentialsJSON: `{"type":"service_account"}`},
+			expectJSON: json.RawMessage(`{"type":"service_account"}`),
+			expectErr:  assert.NoError,
+		},
+		{
+			name:      "invalid credentials json",
+			creds:     credentials.GCS{CredentialsJSON: "not json"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "point in time",
+			repoOpts:  repository.Options{ViewTimestamp: &now},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			opts, err := gcsOptions(
+				storage.GCSConfig{
+					GCS:    test.creds,
+					Bucket: "bkt",
+					Prefix: "pre/",
+				},
+				test.repoOpts)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, "bkt", opts.BucketName)
+			assert.Equal(t, "pre/", opts.Prefix)
+			assert.Equal(t, test.creds.CredentialsFile, opts.ServiceAccountCredentialsFile)
+			assert.Equal(t, test.expectJSON, opts.ServiceAccountCredentialJSON)
+		})
+	}
+}
+
+// ---------------
+// integration tests
+// ---------------
+
+type GCSEmulatorIntegrationSuite struct {
+	tester.Suite
+}
+
+// runs against fake-gcs-server, ex:
+// docker run -p 4443:4443 -v ./gcs-data:/data fsouza/fake-gcs-server -scheme http
+// where ./gcs-data contains an empty corso-integration-test directory.
+func TestGCSEmulatorIntegrationSuite(t *testing.T) {
+	suite.Run(t, &GCSEmulatorIntegrationSuite{
+		Suite: tester.NewIntegrationSuite(
+			t,
+			[][]string{storeTD.GCSEmulatorStorageEnvs}),
+	})
+}
+
+func (suite *GCSEmulatorIntegrationSuite) TestInitializeAndConnect() {
+	t := suite.T()
+	repoNameHash := strTD.NewHashForRepoConfigName()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st := storeTD.NewGCSEmulatorStorage(t)
+	k := NewConn(st)
+
+	err := k.Initialize(ctx, repository.Options{}, repository.Retention{}, repoNameHash)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Close(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Initialize(ctx, repository.Options{}, repository.Retention{}, repoNameHash)
+	assert.ErrorIs(t, err, ErrorRepoAlreadyExists, clues.ToCore(err))
+
+	err = k.Connect(ctx, repository.Options{}, repoNameHash)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Close(ctx)
+	assert.NoError(t, err, clues.ToCore(err))
+}
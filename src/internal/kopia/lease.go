@@ -0,0 +1,338 @@
+package kopia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/google/uuid"
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/blob"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// leaseBlobPrefix marks the blobs holding repository leases.  The prefix
+// doesn't overlap with any of kopia's own blob prefixes, so kopia
+// maintenance never treats leases as unreferenced data.
+const leaseBlobPrefix blob.ID = "corso-lease-"
+
+// ErrLeaseHeld is returned when a lease can't be acquired because another
+// process holds a conflicting lease on the repository.
+var ErrLeaseHeld = clues.New("repository lease held by another operation")
+
+type LeaseKind string
+
+const (
+	// SharedLease is held by operations that write to the repository, like
+	// backups.  Any number of shared leases can be held at the same time.
+	SharedLease LeaseKind = "shared"
+	// ExclusiveLease is held by repository maintenance.  It can't be held
+	// alongside any other lease.
+	ExclusiveLease LeaseKind = "exclusive"
+)
+
+// Lease is an advisory lock on the repository.  Leases are stored as blobs
+// in the repository storage so that every process sharing the storage,
+// cli or lambda, can see them.  A lease that's past its expiry is ignored,
+// so a process which dies while holding one can't block the repository
+// forever.
+type Lease struct {
+	ID         string    `json:"id"`
+	Kind       LeaseKind `json:"kind"`
+	Holder     string    `json:"holder"`
+	Purpose    string    `json:"purpose"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+func (l Lease) blobID() blob.ID {
+	return leaseBlobPrefix + blob.ID(string(l.Kind)+"-"+l.ID)
+}
+
+func (l Lease) expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// conflicts is true if l can't be held at the same time as other.
+func (l Lease) conflicts(other Lease) bool {
+	if l.ID == other.ID {
+		return false
+	}
+
+	return l.Kind == ExclusiveLease || other.Kind == ExclusiveLease
+}
+
+// AcquireLease takes out a lease of the given kind on the repository.  If
+// a conflicting lease is held, ErrLeaseHeld is returned.  The caller is
+// expected to release the lease once it's done.
+func (w Wrapper) AcquireLease(
+	ctx context.Context,
+	kind LeaseKind,
+	purpose string,
+	ttl time.Duration,
+) (*Lease, error) {
+	if w.c == nil {
+		return nil, clues.StackWC(ctx, errNotConnected)
+	}
+
+	now := time.Now()
+
+	l := &Lease{
+		ID:         uuid.NewString(),
+		Kind:       kind,
+		Holder:     w.c.ClientOptions().UsernameAtHost(),
+		Purpose:    purpose,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+
+	ctx = clues.Add(ctx, "lease_id", l.ID, "lease_kind", kind)
+
+	if err := w.checkLeaseConflicts(ctx, *l); err != nil {
+		return nil, err
+	}
+
+	if err := w.putLease(ctx, *l); err != nil {
+		return nil, err
+	}
+
+	// Check again now that our lease is visible.  If two processes race for
+	// conflicting leases, both of them see the other here and back off
+	// instead of both proceeding.
+	if err := w.checkLeaseConflicts(ctx, *l); err != nil {
+		if rerr := w.ReleaseLease(ctx, l); rerr != nil {
+			logger.CtxErr(ctx, rerr).Info("releasing conflicting lease")
+		}
+
+		return nil, err
+	}
+
+	logger.Ctx(ctx).Debug("acquired repository lease")
+
+	return l, nil
+}
+
+// RenewLease pushes the expiry of a held lease back to ttl from now.
+// Long running operations renew their lease periodically, so that the
+// lease can have a short ttl and still outlive the operation.
+func (w Wrapper) RenewLease(ctx context.Context, l *Lease, ttl time.Duration) error {
+	if w.c == nil {
+		return clues.StackWC(ctx, errNotConnected)
+	}
+
+	renewed := *l
+	renewed.ExpiresAt = time.Now().Add(ttl)
+
+	if err := w.putLease(ctx, renewed); err != nil {
+		return clues.Stack(err).With("lease_id", l.ID)
+	}
+
+	l.ExpiresAt = renewed.ExpiresAt
+
+	return nil
+}
+
+// ReleaseLease removes the lease from the repository.  Releasing a nil or
+// already released lease is a no-op.
+func (w Wrapper) ReleaseLease(ctx context.Context, l *Lease) error {
+	if l == nil {
+		return nil
+	}
+
+	if w.c == nil {
+		return clues.StackWC(ctx, errNotConnected)
+	}
+
+	err := w.withBlobStorage(ctx, "Corso lease", func(st blob.Storage) error {
+		err := st.DeleteBlob(ctx, l.blobID())
+		if errors.Is(err, blob.ErrBlobNotFound) {
+			return nil
+		}
+
+		return err
+	})
+
+	return clues.WrapWC(ctx, err, "releasing lease").With("lease_id", l.ID).OrNil()
+}
+
+// Leases returns every unexpired lease on the repository.
+func (w Wrapper) Leases(ctx context.Context) ([]Lease, error) {
+	if w.c == nil {
+		return nil, clues.StackWC(ctx, errNotConnected)
+	}
+
+	dr, ok := w.c.Repository.(repo.DirectRepository)
+	if !ok {
+		return nil, clues.NewWC(ctx, "unable to get valid handle to repo")
+	}
+
+	var (
+		br  = dr.BlobReader()
+		now = time.Now()
+		ids []blob.ID
+		res []Lease
+	)
+
+	err := br.ListBlobs(ctx, leaseBlobPrefix, func(bm blob.Metadata) error {
+		ids = append(ids, bm.BlobID)
+		return nil
+	})
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "listing leases")
+	}
+
+	for _, id := range ids {
+		var buf leaseBuffer
+
+		err := br.GetBlob(ctx, id, 0, -1, &buf)
+		bs := buf.Bytes()
+
+		// released between listing and reading.
+		if errors.Is(err, blob.ErrBlobNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "reading lease").With("lease_blob_id", id)
+		}
+
+		l := Lease{}
+
+		if err := json.Unmarshal(bs, &l); err != nil {
+			logger.CtxErr(ctx, err).Infow("skipping malformed lease", "lease_blob_id", id)
+			continue
+		}
+
+		if !l.expired(now) {
+			res = append(res, l)
+		}
+	}
+
+	return res, nil
+}
+
+func (w Wrapper) checkLeaseConflicts(ctx context.Context, l Lease) error {
+	held, err := w.Leases(ctx)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	for _, h := range held {
+		if l.conflicts(h) {
+			return clues.StackWC(ctx, ErrLeaseHeld).With(
+				"held_lease_kind", h.Kind,
+				"held_lease_purpose", h.Purpose,
+				"held_lease_holder", clues.Hide(h.Holder),
+				"held_lease_expires_at", h.ExpiresAt)
+		}
+	}
+
+	return nil
+}
+
+func (w Wrapper) putLease(ctx context.Context, l Lease) error {
+	bs, err := json.Marshal(l)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "serializing lease")
+	}
+
+	err = w.withBlobStorage(ctx, "Corso lease", func(st blob.Storage) error {
+		return st.PutBlob(ctx, l.blobID(), leaseBytes(bs), blob.PutOptions{})
+	})
+
+	return clues.WrapWC(ctx, err, "writing lease").OrNil()
+}
+
+// kopia's own buffer types are internal to its module, so leases are read
+// and written through these minimal implementations of the blob interfaces.
+
+var _ blob.Bytes = leaseBytes{}
+
+type leaseBytes []byte
+
+func (b leaseBytes) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+func (b leaseBytes) Length() int {
+	return len(b)
+}
+
+func (b leaseBytes) Reader() io.ReadSeekCloser {
+	return nopSeekCloser{bytes.NewReader(b)}
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
+
+var _ blob.OutputBuffer = &leaseBuffer{}
+
+type leaseBuffer struct {
+	bytes.Buffer
+}
+
+func (b *leaseBuffer) Length() int {
+	return b.Len()
+}
+
+func (w Wrapper) withBlobStorage(
+	ctx context.Context,
+	purpose string,
+	fn func(blob.Storage) error,
+) error {
+	dr, ok := w.c.Repository.(repo.DirectRepository)
+	if !ok {
+		return clues.NewWC(ctx, "unable to get valid handle to repo")
+	}
+
+	return repo.DirectWriteSession(
+		ctx,
+		dr,
+		repo.WriteSessionOptions{Purpose: purpose},
+		func(ctx context.Context, dw repo.DirectRepositoryWriter) error {
+			return fn(dw.BlobStorage())
+		})
+}
+
+// StorageStats describes the blobs held in the repository storage.
+type StorageStats struct {
+	Blobs int64
+	Bytes int64
+}
+
+// StorageStats counts the blobs, and their total size, held in the
+// repository storage.  Every blob in the storage gets listed, which is slow
+// and costly for large repositories, so callers should only ask for the
+// stats when they're needed.
+func (w Wrapper) StorageStats(ctx context.Context) (StorageStats, error) {
+	var s StorageStats
+
+	if w.c == nil {
+		return s, clues.StackWC(ctx, errNotConnected)
+	}
+
+	dr, ok := w.c.Repository.(repo.DirectRepository)
+	if !ok {
+		return s, clues.NewWC(ctx, "unable to get valid handle to repo")
+	}
+
+	err := dr.BlobReader().ListBlobs(ctx, "", func(bm blob.Metadata) error {
+		s.Blobs++
+		s.Bytes += bm.Length
+
+		return nil
+	})
+
+	return s, clues.WrapWC(ctx, err, "listing repository blobs").OrNil()
+}
@@ -0,0 +1,167 @@
+package kopia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type LeaseUnitSuite struct {
+	tester.Suite
+}
+
+func TestLeaseUnitSuite(t *testing.T) {
+	suite.Run(t, &LeaseUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *LeaseUnitSuite) TestLease_Conflicts() {
+	table := []struct {
+		name   string
+		l      Lease
+		other  Lease
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "shared and shared",
+			l:      Lease{ID: "a", Kind: SharedLease},
+			other:  Lease{ID: "b", Kind: SharedLease},
+			expect: assert.False,
+		},
+		{
+			name:   "shared and exclusive",
+			l:      Lease{ID: "a", Kind: SharedLease},
+			other:  Lease{ID: "b", Kind: ExclusiveLease},
+			expect: assert.True,
+		},
+		{
+			name:   "exclusive and shared",
+			l:      Lease{ID: "a", Kind: ExclusiveLease},
+			other:  Lease{ID: "b", Kind: SharedLease},
+			expect: assert.True,
+		},
+		{
+			name:   "exclusive and itself",
+			l:      Lease{ID: "a", Kind: ExclusiveLease},
+			other:  Lease{ID: "a", Kind: ExclusiveLease},
+			expect: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), test.l.conflicts(test.other))
+		})
+	}
+}
+
+func (suite *LeaseUnitSuite) TestLease_Expired() {
+	var (
+		t   = suite.T()
+		now = time.Now()
+	)
+
+	assert.False(t, Lease{ExpiresAt: now.Add(time.Minute)}.expired(now))
+	assert.True(t, Lease{ExpiresAt: now}.expired(now))
+	assert.True(t, Lease{ExpiresAt: now.Add(-time.Minute)}.expired(now))
+}
+
+type LeaseIntegrationSuite struct {
+	tester.Suite
+}
+
+func TestLeaseIntegrationSuite(t *testing.T) {
+	suite.Run(t, &LeaseIntegrationSuite{
+		Suite: tester.NewIntegrationSuite(t, nil),
+	})
+}
+
+func (suite *LeaseIntegrationSuite) TestAcquireAndRelease() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openLocalKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	w := &Wrapper{k}
+	defer w.Close(ctx)
+
+	shared1, err := w.AcquireLease(ctx, SharedLease, "backup", time.Hour)
+	require.NoError(t, err, clues.ToCore(err))
+
+	shared2, err := w.AcquireLease(ctx, SharedLease, "backup", time.Hour)
+	require.NoError(t, err, clues.ToCore(err))
+
+	held, err := w.Leases(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Len(t, held, 2)
+
+	_, err = w.AcquireLease(ctx, ExclusiveLease, "maintenance", time.Hour)
+	assert.ErrorIs(t, err, ErrLeaseHeld, clues.ToCore(err))
+
+	err = w.ReleaseLease(ctx, shared1)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = w.ReleaseLease(ctx, shared2)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// releasing twice is a no-op.
+	err = w.ReleaseLease(ctx, shared2)
+	require.NoError(t, err, clues.ToCore(err))
+
+	excl, err := w.AcquireLease(ctx, ExclusiveLease, "maintenance", time.Hour)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = w.AcquireLease(ctx, SharedLease, "backup", time.Hour)
+	assert.ErrorIs(t, err, ErrLeaseHeld, clues.ToCore(err))
+
+	err = w.ReleaseLease(ctx, excl)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// expired leases don't block anything.
+	_, err = w.AcquireLease(ctx, ExclusiveLease, "maintenance", -time.Minute)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = w.AcquireLease(ctx, SharedLease, "backup", time.Hour)
+	require.NoError(t, err, clues.ToCore(err))
+}
+
+func (suite *LeaseIntegrationSuite) TestRenewLease() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openLocalKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	w := &Wrapper{k}
+	defer w.Close(ctx)
+
+	shared, err := w.AcquireLease(ctx, SharedLease, "backup", time.Minute)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = w.RenewLease(ctx, shared, time.Hour)
+	require.NoError(t, err, clues.ToCore(err))
+
+	held, err := w.Leases(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, held, 1)
+
+	assert.Equal(t, shared.ID, held[0].ID)
+	assert.WithinDuration(t, shared.ExpiresAt, held[0].ExpiresAt, time.Second)
+	assert.True(t, held[0].ExpiresAt.After(time.Now().Add(30*time.Minute)))
+
+	// a renewal that expires the lease frees up the repository.
+	err = w.RenewLease(ctx, shared, -time.Minute)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = w.AcquireLease(ctx, ExclusiveLease, "maintenance", time.Hour)
+	require.NoError(t, err, clues.ToCore(err))
+}
@@ -0,0 +1,74 @@
+package kopia
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/blob/sftp"
+
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+func sftpStorage(
+	ctx context.Context,
+	repoOpts repository.Options,
+	s storage.Storage,
+) (blob.Storage, error) {
+	cfg, err := s.ToSFTPConfig()
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	if repoOpts.ViewTimestamp != nil {
+		return nil, clues.NewWC(ctx, "point in time repository views are not supported in sftp storage")
+	}
+
+	ctx = clues.Add(
+		ctx,
+		"sftp_host", cfg.Host,
+		"sftp_path", cfg.Path)
+
+	opts, err := sftpOptions(*cfg)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	store, err := sftp.New(ctx, &opts, true)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	return store, nil
+}
+
+func sftpOptions(cfg storage.SFTPConfig) (sftp.Options, error) {
+	if len(cfg.Password) == 0 && len(cfg.KeyData) == 0 && len(cfg.KeyFile) == 0 {
+		return sftp.Options{}, clues.New("a password or private key is required to connect to the sftp server")
+	}
+
+	opts := sftp.Options{
+		Host:           cfg.Host,
+		Port:           22,
+		Username:       cfg.Username,
+		Path:           cfg.Path,
+		Password:       cfg.Password,
+		Keyfile:        cfg.KeyFile,
+		KeyData:        cfg.KeyData,
+		KnownHostsFile: cfg.KnownHostsFile,
+		KnownHostsData: cfg.KnownHostsData,
+	}
+
+	if len(cfg.Port) > 0 {
+		port, err := strconv.Atoi(cfg.Port)
+		if err != nil {
+			return sftp.Options{}, clues.Wrap(err, "parsing sftp port")
+		}
+
+		opts.Port = port
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,50 @@
+package kopia
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/blob/webdav"
+
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+func webdavStorage(
+	ctx context.Context,
+	repoOpts repository.Options,
+	s storage.Storage,
+) (blob.Storage, error) {
+	cfg, err := s.ToWebDAVConfig()
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	if repoOpts.ViewTimestamp != nil {
+		return nil, clues.NewWC(ctx, "point in time repository views are not supported in webdav storage")
+	}
+
+	ctx = clues.Add(ctx, "webdav_url", cfg.URL)
+
+	opts := webdavOptions(*cfg)
+
+	store, err := webdav.New(ctx, &opts, true)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	return store, nil
+}
+
+func webdavOptions(cfg storage.WebDAVConfig) webdav.Options {
+	return webdav.Options{
+		URL:                                 cfg.URL,
+		Username:                            cfg.Username,
+		Password:                            cfg.Password,
+		TrustedServerCertificateFingerprint: cfg.TrustedServerCertificateFingerprint,
+		// NAS devices are frequently backed by filesystems that expose
+		// partially written files; write to a temp file and rename it.
+		AtomicWrites: true,
+	}
+}
@@ -0,0 +1,117 @@
+package kopia
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/webdav"
+
+	strTD "github.com/alcionai/corso/src/internal/common/str/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+	storeTD "github.com/alcionai/corso/src/pkg/storage/testdata"
+)
+
+type WebDAVUnitSuite struct {
+	tester.Suite
+}
+
+func TestWebDAVUnitSuite(t *testing.T) {
+	suite.Run(t, &WebDAVUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *WebDAVUnitSuite) TestWebDAVOptions() {
+	t := suite.T()
+
+	opts := webdavOptions(storage.WebDAVConfig{
+		WebDAV:                              credentials.WebDAV{Username: "usr", Password: "pwd"},
+		URL:                                 "https://nas.local/corso",
+		TrustedServerCertificateFingerprint: "ab12",
+	})
+
+	assert.Equal(t, "https://nas.local/corso", opts.URL)
+	assert.Equal(t, "usr", opts.Username)
+	assert.Equal(t, "pwd", opts.Password)
+	assert.Equal(t, "ab12", opts.TrustedServerCertificateFingerprint)
+	assert.True(t, opts.AtomicWrites)
+}
+
+func (suite *WebDAVUnitSuite) TestWebDAVStorage_PointInTime() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st, err := storage.NewStorage(
+		storage.ProviderWebDAV,
+		&storage.WebDAVConfig{URL: "https://nas.local/corso"})
+	require.NoError(t, err, clues.ToCore(err))
+
+	now := time.Now()
+
+	_, err = webdavStorage(ctx, repository.Options{ViewTimestamp: &now}, st)
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+// ---------------
+// integration tests
+// ---------------
+
+type WebDAVIntegrationSuite struct {
+	tester.Suite
+}
+
+// runs against an in-process webdav server, so no external
+// dependencies are needed.
+func TestWebDAVIntegrationSuite(t *testing.T) {
+	suite.Run(t, &WebDAVIntegrationSuite{
+		Suite: tester.NewIntegrationSuite(t, nil),
+	})
+}
+
+func (suite *WebDAVIntegrationSuite) TestInitializeAndConnect() {
+	t := suite.T()
+	repoNameHash := strTD.NewHashForRepoConfigName()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	srv := httptest.NewServer(&webdav.Handler{
+		FileSystem: webdav.Dir(t.TempDir()),
+		LockSystem: webdav.NewMemLS(),
+	})
+	defer srv.Close()
+
+	st, err := storage.NewStorage(
+		storage.ProviderWebDAV,
+		&storage.WebDAVConfig{URL: srv.URL},
+		storage.CommonConfig{
+			Corso:       storeTD.GetAndInsertCorso(""),
+			KopiaCfgDir: t.TempDir(),
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	k := NewConn(st)
+
+	err = k.Initialize(ctx, repository.Options{}, repository.Retention{}, repoNameHash)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Close(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Initialize(ctx, repository.Options{}, repository.Retention{}, repoNameHash)
+	assert.ErrorIs(t, err, ErrorRepoAlreadyExists, clues.ToCore(err))
+
+	err = k.Connect(ctx, repository.Options{}, repoNameHash)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Close(ctx)
+	assert.NoError(t, err, clues.ToCore(err))
+}
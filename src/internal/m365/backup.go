@@ -9,10 +9,14 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/diagnostics"
 	kinject "github.com/alcionai/corso/src/internal/kopia/inject"
+	"github.com/alcionai/corso/src/internal/m365/collection/entraid"
+	"github.com/alcionai/corso/src/internal/m365/collection/onenote"
+	entraidService "github.com/alcionai/corso/src/internal/m365/service/entraid"
 	"github.com/alcionai/corso/src/internal/m365/service/exchange"
 	"github.com/alcionai/corso/src/internal/m365/service/groups"
 	"github.com/alcionai/corso/src/internal/m365/service/onedrive"
 	"github.com/alcionai/corso/src/internal/m365/service/sharepoint"
+	"github.com/alcionai/corso/src/internal/m365/service/teamschats"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	bupMD "github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
@@ -120,6 +124,40 @@ func (ctrl *Controller) ProduceBackupCollections(
 		// return a tombstone collection in case the metadata read fails
 		canUsePreviousBackup = true
 
+	case path.TeamsChatsService:
+		colls, excludeItems, err = teamschats.ProduceBackupCollections(
+			ctx,
+			bpc,
+			ctrl.AC,
+			ctrl.credentials,
+			ctrl.UpdateStatus,
+			counter,
+			errs)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		// same as groups, a tombstone collection is returned in case the
+		// metadata read fails.
+		canUsePreviousBackup = true
+
+	case path.EntraIDService:
+		colls, excludeItems, err = entraidService.ProduceBackupCollections(
+			ctx,
+			bpc,
+			ctrl.AC,
+			ctrl.credentials,
+			ctrl.UpdateStatus,
+			counter,
+			errs)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		// same as groups, a tombstone collection is returned in case the
+		// metadata read fails.
+		canUsePreviousBackup = true
+
 	default:
 		return nil, nil, false, clues.Wrap(clues.NewWC(ctx, service.String()), "service not supported")
 	}
@@ -153,6 +191,10 @@ func (ctrl *Controller) IsServiceEnabled(
 		return sharepoint.IsServiceEnabled(ctx, ctrl.AC.Sites(), resourceOwner)
 	case path.GroupsService:
 		return groups.IsServiceEnabled(ctx, ctrl.AC.Groups(), resourceOwner)
+	case path.TeamsChatsService:
+		return teamschats.IsServiceEnabled(ctx, ctrl.AC.Chats(), resourceOwner)
+	case path.EntraIDService:
+		return entraidService.IsServiceEnabled(ctx, ctrl.AC.Directory(), resourceOwner)
 	}
 
 	return false, clues.Wrap(clues.NewWC(ctx, service.String()), "service not supported")
@@ -162,8 +204,12 @@ func verifyBackupInputs(sels selectors.Selector, cachedIDs []string) error {
 	var ids []string
 
 	switch sels.Service {
-	case selectors.ServiceExchange, selectors.ServiceOneDrive:
-		// Exchange and OneDrive user existence now checked in checkServiceEnabled.
+	case selectors.ServiceExchange, selectors.ServiceOneDrive, selectors.ServiceTeamsChats:
+		// Exchange, OneDrive, and chats user existence now checked in checkServiceEnabled.
+		return nil
+
+	case selectors.ServiceEntraID:
+		// the directory's tenant is checked when producing collections.
 		return nil
 
 	case selectors.ServiceSharePoint, selectors.ServiceGroups:
@@ -201,6 +247,14 @@ func (ctrl *Controller) GetMetadataPaths(
 			for _, fn := range sharepoint.ListsMetadataFileNames() {
 				filePaths = append(filePaths, []string{fn})
 			}
+		case reason.Category() == path.NotebooksCategory:
+			for _, fn := range onenote.MetadataFileNames() {
+				filePaths = append(filePaths, []string{fn})
+			}
+		case reason.Service() == path.EntraIDService:
+			for _, fn := range entraid.MetadataFileNames() {
+				filePaths = append(filePaths, []string{fn})
+			}
 		default:
 			for _, fn := range bupMD.AllMetadataFileNames() {
 				filePaths = append(filePaths, []string{fn})
@@ -12,6 +12,8 @@ import (
 	"github.com/alcionai/clues"
 	"github.com/pkg/errors"
 	"github.com/spatialcurrent/go-lazy/pkg/lazy"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/common/ptr"
@@ -86,6 +88,10 @@ type Collection struct {
 	// see: https://learn.microsoft.com/en-us/graph/api/resources/package?view=graph-rest-1.0
 	isPackageOrChildOfPackage bool
 
+	// true if the resource's notebooks are backed up separately, through
+	// the OneNote api.  See Collections.NotebooksBackedUp.
+	notebooksBackedUp bool
+
 	// should only be true if the old delta token expired
 	doNotMergeItems bool
 
@@ -325,6 +331,13 @@ func (oc *Collection) getDriveItemContent(
 			// "item". This will have to be handled during the
 			// restore, or we have to handle it separately by somehow
 			// deleting the entire collection.
+			if oc.notebooksBackedUp {
+				logger.CtxErr(ctx, err).Info("inaccessible one note file; notebook backed up separately")
+				oc.counter.Inc(count.OneNoteFilesDeferred)
+
+				return nil, clues.Wrap(err, "inaccesible oneNote item").Label(graph.LabelsSkippable)
+			}
+
 			logger.
 				CtxErr(ctx, err).
 				With("skipped_reason", fault.SkipOneNote).
@@ -478,11 +491,21 @@ func (oc *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 		"parent_path", parentPath,
 		"is_package", oc.isPackageOrChildOfPackage)
 
-	for _, item := range oc.driveItems {
-		if errs.Failure() != nil {
+	// items are handed over in a stable order, so that a backup which stops
+	// early covers the same items each time.
+	ids := maps.Keys(oc.driveItems)
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		// the context gets cancelled when the backup stops early, such as at
+		// its checkpoint.  The remaining items get picked up by the next
+		// backup.
+		if errs.Failure() != nil || ctx.Err() != nil {
 			break
 		}
 
+		item := oc.driveItems[id]
+
 		semaphoreCh <- struct{}{}
 
 		wg.Add(1)
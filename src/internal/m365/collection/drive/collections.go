@@ -62,6 +62,12 @@ type Collections struct {
 	NumFiles      int
 	NumContainers int
 
+	// NotebooksBackedUp is true when the protected resource's notebooks
+	// are backed up through the OneNote api.  OneNote files that can't
+	// be downloaded from the drive are no longer reported as skipped in
+	// that case, since their content is already part of the backup.
+	NotebooksBackedUp bool
+
 	counter *count.Bus
 }
 
@@ -1091,6 +1097,7 @@ func (c *Collections) processItem(
 		}
 
 		col.driveName = driveName
+		col.notebooksBackedUp = c.NotebooksBackedUp
 
 		c.CollectionMap[driveID][itemID] = col
 		c.NumContainers++
@@ -854,6 +854,7 @@ func (c *Collections) turnTreeIntoCollections(
 		}
 
 		coll.driveItems = cbl.files
+		coll.notebooksBackedUp = c.NotebooksBackedUp
 
 		collections = append(collections, coll)
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -449,6 +450,104 @@ func UpdateLinkShares(
 	return alreadyDeleted, nil
 }
 
+// remapEntityID translates the ID (or, for older backups, the email) of a
+// grantee in the backed up tenant to the ID of its counterpart in the
+// restore tenant.  Mapped values can be either IDs or names of users and
+// groups.  Returns false if the grantee isn't in the mapping.
+func remapEntityID(
+	mapping control.ResourceMapping,
+	availableEntities ResourceIDNames,
+	entityType metadata.GV2Type,
+	id, email string,
+) (string, bool) {
+	mapped, ok := mapping.Get(id, email)
+	if !ok {
+		return id, false
+	}
+
+	entities := availableEntities.Users
+	if entityType == metadata.GV2Group {
+		entities = availableEntities.Groups
+	}
+
+	if entities == nil {
+		return mapped, true
+	}
+
+	if _, ok := entities.NameOf(mapped); ok {
+		return mapped, true
+	}
+
+	if mid, ok := entities.IDOf(mapped); ok {
+		return mid, true
+	}
+
+	return mapped, true
+}
+
+// remapEntitiesInPermissions translates the grantees of the permissions
+// into the restore tenant.  Grantees that aren't in the mapping are left
+// as-is, and get filtered out later on if they don't exist in the restore
+// tenant.
+func remapEntitiesInPermissions(
+	ctx context.Context,
+	perms []metadata.Permission,
+	availableEntities ResourceIDNames,
+	mapping control.ResourceMapping,
+) []metadata.Permission {
+	if len(mapping) == 0 {
+		return perms
+	}
+
+	var (
+		remapped      = make([]metadata.Permission, 0, len(perms))
+		remappedCount int
+	)
+
+	for _, p := range perms {
+		id, ok := remapEntityID(mapping, availableEntities, p.EntityType, p.EntityID, p.Email)
+		if ok {
+			p.EntityID = id
+			p.Email = ""
+			remappedCount++
+		}
+
+		remapped = append(remapped, p)
+	}
+
+	logger.Ctx(ctx).Debugw("remapped permission grantees", "remapped_count", remappedCount)
+
+	return remapped
+}
+
+// remapEntitiesInLinkShare translates the entities of the link shares
+// into the restore tenant.
+func remapEntitiesInLinkShare(
+	linkShares []metadata.LinkShare,
+	availableEntities ResourceIDNames,
+	mapping control.ResourceMapping,
+) []metadata.LinkShare {
+	if len(mapping) == 0 {
+		return linkShares
+	}
+
+	remapped := make([]metadata.LinkShare, 0, len(linkShares))
+
+	for _, ls := range linkShares {
+		entities := make([]metadata.Entity, 0, len(ls.Entities))
+
+		for _, e := range ls.Entities {
+			e.ID, _ = remapEntityID(mapping, availableEntities, e.EntityType, e.ID, "")
+			entities = append(entities, e)
+		}
+
+		ls.Entities = entities
+		remapped = append(remapped, ls)
+	}
+
+	return remapped
+}
+
 func filterUnavailableEntitiesInLinkShare(
 	ctx context.Context,
 	linkShares []metadata.LinkShare,
@@ -574,6 +673,7 @@ func RestorePermissions(
 
 	if previousLinkShares != nil {
 		lsAdded, lsRemoved := metadata.DiffLinkShares(previousLinkShares, current.LinkShares)
+		lsAdded = remapEntitiesInLinkShare(lsAdded, caches.AvailableEntities, caches.ResourceMapping)
 		lsAdded = filterUnavailableEntitiesInLinkShare(ctx, lsAdded, caches.AvailableEntities, caches.OldLinkShareIDToNewID)
 
 		// Link shares have to be updated before permissions as we have to
@@ -600,6 +700,7 @@ func RestorePermissions(
 	}
 
 	permAdded, permRemoved := metadata.DiffPermissions(previous.Permissions, current.Permissions)
+	permAdded = remapEntitiesInPermissions(ctx, permAdded, caches.AvailableEntities, caches.ResourceMapping)
 	permAdded = filterUnavailableEntitiesInPermissions(ctx, permAdded, caches.AvailableEntities, caches.OldPermIDToNewID)
 
 	if didReset {
@@ -609,7 +710,7 @@ func RestorePermissions(
 		logger.Ctx(ctx).Debug("link share creation reset all inherited permissions")
 
 		permRemoved = []metadata.Permission{}
-		permAdded = current.Permissions
+		permAdded = remapEntitiesInPermissions(ctx, current.Permissions, caches.AvailableEntities, caches.ResourceMapping)
 	}
 
 	err = UpdatePermissions(
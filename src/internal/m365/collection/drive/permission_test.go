@@ -18,6 +18,7 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
@@ -471,6 +472,75 @@ func (suite *PermissionsUnitTestSuite) TestFilterUnavailableEntitiesInPermission
 	}
 }
 
+func (suite *PermissionsUnitTestSuite) TestRemapEntitiesInPermissions() {
+	available := ResourceIDNames{
+		Users:  idname.NewCache(map[string]string{"nu1": "batman@justice.org", "nu2": "robin@justice.org"}),
+		Groups: idname.NewCache(map[string]string{"ng1": "league"}),
+	}
+
+	table := []struct {
+		name        string
+		permissions []metadata.Permission
+		mapping     control.ResourceMapping
+		expected    []metadata.Permission
+	}{
+		{
+			name: "no mapping",
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "e1", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "e1", EntityType: metadata.GV2User},
+			},
+		},
+		{
+			name: "mapped to id",
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "e1", EntityType: metadata.GV2User},
+				{ID: "p2", EntityID: "e2", EntityType: metadata.GV2User},
+			},
+			mapping: control.ResourceMapping{"e1": "nu1"},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "nu1", EntityType: metadata.GV2User},
+				{ID: "p2", EntityID: "e2", EntityType: metadata.GV2User},
+			},
+		},
+		{
+			name: "mapped to name",
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "e1", EntityType: metadata.GV2User},
+				{ID: "p2", EntityID: "e2", EntityType: metadata.GV2Group},
+			},
+			mapping: control.ResourceMapping{"e1": "robin@justice.org", "e2": "league"},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "nu2", EntityType: metadata.GV2User},
+				{ID: "p2", EntityID: "ng1", EntityType: metadata.GV2Group},
+			},
+		},
+		{
+			name: "email only",
+			permissions: []metadata.Permission{
+				{ID: "p1", Email: "bruce@wayne.com"},
+			},
+			mapping: control.ResourceMapping{"bruce@wayne.com": "batman@justice.org"},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "nu1"},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			remapped := remapEntitiesInPermissions(ctx, test.permissions, available, test.mapping)
+			assert.Equal(t, test.expected, remapped)
+		})
+	}
+}
+
 type eidtype struct {
 	id    string
 	etype metadata.GV2Type
@@ -0,0 +1,229 @@
+package entraid
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// objectTypeCounts maps each kind of directory object to the key that
+// counts its enumeration.
+var objectTypeCounts = map[string]count.Key{
+	details.EntraIDUsersFolder:             count.DirectoryUsers,
+	details.EntraIDGroupsFolder:            count.DirectoryGroups,
+	details.EntraIDApplicationsFolder:      count.DirectoryApplications,
+	details.EntraIDServicePrincipalsFolder: count.DirectoryServicePrincipals,
+}
+
+// CreateCollections produces one collection for each kind of directory
+// object in scope, plus a metadata collection holding the previous path
+// of every kind for use in the next backup.  Every object is enumerated
+// on each backup; the directory is small enough compared to the rest of
+// the tenant that a full snapshot is cheaper than tracking deltas for
+// four different object types.
+func CreateCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	bh backupHandler,
+	tenantID string,
+	scope selectors.EntraIDScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, bool, error) {
+	prevPaths, canUsePreviousBackup, err := parseMetadataCollections(ctx, bpc.MetadataCollections)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx = clues.Add(ctx, "can_use_previous_backup", canUsePreviousBackup)
+
+	collections, err := populateCollections(
+		ctx,
+		bh,
+		tenantID,
+		bpc.ProtectedResource.ID(),
+		su,
+		scope,
+		prevPaths,
+		bpc.Options,
+		counter,
+		errs)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "filling collections")
+	}
+
+	return collections, canUsePreviousBackup, nil
+}
+
+func populateCollections(
+	ctx context.Context,
+	bh backupHandler,
+	tenantID, protectedResourceID string,
+	statusUpdater support.StatusUpdater,
+	scope selectors.EntraIDScope,
+	prevPaths map[string]string,
+	ctrlOpts control.Options,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	var (
+		// object type -> BackupCollection.
+		collections = map[string]data.BackupCollection{}
+		currPaths   = map[string]string{}
+		// copy of previousPaths.  every object type enumerated gets
+		// removed from this map; the remaining types at the end of the
+		// process no longer exist.
+		tombstones = makeTombstones(prevPaths)
+		el         = errs.Local()
+	)
+
+	logger.Ctx(ctx).Infow("filling collections", "len_prev_paths", len(prevPaths))
+
+	for _, objectType := range objectTypes {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		var (
+			cl          = counter.Local()
+			prevPathStr = prevPaths[objectType]
+			prevPath    path.Path
+			err         error
+			ictx        = clues.Add(ctx, "object_type", objectType)
+		)
+
+		ictx = clues.AddLabelCounter(ictx, cl.PlainAdder())
+
+		delete(tombstones, objectType)
+
+		if !bh.includeObjectType(objectType, scope) {
+			cl.Inc(count.SkippedContainers)
+			continue
+		}
+
+		if len(prevPathStr) > 0 {
+			if prevPath, err = pathFromPrevString(prevPathStr); err != nil {
+				err = clues.StackWC(ictx, err).Label(count.BadPrevPath)
+				logger.CtxErr(ictx, err).Error("parsing prev path")
+			}
+		}
+
+		ictx = clues.Add(ictx, "previous_path", prevPath)
+
+		objects, err := bh.getObjects(ictx, objectType)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		cl.Add(objectTypeCounts[objectType], int64(len(objects)))
+
+		currPath, err := bh.canonicalPath(path.Elements{objectType}, tenantID)
+		if err != nil {
+			err = clues.StackWC(ictx, err).Label(count.BadCollPath)
+			el.AddRecoverable(ictx, err)
+
+			continue
+		}
+
+		collections[objectType] = NewCollection(
+			data.NewBaseCollection(
+				currPath,
+				prevPath,
+				path.Builder{}.Append(objectType),
+				ctrlOpts,
+				// every object is listed on each backup, so objects from
+				// the previous backup must not be merged in; otherwise
+				// deleted objects would live on forever.
+				true,
+				cl),
+			bh,
+			objectType,
+			objects,
+			statusUpdater)
+
+		// add the current path for the object type to be used in the next
+		// backup as the "previous path".
+		currPaths[objectType] = currPath.String()
+	}
+
+	// A tombstone is an object type that needs to be marked for deletion.
+	// This can only happen if a previous backup was made by a version of
+	// corso that produced object types which are no longer supported.
+	for id, p := range tombstones {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		ictx := clues.Add(ctx, "tombstone_id", id)
+
+		if collections[id] != nil {
+			err := clues.NewWC(ictx, "conflict: tombstone exists for a live collection").
+				Label(count.CollectionTombstoneConflict)
+			el.AddRecoverable(ctx, err)
+
+			continue
+		}
+
+		prevPath, err := pathFromPrevString(p)
+		if err != nil {
+			err := clues.StackWC(ictx, err).Label(count.BadPrevPath)
+			// technically shouldn't ever happen.  But just in case...
+			logger.CtxErr(ictx, err).Error("parsing tombstone prev path")
+
+			continue
+		}
+
+		collections[id] = data.NewTombstoneCollection(prevPath, ctrlOpts, counter.Local())
+	}
+
+	logger.Ctx(ctx).Infow(
+		"adding metadata collection entries",
+		"num_paths_entries", len(currPaths))
+
+	pathPrefix, err := path.BuildMetadata(
+		tenantID,
+		protectedResourceID,
+		path.EntraIDService,
+		path.DirectoryObjectsCategory,
+		false)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata path prefix").
+			Label(count.BadPathPrefix)
+	}
+
+	col, err := graph.MakeMetadataCollection(
+		pathPrefix,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(metadata.PreviousPathFileName, currPaths),
+		},
+		statusUpdater,
+		counter.Local())
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata collection")
+	}
+
+	results := make([]data.BackupCollection, 0, len(collections)+1)
+
+	for _, coll := range collections {
+		results = append(results, coll)
+	}
+
+	results = append(results, col)
+
+	return results, el.Failure()
+}
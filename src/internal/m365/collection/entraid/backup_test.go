@@ -0,0 +1,281 @@
+package entraid
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// ---------------------------------------------------------------------------
+// mocks
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = &mockBackupHandler{}
+
+type mockBackupHandler struct {
+	objects    map[string][]models.DirectoryObjectable
+	objectsErr map[string]error
+	members    map[string][]string
+	excluded   map[string]struct{}
+}
+
+func (bh mockBackupHandler) getObjects(
+	_ context.Context,
+	objectType string,
+) ([]models.DirectoryObjectable, error) {
+	return bh.objects[objectType], bh.objectsErr[objectType]
+}
+
+func (bh mockBackupHandler) getGroupMemberIDs(
+	_ context.Context,
+	groupID string,
+) ([]string, error) {
+	return bh.members[groupID], nil
+}
+
+func (bh mockBackupHandler) includeObjectType(
+	objectType string,
+	_ selectors.EntraIDScope,
+) bool {
+	_, ok := bh.excluded[objectType]
+	return !ok
+}
+
+func (bh mockBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			tenantID,
+			path.EntraIDService,
+			path.DirectoryObjectsCategory,
+			false)
+}
+
+func stubUser(id, name string) models.DirectoryObjectable {
+	u := models.NewUser()
+	u.SetId(ptr.To(id))
+	u.SetDisplayName(ptr.To(name))
+	u.SetUserPrincipalName(ptr.To(name + "@contoso.com"))
+
+	return u
+}
+
+func stubGroup(id, name string) models.DirectoryObjectable {
+	g := models.NewGroup()
+	g.SetId(ptr.To(id))
+	g.SetDisplayName(ptr.To(name))
+	g.SetMailNickname(ptr.To(name))
+
+	return g
+}
+
+// ---------------------------------------------------------------------------
+// tests
+// ---------------------------------------------------------------------------
+
+type BackupUnitSuite struct {
+	tester.Suite
+}
+
+func TestBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &BackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *BackupUnitSuite) TestPopulateCollections() {
+	var (
+		statusUpdater = func(*support.ControllerOperationStatus) {}
+		allScope      = selectors.NewEntraIDBackup(nil).AllData()[0]
+		objects       = map[string][]models.DirectoryObjectable{
+			details.EntraIDUsersFolder:  {stubUser("u1", "adele")},
+			details.EntraIDGroupsFolder: {stubGroup("g1", "marketing")},
+		}
+	)
+
+	removedPath, err := path.Build(
+		"tid", "tid",
+		path.EntraIDService,
+		path.DirectoryObjectsCategory,
+		false,
+		"devices")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name                string
+		mock                mockBackupHandler
+		prevPaths           map[string]string
+		expectErr           require.ErrorAssertionFunc
+		expectColls         int
+		expectNewColls      int
+		expectTombstoneCols int
+	}{
+		{
+			name:           "all object types",
+			mock:           mockBackupHandler{objects: objects},
+			expectErr:      require.NoError,
+			expectColls:    5,
+			expectNewColls: 4,
+		},
+		{
+			name: "object types out of scope",
+			mock: mockBackupHandler{
+				objects: objects,
+				excluded: map[string]struct{}{
+					details.EntraIDApplicationsFolder:      {},
+					details.EntraIDServicePrincipalsFolder: {},
+				},
+			},
+			expectErr:      require.NoError,
+			expectColls:    3,
+			expectNewColls: 2,
+		},
+		{
+			name: "err: getting objects",
+			mock: mockBackupHandler{
+				objects: objects,
+				objectsErr: map[string]error{
+					details.EntraIDUsersFolder: assert.AnError,
+				},
+			},
+			expectErr:   require.Error,
+			expectColls: 0,
+		},
+		{
+			name: "object type no longer supported",
+			mock: mockBackupHandler{objects: objects},
+			prevPaths: map[string]string{
+				"devices": removedPath.String(),
+			},
+			expectErr:           require.NoError,
+			expectColls:         6,
+			expectNewColls:      4,
+			expectTombstoneCols: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			collections, err := populateCollections(
+				ctx,
+				test.mock,
+				"tid",
+				"tid",
+				statusUpdater,
+				allScope,
+				test.prevPaths,
+				control.Options{FailureHandling: control.FailFast},
+				count.New(),
+				fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Len(t, collections, test.expectColls, "number of collections")
+
+			if err != nil {
+				return
+			}
+
+			tombstones, news, metadatas := 0, 0, 0
+			for _, c := range collections {
+				if c.FullPath() != nil && c.FullPath().Service() == path.EntraIDMetadataService {
+					metadatas++
+					continue
+				}
+
+				if c.State() == data.DeletedState {
+					tombstones++
+				}
+
+				if c.State() == data.NewState {
+					news++
+				}
+			}
+
+			assert.Equal(t, test.expectNewColls, news, "new collections")
+			assert.Equal(t, test.expectTombstoneCols, tombstones, "tombstone collections")
+			assert.Equal(t, 1, metadatas, "metadata collections")
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestCollection_Items() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	fp, err := path.Build(
+		"tid", "tid",
+		path.EntraIDService,
+		path.DirectoryObjectsCategory,
+		false,
+		details.EntraIDGroupsFolder)
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		bh = mockBackupHandler{
+			members: map[string][]string{"g1": {"u2", "u1"}},
+		}
+		col = NewCollection(
+			data.NewBaseCollection(
+				fp,
+				nil,
+				path.Builder{}.Append(details.EntraIDGroupsFolder),
+				control.DefaultOptions(),
+				true,
+				count.New()),
+			bh,
+			details.EntraIDGroupsFolder,
+			[]models.DirectoryObjectable{stubGroup("g1", "marketing")},
+			func(*support.ControllerOperationStatus) {})
+		items = []data.Item{}
+	)
+
+	for item := range col.Items(ctx, fault.New(true)) {
+		items = append(items, item)
+	}
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "g1", items[0].ID())
+
+	bs, err := io.ReadAll(items[0].ToReader())
+	require.NoError(t, err, clues.ToCore(err))
+
+	var do directoryObject
+
+	err = json.Unmarshal(bs, &do)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, []string{"u1", "u2"}, do.Members, "members are sorted")
+	assert.Contains(t, string(do.Object), "marketing")
+
+	info := items[0].(data.ItemInfo)
+	ii, err := info.Info()
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, ii.EntraID)
+	assert.Equal(t, details.EntraIDGroup, ii.EntraID.ItemType)
+	assert.Equal(t, []string{"u1", "u2"}, ii.EntraID.Members)
+}
@@ -0,0 +1,256 @@
+package entraid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ data.BackupCollection = &prefetchCollection{}
+
+const collectionChannelBufferSize = 1000
+
+// directoryObject is the backed up form of a directory object.  Group
+// memberships aren't part of the group resource in graph, so the IDs of
+// a group's direct members are stored alongside it.
+type directoryObject struct {
+	Object  json.RawMessage `json:"object"`
+	Members []string        `json:"members,omitempty"`
+}
+
+// updateStatus is a utility function used to send the status update through
+// the channel.
+func updateStatus(
+	ctx context.Context,
+	statusUpdater support.StatusUpdater,
+	attempted int,
+	streamedItems int64,
+	folderPath string,
+) {
+	status := support.CreateStatus(
+		ctx,
+		support.Backup,
+		1,
+		support.CollectionMetrics{
+			Objects:   attempted,
+			Successes: int(streamedItems),
+		},
+		folderPath)
+
+	logger.Ctx(ctx).Debugw("done streaming items", "status", status.String())
+
+	statusUpdater(status)
+}
+
+// -----------------------------------------------------------------------------
+// prefetchCollection
+// -----------------------------------------------------------------------------
+
+// prefetchCollection holds every directory object of a single kind.  The
+// objects were fully populated during enumeration, so only group
+// memberships need to be fetched while streaming.
+type prefetchCollection struct {
+	data.BaseCollection
+	stream chan data.Item
+
+	objectType string
+	objects    []models.DirectoryObjectable
+
+	getter groupMemberGetter
+
+	statusUpdater support.StatusUpdater
+}
+
+// NewCollection creates a collection of directory objects.
+func NewCollection(
+	baseCol data.BaseCollection,
+	getter groupMemberGetter,
+	objectType string,
+	objects []models.DirectoryObjectable,
+	statusUpdater support.StatusUpdater,
+) data.BackupCollection {
+	return &prefetchCollection{
+		BaseCollection: baseCol,
+		getter:         getter,
+		objectType:     objectType,
+		objects:        objects,
+		statusUpdater:  statusUpdater,
+		stream:         make(chan data.Item, collectionChannelBufferSize),
+	}
+}
+
+func (col *prefetchCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	go col.streamItems(ctx, errs)
+	return col.stream
+}
+
+func (col *prefetchCollection) streamItems(ctx context.Context, errs *fault.Bus) {
+	var (
+		streamedItems   int64
+		wg              sync.WaitGroup
+		progressMessage chan<- struct{}
+		el              = errs.Local()
+	)
+
+	ctx = clues.Add(ctx, "category", col.Category().String())
+
+	defer func() {
+		close(col.stream)
+		logger.Ctx(ctx).Infow(
+			"finished stream backup collection items",
+			"stats", col.Counter.Values())
+
+		updateStatus(
+			ctx,
+			col.statusUpdater,
+			len(col.objects),
+			streamedItems,
+			col.FullPath().Folder(false))
+	}()
+
+	if len(col.objects) > 0 {
+		progressMessage = observe.CollectionProgress(
+			ctx,
+			col.Category().HumanString(),
+			col.LocationPath().Elements())
+		defer close(progressMessage)
+	}
+
+	semaphoreCh := make(chan struct{}, col.Opts().Parallelism.ItemFetch)
+	defer close(semaphoreCh)
+
+	for _, obj := range col.objects {
+		if el.Failure() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphoreCh <- struct{}{}
+
+		go func(obj models.DirectoryObjectable) {
+			defer wg.Done()
+			defer func() { <-semaphoreCh }()
+
+			ictx := clues.Add(ctx, "item_id", ptr.Val(obj.GetId()))
+
+			item, err := col.getItem(ictx, obj)
+			if err != nil {
+				// objects deleted in flight are dropped from the backup.
+				if clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) || errors.Is(err, core.ErrNotFound) {
+					logger.CtxErr(ictx, err).Info("item deleted in flight. skipping")
+					col.Counter.Inc(count.StreamItemsDeletedInFlight)
+
+					return
+				}
+
+				el.AddRecoverable(ictx, clues.StackWC(ictx, err).Label(fault.LabelForceNoBackupCreation))
+
+				return
+			}
+
+			col.stream <- item
+
+			atomic.AddInt64(&streamedItems, 1)
+
+			if progressMessage != nil {
+				progressMessage <- struct{}{}
+			}
+		}(obj)
+	}
+
+	wg.Wait()
+}
+
+// getItem serializes the object, along with the members of groups.
+func (col *prefetchCollection) getItem(
+	ctx context.Context,
+	obj models.DirectoryObjectable,
+) (data.Item, error) {
+	var (
+		id      = ptr.Val(obj.GetId())
+		members []string
+		err     error
+	)
+
+	if col.objectType == details.EntraIDGroupsFolder {
+		members, err = col.getter.getGroupMemberIDs(ctx, id)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting group members")
+		}
+
+		// graph doesn't guarantee an order for members.
+		slices.Sort(members)
+	}
+
+	objData, err := serializeParsable(obj)
+	if err != nil {
+		return nil, clues.Wrap(err, "serializing directory object")
+	}
+
+	itemData, err := json.Marshal(directoryObject{
+		Object:  objData,
+		Members: members,
+	})
+	if err != nil {
+		return nil, clues.Wrap(err, "serializing item")
+	}
+
+	info, err := api.DirectoryObjectInfo(obj, objData, members, int64(len(itemData)))
+	if err != nil {
+		return nil, clues.Wrap(err, "building item info")
+	}
+
+	info.ParentPath = col.LocationPath().String()
+
+	col.Counter.Inc(count.StreamItemsAdded)
+	col.Counter.Add(count.StreamBytesAdded, info.Size)
+
+	item, err := data.NewPrefetchedItemWithInfo(
+		io.NopCloser(bytes.NewReader(itemData)),
+		id,
+		details.ItemInfo{EntraID: info})
+	if err != nil {
+		return nil, clues.Wrap(err, "creating item")
+	}
+
+	return item, nil
+}
+
+func serializeParsable(v serialization.Parsable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", v); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Stack(err).OrNil()
+}
@@ -0,0 +1,81 @@
+package entraid
+
+import (
+	"context"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// NewExportCollection produces a collection that exports the directory
+// objects of a single kind.  Each object is written to `<id>.json`, in
+// the same form it was backed up in: the object's properties, along with
+// the IDs of its members for groups.
+func NewExportCollection(
+	baseDir string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               cec,
+		Stream:            streamItems,
+		Stats:             stats,
+	}
+}
+
+// streamItems streams each directory object in the backing collections.
+func streamItems(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	_ int,
+	_ control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		for item := range rc.Items(ctx, errs) {
+			stats.UpdateResourceCount(path.DirectoryObjectsCategory)
+			body := metrics.ReaderWithStats(item.ToReader(), path.DirectoryObjectsCategory, stats)
+
+			ch <- export.Item{
+				ID:   item.ID(),
+				Name: item.ID() + ".json",
+				Body: body,
+			}
+		}
+
+		sendErrs(ch, errs)
+	}
+}
+
+// sendErrs returns all the items that we failed to source from the
+// persistence layer.
+func sendErrs(ch chan<- export.Item, errs *fault.Bus) {
+	items, recovered := errs.ItemsAndRecovered()
+
+	for _, item := range items {
+		ch <- export.Item{
+			ID:    item.ID,
+			Error: &item,
+		}
+	}
+
+	for _, err := range recovered {
+		ch <- export.Item{
+			Error: err,
+		}
+	}
+}
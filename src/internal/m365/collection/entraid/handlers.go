@@ -0,0 +1,167 @@
+package entraid
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// objectTypes are the kinds of directory objects in a backup, in the
+// order they're enumerated.  Each kind gets its own collection.
+var objectTypes = []string{
+	details.EntraIDUsersFolder,
+	details.EntraIDGroupsFolder,
+	details.EntraIDApplicationsFolder,
+	details.EntraIDServicePrincipalsFolder,
+}
+
+type backupHandler interface {
+	getObjectser
+	groupMemberGetter
+	includeObjectTypeer
+	canonicalPather
+}
+
+// gets every directory object of the given kind.
+type getObjectser interface {
+	getObjects(
+		ctx context.Context,
+		objectType string,
+	) ([]models.DirectoryObjectable, error)
+}
+
+// gets the IDs of the direct members of a group.
+type groupMemberGetter interface {
+	getGroupMemberIDs(
+		ctx context.Context,
+		groupID string,
+	) ([]string, error)
+}
+
+// includeObjectType evaluates whether the kind of directory object is
+// included in the provided scope.
+type includeObjectTypeer interface {
+	includeObjectType(
+		objectType string,
+		scope selectors.EntraIDScope,
+	) bool
+}
+
+// canonicalPath constructs the service and category specific path for
+// the given builder.
+type canonicalPather interface {
+	canonicalPath(
+		storageDir path.Elements,
+		tenantID string,
+	) (path.Path, error)
+}
+
+// restoreHandler re-adds deleted directory objects and the memberships
+// of groups in the restore target.
+type restoreHandler interface {
+	GetDirectoryObject(ctx context.Context, objectID string) (models.DirectoryObjectable, error)
+	RestoreDeletedItem(ctx context.Context, objectID string) (models.DirectoryObjectable, error)
+	GetGroupMemberIDs(ctx context.Context, groupID string) ([]string, error)
+	AddGroupMember(ctx context.Context, groupID, memberID string) error
+}
+
+var _ restoreHandler = api.Directory{}
+
+// ---------------------------------------------------------------------------
+// backup handler
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = &directoryBackupHandler{}
+
+type directoryBackupHandler struct {
+	ac                api.Directory
+	protectedResource string
+}
+
+func NewBackupHandler(
+	protectedResource string,
+	ac api.Directory,
+) directoryBackupHandler {
+	return directoryBackupHandler{
+		ac:                ac,
+		protectedResource: protectedResource,
+	}
+}
+
+func (bh directoryBackupHandler) getObjects(
+	ctx context.Context,
+	objectType string,
+) ([]models.DirectoryObjectable, error) {
+	switch objectType {
+	case details.EntraIDUsersFolder:
+		return asDirectoryObjects[models.Userable](bh.ac.GetUsers(ctx))
+	case details.EntraIDGroupsFolder:
+		return asDirectoryObjects[models.Groupable](bh.ac.GetGroups(ctx))
+	case details.EntraIDApplicationsFolder:
+		return asDirectoryObjects[models.Applicationable](bh.ac.GetApplications(ctx))
+	case details.EntraIDServicePrincipalsFolder:
+		return asDirectoryObjects[models.ServicePrincipalable](bh.ac.GetServicePrincipals(ctx))
+	}
+
+	return nil, clues.NewWC(ctx, "unknown directory object type").With("object_type", objectType)
+}
+
+func (bh directoryBackupHandler) getGroupMemberIDs(
+	ctx context.Context,
+	groupID string,
+) ([]string, error) {
+	return bh.ac.GetGroupMemberIDs(ctx, groupID)
+}
+
+func (bh directoryBackupHandler) includeObjectType(
+	objectType string,
+	scope selectors.EntraIDScope,
+) bool {
+	return scope.Matches(selectors.EntraIDObjectType, objectType)
+}
+
+func (bh directoryBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			bh.protectedResource,
+			path.EntraIDService,
+			path.DirectoryObjectsCategory,
+			false)
+}
+
+func (bh directoryBackupHandler) PathPrefix(tenantID string) (path.Path, error) {
+	return path.Build(
+		tenantID,
+		bh.protectedResource,
+		path.EntraIDService,
+		path.DirectoryObjectsCategory,
+		false)
+}
+
+func asDirectoryObjects[T models.DirectoryObjectable](
+	objs []T,
+	err error,
+) ([]models.DirectoryObjectable, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.DirectoryObjectable, 0, len(objs))
+
+	for _, o := range objs {
+		results = append(results, o)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,260 @@
+package entraid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// objectFactories produce the model for each kind of directory object.
+// Objects are listed without their odata type, so the kind comes from the
+// collection holding them.
+var objectFactories = map[string]serialization.ParsableFactory{
+	details.EntraIDUsersFolder:             models.CreateUserFromDiscriminatorValue,
+	details.EntraIDGroupsFolder:            models.CreateGroupFromDiscriminatorValue,
+	details.EntraIDApplicationsFolder:      models.CreateApplicationFromDiscriminatorValue,
+	details.EntraIDServicePrincipalsFolder: models.CreateServicePrincipalFromDiscriminatorValue,
+}
+
+// RestoreCollection restores the directory objects in the collection in
+// place.  Directory objects can't be recreated with their original IDs,
+// so objects that no longer exist are brought back from the directory's
+// recycle bin.  Objects that still exist are left untouched, apart from
+// groups, which have any of their backed up members that are missing
+// re-added.
+func RestoreCollection(
+	ctx context.Context,
+	rh restoreHandler,
+	dc data.RestoreCollection,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:entraid:restoreCollection", diagnostics.Label("path", dc.FullPath()))
+	defer end()
+
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		fullPath = dc.FullPath()
+		folders  = fullPath.Folders()
+	)
+
+	if len(folders) == 0 {
+		return metrics, clues.NewWC(ctx, "directory object collection has no object type")
+	}
+
+	objectType := folders[0]
+	ctx = clues.Add(ctx, "object_type", objectType)
+
+	factory, ok := objectFactories[objectType]
+	if !ok {
+		return metrics, clues.NewWC(ctx, "unknown directory object type")
+	}
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		path.DirectoryObjectsCategory.HumanString(),
+		objectType)
+	defer close(progressMessage)
+
+	items := dc.Items(ctx, errs)
+
+	for {
+		if el.Failure() != nil {
+			break
+		}
+
+		itemData, ok := <-items
+		if !ok {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", itemData.ID())
+		metrics.Objects++
+
+		body, err := io.ReadAll(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item data"))
+			continue
+		}
+
+		info, err := restoreObject(ictx, rh, itemData.ID(), body, factory, ctr)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "restoring directory object"))
+			continue
+		}
+
+		metrics.Bytes += int64(len(body))
+		metrics.Successes++
+
+		itemPath, err := fullPath.AppendItem(itemData.ID())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+			continue
+		}
+
+		info.ParentPath = objectType
+
+		err = deets.Add(
+			itemPath,
+			path.Builder{}.Append(objectType),
+			details.ItemInfo{EntraID: info})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+		}
+
+		progressMessage <- struct{}{}
+	}
+
+	return metrics, el.Failure()
+}
+
+// restoreObject undeletes the object if it no longer exists, and re-adds
+// the missing members of groups.
+func restoreObject(
+	ctx context.Context,
+	rh restoreHandler,
+	objectID string,
+	body []byte,
+	factory serialization.ParsableFactory,
+	ctr *count.Bus,
+) (*details.EntraIDInfo, error) {
+	var do directoryObject
+
+	if err := json.Unmarshal(body, &do); err != nil {
+		return nil, clues.WrapWC(ctx, err, "decoding directory object")
+	}
+
+	parsable, err := api.CreateFromBytes(do.Object, factory)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "deserializing directory object")
+	}
+
+	obj, ok := parsable.(models.DirectoryObjectable)
+	if !ok {
+		return nil, clues.NewWC(ctx, "item is not a directory object")
+	}
+
+	info, err := api.DirectoryObjectInfo(obj, do.Object, do.Members, int64(len(body)))
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	if err := ensureObject(ctx, rh, objectID, ctr); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	if info.ItemType != details.EntraIDGroup {
+		return info, nil
+	}
+
+	if err := restoreMembers(ctx, rh, objectID, do.Members, ctr); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return info, nil
+}
+
+// ensureObject restores the object from the recycle bin if it was deleted.
+func ensureObject(
+	ctx context.Context,
+	rh restoreHandler,
+	objectID string,
+	ctr *count.Bus,
+) error {
+	_, err := rh.GetDirectoryObject(ctx, objectID)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, core.ErrNotFound) {
+		return clues.Wrap(err, "looking up directory object")
+	}
+
+	if _, err := rh.RestoreDeletedItem(ctx, objectID); err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return clues.WrapWC(ctx, err, "object was permanently deleted and can't be restored")
+		}
+
+		return clues.Wrap(err, "restoring deleted object")
+	}
+
+	logger.Ctx(ctx).Info("restored deleted directory object")
+	ctr.Inc(count.DirectoryObjectsUndeleted)
+
+	return nil
+}
+
+// restoreMembers adds each backed up member that's missing from the group.
+// Members that were since removed from the directory are skipped.
+func restoreMembers(
+	ctx context.Context,
+	rh restoreHandler,
+	groupID string,
+	members []string,
+	ctr *count.Bus,
+) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	current, err := rh.GetGroupMemberIDs(ctx, groupID)
+	if err != nil {
+		return clues.Wrap(err, "getting current group members")
+	}
+
+	existing := make(map[string]struct{}, len(current))
+
+	for _, id := range current {
+		existing[id] = struct{}{}
+	}
+
+	for _, id := range members {
+		if _, ok := existing[id]; ok {
+			continue
+		}
+
+		ictx := clues.Add(ctx, "member_id", id)
+
+		err := rh.AddGroupMember(ictx, groupID, id)
+		if err != nil {
+			switch {
+			case graph.IsErrObjectReferenceExists(err):
+				// added by someone else since we looked.
+				continue
+			case errors.Is(err, core.ErrNotFound):
+				logger.CtxErr(ictx, err).Info("group member no longer exists")
+				ctr.Inc(count.GroupMembersNotFound)
+
+				continue
+			}
+
+			return clues.Wrap(err, "adding group member")
+		}
+
+		ctr.Inc(count.GroupMembersAdded)
+	}
+
+	return nil
+}
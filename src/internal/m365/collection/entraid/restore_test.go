@@ -0,0 +1,214 @@
+package entraid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+var _ restoreHandler = &mockRestoreHandler{}
+
+type mockRestoreHandler struct {
+	// ids of objects that no longer exist.
+	deleted map[string]struct{}
+	// ids of objects that are no longer in the recycle bin.
+	purged  map[string]struct{}
+	members map[string][]string
+
+	undeleted    []string
+	addedMembers []string
+}
+
+func (rh *mockRestoreHandler) GetDirectoryObject(
+	_ context.Context,
+	objectID string,
+) (models.DirectoryObjectable, error) {
+	if _, ok := rh.deleted[objectID]; ok {
+		return nil, clues.Stack(core.ErrNotFound)
+	}
+
+	o := models.NewDirectoryObject()
+	o.SetId(ptr.To(objectID))
+
+	return o, nil
+}
+
+func (rh *mockRestoreHandler) RestoreDeletedItem(
+	_ context.Context,
+	objectID string,
+) (models.DirectoryObjectable, error) {
+	if _, ok := rh.purged[objectID]; ok {
+		return nil, clues.Stack(core.ErrNotFound)
+	}
+
+	rh.undeleted = append(rh.undeleted, objectID)
+
+	o := models.NewDirectoryObject()
+	o.SetId(ptr.To(objectID))
+
+	return o, nil
+}
+
+func (rh *mockRestoreHandler) GetGroupMemberIDs(
+	_ context.Context,
+	groupID string,
+) ([]string, error) {
+	return rh.members[groupID], nil
+}
+
+func (rh *mockRestoreHandler) AddGroupMember(
+	_ context.Context,
+	_, memberID string,
+) error {
+	if _, ok := rh.deleted[memberID]; ok {
+		return clues.Stack(core.ErrNotFound)
+	}
+
+	rh.addedMembers = append(rh.addedMembers, memberID)
+
+	return nil
+}
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RestoreUnitSuite) TestRestoreCollection() {
+	table := []struct {
+		name            string
+		objectType      string
+		objects         []models.DirectoryObjectable
+		members         []string
+		rh              *mockRestoreHandler
+		expectErr       assert.ErrorAssertionFunc
+		expectUndeleted []string
+		expectAdded     []string
+		expectNotFound  int64
+	}{
+		{
+			name:       "existing users are untouched",
+			objectType: details.EntraIDUsersFolder,
+			objects:    []models.DirectoryObjectable{stubUser("u1", "adele")},
+			rh:         &mockRestoreHandler{},
+			expectErr:  assert.NoError,
+		},
+		{
+			name:            "deleted user is undeleted",
+			objectType:      details.EntraIDUsersFolder,
+			objects:         []models.DirectoryObjectable{stubUser("u1", "adele")},
+			rh:              &mockRestoreHandler{deleted: map[string]struct{}{"u1": {}}},
+			expectErr:       assert.NoError,
+			expectUndeleted: []string{"u1"},
+		},
+		{
+			name:       "purged user can't be restored",
+			objectType: details.EntraIDUsersFolder,
+			objects:    []models.DirectoryObjectable{stubUser("u1", "adele")},
+			rh: &mockRestoreHandler{
+				deleted: map[string]struct{}{"u1": {}},
+				purged:  map[string]struct{}{"u1": {}},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name:       "missing members are re-added",
+			objectType: details.EntraIDGroupsFolder,
+			objects:    []models.DirectoryObjectable{stubGroup("g1", "marketing")},
+			members:    []string{"u1", "u2", "u3"},
+			rh: &mockRestoreHandler{
+				members: map[string][]string{"g1": {"u2"}},
+			},
+			expectErr:   assert.NoError,
+			expectAdded: []string{"u1", "u3"},
+		},
+		{
+			name:       "deleted group and deleted member",
+			objectType: details.EntraIDGroupsFolder,
+			objects:    []models.DirectoryObjectable{stubGroup("g1", "marketing")},
+			members:    []string{"u1", "u2"},
+			rh: &mockRestoreHandler{
+				deleted: map[string]struct{}{"g1": {}, "u2": {}},
+				purged:  map[string]struct{}{"u2": {}},
+			},
+			expectErr:       assert.NoError,
+			expectUndeleted: []string{"g1"},
+			expectAdded:     []string{"u1"},
+			expectNotFound:  1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			fp, err := path.Build(
+				"tid", "tid",
+				path.EntraIDService,
+				path.DirectoryObjectsCategory,
+				false,
+				test.objectType)
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{Path: fp}
+			)
+
+			for _, obj := range test.objects {
+				objData, err := serializeParsable(obj)
+				require.NoError(t, err, clues.ToCore(err))
+
+				bs, err := json.Marshal(directoryObject{Object: objData, Members: test.members})
+				require.NoError(t, err, clues.ToCore(err))
+
+				dc.ItemData = append(dc.ItemData, &dataMock.Item{
+					ItemID: ptr.Val(obj.GetId()),
+					Reader: io.NopCloser(bytes.NewReader(bs)),
+				})
+			}
+
+			_, err = RestoreCollection(
+				ctx,
+				test.rh,
+				dc,
+				deets,
+				fault.New(true),
+				ctr)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectUndeleted, test.rh.undeleted)
+			assert.Equal(t, test.expectAdded, test.rh.addedMembers)
+			assert.Equal(t, int64(len(test.expectUndeleted)), ctr.Get(count.DirectoryObjectsUndeleted))
+			assert.Equal(t, int64(len(test.expectAdded)), ctr.Get(count.GroupMembersAdded))
+			assert.Equal(t, test.expectNotFound, ctr.Get(count.GroupMembersNotFound))
+
+			if err == nil {
+				assert.Len(t, deets.Details().Items(), len(test.objects))
+			}
+		})
+	}
+}
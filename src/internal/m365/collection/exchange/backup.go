@@ -77,6 +77,10 @@ func CreateCollections(
 		return nil, clues.Wrap(err, "populating container cache")
 	}
 
+	if err := populateOptionalTrees(ctx, cc, category, bpc.Options, errs); err != nil {
+		return nil, clues.Wrap(err, "populating optional folder trees")
+	}
+
 	collections, err = populateCollections(
 		ctx,
 		qp,
@@ -239,6 +243,13 @@ func populateCollections(
 
 		ictx = clues.Add(ictx, "previous_path", prevPath)
 
+		// a backup resumed from a checkpoint only trusts the delta tokens of
+		// the containers which the checkpointed backup completed.  The rest
+		// were only partially backed up, and get enumerated in full.
+		if ctrlOpts.Resume.Enabled() && prevPath != nil {
+			prevDelta = ctrlOpts.Resume.Completed[currPath.String()]
+		}
+
 		// Since part of this is about figuring out how many items to get for this
 		// particular container we need to reconfigure for every container we see.
 		if effectiveLimits.Enabled {
@@ -298,6 +309,7 @@ func populateCollections(
 			bh.itemHandler(),
 			addAndRem.Added,
 			addAndRem.Removed,
+			addAndRem.DU.URL,
 			// TODO: produce a feature flag that allows selective
 			// enabling of valid modTimes.  This currently produces
 			// rare failures with incorrect details merging.
@@ -99,6 +99,9 @@ type (
 	mockGetter struct {
 		noReturnDelta bool
 		results       map[string]mockGetterResults
+		// prevDeltas, if non-nil, records the previous delta which each
+		// container was enumerated from.
+		prevDeltas map[string]string
 	}
 	mockGetterResults struct {
 		added    []string
@@ -118,6 +121,10 @@ func (mg mockGetter) GetAddedAndRemovedItemIDs(
 		return pagers.AddedAndRemoved{}, clues.New("mock not found for " + cID)
 	}
 
+	if mg.prevDeltas != nil {
+		mg.prevDeltas[cID] = prevDelta
+	}
+
 	delta := results.newDelta
 	if mg.noReturnDelta {
 		delta.URL = ""
@@ -2795,3 +2802,121 @@ func (suite *CollectionPopulationSuite) TestFilterContainersAndFillCollections_i
 		}
 	}
 }
+
+func (suite *CollectionPopulationSuite) TestFilterContainersAndFillCollections_resume() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		userID   = "user_id"
+		tenantID = suite.creds.AzureTenantID
+		cat      = path.EmailCategory
+		qp       = graph.QueryParams{
+			Category:          cat,
+			ProtectedResource: inMock.NewProvider("user_id", "user_name"),
+			TenantID:          suite.creds.AzureTenantID,
+		}
+		statusUpdater = func(*support.ControllerOperationStatus) {}
+		allScope      = selectors.NewExchangeBackup(nil).MailFolders(selectors.Any())[0]
+		getter        = mockGetter{
+			results: map[string]mockGetterResults{
+				"1": {
+					added:    []string{"a1"},
+					newDelta: pagers.DeltaUpdate{URL: "delta_1"},
+				},
+				"2": {
+					added:    []string{"a2"},
+					newDelta: pagers.DeltaUpdate{URL: "delta_2", Reset: true},
+				},
+				"3": {
+					added:    []string{"a3"},
+					newDelta: pagers.DeltaUpdate{URL: "delta_3", Reset: true},
+				},
+			},
+			prevDeltas: map[string]string{},
+		}
+		resolver = newMockResolver(
+			mockContainer{
+				id:          strPtr("1"),
+				displayName: strPtr("completed"),
+				p:           path.Builder{}.Append("1", "completed"),
+				l:           path.Builder{}.Append("1", "completed"),
+			},
+			mockContainer{
+				id:          strPtr("2"),
+				displayName: strPtr("partial"),
+				p:           path.Builder{}.Append("2", "partial"),
+				l:           path.Builder{}.Append("2", "partial"),
+			},
+			mockContainer{
+				id:          strPtr("3"),
+				displayName: strPtr("new"),
+				p:           path.Builder{}.Append("3", "new"),
+				l:           path.Builder{}.Append("3", "new"),
+			})
+	)
+
+	prevPath := func(at ...string) path.Path {
+		p, err := path.Build(tenantID, userID, path.ExchangeService, cat, false, at...)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return p
+	}
+
+	dps := metadata.DeltaPaths{
+		"1": metadata.DeltaPath{
+			Delta: "checkpoint_delta_1",
+			Path:  prevPath("1", "completed").String(),
+		},
+		"2": metadata.DeltaPath{
+			Delta: "checkpoint_delta_2",
+			Path:  prevPath("2", "partial").String(),
+		},
+	}
+
+	ctrlOpts := control.DefaultOptions()
+	ctrlOpts.Resume = control.Resume{
+		BackupID: "checkpoint",
+		Completed: map[string]string{
+			prevPath("1", "completed").String(): "checkpoint_delta_1",
+		},
+	}
+
+	collections, err := populateCollections(
+		ctx,
+		qp,
+		mockBackupHandler{mg: getter, category: qp.Category},
+		statusUpdater,
+		resolver,
+		allScope,
+		dps,
+		ctrlOpts,
+		count.New(),
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	expectPrevDeltas := map[string]string{
+		"1": "checkpoint_delta_1",
+		// only partially backed up at the checkpoint.
+		"2": "",
+		// not in the checkpointed backup at all.
+		"3": "",
+	}
+
+	assert.Equal(t, expectPrevDeltas, getter.prevDeltas)
+
+	for _, c := range collections {
+		if c.FullPath().Service() == path.ExchangeMetadataService {
+			continue
+		}
+
+		p0 := c.FullPath().Folders()[0]
+
+		dt, ok := c.(data.DeltaTokener)
+		require.True(t, ok, "collection %s has a delta token", p0)
+		assert.Equal(t, "delta_"+p0, dt.DeltaToken(), "collection %s delta token", p0)
+		assert.Equal(t, p0 != "1", c.DoNotMergeItems(), "collection %s DoNotMergeItems", p0)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/alcionai/clues"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/support"
@@ -110,6 +111,7 @@ func NewCollection(
 	items itemGetterSerializer,
 	origAdded map[string]time.Time,
 	origRemoved []string,
+	deltaToken string,
 	validModTimes bool,
 	statusUpdater support.StatusUpdater,
 	counter *count.Bus,
@@ -139,6 +141,7 @@ func NewCollection(
 			user:           user,
 			added:          added,
 			removed:        removed,
+			deltaToken:     deltaToken,
 			getter:         items,
 			statusUpdater:  statusUpdater,
 		}
@@ -149,6 +152,7 @@ func NewCollection(
 		user:           user,
 		added:          added,
 		removed:        removed,
+		deltaToken:     deltaToken,
 		getter:         items,
 		statusUpdater:  statusUpdater,
 		counter:        counter,
@@ -166,12 +170,18 @@ type prefetchCollection struct {
 	added map[string]time.Time
 	// removed is a list of item IDs that were deleted from, or moved out, of a container
 	removed map[string]struct{}
+	// deltaToken continues the enumeration that produced added and removed.
+	deltaToken string
 
 	getter itemGetterSerializer
 
 	statusUpdater support.StatusUpdater
 }
 
+func (col *prefetchCollection) DeltaToken() string {
+	return col.deltaToken
+}
+
 // Items utility function to asynchronously execute process to fill data channel with
 // M365 exchange objects and returns the data channel
 func (col *prefetchCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
@@ -255,9 +265,13 @@ func (col *prefetchCollection) streamItems(
 		el         = errs.Local()
 	)
 
-	// add any new items
-	for id := range col.added {
-		if el.Failure() != nil {
+	// add any new items.  They're fetched in a stable order, so that a
+	// backup which stops early covers the same items each time.
+	for _, id := range sortedIDs(col.added) {
+		// the context gets cancelled when the backup stops early, such as at
+		// its checkpoint.  The remaining items get picked up by the next
+		// backup.
+		if el.Failure() != nil || ctx.Err() != nil {
 			break
 		}
 
@@ -279,6 +293,9 @@ func (col *prefetchCollection) streamItems(
 			if err != nil {
 				// Handle known error cases
 				switch {
+				case ctx.Err() != nil:
+					// the backup stopped early.  Not an error for this item.
+					logger.CtxErr(ctx, err).Debug("item fetch cancelled")
 				case errors.Is(err, core.ErrNotFound):
 					// Don't report errors for deleted items as there's no way for us to
 					// back up data that is gone. Record it as a "success", since there's
@@ -363,6 +380,8 @@ type lazyFetchCollection struct {
 	added map[string]time.Time
 	// removed is a list of item IDs that were deleted from, or moved out, of a container
 	removed map[string]struct{}
+	// deltaToken continues the enumeration that produced added and removed.
+	deltaToken string
 
 	getter itemGetterSerializer
 
@@ -371,6 +390,10 @@ type lazyFetchCollection struct {
 	counter *count.Bus
 }
 
+func (col *lazyFetchCollection) DeltaToken() string {
+	return col.deltaToken
+}
+
 // Items utility function to asynchronously execute process to fill data channel with
 // M365 exchange objects and returns the data channel
 func (col *lazyFetchCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
@@ -429,11 +452,16 @@ func (col *lazyFetchCollection) streamItems(
 	parentPath := col.LocationPath().String()
 
 	// add any new items
-	for id, modTime := range col.added {
-		if errs.Failure() != nil {
+	for _, id := range sortedIDs(col.added) {
+		// the context gets cancelled when the backup stops early, such as at
+		// its checkpoint.  The remaining items get picked up by the next
+		// backup.
+		if errs.Failure() != nil || ctx.Err() != nil {
 			break
 		}
 
+		modTime := col.added[id]
+
 		ictx := clues.Add(
 			ctx,
 			"item_id", id,
@@ -464,6 +492,14 @@ func (col *lazyFetchCollection) streamItems(
 	}
 }
 
+// sortedIDs returns the ids of the items in a stable order.
+func sortedIDs(items map[string]time.Time) []string {
+	ids := maps.Keys(items)
+	slices.Sort(ids)
+
+	return ids
+}
+
 type lazyItemGetter struct {
 	getter       itemGetterSerializer
 	userID       string
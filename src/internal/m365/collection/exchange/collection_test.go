@@ -155,6 +155,7 @@ func (suite *CollectionUnitSuite) TestNewCollection_state() {
 						mock.DefaultItemGetSerialize(),
 						nil,
 						nil,
+						"",
 						colType.validModTimes,
 						nil,
 						count.New())
@@ -175,6 +176,111 @@ func (suite *CollectionUnitSuite) TestNewCollection_state() {
 	}
 }
 
+func (suite *CollectionUnitSuite) TestCollection_Items_cancelled() {
+	statusUpdater := func(*support.ControllerOperationStatus) {}
+
+	fullPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "fnords")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name          string
+		validModTimes bool
+	}{
+		{
+			name: "prefetchCollection",
+		},
+		{
+			name:          "lazyFetchCollection",
+			validModTimes: true,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			col := NewCollection(
+				data.NewBaseCollection(
+					fullPath,
+					nil,
+					fullPath.ToBuilder(),
+					control.DefaultOptions(),
+					false,
+					count.New()),
+				"",
+				mock.DefaultItemGetSerialize(),
+				map[string]time.Time{"a": {}, "b": {}, "c": {}},
+				[]string{"removed"},
+				"delta",
+				test.validModTimes,
+				statusUpdater,
+				count.New())
+
+			var ids []string
+
+			for item := range col.Items(ctx, fault.New(true)) {
+				ids = append(ids, item.ID())
+			}
+
+			// removals don't need fetching, so they get handed over anyway.
+			assert.Equal(t, []string{"removed"}, ids, "no added items after cancellation")
+
+			dt, ok := col.(data.DeltaTokener)
+			require.True(t, ok, "collection has a delta token")
+			assert.Equal(t, "delta", dt.DeltaToken())
+		})
+	}
+}
+
+func (suite *CollectionUnitSuite) TestLazyFetchCollection_Items_order() {
+	var (
+		t             = suite.T()
+		statusUpdater = func(*support.ControllerOperationStatus) {}
+		added         = map[string]time.Time{}
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	fullPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "fnords")
+	require.NoError(t, err, clues.ToCore(err))
+
+	for _, id := range []string{"d", "b", "e", "a", "c"} {
+		added[id] = time.Now()
+	}
+
+	col := NewCollection(
+		data.NewBaseCollection(
+			fullPath,
+			nil,
+			fullPath.ToBuilder(),
+			control.DefaultOptions(),
+			false,
+			count.New()),
+		"",
+		mock.DefaultItemGetSerialize(),
+		added,
+		nil,
+		"",
+		true,
+		statusUpdater,
+		count.New())
+
+	var ids []string
+
+	for item := range col.Items(ctx, fault.New(true)) {
+		ids = append(ids, item.ID())
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, ids, "items are handed over in a stable order")
+}
+
 func (suite *CollectionUnitSuite) TestGetItemWithRetries() {
 	table := []struct {
 		name           string
@@ -300,6 +406,7 @@ func (suite *CollectionUnitSuite) TestPrefetchCollection_Items() {
 				&mock.ItemGetSerialize{},
 				test.added,
 				maps.Keys(test.removed),
+				"",
 				false,
 				statusUpdater,
 				count.New())
@@ -389,6 +496,7 @@ func (suite *CollectionUnitSuite) TestCollection_SkippedErrors() {
 				test.itemGetter,
 				test.added,
 				nil,
+				"",
 				false,
 				statusUpdater,
 				count.New())
@@ -521,6 +629,7 @@ func (suite *CollectionUnitSuite) TestLazyFetchCollection_Items_LazyFetch() {
 				mlg,
 				test.added,
 				maps.Keys(test.removed),
+				"",
 				true,
 				statusUpdater,
 				count.New())
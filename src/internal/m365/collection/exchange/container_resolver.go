@@ -33,6 +33,13 @@ type containersEnumerator[T any] interface {
 	) ([]T, error)
 }
 
+type containerTreeEnumerator[T any] interface {
+	EnumerateContainerTree(
+		ctx context.Context,
+		userID, baseDirID string,
+	) ([]T, error)
+}
+
 type containerRefresher interface {
 	refreshContainer(
 		ctx context.Context,
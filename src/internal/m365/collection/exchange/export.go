@@ -3,12 +3,17 @@ package exchange
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"io/fs"
+	"os"
 
 	"github.com/alcionai/clues"
 
 	"github.com/alcionai/corso/src/internal/converters/eml"
 	"github.com/alcionai/corso/src/internal/converters/ics"
+	"github.com/alcionai/corso/src/internal/converters/mbox"
+	"github.com/alcionai/corso/src/internal/converters/pst"
 	"github.com/alcionai/corso/src/internal/converters/vcf"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/pkg/control"
@@ -59,6 +64,8 @@ func streamItems(
 			ext = ".vcf"
 		case path.EventsCategory:
 			ext = ".ics"
+		case path.MailboxSettingsCategory:
+			ext = ".json"
 		}
 
 		for item := range rc.Items(ictx, errs) {
@@ -132,6 +139,9 @@ func streamItems(
 
 					continue
 				}
+			case path.MailboxSettingsCategory:
+				// settings are already stored as json.
+				outData = string(content)
 			}
 
 			emlReader := io.NopCloser(bytes.NewReader([]byte(outData)))
@@ -161,3 +171,336 @@ func streamItems(
 		}
 	}
 }
+
+// ---------------------------------------------------------------------------
+// mbox
+// ---------------------------------------------------------------------------
+
+// NewMBOXExportCollection produces a collection with a single mbox file,
+// named after the folder, which holds all the mail in the backing
+// collections.
+func NewMBOXExportCollection(
+	baseDir, folderName string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               control.ExportConfig{Format: control.MBOXFormat},
+		Stream: func(
+			ctx context.Context,
+			drc []data.RestoreCollection,
+			backupVersion int,
+			config control.ExportConfig,
+			ch chan<- export.Item,
+			stats *metrics.ExportStats,
+		) {
+			streamMBOX(ctx, folderName, drc, ch, stats)
+		},
+		Stats: stats,
+	}
+}
+
+// streamMBOX converts each email into eml and appends it to an mbox file.
+// The file is spooled to disk, since mailboxes can easily outgrow memory,
+// and is handed over as a single export item once complete.
+func streamMBOX(
+	ctx context.Context,
+	folderName string,
+	drc []data.RestoreCollection,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	var (
+		errs = fault.New(false)
+		name = folderName + ".mbox"
+		ctxn = clues.Add(ctx, "export_file_name", name)
+	)
+
+	f, err := newSpoolFile(name)
+	if err != nil {
+		ch <- export.Item{ID: folderName, Error: clues.StackWC(ctxn, err)}
+		return
+	}
+
+	w := mbox.NewWriter(f)
+
+	for _, rc := range drc {
+		ictx := clues.Add(ctxn, "path_short_ref", rc.FullPath().ShortRef())
+
+		for item := range rc.Items(ictx, errs) {
+			id := item.ID()
+			itemCtx := clues.Add(ictx, "stream_item_id", id)
+
+			content, err := readItem(item)
+			if err == nil {
+				var msg string
+
+				msg, err = eml.FromJSON(itemCtx, content)
+				if err == nil {
+					err = w.WriteMessage(msg)
+				}
+			}
+
+			if err != nil {
+				err = clues.WrapWC(itemCtx, err, "adding item to mbox")
+
+				logger.CtxErr(ctx, err).Info("processing collection item")
+
+				ch <- export.Item{
+					ID:    id,
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(path.EmailCategory)
+		}
+
+		sendFaults(errs, ch)
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		ch <- export.Item{ID: folderName, Error: clues.StackWC(ctxn, err)}
+
+		return
+	}
+
+	sendSpoolFile(ctxn, folderName, name, f, path.EmailCategory, ch, stats)
+}
+
+// ---------------------------------------------------------------------------
+// pst
+// ---------------------------------------------------------------------------
+
+// NewPSTExportCollection produces a collection with a single pst file
+// holding the mail, contacts and events of all the backing collections.
+// Each backing collection becomes a folder within the pst.
+func NewPSTExportCollection(
+	baseDir, mailboxName string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               control.ExportConfig{Format: control.PSTFormat},
+		Stream: func(
+			ctx context.Context,
+			drc []data.RestoreCollection,
+			backupVersion int,
+			config control.ExportConfig,
+			ch chan<- export.Item,
+			stats *metrics.ExportStats,
+		) {
+			streamPST(ctx, mailboxName, drc, ch, stats)
+		},
+		Stats: stats,
+	}
+}
+
+func streamPST(
+	ctx context.Context,
+	mailboxName string,
+	drc []data.RestoreCollection,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	var (
+		errs = fault.New(false)
+		name = mailboxName + ".pst"
+		ctxn = clues.Add(ctx, "export_file_name", name)
+	)
+
+	f, err := newSpoolFile(name)
+	if err != nil {
+		ch <- export.Item{ID: mailboxName, Error: clues.StackWC(ctxn, err)}
+		return
+	}
+
+	w, err := pst.NewWriter(f, mailboxName)
+	if err != nil {
+		f.Close()
+		ch <- export.Item{ID: mailboxName, Error: clues.StackWC(ctxn, err)}
+
+		return
+	}
+
+	for _, rc := range drc {
+		var (
+			ictx     = clues.Add(ctxn, "path_short_ref", rc.FullPath().ShortRef())
+			category = rc.FullPath().Category()
+			kind     = pst.MailFolder
+		)
+
+		switch category {
+		case path.ContactsCategory:
+			kind = pst.ContactFolder
+		case path.EventsCategory:
+			kind = pst.CalendarFolder
+		}
+
+		folder := w.Root().FolderPath(kind, rc.FullPath().Folders()...)
+
+		for item := range rc.Items(ictx, errs) {
+			id := item.ID()
+			itemCtx := clues.Add(ictx, "stream_item_id", id)
+
+			content, err := readItem(item)
+			if err == nil {
+				err = addPSTItem(itemCtx, folder, category, content)
+			}
+
+			if err != nil {
+				err = clues.WrapWC(itemCtx, err, "adding item to pst")
+
+				logger.CtxErr(ctx, err).Info("processing collection item")
+
+				ch <- export.Item{
+					ID:    id,
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(category)
+		}
+
+		sendFaults(errs, ch)
+	}
+
+	if err := w.Close(); err != nil {
+		f.Close()
+		ch <- export.Item{ID: mailboxName, Error: clues.StackWC(ctxn, err)}
+
+		return
+	}
+
+	sendSpoolFile(ctxn, mailboxName, name, f, path.EmailCategory, ch, stats)
+}
+
+func addPSTItem(
+	ctx context.Context,
+	folder *pst.Folder,
+	category path.CategoryType,
+	content []byte,
+) error {
+	switch category {
+	case path.EmailCategory:
+		msg, err := pst.MessageFromJSON(ctx, content)
+		if err != nil {
+			return clues.Wrap(err, "converting message")
+		}
+
+		return folder.AddMessage(msg)
+
+	case path.ContactsCategory:
+		contact, err := pst.ContactFromJSON(ctx, content)
+		if err != nil {
+			return clues.Wrap(err, "converting contact")
+		}
+
+		return folder.AddContact(contact)
+
+	case path.EventsCategory:
+		appt, err := pst.AppointmentFromJSON(ctx, content)
+		if err != nil {
+			return clues.Wrap(err, "converting event")
+		}
+
+		return folder.AddAppointment(appt)
+	}
+
+	return clues.NewWC(ctx, "data category not supported").With("category", category)
+}
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+func readItem(item data.Item) ([]byte, error) {
+	reader := item.ToReader()
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+
+	return content, clues.Wrap(err, "reading export item").OrNil()
+}
+
+// sendFaults returns all the items that we failed to source from the
+// persistence layer.
+func sendFaults(errs *fault.Bus, ch chan<- export.Item) {
+	items, recovered := errs.ItemsAndRecovered()
+
+	for _, err := range items {
+		ch <- export.Item{
+			ID:    err.ID,
+			Error: &err,
+		}
+	}
+
+	for _, err := range recovered {
+		ch <- export.Item{
+			Error: err,
+		}
+	}
+}
+
+// spoolFile is a temporary file which gets deleted once the export
+// consumer closes it.
+type spoolFile struct {
+	*os.File
+}
+
+func newSpoolFile(name string) (*spoolFile, error) {
+	f, err := os.CreateTemp("", "corso-export-*-"+name)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating spool file")
+	}
+
+	return &spoolFile{File: f}, nil
+}
+
+func (sf *spoolFile) Close() error {
+	err := sf.File.Close()
+
+	if rerr := os.Remove(sf.Name()); rerr != nil && !errors.Is(rerr, fs.ErrNotExist) {
+		return clues.Wrap(rerr, "removing spool file")
+	}
+
+	return clues.Wrap(err, "closing spool file").OrNil()
+}
+
+func sendSpoolFile(
+	ctx context.Context,
+	id, name string,
+	f *spoolFile,
+	category path.CategoryType,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		ch <- export.Item{ID: id, Error: clues.WrapWC(ctx, err, "rewinding spool file")}
+
+		return
+	}
+
+	ch <- export.Item{
+		ID:   id,
+		Name: name,
+		Body: metrics.ReaderWithStats(f, category, stats),
+	}
+}
@@ -1,6 +1,14 @@
 package exchange
 
 import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 )
@@ -52,8 +60,84 @@ func (h mailBackupHandler) NewContainerCache(
 	userID string,
 ) (string, graph.ContainerResolver) {
 	return api.MsgFolderRoot, &mailContainerCache{
-		userID: userID,
-		enumer: h.ac,
-		getter: h.ac,
+		userID:     userID,
+		enumer:     h.ac,
+		treeEnumer: h.ac,
+		getter:     h.ac,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// optional folder trees
+// ---------------------------------------------------------------------------
+
+// treePopulator is implemented by container caches that can hold folder
+// trees from outside of their default root.
+type treePopulator interface {
+	PopulateTree(
+		ctx context.Context,
+		errs *fault.Bus,
+		rootID, rootName string,
+	) error
+}
+
+// folderTree identifies the root of a folder tree, and the location it's
+// placed at in the backup.
+type folderTree struct {
+	rootID   string
+	rootName string
+}
+
+// optionalMailTrees lists the mail folder trees, aside from the primary
+// mailbox, that are included in the backup.
+func optionalMailTrees(opts control.Options) []folderTree {
+	trees := []folderTree{}
+
+	if opts.ToggleFeatures.ExchangeArchive {
+		trees = append(trees, folderTree{
+			rootID:   api.ArchiveMsgFolderRoot,
+			rootName: details.ExchangeArchiveFolder,
+		})
+	}
+
+	if opts.ToggleFeatures.ExchangeRecoverableItems {
+		trees = append(trees, folderTree{
+			rootID:   api.RecoverableItemsRoot,
+			rootName: details.ExchangeRecoverableItemsFolder,
+		})
+	}
+
+	return trees
+}
+
+// populateOptionalTrees adds the optional folder trees of the category to
+// the container cache.
+func populateOptionalTrees(
+	ctx context.Context,
+	cc graph.ContainerResolver,
+	category path.CategoryType,
+	opts control.Options,
+	errs *fault.Bus,
+) error {
+	if category != path.EmailCategory {
+		return nil
+	}
+
+	trees := optionalMailTrees(opts)
+	if len(trees) == 0 {
+		return nil
+	}
+
+	tp, ok := cc.(treePopulator)
+	if !ok {
+		return clues.NewWC(ctx, "container cache doesn't support additional folder trees")
 	}
+
+	for _, tree := range trees {
+		if err := tp.PopulateTree(ctx, errs, tree.rootID, tree.rootName); err != nil {
+			return clues.Wrap(err, "populating folder tree").With("tree_root_id", tree.rootID)
+		}
+	}
+
+	return nil
 }
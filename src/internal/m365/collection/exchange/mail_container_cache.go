@@ -2,11 +2,14 @@ package exchange
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -43,9 +46,10 @@ func (r *mailRefresher) refreshContainer(
 // nameLookup map: Key: DisplayName Value: ID
 type mailContainerCache struct {
 	*containerResolver
-	enumer containersEnumerator[models.MailFolderable]
-	getter containerGetter
-	userID string
+	enumer     containersEnumerator[models.MailFolderable]
+	treeEnumer containerTreeEnumerator[models.MailFolderable]
+	getter     containerGetter
+	userID     string
 }
 
 // init ensures that the structure's fields are initialized.
@@ -144,3 +148,74 @@ func (mc *mailContainerCache) Populate(
 
 	return el.Failure()
 }
+
+// PopulateTree adds a folder tree that sits outside of the primary
+// mailbox's msgfolderroot, such as the online archive, to the cache.
+// The root of the tree is given its own path element, and is located
+// at rootName, so the tree stays apart from the primary mailbox folders.
+// Mailboxes that don't have the tree (ex: users without an archive) are
+// left unchanged.
+func (mc *mailContainerCache) PopulateTree(
+	ctx context.Context,
+	errs *fault.Bus,
+	rootID, rootName string,
+) error {
+	ctx = clues.Add(ctx, "tree_root_id", rootID)
+
+	if mc.treeEnumer == nil {
+		return clues.NewWC(ctx, "container cache can't enumerate folder trees")
+	}
+
+	if mc.containerResolver == nil {
+		return clues.NewWC(ctx, "container cache must be populated before adding trees")
+	}
+
+	f, err := mc.getter.GetContainerByID(ctx, mc.userID, rootID)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			logger.CtxErr(ctx, err).Info("mailbox has no folder tree at root")
+			return nil
+		}
+
+		return clues.Wrap(err, "fetching tree root folder")
+	}
+
+	root := graph.NewCacheFolder(
+		f,
+		path.Builder{}.Append(ptr.Val(f.GetId())),
+		path.Builder{}.Append(rootName))
+	if err := mc.addFolder(&root); err != nil {
+		return clues.WrapWC(ctx, err, "adding tree root folder")
+	}
+
+	el := errs.Local()
+
+	containers, err := mc.treeEnumer.EnumerateContainerTree(ctx, mc.userID, rootID)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "enumerating tree containers")
+	}
+
+	for _, c := range containers {
+		if el.Failure() != nil {
+			return el.Failure()
+		}
+
+		cacheFolder := graph.NewCacheFolder(c, nil, nil)
+
+		if err := mc.addFolder(&cacheFolder); err != nil {
+			errs.AddRecoverable(
+				ctx,
+				graph.Stack(ctx, err).Label(fault.LabelForceNoBackupCreation))
+		}
+	}
+
+	if err := mc.populatePaths(ctx, errs); err != nil {
+		return clues.Wrap(err, "populating paths")
+	}
+
+	logger.Ctx(ctx).Infow(
+		"added folder tree to container cache",
+		"num_enumerated_containers", len(containers))
+
+	return el.Failure()
+}
@@ -1,19 +1,24 @@
 package exchange
 
 import (
+	"context"
 	stdpath "path"
 	"testing"
 
 	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
@@ -114,3 +119,78 @@ func (suite *MailFolderCacheIntegrationSuite) TestDeltaFetch() {
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// unit
+// ---------------------------------------------------------------------------
+
+var _ containerTreeEnumerator[models.MailFolderable] = mockMailTreeEnumerator{}
+
+type mockMailTreeEnumerator struct {
+	trees map[string][]models.MailFolderable
+}
+
+func (m mockMailTreeEnumerator) EnumerateContainerTree(
+	_ context.Context,
+	_, baseDirID string,
+) ([]models.MailFolderable, error) {
+	return m.trees[baseDirID], nil
+}
+
+func stubMailFolder(id, parentID, name string) models.MailFolderable {
+	f := models.NewMailFolder()
+	f.SetId(ptr.To(id))
+	f.SetParentFolderId(ptr.To(parentID))
+	f.SetDisplayName(ptr.To(name))
+
+	return f
+}
+
+type MailFolderCacheUnitSuite struct {
+	tester.Suite
+}
+
+func TestMailFolderCacheUnitSuite(t *testing.T) {
+	suite.Run(t, &MailFolderCacheUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *MailFolderCacheUnitSuite) TestPopulateTree() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	mfc := mailContainerCache{
+		userID: "u",
+		getter: mockContainerGetter{
+			itemsByID: map[string]containerGetterRes{
+				api.MsgFolderRoot:        {c: stubMailFolder("root", "top", "Top of Information Store")},
+				api.ArchiveMsgFolderRoot: {c: stubMailFolder("archive", "archive-top", "Top of Information Store")},
+				api.RecoverableItemsRoot: {err: core.ErrNotFound},
+			},
+		},
+		treeEnumer: mockMailTreeEnumerator{
+			trees: map[string][]models.MailFolderable{
+				api.ArchiveMsgFolderRoot: {
+					stubMailFolder("archived-inbox", "archive", "Inbox"),
+					stubMailFolder("archived-2020", "archived-inbox", "2020"),
+				},
+			},
+		},
+	}
+
+	err := mfc.init(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = mfc.PopulateTree(ctx, fault.New(true), api.ArchiveMsgFolderRoot, details.ExchangeArchiveFolder)
+	require.NoError(t, err, clues.ToCore(err))
+
+	p, l, err := mfc.IDToPath(ctx, "archived-2020")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []string{"archive", "archived-inbox", "archived-2020"}, []string(p.Elements()))
+	assert.Equal(t, []string{details.ExchangeArchiveFolder, "Inbox", "2020"}, []string(l.Elements()))
+
+	err = mfc.PopulateTree(ctx, fault.New(true), api.RecoverableItemsRoot, details.ExchangeRecoverableItemsFolder)
+	require.NoError(t, err, "missing trees are skipped", clues.ToCore(err))
+	assert.Len(t, mfc.Items(), 4)
+}
@@ -0,0 +1,335 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ data.BackupCollection = &settingsCollection{}
+
+// settingsGetter fetches the settings of a mailbox.  Settings don't fit
+// the container/item handlers used by mail, contacts and events: they
+// aren't held in folders, and none of them support delta queries.
+type settingsGetter interface {
+	GetSettings(ctx context.Context, userID string) (models.MailboxSettingsable, error)
+	GetInboxRules(ctx context.Context, userID string) ([]models.MessageRuleable, error)
+	GetCategories(ctx context.Context, userID string) ([]models.OutlookCategoryable, error)
+}
+
+var _ settingsGetter = api.MailboxSettings{}
+
+// CreateSettingsCollections produces the mailbox settings collection of
+// the user, holding one item for each setting in scope.  Settings are
+// small and can't be enumerated incrementally, so each backup holds a
+// full copy of them.
+func CreateSettingsCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	sg settingsGetter,
+	tenantID string,
+	scope selectors.ExchangeScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+) ([]data.BackupCollection, error) {
+	settings := []string{}
+
+	for _, name := range details.ExchangeSettingNames {
+		if scope.Matches(selectors.ExchangeSettings, name) {
+			settings = append(settings, name)
+		}
+	}
+
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	fp, err := path.Build(
+		tenantID,
+		bpc.ProtectedResource.ID(),
+		path.ExchangeService,
+		path.MailboxSettingsCategory,
+		false)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making settings path").Label(count.BadCollPath)
+	}
+
+	coll := &settingsCollection{
+		BaseCollection: data.NewBaseCollection(
+			fp,
+			// settings always live in the same place, and are fully
+			// replaced on each backup.
+			fp,
+			path.Builder{}.Append(),
+			bpc.Options,
+			true,
+			counter),
+		getter:        sg,
+		userID:        bpc.ProtectedResource.ID(),
+		settings:      settings,
+		statusUpdater: su,
+		stream:        make(chan data.Item, len(settings)),
+	}
+
+	return []data.BackupCollection{coll}, nil
+}
+
+// ---------------------------------------------------------------------------
+// collection
+// ---------------------------------------------------------------------------
+
+// settingsCollection holds the settings of a single mailbox.
+type settingsCollection struct {
+	data.BaseCollection
+	stream chan data.Item
+
+	getter   settingsGetter
+	userID   string
+	settings []string
+
+	// the mailbox settings resource holds both the general settings and
+	// the automatic replies, so it's only fetched once.
+	mailboxSettings models.MailboxSettingsable
+
+	statusUpdater support.StatusUpdater
+}
+
+func (col *settingsCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	go col.streamItems(ctx, errs)
+	return col.stream
+}
+
+func (col *settingsCollection) streamItems(ctx context.Context, errs *fault.Bus) {
+	var (
+		success    int
+		totalBytes int64
+		el         = errs.Local()
+	)
+
+	ctx = clues.Add(ctx, "category", col.Category().String())
+
+	defer func() {
+		close(col.stream)
+		logger.Ctx(ctx).Infow(
+			"finished stream backup collection items",
+			"stats", col.Counter.Values())
+
+		updateStatus(
+			ctx,
+			col.statusUpdater,
+			len(col.settings),
+			success,
+			totalBytes,
+			col.FullPath().Folder(false),
+			el.Failure())
+	}()
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		col.Category().HumanString(),
+		col.LocationPath().Elements())
+	defer close(progressMessage)
+
+	for _, name := range col.settings {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", name)
+
+		item, size, err := col.getItem(ictx, name)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.StackWC(ictx, err).Label(fault.LabelForceNoBackupCreation))
+			continue
+		}
+
+		col.stream <- item
+
+		success++
+		totalBytes += size
+
+		progressMessage <- struct{}{}
+	}
+}
+
+// getItem fetches and serializes a single setting.
+func (col *settingsCollection) getItem(
+	ctx context.Context,
+	name string,
+) (data.Item, int64, error) {
+	var (
+		content []byte
+		entries = 1
+		err     error
+	)
+
+	switch name {
+	case details.ExchangeMailboxSettings:
+		content, err = col.serializeMailboxSettings(ctx)
+
+	case details.ExchangeAutomaticReplies:
+		content, err = col.serializeAutomaticReplies(ctx)
+
+	case details.ExchangeInboxRules:
+		var rules []models.MessageRuleable
+
+		rules, err = col.getter.GetInboxRules(ctx, col.userID)
+		if err != nil {
+			return nil, 0, clues.Wrap(err, "getting inbox rules")
+		}
+
+		entries = len(rules)
+		col.Counter.Add(count.InboxRules, int64(entries))
+
+		content, err = serializeList(rules)
+
+	case details.ExchangeCategories:
+		var cats []models.OutlookCategoryable
+
+		cats, err = col.getter.GetCategories(ctx, col.userID)
+		if err != nil {
+			return nil, 0, clues.Wrap(err, "getting categories")
+		}
+
+		entries = len(cats)
+		col.Counter.Add(count.MailboxCategories, int64(entries))
+
+		content, err = serializeList(cats)
+
+	default:
+		return nil, 0, clues.NewWC(ctx, "unknown mailbox setting")
+	}
+
+	if err != nil {
+		return nil, 0, clues.Wrap(err, "serializing setting")
+	}
+
+	size := int64(len(content))
+
+	col.Counter.Inc(count.StreamItemsAdded)
+	col.Counter.Add(count.StreamBytesAdded, size)
+
+	item, err := data.NewPrefetchedItemWithInfo(
+		io.NopCloser(bytes.NewReader(content)),
+		name,
+		details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType:       details.ExchangeSetting,
+				Setting:        name,
+				SettingEntries: entries,
+				Size:           size,
+				// settings have no modification time.  They're fetched
+				// fresh on each backup, so they're treated as modified.
+				Modified: time.Now(),
+			},
+		})
+	if err != nil {
+		return nil, 0, clues.Wrap(err, "creating item")
+	}
+
+	return item, size, nil
+}
+
+func (col *settingsCollection) getMailboxSettings(
+	ctx context.Context,
+) (models.MailboxSettingsable, error) {
+	if col.mailboxSettings != nil {
+		return col.mailboxSettings, nil
+	}
+
+	ms, err := col.getter.GetSettings(ctx, col.userID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting mailbox settings")
+	}
+
+	col.mailboxSettings = ms
+
+	return ms, nil
+}
+
+// serializeMailboxSettings serializes the mailbox settings, minus the
+// automatic replies, which are stored as their own item.
+func (col *settingsCollection) serializeMailboxSettings(ctx context.Context) ([]byte, error) {
+	ms, err := col.getMailboxSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ars := ms.GetAutomaticRepliesSetting()
+
+	ms.SetAutomaticRepliesSetting(nil)
+	defer ms.SetAutomaticRepliesSetting(ars)
+
+	return serializeParsable(ms)
+}
+
+func (col *settingsCollection) serializeAutomaticReplies(ctx context.Context) ([]byte, error) {
+	ms, err := col.getMailboxSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ars := ms.GetAutomaticRepliesSetting()
+	if ars == nil {
+		ars = models.NewAutomaticRepliesSetting()
+	}
+
+	return serializeParsable(ars)
+}
+
+// ---------------------------------------------------------------------------
+// serialization
+// ---------------------------------------------------------------------------
+
+func serializeParsable(v serialization.Parsable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", v); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// serializeList produces a json array of the serialized values.
+func serializeList[T serialization.Parsable](vs []T) ([]byte, error) {
+	list := make([]json.RawMessage, 0, len(vs))
+
+	for _, v := range vs {
+		bs, err := serializeParsable(v)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, bs)
+	}
+
+	bs, err := json.Marshal(list)
+
+	return bs, clues.Stack(err).OrNil()
+}
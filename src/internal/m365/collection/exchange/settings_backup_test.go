@@ -0,0 +1,215 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	inMock "github.com/alcionai/corso/src/internal/common/idname/mock"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+var _ settingsGetter = &mockSettingsGetter{}
+
+type mockSettingsGetter struct {
+	settings    models.MailboxSettingsable
+	rules       []models.MessageRuleable
+	categories  []models.OutlookCategoryable
+	settingsErr error
+}
+
+func (m mockSettingsGetter) GetSettings(
+	context.Context,
+	string,
+) (models.MailboxSettingsable, error) {
+	return m.settings, m.settingsErr
+}
+
+func (m mockSettingsGetter) GetInboxRules(
+	context.Context,
+	string,
+) ([]models.MessageRuleable, error) {
+	return m.rules, nil
+}
+
+func (m mockSettingsGetter) GetCategories(
+	context.Context,
+	string,
+) ([]models.OutlookCategoryable, error) {
+	return m.categories, nil
+}
+
+func stubInboxRule(id, name string) models.MessageRuleable {
+	rule := models.NewMessageRule()
+	rule.SetId(ptr.To(id))
+	rule.SetDisplayName(ptr.To(name))
+	rule.SetIsEnabled(ptr.To(true))
+
+	return rule
+}
+
+func stubCategory(id, name string, color models.CategoryColor) models.OutlookCategoryable {
+	cat := models.NewOutlookCategory()
+	cat.SetId(ptr.To(id))
+	cat.SetDisplayName(ptr.To(name))
+	cat.SetColor(ptr.To(color))
+
+	return cat
+}
+
+func stubMailboxSettings() models.MailboxSettingsable {
+	ars := models.NewAutomaticRepliesSetting()
+	ars.SetInternalReplyMessage(ptr.To("out of office"))
+
+	ms := models.NewMailboxSettings()
+	ms.SetTimeZone(ptr.To("Pacific Standard Time"))
+	ms.SetAutomaticRepliesSetting(ars)
+
+	return ms
+}
+
+type SettingsBackupUnitSuite struct {
+	tester.Suite
+}
+
+func TestSettingsBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &SettingsBackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SettingsBackupUnitSuite) TestCreateSettingsCollections() {
+	sel := selectors.NewExchangeBackup([]string{"u"})
+
+	table := []struct {
+		name        string
+		scope       selectors.ExchangeScope
+		getter      mockSettingsGetter
+		expectItems []string
+		expectErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "all settings",
+			scope: sel.Settings(selectors.Any())[0],
+			getter: mockSettingsGetter{
+				settings:   stubMailboxSettings(),
+				rules:      []models.MessageRuleable{stubInboxRule("r1", "rule")},
+				categories: []models.OutlookCategoryable{stubCategory("c1", "Red", models.PRESET0_CATEGORYCOLOR)},
+			},
+			expectItems: details.ExchangeSettingNames,
+			expectErr:   assert.NoError,
+		},
+		{
+			name:        "only rules",
+			scope:       sel.Settings([]string{details.ExchangeInboxRules})[0],
+			getter:      mockSettingsGetter{rules: []models.MessageRuleable{stubInboxRule("r1", "rule")}},
+			expectItems: []string{details.ExchangeInboxRules},
+			expectErr:   assert.NoError,
+		},
+		{
+			name:  "settings failure",
+			scope: sel.Settings([]string{details.ExchangeMailboxSettings})[0],
+			getter: mockSettingsGetter{
+				settingsErr: assert.AnError,
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bpc := inject.BackupProducerConfig{
+				Options:           control.DefaultOptions(),
+				ProtectedResource: inMock.NewProvider("u", "u"),
+			}
+
+			colls, err := CreateSettingsCollections(
+				ctx,
+				bpc,
+				test.getter,
+				"t",
+				test.scope,
+				func(*support.ControllerOperationStatus) {},
+				count.New())
+			require.NoError(t, err, clues.ToCore(err))
+			require.Len(t, colls, 1)
+
+			coll := colls[0]
+			assert.Equal(t, path.MailboxSettingsCategory, coll.FullPath().Category())
+			assert.Empty(t, coll.FullPath().Folders())
+			assert.Equal(t, coll.FullPath(), coll.PreviousPath())
+			assert.True(t, coll.DoNotMergeItems())
+
+			var (
+				errs  = fault.New(true)
+				found = []string{}
+			)
+
+			for item := range coll.Items(ctx, errs) {
+				found = append(found, item.ID())
+
+				bs, err := io.ReadAll(item.ToReader())
+				require.NoError(t, err, clues.ToCore(err))
+				assert.True(t, json.Valid(bs), "item is json")
+
+				info, ok := item.(data.ItemInfo)
+				require.True(t, ok, "item has info")
+
+				ii, err := info.Info()
+				require.NoError(t, err, clues.ToCore(err))
+				assert.Equal(t, details.ExchangeSetting, ii.Exchange.ItemType)
+				assert.Equal(t, item.ID(), ii.Exchange.Setting)
+			}
+
+			test.expectErr(t, errs.Failure(), clues.ToCore(errs.Failure()))
+
+			if test.expectItems != nil {
+				assert.Equal(t, test.expectItems, found)
+			}
+		})
+	}
+}
+
+func (suite *SettingsBackupUnitSuite) TestSettingsCollection_autoRepliesSplit() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	col := &settingsCollection{
+		getter: mockSettingsGetter{settings: stubMailboxSettings()},
+		userID: "u",
+	}
+
+	bs, err := col.serializeMailboxSettings(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.NotContains(t, string(bs), "out of office", "automatic replies are stored separately")
+	assert.Contains(t, string(bs), "Pacific Standard Time")
+	assert.NotNil(
+		t,
+		col.mailboxSettings.GetAutomaticRepliesSetting(),
+		"cached settings keep their automatic replies")
+
+	bs, err = col.serializeAutomaticReplies(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Contains(t, string(bs), "out of office")
+}
@@ -0,0 +1,532 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// settingsRestorer applies backed up settings to a mailbox.
+type settingsRestorer interface {
+	settingsGetter
+	PatchSettings(ctx context.Context, userID string, body models.MailboxSettingsable) error
+	PostInboxRule(ctx context.Context, userID string, body models.MessageRuleable) (models.MessageRuleable, error)
+	DeleteInboxRule(ctx context.Context, userID, ruleID string) error
+	PostCategory(ctx context.Context, userID string, body models.OutlookCategoryable) (models.OutlookCategoryable, error)
+	PatchCategoryColor(ctx context.Context, userID, categoryID string, color models.CategoryColor) error
+}
+
+var _ settingsRestorer = api.MailboxSettings{}
+
+// RestoreSettings applies the settings in the collection to the user's
+// mailbox.  Mailbox settings and automatic replies can't be copied, so
+// they're only overwritten under the Replace collision policy, or if the
+// mailbox has none of its own.  Inbox rules and categories are identified
+// by display name:
+// colliding rules are skipped, replaced, or restored alongside the
+// existing rule according to the collision policy.  Category names are
+// unique within a mailbox, so colliding categories are only ever
+// skipped or have their color replaced.
+func RestoreSettings(
+	ctx context.Context,
+	sr settingsRestorer,
+	dc data.RestoreCollection,
+	userID string,
+	collisionPolicy control.CollisionPolicy,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:exchange:restoreSettings", diagnostics.Label("path", dc.FullPath()))
+	defer end()
+
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		fullPath = dc.FullPath()
+	)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		path.MailboxSettingsCategory.HumanString(),
+		fullPath.Category().HumanString())
+	defer close(progressMessage)
+
+	items := dc.Items(ctx, errs)
+
+	for {
+		if el.Failure() != nil {
+			break
+		}
+
+		itemData, ok := <-items
+		if !ok {
+			break
+		}
+
+		name := itemData.ID()
+		ictx := clues.Add(ctx, "item_id", name)
+		metrics.Objects++
+
+		body, err := io.ReadAll(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item data"))
+			continue
+		}
+
+		entries, err := restoreSetting(ictx, sr, userID, name, body, collisionPolicy, el, ctr)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "restoring mailbox setting"))
+			continue
+		}
+
+		metrics.Bytes += int64(len(body))
+		metrics.Successes++
+
+		itemPath, err := fullPath.AppendItem(name)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+			continue
+		}
+
+		err = deets.Add(
+			itemPath,
+			&path.Builder{},
+			details.ItemInfo{
+				Exchange: &details.ExchangeInfo{
+					ItemType:       details.ExchangeSetting,
+					Setting:        name,
+					SettingEntries: entries,
+					Size:           int64(len(body)),
+				},
+			})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+		}
+
+		progressMessage <- struct{}{}
+	}
+
+	return metrics, el.Failure()
+}
+
+// restoreSetting applies a single setting, returning the number of
+// entries it held.
+func restoreSetting(
+	ctx context.Context,
+	sr settingsRestorer,
+	userID, name string,
+	body []byte,
+	collisionPolicy control.CollisionPolicy,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (int, error) {
+	switch name {
+	case details.ExchangeMailboxSettings:
+		return 1, restoreMailboxSettings(ctx, sr, userID, body, collisionPolicy, ctr)
+
+	case details.ExchangeAutomaticReplies:
+		return 1, restoreAutomaticReplies(ctx, sr, userID, body, collisionPolicy, ctr)
+
+	case details.ExchangeInboxRules:
+		rules, err := deserializeList[models.MessageRuleable](body, models.CreateMessageRuleFromDiscriminatorValue)
+		if err != nil {
+			return 0, clues.WrapWC(ctx, err, "deserializing inbox rules")
+		}
+
+		return len(rules), restoreInboxRules(ctx, sr, userID, rules, collisionPolicy, errs, ctr)
+
+	case details.ExchangeCategories:
+		cats, err := deserializeList[models.OutlookCategoryable](body, models.CreateOutlookCategoryFromDiscriminatorValue)
+		if err != nil {
+			return 0, clues.WrapWC(ctx, err, "deserializing categories")
+		}
+
+		return len(cats), restoreCategories(ctx, sr, userID, cats, collisionPolicy, errs, ctr)
+	}
+
+	return 0, clues.NewWC(ctx, "unknown mailbox setting")
+}
+
+// restoreMailboxSettings patches the writable mailbox settings.  Read-only
+// properties, such as the user purpose, are left out of the request.
+func restoreMailboxSettings(
+	ctx context.Context,
+	sr settingsRestorer,
+	userID string,
+	body []byte,
+	collisionPolicy control.CollisionPolicy,
+	ctr *count.Bus,
+) error {
+	parsable, err := api.CreateFromBytes(body, models.CreateMailboxSettingsFromDiscriminatorValue)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "deserializing mailbox settings")
+	}
+
+	ms, ok := parsable.(models.MailboxSettingsable)
+	if !ok {
+		return clues.NewWC(ctx, "item is not mailbox settings")
+	}
+
+	current, err := sr.GetSettings(ctx, userID)
+	if err != nil {
+		return clues.Wrap(err, "getting existing mailbox settings")
+	}
+
+	var (
+		patch    = writableSettings(ms)
+		existing = writableSettings(current)
+	)
+
+	return patchSetting(
+		ctx,
+		sr,
+		userID,
+		patch,
+		patch,
+		existing,
+		mailboxSettingsAreSet(existing),
+		collisionPolicy,
+		ctr)
+}
+
+func restoreAutomaticReplies(
+	ctx context.Context,
+	sr settingsRestorer,
+	userID string,
+	body []byte,
+	collisionPolicy control.CollisionPolicy,
+	ctr *count.Bus,
+) error {
+	parsable, err := api.CreateFromBytes(body, models.CreateAutomaticRepliesSettingFromDiscriminatorValue)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "deserializing automatic replies")
+	}
+
+	ars, ok := parsable.(models.AutomaticRepliesSettingable)
+	if !ok {
+		return clues.NewWC(ctx, "item is not an automatic replies setting")
+	}
+
+	current, err := sr.GetSettings(ctx, userID)
+	if err != nil {
+		return clues.Wrap(err, "getting existing automatic replies")
+	}
+
+	var existing models.AutomaticRepliesSettingable
+
+	if current != nil {
+		existing = current.GetAutomaticRepliesSetting()
+	}
+
+	if existing == nil {
+		existing = models.NewAutomaticRepliesSetting()
+	}
+
+	patch := models.NewMailboxSettings()
+	patch.SetAutomaticRepliesSetting(ars)
+
+	return patchSetting(
+		ctx,
+		sr,
+		userID,
+		patch,
+		ars,
+		existing,
+		automaticRepliesAreSet(existing),
+		collisionPolicy,
+		ctr)
+}
+
+// patchSetting applies the patch to the mailbox settings, unless the
+// restored setting collides with the existing one.  A setting collides
+// if the mailbox has one of its own that differs from the restored one.
+// Settings can't be copied, so colliding settings are skipped under any
+// policy other than Replace.
+func patchSetting(
+	ctx context.Context,
+	sr settingsRestorer,
+	userID string,
+	patch models.MailboxSettingsable,
+	restored, existing serialization.Parsable,
+	existingIsSet bool,
+	collisionPolicy control.CollisionPolicy,
+	ctr *count.Bus,
+) error {
+	if existingIsSet {
+		rbs, err := serializeParsable(restored)
+		if err != nil {
+			return clues.WrapWC(ctx, err, "serializing restored setting")
+		}
+
+		ebs, err := serializeParsable(existing)
+		if err != nil {
+			return clues.WrapWC(ctx, err, "serializing existing setting")
+		}
+
+		if bytes.Equal(rbs, ebs) {
+			logger.Ctx(ctx).Debug("mailbox setting already matches the backup")
+			return nil
+		}
+
+		log := logger.Ctx(ctx).With("collision_policy", collisionPolicy)
+		log.Debug("mailbox setting collision")
+
+		if collisionPolicy != control.Replace {
+			ctr.Inc(count.CollisionSkip)
+			log.Debug("skipping mailbox setting with collision")
+
+			return nil
+		}
+	}
+
+	if err := sr.PatchSettings(ctx, userID, patch); err != nil {
+		return clues.Stack(err)
+	}
+
+	if existingIsSet {
+		ctr.Inc(count.CollisionReplace)
+	} else {
+		ctr.Inc(count.NewItemCreated)
+	}
+
+	return nil
+}
+
+// writableSettings copies the mailbox settings that can be patched,
+// leaving out the automatic replies, which are restored on their own.
+func writableSettings(ms models.MailboxSettingsable) models.MailboxSettingsable {
+	ws := models.NewMailboxSettings()
+
+	if ms == nil {
+		return ws
+	}
+
+	ws.SetTimeZone(ms.GetTimeZone())
+	ws.SetLanguage(ms.GetLanguage())
+	ws.SetDateFormat(ms.GetDateFormat())
+	ws.SetTimeFormat(ms.GetTimeFormat())
+	ws.SetWorkingHours(ms.GetWorkingHours())
+	ws.SetDelegateMeetingMessageDeliveryOptions(ms.GetDelegateMeetingMessageDeliveryOptions())
+
+	return ws
+}
+
+// mailboxSettingsAreSet is false if none of the user's preferences are
+// set.  Graph reports a default delivery option for delegate meeting
+// messages on every mailbox, so that one isn't considered.
+func mailboxSettingsAreSet(ms models.MailboxSettingsable) bool {
+	return len(ptr.Val(ms.GetTimeZone())) > 0 ||
+		ms.GetLanguage() != nil ||
+		len(ptr.Val(ms.GetDateFormat())) > 0 ||
+		len(ptr.Val(ms.GetTimeFormat())) > 0 ||
+		ms.GetWorkingHours() != nil
+}
+
+// automaticRepliesAreSet is false if automatic replies are disabled and
+// have no messages.
+func automaticRepliesAreSet(ars models.AutomaticRepliesSettingable) bool {
+	status := ars.GetStatus()
+
+	return (status != nil && *status != models.DISABLED_AUTOMATICREPLIESSTATUS) ||
+		len(ptr.Val(ars.GetInternalReplyMessage())) > 0 ||
+		len(ptr.Val(ars.GetExternalReplyMessage())) > 0
+}
+
+// restoreInboxRules creates each rule in the user's inbox.  Rules that
+// reference folders by ID will fail to restore into a different mailbox;
+// those failures are recoverable and don't stop the other rules.
+func restoreInboxRules(
+	ctx context.Context,
+	sr settingsRestorer,
+	userID string,
+	rules []models.MessageRuleable,
+	collisionPolicy control.CollisionPolicy,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) error {
+	current, err := sr.GetInboxRules(ctx, userID)
+	if err != nil {
+		return clues.Wrap(err, "getting existing inbox rules")
+	}
+
+	existing := map[string][]string{}
+
+	for _, r := range current {
+		name := ptr.Val(r.GetDisplayName())
+		existing[name] = append(existing[name], ptr.Val(r.GetId()))
+	}
+
+	for _, rule := range rules {
+		if errs.Failure() != nil {
+			break
+		}
+
+		name := ptr.Val(rule.GetDisplayName())
+		ictx := clues.Add(ctx, "rule_name", clues.Hide(name))
+		replaced := false
+
+		if ids := existing[name]; len(ids) > 0 {
+			log := logger.Ctx(ictx).With("collision_policy", collisionPolicy)
+			log.Debug("inbox rule collision")
+
+			switch collisionPolicy {
+			case control.Skip:
+				ctr.Inc(count.CollisionSkip)
+				log.Debug("skipping inbox rule with collision")
+
+				continue
+
+			case control.Replace:
+				for _, id := range ids {
+					if err := sr.DeleteInboxRule(ictx, userID, id); err != nil {
+						errs.AddRecoverable(ictx, clues.Wrap(err, "deleting existing inbox rule"))
+						continue
+					}
+				}
+
+				delete(existing, name)
+
+				replaced = true
+			}
+		}
+
+		// server-populated properties are rejected on create.
+		rule.SetId(nil)
+		rule.SetHasError(nil)
+		rule.SetIsReadOnly(nil)
+
+		if _, err := sr.PostInboxRule(ictx, userID, rule); err != nil {
+			errs.AddRecoverable(ictx, clues.Wrap(err, "restoring inbox rule"))
+			continue
+		}
+
+		if replaced {
+			ctr.Inc(count.CollisionReplace)
+		} else {
+			ctr.Inc(count.NewItemCreated)
+		}
+	}
+
+	return nil
+}
+
+// restoreCategories adds each category to the user's master category list.
+func restoreCategories(
+	ctx context.Context,
+	sr settingsRestorer,
+	userID string,
+	cats []models.OutlookCategoryable,
+	collisionPolicy control.CollisionPolicy,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) error {
+	current, err := sr.GetCategories(ctx, userID)
+	if err != nil {
+		return clues.Wrap(err, "getting existing categories")
+	}
+
+	// category names are case-insensitive.
+	existing := map[string]models.OutlookCategoryable{}
+
+	for _, c := range current {
+		existing[strings.ToLower(ptr.Val(c.GetDisplayName()))] = c
+	}
+
+	for _, cat := range cats {
+		if errs.Failure() != nil {
+			break
+		}
+
+		name := ptr.Val(cat.GetDisplayName())
+		ictx := clues.Add(ctx, "category_name", clues.Hide(name))
+
+		if ec, ok := existing[strings.ToLower(name)]; ok {
+			log := logger.Ctx(ictx).With("collision_policy", collisionPolicy)
+			log.Debug("category collision")
+
+			// graph rejects duplicate category names, so copies
+			// can't be made.
+			if collisionPolicy != control.Replace {
+				ctr.Inc(count.CollisionSkip)
+				log.Debug("skipping category with collision")
+
+				continue
+			}
+
+			if cat.GetColor() != nil {
+				err := sr.PatchCategoryColor(ictx, userID, ptr.Val(ec.GetId()), ptr.Val(cat.GetColor()))
+				if err != nil {
+					errs.AddRecoverable(ictx, clues.Wrap(err, "replacing category"))
+					continue
+				}
+			}
+
+			ctr.Inc(count.CollisionReplace)
+
+			continue
+		}
+
+		cat.SetId(nil)
+
+		if _, err := sr.PostCategory(ictx, userID, cat); err != nil {
+			errs.AddRecoverable(ictx, clues.Wrap(err, "restoring category"))
+			continue
+		}
+
+		ctr.Inc(count.NewItemCreated)
+	}
+
+	return nil
+}
+
+// deserializeList reverses serializeList.
+func deserializeList[T serialization.Parsable](
+	bs []byte,
+	factory serialization.ParsableFactory,
+) ([]T, error) {
+	var list []json.RawMessage
+
+	if err := json.Unmarshal(bs, &list); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	vs := make([]T, 0, len(list))
+
+	for _, raw := range list {
+		parsable, err := api.CreateFromBytes(raw, factory)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		v, ok := parsable.(T)
+		if !ok {
+			return nil, clues.New("unexpected item type")
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
@@ -0,0 +1,347 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+var _ settingsRestorer = &mockSettingsRestorer{}
+
+type mockSettingsRestorer struct {
+	mockSettingsGetter
+	patched       []models.MailboxSettingsable
+	postedRules   []models.MessageRuleable
+	deletedRules  []string
+	postedCats    []models.OutlookCategoryable
+	patchedColors map[string]models.CategoryColor
+}
+
+func (m *mockSettingsRestorer) PatchSettings(
+	_ context.Context,
+	_ string,
+	body models.MailboxSettingsable,
+) error {
+	m.patched = append(m.patched, body)
+	return nil
+}
+
+func (m *mockSettingsRestorer) PostInboxRule(
+	_ context.Context,
+	_ string,
+	body models.MessageRuleable,
+) (models.MessageRuleable, error) {
+	m.postedRules = append(m.postedRules, body)
+	return body, nil
+}
+
+func (m *mockSettingsRestorer) DeleteInboxRule(
+	_ context.Context,
+	_, ruleID string,
+) error {
+	m.deletedRules = append(m.deletedRules, ruleID)
+	return nil
+}
+
+func (m *mockSettingsRestorer) PostCategory(
+	_ context.Context,
+	_ string,
+	body models.OutlookCategoryable,
+) (models.OutlookCategoryable, error) {
+	m.postedCats = append(m.postedCats, body)
+	return body, nil
+}
+
+func (m *mockSettingsRestorer) PatchCategoryColor(
+	_ context.Context,
+	_, categoryID string,
+	color models.CategoryColor,
+) error {
+	if m.patchedColors == nil {
+		m.patchedColors = map[string]models.CategoryColor{}
+	}
+
+	m.patchedColors[categoryID] = color
+
+	return nil
+}
+
+type SettingsRestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestSettingsRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &SettingsRestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SettingsRestoreUnitSuite) TestRestoreSettings() {
+	table := []struct {
+		name            string
+		collisionPolicy control.CollisionPolicy
+		expectRules     int
+		expectDeleted   []string
+		expectCats      []string
+		expectColors    map[string]models.CategoryColor
+		expectSkipped   int64
+		expectCreated   int64
+		expectReplaced  int64
+	}{
+		{
+			name:            "collision skip",
+			collisionPolicy: control.Skip,
+			expectRules:     1,
+			expectCats:      []string{"Blue"},
+			expectSkipped:   2,
+			expectCreated:   4,
+		},
+		{
+			name:            "collision copy",
+			collisionPolicy: control.Copy,
+			expectRules:     2,
+			expectCats:      []string{"Blue"},
+			expectSkipped:   1,
+			expectCreated:   5,
+		},
+		{
+			name:            "collision replace",
+			collisionPolicy: control.Replace,
+			expectRules:     2,
+			expectDeleted:   []string{"existing"},
+			expectCats:      []string{"Blue"},
+			expectColors:    map[string]models.CategoryColor{"red": models.PRESET1_CATEGORYCOLOR},
+			expectCreated:   4,
+			expectReplaced:  2,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			fp, err := path.Build("t", "u", path.ExchangeService, path.MailboxSettingsCategory, false)
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				sr = &mockSettingsRestorer{
+					mockSettingsGetter: mockSettingsGetter{
+						rules:      []models.MessageRuleable{stubInboxRule("existing", "Move newsletters")},
+						categories: []models.OutlookCategoryable{stubCategory("red", "red", models.PRESET0_CATEGORYCOLOR)},
+					},
+				}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				col   = &settingsCollection{
+					getter: mockSettingsGetter{settings: stubMailboxSettings()},
+				}
+			)
+
+			msBytes, err := col.serializeMailboxSettings(ctx)
+			require.NoError(t, err, clues.ToCore(err))
+
+			arBytes, err := col.serializeAutomaticReplies(ctx)
+			require.NoError(t, err, clues.ToCore(err))
+
+			ruleBytes, err := serializeList([]models.MessageRuleable{
+				stubInboxRule("r1", "Move newsletters"),
+				stubInboxRule("r2", "Flag boss"),
+			})
+			require.NoError(t, err, clues.ToCore(err))
+
+			catBytes, err := serializeList([]models.OutlookCategoryable{
+				stubCategory("c1", "Red", models.PRESET1_CATEGORYCOLOR),
+				stubCategory("c2", "Blue", models.PRESET7_CATEGORYCOLOR),
+			})
+			require.NoError(t, err, clues.ToCore(err))
+
+			dc := dataMock.Collection{
+				Path: fp,
+				ItemData: []data.Item{
+					stubSettingItem(details.ExchangeMailboxSettings, msBytes),
+					stubSettingItem(details.ExchangeAutomaticReplies, arBytes),
+					stubSettingItem(details.ExchangeInboxRules, ruleBytes),
+					stubSettingItem(details.ExchangeCategories, catBytes),
+				},
+			}
+
+			metrics, err := RestoreSettings(
+				ctx,
+				sr,
+				dc,
+				"u",
+				test.collisionPolicy,
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, 4, metrics.Successes)
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace))
+			assert.Equal(t, test.expectDeleted, sr.deletedRules)
+			assert.Equal(t, test.expectColors, sr.patchedColors)
+
+			require.Len(t, sr.patched, 2)
+			assert.Equal(t, "Pacific Standard Time", ptr.Val(sr.patched[0].GetTimeZone()))
+			assert.Nil(t, sr.patched[0].GetAutomaticRepliesSetting())
+			require.NotNil(t, sr.patched[1].GetAutomaticRepliesSetting())
+			assert.Equal(
+				t,
+				"out of office",
+				ptr.Val(sr.patched[1].GetAutomaticRepliesSetting().GetInternalReplyMessage()))
+
+			require.Len(t, sr.postedRules, test.expectRules)
+
+			for _, r := range sr.postedRules {
+				assert.Nil(t, r.GetId(), "ids are assigned by graph")
+			}
+
+			cats := []string{}
+			for _, c := range sr.postedCats {
+				cats = append(cats, ptr.Val(c.GetDisplayName()))
+			}
+
+			assert.Equal(t, test.expectCats, cats)
+			assert.Len(t, deets.Details().Items(), 4)
+		})
+	}
+}
+
+func (suite *SettingsRestoreUnitSuite) TestRestoreSettings_existingSettings() {
+	differentSettings := func() models.MailboxSettingsable {
+		ars := models.NewAutomaticRepliesSetting()
+		ars.SetStatus(ptr.To(models.ALWAYSENABLED_AUTOMATICREPLIESSTATUS))
+		ars.SetInternalReplyMessage(ptr.To("on vacation"))
+
+		ms := models.NewMailboxSettings()
+		ms.SetTimeZone(ptr.To("UTC"))
+		ms.SetAutomaticRepliesSetting(ars)
+
+		return ms
+	}
+
+	table := []struct {
+		name            string
+		existing        models.MailboxSettingsable
+		collisionPolicy control.CollisionPolicy
+		expectPatched   int
+		expectSkipped   int64
+		expectCreated   int64
+		expectReplaced  int64
+	}{
+		{
+			name:            "skip, nothing set",
+			existing:        models.NewMailboxSettings(),
+			collisionPolicy: control.Skip,
+			expectPatched:   2,
+			expectCreated:   2,
+		},
+		{
+			name:            "skip, different settings",
+			existing:        differentSettings(),
+			collisionPolicy: control.Skip,
+			expectSkipped:   2,
+		},
+		{
+			name:            "copy, different settings",
+			existing:        differentSettings(),
+			collisionPolicy: control.Copy,
+			expectSkipped:   2,
+		},
+		{
+			name:            "skip, same settings",
+			existing:        stubMailboxSettings(),
+			collisionPolicy: control.Skip,
+		},
+		{
+			name:            "replace, different settings",
+			existing:        differentSettings(),
+			collisionPolicy: control.Replace,
+			expectPatched:   2,
+			expectReplaced:  2,
+		},
+		{
+			name:            "replace, same settings",
+			existing:        stubMailboxSettings(),
+			collisionPolicy: control.Replace,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			fp, err := path.Build("t", "u", path.ExchangeService, path.MailboxSettingsCategory, false)
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				sr = &mockSettingsRestorer{
+					mockSettingsGetter: mockSettingsGetter{settings: test.existing},
+				}
+				ctr = count.New()
+				col = &settingsCollection{
+					getter: mockSettingsGetter{settings: stubMailboxSettings()},
+				}
+			)
+
+			msBytes, err := col.serializeMailboxSettings(ctx)
+			require.NoError(t, err, clues.ToCore(err))
+
+			arBytes, err := col.serializeAutomaticReplies(ctx)
+			require.NoError(t, err, clues.ToCore(err))
+
+			dc := dataMock.Collection{
+				Path: fp,
+				ItemData: []data.Item{
+					stubSettingItem(details.ExchangeMailboxSettings, msBytes),
+					stubSettingItem(details.ExchangeAutomaticReplies, arBytes),
+				},
+			}
+
+			metrics, err := RestoreSettings(
+				ctx,
+				sr,
+				dc,
+				"u",
+				test.collisionPolicy,
+				&details.Builder{},
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, 2, metrics.Successes)
+			assert.Len(t, sr.patched, test.expectPatched)
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace))
+		})
+	}
+}
+
+func stubSettingItem(name string, bs []byte) data.Item {
+	return &dataMock.Item{
+		ItemID: name,
+		Reader: io.NopCloser(bytes.NewReader(bs)),
+	}
+}
@@ -0,0 +1,420 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// maxChannelNameLen is the longest display name teams accepts for a channel.
+const maxChannelNameLen = 50
+
+// characters teams doesn't allow in channel names.
+var channelNameReplacer = strings.NewReplacer(
+	"~", "-", "#", "-", "%", "-", "&", "-", "*", "-",
+	"{", "-", "}", "-", "+", "-", "/", "-", `\`, "-",
+	":", "-", "<", "-", ">", "-", "?", "-", "|", "-",
+	"'", "-", `"`, "-", ",", "-", "..", "-")
+
+var _ channelRestorer = api.Channels{}
+
+type channelRestorer interface {
+	GetChannels(
+		ctx context.Context,
+		teamID string,
+	) ([]models.Channelable, error)
+	CreateMigrationChannel(
+		ctx context.Context,
+		teamID, displayName string,
+		createdAt time.Time,
+	) (models.Channelable, error)
+	PostMigrationMessage(
+		ctx context.Context,
+		teamID, channelID string,
+		body models.ChatMessageable,
+	) (models.ChatMessageable, error)
+	PostMigrationReply(
+		ctx context.Context,
+		teamID, channelID, messageID string,
+		body models.ChatMessageable,
+	) (models.ChatMessageable, error)
+	CompleteChannelMigration(
+		ctx context.Context,
+		teamID, channelID string,
+	) error
+}
+
+// ChannelRestoreCache holds the names of the team's channels, so that
+// restored channels don't collide with each other or with existing ones.
+type ChannelRestoreCache struct {
+	// lowercased display names, since teams compares them case-insensitively.
+	names map[string]struct{}
+}
+
+func NewChannelRestoreCache() *ChannelRestoreCache {
+	return &ChannelRestoreCache{}
+}
+
+func (c *ChannelRestoreCache) populate(
+	ctx context.Context,
+	cr channelRestorer,
+	teamID string,
+) error {
+	if c.names != nil {
+		return nil
+	}
+
+	chans, err := cr.GetChannels(ctx, teamID)
+	if err != nil {
+		return clues.Wrap(err, "getting existing channels")
+	}
+
+	c.names = map[string]struct{}{}
+
+	for _, ch := range chans {
+		c.add(ptr.Val(ch.GetDisplayName()))
+	}
+
+	return nil
+}
+
+func (c *ChannelRestoreCache) has(name string) bool {
+	_, ok := c.names[strings.ToLower(name)]
+	return ok
+}
+
+func (c *ChannelRestoreCache) add(name string) {
+	c.names[strings.ToLower(name)] = struct{}{}
+}
+
+type restoreMessage struct {
+	itemID string
+	size   int64
+	msg    models.ChatMessageable
+}
+
+// RestoreChannelMessages imports the messages in the collection, along
+// with their replies, into a new channel.  Messages keep their original
+// authors and timestamps, which graph only allows for channels created in
+// migration mode.  Since existing channels can't be put into migration
+// mode, collisions are handled per channel: if a channel with the
+// restored name already exists, its messages are either skipped, or
+// restored into a copy of the channel.  Graph has no way to replace the
+// messages of an existing channel, so the Replace policy also produces
+// a copy.
+func RestoreChannelMessages(
+	ctx context.Context,
+	cr channelRestorer,
+	dc data.RestoreCollection,
+	teamID, restoreLocation string,
+	cache *ChannelRestoreCache,
+	collisionPolicy control.CollisionPolicy,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:groups:restoreChannelMessages", diagnostics.Label("path", dc.FullPath()))
+	defer end()
+
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		fullPath = dc.FullPath()
+		folders  = fullPath.Folders()
+	)
+
+	if len(folders) == 0 {
+		return metrics, clues.NewWC(ctx, "channel messages collection has no channel")
+	}
+
+	msgs, err := readRestoreMessages(ctx, dc, &metrics, errs)
+	if err != nil || len(msgs) == 0 {
+		return metrics, clues.Stack(err).OrNil()
+	}
+
+	if err := cache.populate(ctx, cr, teamID); err != nil {
+		return metrics, clues.Stack(err)
+	}
+
+	name := channelRestoreName(restoreLocation, folders[len(folders)-1])
+	ctx = clues.Add(ctx, "restore_channel_name", clues.Hide(name))
+
+	if cache.has(name) {
+		log := logger.Ctx(ctx).With("collision_policy", collisionPolicy)
+		log.Debug("channel collision")
+
+		if collisionPolicy == control.Skip {
+			ctr.Add(count.CollisionSkip, int64(len(msgs)))
+			log.Debug("skipping channel with collision")
+
+			return metrics, nil
+		}
+
+		if collisionPolicy == control.Replace {
+			log.Info("existing channels can't be replaced, restoring a copy of the channel")
+		}
+
+		name = nextChannelName(name, cache)
+		ctx = clues.Add(ctx, "restore_channel_name", clues.Hide(name))
+	}
+
+	ch, err := cr.CreateMigrationChannel(ctx, teamID, name, earliestMessage(msgs).Add(-time.Second))
+	if err != nil {
+		return metrics, clues.Wrap(err, "creating restore channel")
+	}
+
+	cache.add(name)
+
+	channelID := ptr.Val(ch.GetId())
+	ctx = clues.Add(ctx, "restore_channel_id", channelID)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		path.ChannelMessagesCategory.HumanString(),
+		clues.Hide(name))
+	defer close(progressMessage)
+
+	for _, rm := range msgs {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", rm.itemID)
+
+		if err := importMessage(ictx, cr, teamID, channelID, rm.msg); err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "restoring channel message"))
+			continue
+		}
+
+		metrics.Bytes += rm.size
+		metrics.Successes++
+
+		ctr.Inc(count.NewItemCreated)
+
+		itemPath, err := fullPath.AppendItem(rm.itemID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+			continue
+		}
+
+		err = deets.Add(
+			itemPath,
+			path.Builder{}.Append(name),
+			details.ItemInfo{
+				Groups: api.ChannelMessageInfo(rm.msg),
+			})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+		}
+
+		progressMessage <- struct{}{}
+	}
+
+	// The channel stays locked until the migration completes, so this
+	// needs to happen even if some of the messages failed.
+	if err := cr.CompleteChannelMigration(ctx, teamID, channelID); err != nil {
+		el.AddRecoverable(ctx, clues.Wrap(err, "completing channel migration"))
+	}
+
+	return metrics, el.Failure()
+}
+
+// readRestoreMessages deserializes every message in the collection,
+// ordered oldest first.  Messages need to be in hand before the channel
+// is created, since the channel must predate all of them.
+func readRestoreMessages(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	metrics *support.CollectionMetrics,
+	errs *fault.Bus,
+) ([]restoreMessage, error) {
+	var (
+		el    = errs.Local()
+		msgs  = []restoreMessage{}
+		items = dc.Items(ctx, errs)
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, clues.WrapWC(ctx, ctx.Err(), "context cancelled")
+
+		case itemData, ok := <-items:
+			if !ok || el.Failure() != nil {
+				sort.SliceStable(msgs, func(i, j int) bool {
+					return ptr.Val(msgs[i].msg.GetCreatedDateTime()).
+						Before(ptr.Val(msgs[j].msg.GetCreatedDateTime()))
+				})
+
+				return msgs, el.Failure()
+			}
+
+			ictx := clues.Add(ctx, "item_id", itemData.ID())
+			metrics.Objects++
+
+			buf := &bytes.Buffer{}
+
+			_, err := buf.ReadFrom(itemData.ToReader())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+				continue
+			}
+
+			msg, err := api.BytesToChatMessageable(buf.Bytes())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "creating channel message from bytes"))
+				continue
+			}
+
+			msgs = append(msgs, restoreMessage{
+				itemID: itemData.ID(),
+				size:   int64(buf.Len()),
+				msg:    msg,
+			})
+		}
+	}
+}
+
+// importMessage imports the message, then each of its replies, oldest
+// first.
+func importMessage(
+	ctx context.Context,
+	cr channelRestorer,
+	teamID, channelID string,
+	msg models.ChatMessageable,
+) error {
+	posted, err := cr.PostMigrationMessage(ctx, teamID, channelID, toImportMessage(msg))
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	replies := msg.GetReplies()
+
+	sort.SliceStable(replies, func(i, j int) bool {
+		return ptr.Val(replies[i].GetCreatedDateTime()).
+			Before(ptr.Val(replies[j].GetCreatedDateTime()))
+	})
+
+	for _, r := range replies {
+		_, err := cr.PostMigrationReply(
+			ctx,
+			teamID,
+			channelID,
+			ptr.Val(posted.GetId()),
+			toImportMessage(r))
+		if err != nil {
+			return clues.Wrap(err, "restoring reply").With("reply_id", ptr.Val(r.GetId()))
+		}
+	}
+
+	return nil
+}
+
+// toImportMessage copies the properties of a backed up message that graph
+// accepts when importing it.  Only attachments that reference files can
+// be imported; the markup of any other attachment is swapped for a plain
+// text reference.
+func toImportMessage(msg models.ChatMessageable) models.ChatMessageable {
+	var (
+		im   = models.NewChatMessage()
+		keep = []models.ChatMessageAttachmentable{}
+		drop = []models.ChatMessageAttachmentable{}
+	)
+
+	im.SetCreatedDateTime(msg.GetCreatedDateTime())
+	im.SetFrom(msg.GetFrom())
+	im.SetSubject(msg.GetSubject())
+	im.SetImportance(msg.GetImportance())
+	im.SetMentions(msg.GetMentions())
+
+	for _, a := range msg.GetAttachments() {
+		if ptr.Val(a.GetContentType()) == "reference" {
+			keep = append(keep, a)
+		} else {
+			drop = append(drop, a)
+		}
+	}
+
+	im.SetAttachments(keep)
+
+	body := models.NewItemBody()
+	body.SetContent(ptr.To(""))
+
+	if b := msg.GetBody(); b != nil {
+		body.SetContentType(b.GetContentType())
+		body.SetContent(ptr.To(api.ReplaceChatMessageAttachmentMarkup(ptr.Val(b.GetContent()), drop)))
+	}
+
+	im.SetBody(body)
+
+	return im
+}
+
+func earliestMessage(msgs []restoreMessage) time.Time {
+	earliest := time.Now()
+
+	for _, rm := range msgs {
+		if t := ptr.Val(rm.msg.GetCreatedDateTime()); !t.IsZero() && t.Before(earliest) {
+			earliest = t
+		}
+
+		for _, r := range rm.msg.GetReplies() {
+			if t := ptr.Val(r.GetCreatedDateTime()); !t.IsZero() && t.Before(earliest) {
+				earliest = t
+			}
+		}
+	}
+
+	return earliest
+}
+
+// channelRestoreName produces the name of the channel that receives the
+// restored messages.  In-place restores reuse the original name.
+func channelRestoreName(restoreLocation, channelName string) string {
+	name := channelName
+	if len(restoreLocation) > 0 {
+		name = restoreLocation + "_" + channelName
+	}
+
+	return truncateChannelName(channelNameReplacer.Replace(name), "")
+}
+
+// nextChannelName finds the first free name of the form "<name> <n>".
+func nextChannelName(name string, cache *ChannelRestoreCache) string {
+	for i := 1; ; i++ {
+		next := truncateChannelName(name, " "+strconv.Itoa(i))
+		if !cache.has(next) {
+			return next
+		}
+	}
+}
+
+func truncateChannelName(name, suffix string) string {
+	rs := []rune(name)
+	if limit := maxChannelNameLen - len([]rune(suffix)); len(rs) > limit {
+		rs = rs[:limit]
+	}
+
+	return strings.TrimSpace(string(rs)) + suffix
+}
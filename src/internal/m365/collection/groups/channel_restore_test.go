@@ -0,0 +1,322 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+var _ channelRestorer = &mockChannelRestorer{}
+
+type mockChannelRestorer struct {
+	channels  []models.Channelable
+	created   []string
+	createdAt []time.Time
+	messages  []models.ChatMessageable
+	replies   map[string][]models.ChatMessageable
+	completed []string
+}
+
+func (m *mockChannelRestorer) GetChannels(
+	_ context.Context,
+	_ string,
+) ([]models.Channelable, error) {
+	return m.channels, nil
+}
+
+func (m *mockChannelRestorer) CreateMigrationChannel(
+	_ context.Context,
+	_, displayName string,
+	createdAt time.Time,
+) (models.Channelable, error) {
+	m.created = append(m.created, displayName)
+	m.createdAt = append(m.createdAt, createdAt)
+
+	ch := models.NewChannel()
+	ch.SetId(ptr.To("id-" + displayName))
+	ch.SetDisplayName(ptr.To(displayName))
+
+	return ch, nil
+}
+
+func (m *mockChannelRestorer) PostMigrationMessage(
+	_ context.Context,
+	_, _ string,
+	body models.ChatMessageable,
+) (models.ChatMessageable, error) {
+	m.messages = append(m.messages, body)
+
+	msg := models.NewChatMessage()
+	msg.SetId(body.GetBody().GetContent())
+
+	return msg, nil
+}
+
+func (m *mockChannelRestorer) PostMigrationReply(
+	_ context.Context,
+	_, _, messageID string,
+	body models.ChatMessageable,
+) (models.ChatMessageable, error) {
+	if m.replies == nil {
+		m.replies = map[string][]models.ChatMessageable{}
+	}
+
+	m.replies[messageID] = append(m.replies[messageID], body)
+
+	return body, nil
+}
+
+func (m *mockChannelRestorer) CompleteChannelMigration(
+	_ context.Context,
+	_, channelID string,
+) error {
+	m.completed = append(m.completed, channelID)
+	return nil
+}
+
+type ChannelRestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestChannelRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &ChannelRestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ChannelRestoreUnitSuite) TestChannelRestoreName() {
+	table := []struct {
+		name     string
+		location string
+		channel  string
+		expect   string
+	}{
+		{
+			name:    "in place",
+			channel: "General",
+			expect:  "General",
+		},
+		{
+			name:     "restore location",
+			location: "Corso_Restore",
+			channel:  "General",
+			expect:   "Corso_Restore_General",
+		},
+		{
+			name:     "invalid characters",
+			location: "Corso_Restore_01-Jan-2024_01:02:03",
+			channel:  "Q&A?",
+			expect:   "Corso_Restore_01-Jan-2024_01-02-03_Q-A-",
+		},
+		{
+			name:     "too long",
+			location: "Corso_Restore",
+			channel:  strings.Repeat("a", 60),
+			expect:   "Corso_Restore_" + strings.Repeat("a", maxChannelNameLen-len("Corso_Restore_")),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			result := channelRestoreName(test.location, test.channel)
+			assert.Equal(suite.T(), test.expect, result)
+		})
+	}
+}
+
+func (suite *ChannelRestoreUnitSuite) TestNextChannelName() {
+	t := suite.T()
+	cache := &ChannelRestoreCache{names: map[string]struct{}{}}
+
+	cache.add("General")
+	cache.add("general 1")
+
+	assert.Equal(t, "General 2", nextChannelName("General", cache))
+
+	long := strings.Repeat("a", maxChannelNameLen)
+	cache.add(long)
+
+	result := nextChannelName(long, cache)
+	assert.Equal(t, strings.Repeat("a", maxChannelNameLen-2)+" 1", result)
+	assert.Len(t, []rune(result), maxChannelNameLen)
+}
+
+func (suite *ChannelRestoreUnitSuite) TestToImportMessage() {
+	t := suite.T()
+
+	ref := models.NewChatMessageAttachment()
+	ref.SetId(ptr.To("ref"))
+	ref.SetName(ptr.To("file.docx"))
+	ref.SetContentType(ptr.To("reference"))
+
+	card := models.NewChatMessageAttachment()
+	card.SetId(ptr.To("card"))
+	card.SetName(ptr.To("meeting"))
+	card.SetContentType(ptr.To("application/vnd.microsoft.card.adaptive"))
+
+	now := time.Now()
+
+	body := models.NewItemBody()
+	body.SetContent(ptr.To(`<attachment id="ref"></attachment><attachment id="card"></attachment>`))
+
+	msg := models.NewChatMessage()
+	msg.SetId(ptr.To("id"))
+	msg.SetCreatedDateTime(&now)
+	msg.SetBody(body)
+	msg.SetAttachments([]models.ChatMessageAttachmentable{ref, card})
+
+	result := toImportMessage(msg)
+
+	assert.Nil(t, result.GetId(), "ids are assigned by graph")
+	assert.Equal(t, now, ptr.Val(result.GetCreatedDateTime()))
+	assert.Equal(
+		t,
+		`<attachment id="ref"></attachment>[attachment:meeting]`,
+		ptr.Val(result.GetBody().GetContent()))
+	require.Len(t, result.GetAttachments(), 1)
+	assert.Equal(t, "ref", ptr.Val(result.GetAttachments()[0].GetId()))
+}
+
+func (suite *ChannelRestoreUnitSuite) TestRestoreChannelMessages() {
+	var (
+		now  = time.Now()
+		msgs = testdata.StubChatMessages("first", "second")
+	)
+
+	msgs[0].SetCreatedDateTime(ptr.To(now.Add(-time.Hour)))
+	msgs[1].SetCreatedDateTime(ptr.To(now))
+
+	reply := models.NewChatMessage()
+	reply.SetCreatedDateTime(ptr.To(now.Add(-2 * time.Hour)))
+	msgs[1].SetReplies([]models.ChatMessageable{reply})
+
+	table := []struct {
+		name            string
+		location        string
+		existing        []models.Channelable
+		collisionPolicy control.CollisionPolicy
+		expectChannel   string
+		expectSkipped   int64
+		expectCreated   int64
+	}{
+		{
+			name:            "new channel",
+			location:        "Corso_Restore",
+			existing:        testdata.StubChannels("General"),
+			collisionPolicy: control.Skip,
+			expectChannel:   "Corso_Restore_General",
+			expectCreated:   2,
+		},
+		{
+			name:            "collision skip",
+			existing:        testdata.StubChannels("General"),
+			collisionPolicy: control.Skip,
+			expectSkipped:   2,
+		},
+		{
+			name:            "collision copy",
+			existing:        testdata.StubChannels("General"),
+			collisionPolicy: control.Copy,
+			expectChannel:   "General 1",
+			expectCreated:   2,
+		},
+		{
+			name:            "collision replace",
+			existing:        testdata.StubChannels("General"),
+			collisionPolicy: control.Replace,
+			expectChannel:   "General 1",
+			expectCreated:   2,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			fp, err := path.Build("t", "g", path.GroupsService, path.ChannelMessagesCategory, false, "General")
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				cr    = &mockChannelRestorer{channels: test.existing}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{Path: fp}
+			)
+
+			for _, msg := range msgs {
+				dc.ItemData = append(dc.ItemData, &dataMock.Item{
+					ItemID: ptr.Val(msg.GetId()),
+					Reader: io.NopCloser(bytes.NewReader(serializeChatMessage(t, msg))),
+				})
+			}
+
+			_, err = RestoreChannelMessages(
+				ctx,
+				cr,
+				dc,
+				"g",
+				test.location,
+				NewChannelRestoreCache(),
+				test.collisionPolicy,
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+
+			if len(test.expectChannel) == 0 {
+				assert.Empty(t, cr.created)
+				assert.Empty(t, cr.completed)
+
+				return
+			}
+
+			require.Equal(t, []string{test.expectChannel}, cr.created)
+			assert.Equal(t, []string{"id-" + test.expectChannel}, cr.completed)
+			assert.True(
+				t,
+				cr.createdAt[0].Before(now.Add(-2*time.Hour)),
+				"channel predates the oldest reply")
+
+			require.Len(t, cr.messages, 2)
+			assert.Equal(t, "first", ptr.Val(cr.messages[0].GetBody().GetContent()))
+			assert.Equal(t, "second", ptr.Val(cr.messages[1].GetBody().GetContent()))
+			assert.Len(t, cr.replies["second"], 1)
+			assert.Len(t, deets.Details().Items(), 2)
+		})
+	}
+}
+
+func serializeChatMessage(t *testing.T, msg models.ChatMessageable) []byte {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", msg)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
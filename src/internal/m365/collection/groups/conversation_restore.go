@@ -0,0 +1,385 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"sort"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ conversationRestorer = api.Conversations{}
+
+type conversationRestorer interface {
+	GetConversations(
+		ctx context.Context,
+		groupID string,
+		cc api.CallConfig,
+	) ([]models.Conversationable, error)
+	CreateConversationThread(
+		ctx context.Context,
+		groupID, topic string,
+		post models.Postable,
+	) (models.ConversationThreadable, error)
+	ReplyToConversationThread(
+		ctx context.Context,
+		groupID, threadID string,
+		post models.Postable,
+	) error
+	DeleteConversation(
+		ctx context.Context,
+		groupID, conversationID string,
+	) error
+}
+
+// ConversationRestoreCache maps the topics of the group's conversations
+// to their IDs.  Topics aren't unique, so a topic may hold several
+// conversations.
+type ConversationRestoreCache struct {
+	topics map[string][]string
+}
+
+func NewConversationRestoreCache() *ConversationRestoreCache {
+	return &ConversationRestoreCache{}
+}
+
+func (c *ConversationRestoreCache) populate(
+	ctx context.Context,
+	cr conversationRestorer,
+	groupID string,
+) error {
+	if c.topics != nil {
+		return nil
+	}
+
+	convs, err := cr.GetConversations(ctx, groupID, api.CallConfig{})
+	if err != nil {
+		return clues.Wrap(err, "getting existing conversations")
+	}
+
+	c.topics = map[string][]string{}
+
+	for _, conv := range convs {
+		topic := ptr.Val(conv.GetTopic())
+		c.topics[topic] = append(c.topics[topic], ptr.Val(conv.GetId()))
+	}
+
+	return nil
+}
+
+type restorePost struct {
+	itemID string
+	size   int64
+	post   models.Postable
+}
+
+// RestoreConversationPosts restores the posts in the collection as a new
+// conversation, with the first post starting the thread and the rest
+// added as replies, oldest first.  Graph doesn't allow posting on behalf
+// of other users, so each restored post is prefixed with its original
+// author and time.  Collisions are handled per conversation topic: the
+// posts are skipped, restored as a second conversation with the same
+// topic, or restored after the existing conversations are deleted.
+func RestoreConversationPosts(
+	ctx context.Context,
+	cr conversationRestorer,
+	dc data.RestoreCollection,
+	groupID, restoreLocation string,
+	cache *ConversationRestoreCache,
+	collisionPolicy control.CollisionPolicy,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:groups:restoreConversationPosts", diagnostics.Label("path", dc.FullPath()))
+	defer end()
+
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		fullPath = dc.FullPath()
+		folders  = fullPath.Folders()
+	)
+
+	if len(folders) == 0 {
+		return metrics, clues.NewWC(ctx, "conversation posts collection has no topic")
+	}
+
+	posts, err := readRestorePosts(ctx, dc, &metrics, errs)
+	if err != nil || len(posts) == 0 {
+		return metrics, clues.Stack(err).OrNil()
+	}
+
+	if err := cache.populate(ctx, cr, groupID); err != nil {
+		return metrics, clues.Stack(err)
+	}
+
+	topic := conversationRestoreTopic(restoreLocation, folders[len(folders)-1])
+	ctx = clues.Add(ctx, "restore_conversation_topic", clues.Hide(topic))
+
+	var replaced bool
+
+	if existing := cache.topics[topic]; len(existing) > 0 {
+		log := logger.Ctx(ctx).With("collision_policy", collisionPolicy)
+		log.Debug("conversation collision")
+
+		switch collisionPolicy {
+		case control.Skip:
+			ctr.Add(count.CollisionSkip, int64(len(posts)))
+			log.Debug("skipping conversation with collision")
+
+			return metrics, nil
+
+		case control.Replace:
+			for _, id := range existing {
+				if err := cr.DeleteConversation(ctx, groupID, id); err != nil {
+					return metrics, clues.Wrap(err, "deleting existing conversation").
+						With("conversation_id", id)
+				}
+			}
+
+			replaced = true
+
+			delete(cache.topics, topic)
+		}
+	}
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		path.ConversationPostsCategory.HumanString(),
+		clues.Hide(topic))
+	defer close(progressMessage)
+
+	var threadID string
+
+	for _, rp := range posts {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", rp.itemID)
+		post := toRestorePost(rp.post)
+
+		if len(threadID) == 0 {
+			thread, err := cr.CreateConversationThread(ictx, groupID, topic, post)
+			if err != nil {
+				// without a thread, none of the remaining posts have a
+				// place to go.
+				return metrics, clues.Wrap(err, "creating conversation thread")
+			}
+
+			threadID = ptr.Val(thread.GetId())
+			ctx = clues.Add(ctx, "restore_thread_id", threadID)
+		} else if err := cr.ReplyToConversationThread(ictx, groupID, threadID, post); err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "restoring conversation post"))
+			continue
+		}
+
+		metrics.Bytes += rp.size
+		metrics.Successes++
+
+		if replaced {
+			ctr.Inc(count.CollisionReplace)
+		} else {
+			ctr.Inc(count.NewItemCreated)
+		}
+
+		itemPath, err := fullPath.AppendItem(rp.itemID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+			continue
+		}
+
+		err = deets.Add(
+			itemPath,
+			path.Builder{}.Append(topic),
+			details.ItemInfo{
+				Groups: api.ConversationPostInfo(rp.post, topic),
+			})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+		}
+
+		progressMessage <- struct{}{}
+	}
+
+	return metrics, el.Failure()
+}
+
+// readRestorePosts deserializes every post in the collection, ordered
+// oldest first, so that the thread is rebuilt in its original order.
+func readRestorePosts(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	metrics *support.CollectionMetrics,
+	errs *fault.Bus,
+) ([]restorePost, error) {
+	var (
+		el    = errs.Local()
+		posts = []restorePost{}
+		items = dc.Items(ctx, errs)
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, clues.WrapWC(ctx, ctx.Err(), "context cancelled")
+
+		case itemData, ok := <-items:
+			if !ok || el.Failure() != nil {
+				sort.SliceStable(posts, func(i, j int) bool {
+					return ptr.Val(posts[i].post.GetCreatedDateTime()).
+						Before(ptr.Val(posts[j].post.GetCreatedDateTime()))
+				})
+
+				return posts, el.Failure()
+			}
+
+			ictx := clues.Add(ctx, "item_id", itemData.ID())
+			metrics.Objects++
+
+			buf := &bytes.Buffer{}
+
+			_, err := buf.ReadFrom(itemData.ToReader())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+				continue
+			}
+
+			post, err := api.BytesToPostable(buf.Bytes())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "creating conversation post from bytes"))
+				continue
+			}
+
+			posts = append(posts, restorePost{
+				itemID: itemData.ID(),
+				size:   int64(buf.Len()),
+				post:   post,
+			})
+		}
+	}
+}
+
+// toRestorePost copies the properties of a backed up post that graph
+// accepts when creating one.  The original author and time can't be set,
+// so they're recorded in a header at the top of the body.  Only file
+// attachments that carry their content can be re-uploaded; the others
+// are dropped.
+func toRestorePost(post models.Postable) models.Postable {
+	var (
+		rp      = models.NewPost()
+		attachs = []models.Attachmentable{}
+	)
+
+	for _, a := range post.GetAttachments() {
+		fa, ok := a.(models.FileAttachmentable)
+		if !ok || len(fa.GetContentBytes()) == 0 {
+			continue
+		}
+
+		na := models.NewFileAttachment()
+		na.SetName(fa.GetName())
+		na.SetContentType(fa.GetContentType())
+		na.SetContentBytes(fa.GetContentBytes())
+		na.SetIsInline(fa.GetIsInline())
+		na.SetContentId(fa.GetContentId())
+
+		attachs = append(attachs, na)
+	}
+
+	rp.SetAttachments(attachs)
+
+	var (
+		content     string
+		contentType = models.HTML_BODYTYPE
+	)
+
+	if b := post.GetBody(); b != nil {
+		content = ptr.Val(b.GetContent())
+
+		if b.GetContentType() != nil {
+			contentType = ptr.Val(b.GetContentType())
+		}
+	}
+
+	header := postHeader(post)
+
+	if contentType == models.HTML_BODYTYPE {
+		header = "<p><i>" + html.EscapeString(header) + "</i></p>"
+	} else {
+		header += "\n\n"
+	}
+
+	body := models.NewItemBody()
+	body.SetContentType(&contentType)
+	body.SetContent(ptr.To(header + content))
+
+	rp.SetBody(body)
+
+	return rp
+}
+
+// postHeader describes the original author and time of the post.
+func postHeader(post models.Postable) string {
+	var author string
+
+	for _, r := range []models.Recipientable{post.GetFrom(), post.GetSender()} {
+		if r == nil || r.GetEmailAddress() == nil {
+			continue
+		}
+
+		ea := r.GetEmailAddress()
+
+		author = ptr.Val(ea.GetName())
+		if addr := ptr.Val(ea.GetAddress()); len(addr) > 0 {
+			if len(author) > 0 {
+				author += " <" + addr + ">"
+			} else {
+				author = addr
+			}
+		}
+
+		if len(author) > 0 {
+			break
+		}
+	}
+
+	if len(author) == 0 {
+		author = "unknown sender"
+	}
+
+	return fmt.Sprintf(
+		"Originally posted by %s on %s",
+		author,
+		dttm.FormatToTabularDisplay(ptr.Val(post.GetCreatedDateTime())))
+}
+
+// conversationRestoreTopic produces the topic of the conversation that
+// receives the restored posts.  In-place restores reuse the original topic.
+func conversationRestoreTopic(restoreLocation, topic string) string {
+	if len(restoreLocation) > 0 {
+		return restoreLocation + "_" + topic
+	}
+
+	return topic
+}
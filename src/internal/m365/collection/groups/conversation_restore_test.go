@@ -0,0 +1,259 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ conversationRestorer = &mockConversationRestorer{}
+
+type mockConversationRestorer struct {
+	conversations []models.Conversationable
+	topics        []string
+	started       []models.Postable
+	replies       map[string][]models.Postable
+	deleted       []string
+}
+
+func (m *mockConversationRestorer) GetConversations(
+	_ context.Context,
+	_ string,
+	_ api.CallConfig,
+) ([]models.Conversationable, error) {
+	return m.conversations, nil
+}
+
+func (m *mockConversationRestorer) CreateConversationThread(
+	_ context.Context,
+	_, topic string,
+	post models.Postable,
+) (models.ConversationThreadable, error) {
+	m.topics = append(m.topics, topic)
+	m.started = append(m.started, post)
+
+	thread := models.NewConversationThread()
+	thread.SetId(ptr.To("thread-" + topic))
+	thread.SetTopic(ptr.To(topic))
+
+	return thread, nil
+}
+
+func (m *mockConversationRestorer) ReplyToConversationThread(
+	_ context.Context,
+	_, threadID string,
+	post models.Postable,
+) error {
+	if m.replies == nil {
+		m.replies = map[string][]models.Postable{}
+	}
+
+	m.replies[threadID] = append(m.replies[threadID], post)
+
+	return nil
+}
+
+func (m *mockConversationRestorer) DeleteConversation(
+	_ context.Context,
+	_, conversationID string,
+) error {
+	m.deleted = append(m.deleted, conversationID)
+	return nil
+}
+
+type ConversationRestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestConversationRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &ConversationRestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ConversationRestoreUnitSuite) TestToRestorePost() {
+	t := suite.T()
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ea := models.NewEmailAddress()
+	ea.SetName(ptr.To("Adele Vance"))
+	ea.SetAddress(ptr.To("adele@contoso.com"))
+
+	from := models.NewRecipient()
+	from.SetEmailAddress(ea)
+
+	file := models.NewFileAttachment()
+	file.SetName(ptr.To("numbers.txt"))
+	file.SetContentBytes([]byte("numbers"))
+
+	ref := models.NewReferenceAttachment()
+	ref.SetName(ptr.To("link"))
+
+	body := models.NewItemBody()
+	body.SetContentType(ptr.To(models.HTML_BODYTYPE))
+	body.SetContent(ptr.To("<p>hello</p>"))
+
+	post := models.NewPost()
+	post.SetId(ptr.To("id"))
+	post.SetCreatedDateTime(&created)
+	post.SetFrom(from)
+	post.SetBody(body)
+	post.SetAttachments([]models.Attachmentable{file, ref})
+
+	result := toRestorePost(post)
+
+	assert.Nil(t, result.GetId(), "ids are assigned by graph")
+	assert.Equal(
+		t,
+		"<p><i>Originally posted by Adele Vance &lt;adele@contoso.com&gt; on 2024-01-02T03:04:05Z</i></p><p>hello</p>",
+		ptr.Val(result.GetBody().GetContent()))
+	require.Len(t, result.GetAttachments(), 1)
+	assert.Equal(t, "numbers.txt", ptr.Val(result.GetAttachments()[0].GetName()))
+}
+
+func (suite *ConversationRestoreUnitSuite) TestRestoreConversationPosts() {
+	now := time.Now()
+
+	existing := models.NewConversation()
+	existing.SetId(ptr.To("conv"))
+	existing.SetTopic(ptr.To("Planning"))
+
+	table := []struct {
+		name            string
+		location        string
+		collisionPolicy control.CollisionPolicy
+		expectTopic     string
+		expectDeleted   []string
+		expectSkipped   int64
+		expectCreated   int64
+		expectReplaced  int64
+	}{
+		{
+			name:            "new conversation",
+			location:        "Corso_Restore",
+			collisionPolicy: control.Skip,
+			expectTopic:     "Corso_Restore_Planning",
+			expectCreated:   2,
+		},
+		{
+			name:            "collision skip",
+			collisionPolicy: control.Skip,
+			expectSkipped:   2,
+		},
+		{
+			name:            "collision copy",
+			collisionPolicy: control.Copy,
+			expectTopic:     "Planning",
+			expectCreated:   2,
+		},
+		{
+			name:            "collision replace",
+			collisionPolicy: control.Replace,
+			expectTopic:     "Planning",
+			expectDeleted:   []string{"conv"},
+			expectReplaced:  2,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			fp, err := path.Build("t", "g", path.GroupsService, path.ConversationPostsCategory, false, "Planning")
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				cr = &mockConversationRestorer{
+					conversations: []models.Conversationable{existing},
+				}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{Path: fp}
+			)
+
+			// added newest first to check that posts are restored in order.
+			for i, content := range []string{"reply", "first"} {
+				body := models.NewItemBody()
+				body.SetContentType(ptr.To(models.TEXT_BODYTYPE))
+				body.SetContent(ptr.To(content))
+
+				post := models.NewPost()
+				post.SetId(ptr.To(content))
+				post.SetCreatedDateTime(ptr.To(now.Add(-time.Duration(i) * time.Hour)))
+				post.SetBody(body)
+
+				dc.ItemData = append(dc.ItemData, &dataMock.Item{
+					ItemID: content,
+					Reader: io.NopCloser(bytes.NewReader(serializePost(t, post))),
+				})
+			}
+
+			_, err = RestoreConversationPosts(
+				ctx,
+				cr,
+				dc,
+				"g",
+				test.location,
+				NewConversationRestoreCache(),
+				test.collisionPolicy,
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace))
+			assert.Equal(t, test.expectDeleted, cr.deleted)
+
+			if len(test.expectTopic) == 0 {
+				assert.Empty(t, cr.topics)
+				return
+			}
+
+			require.Equal(t, []string{test.expectTopic}, cr.topics)
+			assert.Contains(t, ptr.Val(cr.started[0].GetBody().GetContent()), "first")
+
+			replies := cr.replies["thread-"+test.expectTopic]
+			require.Len(t, replies, 1)
+			assert.Contains(t, ptr.Val(replies[0].GetBody().GetContent()), "reply")
+
+			items := deets.Details().Items()
+			require.Len(t, items, 2)
+			assert.Equal(t, test.expectTopic, items[0].Groups.Post.Topic)
+		})
+	}
+}
+
+func serializePost(t *testing.T, post models.Postable) []byte {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", post)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
@@ -5,16 +5,21 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/converters/eml"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/metrics"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -91,6 +96,331 @@ func streamItems(
 	}
 }
 
+// NewConversationExportCollection produces a collection that exports the
+// posts of a single conversation.  Posts are written as eml files, unless
+// json was requested.  Posts don't hold the conversation topic or the
+// group's address, so both get handed in from the backup details.
+func NewConversationExportCollection(
+	baseDir, topic string,
+	recipients []string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               cec,
+		Stream: func(
+			ctx context.Context,
+			drc []data.RestoreCollection,
+			backupVersion int,
+			cec control.ExportConfig,
+			ch chan<- export.Item,
+			stats *metrics.ExportStats,
+		) {
+			streamConversationPosts(ctx, topic, recipients, drc, cec, ch, stats)
+		},
+		Stats: stats,
+	}
+}
+
+func streamConversationPosts(
+	ctx context.Context,
+	topic string,
+	recipients []string,
+	drc []data.RestoreCollection,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		ictx := clues.Add(ctx, "path_short_ref", rc.FullPath().ShortRef())
+
+		for item := range rc.Items(ictx, errs) {
+			var (
+				id      = item.ID()
+				itemCtx = clues.Add(ictx, "stream_item_id", id)
+			)
+
+			body, name, err := formatConversationPost(itemCtx, cec, topic, recipients, item)
+			if err != nil {
+				logger.CtxErr(itemCtx, err).Info("processing collection item")
+
+				ch <- export.Item{
+					ID:    id,
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(path.ConversationPostsCategory)
+			body = metrics.ReaderWithStats(body, path.ConversationPostsCategory, stats)
+
+			ch <- export.Item{
+				ID:   id,
+				Name: name,
+				Body: body,
+			}
+		}
+
+		items, recovered := errs.ItemsAndRecovered()
+
+		// Return all the items that we failed to source from the persistence layer
+		for _, item := range items {
+			ch <- export.Item{
+				ID:    item.ID,
+				Error: &item,
+			}
+		}
+
+		for _, err := range recovered {
+			ch <- export.Item{
+				Error: err,
+			}
+		}
+	}
+}
+
+func formatConversationPost(
+	ctx context.Context,
+	cec control.ExportConfig,
+	topic string,
+	recipients []string,
+	item data.Item,
+) (io.ReadCloser, string, error) {
+	rc := item.ToReader()
+
+	if cec.Format == control.JSONFormat {
+		return rc, item.ID() + ".json", nil
+	}
+
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, "", clues.WrapWC(ctx, err, "reading item bytes")
+	}
+
+	out, err := eml.FromJSONPost(ctx, bs, topic, recipients)
+	if err != nil {
+		return nil, "", clues.Wrap(err, "converting to eml")
+	}
+
+	return io.NopCloser(strings.NewReader(out)), item.ID() + ".eml", nil
+}
+
+// NewPlannerExportCollection produces a collection that exports the
+// tasks of a single plan as json.  Unless the json format was requested,
+// which writes the backed up task, details and bucket as-is, each task
+// is minimized to its human-readable properties.
+func NewPlannerExportCollection(
+	baseDir string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               cec,
+		Stream:            streamPlannerTasks,
+		Stats:             stats,
+	}
+}
+
+func streamPlannerTasks(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		for item := range rc.Items(ctx, errs) {
+			body, err := formatPlannerTask(cec, item.ToReader())
+			if err != nil {
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(path.PlannerTasksCategory)
+			body = metrics.ReaderWithStats(body, path.PlannerTasksCategory, stats)
+
+			ch <- export.Item{
+				ID:   item.ID(),
+				Name: item.ID() + ".json",
+				Body: body,
+			}
+		}
+
+		items, recovered := errs.ItemsAndRecovered()
+
+		// Return all the items that we failed to source from the persistence layer
+		for _, item := range items {
+			ch <- export.Item{
+				ID:    item.ID,
+				Error: &item,
+			}
+		}
+
+		for _, err := range recovered {
+			ch <- export.Item{
+				Error: err,
+			}
+		}
+	}
+}
+
+type (
+	minimumPlannerTask struct {
+		Title             string                 `json:"title"`
+		Bucket            string                 `json:"bucket,omitempty"`
+		PercentComplete   int32                  `json:"percentComplete"`
+		Priority          int32                  `json:"priority"`
+		Assignees         []string               `json:"assignees,omitempty"`
+		CreatedDateTime   *time.Time             `json:"createdDateTime,omitempty"`
+		StartDateTime     *time.Time             `json:"startDateTime,omitempty"`
+		DueDateTime       *time.Time             `json:"dueDateTime,omitempty"`
+		CompletedDateTime *time.Time             `json:"completedDateTime,omitempty"`
+		Description       string                 `json:"description,omitempty"`
+		Checklist         []minimumChecklistItem `json:"checklist,omitempty"`
+		References        []minimumReference     `json:"references,omitempty"`
+	}
+
+	minimumChecklistItem struct {
+		Title     string `json:"title"`
+		IsChecked bool   `json:"isChecked"`
+	}
+
+	minimumReference struct {
+		URL   string `json:"url"`
+		Alias string `json:"alias,omitempty"`
+	}
+)
+
+func formatPlannerTask(
+	cec control.ExportConfig,
+	rc io.ReadCloser,
+) (io.ReadCloser, error) {
+	if cec.Format == control.JSONFormat {
+		return rc, nil
+	}
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading item bytes")
+	}
+
+	defer rc.Close()
+
+	rt, err := toRestoreTaskParts(bs)
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing bytes to planner task")
+	}
+
+	bs, err = marshalJSONContainingHTML(makeMinimumPlannerTask(rt))
+	if err != nil {
+		return nil, clues.Wrap(err, "serializing minimized planner task")
+	}
+
+	return io.NopCloser(bytes.NewReader(bs)), nil
+}
+
+func makeMinimumPlannerTask(rt restoreTask) minimumPlannerTask {
+	task := rt.task
+
+	mpt := minimumPlannerTask{
+		Title:             ptr.Val(task.GetTitle()),
+		PercentComplete:   ptr.Val(task.GetPercentComplete()),
+		Priority:          ptr.Val(task.GetPriority()),
+		CreatedDateTime:   task.GetCreatedDateTime(),
+		StartDateTime:     task.GetStartDateTime(),
+		DueDateTime:       task.GetDueDateTime(),
+		CompletedDateTime: task.GetCompletedDateTime(),
+	}
+
+	if rt.bucket != nil {
+		mpt.Bucket = ptr.Val(rt.bucket.GetName())
+	}
+
+	if as := task.GetAssignments(); as != nil {
+		for id := range as.GetAdditionalData() {
+			mpt.Assignees = append(mpt.Assignees, id)
+		}
+
+		sort.Strings(mpt.Assignees)
+	}
+
+	if rt.details == nil {
+		return mpt
+	}
+
+	mpt.Description = ptr.Val(rt.details.GetDescription())
+
+	if cl := rt.details.GetChecklist(); cl != nil {
+		for _, v := range cl.GetAdditionalData() {
+			m, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			mpt.Checklist = append(mpt.Checklist, minimumChecklistItem{
+				Title:     ptr.Val(anyToString(m["title"])),
+				IsChecked: ptr.Val(anyToBool(m["isChecked"])),
+			})
+		}
+
+		sort.Slice(mpt.Checklist, func(i, j int) bool {
+			return mpt.Checklist[i].Title < mpt.Checklist[j].Title
+		})
+	}
+
+	if refs := rt.details.GetReferences(); refs != nil {
+		for key, v := range refs.GetAdditionalData() {
+			m, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			// reference urls are encoded to be usable as json keys.
+			u, err := url.QueryUnescape(key)
+			if err != nil {
+				u = key
+			}
+
+			mpt.References = append(mpt.References, minimumReference{
+				URL:   u,
+				Alias: ptr.Val(anyToString(m["alias"])),
+			})
+		}
+
+		sort.Slice(mpt.References, func(i, j int) bool {
+			return mpt.References[i].URL < mpt.References[j].URL
+		})
+	}
+
+	return mpt
+}
+
 type (
 	minimumChannelMessage struct {
 		Attachments          []minimumAttachment `json:"attachments"`
@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	"github.com/alcionai/corso/src/internal/tester"
@@ -113,3 +115,63 @@ func (suite *ExportUnitSuite) TestStreamItems() {
 		})
 	}
 }
+
+func (suite *ExportUnitSuite) TestMakeMinimumPlannerTask() {
+	var (
+		t       = suite.T()
+		task    = models.NewPlannerTask()
+		td      = models.NewPlannerTaskDetails()
+		bucket  = models.NewPlannerBucket()
+		assigns = models.NewPlannerAssignments()
+		cl      = models.NewPlannerChecklistItems()
+		refs    = models.NewPlannerExternalReferences()
+	)
+
+	task.SetTitle(ptr.To("title"))
+	task.SetPercentComplete(ptr.To[int32](50))
+	task.SetPriority(ptr.To[int32](5))
+
+	assigns.SetAdditionalData(map[string]any{
+		"u2": map[string]any{},
+		"u1": map[string]any{},
+	})
+	task.SetAssignments(assigns)
+
+	bucket.SetName(ptr.To("To do"))
+
+	td.SetDescription(ptr.To("description"))
+	cl.SetAdditionalData(map[string]any{
+		"b": map[string]any{"title": ptr.To("second"), "isChecked": ptr.To(true)},
+		"a": map[string]any{"title": ptr.To("first"), "isChecked": ptr.To(false)},
+	})
+	td.SetChecklist(cl)
+	refs.SetAdditionalData(map[string]any{
+		"https%3A//example%2Ecom": map[string]any{"alias": ptr.To("example")},
+	})
+	td.SetReferences(refs)
+
+	result := makeMinimumPlannerTask(restoreTask{
+		task:    task,
+		details: td,
+		bucket:  bucket,
+	})
+
+	assert.Equal(
+		t,
+		minimumPlannerTask{
+			Title:           "title",
+			Bucket:          "To do",
+			PercentComplete: 50,
+			Priority:        5,
+			Assignees:       []string{"u1", "u2"},
+			Description:     "description",
+			Checklist: []minimumChecklistItem{
+				{Title: "first", IsChecked: false},
+				{Title: "second", IsChecked: true},
+			},
+			References: []minimumReference{
+				{URL: "https://example.com", Alias: "example"},
+			},
+		},
+		result)
+}
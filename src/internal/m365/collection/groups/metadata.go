@@ -22,12 +22,14 @@ func parseMetadataCollections(
 	// cdp stores metadata
 	cdp := metadata.CatDeltaPaths{
 		path.ChannelMessagesCategory: {},
+		path.PlannerTasksCategory:    {},
 	}
 
 	// found tracks the metadata we've loaded, to make sure we don't
 	// fetch overlapping copies.
 	found := map[path.CategoryType]map[string]struct{}{
 		path.ChannelMessagesCategory: {},
+		path.PlannerTasksCategory:    {},
 	}
 
 	// errors from metadata items should not stop the backup,
@@ -105,6 +107,7 @@ func parseMetadataCollections(
 
 		return metadata.CatDeltaPaths{
 			path.ChannelMessagesCategory: {},
+			path.PlannerTasksCategory:    {},
 		}, false, nil
 	}
 
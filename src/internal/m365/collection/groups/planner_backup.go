@@ -0,0 +1,456 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ data.BackupCollection = &plannerCollection{}
+
+// plannerTask is the backed up form of a planner task.  Graph splits a
+// task across the task itself, its details, and the bucket holding it;
+// each part is stored as its serialized graph model so that every item
+// can be restored on its own.
+type plannerTask struct {
+	Task    json.RawMessage `json:"task"`
+	Details json.RawMessage `json:"details,omitempty"`
+	Bucket  json.RawMessage `json:"bucket,omitempty"`
+}
+
+// CreatePlannerCollections produces one collection for each plan in
+// scope, plus a metadata collection holding the previous path of every
+// plan for use in the next backup.  Planner has no delta support, and
+// tasks carry no modification time, so every task is fetched on each
+// backup.
+func CreatePlannerCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	bh plannerBackuper,
+	tenantID string,
+	scope selectors.GroupsScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, bool, error) {
+	cdps, canUsePreviousBackup, err := parseMetadataCollections(ctx, bpc.MetadataCollections)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx = clues.Add(ctx, "can_use_previous_backup", canUsePreviousBackup)
+
+	plans, err := bh.getPlans(ctx)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "getting plans")
+	}
+
+	counter.Add(count.PlannerPlans, int64(len(plans)))
+
+	// plan ID -> previous path
+	prevPaths := makeTombstones(cdps[path.PlannerTasksCategory])
+
+	collections, err := populatePlannerCollections(
+		ctx,
+		bh,
+		tenantID,
+		bpc.ProtectedResource.ID(),
+		su,
+		plans,
+		scope,
+		prevPaths,
+		bpc.Options,
+		counter,
+		errs)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "filling collections")
+	}
+
+	return collections, canUsePreviousBackup, nil
+}
+
+func populatePlannerCollections(
+	ctx context.Context,
+	bh plannerBackuper,
+	tenantID, protectedResourceID string,
+	statusUpdater support.StatusUpdater,
+	plans []models.PlannerPlanable,
+	scope selectors.GroupsScope,
+	prevPaths map[string]string,
+	ctrlOpts control.Options,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	var (
+		// plan ID -> BackupCollection.
+		collections = map[string]data.BackupCollection{}
+		currPaths   = map[string]string{}
+		// copy of previousPaths.  every plan present in the slice param
+		// gets removed from this map; the remaining plans at the end of
+		// the process have been deleted.
+		tombstones = make(map[string]string, len(prevPaths))
+		el         = errs.Local()
+	)
+
+	for k, v := range prevPaths {
+		tombstones[k] = v
+	}
+
+	logger.Ctx(ctx).Infow("filling collections", "len_prev_paths", len(prevPaths))
+
+	for _, plan := range plans {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		var (
+			cl          = counter.Local()
+			planID      = ptr.Val(plan.GetId())
+			storageDir  = path.Elements{planID}
+			prevPathStr = prevPaths[planID] // do not log: pii; log prevPath instead
+			prevPath    path.Path
+			err         error
+			ictx        = clues.Add(ctx, "plan_id", planID)
+		)
+
+		ictx = clues.AddLabelCounter(ictx, cl.PlainAdder())
+
+		delete(tombstones, planID)
+
+		if !bh.includePlan(plan, scope) {
+			cl.Inc(count.SkippedContainers)
+			continue
+		}
+
+		if len(prevPathStr) > 0 {
+			if prevPath, err = pathFromPrevString(prevPathStr); err != nil {
+				err = clues.StackWC(ictx, err).Label(count.BadPrevPath)
+				logger.CtxErr(ictx, err).Error("parsing prev path")
+			}
+		}
+
+		ictx = clues.Add(ictx, "previous_path", prevPath)
+
+		buckets, err := bh.getBuckets(ictx, planID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		tasks, err := bh.getTasks(ictx, planID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		cl.Add(count.PlannerTasks, int64(len(tasks)))
+
+		currPath, err := bh.canonicalPath(storageDir, tenantID)
+		if err != nil {
+			err = clues.StackWC(ictx, err).Label(count.BadCollPath)
+			el.AddRecoverable(ictx, err)
+
+			continue
+		}
+
+		bucketsByID := make(map[string]models.PlannerBucketable, len(buckets))
+
+		for _, b := range buckets {
+			bucketsByID[ptr.Val(b.GetId())] = b
+		}
+
+		collections[planID] = &plannerCollection{
+			BaseCollection: data.NewBaseCollection(
+				currPath,
+				prevPath,
+				path.Builder{}.Append(ptr.Val(plan.GetTitle())),
+				ctrlOpts,
+				// every task in the plan is listed on each backup, so
+				// tasks from the previous backup must not be merged in;
+				// otherwise deleted tasks would live on forever.
+				true,
+				cl),
+			getter:        bh,
+			plan:          plan,
+			buckets:       bucketsByID,
+			tasks:         tasks,
+			statusUpdater: statusUpdater,
+			stream:        make(chan data.Item, collectionChannelBufferSize),
+		}
+
+		// add the current path for the plan to be used in the next
+		// backup as the "previous path", for reference in case of a rename.
+		currPaths[planID] = currPath.String()
+	}
+
+	// A tombstone is a plan that needs to be marked for deletion.
+	// The only situation where a tombstone should appear is if the plan
+	// exists in the `previousPath` set, but does not exist in the enumeration.
+	for id, p := range tombstones {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		ictx := clues.Add(ctx, "tombstone_id", id)
+
+		if collections[id] != nil {
+			err := clues.NewWC(ictx, "conflict: tombstone exists for a live collection").
+				Label(count.CollectionTombstoneConflict)
+			el.AddRecoverable(ctx, err)
+
+			continue
+		}
+
+		prevPath, err := pathFromPrevString(p)
+		if err != nil {
+			err := clues.StackWC(ictx, err).Label(count.BadPrevPath)
+			// technically shouldn't ever happen.  But just in case...
+			logger.CtxErr(ictx, err).Error("parsing tombstone prev path")
+
+			continue
+		}
+
+		collections[id] = data.NewTombstoneCollection(prevPath, ctrlOpts, counter.Local())
+	}
+
+	logger.Ctx(ctx).Infow(
+		"adding metadata collection entries",
+		"num_paths_entries", len(currPaths))
+
+	pathPrefix, err := path.BuildMetadata(
+		tenantID,
+		protectedResourceID,
+		path.GroupsService,
+		path.PlannerTasksCategory,
+		false)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata path prefix").
+			Label(count.BadPathPrefix)
+	}
+
+	col, err := graph.MakeMetadataCollection(
+		pathPrefix,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(metadata.PreviousPathFileName, currPaths),
+		},
+		statusUpdater,
+		counter.Local())
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata collection")
+	}
+
+	results := make([]data.BackupCollection, 0, len(collections)+1)
+
+	for _, coll := range collections {
+		results = append(results, coll)
+	}
+
+	results = append(results, col)
+
+	return results, el.Failure()
+}
+
+// -----------------------------------------------------------------------------
+// plannerCollection
+// -----------------------------------------------------------------------------
+
+// plannerCollection holds the tasks of a single plan.  Tasks have no
+// modification time, so there's nothing to gain from lazy fetching:
+// the details of every task are fetched while streaming.
+type plannerCollection struct {
+	data.BaseCollection
+	stream chan data.Item
+
+	plan    models.PlannerPlanable
+	buckets map[string]models.PlannerBucketable
+	tasks   []models.PlannerTaskable
+
+	getter taskDetailsGetter
+
+	statusUpdater support.StatusUpdater
+}
+
+func (col *plannerCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	go col.streamItems(ctx, errs)
+	return col.stream
+}
+
+func (col *plannerCollection) streamItems(ctx context.Context, errs *fault.Bus) {
+	var (
+		streamedItems   int64
+		totalBytes      int64
+		wg              sync.WaitGroup
+		progressMessage chan<- struct{}
+		el              = errs.Local()
+	)
+
+	ctx = clues.Add(ctx, "category", col.Category().String())
+
+	defer func() {
+		close(col.stream)
+		logger.Ctx(ctx).Infow(
+			"finished stream backup collection items",
+			"stats", col.Counter.Values())
+
+		updateStatus(
+			ctx,
+			col.statusUpdater,
+			len(col.tasks),
+			streamedItems,
+			totalBytes,
+			col.FullPath().Folder(false),
+			errs.Failure())
+	}()
+
+	if len(col.tasks) > 0 {
+		progressMessage = observe.CollectionProgress(
+			ctx,
+			col.Category().HumanString(),
+			col.LocationPath().Elements())
+		defer close(progressMessage)
+	}
+
+	semaphoreCh := make(chan struct{}, col.Opts().Parallelism.ItemFetch)
+	defer close(semaphoreCh)
+
+	for _, task := range col.tasks {
+		if el.Failure() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphoreCh <- struct{}{}
+
+		go func(task models.PlannerTaskable) {
+			defer wg.Done()
+			defer func() { <-semaphoreCh }()
+
+			var (
+				id   = ptr.Val(task.GetId())
+				ictx = clues.Add(ctx, "item_id", id)
+			)
+
+			item, size, err := col.getTaskItem(ictx, task)
+			if err != nil {
+				// tasks deleted in flight are dropped from the backup.
+				if clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) || errors.Is(err, core.ErrNotFound) {
+					logger.CtxErr(ictx, err).Info("item deleted in flight. skipping")
+					col.Counter.Inc(count.StreamItemsDeletedInFlight)
+
+					return
+				}
+
+				el.AddRecoverable(ictx, clues.StackWC(ictx, err).Label(fault.LabelForceNoBackupCreation))
+
+				return
+			}
+
+			col.stream <- item
+
+			atomic.AddInt64(&streamedItems, 1)
+			atomic.AddInt64(&totalBytes, size)
+
+			if progressMessage != nil {
+				progressMessage <- struct{}{}
+			}
+		}(task)
+	}
+
+	wg.Wait()
+}
+
+// getTaskItem fetches the details of the task and bundles them with the
+// task and its bucket.
+func (col *plannerCollection) getTaskItem(
+	ctx context.Context,
+	task models.PlannerTaskable,
+) (data.Item, int64, error) {
+	taskDetails, err := col.getter.getTaskDetails(ctx, ptr.Val(task.GetId()))
+	if err != nil {
+		return nil, 0, clues.Wrap(err, "getting task details")
+	}
+
+	var (
+		bucket     = col.buckets[ptr.Val(task.GetBucketId())]
+		bucketName string
+		pt         = plannerTask{}
+	)
+
+	if pt.Task, err = serializeParsable(task); err != nil {
+		return nil, 0, clues.Wrap(err, "serializing task")
+	}
+
+	if pt.Details, err = serializeParsable(taskDetails); err != nil {
+		return nil, 0, clues.Wrap(err, "serializing task details")
+	}
+
+	if bucket != nil {
+		bucketName = ptr.Val(bucket.GetName())
+
+		if pt.Bucket, err = serializeParsable(bucket); err != nil {
+			return nil, 0, clues.Wrap(err, "serializing bucket")
+		}
+	}
+
+	itemData, err := json.Marshal(pt)
+	if err != nil {
+		return nil, 0, clues.Wrap(err, "serializing item")
+	}
+
+	size := int64(len(itemData))
+	info := api.PlannerTaskInfo(task, ptr.Val(col.plan.GetTitle()), bucketName, size)
+	info.ParentPath = col.LocationPath().String()
+
+	item, err := data.NewPrefetchedItemWithInfo(
+		io.NopCloser(bytes.NewReader(itemData)),
+		ptr.Val(task.GetId()),
+		details.ItemInfo{Groups: info})
+	if err != nil {
+		return nil, 0, clues.Wrap(err, "creating item")
+	}
+
+	return item, size, nil
+}
+
+func serializeParsable(v serialization.Parsable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", v); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Stack(err).OrNil()
+}
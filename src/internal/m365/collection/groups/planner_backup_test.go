@@ -0,0 +1,333 @@
+package groups
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// ---------------------------------------------------------------------------
+// mocks
+// ---------------------------------------------------------------------------
+
+var _ plannerBackuper = &mockPlannerBackupHandler{}
+
+type mockPlannerBackupHandler struct {
+	plans        []models.PlannerPlanable
+	buckets      map[string][]models.PlannerBucketable
+	tasks        map[string][]models.PlannerTaskable
+	tasksErr     error
+	details      map[string]models.PlannerTaskDetailsable
+	detailsErr   map[string]error
+	doNotInclude bool
+}
+
+func (bh mockPlannerBackupHandler) getPlans(
+	context.Context,
+) ([]models.PlannerPlanable, error) {
+	return bh.plans, nil
+}
+
+func (bh mockPlannerBackupHandler) getBuckets(
+	_ context.Context,
+	planID string,
+) ([]models.PlannerBucketable, error) {
+	return bh.buckets[planID], nil
+}
+
+func (bh mockPlannerBackupHandler) getTasks(
+	_ context.Context,
+	planID string,
+) ([]models.PlannerTaskable, error) {
+	return bh.tasks[planID], bh.tasksErr
+}
+
+func (bh mockPlannerBackupHandler) getTaskDetails(
+	_ context.Context,
+	taskID string,
+) (models.PlannerTaskDetailsable, error) {
+	return bh.details[taskID], bh.detailsErr[taskID]
+}
+
+func (bh mockPlannerBackupHandler) includePlan(
+	models.PlannerPlanable,
+	selectors.GroupsScope,
+) bool {
+	return !bh.doNotInclude
+}
+
+func (bh mockPlannerBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			"protectedResource",
+			path.GroupsService,
+			path.PlannerTasksCategory,
+			false)
+}
+
+func stubPlans(ids ...string) []models.PlannerPlanable {
+	plans := make([]models.PlannerPlanable, 0, len(ids))
+
+	for _, id := range ids {
+		p := models.NewPlannerPlan()
+		p.SetId(ptr.To(id))
+		p.SetTitle(ptr.To("title-" + id))
+
+		plans = append(plans, p)
+	}
+
+	return plans
+}
+
+func stubPlannerTask(id, bucketID string) models.PlannerTaskable {
+	t := models.NewPlannerTask()
+	t.SetId(ptr.To(id))
+	t.SetTitle(ptr.To("title-" + id))
+
+	if len(bucketID) > 0 {
+		t.SetBucketId(ptr.To(bucketID))
+	}
+
+	return t
+}
+
+// ---------------------------------------------------------------------------
+// Unit Suite
+// ---------------------------------------------------------------------------
+
+type PlannerBackupUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlannerBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &PlannerBackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlannerBackupUnitSuite) TestPopulatePlannerCollections() {
+	var (
+		tenantID      = "tenant"
+		statusUpdater = func(*support.ControllerOperationStatus) {}
+	)
+
+	gonePath, err := path.Build(
+		tenantID,
+		"protectedResource",
+		path.GroupsService,
+		path.PlannerTasksCategory,
+		false,
+		"gone")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name                string
+		mock                mockPlannerBackupHandler
+		prevPaths           map[string]string
+		expectErr           require.ErrorAssertionFunc
+		expectColls         int
+		expectNewColls      int
+		expectDeletedColls  int
+		expectMetadataColls int
+	}{
+		{
+			name: "one plan",
+			mock: mockPlannerBackupHandler{
+				plans: stubPlans("one"),
+				tasks: map[string][]models.PlannerTaskable{
+					"one": {stubPlannerTask("task-one", "")},
+				},
+			},
+			expectErr:           require.NoError,
+			expectColls:         2,
+			expectNewColls:      1,
+			expectMetadataColls: 1,
+		},
+		{
+			name: "many plans",
+			mock: mockPlannerBackupHandler{
+				plans: stubPlans("one", "two"),
+			},
+			expectErr:           require.NoError,
+			expectColls:         3,
+			expectNewColls:      2,
+			expectMetadataColls: 1,
+		},
+		{
+			name: "no plans pass scope",
+			mock: mockPlannerBackupHandler{
+				plans:        stubPlans("one"),
+				doNotInclude: true,
+			},
+			expectErr:           require.NoError,
+			expectColls:         1,
+			expectMetadataColls: 1,
+		},
+		{
+			name:                "no plans",
+			mock:                mockPlannerBackupHandler{},
+			expectErr:           require.NoError,
+			expectColls:         1,
+			expectMetadataColls: 1,
+		},
+		{
+			name: "deleted plan",
+			mock: mockPlannerBackupHandler{
+				plans: stubPlans("one"),
+			},
+			prevPaths:           map[string]string{"gone": gonePath.String()},
+			expectErr:           require.NoError,
+			expectColls:         3,
+			expectNewColls:      1,
+			expectDeletedColls:  1,
+			expectMetadataColls: 1,
+		},
+		{
+			name: "err: getting tasks",
+			mock: mockPlannerBackupHandler{
+				plans:    stubPlans("one"),
+				tasksErr: assert.AnError,
+			},
+			expectErr:           require.Error,
+			expectColls:         1,
+			expectMetadataColls: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			collections, err := populatePlannerCollections(
+				ctx,
+				test.mock,
+				tenantID,
+				"protectedResource",
+				statusUpdater,
+				test.mock.plans,
+				selectors.NewGroupsBackup(nil).Plans(selectors.Any())[0],
+				test.prevPaths,
+				control.Options{FailureHandling: control.FailFast},
+				count.New(),
+				fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Len(t, collections, test.expectColls, "number of collections")
+
+			deleteds, news, metadatas := 0, 0, 0
+			for _, c := range collections {
+				if c.FullPath() == nil {
+					deleteds++
+					continue
+				}
+
+				if c.FullPath().Service() == path.GroupsMetadataService {
+					metadatas++
+					continue
+				}
+
+				if c.State() == data.NewState {
+					news++
+				}
+
+				assert.True(t, c.DoNotMergeItems(), "plans are never merged")
+			}
+
+			assert.Equal(t, test.expectDeletedColls, deleteds, "deleted collections")
+			assert.Equal(t, test.expectNewColls, news, "new collections")
+			assert.Equal(t, test.expectMetadataColls, metadatas, "metadata collections")
+		})
+	}
+}
+
+func (suite *PlannerBackupUnitSuite) TestPlannerCollection_streamItems() {
+	var (
+		t        = suite.T()
+		bucket   = models.NewPlannerBucket()
+		detailsA = models.NewPlannerTaskDetails()
+		plan     = stubPlans("plan")[0]
+		tasks    = []models.PlannerTaskable{
+			stubPlannerTask("a", "bucket"),
+			stubPlannerTask("b", ""),
+			stubPlannerTask("gone", ""),
+		}
+		getter = mockPlannerBackupHandler{
+			details: map[string]models.PlannerTaskDetailsable{
+				"a": detailsA,
+				"b": models.NewPlannerTaskDetails(),
+			},
+			detailsErr: map[string]error{
+				"gone": core.ErrNotFound,
+			},
+		}
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	bucket.SetId(ptr.To("bucket"))
+	bucket.SetName(ptr.To("To do"))
+	detailsA.SetDescription(ptr.To("description"))
+
+	fullPath, err := getter.canonicalPath(path.Elements{"plan"}, "tenant")
+	require.NoError(t, err, clues.ToCore(err))
+
+	col := &plannerCollection{
+		BaseCollection: data.NewBaseCollection(
+			fullPath,
+			nil,
+			path.Builder{}.Append(ptr.Val(plan.GetTitle())),
+			control.DefaultOptions(),
+			true,
+			count.New()),
+		getter:        getter,
+		plan:          plan,
+		buckets:       map[string]models.PlannerBucketable{"bucket": bucket},
+		tasks:         tasks,
+		statusUpdater: func(*support.ControllerOperationStatus) {},
+		stream:        make(chan data.Item, collectionChannelBufferSize),
+	}
+
+	errs := fault.New(true)
+	found := map[string]restoreTask{}
+
+	for item := range col.Items(ctx, errs) {
+		bs, err := io.ReadAll(item.ToReader())
+		require.NoError(t, err, clues.ToCore(err))
+
+		rt, err := toRestoreTaskParts(bs)
+		require.NoError(t, err, clues.ToCore(err))
+
+		found[item.ID()] = rt
+	}
+
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	require.Len(t, found, 2, "deleted in flight tasks are skipped")
+
+	require.NotNil(t, found["a"].bucket)
+	assert.Equal(t, "To do", ptr.Val(found["a"].bucket.GetName()))
+	require.NotNil(t, found["a"].details)
+	assert.Equal(t, "description", ptr.Val(found["a"].details.GetDescription()))
+	assert.Nil(t, found["b"].bucket)
+}
@@ -0,0 +1,124 @@
+package groups
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// plannerBackuper handles the enumeration of a group's plans.  Plans
+// don't fit the container/item handlers used by channels and
+// conversations: tasks carry no modification time, their details live
+// behind a separate resource, and bucket names are owned by the plan.
+type plannerBackuper interface {
+	getPlans(ctx context.Context) ([]models.PlannerPlanable, error)
+	getBuckets(ctx context.Context, planID string) ([]models.PlannerBucketable, error)
+	getTasks(ctx context.Context, planID string) ([]models.PlannerTaskable, error)
+	taskDetailsGetter
+	includePlan(plan models.PlannerPlanable, scope selectors.GroupsScope) bool
+	canonicalPather
+}
+
+// gets the description, checklist and references of a task.
+type taskDetailsGetter interface {
+	getTaskDetails(ctx context.Context, taskID string) (models.PlannerTaskDetailsable, error)
+}
+
+var _ plannerBackuper = &plannerBackupHandler{}
+
+type plannerBackupHandler struct {
+	ac                api.Planner
+	protectedResource string
+}
+
+func NewPlannerBackupHandler(
+	protectedResource string,
+	ac api.Planner,
+) plannerBackupHandler {
+	return plannerBackupHandler{
+		ac:                ac,
+		protectedResource: protectedResource,
+	}
+}
+
+func (bh plannerBackupHandler) getPlans(
+	ctx context.Context,
+) ([]models.PlannerPlanable, error) {
+	return bh.ac.GetPlans(ctx, bh.protectedResource)
+}
+
+func (bh plannerBackupHandler) getBuckets(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerBucketable, error) {
+	return bh.ac.GetBuckets(ctx, planID)
+}
+
+func (bh plannerBackupHandler) getTasks(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerTaskable, error) {
+	return bh.ac.GetTasks(ctx, planID)
+}
+
+func (bh plannerBackupHandler) getTaskDetails(
+	ctx context.Context,
+	taskID string,
+) (models.PlannerTaskDetailsable, error) {
+	return bh.ac.GetTaskDetails(ctx, taskID)
+}
+
+func (bh plannerBackupHandler) includePlan(
+	plan models.PlannerPlanable,
+	scope selectors.GroupsScope,
+) bool {
+	return scope.Matches(selectors.GroupsPlan, ptr.Val(plan.GetTitle()))
+}
+
+func (bh plannerBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			bh.protectedResource,
+			path.GroupsService,
+			path.PlannerTasksCategory,
+			false)
+}
+
+func (bh plannerBackupHandler) PathPrefix(tenantID string) (path.Path, error) {
+	return path.Build(
+		tenantID,
+		bh.protectedResource,
+		path.GroupsService,
+		path.PlannerTasksCategory,
+		false)
+}
+
+// plannerRestorer handles the creation of plans, buckets and tasks in
+// the restore target.
+type plannerRestorer interface {
+	GetPlans(ctx context.Context, groupID string) ([]models.PlannerPlanable, error)
+	GetBuckets(ctx context.Context, planID string) ([]models.PlannerBucketable, error)
+	GetTasks(ctx context.Context, planID string) ([]models.PlannerTaskable, error)
+	GetTaskDetails(ctx context.Context, taskID string) (models.PlannerTaskDetailsable, error)
+	PostPlan(ctx context.Context, groupID, title string) (models.PlannerPlanable, error)
+	PostBucket(ctx context.Context, planID, name, orderHint string) (models.PlannerBucketable, error)
+	PostTask(ctx context.Context, task models.PlannerTaskable) (models.PlannerTaskable, error)
+	PatchTaskDetails(
+		ctx context.Context,
+		taskID, etag string,
+		body models.PlannerTaskDetailsable,
+	) error
+	DeleteTask(ctx context.Context, taskID, etag string) error
+}
+
+var _ plannerRestorer = api.Planner{}
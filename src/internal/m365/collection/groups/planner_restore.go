@@ -0,0 +1,604 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// PlannerRestoreCache maps the titles of the group's plans to their IDs.
+type PlannerRestoreCache struct {
+	plans map[string]string
+}
+
+func NewPlannerRestoreCache() *PlannerRestoreCache {
+	return &PlannerRestoreCache{}
+}
+
+func (c *PlannerRestoreCache) populate(
+	ctx context.Context,
+	pr plannerRestorer,
+	groupID string,
+) error {
+	if c.plans != nil {
+		return nil
+	}
+
+	plans, err := pr.GetPlans(ctx, groupID)
+	if err != nil {
+		return clues.Wrap(err, "getting existing plans")
+	}
+
+	c.plans = map[string]string{}
+
+	for _, p := range plans {
+		c.plans[ptr.Val(p.GetTitle())] = ptr.Val(p.GetId())
+	}
+
+	return nil
+}
+
+type restoreTask struct {
+	itemID  string
+	size    int64
+	task    models.PlannerTaskable
+	details models.PlannerTaskDetailsable
+	bucket  models.PlannerBucketable
+}
+
+// RestorePlannerTasks restores the tasks in the collection into the plan
+// with the collection's title, creating the plan if the group doesn't
+// have one.  Buckets are matched by name, and created as needed.  Task
+// collisions are identified by title within the plan: the task is
+// skipped, restored alongside the existing task, or restored after the
+// existing tasks are deleted.
+func RestorePlannerTasks(
+	ctx context.Context,
+	pr plannerRestorer,
+	dc data.RestoreCollection,
+	groupID, restoreLocation string,
+	cache *PlannerRestoreCache,
+	collisionPolicy control.CollisionPolicy,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:groups:restorePlannerTasks", diagnostics.Label("path", dc.FullPath()))
+	defer end()
+
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		fullPath = dc.FullPath()
+		folders  = fullPath.Folders()
+	)
+
+	if len(folders) == 0 {
+		return metrics, clues.NewWC(ctx, "planner collection has no plan")
+	}
+
+	tasks, err := readRestoreTasks(ctx, dc, &metrics, errs)
+	if err != nil || len(tasks) == 0 {
+		return metrics, clues.Stack(err).OrNil()
+	}
+
+	if err := cache.populate(ctx, pr, groupID); err != nil {
+		return metrics, clues.Stack(err)
+	}
+
+	title := plannerRestoreTitle(restoreLocation, folders[len(folders)-1])
+	ctx = clues.Add(ctx, "restore_plan_title", clues.Hide(title))
+
+	plan, err := ensurePlan(ctx, pr, groupID, title, cache)
+	if err != nil {
+		return metrics, clues.Stack(err)
+	}
+
+	ctx = clues.Add(ctx, "restore_plan_id", plan.id)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		path.PlannerTasksCategory.HumanString(),
+		clues.Hide(title))
+	defer close(progressMessage)
+
+	for _, rt := range tasks {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", rt.itemID)
+		taskTitle := ptr.Val(rt.task.GetTitle())
+
+		existing := plan.tasks[taskTitle]
+		replaced := false
+
+		if len(existing) > 0 {
+			log := logger.Ctx(ictx).With("collision_policy", collisionPolicy)
+			log.Debug("planner task collision")
+
+			switch collisionPolicy {
+			case control.Skip:
+				ctr.Inc(count.CollisionSkip)
+				log.Debug("skipping task with collision")
+
+				continue
+
+			case control.Replace:
+				for _, t := range existing {
+					if err := pr.DeleteTask(ictx, ptr.Val(t.GetId()), api.PlannerETag(t)); err != nil {
+						el.AddRecoverable(ictx, clues.Wrap(err, "deleting existing task"))
+						continue
+					}
+				}
+
+				delete(plan.tasks, taskTitle)
+
+				replaced = true
+			}
+		}
+
+		bucketName, err := plan.ensureBucket(ictx, pr, rt.bucket)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		if err := postRestoreTask(ictx, pr, plan.id, plan.buckets[bucketName], rt); err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		metrics.Bytes += rt.size
+		metrics.Successes++
+
+		if replaced {
+			ctr.Inc(count.CollisionReplace)
+		} else {
+			ctr.Inc(count.NewItemCreated)
+		}
+
+		itemPath, err := fullPath.AppendItem(rt.itemID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+			continue
+		}
+
+		err = deets.Add(
+			itemPath,
+			path.Builder{}.Append(title),
+			details.ItemInfo{
+				Groups: api.PlannerTaskInfo(rt.task, title, bucketName, rt.size),
+			})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+		}
+
+		progressMessage <- struct{}{}
+	}
+
+	return metrics, el.Failure()
+}
+
+// restorePlan is the plan receiving the restored tasks, along with its
+// buckets (by name) and tasks (by title).
+type restorePlan struct {
+	id      string
+	buckets map[string]string
+	tasks   map[string][]models.PlannerTaskable
+}
+
+// ensurePlan produces the plan with the given title, creating it if
+// the group has no such plan.  The buckets and tasks of existing plans
+// are loaded for collision checks.
+func ensurePlan(
+	ctx context.Context,
+	pr plannerRestorer,
+	groupID, title string,
+	cache *PlannerRestoreCache,
+) (*restorePlan, error) {
+	rp := &restorePlan{
+		buckets: map[string]string{},
+		tasks:   map[string][]models.PlannerTaskable{},
+	}
+
+	if id, ok := cache.plans[title]; ok {
+		rp.id = id
+
+		buckets, err := pr.GetBuckets(ctx, id)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting existing buckets")
+		}
+
+		for _, b := range buckets {
+			rp.buckets[ptr.Val(b.GetName())] = ptr.Val(b.GetId())
+		}
+
+		tasks, err := pr.GetTasks(ctx, id)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting existing tasks")
+		}
+
+		for _, t := range tasks {
+			tt := ptr.Val(t.GetTitle())
+			rp.tasks[tt] = append(rp.tasks[tt], t)
+		}
+
+		return rp, nil
+	}
+
+	plan, err := pr.PostPlan(ctx, groupID, title)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating plan")
+	}
+
+	rp.id = ptr.Val(plan.GetId())
+	cache.plans[title] = rp.id
+
+	return rp, nil
+}
+
+// ensureBucket produces the name of the bucket that receives the task,
+// creating the bucket in the plan if needed.  Tasks without a bucket
+// produce an empty name.
+func (rp *restorePlan) ensureBucket(
+	ctx context.Context,
+	pr plannerRestorer,
+	bucket models.PlannerBucketable,
+) (string, error) {
+	if bucket == nil {
+		return "", nil
+	}
+
+	name := ptr.Val(bucket.GetName())
+
+	if _, ok := rp.buckets[name]; ok {
+		return name, nil
+	}
+
+	nb, err := pr.PostBucket(ctx, rp.id, name, ptr.Val(bucket.GetOrderHint()))
+	if err != nil {
+		return "", clues.Wrap(err, "creating bucket")
+	}
+
+	rp.buckets[name] = ptr.Val(nb.GetId())
+
+	return name, nil
+}
+
+// postRestoreTask creates the task, then fills in its details.  Graph
+// creates the details alongside the task, so they can only be patched
+// in after the fact.
+func postRestoreTask(
+	ctx context.Context,
+	pr plannerRestorer,
+	planID, bucketID string,
+	rt restoreTask,
+) error {
+	task, err := pr.PostTask(ctx, toRestoreTask(rt.task, planID, bucketID))
+	if err != nil {
+		return clues.Wrap(err, "creating task")
+	}
+
+	body := toRestoreTaskDetails(rt.details)
+	if body == nil {
+		return nil
+	}
+
+	taskID := ptr.Val(task.GetId())
+
+	current, err := pr.GetTaskDetails(ctx, taskID)
+	if err != nil {
+		return clues.Wrap(err, "getting new task details")
+	}
+
+	err = pr.PatchTaskDetails(ctx, taskID, api.PlannerETag(current), body)
+
+	return clues.Wrap(err, "restoring task details").OrNil()
+}
+
+// readRestoreTasks deserializes every task in the collection.
+func readRestoreTasks(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	metrics *support.CollectionMetrics,
+	errs *fault.Bus,
+) ([]restoreTask, error) {
+	var (
+		el    = errs.Local()
+		tasks = []restoreTask{}
+		items = dc.Items(ctx, errs)
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, clues.WrapWC(ctx, ctx.Err(), "context cancelled")
+
+		case itemData, ok := <-items:
+			if !ok || el.Failure() != nil {
+				return tasks, el.Failure()
+			}
+
+			ictx := clues.Add(ctx, "item_id", itemData.ID())
+			metrics.Objects++
+
+			buf := &bytes.Buffer{}
+
+			_, err := buf.ReadFrom(itemData.ToReader())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+				continue
+			}
+
+			rt, err := toRestoreTaskParts(buf.Bytes())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "creating planner task from bytes"))
+				continue
+			}
+
+			rt.itemID = itemData.ID()
+			rt.size = int64(buf.Len())
+
+			tasks = append(tasks, rt)
+		}
+	}
+}
+
+// toRestoreTaskParts deserializes the task, details and bucket bundled
+// in a backed up planner task.
+func toRestoreTaskParts(bs []byte) (restoreTask, error) {
+	var (
+		pt plannerTask
+		rt restoreTask
+	)
+
+	if err := json.Unmarshal(bs, &pt); err != nil {
+		return rt, clues.Wrap(err, "unmarshalling planner task")
+	}
+
+	task, err := api.BytesToPlannerTaskable(pt.Task)
+	if err != nil {
+		return rt, clues.Stack(err)
+	}
+
+	rt.task = task
+
+	if len(pt.Details) > 0 {
+		if rt.details, err = api.BytesToPlannerTaskDetailsable(pt.Details); err != nil {
+			return rt, clues.Stack(err)
+		}
+	}
+
+	if len(pt.Bucket) > 0 {
+		if rt.bucket, err = api.BytesToPlannerBucketable(pt.Bucket); err != nil {
+			return rt, clues.Stack(err)
+		}
+	}
+
+	return rt, nil
+}
+
+// toRestoreTask copies the properties of a backed up task that graph
+// accepts when creating one.  Authorship, creation and completion times
+// are owned by graph and can't be restored.
+func toRestoreTask(
+	task models.PlannerTaskable,
+	planID, bucketID string,
+) models.PlannerTaskable {
+	rt := models.NewPlannerTask()
+	rt.SetPlanId(ptr.To(planID))
+	rt.SetTitle(task.GetTitle())
+	rt.SetPercentComplete(task.GetPercentComplete())
+	rt.SetPriority(task.GetPriority())
+	rt.SetStartDateTime(task.GetStartDateTime())
+	rt.SetDueDateTime(task.GetDueDateTime())
+	rt.SetAppliedCategories(task.GetAppliedCategories())
+
+	if len(bucketID) > 0 {
+		rt.SetBucketId(ptr.To(bucketID))
+	}
+
+	if as := task.GetAssignments(); as != nil && len(as.GetAdditionalData()) > 0 {
+		assigns := map[string]any{}
+
+		for userID := range as.GetAdditionalData() {
+			// " !" places the assignee after any existing assignees.
+			assigns[userID] = newPlannerEntry(
+				"#microsoft.graph.plannerAssignment",
+				map[string]any{"orderHint": ptr.To(" !")})
+		}
+
+		ra := models.NewPlannerAssignments()
+		ra.SetAdditionalData(assigns)
+
+		rt.SetAssignments(ra)
+	}
+
+	return rt
+}
+
+// toRestoreTaskDetails copies the description, checklist and references
+// of the backed up details.  Returns nil if the details hold nothing
+// worth restoring.
+func toRestoreTaskDetails(td models.PlannerTaskDetailsable) models.PlannerTaskDetailsable {
+	if td == nil {
+		return nil
+	}
+
+	var (
+		rd      = models.NewPlannerTaskDetails()
+		desc    = ptr.Val(td.GetDescription())
+		hasData = len(desc) > 0
+	)
+
+	if hasData {
+		rd.SetDescription(ptr.To(desc))
+	}
+
+	if cl := td.GetChecklist(); cl != nil && len(cl.GetAdditionalData()) > 0 {
+		items := map[string]any{}
+
+		for id, v := range cl.GetAdditionalData() {
+			m, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			items[id] = newPlannerEntry(
+				"#microsoft.graph.plannerChecklistItem",
+				map[string]any{
+					"title":     anyToString(m["title"]),
+					"isChecked": anyToBool(m["isChecked"]),
+					"orderHint": anyToString(m["orderHint"]),
+				})
+		}
+
+		checklist := models.NewPlannerChecklistItems()
+		checklist.SetAdditionalData(items)
+
+		rd.SetChecklist(checklist)
+
+		hasData = hasData || len(items) > 0
+	}
+
+	if refs := td.GetReferences(); refs != nil && len(refs.GetAdditionalData()) > 0 {
+		items := map[string]any{}
+
+		for url, v := range refs.GetAdditionalData() {
+			m, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			items[url] = newPlannerEntry(
+				"#microsoft.graph.plannerExternalReference",
+				map[string]any{
+					"alias":           anyToString(m["alias"]),
+					"type":            anyToString(m["type"]),
+					"previewPriority": anyToString(m["previewPriority"]),
+				})
+		}
+
+		references := models.NewPlannerExternalReferences()
+		references.SetAdditionalData(items)
+
+		rd.SetReferences(references)
+
+		hasData = hasData || len(items) > 0
+	}
+
+	if !hasData {
+		return nil
+	}
+
+	rd.SetPreviewType(td.GetPreviewType())
+
+	return rd
+}
+
+// anyToString produces the string held by an untyped graph value.
+// Properties that aren't in the graph model, such as checklist items,
+// are deserialized as maps of pointers.
+func anyToString(v any) *string {
+	switch s := v.(type) {
+	case *string:
+		return s
+	case string:
+		return ptr.To(s)
+	}
+
+	return nil
+}
+
+// plannerEntry is a value in one of planner's open types: the assignments
+// of a task, or the checklist and references of its details.  The sdk
+// doesn't model the values themselves, so they're serialized from their
+// odata type and properties.
+type plannerEntry struct {
+	odataType string
+	props     map[string]any
+}
+
+var _ serialization.Parsable = &plannerEntry{}
+
+// newPlannerEntry drops nil properties, which graph would otherwise
+// receive as explicit nulls.
+func newPlannerEntry(odataType string, props map[string]any) *plannerEntry {
+	pe := &plannerEntry{
+		odataType: odataType,
+		props:     map[string]any{},
+	}
+
+	for k, v := range props {
+		switch tv := v.(type) {
+		case *string:
+			if tv != nil {
+				pe.props[k] = tv
+			}
+		case *bool:
+			if tv != nil {
+				pe.props[k] = tv
+			}
+		default:
+			if v != nil {
+				pe.props[k] = v
+			}
+		}
+	}
+
+	return pe
+}
+
+func (pe *plannerEntry) Serialize(writer serialization.SerializationWriter) error {
+	if err := writer.WriteStringValue("@odata.type", ptr.To(pe.odataType)); err != nil {
+		return err
+	}
+
+	return writer.WriteAdditionalData(pe.props)
+}
+
+func (pe *plannerEntry) GetFieldDeserializers() map[string]func(serialization.ParseNode) error {
+	return map[string]func(serialization.ParseNode) error{}
+}
+
+// anyToBool produces the bool held by an untyped graph value.
+func anyToBool(v any) *bool {
+	switch b := v.(type) {
+	case *bool:
+		return b
+	case bool:
+		return ptr.To(b)
+	}
+
+	return nil
+}
+
+// plannerRestoreTitle produces the title of the plan that receives the
+// restored tasks.  In-place restores reuse the original title.
+func plannerRestoreTitle(restoreLocation, title string) string {
+	if len(restoreLocation) > 0 {
+		return restoreLocation + "_" + title
+	}
+
+	return title
+}
@@ -0,0 +1,318 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+var _ plannerRestorer = &mockPlannerRestorer{}
+
+type mockPlannerRestorer struct {
+	plans        []models.PlannerPlanable
+	buckets      []models.PlannerBucketable
+	tasks        []models.PlannerTaskable
+	postedPlans  []string
+	postedBucket []string
+	postedTasks  []models.PlannerTaskable
+	patched      map[string]models.PlannerTaskDetailsable
+	deleted      []string
+}
+
+func (m *mockPlannerRestorer) GetPlans(
+	context.Context,
+	string,
+) ([]models.PlannerPlanable, error) {
+	return m.plans, nil
+}
+
+func (m *mockPlannerRestorer) GetBuckets(
+	context.Context,
+	string,
+) ([]models.PlannerBucketable, error) {
+	return m.buckets, nil
+}
+
+func (m *mockPlannerRestorer) GetTasks(
+	context.Context,
+	string,
+) ([]models.PlannerTaskable, error) {
+	return m.tasks, nil
+}
+
+func (m *mockPlannerRestorer) GetTaskDetails(
+	context.Context,
+	string,
+) (models.PlannerTaskDetailsable, error) {
+	td := models.NewPlannerTaskDetails()
+	td.SetAdditionalData(map[string]any{"@odata.etag": "etag"})
+
+	return td, nil
+}
+
+func (m *mockPlannerRestorer) PostPlan(
+	_ context.Context,
+	_, title string,
+) (models.PlannerPlanable, error) {
+	m.postedPlans = append(m.postedPlans, title)
+
+	plan := models.NewPlannerPlan()
+	plan.SetId(ptr.To("plan-" + title))
+	plan.SetTitle(ptr.To(title))
+
+	return plan, nil
+}
+
+func (m *mockPlannerRestorer) PostBucket(
+	_ context.Context,
+	_, name, _ string,
+) (models.PlannerBucketable, error) {
+	m.postedBucket = append(m.postedBucket, name)
+
+	bucket := models.NewPlannerBucket()
+	bucket.SetId(ptr.To("bucket-" + name))
+	bucket.SetName(ptr.To(name))
+
+	return bucket, nil
+}
+
+func (m *mockPlannerRestorer) PostTask(
+	_ context.Context,
+	task models.PlannerTaskable,
+) (models.PlannerTaskable, error) {
+	m.postedTasks = append(m.postedTasks, task)
+
+	posted := models.NewPlannerTask()
+	posted.SetId(ptr.To("new-" + ptr.Val(task.GetTitle())))
+
+	return posted, nil
+}
+
+func (m *mockPlannerRestorer) PatchTaskDetails(
+	_ context.Context,
+	taskID, _ string,
+	body models.PlannerTaskDetailsable,
+) error {
+	if m.patched == nil {
+		m.patched = map[string]models.PlannerTaskDetailsable{}
+	}
+
+	m.patched[taskID] = body
+
+	return nil
+}
+
+func (m *mockPlannerRestorer) DeleteTask(
+	_ context.Context,
+	taskID, _ string,
+) error {
+	m.deleted = append(m.deleted, taskID)
+	return nil
+}
+
+type PlannerRestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlannerRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &PlannerRestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlannerRestoreUnitSuite) TestRestorePlannerTasks() {
+	existingPlan := models.NewPlannerPlan()
+	existingPlan.SetId(ptr.To("plan"))
+	existingPlan.SetTitle(ptr.To("Launch"))
+
+	existingTask := stubPlannerTask("existing", "")
+	existingTask.SetTitle(ptr.To("Prepare"))
+
+	table := []struct {
+		name            string
+		location        string
+		collisionPolicy control.CollisionPolicy
+		expectPlans     []string
+		expectBuckets   []string
+		expectTasks     int
+		expectDeleted   []string
+		expectSkipped   int64
+		expectCreated   int64
+		expectReplaced  int64
+	}{
+		{
+			name:            "new plan",
+			location:        "Corso_Restore",
+			collisionPolicy: control.Skip,
+			expectPlans:     []string{"Corso_Restore_Launch"},
+			expectBuckets:   []string{"To do"},
+			expectTasks:     1,
+			expectCreated:   1,
+		},
+		{
+			name:            "collision skip",
+			collisionPolicy: control.Skip,
+			expectSkipped:   1,
+		},
+		{
+			name:            "collision copy",
+			collisionPolicy: control.Copy,
+			expectBuckets:   []string{"To do"},
+			expectTasks:     1,
+			expectCreated:   1,
+		},
+		{
+			name:            "collision replace",
+			collisionPolicy: control.Replace,
+			expectBuckets:   []string{"To do"},
+			expectTasks:     1,
+			expectDeleted:   []string{"existing"},
+			expectReplaced:  1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			fp, err := path.Build("t", "g", path.GroupsService, path.PlannerTasksCategory, false, "Launch")
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				pr = &mockPlannerRestorer{
+					plans: []models.PlannerPlanable{existingPlan},
+					tasks: []models.PlannerTaskable{existingTask},
+				}
+				ctr    = count.New()
+				deets  = &details.Builder{}
+				task   = stubPlannerTask("task", "bucket")
+				td     = models.NewPlannerTaskDetails()
+				bucket = models.NewPlannerBucket()
+			)
+
+			task.SetTitle(ptr.To("Prepare"))
+			td.SetDescription(ptr.To("description"))
+			bucket.SetId(ptr.To("bucket"))
+			bucket.SetName(ptr.To("To do"))
+
+			dc := dataMock.Collection{
+				Path: fp,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "task",
+						Reader: io.NopCloser(bytes.NewReader(serializePlannerTask(t, task, td, bucket))),
+					},
+				},
+			}
+
+			_, err = RestorePlannerTasks(
+				ctx,
+				pr,
+				dc,
+				"g",
+				test.location,
+				NewPlannerRestoreCache(),
+				test.collisionPolicy,
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace))
+			assert.Equal(t, test.expectPlans, pr.postedPlans)
+			assert.Equal(t, test.expectBuckets, pr.postedBucket)
+			assert.Equal(t, test.expectDeleted, pr.deleted)
+			require.Len(t, pr.postedTasks, test.expectTasks)
+
+			if test.expectTasks == 0 {
+				assert.Empty(t, deets.Details().Items())
+				return
+			}
+
+			posted := pr.postedTasks[0]
+			assert.Nil(t, posted.GetId(), "ids are assigned by graph")
+			assert.Equal(t, "bucket-To do", ptr.Val(posted.GetBucketId()))
+
+			require.Contains(t, pr.patched, "new-Prepare")
+			assert.Equal(t, "description", ptr.Val(pr.patched["new-Prepare"].GetDescription()))
+
+			items := deets.Details().Items()
+			require.Len(t, items, 1)
+			assert.Equal(t, "To do", items[0].Groups.Task.Bucket)
+		})
+	}
+}
+
+func (suite *PlannerRestoreUnitSuite) TestToRestoreTaskDetails() {
+	t := suite.T()
+
+	assert.Nil(t, toRestoreTaskDetails(nil))
+	assert.Nil(t, toRestoreTaskDetails(models.NewPlannerTaskDetails()), "empty details")
+
+	cl := models.NewPlannerChecklistItems()
+	cl.SetAdditionalData(map[string]any{
+		"item": map[string]any{
+			"title":     ptr.To("check"),
+			"isChecked": ptr.To(true),
+		},
+	})
+
+	td := models.NewPlannerTaskDetails()
+	td.SetChecklist(cl)
+
+	result := toRestoreTaskDetails(td)
+	require.NotNil(t, result)
+
+	item, ok := result.GetChecklist().GetAdditionalData()["item"].(*plannerEntry)
+	require.True(t, ok, "checklist items are restored as typed planner entries")
+	assert.Equal(t, "#microsoft.graph.plannerChecklistItem", item.odataType)
+	assert.Equal(t, "check", ptr.Val(item.props["title"].(*string)))
+	assert.True(t, ptr.Val(item.props["isChecked"].(*bool)))
+	assert.NotContains(t, item.props, "orderHint", "nil properties are dropped")
+}
+
+func serializePlannerTask(
+	t *testing.T,
+	task models.PlannerTaskable,
+	td models.PlannerTaskDetailsable,
+	bucket models.PlannerBucketable,
+) []byte {
+	var (
+		pt  plannerTask
+		err error
+	)
+
+	pt.Task, err = serializeParsable(task)
+	require.NoError(t, err, clues.ToCore(err))
+
+	pt.Details, err = serializeParsable(td)
+	require.NoError(t, err, clues.ToCore(err))
+
+	pt.Bucket, err = serializeParsable(bucket)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := json.Marshal(pt)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
@@ -0,0 +1,369 @@
+package onenote
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// CreateCollections produces one collection for each notebook section in
+// scope, plus a metadata collection holding the previous path of every
+// section for use in the next backup.  Graph has no delta support for
+// notebooks, so every section is enumerated in full on each backup;
+// kopia still skips re-uploading pages whose mod time hasn't changed.
+func CreateCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	bh backupHandler,
+	tenantID string,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, bool, error) {
+	prevPaths, canUsePreviousBackup, err := parseMetadataCollections(ctx, bpc.MetadataCollections)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx = clues.Add(ctx, "can_use_previous_backup", canUsePreviousBackup)
+
+	containers, err := getContainers(ctx, bh)
+	if err != nil {
+		return nil, false, clues.Stack(err)
+	}
+
+	counter.Add(count.NotebookSections, int64(len(containers)))
+
+	collections, err := populateCollections(
+		ctx,
+		bh,
+		tenantID,
+		bpc.ProtectedResource.ID(),
+		su,
+		containers,
+		prevPaths,
+		bpc.Options,
+		counter,
+		errs)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "filling collections")
+	}
+
+	return collections, canUsePreviousBackup, nil
+}
+
+// IsInaccessible is true if the error means none of the notebooks of the
+// resource can be read, either because the app wasn't granted access to
+// notebooks or because the resource isn't licensed for OneNote.  These
+// conditions don't go away on their own, so callers skip the notebooks
+// instead of failing the backup.
+func IsInaccessible(err error) bool {
+	return graph.IsErrAccessDenied(err) ||
+		errors.Is(err, core.ErrInsufficientAuthorization) ||
+		clues.HasLabel(err, graph.LabelsMysiteNotFound) ||
+		clues.HasLabel(err, graph.LabelsNoSharePointLicense)
+}
+
+// InaccessibleSkip produces the skipped item recorded in place of the
+// notebooks of a resource whose notebooks are inaccessible.
+func InaccessibleSkip(pr idname.Provider) *fault.Skipped {
+	return fault.OwnerSkip(
+		fault.SkipNotebooksInaccessible,
+		path.NotebooksCategory.String(),
+		pr.ID(),
+		pr.Name(),
+		nil)
+}
+
+// getContainers produces a container for every section, located by
+// the notebook and section groups that hold it.
+func getContainers(
+	ctx context.Context,
+	bh getContainerser,
+) ([]container, error) {
+	groups, err := bh.getSectionGroups(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting section groups")
+	}
+
+	sections, err := bh.getSections(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting sections")
+	}
+
+	groupsByID := make(map[string]models.SectionGroupable, len(groups))
+
+	for _, g := range groups {
+		groupsByID[ptr.Val(g.GetId())] = g
+	}
+
+	results := make([]container, 0, len(sections))
+
+	for _, s := range sections {
+		c, err := sectionContainer(s, groupsByID)
+		if err != nil {
+			return nil, clues.StackWC(ctx, err).With("section_id", ptr.Val(s.GetId()))
+		}
+
+		results = append(results, c)
+	}
+
+	return results, nil
+}
+
+// sectionContainer produces the container of the section, located by
+// the notebook and section groups above it.
+func sectionContainer(
+	s models.OnenoteSectionable,
+	groupsByID map[string]models.SectionGroupable,
+) (container, error) {
+	ids, names, err := ancestors(s.GetParentNotebook(), s.GetParentSectionGroup(), groupsByID)
+	if err != nil {
+		return container{}, err
+	}
+
+	return container{
+		storageDirFolders: append(ids, ptr.Val(s.GetId())),
+		humanLocation:     append(names, ptr.Val(s.GetDisplayName())),
+		notebook:          names[0],
+		section:           s,
+	}, nil
+}
+
+// ancestors produces the IDs and names of the notebook and section groups
+// above a section or section group, notebook first.  Graph only expands
+// the immediate parents of each section and group, so the groups are
+// looked up by ID while walking up to the notebook.
+func ancestors(
+	nb models.Notebookable,
+	pg models.SectionGroupable,
+	groupsByID map[string]models.SectionGroupable,
+) ([]string, []string, error) {
+	var (
+		ids   = []string{}
+		names = []string{}
+	)
+
+	for pg != nil {
+		// guards against a cycle in malformed responses.
+		if len(ids) > len(groupsByID) {
+			return nil, nil, clues.New("section group cycle")
+		}
+
+		g, ok := groupsByID[ptr.Val(pg.GetId())]
+		if !ok {
+			return nil, nil, clues.New("unknown parent section group").
+				With("section_group_id", ptr.Val(pg.GetId()))
+		}
+
+		ids = append(ids, ptr.Val(g.GetId()))
+		names = append(names, ptr.Val(g.GetDisplayName()))
+
+		if nb == nil {
+			nb = g.GetParentNotebook()
+		}
+
+		pg = g.GetParentSectionGroup()
+	}
+
+	if nb == nil {
+		return nil, nil, clues.New("no parent notebook")
+	}
+
+	ids = append(ids, ptr.Val(nb.GetId()))
+	names = append(names, ptr.Val(nb.GetDisplayName()))
+
+	reverse(ids)
+	reverse(names)
+
+	return ids, names, nil
+}
+
+func reverse(ss []string) {
+	for i, j := 0, len(ss)-1; i < j; i, j = i+1, j-1 {
+		ss[i], ss[j] = ss[j], ss[i]
+	}
+}
+
+func populateCollections(
+	ctx context.Context,
+	bh backupHandler,
+	tenantID, protectedResourceID string,
+	statusUpdater support.StatusUpdater,
+	containers []container,
+	prevPaths map[string]string,
+	ctrlOpts control.Options,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	var (
+		// storage dir -> BackupCollection.
+		collections = map[string]data.BackupCollection{}
+		currPaths   = map[string]string{}
+		// copy of previousPaths.  every section present in the slice param
+		// gets removed from this map; the remaining sections at the end of
+		// the process have been deleted.
+		tombstones = makeTombstones(prevPaths)
+		el         = errs.Local()
+	)
+
+	logger.Ctx(ctx).Infow("filling collections", "len_prev_paths", len(prevPaths))
+
+	for _, c := range containers {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		var (
+			cl          = counter.Local()
+			sectionID   = ptr.Val(c.section.GetId())
+			key         = c.storageDirFolders.String()
+			prevPathStr = prevPaths[key] // do not log: pii; log prevPath instead
+			prevPath    path.Path
+			err         error
+			ictx        = clues.Add(ctx, "section_id", sectionID)
+		)
+
+		ictx = clues.AddLabelCounter(ictx, cl.PlainAdder())
+
+		delete(tombstones, key)
+
+		if !bh.includeContainer(c.humanLocation.Builder()) {
+			cl.Inc(count.SkippedContainers)
+			continue
+		}
+
+		if len(prevPathStr) > 0 {
+			if prevPath, err = pathFromPrevString(prevPathStr); err != nil {
+				err = clues.StackWC(ictx, err).Label(count.BadPrevPath)
+				logger.CtxErr(ictx, err).Error("parsing prev path")
+			}
+		}
+
+		ictx = clues.Add(ictx, "previous_path", prevPath)
+
+		pages, err := bh.getPages(ictx, sectionID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		cl.Add(count.NotebookPages, int64(len(pages)))
+
+		currPath, err := bh.canonicalPath(c.storageDirFolders, tenantID)
+		if err != nil {
+			err = clues.StackWC(ictx, err).Label(count.BadCollPath)
+			el.AddRecoverable(ictx, err)
+
+			continue
+		}
+
+		added := make(map[string]models.OnenotePageable, len(pages))
+
+		for _, p := range pages {
+			added[ptr.Val(p.GetId())] = p
+		}
+
+		collections[key] = NewCollection(
+			data.NewBaseCollection(
+				currPath,
+				prevPath,
+				c.humanLocation.Builder(),
+				ctrlOpts,
+				// every page in the section is listed on each backup, so
+				// pages from the previous backup must not be merged in;
+				// otherwise deleted pages would live on forever.
+				true,
+				cl),
+			bh,
+			added,
+			c,
+			statusUpdater)
+
+		// add the current path for the section to be used in the next
+		// backup as the "previous path", for reference in case of a rename.
+		currPaths[key] = currPath.String()
+	}
+
+	// A tombstone is a section that needs to be marked for deletion.
+	// The only situation where a tombstone should appear is if the section
+	// exists in the `previousPath` set, but does not exist in the enumeration.
+	for id, p := range tombstones {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		ictx := clues.Add(ctx, "tombstone_id", id)
+
+		if collections[id] != nil {
+			err := clues.NewWC(ictx, "conflict: tombstone exists for a live collection").
+				Label(count.CollectionTombstoneConflict)
+			el.AddRecoverable(ctx, err)
+
+			continue
+		}
+
+		prevPath, err := pathFromPrevString(p)
+		if err != nil {
+			err := clues.StackWC(ictx, err).Label(count.BadPrevPath)
+			// technically shouldn't ever happen.  But just in case...
+			logger.CtxErr(ictx, err).Error("parsing tombstone prev path")
+
+			continue
+		}
+
+		collections[id] = data.NewTombstoneCollection(prevPath, ctrlOpts, counter.Local())
+	}
+
+	logger.Ctx(ctx).Infow(
+		"adding metadata collection entries",
+		"num_paths_entries", len(currPaths))
+
+	pathPrefix, err := path.BuildMetadata(
+		tenantID,
+		protectedResourceID,
+		bh.service(),
+		path.NotebooksCategory,
+		false)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata path prefix").
+			Label(count.BadPathPrefix)
+	}
+
+	col, err := graph.MakeMetadataCollection(
+		pathPrefix,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(metadata.PreviousPathFileName, currPaths),
+		},
+		statusUpdater,
+		counter.Local())
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata collection")
+	}
+
+	results := make([]data.BackupCollection, 0, len(collections)+1)
+
+	for _, coll := range collections {
+		results = append(results, coll)
+	}
+
+	results = append(results, col)
+
+	return results, el.Failure()
+}
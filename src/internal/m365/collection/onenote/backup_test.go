@@ -0,0 +1,367 @@
+package onenote
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
+)
+
+// ---------------------------------------------------------------------------
+// mocks
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = &mockBackupHandler{}
+
+type mockBackupHandler struct {
+	groups   []models.SectionGroupable
+	sections []models.OnenoteSectionable
+	// section id -> pages
+	pages     map[string][]models.OnenotePageable
+	content   map[string][]byte
+	resources map[string][]byte
+	// section locations to exclude
+	excluded map[string]struct{}
+}
+
+func (bh mockBackupHandler) getSectionGroups(
+	context.Context,
+) ([]models.SectionGroupable, error) {
+	return bh.groups, nil
+}
+
+func (bh mockBackupHandler) getSections(
+	context.Context,
+) ([]models.OnenoteSectionable, error) {
+	return bh.sections, nil
+}
+
+func (bh mockBackupHandler) getPages(
+	_ context.Context,
+	sectionID string,
+) ([]models.OnenotePageable, error) {
+	return bh.pages[sectionID], nil
+}
+
+func (bh mockBackupHandler) getPageContent(
+	_ context.Context,
+	pageID string,
+) ([]byte, error) {
+	return bh.content[pageID], nil
+}
+
+func (bh mockBackupHandler) getResourceContent(
+	_ context.Context,
+	resourceID string,
+) ([]byte, error) {
+	return bh.resources[resourceID], nil
+}
+
+func (bh mockBackupHandler) includeContainer(loc *path.Builder) bool {
+	_, ok := bh.excluded[loc.String()]
+	return !ok
+}
+
+func (bh mockBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			"user",
+			path.OneDriveService,
+			path.NotebooksCategory,
+			false)
+}
+
+func (bh mockBackupHandler) service() path.ServiceType {
+	return path.OneDriveService
+}
+
+func stubNotebook(id, name string) models.Notebookable {
+	nb := models.NewNotebook()
+	nb.SetId(ptr.To(id))
+	nb.SetDisplayName(ptr.To(name))
+
+	return nb
+}
+
+func stubSectionGroup(
+	id, name string,
+	nb models.Notebookable,
+	parent models.SectionGroupable,
+) models.SectionGroupable {
+	g := models.NewSectionGroup()
+	g.SetId(ptr.To(id))
+	g.SetDisplayName(ptr.To(name))
+	g.SetParentNotebook(nb)
+	g.SetParentSectionGroup(parent)
+
+	return g
+}
+
+func stubSection(
+	id, name string,
+	nb models.Notebookable,
+	parent models.SectionGroupable,
+) models.OnenoteSectionable {
+	s := models.NewOnenoteSection()
+	s.SetId(ptr.To(id))
+	s.SetDisplayName(ptr.To(name))
+	s.SetParentNotebook(nb)
+	s.SetParentSectionGroup(parent)
+
+	return s
+}
+
+func stubPage(id, title string) models.OnenotePageable {
+	p := models.NewOnenotePage()
+	p.SetId(ptr.To(id))
+	p.SetTitle(ptr.To(title))
+
+	return p
+}
+
+// ---------------------------------------------------------------------------
+// tests
+// ---------------------------------------------------------------------------
+
+type BackupUnitSuite struct {
+	tester.Suite
+}
+
+func TestBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &BackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *BackupUnitSuite) TestGetContainers() {
+	var (
+		nb     = stubNotebook("nb", "Notebook")
+		outer  = stubSectionGroup("g1", "Outer", nb, nil)
+		inner  = stubSectionGroup("g2", "Inner", nil, outer)
+		orphan = stubSectionGroup("g3", "Orphan", nil, stubSectionGroup("missing", "Missing", nil, nil))
+	)
+
+	table := []struct {
+		name         string
+		groups       []models.SectionGroupable
+		sections     []models.OnenoteSectionable
+		expectErr    assert.ErrorAssertionFunc
+		expectStored []string
+		expectLoc    []string
+	}{
+		{
+			name:         "notebook section",
+			sections:     []models.OnenoteSectionable{stubSection("s", "Section", nb, nil)},
+			expectErr:    assert.NoError,
+			expectStored: []string{"nb", "s"},
+			expectLoc:    []string{"Notebook", "Section"},
+		},
+		{
+			name:         "nested section groups",
+			groups:       []models.SectionGroupable{outer, inner},
+			sections:     []models.OnenoteSectionable{stubSection("s", "Section", nil, inner)},
+			expectErr:    assert.NoError,
+			expectStored: []string{"nb", "g1", "g2", "s"},
+			expectLoc:    []string{"Notebook", "Outer", "Inner", "Section"},
+		},
+		{
+			name:      "unknown section group",
+			groups:    []models.SectionGroupable{orphan},
+			sections:  []models.OnenoteSectionable{stubSection("s", "Section", nil, orphan)},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no notebook",
+			sections:  []models.OnenoteSectionable{stubSection("s", "Section", nil, nil)},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bh := mockBackupHandler{
+				groups:   test.groups,
+				sections: test.sections,
+			}
+
+			result, err := getContainers(ctx, bh)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			require.Len(t, result, 1)
+			assert.Equal(t, test.expectStored, []string(result[0].storageDirFolders))
+			assert.Equal(t, test.expectLoc, []string(result[0].humanLocation))
+			assert.Equal(t, "Notebook", result[0].notebook)
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestPopulateCollections() {
+	var (
+		nb       = stubNotebook("nb", "Notebook")
+		sections = []models.OnenoteSectionable{
+			stubSection("s1", "First", nb, nil),
+			stubSection("s2", "Second", nb, nil),
+		}
+		pages = map[string][]models.OnenotePageable{
+			"s1": {stubPage("p1", "one"), stubPage("p2", "two")},
+			"s2": {stubPage("p3", "three")},
+		}
+	)
+
+	prevPath, err := path.Build("t", "user", path.OneDriveService, path.NotebooksCategory, false, "nb", "gone")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name            string
+		excluded        map[string]struct{}
+		prevPaths       map[string]string
+		expectNew       int
+		expectTombstone int
+		expectPages     int
+	}{
+		{
+			name:        "all sections",
+			expectNew:   2,
+			expectPages: 3,
+		},
+		{
+			name:        "excluded section",
+			excluded:    map[string]struct{}{"Notebook/Second": {}},
+			expectNew:   1,
+			expectPages: 2,
+		},
+		{
+			name: "deleted section",
+			prevPaths: map[string]string{
+				path.Elements{"nb", "gone"}.String(): prevPath.String(),
+			},
+			expectNew:       2,
+			expectTombstone: 1,
+			expectPages:     3,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bh := mockBackupHandler{
+				sections: sections,
+				pages:    pages,
+				excluded: test.excluded,
+			}
+
+			containers, err := getContainers(ctx, bh)
+			require.NoError(t, err, clues.ToCore(err))
+
+			collections, err := populateCollections(
+				ctx,
+				bh,
+				"t",
+				"user",
+				func(*support.ControllerOperationStatus) {},
+				containers,
+				test.prevPaths,
+				control.DefaultOptions(),
+				count.New(),
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				news, tombstones, metadata, numPages int
+			)
+
+			for _, c := range collections {
+				switch {
+				case c.FullPath() != nil && c.FullPath().Service() == path.OneDriveMetadataService:
+					metadata++
+				case c.State() == data.DeletedState:
+					tombstones++
+				case c.State() == data.NewState:
+					news++
+					numPages += len(c.(*lazyFetchCollection).added)
+
+					assert.True(t, c.DoNotMergeItems(), "collections list every page")
+				}
+			}
+
+			assert.Equal(t, 1, metadata, "metadata collections")
+			assert.Equal(t, test.expectNew, news, "new collections")
+			assert.Equal(t, test.expectTombstone, tombstones, "tombstones")
+			assert.Equal(t, test.expectPages, numPages, "pages")
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestIsInaccessible() {
+	table := []struct {
+		name   string
+		err    error
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "nil",
+			expect: assert.False,
+		},
+		{
+			name:   "access denied",
+			err:    graphTD.ODataErr(string(graph.ErrorAccessDenied)),
+			expect: assert.True,
+		},
+		{
+			name:   "forbidden",
+			err:    clues.New("forbidden").Label(graph.LabelStatus(http.StatusForbidden)),
+			expect: assert.True,
+		},
+		{
+			name:   "insufficient authorization",
+			err:    clues.Stack(core.ErrInsufficientAuthorization),
+			expect: assert.True,
+		},
+		{
+			name:   "not licensed",
+			err:    clues.New("no mysite").Label(graph.LabelsMysiteNotFound),
+			expect: assert.True,
+		},
+		{
+			name:   "other error",
+			err:    clues.New("boom"),
+			expect: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), IsInaccessible(test.err))
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package onenote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ data.BackupCollection = &lazyFetchCollection{}
+
+const collectionChannelBufferSize = 1000
+
+// updateStatus is a utility function used to send the status update through
+// the channel.
+func updateStatus(
+	ctx context.Context,
+	statusUpdater support.StatusUpdater,
+	attempted int,
+	streamedItems int64,
+	folderPath string,
+) {
+	status := support.CreateStatus(
+		ctx,
+		support.Backup,
+		1,
+		support.CollectionMetrics{
+			Objects:   attempted,
+			Successes: int(streamedItems),
+		},
+		folderPath)
+
+	logger.Ctx(ctx).Debugw("done streaming items", "status", status.String())
+
+	statusUpdater(status)
+}
+
+// -----------------------------------------------------------------------------
+// lazyFetchCollection
+// -----------------------------------------------------------------------------
+
+// lazyFetchCollection defers fetching each page until kopia asks for its
+// data.  Page listings include reliable mod times, so kopia skips any
+// page that hasn't changed since the last backup without a fetch.
+type lazyFetchCollection struct {
+	data.BaseCollection
+	stream chan data.Item
+
+	contains container
+
+	// added is the set of pages in the section, by ID.
+	added map[string]models.OnenotePageable
+
+	getter pageContentGetter
+
+	statusUpdater support.StatusUpdater
+}
+
+// State of the collection is set as an observation of the current
+// and previous paths.  If the curr path is nil, the state is assumed
+// to be deleted.  If the prev path is nil, it is assumed newly created.
+// If both are populated, then state is either moved (if they differ),
+// or notMoved (if they match).
+func NewCollection(
+	baseCol data.BaseCollection,
+	getter pageContentGetter,
+	added map[string]models.OnenotePageable,
+	contains container,
+	statusUpdater support.StatusUpdater,
+) data.BackupCollection {
+	return &lazyFetchCollection{
+		BaseCollection: baseCol,
+		added:          added,
+		contains:       contains,
+		getter:         getter,
+		statusUpdater:  statusUpdater,
+		stream:         make(chan data.Item, collectionChannelBufferSize),
+	}
+}
+
+func (col *lazyFetchCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	go col.streamItems(ctx, errs)
+	return col.stream
+}
+
+func (col *lazyFetchCollection) streamItems(ctx context.Context, errs *fault.Bus) {
+	var (
+		streamedItems   int64
+		wg              sync.WaitGroup
+		progressMessage chan<- struct{}
+		el              = errs.Local()
+	)
+
+	ctx = clues.Add(ctx, "category", col.Category().String())
+
+	defer func() {
+		close(col.stream)
+		logger.Ctx(ctx).Infow(
+			"finished stream backup collection items",
+			"stats", col.Counter.Values())
+
+		updateStatus(
+			ctx,
+			col.statusUpdater,
+			len(col.added),
+			streamedItems,
+			col.FullPath().Folder(false))
+	}()
+
+	if len(col.added) > 0 {
+		progressMessage = observe.CollectionProgress(
+			ctx,
+			col.Category().HumanString(),
+			col.LocationPath().Elements())
+		defer close(progressMessage)
+	}
+
+	semaphoreCh := make(chan struct{}, col.Opts().Parallelism.ItemFetch)
+	defer close(semaphoreCh)
+
+	for id, page := range col.added {
+		if el.Failure() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphoreCh <- struct{}{}
+
+		go func(id string, page models.OnenotePageable) {
+			defer wg.Done()
+			defer func() { <-semaphoreCh }()
+
+			ictx := clues.Add(
+				ctx,
+				"item_id", id,
+				"parent_path", path.LoggableDir(col.LocationPath().String()))
+
+			col.stream <- data.NewLazyItemWithInfo(
+				ictx,
+				&lazyItemGetter{
+					getter:     col.getter,
+					page:       page,
+					contains:   col.contains,
+					parentPath: col.LocationPath().String(),
+				},
+				id,
+				ptr.Val(page.GetLastModifiedDateTime()),
+				col.Counter,
+				el)
+
+			atomic.AddInt64(&streamedItems, 1)
+
+			if progressMessage != nil {
+				progressMessage <- struct{}{}
+			}
+		}(id, page)
+	}
+
+	wg.Wait()
+}
+
+type lazyItemGetter struct {
+	getter     pageContentGetter
+	page       models.OnenotePageable
+	parentPath string
+	contains   container
+}
+
+func (lig *lazyItemGetter) GetData(
+	ctx context.Context,
+	errs *fault.Bus,
+) (io.ReadCloser, *details.ItemInfo, bool, error) {
+	p, err := getPage(ctx, lig.getter, lig.page)
+	if err != nil {
+		// For pages that were deleted in flight, add the skip label so that
+		// they don't lead to recoverable failures during backup.
+		if clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) || errors.Is(err, core.ErrNotFound) {
+			logger.CtxErr(ctx, err).Info("item deleted in flight. skipping")
+
+			// Returning delInFlight as true here for correctness, although the caller is going
+			// to ignore it since we are returning an error.
+			return nil, nil, true, clues.Wrap(err, "deleted item").Label(graph.LabelsSkippable)
+		}
+
+		err = clues.WrapWC(ctx, err, "getting item data").Label(fault.LabelForceNoBackupCreation)
+		errs.AddRecoverable(ctx, err)
+
+		return nil, nil, false, err
+	}
+
+	itemData, err := json.Marshal(p)
+	if err != nil {
+		err = clues.WrapWC(ctx, err, "serializing item").Label(fault.LabelForceNoBackupCreation)
+		errs.AddRecoverable(ctx, err)
+
+		return nil, nil, false, err
+	}
+
+	info := api.OneNotePageInfo(lig.page, int64(len(itemData)))
+	info.Notebook = lig.contains.notebook
+	info.Section = ptr.Val(lig.contains.section.GetDisplayName())
+	info.ParentPath = lig.parentPath
+
+	return io.NopCloser(bytes.NewReader(itemData)),
+		&details.ItemInfo{OneNote: info},
+		false,
+		nil
+}
@@ -0,0 +1,196 @@
+package onenote
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const untitledPage = "Untitled Page"
+
+// characters that can't appear in exported file names.
+var fileNameReplacer = strings.NewReplacer(
+	"/", "-", `\`, "-", ":", "-", "*", "-", "?", "-",
+	`"`, "-", "<", "-", ">", "-", "|", "-")
+
+// common resource types, for readable file extensions.  Anything else
+// falls back to the system's mime table.
+var resourceExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/bmp":       ".bmp",
+	"image/gif":       ".gif",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// NewExportCollection produces a collection that exports the pages of a
+// single notebook section.  Each page is written as `<title>.html`, with
+// its images and files in a `<title>_files` folder next to it.  The
+// page's html is rewritten to reference those local copies.
+func NewExportCollection(
+	baseDir string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               cec,
+		Stream:            streamItems,
+		Stats:             stats,
+	}
+}
+
+// streamItems streams each page in the backing collections, along
+// with its resources.
+func streamItems(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	_ int,
+	_ control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	var (
+		errs = fault.New(false)
+		// lowercased page file names, since titles aren't unique.
+		names = map[string]struct{}{}
+	)
+
+	for _, rc := range drc {
+		ictx := clues.Add(ctx, "path_short_ref", rc.FullPath().ShortRef())
+
+		for item := range rc.Items(ictx, errs) {
+			p, err := readPage(item.ToReader())
+			if err != nil {
+				logger.CtxErr(ictx, err).Info("processing collection item")
+
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(path.NotebooksCategory)
+
+			for _, ei := range exportPage(p, uniquePageName(p.Title, names)) {
+				ei.Body = metrics.ReaderWithStats(ei.Body, path.NotebooksCategory, stats)
+				ch <- ei
+			}
+		}
+
+		sendErrs(ch, errs)
+	}
+}
+
+// exportPage produces the export items for the page's html and for each
+// of its resources.
+func exportPage(p Page, name string) []export.Item {
+	var (
+		items    = []export.Item{}
+		filesDir = name + "_files"
+		written  = map[string]struct{}{}
+	)
+
+	html := replaceResourceURLs(p, func(r Resource) string {
+		return (&url.URL{Path: filesDir + "/" + resourceFileName(r)}).String()
+	})
+
+	items = append(items, export.Item{
+		ID:   p.ID,
+		Name: name + ".html",
+		Body: io.NopCloser(strings.NewReader(html)),
+	})
+
+	for _, r := range p.Resources {
+		if _, ok := written[r.ID]; ok {
+			continue
+		}
+
+		written[r.ID] = struct{}{}
+
+		items = append(items, export.Item{
+			ID:   r.ID,
+			Name: filesDir + "/" + resourceFileName(r),
+			Body: io.NopCloser(bytes.NewReader(r.Content)),
+		})
+	}
+
+	return items
+}
+
+// uniquePageName produces a file name for the page that isn't already
+// in use by another page in the same section.
+func uniquePageName(title string, names map[string]struct{}) string {
+	name := strings.TrimSpace(fileNameReplacer.Replace(title))
+	if len(name) == 0 {
+		name = untitledPage
+	}
+
+	candidate := name
+
+	for i := 1; ; i++ {
+		if _, ok := names[strings.ToLower(candidate)]; !ok {
+			break
+		}
+
+		candidate = name + " (" + strconv.Itoa(i) + ")"
+	}
+
+	names[strings.ToLower(candidate)] = struct{}{}
+
+	return candidate
+}
+
+func resourceFileName(r Resource) string {
+	ct, _, _ := mime.ParseMediaType(r.ContentType)
+
+	ext, ok := resourceExtensions[ct]
+	if !ok {
+		if exts, _ := mime.ExtensionsByType(ct); len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+
+	return fileNameReplacer.Replace(r.ID) + ext
+}
+
+// sendErrs returns all the items that we failed to source from the
+// persistence layer.
+func sendErrs(ch chan<- export.Item, errs *fault.Bus) {
+	items, recovered := errs.ItemsAndRecovered()
+
+	for _, item := range items {
+		ch <- export.Item{
+			ID:    item.ID,
+			Error: &item,
+		}
+	}
+
+	for _, err := range recovered {
+		ch <- export.Item{
+			Error: err,
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package onenote
+
+import (
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ExportUnitSuite) TestUniquePageName() {
+	var (
+		t     = suite.T()
+		names = map[string]struct{}{}
+	)
+
+	assert.Equal(t, "Meeting notes", uniquePageName("Meeting notes", names))
+	assert.Equal(t, "Meeting notes (1)", uniquePageName("meeting notes", names))
+	assert.Equal(t, "Meeting notes (2)", uniquePageName("Meeting notes", names))
+	assert.Equal(t, untitledPage, uniquePageName("  ", names))
+	assert.Equal(t, "a-b-c", uniquePageName("a/b:c", names))
+}
+
+func (suite *ExportUnitSuite) TestExportPage() {
+	var (
+		t      = suite.T()
+		imgURL = "https://graph.microsoft.com/v1.0/users/u/onenote/resources/img/$value"
+		pdfURL = "https://graph.microsoft.com/v1.0/users/u/onenote/resources/pdf/content"
+		page   = Page{
+			ID:    "p",
+			Title: "Page",
+			Content: `<img src="` + imgURL + `" />` +
+				`<object data="` + pdfURL + `"></object>` +
+				`<img src="` + imgURL + `" />`,
+			Resources: []Resource{
+				{ID: "img", URL: imgURL, ContentType: "image/png", Content: []byte("png")},
+				{ID: "pdf", URL: pdfURL, ContentType: "application/pdf", Content: []byte("pdf")},
+			},
+		}
+	)
+
+	items := exportPage(page, "Page")
+	require.Len(t, items, 3)
+
+	assert.Equal(t, "Page.html", items[0].Name)
+
+	html, err := io.ReadAll(items[0].Body)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(
+		t,
+		`<img src="Page_files/img.png" />`+
+			`<object data="Page_files/pdf.pdf"></object>`+
+			`<img src="Page_files/img.png" />`,
+		string(html))
+
+	assert.Equal(t, "Page_files/img.png", items[1].Name)
+	assert.Equal(t, "Page_files/pdf.pdf", items[2].Name)
+
+	body, err := io.ReadAll(items[1].Body)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "png", string(body))
+}
@@ -0,0 +1,90 @@
+package onenote
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type backupHandler interface {
+	getContainerser
+	getPageser
+	pageContentGetter
+	includeContainerer
+	canonicalPather
+}
+
+// gets every section group and section owned by the resource.  Section
+// groups are only needed to rebuild the location of their sections.
+type getContainerser interface {
+	getSectionGroups(ctx context.Context) ([]models.SectionGroupable, error)
+	getSections(ctx context.Context) ([]models.OnenoteSectionable, error)
+}
+
+// gets the metadata of every page in the section.
+type getPageser interface {
+	getPages(ctx context.Context, sectionID string) ([]models.OnenotePageable, error)
+}
+
+// gets the html content of a page, and the content of the images and
+// files embedded in it.
+type pageContentGetter interface {
+	getPageContent(ctx context.Context, pageID string) ([]byte, error)
+	getResourceContent(ctx context.Context, resourceID string) ([]byte, error)
+}
+
+// includeContainer evaluates whether the section, identified by its
+// human-readable location, is included in the handler's scope.
+type includeContainerer interface {
+	includeContainer(loc *path.Builder) bool
+}
+
+// canonicalPath constructs the service and category specific path for
+// the given builder.
+type canonicalPather interface {
+	canonicalPath(
+		storageDir path.Elements,
+		tenantID string,
+	) (path.Path, error)
+	// service identifies the owner of the notebooks: users for onedrive,
+	// sites for sharepoint.
+	service() path.ServiceType
+}
+
+type restoreHandler interface {
+	getContainerser
+	getPageser
+	getNotebooks(ctx context.Context) ([]models.Notebookable, error)
+	postNotebook(ctx context.Context, name string) (models.Notebookable, error)
+	postSectionGroup(
+		ctx context.Context,
+		notebookID, parentGroupID, name string,
+	) (models.SectionGroupable, error)
+	postSection(
+		ctx context.Context,
+		notebookID, parentGroupID, name string,
+	) (models.OnenoteSectionable, error)
+	postPage(
+		ctx context.Context,
+		sectionID string,
+		body []byte,
+		contentType string,
+	) (models.OnenotePageable, error)
+	deletePage(ctx context.Context, pageID string) error
+}
+
+// ---------------------------------------------------------------------------
+// Container management
+// ---------------------------------------------------------------------------
+
+// container is a notebook section.  Sections are stored by the IDs of the
+// notebook, section groups, and section that contain them, and located
+// by the names of the same.
+type container struct {
+	storageDirFolders path.Elements
+	humanLocation     path.Elements
+	notebook          string
+	section           models.OnenoteSectionable
+}
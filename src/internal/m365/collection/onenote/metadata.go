@@ -0,0 +1,102 @@
+package onenote
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// MetadataFileNames only contains PreviousPathFileName and not
+// DeltaURLsFileName because graph has no delta support for notebooks.
+func MetadataFileNames() []string {
+	return []string{metadata.PreviousPathFileName}
+}
+
+// parseMetadataCollections produces the map of section storage dir ->
+// previous path.  Collections in any category other than notebooks are
+// ignored.
+func parseMetadataCollections(
+	ctx context.Context,
+	colls []data.RestoreCollection,
+) (map[string]string, bool, error) {
+	var (
+		prevPaths = map[string]string{}
+		found     bool
+		// errors from metadata items should not stop the backup,
+		// but it should prevent us from using previous backups
+		errs = fault.New(true)
+	)
+
+	for _, coll := range colls {
+		if coll.FullPath().Category() != path.NotebooksCategory {
+			continue
+		}
+
+		items := coll.Items(ctx, errs)
+
+		for breakLoop := false; !breakLoop; {
+			select {
+			case <-ctx.Done():
+				return nil, false, clues.WrapWC(ctx, ctx.Err(), "parsing collection metadata")
+
+			case item, ok := <-items:
+				if !ok || errs.Failure() != nil {
+					breakLoop = true
+					break
+				}
+
+				if item.ID() != metadata.PreviousPathFileName {
+					continue
+				}
+
+				if found {
+					return nil, false, clues.NewWC(ctx, "multiple versions of path metadata")
+				}
+
+				if err := json.NewDecoder(item.ToReader()).Decode(&prevPaths); err != nil {
+					return nil, false, clues.WrapWC(ctx, err, "decoding metadata json")
+				}
+
+				found = true
+			}
+		}
+	}
+
+	if errs.Failure() != nil {
+		logger.CtxErr(ctx, errs.Failure()).Info("reading metadata collection items")
+		return map[string]string{}, false, nil
+	}
+
+	return prevPaths, true, nil
+}
+
+// produces a set of id:path pairs from the previous paths map.
+// Each entry in the set will, if not removed, produce a collection
+// that will delete the tombstone by path.
+func makeTombstones(prevPaths map[string]string) map[string]string {
+	r := make(map[string]string, len(prevPaths))
+
+	for id, p := range prevPaths {
+		if len(p) > 0 {
+			r[id] = p
+		}
+	}
+
+	return r
+}
+
+func pathFromPrevString(ps string) (path.Path, error) {
+	p, err := path.FromDataLayerPath(ps, false)
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing previous path string")
+	}
+
+	return p, nil
+}
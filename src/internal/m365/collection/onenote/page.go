@@ -0,0 +1,120 @@
+package onenote
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// Page is the stored form of a notebook page.  Graph serves the page's
+// html separately from the images and files embedded in it, and the
+// resource urls in the html stop working once the page is deleted, so
+// every resource is stored alongside the html.
+type Page struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Created   time.Time  `json:"created"`
+	Modified  time.Time  `json:"modified"`
+	Level     int32      `json:"level"`
+	Order     int32      `json:"order"`
+	Content   string     `json:"content"`
+	Resources []Resource `json:"resources,omitempty"`
+}
+
+// Resource is an image or file embedded in a page.
+type Resource struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	ContentType string `json:"contentType,omitempty"`
+	Content     []byte `json:"content"`
+}
+
+// getPage fetches the html content of the page, and the content of
+// every resource the html references.
+func getPage(
+	ctx context.Context,
+	pcg pageContentGetter,
+	page models.OnenotePageable,
+) (Page, error) {
+	pageID := ptr.Val(page.GetId())
+
+	html, err := pcg.getPageContent(ctx, pageID)
+	if err != nil {
+		return Page{}, clues.Wrap(err, "getting page content")
+	}
+
+	p := Page{
+		ID:       pageID,
+		Title:    ptr.Val(page.GetTitle()),
+		Created:  ptr.Val(page.GetCreatedDateTime()),
+		Modified: ptr.Val(page.GetLastModifiedDateTime()),
+		Level:    ptr.Val(page.GetLevel()),
+		Order:    ptr.Val(page.GetOrder()),
+		Content:  string(html),
+	}
+
+	// the same resource can be referenced by more than one url, ex: an
+	// image and its full resolution source.  Only fetch it once.
+	fetched := map[string]Resource{}
+
+	for url, id := range api.PageResourceURLs(p.Content) {
+		r, ok := fetched[id]
+		if !ok {
+			bs, err := pcg.getResourceContent(ctx, id)
+			if err != nil {
+				return Page{}, clues.Wrap(err, "getting page resource").With("resource_id", id)
+			}
+
+			r = Resource{
+				ID:          id,
+				ContentType: http.DetectContentType(bs),
+				Content:     bs,
+			}
+
+			fetched[id] = r
+		}
+
+		r.URL = url
+		p.Resources = append(p.Resources, r)
+	}
+
+	sort.Slice(p.Resources, func(i, j int) bool {
+		return p.Resources[i].URL < p.Resources[j].URL
+	})
+
+	return p, nil
+}
+
+func readPage(rc io.ReadCloser) (Page, error) {
+	defer rc.Close()
+
+	p := Page{}
+
+	if err := json.NewDecoder(rc).Decode(&p); err != nil {
+		return Page{}, clues.Wrap(err, "deserializing page")
+	}
+
+	return p, nil
+}
+
+// replaceResourceURLs rewrites every resource url in the page's html
+// using the provided func.
+func replaceResourceURLs(p Page, fn func(r Resource) string) string {
+	oldnew := make([]string, 0, len(p.Resources)*2)
+
+	for _, r := range p.Resources {
+		oldnew = append(oldnew, r.URL, fn(r))
+	}
+
+	return strings.NewReplacer(oldnew...).Replace(p.Content)
+}
@@ -0,0 +1,411 @@
+package onenote
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+const (
+	// longest display names onenote accepts for notebooks and sections.
+	maxNotebookNameLen = 128
+	maxSectionNameLen  = 50
+)
+
+// characters onenote doesn't allow in notebook, section group, and
+// section names.
+var containerNameReplacer = strings.NewReplacer(
+	"?", "-", "*", "-", "/", "-", `\`, "-", ":", "-",
+	"<", "-", ">", "-", "|", "-", "&", "-", "#", "-",
+	"'", "-", `"`, "-", "%", "-", "~", "-")
+
+// RestoreCache holds the IDs of the resource's notebooks, section groups
+// and sections, keyed by their lowercased location, so that each one is
+// only looked up or created once per restore.
+type RestoreCache struct {
+	containers map[string]string
+}
+
+func NewRestoreCache() *RestoreCache {
+	return &RestoreCache{}
+}
+
+func (c *RestoreCache) populate(
+	ctx context.Context,
+	rh restoreHandler,
+) error {
+	if c.containers != nil {
+		return nil
+	}
+
+	notebooks, err := rh.getNotebooks(ctx)
+	if err != nil {
+		return clues.Wrap(err, "getting notebooks")
+	}
+
+	groups, err := rh.getSectionGroups(ctx)
+	if err != nil {
+		return clues.Wrap(err, "getting section groups")
+	}
+
+	sections, err := rh.getSections(ctx)
+	if err != nil {
+		return clues.Wrap(err, "getting sections")
+	}
+
+	c.containers = map[string]string{}
+
+	for _, nb := range notebooks {
+		c.add([]string{ptr.Val(nb.GetDisplayName())}, ptr.Val(nb.GetId()))
+	}
+
+	groupsByID := make(map[string]models.SectionGroupable, len(groups))
+
+	for _, g := range groups {
+		groupsByID[ptr.Val(g.GetId())] = g
+	}
+
+	for _, g := range groups {
+		_, names, err := ancestors(g.GetParentNotebook(), g.GetParentSectionGroup(), groupsByID)
+		if err != nil {
+			logger.CtxErr(ctx, err).Info("locating section group")
+			continue
+		}
+
+		c.add(append(names, ptr.Val(g.GetDisplayName())), ptr.Val(g.GetId()))
+	}
+
+	for _, s := range sections {
+		sc, err := sectionContainer(s, groupsByID)
+		if err != nil {
+			logger.CtxErr(ctx, err).Info("locating section")
+			continue
+		}
+
+		c.add(sc.humanLocation, ptr.Val(s.GetId()))
+	}
+
+	return nil
+}
+
+func (c *RestoreCache) get(loc []string) (string, bool) {
+	id, ok := c.containers[locKey(loc)]
+	return id, ok
+}
+
+func (c *RestoreCache) add(loc []string, id string) {
+	c.containers[locKey(loc)] = id
+}
+
+// onenote compares names case-insensitively.
+func locKey(loc []string) string {
+	return strings.ToLower(path.Builder{}.Append(loc...).String())
+}
+
+// RestoreCollection restores the pages in the collection into their
+// section, creating the notebook, section groups, and section if they
+// don't exist.  Pages are matched to existing pages in the section by
+// title to detect collisions.
+func RestoreCollection(
+	ctx context.Context,
+	rh restoreHandler,
+	dc data.RestoreCollection,
+	restoreLocation string,
+	cache *RestoreCache,
+	collisionPolicy control.CollisionPolicy,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:onenote:restoreCollection", diagnostics.Label("path", dc.FullPath()))
+	defer end()
+
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		fullPath = dc.FullPath()
+		folders  = fullPath.Folders()
+	)
+
+	// at minimum: notebook, section.
+	if len(folders) < 2 {
+		return metrics, clues.NewWC(ctx, "notebook collection has no section")
+	}
+
+	if err := cache.populate(ctx, rh); err != nil {
+		return metrics, clues.Stack(err)
+	}
+
+	loc := restoreContainerNames(restoreLocation, folders)
+	ctx = clues.Add(ctx, "restore_location", path.LoggableDir(path.Builder{}.Append(loc...).String()))
+
+	sectionID, existed, err := ensureSection(ctx, rh, cache, loc)
+	if err != nil {
+		return metrics, clues.Wrap(err, "creating restore section")
+	}
+
+	collisions := map[string][]string{}
+
+	if existed {
+		collisions, err = pagesByTitle(ctx, rh, sectionID)
+		if err != nil {
+			return metrics, clues.Stack(err)
+		}
+	}
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		path.NotebooksCategory.HumanString(),
+		clues.Hide(loc[len(loc)-1]))
+	defer close(progressMessage)
+
+	items := dc.Items(ctx, errs)
+
+	for {
+		if el.Failure() != nil {
+			break
+		}
+
+		itemData, ok := <-items
+		if !ok {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", itemData.ID())
+		metrics.Objects++
+
+		p, err := readPage(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading page"))
+			continue
+		}
+
+		existing := collisions[strings.ToLower(p.Title)]
+
+		if len(existing) > 0 {
+			log := logger.Ctx(ictx).With("collision_policy", collisionPolicy)
+			log.Debug("page collision")
+
+			if collisionPolicy == control.Skip {
+				ctr.Inc(count.CollisionSkip)
+				log.Debug("skipping page with collision")
+
+				continue
+			}
+		}
+
+		size, info, err := restorePage(ictx, rh, sectionID, p)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "restoring page"))
+			continue
+		}
+
+		// only remove the original pages once their replacement exists.
+		if len(existing) > 0 && collisionPolicy == control.Replace {
+			for _, id := range existing {
+				if err := rh.deletePage(ictx, id); err != nil {
+					el.AddRecoverable(ictx, clues.Wrap(err, "deleting replaced page"))
+				}
+			}
+
+			delete(collisions, strings.ToLower(p.Title))
+			ctr.Inc(count.CollisionReplace)
+		} else {
+			ctr.Inc(count.NewItemCreated)
+		}
+
+		metrics.Bytes += size
+		metrics.Successes++
+
+		info.Notebook = loc[0]
+		info.Section = loc[len(loc)-1]
+
+		itemPath, err := fullPath.AppendItem(itemData.ID())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+			continue
+		}
+
+		err = deets.Add(
+			itemPath,
+			path.Builder{}.Append(loc...),
+			details.ItemInfo{OneNote: info})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+		}
+
+		progressMessage <- struct{}{}
+	}
+
+	return metrics, el.Failure()
+}
+
+// restorePage creates a copy of the page in the section.  Resources are
+// uploaded alongside the html, which references them by part name.
+func restorePage(
+	ctx context.Context,
+	rh restoreHandler,
+	sectionID string,
+	p Page,
+) (int64, *details.OneNoteInfo, error) {
+	var (
+		resources = []api.PageResource{}
+		partNames = map[string]string{}
+	)
+
+	for _, r := range p.Resources {
+		if _, ok := partNames[r.ID]; ok {
+			continue
+		}
+
+		name := "resource" + strconv.Itoa(len(partNames))
+		partNames[r.ID] = name
+
+		resources = append(resources, api.PageResource{
+			Name:        name,
+			ContentType: r.ContentType,
+			Content:     r.Content,
+		})
+	}
+
+	html := replaceResourceURLs(p, func(r Resource) string {
+		return "name:" + partNames[r.ID]
+	})
+
+	body, contentType, err := api.PagePostBody(html, resources)
+	if err != nil {
+		return 0, nil, clues.Stack(err)
+	}
+
+	page, err := rh.postPage(ctx, sectionID, body, contentType)
+	if err != nil {
+		return 0, nil, clues.Stack(err)
+	}
+
+	size := int64(len(body))
+
+	return size, api.OneNotePageInfo(page, size), nil
+}
+
+// ensureSection produces the ID of the section at the location, creating
+// the notebook, section groups and section as needed.  Returns true if
+// the section already existed.
+func ensureSection(
+	ctx context.Context,
+	rh restoreHandler,
+	cache *RestoreCache,
+	loc []string,
+) (string, bool, error) {
+	if id, ok := cache.get(loc); ok {
+		return id, true, nil
+	}
+
+	notebookID, ok := cache.get(loc[:1])
+	if !ok {
+		nb, err := rh.postNotebook(ctx, loc[0])
+		if err != nil {
+			return "", false, clues.Wrap(err, "creating notebook")
+		}
+
+		notebookID = ptr.Val(nb.GetId())
+		cache.add(loc[:1], notebookID)
+	}
+
+	var parentGroupID string
+
+	for i := 1; i < len(loc)-1; i++ {
+		groupID, ok := cache.get(loc[:i+1])
+		if !ok {
+			g, err := rh.postSectionGroup(ctx, notebookID, parentGroupID, loc[i])
+			if err != nil {
+				return "", false, clues.Wrap(err, "creating section group")
+			}
+
+			groupID = ptr.Val(g.GetId())
+			cache.add(loc[:i+1], groupID)
+		}
+
+		parentGroupID = groupID
+	}
+
+	s, err := rh.postSection(ctx, notebookID, parentGroupID, loc[len(loc)-1])
+	if err != nil {
+		return "", false, clues.Wrap(err, "creating section")
+	}
+
+	sectionID := ptr.Val(s.GetId())
+	cache.add(loc, sectionID)
+
+	return sectionID, false, nil
+}
+
+// pagesByTitle produces a map of lowercased page title -> page IDs for
+// every page in the section.
+func pagesByTitle(
+	ctx context.Context,
+	rh restoreHandler,
+	sectionID string,
+) (map[string][]string, error) {
+	pages, err := rh.getPages(ctx, sectionID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting existing pages")
+	}
+
+	result := map[string][]string{}
+
+	for _, p := range pages {
+		k := strings.ToLower(ptr.Val(p.GetTitle()))
+		result[k] = append(result[k], ptr.Val(p.GetId()))
+	}
+
+	return result, nil
+}
+
+// restoreContainerNames produces the names of the notebook, section
+// groups, and section that receive the restored pages.  The restore
+// location, if any, prefixes the notebook name; in-place restores reuse
+// the original names.
+func restoreContainerNames(restoreLocation string, folders []string) []string {
+	names := make([]string, 0, len(folders))
+
+	notebook := folders[0]
+	if len(restoreLocation) > 0 {
+		notebook = restoreLocation + "_" + notebook
+	}
+
+	names = append(names, containerName(notebook, maxNotebookNameLen))
+
+	for _, f := range folders[1:] {
+		names = append(names, containerName(f, maxSectionNameLen))
+	}
+
+	return names
+}
+
+func containerName(name string, maxLen int) string {
+	name = containerNameReplacer.Replace(name)
+
+	if r := []rune(name); len(r) > maxLen {
+		name = string(r[:maxLen])
+	}
+
+	return name
+}
@@ -0,0 +1,288 @@
+package onenote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+var _ restoreHandler = &mockRestoreHandler{}
+
+type mockRestoreHandler struct {
+	notebooks []models.Notebookable
+	groups    []models.SectionGroupable
+	sections  []models.OnenoteSectionable
+	pages     map[string][]models.OnenotePageable
+
+	createdNotebooks []string
+	createdGroups    []string
+	createdSections  []string
+	postedBodies     []string
+	deletedPages     []string
+}
+
+func (rh *mockRestoreHandler) getNotebooks(
+	context.Context,
+) ([]models.Notebookable, error) {
+	return rh.notebooks, nil
+}
+
+func (rh *mockRestoreHandler) getSectionGroups(
+	context.Context,
+) ([]models.SectionGroupable, error) {
+	return rh.groups, nil
+}
+
+func (rh *mockRestoreHandler) getSections(
+	context.Context,
+) ([]models.OnenoteSectionable, error) {
+	return rh.sections, nil
+}
+
+func (rh *mockRestoreHandler) getPages(
+	_ context.Context,
+	sectionID string,
+) ([]models.OnenotePageable, error) {
+	return rh.pages[sectionID], nil
+}
+
+func (rh *mockRestoreHandler) postNotebook(
+	_ context.Context,
+	name string,
+) (models.Notebookable, error) {
+	rh.createdNotebooks = append(rh.createdNotebooks, name)
+	return stubNotebook("id-"+name, name), nil
+}
+
+func (rh *mockRestoreHandler) postSectionGroup(
+	_ context.Context,
+	_, _, name string,
+) (models.SectionGroupable, error) {
+	rh.createdGroups = append(rh.createdGroups, name)
+	return stubSectionGroup("id-"+name, name, nil, nil), nil
+}
+
+func (rh *mockRestoreHandler) postSection(
+	_ context.Context,
+	_, _, name string,
+) (models.OnenoteSectionable, error) {
+	rh.createdSections = append(rh.createdSections, name)
+	return stubSection("id-"+name, name, nil, nil), nil
+}
+
+func (rh *mockRestoreHandler) postPage(
+	_ context.Context,
+	_ string,
+	body []byte,
+	_ string,
+) (models.OnenotePageable, error) {
+	rh.postedBodies = append(rh.postedBodies, string(body))
+	return stubPage("new", "title"), nil
+}
+
+func (rh *mockRestoreHandler) deletePage(
+	_ context.Context,
+	pageID string,
+) error {
+	rh.deletedPages = append(rh.deletedPages, pageID)
+	return nil
+}
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RestoreUnitSuite) TestRestoreContainerNames() {
+	table := []struct {
+		name     string
+		location string
+		folders  []string
+		expect   []string
+	}{
+		{
+			name:    "in place",
+			folders: []string{"Notebook", "Section"},
+			expect:  []string{"Notebook", "Section"},
+		},
+		{
+			name:     "restore location",
+			location: "Corso_Restore",
+			folders:  []string{"Notebook", "Group", "Section"},
+			expect:   []string{"Corso_Restore_Notebook", "Group", "Section"},
+		},
+		{
+			name:     "invalid characters",
+			location: "Corso_Restore_01-Jan-2024_01:02:03",
+			folders:  []string{"Q&A", "#1"},
+			expect:   []string{"Corso_Restore_01-Jan-2024_01-02-03_Q-A", "-1"},
+		},
+		{
+			name:    "too long",
+			folders: []string{strings.Repeat("a", 130), strings.Repeat("b", 60)},
+			expect: []string{
+				strings.Repeat("a", maxNotebookNameLen),
+				strings.Repeat("b", maxSectionNameLen),
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			result := restoreContainerNames(test.location, test.folders)
+			assert.Equal(suite.T(), test.expect, result)
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestRestoreCollection() {
+	var (
+		nb      = stubNotebook("nb", "Notebook")
+		group   = stubSectionGroup("g", "Group", nb, nil)
+		section = stubSection("s", "Section", nil, group)
+		pageURL = "https://graph.microsoft.com/v1.0/users/u/onenote/resources/r1/$value"
+		pages   = []Page{
+			{
+				ID:      "p1",
+				Title:   "First",
+				Content: `<img src="` + pageURL + `" />`,
+				Resources: []Resource{{
+					ID:          "r1",
+					URL:         pageURL,
+					ContentType: "image/png",
+					Content:     []byte("png"),
+				}},
+			},
+			{
+				ID:      "p2",
+				Title:   "Second",
+				Content: "<p>second</p>",
+			},
+		}
+	)
+
+	table := []struct {
+		name            string
+		location        string
+		collisionPolicy control.CollisionPolicy
+		expectSections  []string
+		expectPosted    int
+		expectDeleted   []string
+		expectSkipped   int64
+		expectReplaced  int64
+		expectCreated   int64
+	}{
+		{
+			name:            "new notebook",
+			location:        "Corso_Restore",
+			collisionPolicy: control.Skip,
+			expectSections:  []string{"Section"},
+			expectPosted:    2,
+			expectCreated:   2,
+		},
+		{
+			name:            "collision skip",
+			collisionPolicy: control.Skip,
+			expectPosted:    1,
+			expectSkipped:   1,
+			expectCreated:   1,
+		},
+		{
+			name:            "collision copy",
+			collisionPolicy: control.Copy,
+			expectPosted:    2,
+			expectCreated:   2,
+		},
+		{
+			name:            "collision replace",
+			collisionPolicy: control.Replace,
+			expectPosted:    2,
+			expectDeleted:   []string{"existing"},
+			expectReplaced:  1,
+			expectCreated:   1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			fp, err := path.Build(
+				"t", "u",
+				path.OneDriveService,
+				path.NotebooksCategory,
+				false,
+				"Notebook", "Group", "Section")
+			require.NoError(t, err, clues.ToCore(err))
+
+			var (
+				rh = &mockRestoreHandler{
+					notebooks: []models.Notebookable{nb},
+					groups:    []models.SectionGroupable{group},
+					sections:  []models.OnenoteSectionable{section},
+					pages: map[string][]models.OnenotePageable{
+						"s": {stubPage("existing", "first")},
+					},
+				}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{Path: fp}
+			)
+
+			for _, p := range pages {
+				bs, err := json.Marshal(p)
+				require.NoError(t, err, clues.ToCore(err))
+
+				dc.ItemData = append(dc.ItemData, &dataMock.Item{
+					ItemID: p.ID,
+					Reader: io.NopCloser(bytes.NewReader(bs)),
+				})
+			}
+
+			_, err = RestoreCollection(
+				ctx,
+				rh,
+				dc,
+				test.location,
+				NewRestoreCache(),
+				test.collisionPolicy,
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectSections, rh.createdSections)
+			assert.Len(t, rh.postedBodies, test.expectPosted)
+			assert.Equal(t, test.expectDeleted, rh.deletedPages)
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace))
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+			assert.Len(t, deets.Details().Items(), test.expectPosted)
+
+			for _, body := range rh.postedBodies {
+				assert.NotContains(t, body, pageURL, "resource urls are replaced by part names")
+			}
+		})
+	}
+}
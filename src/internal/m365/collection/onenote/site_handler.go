@@ -0,0 +1,166 @@
+package onenote
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// ---------------------------------------------------------------------------
+// Base
+// ---------------------------------------------------------------------------
+
+type baseSiteHandler struct {
+	ac     api.OneNote
+	siteID string
+}
+
+func (h baseSiteHandler) getSectionGroups(
+	ctx context.Context,
+) ([]models.SectionGroupable, error) {
+	return h.ac.GetSiteSectionGroups(ctx, h.siteID)
+}
+
+func (h baseSiteHandler) getSections(
+	ctx context.Context,
+) ([]models.OnenoteSectionable, error) {
+	return h.ac.GetSiteSections(ctx, h.siteID)
+}
+
+func (h baseSiteHandler) getPages(
+	ctx context.Context,
+	sectionID string,
+) ([]models.OnenotePageable, error) {
+	return h.ac.GetSitePages(ctx, h.siteID, sectionID)
+}
+
+// ---------------------------------------------------------------------------
+// Backup
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = &siteBackupHandler{}
+
+type siteBackupHandler struct {
+	baseSiteHandler
+	scope selectors.SharePointScope
+}
+
+func NewSiteBackupHandler(
+	ac api.OneNote,
+	siteID string,
+	scope selectors.SharePointScope,
+) siteBackupHandler {
+	return siteBackupHandler{
+		baseSiteHandler: baseSiteHandler{
+			ac:     ac,
+			siteID: siteID,
+		},
+		scope: scope,
+	}
+}
+
+func (h siteBackupHandler) getPageContent(
+	ctx context.Context,
+	pageID string,
+) ([]byte, error) {
+	return h.ac.GetSitePageContent(ctx, h.siteID, pageID)
+}
+
+func (h siteBackupHandler) getResourceContent(
+	ctx context.Context,
+	resourceID string,
+) ([]byte, error) {
+	return h.ac.GetSiteResourceContent(ctx, h.siteID, resourceID)
+}
+
+func (h siteBackupHandler) includeContainer(loc *path.Builder) bool {
+	return h.scope.IsAny(selectors.SharePointNotebookSection) ||
+		h.scope.Matches(selectors.SharePointNotebookSection, loc.String())
+}
+
+func (h siteBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			h.siteID,
+			path.SharePointService,
+			path.NotebooksCategory,
+			false)
+}
+
+func (h siteBackupHandler) service() path.ServiceType {
+	return path.SharePointService
+}
+
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+var _ restoreHandler = &siteRestoreHandler{}
+
+type siteRestoreHandler struct {
+	baseSiteHandler
+}
+
+func NewSiteRestoreHandler(
+	ac api.OneNote,
+	siteID string,
+) siteRestoreHandler {
+	return siteRestoreHandler{
+		baseSiteHandler: baseSiteHandler{
+			ac:     ac,
+			siteID: siteID,
+		},
+	}
+}
+
+func (h siteRestoreHandler) getNotebooks(
+	ctx context.Context,
+) ([]models.Notebookable, error) {
+	return h.ac.GetSiteNotebooks(ctx, h.siteID)
+}
+
+func (h siteRestoreHandler) postNotebook(
+	ctx context.Context,
+	name string,
+) (models.Notebookable, error) {
+	return h.ac.PostSiteNotebook(ctx, h.siteID, name)
+}
+
+func (h siteRestoreHandler) postSectionGroup(
+	ctx context.Context,
+	notebookID, parentGroupID, name string,
+) (models.SectionGroupable, error) {
+	return h.ac.PostSiteSectionGroup(ctx, h.siteID, notebookID, parentGroupID, name)
+}
+
+func (h siteRestoreHandler) postSection(
+	ctx context.Context,
+	notebookID, parentGroupID, name string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.PostSiteSection(ctx, h.siteID, notebookID, parentGroupID, name)
+}
+
+func (h siteRestoreHandler) postPage(
+	ctx context.Context,
+	sectionID string,
+	body []byte,
+	contentType string,
+) (models.OnenotePageable, error) {
+	return h.ac.PostSitePage(ctx, h.siteID, sectionID, body, contentType)
+}
+
+func (h siteRestoreHandler) deletePage(
+	ctx context.Context,
+	pageID string,
+) error {
+	return h.ac.DeleteSitePage(ctx, h.siteID, pageID)
+}
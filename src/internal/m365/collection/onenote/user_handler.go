@@ -0,0 +1,166 @@
+package onenote
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// ---------------------------------------------------------------------------
+// Base
+// ---------------------------------------------------------------------------
+
+type baseUserHandler struct {
+	ac     api.OneNote
+	userID string
+}
+
+func (h baseUserHandler) getSectionGroups(
+	ctx context.Context,
+) ([]models.SectionGroupable, error) {
+	return h.ac.GetUserSectionGroups(ctx, h.userID)
+}
+
+func (h baseUserHandler) getSections(
+	ctx context.Context,
+) ([]models.OnenoteSectionable, error) {
+	return h.ac.GetUserSections(ctx, h.userID)
+}
+
+func (h baseUserHandler) getPages(
+	ctx context.Context,
+	sectionID string,
+) ([]models.OnenotePageable, error) {
+	return h.ac.GetUserPages(ctx, h.userID, sectionID)
+}
+
+// ---------------------------------------------------------------------------
+// Backup
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = &userBackupHandler{}
+
+type userBackupHandler struct {
+	baseUserHandler
+	scope selectors.OneDriveScope
+}
+
+func NewUserBackupHandler(
+	ac api.OneNote,
+	userID string,
+	scope selectors.OneDriveScope,
+) userBackupHandler {
+	return userBackupHandler{
+		baseUserHandler: baseUserHandler{
+			ac:     ac,
+			userID: userID,
+		},
+		scope: scope,
+	}
+}
+
+func (h userBackupHandler) getPageContent(
+	ctx context.Context,
+	pageID string,
+) ([]byte, error) {
+	return h.ac.GetUserPageContent(ctx, h.userID, pageID)
+}
+
+func (h userBackupHandler) getResourceContent(
+	ctx context.Context,
+	resourceID string,
+) ([]byte, error) {
+	return h.ac.GetUserResourceContent(ctx, h.userID, resourceID)
+}
+
+func (h userBackupHandler) includeContainer(loc *path.Builder) bool {
+	return h.scope.IsAny(selectors.OneDriveNotebookSection) ||
+		h.scope.Matches(selectors.OneDriveNotebookSection, loc.String())
+}
+
+func (h userBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			h.userID,
+			path.OneDriveService,
+			path.NotebooksCategory,
+			false)
+}
+
+func (h userBackupHandler) service() path.ServiceType {
+	return path.OneDriveService
+}
+
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+var _ restoreHandler = &userRestoreHandler{}
+
+type userRestoreHandler struct {
+	baseUserHandler
+}
+
+func NewUserRestoreHandler(
+	ac api.OneNote,
+	userID string,
+) userRestoreHandler {
+	return userRestoreHandler{
+		baseUserHandler: baseUserHandler{
+			ac:     ac,
+			userID: userID,
+		},
+	}
+}
+
+func (h userRestoreHandler) getNotebooks(
+	ctx context.Context,
+) ([]models.Notebookable, error) {
+	return h.ac.GetUserNotebooks(ctx, h.userID)
+}
+
+func (h userRestoreHandler) postNotebook(
+	ctx context.Context,
+	name string,
+) (models.Notebookable, error) {
+	return h.ac.PostUserNotebook(ctx, h.userID, name)
+}
+
+func (h userRestoreHandler) postSectionGroup(
+	ctx context.Context,
+	notebookID, parentGroupID, name string,
+) (models.SectionGroupable, error) {
+	return h.ac.PostUserSectionGroup(ctx, h.userID, notebookID, parentGroupID, name)
+}
+
+func (h userRestoreHandler) postSection(
+	ctx context.Context,
+	notebookID, parentGroupID, name string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.PostUserSection(ctx, h.userID, notebookID, parentGroupID, name)
+}
+
+func (h userRestoreHandler) postPage(
+	ctx context.Context,
+	sectionID string,
+	body []byte,
+	contentType string,
+) (models.OnenotePageable, error) {
+	return h.ac.PostUserPage(ctx, h.userID, sectionID, body, contentType)
+}
+
+func (h userRestoreHandler) deletePage(
+	ctx context.Context,
+	pageID string,
+) error {
+	return h.ac.DeleteUserPage(ctx, h.userID, pageID)
+}
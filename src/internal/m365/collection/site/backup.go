@@ -29,13 +29,16 @@ import (
 )
 
 // CollectLibraries constructs a onedrive Collections struct and Get()s
-// all the drives associated with the site.
+// all the drives associated with the site.  If notebooksBackedUp is set,
+// onenote packages in the drives are left to the notebooks backup instead
+// of being recorded as skipped items.
 func CollectLibraries(
 	ctx context.Context,
 	bpc inject.BackupProducerConfig,
 	bh drive.BackupHandler,
 	tenantID string,
 	ssmb *prefixmatcher.StringSetMatchBuilder,
+	notebooksBackedUp bool,
 	su support.StatusUpdater,
 	counter *count.Bus,
 	errs *fault.Bus,
@@ -53,6 +56,8 @@ func CollectLibraries(
 			counter)
 	)
 
+	colls.NotebooksBackedUp = notebooksBackedUp
+
 	msg := fmt.Sprintf(
 		"%s (%s)",
 		path.LibrariesCategory.HumanString(),
@@ -97,9 +102,7 @@ func CollectPages(
 	// make the betaClient
 	// Need to receive From DataCollection Call
 	adpt, err := graph.CreateAdapter(
-		creds.AzureTenantID,
-		creds.AzureClientID,
-		creds.AzureClientSecret,
+		creds,
 		counter)
 	if err != nil {
 		return nil, clues.Wrap(err, "creating azure client adapter")
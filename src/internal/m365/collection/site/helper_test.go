@@ -49,9 +49,7 @@ func (ms *MockGraphService) UpdateStatus(*support.ControllerOperationStatus) {
 
 func createTestService(t *testing.T, credentials account.M365Config) *graph.Service {
 	adapter, err := graph.CreateAdapter(
-		credentials.AzureTenantID,
-		credentials.AzureClientID,
-		credentials.AzureClientSecret,
+		credentials,
 		count.New())
 	require.NoError(t, err, "creating microsoft graph service for exchange", clues.ToCore(err))
 
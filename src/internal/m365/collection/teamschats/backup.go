@@ -0,0 +1,284 @@
+package teamschats
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/pii"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// CreateCollections produces one collection for each chat in scope,
+// plus a metadata collection holding the delta tokens and previous
+// paths of every chat for use in the next incremental backup.
+func CreateCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	bh backupHandler,
+	tenantID string,
+	scope selectors.TeamsChatsScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, bool, error) {
+	var (
+		allCollections = make([]data.BackupCollection, 0)
+		category       = scope.Category().PathType()
+		qp             = graph.QueryParams{
+			Category:          category,
+			ProtectedResource: bpc.ProtectedResource,
+			TenantID:          tenantID,
+		}
+	)
+
+	cdps, canUsePreviousBackup, err := parseMetadataCollections(ctx, bpc.MetadataCollections)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx = clues.Add(ctx, "can_use_previous_backup", canUsePreviousBackup)
+
+	cc := api.CallConfig{
+		CanMakeDeltaQueries: bh.canMakeDeltaQueries(),
+	}
+
+	containers, err := bh.getContainers(ctx, cc)
+	if err != nil {
+		return nil, false, clues.Stack(err)
+	}
+
+	counter.Add(count.Chats, int64(len(containers)))
+
+	collections, err := populateCollections(
+		ctx,
+		qp,
+		bh,
+		su,
+		containers,
+		scope,
+		cdps[scope.Category().PathType()],
+		bpc.Options,
+		counter,
+		errs)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "filling collections")
+	}
+
+	for _, coll := range collections {
+		allCollections = append(allCollections, coll)
+	}
+
+	return allCollections, canUsePreviousBackup, nil
+}
+
+func populateCollections(
+	ctx context.Context,
+	qp graph.QueryParams,
+	bh backupHandler,
+	statusUpdater support.StatusUpdater,
+	containers []container,
+	scope selectors.TeamsChatsScope,
+	dps metadata.DeltaPaths,
+	ctrlOpts control.Options,
+	counter *count.Bus,
+	errs *fault.Bus,
+) (map[string]data.BackupCollection, error) {
+	var (
+		// chat ID -> BackupCollection.
+		collections = map[string]data.BackupCollection{}
+		// chat ID -> delta url or folder path lookups
+		deltaURLs = map[string]string{}
+		currPaths = map[string]string{}
+		// copy of previousPaths.  every chat present in the slice param
+		// gets removed from this map; the remaining chats at the end of
+		// the process have been deleted.
+		tombstones = makeTombstones(dps)
+		el         = errs.Local()
+	)
+
+	logger.Ctx(ctx).Infow("filling collections", "len_deltapaths", len(dps))
+
+	for _, c := range containers {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		var (
+			cl          = counter.Local()
+			cID         = ptr.Val(c.container.GetId())
+			err         error
+			dp          = dps[c.storageDirFolders.String()]
+			prevDelta   = dp.Delta
+			prevPathStr = dp.Path // do not log: pii; log prevPath instead
+			prevPath    path.Path
+			ictx        = clues.Add(
+				ctx,
+				"collection_path", c,
+				"previous_delta", pii.SafeURL{
+					URL:           prevDelta,
+					SafePathElems: graph.SafeURLPathParams,
+					SafeQueryKeys: graph.SafeURLQueryParams,
+				})
+		)
+
+		ictx = clues.AddLabelCounter(ictx, cl.PlainAdder())
+
+		delete(tombstones, cID)
+
+		// Only create a collection if the chat matches the scope.
+		if !bh.includeContainer(c.container, scope) {
+			cl.Inc(count.SkippedContainers)
+			continue
+		}
+
+		if len(prevPathStr) > 0 {
+			if prevPath, err = pathFromPrevString(prevPathStr); err != nil {
+				err = clues.StackWC(ctx, err).Label(count.BadPrevPath)
+				logger.CtxErr(ictx, err).Error("parsing prev path")
+				// if the previous path is unusable, then the delta must be, too.
+				prevDelta = ""
+			}
+		}
+
+		ictx = clues.Add(ictx, "previous_path", prevPath)
+
+		cc := api.CallConfig{
+			CanMakeDeltaQueries: bh.canMakeDeltaQueries(),
+		}
+
+		addAndRem, err := bh.getContainerItemIDs(ictx, c.storageDirFolders, prevDelta, cc)
+		if err != nil {
+			el.AddRecoverable(ctx, clues.Stack(err))
+			continue
+		}
+
+		removed := str.SliceToMap(addAndRem.Removed)
+
+		cl.Add(count.ItemsAdded, int64(len(addAndRem.Added)))
+		cl.Add(count.ItemsRemoved, int64(len(removed)))
+
+		if len(addAndRem.DU.URL) > 0 {
+			deltaURLs[c.storageDirFolders.String()] = addAndRem.DU.URL
+		} else if !addAndRem.DU.Reset {
+			logger.Ctx(ictx).Info("missing delta url")
+		}
+
+		currPath, err := bh.canonicalPath(c.storageDirFolders, qp.TenantID)
+		if err != nil {
+			err = clues.StackWC(ctx, err).Label(count.BadCollPath)
+			el.AddRecoverable(ctx, err)
+
+			continue
+		}
+
+		// Remove any deleted IDs from the set of added IDs because items that are
+		// deleted and then restored will have a different ID than they did
+		// originally.
+		for remove := range removed {
+			delete(addAndRem.Added, remove)
+		}
+
+		edc := NewCollection(
+			data.NewBaseCollection(
+				currPath,
+				prevPath,
+				c.humanLocation.Builder(),
+				ctrlOpts,
+				addAndRem.DU.Reset,
+				cl),
+			bh,
+			addAndRem.Added,
+			removed,
+			c,
+			statusUpdater)
+
+		collections[c.storageDirFolders.String()] = edc
+
+		// add the current path for the chat ID to be used in the next backup
+		// as the "previous path", for reference in case of a rename.
+		currPaths[c.storageDirFolders.String()] = currPath.String()
+	}
+
+	// A tombstone is a chat that needs to be marked for deletion.
+	// The only situation where a tombstone should appear is if the chat exists
+	// in the `previousPath` set, but does not exist in the enumeration.  That
+	// happens when the user leaves the chat, or the chat gets deleted.
+	for id, p := range tombstones {
+		if el.Failure() != nil {
+			return nil, el.Failure()
+		}
+
+		var (
+			err  error
+			ictx = clues.Add(ctx, "tombstone_id", id)
+		)
+
+		if collections[id] != nil {
+			err := clues.NewWC(ictx, "conflict: tombstone exists for a live collection").Label(count.CollectionTombstoneConflict)
+			el.AddRecoverable(ctx, err)
+
+			continue
+		}
+
+		if len(p) == 0 {
+			continue
+		}
+
+		prevPath, err := pathFromPrevString(p)
+		if err != nil {
+			err := clues.StackWC(ctx, err).Label(count.BadPrevPath)
+			// technically shouldn't ever happen.  But just in case...
+			logger.CtxErr(ictx, err).Error("parsing tombstone prev path")
+
+			continue
+		}
+
+		collections[id] = data.NewTombstoneCollection(prevPath, ctrlOpts, counter.Local())
+	}
+
+	logger.Ctx(ctx).Infow(
+		"adding metadata collection entries",
+		"num_deltas_entries", len(deltaURLs),
+		"num_paths_entries", len(collections))
+
+	pathPrefix, err := path.BuildMetadata(
+		qp.TenantID,
+		qp.ProtectedResource.ID(),
+		path.TeamsChatsService,
+		qp.Category,
+		false)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata path prefix").
+			Label(count.BadPathPrefix)
+	}
+
+	col, err := graph.MakeMetadataCollection(
+		pathPrefix,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(metadata.PreviousPathFileName, currPaths),
+			graph.NewMetadataEntry(metadata.DeltaURLsFileName, deltaURLs),
+		},
+		statusUpdater,
+		counter.Local())
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making metadata collection")
+	}
+
+	collections["metadata"] = col
+
+	return collections, el.Failure()
+}
@@ -0,0 +1,316 @@
+package teamschats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	inMock "github.com/alcionai/corso/src/internal/common/idname/mock"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// mocks
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = &mockBackupHandler{}
+
+type mockBackupHandler struct {
+	chats         []models.Chatable
+	messageIDs    []string
+	deletedMsgIDs []string
+	messagesErr   error
+	messages      map[string]models.ChatMessageable
+	info          map[string]*details.TeamsChatsInfo
+	getMessageErr map[string]error
+	doNotInclude  bool
+}
+
+func (bh mockBackupHandler) augmentItemInfo(
+	*details.TeamsChatsInfo,
+	models.Chatable,
+) {
+	// no-op
+}
+
+func (bh mockBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+func (bh mockBackupHandler) containers() []container {
+	containers := make([]container, 0, len(bh.chats))
+
+	for _, ch := range bh.chats {
+		containers = append(containers, chatContainer(ch))
+	}
+
+	return containers
+}
+
+func (bh mockBackupHandler) getContainers(
+	context.Context,
+	api.CallConfig,
+) ([]container, error) {
+	return bh.containers(), nil
+}
+
+func (bh mockBackupHandler) getContainerItemIDs(
+	_ context.Context,
+	_ path.Elements,
+	_ string,
+	_ api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	idRes := make(map[string]time.Time, len(bh.messageIDs))
+
+	for _, id := range bh.messageIDs {
+		idRes[id] = time.Time{}
+	}
+
+	aar := pagers.AddedAndRemoved{
+		Added:         idRes,
+		Removed:       bh.deletedMsgIDs,
+		ValidModTimes: true,
+		DU:            pagers.DeltaUpdate{},
+	}
+
+	return aar, bh.messagesErr
+}
+
+func (bh mockBackupHandler) includeContainer(
+	models.Chatable,
+	selectors.TeamsChatsScope,
+) bool {
+	return !bh.doNotInclude
+}
+
+func (bh mockBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			"protectedResource",
+			path.TeamsChatsService,
+			path.ChatsCategory,
+			false)
+}
+
+func (bh mockBackupHandler) getItem(
+	_ context.Context,
+	_ path.Elements,
+	itemID string,
+) (models.ChatMessageable, *details.TeamsChatsInfo, error) {
+	return bh.messages[itemID], bh.info[itemID], bh.getMessageErr[itemID]
+}
+
+func stubChats(ids ...string) []models.Chatable {
+	chats := make([]models.Chatable, 0, len(ids))
+
+	for _, id := range ids {
+		ch := models.NewChat()
+		ch.SetId(ptr.To(id))
+		ch.SetTopic(ptr.To(id))
+
+		chats = append(chats, ch)
+	}
+
+	return chats
+}
+
+// ---------------------------------------------------------------------------
+// Unit Suite
+// ---------------------------------------------------------------------------
+
+type BackupUnitSuite struct {
+	tester.Suite
+	creds account.M365Config
+}
+
+func TestBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &BackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *BackupUnitSuite) SetupSuite() {
+	a := tconfig.NewFakeM365Account(suite.T())
+	m365, err := a.M365Config()
+	require.NoError(suite.T(), err, clues.ToCore(err))
+	suite.creds = m365
+}
+
+func (suite *BackupUnitSuite) TestPopulateCollections() {
+	var (
+		qp = graph.QueryParams{
+			Category:          path.ChatsCategory,
+			ProtectedResource: inMock.NewProvider("user_id", "user_name"),
+			TenantID:          suite.creds.AzureTenantID,
+		}
+		statusUpdater = func(*support.ControllerOperationStatus) {}
+		allScope      = selectors.NewTeamsChatsBackup(nil).Chats(selectors.Any())[0]
+	)
+
+	chatPath, err := path.Build("tid", "usr", path.TeamsChatsService, path.ChatsCategory, false, "chat")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name                string
+		mock                mockBackupHandler
+		deltaPaths          metadata.DeltaPaths
+		expectErr           require.ErrorAssertionFunc
+		expectColls         int
+		expectNewColls      int
+		expectTombstoneCols int
+	}{
+		{
+			name: "one chat",
+			mock: mockBackupHandler{
+				chats:      stubChats("chat"),
+				messageIDs: []string{"msg"},
+			},
+			expectErr:      require.NoError,
+			expectColls:    2,
+			expectNewColls: 1,
+		},
+		{
+			name: "many chats",
+			mock: mockBackupHandler{
+				chats:      stubChats("chat", "chat2"),
+				messageIDs: []string{"msg"},
+			},
+			expectErr:      require.NoError,
+			expectColls:    3,
+			expectNewColls: 2,
+		},
+		{
+			name: "no chats pass scope",
+			mock: mockBackupHandler{
+				chats:        stubChats("chat"),
+				doNotInclude: true,
+			},
+			expectErr:   require.NoError,
+			expectColls: 1,
+		},
+		{
+			name: "err: getting messages",
+			mock: mockBackupHandler{
+				chats:       stubChats("chat"),
+				messagesErr: assert.AnError,
+			},
+			expectErr:   require.Error,
+			expectColls: 1,
+		},
+		{
+			name: "incremental",
+			mock: mockBackupHandler{
+				chats:         stubChats("chat"),
+				deletedMsgIDs: []string{"msg"},
+			},
+			deltaPaths: metadata.DeltaPaths{
+				"chat": {
+					Delta: "delta",
+					Path:  chatPath.String(),
+				},
+			},
+			expectErr:   require.NoError,
+			expectColls: 2,
+		},
+		{
+			name: "incremental, user left the chat",
+			mock: mockBackupHandler{
+				chats: stubChats(),
+			},
+			deltaPaths: metadata.DeltaPaths{
+				"chat": {
+					Delta: "delta",
+					Path:  chatPath.String(),
+				},
+			},
+			expectErr:           require.NoError,
+			expectColls:         2,
+			expectTombstoneCols: 1,
+		},
+		{
+			name: "incremental, new and removed chat",
+			mock: mockBackupHandler{
+				chats:      stubChats("chat2"),
+				messageIDs: []string{"msg"},
+			},
+			deltaPaths: metadata.DeltaPaths{
+				"chat": {
+					Delta: "delta",
+					Path:  chatPath.String(),
+				},
+			},
+			expectErr:           require.NoError,
+			expectColls:         3,
+			expectNewColls:      1,
+			expectTombstoneCols: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ctrlOpts := control.Options{FailureHandling: control.FailFast}
+
+			collections, err := populateCollections(
+				ctx,
+				qp,
+				test.mock,
+				statusUpdater,
+				test.mock.containers(),
+				allScope,
+				test.deltaPaths,
+				ctrlOpts,
+				count.New(),
+				fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Len(t, collections, test.expectColls, "number of collections")
+
+			tombstones, news, metadatas := 0, 0, 0
+			for _, c := range collections {
+				if c.FullPath() != nil && c.FullPath().Service() == path.TeamsChatsMetadataService {
+					metadatas++
+					continue
+				}
+
+				if c.State() == data.DeletedState {
+					tombstones++
+				}
+
+				if c.State() == data.NewState {
+					news++
+				}
+			}
+
+			assert.Equal(t, test.expectNewColls, news, "new collections")
+			assert.Equal(t, test.expectTombstoneCols, tombstones, "tombstone collections")
+			assert.Equal(t, 1, metadatas, "metadata collections")
+		})
+	}
+}
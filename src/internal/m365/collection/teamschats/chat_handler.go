@@ -0,0 +1,119 @@
+package teamschats
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+var _ backupHandler = &chatsBackupHandler{}
+
+type chatsBackupHandler struct {
+	ac                api.Chats
+	protectedResource string
+}
+
+func NewChatBackupHandler(
+	protectedResource string,
+	ac api.Chats,
+) chatsBackupHandler {
+	return chatsBackupHandler{
+		ac:                ac,
+		protectedResource: protectedResource,
+	}
+}
+
+func (bh chatsBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+func (bh chatsBackupHandler) getContainers(
+	ctx context.Context,
+	_ api.CallConfig,
+) ([]container, error) {
+	chats, err := bh.ac.GetChats(ctx, bh.protectedResource)
+	results := make([]container, 0, len(chats))
+
+	for _, ch := range chats {
+		results = append(results, chatContainer(ch))
+	}
+
+	return results, clues.Stack(err).OrNil()
+}
+
+func (bh chatsBackupHandler) getContainerItemIDs(
+	ctx context.Context,
+	containerPath path.Elements,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return bh.ac.GetChatMessageIDs(
+		ctx,
+		containerPath[0],
+		prevDelta,
+		cc)
+}
+
+// chats can be selected by either their display name or their ID,
+// since most chats don't have a name of their own.
+func (bh chatsBackupHandler) includeContainer(
+	ch models.Chatable,
+	scope selectors.TeamsChatsScope,
+) bool {
+	return scope.Matches(selectors.TeamsChatsChat, api.ChatDisplayName(ch)) ||
+		scope.Matches(selectors.TeamsChatsChat, ptr.Val(ch.GetId()))
+}
+
+func (bh chatsBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			bh.protectedResource,
+			path.TeamsChatsService,
+			path.ChatsCategory,
+			false)
+}
+
+func (bh chatsBackupHandler) PathPrefix(tenantID string) (path.Path, error) {
+	return path.Build(
+		tenantID,
+		bh.protectedResource,
+		path.TeamsChatsService,
+		path.ChatsCategory,
+		false)
+}
+
+func (bh chatsBackupHandler) getItem(
+	ctx context.Context,
+	containerIDs path.Elements,
+	messageID string,
+) (models.ChatMessageable, *details.TeamsChatsInfo, error) {
+	return bh.ac.GetChatMessage(ctx, containerIDs[0], messageID)
+}
+
+func (bh chatsBackupHandler) augmentItemInfo(
+	dtci *details.TeamsChatsInfo,
+	ch models.Chatable,
+) {
+	dtci.Chat = api.ChatInfo(ch)
+}
+
+func chatContainer(ch models.Chatable) container {
+	return container{
+		storageDirFolders: path.Elements{ptr.Val(ch.GetId())},
+		humanLocation:     path.Elements{api.ChatDisplayName(ch)},
+		container:         ch,
+	}
+}
@@ -0,0 +1,257 @@
+package teamschats
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// TranscriptFileName is the name of the html file holding a chat's
+// messages in the default export format.
+const TranscriptFileName = "transcript.html"
+
+// NewExportCollection produces a collection that exports the messages of
+// a single chat.  By default, the messages are written out as a single
+// html transcript, ordered by the time they were sent.  If json was
+// requested, every message is written to its own file instead.
+func NewExportCollection(
+	baseDir, chatName string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               cec,
+		Stream: func(
+			ctx context.Context,
+			drc []data.RestoreCollection,
+			backupVersion int,
+			cec control.ExportConfig,
+			ch chan<- export.Item,
+			stats *metrics.ExportStats,
+		) {
+			if cec.Format == control.JSONFormat {
+				streamItems(ctx, drc, ch, stats)
+				return
+			}
+
+			streamTranscript(ctx, chatName, drc, ch, stats)
+		},
+		Stats: stats,
+	}
+}
+
+// streamItems streams each message in the backing collections as json.
+func streamItems(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		for item := range rc.Items(ctx, errs) {
+			stats.UpdateResourceCount(path.ChatsCategory)
+			body := metrics.ReaderWithStats(item.ToReader(), path.ChatsCategory, stats)
+
+			ch <- export.Item{
+				ID:   item.ID(),
+				Name: item.ID() + ".json",
+				Body: body,
+			}
+		}
+
+		sendErrs(ch, errs)
+	}
+}
+
+// streamTranscript reads every message in the backing collections and
+// streams them as a single html document.
+func streamTranscript(
+	ctx context.Context,
+	chatName string,
+	drc []data.RestoreCollection,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	var (
+		errs = fault.New(false)
+		msgs = []models.ChatMessageable{}
+	)
+
+	for _, rc := range drc {
+		ictx := clues.Add(ctx, "path_short_ref", rc.FullPath().ShortRef())
+
+		for item := range rc.Items(ictx, errs) {
+			msg, err := readChatMessage(item.ToReader())
+			if err != nil {
+				logger.CtxErr(ictx, err).Info("processing collection item")
+
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(path.ChatsCategory)
+
+			msgs = append(msgs, msg)
+		}
+
+		sendErrs(ch, errs)
+	}
+
+	if len(msgs) == 0 {
+		return
+	}
+
+	bs, err := formatTranscript(chatName, msgs)
+	if err != nil {
+		ch <- export.Item{
+			ID:    TranscriptFileName,
+			Error: err,
+		}
+
+		return
+	}
+
+	ch <- export.Item{
+		ID:   TranscriptFileName,
+		Name: TranscriptFileName,
+		Body: metrics.ReaderWithStats(io.NopCloser(bytes.NewReader(bs)), path.ChatsCategory, stats),
+	}
+}
+
+// sendErrs returns all the items that we failed to source from the
+// persistence layer.
+func sendErrs(ch chan<- export.Item, errs *fault.Bus) {
+	items, recovered := errs.ItemsAndRecovered()
+
+	for _, item := range items {
+		ch <- export.Item{
+			ID:    item.ID,
+			Error: &item,
+		}
+	}
+
+	for _, err := range recovered {
+		ch <- export.Item{
+			Error: err,
+		}
+	}
+}
+
+func readChatMessage(rc io.ReadCloser) (models.ChatMessageable, error) {
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading item bytes")
+	}
+
+	msg, err := api.BytesToChatMessageable(bs)
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing bytes to message")
+	}
+
+	return msg, nil
+}
+
+// ---------------------------------------------------------------------------
+// html transcript
+// ---------------------------------------------------------------------------
+
+type (
+	transcript struct {
+		Name     string
+		Messages []transcriptMessage
+	}
+
+	transcriptMessage struct {
+		From        string
+		Sent        string
+		Content     template.HTML
+		Attachments []string
+	}
+)
+
+var transcriptTmpl = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{range .Messages}}<div class="message">
+<p><strong>{{.From}}</strong> <em>{{.Sent}}</em></p>
+<div class="content">{{.Content}}</div>
+{{range .Attachments}}<p class="attachment">[attachment: {{.}}]</p>
+{{end}}</div>
+{{end}}</body>
+</html>
+`))
+
+// formatTranscript renders the messages, oldest first, as an html page.
+// Message bodies are already html, and are embedded as-is.
+func formatTranscript(chatName string, msgs []models.ChatMessageable) ([]byte, error) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return ptr.Val(msgs[i].GetCreatedDateTime()).
+			Before(ptr.Val(msgs[j].GetCreatedDateTime()))
+	})
+
+	t := transcript{
+		Name:     chatName,
+		Messages: make([]transcriptMessage, 0, len(msgs)),
+	}
+
+	for _, msg := range msgs {
+		var content string
+
+		if msg.GetBody() != nil {
+			content = ptr.Val(msg.GetBody().GetContent())
+		}
+
+		t.Messages = append(t.Messages, transcriptMessage{
+			From:        api.GetChatMessageFrom(msg),
+			Sent:        dttm.FormatToTabularDisplay(ptr.Val(msg.GetCreatedDateTime())),
+			Content:     template.HTML(content), //nolint:gosec
+			Attachments: api.GetChatMessageAttachmentNames(msg),
+		})
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := transcriptTmpl.Execute(buf, t); err != nil {
+		return nil, clues.Wrap(err, "rendering chat transcript")
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,137 @@
+package teamschats
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func stubChatMessage(id, from, content string, created time.Time) models.ChatMessageable {
+	body := models.NewItemBody()
+	body.SetContent(ptr.To(content))
+
+	iden := models.NewIdentity()
+	iden.SetDisplayName(ptr.To(from))
+
+	fromSet := models.NewChatMessageFromIdentitySet()
+	fromSet.SetUser(iden)
+
+	msg := models.NewChatMessage()
+	msg.SetId(ptr.To(id))
+	msg.SetBody(body)
+	msg.SetFrom(fromSet)
+	msg.SetCreatedDateTime(ptr.To(created))
+
+	return msg
+}
+
+func serializeChatMessage(t *testing.T, msg models.ChatMessageable) []byte {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", msg)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
+
+func (suite *ExportUnitSuite) TestFormatTranscript() {
+	var (
+		t    = suite.T()
+		now  = time.Now()
+		msgs = []models.ChatMessageable{
+			stubChatMessage("2", "zim", "<p>second</p>", now),
+			stubChatMessage("1", "gir", "<p>first</p>", now.Add(-time.Hour)),
+		}
+	)
+
+	bs, err := formatTranscript("Invader <Chat>", msgs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result := string(bs)
+
+	assert.Contains(t, result, "<title>Invader &lt;Chat&gt;</title>", "chat name is escaped")
+	assert.Contains(t, result, "<p>first</p>", "message content is kept as html")
+	assert.Less(
+		t,
+		strings.Index(result, "first"),
+		strings.Index(result, "second"),
+		"messages are ordered by creation time")
+	assert.Contains(t, result, "<strong>gir</strong>")
+}
+
+func (suite *ExportUnitSuite) TestStreamTranscript() {
+	var (
+		t     = suite.T()
+		now   = time.Now()
+		stats = metrics.NewExportStats()
+		coll  = dataMock.Collection{
+			ItemData: []data.Item{
+				&dataMock.Item{
+					ItemID: "1",
+					Reader: io.NopCloser(bytes.NewReader(serializeChatMessage(
+						t,
+						stubChatMessage("1", "gir", "first", now)))),
+				},
+				&dataMock.Item{
+					ItemID: "2",
+					Reader: io.NopCloser(bytes.NewReader(serializeChatMessage(
+						t,
+						stubChatMessage("2", "zim", "second", now)))),
+				},
+			},
+		}
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ch := make(chan export.Item)
+
+	go streamTranscript(ctx, "chat", []data.RestoreCollection{coll}, ch, stats)
+
+	items := []export.Item{}
+
+	for i := range ch {
+		require.NoError(t, i.Error, clues.ToCore(i.Error))
+		items = append(items, i)
+	}
+
+	require.Len(t, items, 1, "one transcript per chat")
+	assert.Equal(t, TranscriptFileName, items[0].Name)
+
+	bs, err := io.ReadAll(items[0].Body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Contains(t, string(bs), "first")
+	assert.Contains(t, string(bs), "second")
+	assert.Equal(t, int64(2), stats.GetStats()[path.ChatsCategory].ResourceCount)
+}
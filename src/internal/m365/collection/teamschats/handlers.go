@@ -0,0 +1,93 @@
+package teamschats
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+type backupHandler interface {
+	getItemAndAugmentInfoer
+	getContainerser
+	getContainerItemIDser
+	includeContainerer
+	canonicalPather
+	canMakeDeltaQuerieser
+}
+
+type getItemAndAugmentInfoer interface {
+	getItemer
+	augmentItemInfoer
+}
+
+type augmentItemInfoer interface {
+	// augmentItemInfo completes the teamsChatsInfo population with any data
+	// owned by the chat and not accessible to the message.
+	augmentItemInfo(*details.TeamsChatsInfo, models.Chatable)
+}
+
+type getItemer interface {
+	getItem(
+		ctx context.Context,
+		containerIDs path.Elements,
+		itemID string,
+	) (models.ChatMessageable, *details.TeamsChatsInfo, error)
+}
+
+// gets all chats for the user
+type getContainerser interface {
+	getContainers(
+		ctx context.Context,
+		cc api.CallConfig,
+	) ([]container, error)
+}
+
+// gets all message IDs (by delta, if possible) in the chat
+type getContainerItemIDser interface {
+	getContainerItemIDs(
+		ctx context.Context,
+		containerPath path.Elements,
+		prevDelta string,
+		cc api.CallConfig,
+	) (pagers.AddedAndRemoved, error)
+}
+
+// includeContainer evaluates whether the chat is included
+// in the provided scope.
+type includeContainerer interface {
+	includeContainer(
+		c models.Chatable,
+		scope selectors.TeamsChatsScope,
+	) bool
+}
+
+// canonicalPath constructs the service and category specific path for
+// the given builder.
+type canonicalPather interface {
+	canonicalPath(
+		storageDir path.Elements,
+		tenantID string,
+	) (path.Path, error)
+}
+
+// canMakeDeltaQueries evaluates whether the handler can support a
+// delta query when enumerating its items.
+type canMakeDeltaQuerieser interface {
+	canMakeDeltaQueries() bool
+}
+
+// ---------------------------------------------------------------------------
+// Container management
+// ---------------------------------------------------------------------------
+
+type container struct {
+	storageDirFolders path.Elements
+	humanLocation     path.Elements
+	container         models.Chatable
+}
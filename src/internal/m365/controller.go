@@ -112,7 +112,7 @@ func (ctrl *Controller) setResourceHandler(
 	var rh *resourceGetter
 
 	switch serviceInOperation {
-	case path.ExchangeService, path.OneDriveService:
+	case path.ExchangeService, path.OneDriveService, path.TeamsChatsService:
 		rh = &resourceGetter{
 			enum:   resource.Users,
 			getter: ctrl.AC.Users(),
@@ -127,6 +127,11 @@ func (ctrl *Controller) setResourceHandler(
 			enum:   resource.Sites,
 			getter: ctrl.AC.Sites(),
 		}
+	case path.EntraIDService:
+		rh = &resourceGetter{
+			enum:   resource.Tenants,
+			getter: ctrl.AC.Directory(),
+		}
 	}
 
 	ctrl.resourceHandler = rh
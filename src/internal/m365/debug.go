@@ -7,8 +7,10 @@ import (
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/entraid"
 	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
 	"github.com/alcionai/corso/src/internal/m365/collection/groups"
+	"github.com/alcionai/corso/src/internal/m365/collection/teamschats"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/store"
@@ -34,6 +36,10 @@ func (ctrl *Controller) DeserializeMetadataFiles(
 		return drive.DeserializeMetadataFiles(ctx, colls, count.New())
 	case path.GroupsService, path.GroupsMetadataService:
 		return groups.DeserializeMetadataFiles(ctx, colls)
+	case path.TeamsChatsService, path.TeamsChatsMetadataService:
+		return teamschats.DeserializeMetadataFiles(ctx, colls)
+	case path.EntraIDService, path.EntraIDMetadataService:
+		return entraid.DeserializeMetadataFiles(ctx, colls)
 	default:
 		return nil, clues.NewWC(ctx, "unrecognized service").With("service", service)
 	}
@@ -11,4 +11,5 @@ const (
 	Users           Category = "users"
 	Sites           Category = "sites"
 	Groups          Category = "groups"
+	Tenants         Category = "tenants"
 )
@@ -0,0 +1,28 @@
+package entraid
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type getIDAndNamer interface {
+	GetIDAndName(ctx context.Context, tenantID string, cc api.CallConfig) (string, string, error)
+}
+
+// IsServiceEnabled reports whether the directory of the tenant can be
+// backed up.  Every tenant has a directory, so this only checks that the
+// tenant can be found.
+func IsServiceEnabled(
+	ctx context.Context,
+	gin getIDAndNamer,
+	resource string,
+) (bool, error) {
+	if _, _, err := gin.GetIDAndName(ctx, resource, api.CallConfig{}); err != nil {
+		return false, clues.Stack(err)
+	}
+
+	return true, nil
+}
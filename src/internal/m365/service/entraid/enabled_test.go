@@ -0,0 +1,69 @@
+package entraid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type EnabledUnitSuite struct {
+	tester.Suite
+}
+
+func TestEnabledUnitSuite(t *testing.T) {
+	suite.Run(t, &EnabledUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var _ getIDAndNamer = mockGIN{}
+
+type mockGIN struct {
+	err error
+}
+
+func (m mockGIN) GetIDAndName(
+	_ context.Context,
+	tenantID string,
+	_ api.CallConfig,
+) (string, string, error) {
+	return tenantID, "contoso", m.err
+}
+
+func (suite *EnabledUnitSuite) TestIsServiceEnabled() {
+	table := []struct {
+		name      string
+		mock      getIDAndNamer
+		expect    assert.BoolAssertionFunc
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "ok",
+			mock:      mockGIN{},
+			expect:    assert.True,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "arbitrary error",
+			mock:      mockGIN{err: assert.AnError},
+			expect:    assert.False,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ok, err := IsServiceEnabled(ctx, test.mock, "tid")
+			test.expect(t, ok, "has directory enabled")
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
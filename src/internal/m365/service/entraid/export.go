@@ -0,0 +1,206 @@
+package entraid
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/entraid"
+	"github.com/alcionai/corso/src/internal/m365/resource"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ inject.ServiceHandler = &entraIDHandler{}
+
+func NewEntraIDHandler(
+	apiClient api.Client,
+	resourceGetter idname.GetResourceIDAndNamer,
+) *entraIDHandler {
+	return &entraIDHandler{
+		baseEntraIDHandler: baseEntraIDHandler{},
+		apiClient:          apiClient,
+		resourceGetter:     resourceGetter,
+	}
+}
+
+// ========================================================================== //
+//                           baseEntraIDHandler
+// ========================================================================== //
+
+// baseEntraIDHandler contains logic for tracking data and doing operations
+// (e.x. export) that don't require contact with external M356 services.
+type baseEntraIDHandler struct{}
+
+func (h *baseEntraIDHandler) CacheItemInfo(v details.ItemInfo) {}
+
+// ProduceExportCollections will create the export collections for the
+// given restore collections.
+func (h *baseEntraIDHandler) ProduceExportCollections(
+	ctx context.Context,
+	backupVersion int,
+	exportCfg control.ExportConfig,
+	dcs []data.RestoreCollection,
+	stats *metrics.ExportStats,
+	errs *fault.Bus,
+) ([]export.Collectioner, error) {
+	var (
+		el = errs.Local()
+		ec = make([]export.Collectioner, 0, len(dcs))
+	)
+
+	for _, restoreColl := range dcs {
+		var (
+			fp      = restoreColl.FullPath()
+			cat     = fp.Category()
+			folders = append([]string{cat.HumanString()}, fp.Folders()...)
+		)
+
+		if cat != path.DirectoryObjectsCategory {
+			el.AddRecoverable(
+				ctx,
+				clues.New("unsupported category for export").With("category", cat))
+
+			continue
+		}
+
+		ec = append(
+			ec,
+			entraid.NewExportCollection(
+				path.Builder{}.Append(folders...).String(),
+				[]data.RestoreCollection{restoreColl},
+				backupVersion,
+				exportCfg,
+				stats))
+	}
+
+	return ec, el.Failure()
+}
+
+// ========================================================================== //
+//                             entraIDHandler
+// ========================================================================== //
+
+// entraIDHandler contains logic for handling data and performing operations
+// (e.x. restore) regardless of whether they require contact with external M365
+// services or not.
+type entraIDHandler struct {
+	baseEntraIDHandler
+	apiClient      api.Client
+	resourceGetter idname.GetResourceIDAndNamer
+}
+
+func (h *entraIDHandler) IsServiceEnabled(
+	ctx context.Context,
+	resourceID string,
+) (bool, error) {
+	res, err := IsServiceEnabled(ctx, h.apiClient.Directory(), resourceID)
+	return res, clues.Stack(err).OrNil()
+}
+
+func (h *entraIDHandler) PopulateProtectedResourceIDAndName(
+	ctx context.Context,
+	resourceID string, // Can be either ID or name.
+	ins idname.Cacher,
+) (idname.Provider, error) {
+	if h.resourceGetter == nil {
+		return nil, clues.StackWC(ctx, resource.ErrNoResourceLookup)
+	}
+
+	pr, err := h.resourceGetter.GetResourceIDAndNameFrom(ctx, resourceID, ins)
+
+	return pr, clues.Wrap(err, "identifying resource owner").OrNil()
+}
+
+// ConsumeRestoreCollections restores directory objects in place.  Objects
+// keep their IDs, so they can only be restored into the tenant they were
+// backed up from.  Deleted objects are recovered from the directory's
+// recycle bin, and missing group memberships are re-added.
+func (h *entraIDHandler) ConsumeRestoreCollections(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (*details.Details, *data.CollectionStats, error) {
+	if len(dcs) == 0 {
+		return nil, nil, clues.WrapWC(ctx, data.ErrNoData, "performing restore")
+	}
+
+	ctx = graph.BindRateLimiterConfig(
+		ctx,
+		graph.LimiterCfg{Service: path.EntraIDService})
+
+	var (
+		deets   = &details.Builder{}
+		rh      = h.apiClient.Directory()
+		metrics support.CollectionMetrics
+		el      = errs.Local()
+	)
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		var (
+			fp   = dc.FullPath()
+			ictx = clues.Add(
+				ctx,
+				"restore_category", fp.Category(),
+				"restore_full_path", fp)
+		)
+
+		if fp.Category() != path.DirectoryObjectsCategory {
+			el.AddRecoverable(ictx, clues.NewWC(ictx, "unsupported restore path category"))
+			continue
+		}
+
+		if fp.ProtectedResource() != rcc.ProtectedResource.ID() {
+			el.AddRecoverable(
+				ictx,
+				clues.NewWC(ictx, "directory objects can only be restored to the tenant they were backed up from").
+					With("restore_protected_resource", rcc.ProtectedResource.ID()))
+
+			continue
+		}
+
+		temp, err := entraid.RestoreCollection(
+			ictx,
+			rh,
+			dc,
+			deets,
+			errs,
+			ctr)
+
+		metrics = support.CombineMetrics(metrics, temp)
+
+		if err != nil {
+			if graph.IsErrTimeout(err) {
+				break
+			}
+
+			el.AddRecoverable(ictx, err)
+		}
+	}
+
+	status := support.CreateStatus(
+		ctx,
+		support.Restore,
+		len(dcs),
+		metrics,
+		rcc.RestoreConfig.Location)
+
+	return deets.Details(), status.ToCollectionStats(), el.Failure()
+}
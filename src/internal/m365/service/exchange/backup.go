@@ -72,16 +72,31 @@ func ProduceBackupCollections(
 			break
 		}
 
-		dcs, err := exchange.CreateCollections(
-			ctx,
-			bpc,
-			handlers,
-			tenantID,
-			scope,
-			cdps[scope.Category().PathType()],
-			su,
-			counter,
-			errs)
+		var dcs []data.BackupCollection
+
+		switch scope.Category().PathType() {
+		case path.MailboxSettingsCategory:
+			dcs, err = exchange.CreateSettingsCollections(
+				ctx,
+				bpc,
+				ac.MailboxSettings(),
+				tenantID,
+				scope,
+				su,
+				counter)
+		default:
+			dcs, err = exchange.CreateCollections(
+				ctx,
+				bpc,
+				handlers,
+				tenantID,
+				scope,
+				cdps[scope.Category().PathType()],
+				su,
+				counter,
+				errs)
+		}
+
 		if err != nil {
 			el.AddRecoverable(ctx, err)
 			continue
@@ -2,8 +2,10 @@ package exchange
 
 import (
 	"context"
+	"slices"
 
 	"github.com/alcionai/clues"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/data"
@@ -55,29 +57,83 @@ func (h *baseExchangeHandler) ProduceExportCollections(
 	var (
 		el = errs.Local()
 		ec = make([]export.Collectioner, 0, len(dcs))
+		// pst files hold the entire mailbox of each protected resource.
+		pstColls = map[string][]data.RestoreCollection{}
 	)
 
 	for _, dc := range dcs {
 		category := dc.FullPath().Category()
 
 		switch category {
-		case path.ContactsCategory, path.EmailCategory, path.EventsCategory:
-			folders := dc.FullPath().Folders()
-			pth := path.Builder{}.Append(category.HumanString()).Append(folders...)
+		case path.ContactsCategory, path.EmailCategory, path.EventsCategory, path.MailboxSettingsCategory:
+		default:
+			return nil, clues.NewWC(ctx, "data category not supported").
+				With("category", category)
+		}
+
+		folders := dc.FullPath().Folders()
 
+		switch {
+		// settings aren't mailbox items, and are always exported as json,
+		// regardless of the format of the other items.
+		case category == path.MailboxSettingsCategory:
 			ec = append(
 				ec,
 				exchange.NewExportCollection(
+					category.HumanString(),
+					[]data.RestoreCollection{dc},
+					backupVersion,
+					stats))
+
+		case exportCfg.Format == control.PSTFormat:
+			pr := dc.FullPath().ProtectedResource()
+			pstColls[pr] = append(pstColls[pr], dc)
+
+		case exportCfg.Format == control.MBOXFormat &&
+			category == path.EmailCategory &&
+			len(folders) > 0:
+			// each mail folder becomes an mbox file, named after the
+			// folder, and placed alongside its parent's mbox.
+			pth := path.Builder{}.
+				Append(category.HumanString()).
+				Append(folders[:len(folders)-1]...)
+
+			ec = append(
+				ec,
+				exchange.NewMBOXExportCollection(
 					pth.String(),
+					folders[len(folders)-1],
 					[]data.RestoreCollection{dc},
 					backupVersion,
 					stats))
+
 		default:
-			return nil, clues.NewWC(ctx, "data category not supported").
-				With("category", category)
+			pth := path.Builder{}.Append(category.HumanString()).Append(folders...)
+
+			ec = append(
+				ec,
+				exchange.NewExportCollection(
+					pth.String(),
+					[]data.RestoreCollection{dc},
+					backupVersion,
+					stats))
 		}
 	}
 
+	prs := maps.Keys(pstColls)
+	slices.Sort(prs)
+
+	for _, pr := range prs {
+		ec = append(
+			ec,
+			exchange.NewPSTExportCollection(
+				"",
+				pr,
+				pstColls[pr],
+				backupVersion,
+				stats))
+	}
+
 	return ec, el.Failure()
 }
 
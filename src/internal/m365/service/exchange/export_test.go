@@ -3,10 +3,13 @@ package exchange
 import (
 	"bytes"
 	"io"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/converters/eml/testdata"
@@ -460,3 +463,240 @@ func (suite *ExportUnitSuite) TestExportRestoreCollections() {
 		})
 	}
 }
+
+// mboxSeparator matches the "From " line which starts each mbox message.
+var mboxSeparator = regexp.MustCompile(`(?m)^From `)
+
+func (suite *ExportUnitSuite) TestExportRestoreCollections_MBOX() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	emailBodyBytes := []byte(testdata.EmailWithAttachments)
+
+	inbox, err := path.Builder{}.
+		Append("Inbox").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.EmailCategory, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	sub, err := path.Builder{}.
+		Append("Inbox", "Sub").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.EmailCategory, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	contacts, err := path.Builder{}.
+		Append("Contacts").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.ContactsCategory, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	dcs := []data.RestoreCollection{
+		data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: inbox,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "id1",
+						Reader: io.NopCloser(bytes.NewReader(emailBodyBytes)),
+					},
+					&dataMock.Item{
+						ItemID:  "id2",
+						ReadErr: assert.AnError,
+					},
+					&dataMock.Item{
+						ItemID: "id3",
+						Reader: io.NopCloser(bytes.NewReader(emailBodyBytes)),
+					},
+				},
+			},
+		},
+		data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: sub,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "id4",
+						Reader: io.NopCloser(bytes.NewReader(emailBodyBytes)),
+					},
+				},
+			},
+		},
+		data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: contacts,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "id5",
+						Reader: io.NopCloser(bytes.NewReader(exchMock.ContactBytes("contact"))),
+					},
+				},
+			},
+		},
+	}
+
+	ecs, err := NewExchangeHandler(api.Client{}, nil).
+		ProduceExportCollections(
+			ctx,
+			int(version.Backup),
+			control.ExportConfig{Format: control.MBOXFormat},
+			dcs,
+			metrics.NewExportStats(),
+			fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, ecs, 3, "num of collections")
+
+	expect := []struct {
+		basePath string
+		name     string
+		messages int
+		errs     int
+	}{
+		{"Emails", "Inbox.mbox", 2, 1},
+		{"Emails/Inbox", "Sub.mbox", 1, 0},
+		{"Contacts/Contacts", "id5.vcf", 0, 0},
+	}
+
+	for i, ec := range ecs {
+		assert.Equal(t, expect[i].basePath, ec.BasePath(), "base path")
+
+		var names []string
+
+		errCount := 0
+
+		for item := range ec.Items(ctx) {
+			if item.Error != nil {
+				errCount++
+				continue
+			}
+
+			names = append(names, item.Name)
+
+			b, err := io.ReadAll(item.Body)
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = item.Body.Close()
+			require.NoError(t, err, clues.ToCore(err))
+
+			if strings.HasSuffix(item.Name, ".mbox") {
+				assert.Equal(
+					t,
+					expect[i].messages,
+					len(mboxSeparator.FindAll(b, -1)),
+					"messages in mbox")
+			}
+		}
+
+		assert.Equal(t, []string{expect[i].name}, names, "item names")
+		assert.Equal(t, expect[i].errs, errCount, "errored items")
+	}
+}
+
+func (suite *ExportUnitSuite) TestExportRestoreCollections_PST() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	emailBodyBytes := []byte(testdata.EmailWithAttachments)
+
+	inbox, err := path.Builder{}.
+		Append("Inbox").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.EmailCategory, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	contacts, err := path.Builder{}.
+		Append("Contacts").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.ContactsCategory, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	events, err := path.Builder{}.
+		Append("Calendar").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.EventsCategory, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	dcs := []data.RestoreCollection{
+		data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: inbox,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "id1",
+						Reader: io.NopCloser(bytes.NewReader(emailBodyBytes)),
+					},
+					&dataMock.Item{
+						ItemID:  "id2",
+						ReadErr: assert.AnError,
+					},
+				},
+			},
+		},
+		data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: contacts,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "id3",
+						Reader: io.NopCloser(bytes.NewReader(exchMock.ContactBytes("contact"))),
+					},
+				},
+			},
+		},
+		data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: events,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "id4",
+						Reader: io.NopCloser(bytes.NewReader(exchMock.EventBytes("event"))),
+					},
+				},
+			},
+		},
+	}
+
+	stats := metrics.NewExportStats()
+
+	ecs, err := NewExchangeHandler(api.Client{}, nil).
+		ProduceExportCollections(
+			ctx,
+			int(version.Backup),
+			control.ExportConfig{Format: control.PSTFormat},
+			dcs,
+			stats,
+			fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, ecs, 1, "num of collections")
+	assert.Empty(t, ecs[0].BasePath(), "base path")
+
+	var (
+		items    []export.Item
+		errCount int
+	)
+
+	for item := range ecs[0].Items(ctx) {
+		if item.Error != nil {
+			errCount++
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	assert.Equal(t, 1, errCount, "errored items")
+	require.Len(t, items, 1, "num of items")
+	assert.Equal(t, "r.pst", items[0].Name)
+
+	b, err := io.ReadAll(items[0].Body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = items[0].Body.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "!BDN", string(b[:4]), "pst magic")
+
+	exportStats := stats.GetStats()
+	assert.Equal(t, int64(1), exportStats[path.EmailCategory].ResourceCount)
+	assert.Equal(t, int64(1), exportStats[path.ContactsCategory].ResourceCount)
+	assert.Equal(t, int64(1), exportStats[path.EventsCategory].ResourceCount)
+	assert.Equal(t, int64(len(b)), exportStats[path.EmailCategory].BytesRead)
+}
@@ -63,6 +63,28 @@ func (h *exchangeHandler) ConsumeRestoreCollections(
 				"restore_full_path", dc.FullPath())
 		)
 
+		// settings are applied to the mailbox itself, and aren't
+		// restored into a container.
+		if category == path.MailboxSettingsCategory {
+			temp, err := exchange.RestoreSettings(
+				ictx,
+				h.apiClient.MailboxSettings(),
+				dc,
+				resourceID,
+				rcc.RestoreConfig.OnCollision,
+				deets,
+				errs,
+				ctr)
+
+			metrics = support.CombineMetrics(metrics, temp)
+
+			if err != nil {
+				el.AddRecoverable(ictx, err)
+			}
+
+			continue
+		}
+
 		handler, ok := handlers[category]
 		if !ok {
 			el.AddRecoverable(ictx, clues.NewWC(ictx, "unsupported restore path category"))
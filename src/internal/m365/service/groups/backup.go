@@ -105,6 +105,13 @@ func ProduceBackupCollections(
 				scope,
 				cl,
 				el)
+		case path.PlannerTasksCategory:
+			colls, err = backupPlanner(
+				ictx,
+				bc,
+				scope,
+				cl,
+				el)
 		}
 
 		if err != nil {
@@ -235,6 +242,7 @@ func backupLibraries(
 			bh,
 			bc.creds.AzureTenantID,
 			globalItemIDExclusions,
+			false,
 			bc.statusUpdater,
 			cl,
 			errs)
@@ -372,6 +380,55 @@ func backupConversations(
 	return colls, nil
 }
 
+func backupPlanner(
+	ctx context.Context,
+	bc backupCommon,
+	scope selectors.GroupsScope,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	var (
+		bh = groups.NewPlannerBackupHandler(
+			bc.producerConfig.ProtectedResource.ID(),
+			bc.apiCli.Planner())
+		colls []data.BackupCollection
+	)
+
+	progressMessage := observe.MessageWithCompletion(
+		ctx,
+		observe.ProgressCfg{
+			Indent:            1,
+			CompletionMessage: func() string { return fmt.Sprintf("(found %d plans)", len(colls)) },
+		},
+		scope.Category().PathType().HumanString())
+	defer close(progressMessage)
+
+	colls, canUsePreviousBackup, err := groups.CreatePlannerCollections(
+		ctx,
+		bc.producerConfig,
+		bh,
+		bc.creds.AzureTenantID,
+		scope,
+		bc.statusUpdater,
+		counter,
+		errs)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	if !canUsePreviousBackup {
+		tp, err := bh.PathPrefix(bc.creds.AzureTenantID)
+		if err != nil {
+			err = clues.WrapWC(ctx, err, "getting planner path").Label(count.BadPathPrefix)
+			return nil, err
+		}
+
+		colls = append(colls, data.NewTombstoneCollection(tp, control.Options{}, counter))
+	}
+
+	return colls, nil
+}
+
 // ---------------------------------------------------------------------------
 // metadata
 // ---------------------------------------------------------------------------
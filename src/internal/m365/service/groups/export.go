@@ -32,6 +32,7 @@ func NewGroupsHandler(
 		baseGroupsHandler: baseGroupsHandler{
 			backupDriveIDNames: idname.NewCache(nil),
 			backupSiteIDWebURL: idname.NewCache(nil),
+			postRecipients:     map[string][]string{},
 		},
 		apiClient:      apiClient,
 		resourceGetter: resourceGetter,
@@ -47,6 +48,9 @@ func NewGroupsHandler(
 type baseGroupsHandler struct {
 	backupDriveIDNames idname.CacheBuilder
 	backupSiteIDWebURL idname.CacheBuilder
+	// postRecipients maps conversation topics to the recipients of
+	// their posts, which aren't stored alongside the posts themselves.
+	postRecipients map[string][]string
 }
 
 func (h *baseGroupsHandler) CacheItemInfo(v details.ItemInfo) {
@@ -56,6 +60,10 @@ func (h *baseGroupsHandler) CacheItemInfo(v details.ItemInfo) {
 
 	h.backupDriveIDNames.Add(v.Groups.DriveID, v.Groups.DriveName)
 	h.backupSiteIDWebURL.Add(v.Groups.SiteID, v.Groups.WebURL)
+
+	if v.Groups.ItemType == details.GroupsConversationPost && len(v.Groups.Post.Recipients) > 0 {
+		h.postRecipients[v.Groups.Post.Topic] = v.Groups.Post.Recipients
+	}
 }
 
 // ProduceExportCollections will create the export collections for the
@@ -92,6 +100,37 @@ func (h *baseGroupsHandler) ProduceExportCollections(
 				exportCfg,
 				stats)
 
+		case path.ConversationPostsCategory:
+			var (
+				fds   = fp.Folders()
+				topic string
+			)
+
+			if len(fds) > 0 {
+				topic = fds[len(fds)-1]
+			}
+
+			folders = append(folders, fds...)
+
+			coll = groups.NewConversationExportCollection(
+				path.Builder{}.Append(folders...).String(),
+				topic,
+				h.postRecipients[topic],
+				[]data.RestoreCollection{restoreColl},
+				backupVersion,
+				exportCfg,
+				stats)
+
+		case path.PlannerTasksCategory:
+			folders = append(folders, fp.Folders()...)
+
+			coll = groups.NewPlannerExportCollection(
+				path.Builder{}.Append(folders...).String(),
+				[]data.RestoreCollection{restoreColl},
+				backupVersion,
+				exportCfg,
+				stats)
+
 		case path.LibrariesCategory:
 			drivePath, err := path.ToDrivePath(restoreColl.FullPath())
 			if err != nil {
@@ -8,8 +8,10 @@ import (
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	emlTD "github.com/alcionai/corso/src/internal/converters/eml/testdata"
 	"github.com/alcionai/corso/src/internal/data"
 	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	groupMock "github.com/alcionai/corso/src/internal/m365/service/groups/mock"
@@ -238,3 +240,89 @@ func (suite *ExportUnitSuite) TestExportRestoreCollections_libraries() {
 	expectedStats.UpdateResourceCount(path.FilesCategory)
 	assert.Equal(t, expectedStats.GetStats(), stats.GetStats(), "stats")
 }
+
+func (suite *ExportUnitSuite) TestExportRestoreCollections_conversations() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		itemID    = "postID"
+		topic     = "Quarterly planning"
+		recipient = "marketing@contoso.onmicrosoft.com"
+		exportCfg = control.ExportConfig{}
+
+		dii = details.ItemInfo{
+			Groups: &details.GroupsInfo{
+				ItemType: details.GroupsConversationPost,
+				Post: details.ConversationPostInfo{
+					Recipients: []string{recipient},
+					Topic:      topic,
+				},
+			},
+		}
+
+		expectedPath = path.ConversationPostsCategory.HumanString() + "/" + topic
+	)
+
+	p, err := path.Build("t", "pr", path.GroupsService, path.ConversationPostsCategory, false, topic)
+	require.NoError(t, err, "build path")
+
+	dcs := []data.RestoreCollection{
+		data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: p,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: itemID,
+						Reader: io.NopCloser(bytes.NewBufferString(emlTD.GroupConversationPost)),
+					},
+				},
+			},
+		},
+	}
+
+	handler := NewGroupsHandler(api.Client{}, nil)
+	handler.CacheItemInfo(dii)
+
+	stats := metrics.NewExportStats()
+
+	ecs, err := handler.ProduceExportCollections(
+		ctx,
+		int(version.Backup),
+		exportCfg,
+		dcs,
+		stats,
+		fault.New(true))
+	require.NoError(t, err, "export collections error")
+	require.Len(t, ecs, 1, "num of collections")
+
+	assert.Equal(t, expectedPath, ecs[0].BasePath(), "base dir")
+
+	var (
+		fitems = 0
+		size   = 0
+	)
+
+	for item := range ecs[0].Items(ctx) {
+		require.NoError(t, item.Error, clues.ToCore(item.Error))
+
+		b, err := io.ReadAll(item.Body)
+		require.NoError(t, err, clues.ToCore(err))
+
+		size += len(b)
+		fitems++
+
+		assert.Equal(t, itemID+".eml", item.Name)
+		assert.Contains(t, string(b), "Subject: "+topic)
+		assert.Contains(t, string(b), "To: "+recipient)
+	}
+
+	assert.Equal(t, 1, fitems, "items")
+
+	expectedStats := metrics.NewExportStats()
+	expectedStats.UpdateBytes(path.ConversationPostsCategory, int64(size))
+	expectedStats.UpdateResourceCount(path.ConversationPostsCategory)
+	assert.Equal(t, expectedStats.GetStats(), stats.GetStats(), "stats")
+}
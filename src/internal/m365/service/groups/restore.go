@@ -11,6 +11,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -56,8 +57,13 @@ func (h *groupsHandler) ConsumeRestoreCollections(
 			rcc.Selector.PathService())
 		el                = errs.Local()
 		webURLToSiteNames = map[string]string{}
+		channelCache      = groups.NewChannelRestoreCache()
+		conversationCache = groups.NewConversationRestoreCache()
+		plannerCache      = groups.NewPlannerRestoreCache()
 	)
 
+	caches.ResourceMapping = rcc.RestoreConfig.ResourceMapping
+
 	// Reorder collections so that the parents directories are created
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
@@ -125,8 +131,41 @@ func (h *groupsHandler) ConsumeRestoreCollections(
 				errs,
 				ctr)
 		case path.ChannelMessagesCategory:
-			// Message cannot be restored as of now using Graph API.
-			logger.Ctx(ictx).Debug("Skipping restore for channel messages")
+			metrics, err = groups.RestoreChannelMessages(
+				ictx,
+				h.apiClient.Channels(),
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig.Location,
+				channelCache,
+				rcc.RestoreConfig.OnCollision,
+				deets,
+				errs,
+				ctr)
+		case path.ConversationPostsCategory:
+			metrics, err = groups.RestoreConversationPosts(
+				ictx,
+				h.apiClient.Conversations(),
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig.Location,
+				conversationCache,
+				rcc.RestoreConfig.OnCollision,
+				deets,
+				errs,
+				ctr)
+		case path.PlannerTasksCategory:
+			metrics, err = groups.RestorePlannerTasks(
+				ictx,
+				h.apiClient.Planner(),
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig.Location,
+				plannerCache,
+				rcc.RestoreConfig.OnCollision,
+				deets,
+				errs,
+				ctr)
 		default:
 			return nil, nil, clues.NewWC(ictx, "data category not supported").
 				With("category", category)
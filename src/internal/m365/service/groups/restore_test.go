@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/exp/slices"
 
+	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/data/mock"
@@ -37,7 +38,9 @@ func (suite *GroupsUnitSuite) TestConsumeRestoreCollections_noErrorOnGroups() {
 	ctx, flush := tester.NewContext(t)
 	defer flush()
 
-	rcc := inject.RestoreConsumerConfig{}
+	rcc := inject.RestoreConsumerConfig{
+		ProtectedResource: idname.NewProvider("g", "g"),
+	}
 	pth, err := path.Builder{}.
 		Append("General").
 		ToDataLayerPath(
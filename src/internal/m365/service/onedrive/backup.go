@@ -9,6 +9,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/prefixmatcher"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/onenote"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/internal/operations/inject"
@@ -18,6 +19,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 )
@@ -42,43 +44,74 @@ func ProduceBackupCollections(
 		categories           = map[path.CategoryType]struct{}{}
 		collections          = []data.BackupCollection{}
 		ssmb                 = prefixmatcher.NewStringSetBuilder()
-		odcs                 []data.BackupCollection
-		canUsePreviousBackup bool
+		canUsePreviousBackup = true
+		notebooksInScope     bool
 	)
 
+	for _, scope := range odb.Scopes() {
+		if scope.Category().PathType() == path.NotebooksCategory {
+			notebooksInScope = true
+		}
+	}
+
 	// for each scope that includes oneDrive items, get all
 	for _, scope := range odb.Scopes() {
 		if el.Failure() != nil {
 			break
 		}
 
-		logger.Ctx(ctx).Debug("creating OneDrive collections")
+		var (
+			cat      = scope.Category().PathType()
+			colls    []data.BackupCollection
+			canUsePB bool
+			err      error
+		)
 
-		nc := drive.NewCollections(
-			drive.NewUserDriveBackupHandler(ac.Drives(), bpc.ProtectedResource.ID(), scope),
-			tenantID,
-			bpc.ProtectedResource,
-			su,
-			bpc.Options,
-			counter)
+		switch cat {
+		case path.FilesCategory:
+			colls, canUsePB, err = produceDriveCollections(
+				ctx,
+				bpc,
+				ac,
+				tenantID,
+				scope,
+				notebooksInScope,
+				ssmb,
+				su,
+				counter,
+				errs)
+		case path.NotebooksCategory:
+			colls, canUsePB, err = produceNotebookCollections(
+				ctx,
+				bpc,
+				ac,
+				tenantID,
+				scope,
+				su,
+				counter,
+				errs)
+			if onenote.IsInaccessible(err) {
+				logger.CtxErr(ctx, err).Info("skipping inaccessible notebooks")
+				errs.AddSkip(ctx, onenote.InaccessibleSkip(bpc.ProtectedResource))
+
+				continue
+			}
+		default:
+			continue
+		}
 
-		progressMessage := observe.MessageWithCompletion(
-			ctx,
-			observe.ProgressCfg{
-				Indent:            1,
-				CompletionMessage: func() string { return fmt.Sprintf("(found %d files)", nc.NumFiles) },
-			},
-			path.FilesCategory.HumanString())
-		defer close(progressMessage)
-
-		odcs, canUsePreviousBackup, err = nc.Get(ctx, bpc.MetadataCollections, ssmb, errs)
 		if err != nil {
 			el.AddRecoverable(ctx, clues.Stack(err).Label(fault.LabelForceNoBackupCreation))
 		}
 
-		categories[scope.Category().PathType()] = struct{}{}
+		canUsePreviousBackup = canUsePreviousBackup && canUsePB
+		categories[cat] = struct{}{}
 
-		collections = append(collections, odcs...)
+		collections = append(collections, colls...)
+	}
+
+	if len(categories) == 0 {
+		canUsePreviousBackup = false
 	}
 
 	mcs, err := migrationCollections(bpc, tenantID, su, counter)
@@ -111,6 +144,74 @@ func ProduceBackupCollections(
 	return collections, ssmb.ToReader(), canUsePreviousBackup, el.Failure()
 }
 
+func produceDriveCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	ac api.Client,
+	tenantID string,
+	scope selectors.OneDriveScope,
+	notebooksInScope bool,
+	ssmb *prefixmatcher.StringSetMatchBuilder,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, bool, error) {
+	logger.Ctx(ctx).Debug("creating OneDrive collections")
+
+	nc := drive.NewCollections(
+		drive.NewUserDriveBackupHandler(ac.Drives(), bpc.ProtectedResource.ID(), scope),
+		tenantID,
+		bpc.ProtectedResource,
+		su,
+		bpc.Options,
+		counter)
+
+	// onenote packages in the drive are covered by the notebooks backup.
+	nc.NotebooksBackedUp = notebooksInScope
+
+	progressMessage := observe.MessageWithCompletion(
+		ctx,
+		observe.ProgressCfg{
+			Indent:            1,
+			CompletionMessage: func() string { return fmt.Sprintf("(found %d files)", nc.NumFiles) },
+		},
+		path.FilesCategory.HumanString())
+	defer close(progressMessage)
+
+	// the drive metadata parser doesn't expect notebook metadata.
+	mcs := data.FilterByCategory(bpc.MetadataCollections, path.FilesCategory)
+
+	return nc.Get(ctx, mcs, ssmb, errs)
+}
+
+func produceNotebookCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	ac api.Client,
+	tenantID string,
+	scope selectors.OneDriveScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, bool, error) {
+	logger.Ctx(ctx).Debug("creating OneNote collections")
+
+	progressMessage := observe.MessageWithCompletion(
+		ctx,
+		observe.ProgressCfg{Indent: 1},
+		path.NotebooksCategory.HumanString())
+	defer close(progressMessage)
+
+	return onenote.CreateCollections(
+		ctx,
+		bpc,
+		onenote.NewUserBackupHandler(ac.OneNote(), bpc.ProtectedResource.ID(), scope),
+		tenantID,
+		su,
+		counter,
+		errs)
+}
+
 // adds data migrations to the collection set.
 func migrationCollections(
 	bpc inject.BackupProducerConfig,
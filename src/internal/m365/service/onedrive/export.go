@@ -8,6 +8,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/onenote"
 	"github.com/alcionai/corso/src/internal/m365/resource"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -68,6 +69,23 @@ func (h *baseOneDriveHandler) ProduceExportCollections(
 	)
 
 	for _, dc := range dcs {
+		if dc.FullPath().Category() == path.NotebooksCategory {
+			baseDir := path.Builder{}.
+				Append(path.NotebooksCategory.HumanString()).
+				Append(dc.FullPath().Folders()...)
+
+			ec = append(
+				ec,
+				onenote.NewExportCollection(
+					baseDir.String(),
+					[]data.RestoreCollection{dc},
+					backupVersion,
+					exportCfg,
+					stats))
+
+			continue
+		}
+
 		drivePath, err := path.ToDrivePath(dc.FullPath())
 		if err != nil {
 			return nil, clues.WrapWC(ctx, err, "transforming path to drive path")
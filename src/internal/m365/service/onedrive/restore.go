@@ -9,6 +9,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/onenote"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/internal/version"
@@ -49,8 +50,12 @@ func (h *onedriveHandler) ConsumeRestoreCollections(
 		caches            = drive.NewRestoreCaches(h.backupDriveIDNames)
 		fallbackDriveName = rcc.RestoreConfig.Location
 		rh                = drive.NewUserDriveRestoreHandler(h.apiClient)
+		nrh               = onenote.NewUserRestoreHandler(h.apiClient.OneNote(), rcc.ProtectedResource.ID())
+		notebookCache     = onenote.NewRestoreCache()
 	)
 
+	caches.ResourceMapping = rcc.RestoreConfig.ResourceMapping
+
 	ctx = clues.Add(ctx, "backup_version", rcc.BackupVersion)
 
 	err := caches.Populate(ctx, h.apiClient.Users(), h.apiClient.Groups(), rh, rcc.ProtectedResource.ID(), errs)
@@ -77,16 +82,30 @@ func (h *onedriveHandler) ConsumeRestoreCollections(
 				"full_path", dc.FullPath())
 		)
 
-		metrics, err = drive.RestoreCollection(
-			ictx,
-			rh,
-			rcc,
-			dc,
-			caches,
-			deets,
-			fallbackDriveName,
-			errs,
-			ctr.Local())
+		if dc.FullPath().Category() == path.NotebooksCategory {
+			metrics, err = onenote.RestoreCollection(
+				ictx,
+				nrh,
+				dc,
+				rcc.RestoreConfig.Location,
+				notebookCache,
+				rcc.RestoreConfig.OnCollision,
+				deets,
+				errs,
+				ctr.Local())
+		} else {
+			metrics, err = drive.RestoreCollection(
+				ictx,
+				rh,
+				rcc,
+				dc,
+				caches,
+				deets,
+				fallbackDriveName,
+				errs,
+				ctr.Local())
+		}
+
 		if err != nil {
 			el.AddRecoverable(ctx, err)
 		}
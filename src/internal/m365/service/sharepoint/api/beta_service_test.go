@@ -30,9 +30,7 @@ func (suite *BetaUnitSuite) TestBetaService_Adapter() {
 	require.NoError(t, err, clues.ToCore(err))
 
 	adpt, err := graph.CreateAdapter(
-		m365.AzureTenantID,
-		m365.AzureClientID,
-		m365.AzureClientSecret,
+		m365,
 		count.New())
 	require.NoError(t, err, clues.ToCore(err))
 
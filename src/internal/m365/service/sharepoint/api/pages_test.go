@@ -27,9 +27,7 @@ import (
 
 func createTestBetaService(t *testing.T, credentials account.M365Config) *api.BetaService {
 	adapter, err := graph.CreateAdapter(
-		credentials.AzureTenantID,
-		credentials.AzureClientID,
-		credentials.AzureClientSecret,
+		credentials,
 		count.New())
 	require.NoError(t, err, clues.ToCore(err))
 
@@ -8,6 +8,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/prefixmatcher"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/onenote"
 	"github.com/alcionai/corso/src/internal/m365/collection/site"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
@@ -15,6 +16,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
@@ -40,8 +42,15 @@ func ProduceBackupCollections(
 		categories           = map[path.CategoryType]struct{}{}
 		ssmb                 = prefixmatcher.NewStringSetBuilder()
 		canUsePreviousBackup bool
+		notebooksInScope     bool
 	)
 
+	for _, scope := range b.Scopes() {
+		if scope.Category().PathType() == path.NotebooksCategory {
+			notebooksInScope = true
+		}
+	}
+
 	ctx = clues.Add(
 		ctx,
 		"site_id", clues.Hide(bpc.ProtectedResource.ID()),
@@ -74,9 +83,15 @@ func ProduceBackupCollections(
 			}
 
 		case path.LibrariesCategory:
+			// the drive metadata parser doesn't expect notebook metadata.
+			lbpc := bpc
+			lbpc.MetadataCollections = data.FilterByCategory(
+				bpc.MetadataCollections,
+				path.LibrariesCategory)
+
 			spcs, canUsePreviousBackup, err = site.CollectLibraries(
 				ctx,
-				bpc,
+				lbpc,
 				drive.NewSiteBackupHandler(
 					ac.Drives(),
 					bpc.ProtectedResource.ID(),
@@ -84,6 +99,7 @@ func ProduceBackupCollections(
 					bpc.Selector.PathService()),
 				creds.AzureTenantID,
 				ssmb,
+				notebooksInScope,
 				su,
 				counter,
 				errs)
@@ -92,6 +108,27 @@ func ProduceBackupCollections(
 				continue
 			}
 
+		case path.NotebooksCategory:
+			spcs, canUsePreviousBackup, err = onenote.CreateCollections(
+				ctx,
+				bpc,
+				onenote.NewSiteBackupHandler(ac.OneNote(), bpc.ProtectedResource.ID(), scope),
+				creds.AzureTenantID,
+				su,
+				counter,
+				errs)
+			if onenote.IsInaccessible(err) {
+				logger.CtxErr(ctx, err).Info("skipping inaccessible notebooks")
+				errs.AddSkip(ctx, onenote.InaccessibleSkip(bpc.ProtectedResource))
+
+				continue
+			}
+
+			if err != nil {
+				el.AddRecoverable(ctx, err)
+				continue
+			}
+
 		case path.PagesCategory:
 			spcs, err = site.CollectPages(
 				ctx,
@@ -8,6 +8,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/onenote"
 	"github.com/alcionai/corso/src/internal/m365/collection/site"
 	"github.com/alcionai/corso/src/internal/m365/resource"
 	"github.com/alcionai/corso/src/internal/operations/inject"
@@ -114,6 +115,18 @@ func (h *baseSharePointHandler) ProduceExportCollections(
 					[]data.RestoreCollection{dc},
 					backupVersion,
 					stats))
+		case path.NotebooksCategory:
+			folders := dc.FullPath().Folders()
+			pth := path.Builder{}.Append(path.NotebooksCategory.HumanString()).Append(folders...)
+
+			ec = append(
+				ec,
+				onenote.NewExportCollection(
+					pth.String(),
+					[]data.RestoreCollection{dc},
+					backupVersion,
+					exportCfg,
+					stats))
 		default:
 			return nil, clues.NewWC(ctx, "data category not supported").
 				With("category", cat)
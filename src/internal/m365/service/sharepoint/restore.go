@@ -8,6 +8,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/onenote"
 	"github.com/alcionai/corso/src/internal/m365/collection/site"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
@@ -51,6 +52,10 @@ func (h *sharepointHandler) ConsumeRestoreCollections(
 		listsRh = site.NewListsRestoreHandler(
 			rcc.ProtectedResource.ID(),
 			h.apiClient.Lists())
+		notebooksRh = onenote.NewSiteRestoreHandler(
+			h.apiClient.OneNote(),
+			rcc.ProtectedResource.ID())
+		notebookCache  = onenote.NewRestoreCache()
 		restoreMetrics support.CollectionMetrics
 
 		caches = drive.NewRestoreCaches(h.backupDriveIDNames)
@@ -59,6 +64,8 @@ func (h *sharepointHandler) ConsumeRestoreCollections(
 		cl = ctr.Local()
 	)
 
+	caches.ResourceMapping = rcc.RestoreConfig.ResourceMapping
+
 	// Reorder collections so that the parents directories are created
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
@@ -116,6 +123,18 @@ func (h *sharepointHandler) ConsumeRestoreCollections(
 				cl,
 				errs)
 
+		case path.NotebooksCategory:
+			metrics, err = onenote.RestoreCollection(
+				ictx,
+				notebooksRh,
+				dc,
+				rcc.RestoreConfig.Location,
+				notebookCache,
+				rcc.RestoreConfig.OnCollision,
+				deets,
+				errs,
+				cl)
+
 		case path.PagesCategory:
 			metrics, err = site.RestorePageCollection(
 				ictx,
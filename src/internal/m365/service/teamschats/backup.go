@@ -0,0 +1,159 @@
+package teamschats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/prefixmatcher"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/teamschats"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+func ProduceBackupCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	ac api.Client,
+	creds account.M365Config,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, *prefixmatcher.StringSetMatcher, error) {
+	b, err := bpc.Selector.ToTeamsChatsBackup()
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "teamsChatsDataCollection: parsing selector")
+	}
+
+	var (
+		el          = errs.Local()
+		collections = []data.BackupCollection{}
+		categories  = map[path.CategoryType]struct{}{}
+	)
+
+	ctx = clues.Add(
+		ctx,
+		"user_id", clues.Hide(bpc.ProtectedResource.ID()),
+		"user_name", clues.Hide(bpc.ProtectedResource.Name()))
+
+	for _, scope := range b.Scopes() {
+		if el.Failure() != nil {
+			break
+		}
+
+		cl := counter.Local()
+		ictx := clues.AddLabelCounter(ctx, cl.PlainAdder())
+		ictx = clues.Add(ictx, "category", scope.Category().PathType())
+
+		var colls []data.BackupCollection
+
+		switch scope.Category().PathType() {
+		case path.ChatsCategory:
+			colls, err = backupChats(
+				ictx,
+				bpc,
+				ac,
+				creds,
+				scope,
+				su,
+				cl,
+				el)
+		}
+
+		if err != nil {
+			el.AddRecoverable(ctx, clues.Stack(err))
+			continue
+		}
+
+		collections = append(collections, colls...)
+
+		categories[scope.Category().PathType()] = struct{}{}
+	}
+
+	if len(collections) > 0 {
+		baseCols, err := graph.BaseCollections(
+			ctx,
+			collections,
+			creds.AzureTenantID,
+			bpc.ProtectedResource.ID(),
+			path.TeamsChatsService,
+			categories,
+			su,
+			counter,
+			errs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		collections = append(collections, baseCols...)
+	}
+
+	counter.Add(count.Collections, int64(len(collections)))
+
+	logger.Ctx(ctx).Infow("produced collections", "stats", counter.Values())
+
+	return collections, nil, el.Failure()
+}
+
+func backupChats(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	ac api.Client,
+	creds account.M365Config,
+	scope selectors.TeamsChatsScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	var colls []data.BackupCollection
+
+	progressMessage := observe.MessageWithCompletion(
+		ctx,
+		observe.ProgressCfg{
+			Indent:            1,
+			CompletionMessage: func() string { return fmt.Sprintf("(found %d chats)", len(colls)) },
+		},
+		scope.Category().PathType().HumanString())
+	defer close(progressMessage)
+
+	bh := teamschats.NewChatBackupHandler(
+		bpc.ProtectedResource.ID(),
+		ac.Chats())
+
+	colls, canUsePreviousBackup, err := teamschats.CreateCollections(
+		ctx,
+		bpc,
+		bh,
+		creds.AzureTenantID,
+		scope,
+		su,
+		counter,
+		errs)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	if !canUsePreviousBackup {
+		tp, err := bh.PathPrefix(creds.AzureTenantID)
+		if err != nil {
+			err = clues.WrapWC(ctx, err, "getting chats path").Label(count.BadPathPrefix)
+			return nil, err
+		}
+
+		colls = append(colls, data.NewTombstoneCollection(tp, control.Options{}, counter))
+	}
+
+	return colls, nil
+}
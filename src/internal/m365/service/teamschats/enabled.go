@@ -0,0 +1,36 @@
+package teamschats
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type getFirstChater interface {
+	GetFirstChat(ctx context.Context, userID string) (models.Chatable, error)
+}
+
+func IsServiceEnabled(
+	ctx context.Context,
+	gfc getFirstChater,
+	resource string,
+) (bool, error) {
+	_, err := gfc.GetFirstChat(ctx, resource)
+	if err != nil {
+		// users without a teams license are denied access to chats.  We
+		// consider this a non-error case, since it answers the question
+		// the caller is asking.
+		if graph.IsErrAccessDenied(err) {
+			logger.CtxErr(ctx, err).Info("resource owner does not have teams chats enabled")
+			return false, nil
+		}
+
+		return false, clues.Stack(err)
+	}
+
+	return true, nil
+}
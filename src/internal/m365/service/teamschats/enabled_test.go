@@ -0,0 +1,97 @@
+package teamschats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
+)
+
+type EnabledUnitSuite struct {
+	tester.Suite
+}
+
+func TestEnabledUnitSuite(t *testing.T) {
+	suite.Run(t, &EnabledUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var _ getFirstChater = mockGFC{}
+
+type mockGFC struct {
+	chat models.Chatable
+	err  error
+}
+
+func (m mockGFC) GetFirstChat(
+	ctx context.Context,
+	userID string,
+) (models.Chatable, error) {
+	return m.chat, m.err
+}
+
+func (suite *EnabledUnitSuite) TestIsServiceEnabled() {
+	table := []struct {
+		name      string
+		mock      func(context.Context) getFirstChater
+		expect    assert.BoolAssertionFunc
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "ok",
+			mock: func(ctx context.Context) getFirstChater {
+				return mockGFC{
+					chat: models.NewChat(),
+				}
+			},
+			expect:    assert.True,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "no chats",
+			mock: func(ctx context.Context) getFirstChater {
+				return mockGFC{}
+			},
+			expect:    assert.True,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "access denied",
+			mock: func(ctx context.Context) getFirstChater {
+				return mockGFC{
+					err: graph.Stack(ctx, graphTD.ODataErr(string(graph.ErrorAccessDenied))),
+				}
+			},
+			expect:    assert.False,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "arbitrary error",
+			mock: func(ctx context.Context) getFirstChater {
+				return mockGFC{
+					err: assert.AnError,
+				}
+			},
+			expect:    assert.False,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ok, err := IsServiceEnabled(ctx, test.mock(ctx), "resource_id")
+			test.expect(t, ok, "has chats flag")
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package teamschats
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/teamschats"
+	"github.com/alcionai/corso/src/internal/m365/resource"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ inject.ServiceHandler = &teamsChatsHandler{}
+
+func NewTeamsChatsHandler(
+	apiClient api.Client,
+	resourceGetter idname.GetResourceIDAndNamer,
+) *teamsChatsHandler {
+	return &teamsChatsHandler{
+		baseTeamsChatsHandler: baseTeamsChatsHandler{},
+		apiClient:             apiClient,
+		resourceGetter:        resourceGetter,
+	}
+}
+
+// ========================================================================== //
+//                         baseTeamsChatsHandler
+// ========================================================================== //
+
+// baseTeamsChatsHandler contains logic for tracking data and doing operations
+// (e.x. export) that don't require contact with external M356 services.
+type baseTeamsChatsHandler struct{}
+
+func (h *baseTeamsChatsHandler) CacheItemInfo(v details.ItemInfo) {}
+
+// ProduceExportCollections will create the export collections for the
+// given restore collections.
+func (h *baseTeamsChatsHandler) ProduceExportCollections(
+	ctx context.Context,
+	backupVersion int,
+	exportCfg control.ExportConfig,
+	dcs []data.RestoreCollection,
+	stats *metrics.ExportStats,
+	errs *fault.Bus,
+) ([]export.Collectioner, error) {
+	var (
+		el = errs.Local()
+		ec = make([]export.Collectioner, 0, len(dcs))
+	)
+
+	for _, restoreColl := range dcs {
+		var (
+			fp       = restoreColl.FullPath()
+			cat      = fp.Category()
+			folders  = append([]string{cat.HumanString()}, fp.Folders()...)
+			chatName string
+		)
+
+		if cat != path.ChatsCategory {
+			el.AddRecoverable(
+				ctx,
+				clues.New("unsupported category for export").With("category", cat))
+
+			continue
+		}
+
+		if fds := fp.Folders(); len(fds) > 0 {
+			chatName = fds[len(fds)-1]
+		}
+
+		ec = append(
+			ec,
+			teamschats.NewExportCollection(
+				path.Builder{}.Append(folders...).String(),
+				chatName,
+				[]data.RestoreCollection{restoreColl},
+				backupVersion,
+				exportCfg,
+				stats))
+	}
+
+	return ec, el.Failure()
+}
+
+// ========================================================================== //
+//                           teamsChatsHandler
+// ========================================================================== //
+
+// teamsChatsHandler contains logic for handling data and performing operations
+// (e.x. restore) regardless of whether they require contact with external M365
+// services or not.
+type teamsChatsHandler struct {
+	baseTeamsChatsHandler
+	apiClient      api.Client
+	resourceGetter idname.GetResourceIDAndNamer
+}
+
+func (h *teamsChatsHandler) IsServiceEnabled(
+	ctx context.Context,
+	resourceID string,
+) (bool, error) {
+	res, err := IsServiceEnabled(ctx, h.apiClient.Chats(), resourceID)
+	return res, clues.Stack(err).OrNil()
+}
+
+func (h *teamsChatsHandler) PopulateProtectedResourceIDAndName(
+	ctx context.Context,
+	resourceID string, // Can be either ID or name.
+	ins idname.Cacher,
+) (idname.Provider, error) {
+	if h.resourceGetter == nil {
+		return nil, clues.StackWC(ctx, resource.ErrNoResourceLookup)
+	}
+
+	pr, err := h.resourceGetter.GetResourceIDAndNameFrom(ctx, resourceID, ins)
+
+	return pr, clues.Wrap(err, "identifying resource owner").OrNil()
+}
+
+// ConsumeRestoreCollections always fails.  Graph can't create chats with
+// their original members and history, so chats are export-only.
+func (h *teamsChatsHandler) ConsumeRestoreCollections(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (*details.Details, *data.CollectionStats, error) {
+	return nil, nil, clues.NewWC(ctx, "restoring teams chats is not supported")
+}
@@ -30,6 +30,8 @@ const (
 	BackupSchema        Schema = 3
 	BackupDetailsSchema Schema = 4
 	RepositorySchema    Schema = 5
+	RetentionSchema     Schema = 6
+	MaintenanceSchema   Schema = 7
 )
 
 // common tags for filtering
@@ -71,7 +73,7 @@ const (
 
 // Valid returns true if the ModelType value fits within the const range.
 func (mt Schema) Valid() bool {
-	return mt > 0 && mt < RepositorySchema+1
+	return mt > 0 && mt < MaintenanceSchema+1
 }
 
 type Model interface {
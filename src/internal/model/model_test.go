@@ -30,7 +30,9 @@ func (suite *ModelUnitSuite) TestValid() {
 		{model.BackupSchema, assert.True},
 		{model.BackupDetailsSchema, assert.True},
 		{model.RepositorySchema, assert.True},
-		{model.RepositorySchema + 1, assert.False},
+		{model.RetentionSchema, assert.True},
+		{model.MaintenanceSchema, assert.True},
+		{model.MaintenanceSchema + 1, assert.False},
 		{model.Schema(-1), assert.False},
 		{model.Schema(100), assert.False},
 	}
@@ -14,11 +14,13 @@ func _() {
 	_ = x[BackupSchema-3]
 	_ = x[BackupDetailsSchema-4]
 	_ = x[RepositorySchema-5]
+	_ = x[RetentionSchema-6]
+	_ = x[MaintenanceSchema-7]
 }
 
-const _Schema_name = "UnknownSchemaBackupOpSchemaRestoreOpSchemaBackupSchemaBackupDetailsSchemaRepositorySchema"
+const _Schema_name = "UnknownSchemaBackupOpSchemaRestoreOpSchemaBackupSchemaBackupDetailsSchemaRepositorySchemaRetentionSchemaMaintenanceSchema"
 
-var _Schema_index = [...]uint8{0, 13, 27, 42, 54, 73, 89}
+var _Schema_index = [...]uint8{0, 13, 27, 42, 54, 73, 89, 104, 121}
 
 func (i Schema) String() string {
 	if i < 0 || i >= Schema(len(_Schema_index)-1) {
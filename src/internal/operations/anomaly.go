@@ -0,0 +1,160 @@
+package operations
+
+import (
+	"context"
+	"slices"
+
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/backup/anomaly"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// anomalyDetector compares the churn of a backup against the baseline
+// recorded in its merge bases.
+type anomalyDetector struct {
+	sampler     *anomaly.Sampler
+	thresholds  anomaly.Thresholds
+	markSuspect bool
+
+	// populated once the merge bases are known.
+	baseline    anomaly.Baseline
+	incremental bool
+
+	// populated once the backup completes.
+	record *anomaly.Record
+}
+
+// newAnomalyDetector produces a detector if anomaly detection was
+// requested, and adds its sampler to the item extensions in the options.
+// Returns nil otherwise.
+func newAnomalyDetector(opts *control.Options) *anomalyDetector {
+	if !opts.ToggleFeatures.DetectAnomalies {
+		return nil
+	}
+
+	ad := &anomalyDetector{
+		sampler:     anomaly.NewSampler(),
+		thresholds:  anomaly.DefaultThresholds(),
+		markSuspect: opts.ToggleFeatures.MarkSuspectBackups,
+	}
+
+	// clone the factories so that callers sharing the options between
+	// backups don't share the sampler.
+	opts.ItemExtensionFactory = append(
+		slices.Clone(opts.ItemExtensionFactory),
+		ad.sampler)
+
+	return ad
+}
+
+// setBases derives the baseline from the anomaly records of the merge
+// bases.  incremental is false if the backup retrieves every item, in
+// which case its churn isn't compared against the baseline.
+func (ad *anomalyDetector) setBases(bases []kopia.BackupBase, incremental bool) {
+	if ad == nil {
+		return
+	}
+
+	var (
+		seen = map[model.StableID]struct{}{}
+		bls  = []anomaly.Baseline{}
+	)
+
+	for _, base := range bases {
+		if base.Backup == nil {
+			continue
+		}
+
+		if _, ok := seen[base.Backup.ID]; ok {
+			continue
+		}
+
+		seen[base.Backup.ID] = struct{}{}
+
+		bls = append(bls, base.Backup.Anomaly.Next())
+	}
+
+	ad.baseline = anomaly.MergeBaselines(bls...)
+	ad.incremental = incremental && len(bases) > 0
+}
+
+// detect compares the counts of the completed backup against the baseline.
+// items is the count of items in the backup's details.
+func (ad *anomalyDetector) detect(counter *count.Bus, items int) *anomaly.Record {
+	stats := ad.sampler.Stats(anomaly.Stats{
+		Items:   int64(items),
+		Changed: counter.Total(count.StreamItemsAdded),
+		// drive deletions get counted separately by the old and new
+		// delta processing.
+		Deleted: counter.Total(count.StreamItemsRemoved) +
+			counter.Total(count.DeleteItemMarker) +
+			counter.Total(count.TotalDeleteFilesProcessed),
+	})
+
+	rec := &anomaly.Record{
+		Stats:       stats,
+		Baseline:    ad.baseline,
+		Incremental: ad.incremental,
+	}
+
+	// every item in a full backup is new, so only the content of the items
+	// can be compared.
+	bl := ad.baseline
+	if !ad.incremental {
+		bl = anomaly.Baseline{}
+	}
+
+	rec.Findings = anomaly.Detect(stats, bl, ad.thresholds)
+
+	return rec
+}
+
+// suspect is true if the backup should be marked as suspect.
+func (ad *anomalyDetector) suspect() bool {
+	return ad != nil && ad.markSuspect && ad.record.Anomalous()
+}
+
+// detectAnomalies runs anomaly detection on the completed backup, and
+// reports each finding as an alert and an event.  No-op unless detection
+// was requested.
+func (op *BackupOperation) detectAnomalies(ctx context.Context, items int) {
+	if op.anomalies == nil {
+		return
+	}
+
+	rec := op.anomalies.detect(op.Counter, items)
+	op.anomalies.record = rec
+
+	logger.Ctx(ctx).Infow(
+		"anomaly detection",
+		"anomaly_stats", rec.Stats,
+		"anomaly_baseline", rec.Baseline,
+		"anomaly_findings", len(rec.Findings))
+
+	for _, f := range rec.Findings {
+		op.Errors.AddAlert(ctx, fault.NewAlert(
+			fault.AlertBackupAnomaly,
+			"", // no namespace
+			"", // no item id
+			f.Kind,
+			map[string]any{
+				"anomaly_description": f.Message,
+				"anomaly_observed":    f.Observed,
+				"anomaly_threshold":   f.Expected,
+			}))
+
+		op.bus.Event(
+			ctx,
+			events.BackupAnomaly,
+			map[string]any{
+				events.AnomalyKind: f.Kind,
+				events.BackupID:    op.Results.BackupID,
+				events.Service:     op.Selectors.PathService().String(),
+			})
+	}
+}
@@ -0,0 +1,173 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/events"
+	evmock "github.com/alcionai/corso/src/internal/events/mock"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/anomaly"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/extensions"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type AnomalyDetectorUnitSuite struct {
+	tester.Suite
+}
+
+func TestAnomalyDetectorUnitSuite(t *testing.T) {
+	suite.Run(t, &AnomalyDetectorUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *AnomalyDetectorUnitSuite) TestNewAnomalyDetector() {
+	t := suite.T()
+
+	mockExt := &extensions.MockItemExtensionFactory{}
+
+	opts := control.DefaultOptions()
+	opts.ItemExtensionFactory = []extensions.CreateItemExtensioner{mockExt}
+
+	ad := newAnomalyDetector(&opts)
+	assert.Nil(t, ad, "detection not requested")
+	assert.Len(t, opts.ItemExtensionFactory, 1)
+
+	opts.ToggleFeatures.DetectAnomalies = true
+
+	ad = newAnomalyDetector(&opts)
+	require.NotNil(t, ad)
+	assert.Equal(t, []extensions.CreateItemExtensioner{mockExt, ad.sampler}, opts.ItemExtensionFactory)
+}
+
+func (suite *AnomalyDetectorUnitSuite) TestDetectAnomalies() {
+	prior := &backup.Backup{
+		BaseModel: model.BaseModel{ID: "prior"},
+		Anomaly: &anomaly.Record{
+			Stats:       anomaly.Stats{Items: 1000, Changed: 10, Deleted: 10},
+			Baseline:    anomaly.Baseline{Backups: 5, ChangedRate: 0.01, DeletedRate: 0.01},
+			Incremental: true,
+		},
+	}
+
+	// the same backup can be the base for multiple reasons.
+	bases := []kopia.BackupBase{{Backup: prior}, {Backup: prior}}
+
+	table := []struct {
+		name          string
+		markSuspect   bool
+		incremental   bool
+		removed       int64
+		expectKinds   []string
+		expectSuspect bool
+	}{
+		{
+			name:        "typical incremental",
+			incremental: true,
+			removed:     5,
+			expectKinds: []string{},
+		},
+		{
+			name:        "mass deletion",
+			incremental: true,
+			removed:     600,
+			expectKinds: []string{anomaly.MassDeletion},
+		},
+		{
+			name:          "mass deletion, marked suspect",
+			markSuspect:   true,
+			incremental:   true,
+			removed:       600,
+			expectKinds:   []string{anomaly.MassDeletion},
+			expectSuspect: true,
+		},
+		{
+			name:        "full backup",
+			markSuspect: true,
+			removed:     600,
+			expectKinds: []string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				bus  = evmock.NewBus()
+				ctr  = count.New()
+				opts = control.DefaultOptions()
+			)
+
+			opts.ToggleFeatures.DetectAnomalies = true
+			opts.ToggleFeatures.MarkSuspectBackups = test.markSuspect
+
+			ctr.Add(count.StreamItemsRemoved, test.removed)
+
+			ad := newAnomalyDetector(&opts)
+			ad.setBases(bases, test.incremental)
+
+			assert.Equal(t, 6, ad.baseline.Backups, "baseline includes the prior backup once")
+
+			op := &BackupOperation{
+				operation: newOperation(opts, bus, ctr, nil, nil),
+				Selectors: selectors.NewExchangeBackup([]string{"uid"}).Selector,
+				anomalies: ad,
+			}
+
+			op.detectAnomalies(ctx, 400)
+
+			require.NotNil(t, ad.record)
+			assert.Equal(t, int64(400), ad.record.Stats.Items)
+			assert.Equal(t, test.removed, ad.record.Stats.Deleted)
+			assert.Equal(t, test.incremental, ad.record.Incremental)
+			assert.Equal(t, test.expectSuspect, ad.suspect())
+
+			kinds := []string{}
+			for _, f := range ad.record.Findings {
+				kinds = append(kinds, f.Kind)
+			}
+
+			assert.Equal(t, test.expectKinds, kinds)
+			assert.Equal(t, len(test.expectKinds), bus.TimesCalled[events.BackupAnomaly])
+
+			alerts := []string{}
+			for _, a := range op.Errors.Alerts() {
+				assert.Equal(t, fault.AlertBackupAnomaly, a.Message)
+				alerts = append(alerts, a.Item.Name)
+			}
+
+			assert.Equal(t, test.expectKinds, alerts)
+		})
+	}
+}
+
+func (suite *AnomalyDetectorUnitSuite) TestDetectAnomalies_disabled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	bus := evmock.NewBus()
+
+	op := &BackupOperation{
+		operation: newOperation(control.DefaultOptions(), bus, count.New(), nil, nil),
+	}
+
+	op.anomalies.setBases(nil, true)
+	op.detectAnomalies(ctx, 10)
+
+	assert.Empty(t, op.Errors.Alerts())
+	assert.Empty(t, bus.TimesCalled)
+	assert.False(t, op.anomalies.suspect())
+}
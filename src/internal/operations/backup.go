@@ -25,6 +25,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/backup/search"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/dttm"
@@ -37,6 +38,10 @@ import (
 	"github.com/alcionai/corso/src/pkg/store"
 )
 
+// backupLeaseWait bounds how long a backup waits for repository maintenance
+// to release the repository before giving up.
+const backupLeaseWait = time.Hour
+
 // BackupOperation wraps an operation with backup-specific props.
 type BackupOperation struct {
 	operation
@@ -62,6 +67,14 @@ type BackupOperation struct {
 	// When true, disables kopia-assisted incremental backups. This forces
 	// downloading and hashing all item data for items not in the merge base(s).
 	disableAssistBackup bool
+	// gathers the search index of the backed up items.  Nil unless the
+	// index was requested.
+	searchIndex *search.Builder
+	// compares the churn of the backup against prior backups.  Nil unless
+	// anomaly detection was requested.
+	anomalies *anomalyDetector
+	// stops the backup at its checkpoint.  Nil unless a checkpoint was set.
+	checkpoint *checkpointer
 }
 
 // BackupResults aggregate the details of the result of the operation.
@@ -86,6 +99,9 @@ func NewBackupOperation(
 	bus events.Eventer,
 	counter *count.Bus,
 ) (BackupOperation, error) {
+	// must precede newOperation, since it adds to the item extensions.
+	anomalies := newAnomalyDetector(&opts)
+
 	op := BackupOperation{
 		operation:           newOperation(opts, bus, counter, kw, sw),
 		ResourceOwner:       owner,
@@ -96,6 +112,12 @@ func NewBackupOperation(
 		incremental:         useIncrementalBackup(selector, opts),
 		disableAssistBackup: opts.ToggleFeatures.ForceItemDataDownload,
 		bp:                  bp,
+		anomalies:           anomalies,
+		checkpoint:          newCheckpointer(opts.CheckpointAt),
+	}
+
+	if opts.ToggleFeatures.SearchIndex {
+		op.searchIndex = search.NewBuilder()
 	}
 
 	if err := op.validate(); err != nil {
@@ -118,6 +140,13 @@ func (op BackupOperation) validate() error {
 		return clues.New("missing backup producer")
 	}
 
+	// any other policy would either fail the backup at the checkpoint without
+	// persisting it, or persist the partial backup as a merge base.
+	if !op.Options.CheckpointAt.IsZero() &&
+		op.Options.FailureHandling != control.FailAfterRecovery {
+		return clues.New("backup checkpoints require the " + string(control.FailAfterRecovery) + " failure policy")
+	}
+
 	return op.operation.validate()
 }
 
@@ -284,6 +313,25 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 			})
 	}()
 
+	// Hold a shared lease for the duration of the backup so that repository
+	// maintenance doesn't run underneath it.  If maintenance is running, wait
+	// for it to finish instead of failing the backup.
+	releaseLease, err := holdLease(
+		ctx,
+		op.kopia,
+		kopia.SharedLease,
+		"backup "+string(op.Results.BackupID),
+		backupLeaseWait)
+	if err != nil {
+		op.Status = Failed
+		err = clues.Wrap(err, "acquiring repository lease")
+		op.Errors.Fail(err)
+
+		return err
+	}
+
+	defer releaseLease()
+
 	// -----
 	// Execution
 	// -----
@@ -317,6 +365,15 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 	return op.Errors.Failure()
 }
 
+// Progress returns the collections which the backup completed before it
+// stopped at its checkpoint, mapped to the delta tokens they were enumerated
+// to.  Passing them back through control.Resume lets the next backup of the
+// resource continue from them.  Returns nil if the backup didn't stop at a
+// checkpoint.
+func (op *BackupOperation) Progress() map[string]string {
+	return op.checkpoint.progress()
+}
+
 func (op *BackupOperation) doPersistence(
 	ctx context.Context,
 	opStats *backupStats,
@@ -332,13 +389,19 @@ func (op *BackupOperation) doPersistence(
 		return
 	}
 
+	dd := deets.Details()
+
+	// alerts must be raised before the errors get persisted alongside the
+	// details.
+	op.detectAnomalies(ctx, len(dd.Items()))
+
 	err = op.createBackupModels(
 		ctx,
 		detailsStore,
 		*opStats,
 		op.Results.BackupID,
 		op.BackupVersion,
-		deets.Details())
+		dd)
 	if err != nil {
 		op.Errors.Fail(clues.Wrap(err, "persisting backup models"))
 	}
@@ -378,9 +441,18 @@ func (op *BackupOperation) do(
 		return nil, clues.Stack(err)
 	}
 
+	var bf kinject.BaseFinder = kbf
+
+	if op.Options.Resume.Enabled() {
+		bf = resumeBaseFinder{
+			BaseFinder: kbf,
+			backupID:   model.StableID(op.Options.Resume.BackupID),
+		}
+	}
+
 	mans, mdColls, canUseMetadata, err := produceManifestsAndMetadata(
 		ctx,
-		kbf,
+		bf,
 		op.bp,
 		op.kopia,
 		reasons, fallbackReasons,
@@ -447,10 +519,18 @@ func (op *BackupOperation) do(
 		return nil, clues.Wrap(err, "producing backup data collections")
 	}
 
+	op.anomalies.setBases(
+		mans.MergeBases(),
+		op.incremental && canUseMetadata && canUsePreviousBackup)
+
 	ctx = clues.Add(
 		ctx,
 		"can_use_previous_backup", canUsePreviousBackup,
-		"collection_count", len(cs))
+		"collection_count", len(cs),
+		"checkpoint_at", op.Options.CheckpointAt)
+
+	cs = checkpointCollections(cs, op.checkpoint)
+	cs = indexCollections(cs, op.searchIndex)
 
 	writeStats, deets, toMerge, err := consumeBackupCollections(
 		ctx,
@@ -480,6 +560,7 @@ func (op *BackupOperation) do(
 		deets,
 		writeStats,
 		op.Selectors.PathService(),
+		op.searchIndex,
 		op.Errors)
 	if err != nil {
 		return nil, clues.Wrap(err, "merging details")
@@ -663,6 +744,7 @@ func mergeItemsFromBase(
 	dataFromBackup kopia.DetailsMergeInfoer,
 	deets *details.Builder,
 	alreadySeenItems map[string]struct{},
+	sb *search.Builder,
 	errs *fault.Bus,
 ) (int, error) {
 	var (
@@ -677,6 +759,7 @@ func mergeItemsFromBase(
 		ctx,
 		baseBackup.Backup,
 		detailsStore,
+		details.ChunkFilter{},
 		errs)
 	if err != nil {
 		return manifestAddedEntries,
@@ -742,6 +825,12 @@ func mergeItemsFromBase(
 				clues.WrapWC(ictx, err, "adding item to details")
 		}
 
+		// The item's content isn't read again, so its search terms come from
+		// the base.
+		if sb != nil {
+			sb.Carry(string(baseBackup.Backup.ID), entry.RepoRef, newPath.String())
+		}
+
 		// Make sure we won't add this again in another base.
 		alreadySeenItems[rr.ShortRef()] = struct{}{}
 
@@ -750,6 +839,8 @@ func mergeItemsFromBase(
 		manifestAddedEntries++
 	}
 
+	readBaseSearchIndex(ctx, sb, baseBackup.Backup, detailsStore)
+
 	logger.Ctx(ctx).Infow(
 		"merged details with base manifest",
 		"count_base_item_unfiltered", totalBaseItems,
@@ -766,6 +857,7 @@ func mergeDetails(
 	deets *details.Builder,
 	writeStats *kopia.BackupStats,
 	serviceType path.ServiceType,
+	sb *search.Builder,
 	errs *fault.Bus,
 ) error {
 	detailsModel := deets.Details().DetailsModel
@@ -808,6 +900,7 @@ func mergeDetails(
 			dataFromBackup,
 			deets,
 			alreadySeenEntries,
+			sb,
 			errs)
 		if err != nil {
 			return clues.Wrap(err, "merging assist backup base details")
@@ -833,6 +926,7 @@ func mergeDetails(
 			dataFromBackup,
 			deets,
 			alreadySeenEntries,
+			sb,
 			errs)
 		if err != nil {
 			return clues.Wrap(err, "merging merge backup base details")
@@ -938,11 +1032,21 @@ func (op *BackupOperation) createBackupModels(
 		return clues.NewWC(ctx, "no snapshot ID to record")
 	}
 
-	err := sscw.Collect(ctx, streamstore.DetailsCollector(deets))
+	err := sscw.Collect(
+		ctx,
+		streamstore.ChunkedDetailsCollector(details.NewChunkWriter(deets, details.DefaultChunkSize)))
 	if err != nil {
 		return clues.Wrap(err, "collecting details for persistence")
 	}
 
+	if op.searchIndex != nil {
+		err = sscw.Collect(ctx, streamstore.SearchIndexCollector(
+			op.searchIndex.Shards(deets, search.DefaultShardSize)))
+		if err != nil {
+			return clues.Wrap(err, "collecting search index for persistence")
+		}
+	}
+
 	err = sscw.Collect(ctx, streamstore.FaultErrorsCollector(op.Errors.Errors()))
 	if err != nil {
 		return clues.Wrap(err, "collecting errors for persistence")
@@ -1018,6 +1122,14 @@ func (op *BackupOperation) createBackupModels(
 		op.Errors.Errors(),
 		tags)
 
+	b.DetailsFormat = details.ChunkedFormat
+	b.HasSearchIndex = op.searchIndex != nil
+
+	if op.anomalies != nil {
+		b.Anomaly = op.anomalies.record
+		b.Suspect = op.anomalies.suspect()
+	}
+
 	logger.Ctx(ctx).Info("creating new backup")
 
 	if err = op.store.Put(ctx, model.BackupSchema, b); err != nil {
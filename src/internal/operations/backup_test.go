@@ -1313,6 +1313,7 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_MergeBackupDetails_AddsItems
 				&deets,
 				&writeStats,
 				path.OneDriveService,
+				nil,
 				fault.New(true))
 			test.errCheck(t, err, clues.ToCore(err))
 
@@ -1437,6 +1438,7 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_MergeBackupDetails_AddsFolde
 		&deets,
 		&writeStats,
 		path.ExchangeService,
+		nil,
 		fault.New(true))
 	assert.NoError(t, err, clues.ToCore(err))
 	compareDeetEntries(t, expectedEntries, deets.Details().Entries)
@@ -0,0 +1,266 @@
+package operations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+	"golang.org/x/exp/maps"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/kopia"
+	kinject "github.com/alcionai/corso/src/internal/kopia/inject"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// checkpointer tracks whether a backup has passed its checkpoint.  The
+// first check past the checkpoint records a single recoverable error, which
+// keeps the backup from being used as a merge base while still allowing it
+// to be persisted as an assist base.
+//
+// It also records the collections which produced all of their items before
+// the checkpoint, so that a resumed backup can continue from their delta
+// tokens instead of backing them up again.
+type checkpointer struct {
+	at   time.Time
+	once sync.Once
+
+	mu sync.Mutex
+	// completed maps the paths of the completed collections to their
+	// delta tokens.
+	completed map[string]string
+	stopped   bool
+}
+
+// newCheckpointer produces a checkpointer for the given time.  Returns nil
+// if no checkpoint is set.
+func newCheckpointer(at time.Time) *checkpointer {
+	if at.IsZero() {
+		return nil
+	}
+
+	return &checkpointer{
+		at:        at,
+		completed: map[string]string{},
+	}
+}
+
+func (cp *checkpointer) reached(ctx context.Context, errs *fault.Bus) bool {
+	if cp.at.IsZero() || time.Now().Before(cp.at) {
+		return false
+	}
+
+	cp.once.Do(func() {
+		logger.Ctx(ctx).Infow("backup reached checkpoint", "checkpoint", cp.at)
+		errs.AddRecoverable(ctx, clues.StackWC(ctx, core.ErrStoppedAtCheckpoint))
+
+		cp.mu.Lock()
+		defer cp.mu.Unlock()
+
+		cp.stopped = true
+	})
+
+	return true
+}
+
+// complete records that the collection produced all of its items.
+func (cp *checkpointer) complete(c data.BackupCollection) {
+	// deleted collections have no items to resume.
+	if c.FullPath() == nil {
+		return
+	}
+
+	var token string
+
+	if dt, ok := c.(data.DeltaTokener); ok {
+		token = dt.DeltaToken()
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.completed[c.FullPath().String()] = token
+}
+
+// progress returns the collections which were completed before the
+// checkpoint, mapped to their delta tokens.  Returns nil if the backup
+// didn't stop at its checkpoint.
+func (cp *checkpointer) progress() map[string]string {
+	if cp == nil {
+		return nil
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.stopped {
+		return nil
+	}
+
+	return maps.Clone(cp.completed)
+}
+
+// checkpointCollections wraps each collection so that it stops producing
+// items once the checkpoint passes.  Metadata collections are never
+// wrapped, since the next incremental backup depends on all of their
+// content.  Returns the collections unchanged if no checkpoint is set.
+func checkpointCollections(
+	cs []data.BackupCollection,
+	cp *checkpointer,
+) []data.BackupCollection {
+	if cp == nil {
+		return cs
+	}
+
+	res := make([]data.BackupCollection, 0, len(cs))
+
+	for _, c := range cs {
+		if isMetadataCollection(c) {
+			res = append(res, c)
+			continue
+		}
+
+		cc := checkpointCollection{
+			BackupCollection: c,
+			cp:               cp,
+		}
+
+		// kopia checks for these interfaces on the collection, so the wrapper
+		// must implement exactly the same set as the collection it wraps.
+		switch c.(type) {
+		case data.PreviousLocationPather:
+			res = append(res, checkpointPrevLocCollection{cc})
+		case data.LocationPather:
+			res = append(res, checkpointLocCollection{cc})
+		default:
+			res = append(res, cc)
+		}
+	}
+
+	return res
+}
+
+// isMetadataCollection is true for the collections that hold the metadata
+// of a backup, such as delta tokens and previous paths.
+func isMetadataCollection(c data.BackupCollection) bool {
+	p := c.FullPath()
+	if p == nil {
+		p = c.PreviousPath()
+	}
+
+	if p == nil {
+		return false
+	}
+
+	switch p.Service() {
+	case path.ExchangeMetadataService,
+		path.OneDriveMetadataService,
+		path.SharePointMetadataService,
+		path.GroupsMetadataService,
+		path.TeamsChatsMetadataService,
+		path.EntraIDMetadataService:
+		return true
+	}
+
+	return false
+}
+
+// resumeBaseFinder makes the assist backup persisted at a checkpoint the
+// merge base of the backup which resumes from it.  Its metadata holds the
+// delta tokens of the collections it completed, and its snapshot holds
+// their items.
+type resumeBaseFinder struct {
+	kinject.BaseFinder
+	backupID model.StableID
+}
+
+func (bf resumeBaseFinder) FindBases(
+	ctx context.Context,
+	reasons []identity.Reasoner,
+	tags map[string]string,
+) kopia.BackupBases {
+	bb := bf.BaseFinder.FindBases(ctx, reasons, tags)
+	bb.ConvertToMergeBase(bf.backupID)
+
+	return bb
+}
+
+type checkpointCollection struct {
+	data.BackupCollection
+	cp *checkpointer
+}
+
+// Items forwards items from the wrapped collection until the checkpoint
+// passes.  At that point the context of the wrapped collection gets
+// cancelled so that it stops fetching items, and whatever it already
+// produced is drained so that it can complete and report its status.
+// Lazily fetched items which were handed over before the checkpoint, but
+// not yet read, fail to read; they're picked up by the next backup.
+// Collections which produce all of their items before the checkpoint get
+// recorded as completed.
+func (c checkpointCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	var (
+		res        = make(chan data.Item)
+		ictx, stop = context.WithCancel(ctx)
+		items      = c.BackupCollection.Items(ictx, errs)
+	)
+
+	go func() {
+		defer stop()
+		defer close(res)
+
+		var stopped bool
+
+		for item := range items {
+			if c.cp.reached(ctx, errs) {
+				stopped = true
+
+				stop()
+
+				break
+			}
+
+			res <- item
+		}
+
+		// let the wrapped collection wind down.
+		for range items {
+		}
+
+		if !stopped && ctx.Err() == nil {
+			c.cp.complete(c.BackupCollection)
+		}
+	}()
+
+	return res
+}
+
+type checkpointLocCollection struct {
+	checkpointCollection
+}
+
+func (c checkpointLocCollection) LocationPath() *path.Builder {
+	return c.BackupCollection.(data.LocationPather).LocationPath()
+}
+
+type checkpointPrevLocCollection struct {
+	checkpointCollection
+}
+
+func (c checkpointPrevLocCollection) LocationPath() *path.Builder {
+	return c.BackupCollection.(data.PreviousLocationPather).LocationPath()
+}
+
+func (c checkpointPrevLocCollection) PreviousLocationPath() details.LocationIDer {
+	return c.BackupCollection.(data.PreviousLocationPather).PreviousLocationPath()
+}
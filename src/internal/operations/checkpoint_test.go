@@ -0,0 +1,279 @@
+package operations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type CheckpointUnitSuite struct {
+	tester.Suite
+}
+
+func TestCheckpointUnitSuite(t *testing.T) {
+	suite.Run(t, &CheckpointUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *CheckpointUnitSuite) TestCheckpointCollections() {
+	makeColl := func() data.BackupCollection {
+		return dataMock.Collection{
+			Loc: path.Builder{}.Append("loc"),
+			ItemData: []data.Item{
+				&dataMock.Item{ItemID: "1"},
+				&dataMock.Item{ItemID: "2"},
+				&dataMock.Item{ItemID: "3"},
+			},
+		}
+	}
+
+	table := []struct {
+		name              string
+		at                time.Time
+		expectItems       int
+		expectCheckpoints int
+	}{
+		{
+			name:              "no checkpoint",
+			expectItems:       3,
+			expectCheckpoints: 0,
+		},
+		{
+			name:              "checkpoint in the future",
+			at:                time.Now().Add(time.Hour),
+			expectItems:       3,
+			expectCheckpoints: 0,
+		},
+		{
+			name:              "checkpoint passed",
+			at:                time.Now().Add(-time.Minute),
+			expectItems:       0,
+			expectCheckpoints: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				errs = fault.New(false)
+				cs   = checkpointCollections(
+					[]data.BackupCollection{makeColl(), makeColl()},
+					newCheckpointer(test.at))
+				found int
+			)
+
+			require.Len(t, cs, 2)
+
+			for _, c := range cs {
+				lp, ok := c.(data.LocationPather)
+				require.True(t, ok, "wrapped collection is a location pather")
+				assert.Equal(t, "loc", lp.LocationPath().String())
+
+				_, ok = c.(data.PreviousLocationPather)
+				assert.False(t, ok, "wrapped collection is not a previous location pather")
+
+				for range c.Items(ctx, errs) {
+					found++
+				}
+			}
+
+			assert.Equal(t, test.expectItems*len(cs), found)
+
+			var checkpoints int
+
+			for _, err := range errs.Recovered() {
+				if errors.Is(err, core.ErrStoppedAtCheckpoint) {
+					checkpoints++
+				}
+			}
+
+			assert.Equal(t, test.expectCheckpoints, checkpoints, clues.ToCore(errs.Failure()))
+		})
+	}
+}
+
+func (suite *CheckpointUnitSuite) TestCheckpointCollections_metadata() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	mdp, err := path.BuildMetadata("tid", "uid", path.ExchangeService, path.EmailCategory, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		errs = fault.New(false)
+		md   = dataMock.Collection{
+			Path: mdp,
+			ItemData: []data.Item{
+				&dataMock.Item{ItemID: "delta"},
+				&dataMock.Item{ItemID: "previouspath"},
+			},
+		}
+		cs = checkpointCollections(
+			[]data.BackupCollection{md},
+			newCheckpointer(time.Now().Add(-time.Minute)))
+	)
+
+	require.Len(t, cs, 1)
+	assert.Equal(t, md, cs[0], "metadata collection is not wrapped")
+
+	var found int
+
+	for range cs[0].Items(ctx, errs) {
+		found++
+	}
+
+	assert.Equal(t, 2, found, "all metadata gets backed up")
+	assert.Empty(t, errs.Recovered())
+}
+
+// endlessCollection produces items until its context gets cancelled.
+type endlessCollection struct {
+	dataMock.Collection
+	cancelled chan struct{}
+}
+
+func (c endlessCollection) Items(ctx context.Context, _ *fault.Bus) <-chan data.Item {
+	ch := make(chan data.Item)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				close(c.cancelled)
+				return
+			case ch <- &dataMock.Item{ItemID: "id"}:
+			}
+		}
+	}()
+
+	return ch
+}
+
+func (suite *CheckpointUnitSuite) TestCheckpointCollections_cancelsCollection() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		errs = fault.New(false)
+		ec   = endlessCollection{cancelled: make(chan struct{})}
+		cs   = checkpointCollections(
+			[]data.BackupCollection{ec},
+			newCheckpointer(time.Now().Add(-time.Minute)))
+	)
+
+	require.Len(t, cs, 1)
+
+	for range cs[0].Items(ctx, errs) {
+		assert.Fail(t, "no items after the checkpoint")
+	}
+
+	select {
+	case <-ec.cancelled:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "wrapped collection was not cancelled")
+	}
+
+	assert.Len(t, errs.Recovered(), 1)
+}
+
+// deltaCollection is a collection enumerated with a delta query.
+type deltaCollection struct {
+	dataMock.Collection
+	token string
+}
+
+func (c deltaCollection) DeltaToken() string {
+	return c.token
+}
+
+func (suite *CheckpointUnitSuite) TestCheckpointer_Progress() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	done, err := path.Build("tid", "uid", path.ExchangeService, path.EmailCategory, false, "done")
+	require.NoError(t, err, clues.ToCore(err))
+
+	plain, err := path.Build("tid", "uid", path.ExchangeService, path.EmailCategory, false, "plain")
+	require.NoError(t, err, clues.ToCore(err))
+
+	stopped, err := path.Build("tid", "uid", path.ExchangeService, path.EmailCategory, false, "stopped")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		errs = fault.New(false)
+		cp   = newCheckpointer(time.Now().Add(time.Hour))
+		cs   = checkpointCollections(
+			[]data.BackupCollection{
+				deltaCollection{
+					Collection: dataMock.Collection{
+						Path:     done,
+						ItemData: []data.Item{&dataMock.Item{ItemID: "1"}},
+					},
+					token: "delta",
+				},
+				dataMock.Collection{
+					Path:     plain,
+					ItemData: []data.Item{&dataMock.Item{ItemID: "2"}},
+				},
+				// deleted collections have nothing to resume.
+				dataMock.Collection{},
+			},
+			cp)
+	)
+
+	for _, c := range cs {
+		for range c.Items(ctx, errs) {
+		}
+	}
+
+	assert.Nil(t, cp.progress(), "no progress before the checkpoint")
+
+	cp.at = time.Now().Add(-time.Minute)
+
+	later := checkpointCollections(
+		[]data.BackupCollection{
+			deltaCollection{
+				Collection: dataMock.Collection{
+					Path:     stopped,
+					ItemData: []data.Item{&dataMock.Item{ItemID: "3"}},
+				},
+				token: "stopped",
+			},
+		},
+		cp)
+
+	for range later[0].Items(ctx, errs) {
+		assert.Fail(t, "no items after the checkpoint")
+	}
+
+	expect := map[string]string{
+		done.String():  "delta",
+		plain.String(): "",
+	}
+
+	assert.Equal(t, expect, cp.progress())
+}
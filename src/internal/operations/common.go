@@ -18,6 +18,7 @@ func getBackupAndDetailsFromID(
 	backupID model.StableID,
 	ms store.BackupStorer,
 	detailsStore streamstore.Reader,
+	filter details.ChunkFilter,
 	errs *fault.Bus,
 ) (*backup.Backup, *details.Details, error) {
 	bup, err := ms.GetBackup(ctx, backupID)
@@ -25,7 +26,7 @@ func getBackupAndDetailsFromID(
 		return nil, nil, clues.Stack(err)
 	}
 
-	deets, err := getDetailsFromBackup(ctx, bup, detailsStore, errs)
+	deets, err := getDetailsFromBackup(ctx, bup, detailsStore, filter, errs)
 	if err != nil {
 		return nil, nil, clues.Stack(err)
 	}
@@ -37,6 +38,7 @@ func getDetailsFromBackup(
 	ctx context.Context,
 	bup *backup.Backup,
 	detailsStore streamstore.Reader,
+	filter details.ChunkFilter,
 	errs *fault.Bus,
 ) (*details.Details, error) {
 	var (
@@ -45,6 +47,12 @@ func getDetailsFromBackup(
 		ssid  = bup.StreamStoreID
 	)
 
+	// chunked details only get read in part, as narrowed by the filter.
+	// Older backups hold all of the details in a single item.
+	if bup.DetailsFormat == details.ChunkedFormat {
+		umt = streamstore.ChunkedDetailsReader(details.NewChunkReader(&deets, filter))
+	}
+
 	if len(ssid) == 0 {
 		ssid = bup.DetailsID
 	}
@@ -219,6 +219,7 @@ func (op *ExportOperation) do(
 		op.BackupID,
 		op.store,
 		detailsStore,
+		op.Selectors.DetailsFilter(),
 		op.Errors)
 	if err != nil {
 		return nil, clues.Wrap(err, "getting backup and details")
@@ -0,0 +1,140 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// leaseTTL is how long a lease outlives its last renewal.  A process which
+// dies without releasing its lease blocks conflicting operations for at
+// most this long.
+const leaseTTL = 15 * time.Minute
+
+var (
+	// leaseRenewInterval is how often a held lease gets renewed.  Kept well
+	// under the ttl so that a failed renewal can be retried before the lease
+	// expires.
+	leaseRenewInterval = 5 * time.Minute
+	// leaseRetryInterval is how long to wait between attempts to acquire a
+	// lease that's held by another operation.
+	leaseRetryInterval = 30 * time.Second
+)
+
+// leaser manages the leases on a repository.
+type leaser interface {
+	AcquireLease(
+		ctx context.Context,
+		kind kopia.LeaseKind,
+		purpose string,
+		ttl time.Duration,
+	) (*kopia.Lease, error)
+	RenewLease(ctx context.Context, l *kopia.Lease, ttl time.Duration) error
+	ReleaseLease(ctx context.Context, l *kopia.Lease) error
+}
+
+// leaseTTLFor caps the lease ttl at the deadline of the context, if it has
+// one.  A lambda that times out can't release its lease, and shouldn't
+// leave it behind for longer than the invocation could have run.
+func leaseTTLFor(ctx context.Context) time.Duration {
+	dl, ok := ctx.Deadline()
+	if !ok {
+		return leaseTTL
+	}
+
+	return max(min(leaseTTL, time.Until(dl)), time.Second)
+}
+
+// holdLease acquires a lease on the repository and renews it until the
+// returned release func is called.  If a conflicting lease is held, it
+// retries until maxWait passes, or until half the time left before the
+// context's deadline passes, whichever comes first.  A maxWait of 0 fails
+// on the first conflict.
+func holdLease(
+	ctx context.Context,
+	lr leaser,
+	kind kopia.LeaseKind,
+	purpose string,
+	maxWait time.Duration,
+) (func(), error) {
+	lease, err := acquireLease(ctx, lr, kind, purpose, maxWait)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		rctx, cancel = context.WithCancel(ctx)
+		done         = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+
+		t := time.NewTicker(leaseRenewInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-rctx.Done():
+				return
+			case <-t.C:
+			}
+
+			// a failed renewal leaves the lease in place until it expires,
+			// and the next tick tries again.
+			if err := lr.RenewLease(rctx, lease, leaseTTLFor(rctx)); err != nil {
+				logger.CtxErr(rctx, err).Info("renewing repository lease")
+			}
+		}
+	}()
+
+	release := func() {
+		cancel()
+		<-done
+
+		if err := lr.ReleaseLease(ctx, lease); err != nil {
+			logger.CtxErr(ctx, err).Info("releasing repository lease")
+		}
+	}
+
+	return release, nil
+}
+
+func acquireLease(
+	ctx context.Context,
+	lr leaser,
+	kind kopia.LeaseKind,
+	purpose string,
+	maxWait time.Duration,
+) (*kopia.Lease, error) {
+	// leave the rest of the invocation to the operation itself.
+	if dl, ok := ctx.Deadline(); ok {
+		maxWait = min(maxWait, time.Until(dl)/2)
+	}
+
+	giveUp := time.Now().Add(maxWait)
+
+	for {
+		lease, err := lr.AcquireLease(ctx, kind, purpose, leaseTTLFor(ctx))
+		if err == nil {
+			return lease, nil
+		}
+
+		if !errors.Is(err, kopia.ErrLeaseHeld) || !time.Now().Add(leaseRetryInterval).Before(giveUp) {
+			return nil, clues.Stack(err)
+		}
+
+		logger.CtxErr(ctx, err).Info("waiting for repository lease")
+
+		select {
+		case <-ctx.Done():
+			return nil, clues.Stack(err, ctx.Err())
+		case <-time.After(leaseRetryInterval):
+		}
+	}
+}
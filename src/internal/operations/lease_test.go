@@ -0,0 +1,215 @@
+package operations
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type mockLeaser struct {
+	mu sync.Mutex
+	// conflicts is the count of acquisitions that fail with ErrLeaseHeld
+	// before one succeeds.
+	conflicts int
+	acquired  int
+	renewed   int
+	released  int
+	ttls      []time.Duration
+}
+
+func (ml *mockLeaser) AcquireLease(
+	_ context.Context,
+	kind kopia.LeaseKind,
+	purpose string,
+	ttl time.Duration,
+) (*kopia.Lease, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	ml.ttls = append(ml.ttls, ttl)
+
+	if ml.conflicts > 0 {
+		ml.conflicts--
+		return nil, clues.Stack(kopia.ErrLeaseHeld)
+	}
+
+	ml.acquired++
+
+	return &kopia.Lease{ID: "id", Kind: kind, Purpose: purpose}, nil
+}
+
+func (ml *mockLeaser) RenewLease(context.Context, *kopia.Lease, time.Duration) error {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	ml.renewed++
+
+	return nil
+}
+
+func (ml *mockLeaser) ReleaseLease(context.Context, *kopia.Lease) error {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	ml.released++
+
+	return nil
+}
+
+type LeaseUnitSuite struct {
+	tester.Suite
+}
+
+func TestLeaseUnitSuite(t *testing.T) {
+	suite.Run(t, &LeaseUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *LeaseUnitSuite) SetupSuite() {
+	leaseRetryInterval = 10 * time.Millisecond
+	leaseRenewInterval = 10 * time.Millisecond
+}
+
+func (suite *LeaseUnitSuite) TearDownSuite() {
+	leaseRetryInterval = 30 * time.Second
+	leaseRenewInterval = 5 * time.Minute
+}
+
+func (suite *LeaseUnitSuite) TestHoldLease() {
+	table := []struct {
+		name         string
+		conflicts    int
+		maxWait      time.Duration
+		expectErr    assert.ErrorAssertionFunc
+		expectTTLs   int
+		expectHolder bool
+	}{
+		{
+			name:         "no conflict",
+			maxWait:      time.Minute,
+			expectErr:    assert.NoError,
+			expectTTLs:   1,
+			expectHolder: true,
+		},
+		{
+			name:         "waits out conflicts",
+			conflicts:    2,
+			maxWait:      time.Minute,
+			expectErr:    assert.NoError,
+			expectTTLs:   3,
+			expectHolder: true,
+		},
+		{
+			name:       "no wait",
+			conflicts:  1,
+			expectErr:  assert.Error,
+			expectTTLs: 1,
+		},
+		{
+			name:       "gives up",
+			conflicts:  1000,
+			maxWait:    50 * time.Millisecond,
+			expectErr:  assert.Error,
+			expectTTLs: 2,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ml := &mockLeaser{conflicts: test.conflicts}
+
+			release, err := holdLease(ctx, ml, kopia.SharedLease, "backup", test.maxWait)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if !test.expectHolder {
+				assert.ErrorIs(t, err, kopia.ErrLeaseHeld, clues.ToCore(err))
+				assert.Zero(t, ml.acquired)
+
+				if test.maxWait == 0 {
+					assert.Len(t, ml.ttls, test.expectTTLs)
+				} else {
+					assert.GreaterOrEqual(t, len(ml.ttls), test.expectTTLs, "retried")
+				}
+
+				return
+			}
+
+			require.NotNil(t, release)
+			assert.Len(t, ml.ttls, test.expectTTLs)
+
+			for _, ttl := range ml.ttls {
+				assert.Equal(t, leaseTTL, ttl, "no deadline")
+			}
+
+			release()
+
+			assert.Equal(t, 1, ml.acquired)
+			assert.Equal(t, 1, ml.released)
+		})
+	}
+}
+
+func (suite *LeaseUnitSuite) TestHoldLease_renews() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ml := &mockLeaser{}
+
+	release, err := holdLease(ctx, ml, kopia.SharedLease, "backup", 0)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Eventually(
+		t,
+		func() bool {
+			ml.mu.Lock()
+			defer ml.mu.Unlock()
+
+			return ml.renewed > 1
+		},
+		time.Second,
+		5*time.Millisecond)
+
+	release()
+
+	renewed := ml.renewed
+
+	time.Sleep(5 * leaseRenewInterval)
+
+	assert.Equal(t, renewed, ml.renewed, "no renewals after release")
+	assert.Equal(t, 1, ml.released)
+}
+
+func (suite *LeaseUnitSuite) TestLeaseTTLFor() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	assert.Equal(t, leaseTTL, leaseTTLFor(ctx), "no deadline")
+
+	dctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	ttl := leaseTTLFor(dctx)
+	assert.LessOrEqual(t, ttl, time.Minute, "capped at the deadline")
+	assert.Greater(t, ttl, 50*time.Second)
+
+	lctx, lcancel := context.WithTimeout(ctx, 2*leaseTTL)
+	defer lcancel()
+
+	assert.Equal(t, leaseTTL, leaseTTLFor(lctx), "deadline past the ttl")
+}
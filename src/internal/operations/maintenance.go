@@ -2,18 +2,23 @@ package operations
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/alcionai/clues"
+	"github.com/google/uuid"
 
 	"github.com/alcionai/corso/src/internal/common/crash"
 	"github.com/alcionai/corso/src/internal/events"
 	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/maintenance"
 	"github.com/alcionai/corso/src/pkg/store"
 )
 
@@ -27,6 +32,11 @@ type MaintenanceOperation struct {
 // MaintenanceResults aggregate the details of the results of the operation.
 type MaintenanceResults struct {
 	stats.StartAndEndTime
+	// BlobsDeleted and BytesReclaimed are the net reduction in the blobs
+	// held by the repository storage.  Only populated if the maintenance
+	// options ask for the storage to be measured.
+	BlobsDeleted   int64 `json:"blobsDeleted"`
+	BytesReclaimed int64 `json:"bytesReclaimed"`
 }
 
 // NewMaintenanceOperation constructs and validates a maintenance operation.
@@ -70,7 +80,14 @@ func (op *MaintenanceOperation) Run(ctx context.Context) (err error) {
 			})
 	}()
 
-	return op.do(ctx)
+	err = op.do(ctx)
+
+	// recording the run is best-effort; the maintenance itself is done.
+	if perr := op.persistResults(ctx, err); perr != nil {
+		logger.CtxErr(ctx, perr).Info("recording maintenance results")
+	}
+
+	return err
 }
 
 func (op *MaintenanceOperation) do(ctx context.Context) error {
@@ -78,7 +95,36 @@ func (op *MaintenanceOperation) do(ctx context.Context) error {
 		op.Results.CompletedAt = time.Now()
 	}()
 
-	err := op.operation.kopia.RepoMaintenance(ctx, op.store, op.mOpts)
+	// backups hold a shared lease while they run.  Maintenance doesn't wait
+	// for them; it gets skipped, and the next scheduled run tries again.
+	releaseLease, err := holdLease(ctx, op.operation.kopia, kopia.ExclusiveLease, "maintenance", 0)
+	if err != nil {
+		if errors.Is(err, kopia.ErrLeaseHeld) {
+			op.Status = Skipped
+		} else {
+			op.Status = Failed
+		}
+
+		return clues.Wrap(err, "acquiring repository lease")
+	}
+
+	defer releaseLease()
+
+	var (
+		before kopia.StorageStats
+		serr   error
+	)
+
+	// storage stats are informational, so failing to get them doesn't stop
+	// maintenance.
+	if op.mOpts.MeasureStorage {
+		before, serr = op.operation.kopia.StorageStats(ctx)
+		if serr != nil {
+			logger.CtxErr(ctx, serr).Info("getting storage stats before maintenance")
+		}
+	}
+
+	err = op.operation.kopia.RepoMaintenance(ctx, op.store, op.mOpts)
 	if err != nil {
 		op.Status = Failed
 		return clues.Wrap(err, "running maintenance operation")
@@ -86,5 +132,42 @@ func (op *MaintenanceOperation) do(ctx context.Context) error {
 
 	op.Status = Completed
 
+	if !op.mOpts.MeasureStorage || serr != nil {
+		return nil
+	}
+
+	after, err := op.operation.kopia.StorageStats(ctx)
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("getting storage stats after maintenance")
+		return nil
+	}
+
+	op.Results.BlobsDeleted = before.Blobs - after.Blobs
+	op.Results.BytesReclaimed = before.Bytes - after.Bytes
+
 	return nil
 }
+
+// persistResults records the outcome of the run in the model store.
+func (op *MaintenanceOperation) persistResults(ctx context.Context, runErr error) error {
+	r := &maintenance.Result{
+		BaseModel: model.BaseModel{
+			ID: model.StableID(uuid.NewString()),
+		},
+		StartAndEndTime: op.Results.StartAndEndTime,
+		Type:            op.mOpts.Type,
+		Force:           op.mOpts.Force,
+		Status:          op.Status.String(),
+		StorageMeasured: op.mOpts.MeasureStorage,
+		BlobsDeleted:    op.Results.BlobsDeleted,
+		BytesReclaimed:  op.Results.BytesReclaimed,
+	}
+
+	if runErr != nil {
+		r.Failure = runErr.Error()
+	}
+
+	err := op.store.Put(ctx, model.MaintenanceSchema, r)
+
+	return clues.Wrap(err, "persisting maintenance result").OrNil()
+}
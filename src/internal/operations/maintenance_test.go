@@ -94,6 +94,44 @@ func (suite *MaintenanceOpIntegrationSuite) TestRepoMaintenance() {
 
 	err = mo.Run(ctx)
 	assert.NoError(t, err, clues.ToCore(err))
+
+	rs, err := store.NewWrapper(ms).GetMaintenanceResults(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, rs, 1)
+
+	assert.Equal(t, repository.MetadataMaintenance, rs[0].Type)
+	assert.Equal(t, Completed.String(), rs[0].Status)
+	assert.Empty(t, rs[0].Failure)
+	assert.False(t, rs[0].StorageMeasured, "storage measurement is opt-in")
+	assert.False(t, rs[0].StartedAt.IsZero())
+	assert.False(t, rs[0].CompletedAt.IsZero())
+
+	// maintenance is skipped while a backup holds the repository.
+	lease, err := kw.AcquireLease(ctx, kopia.SharedLease, "backup", time.Hour)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer kw.ReleaseLease(ctx, lease)
+
+	mo, err = NewMaintenanceOperation(
+		ctx,
+		control.DefaultOptions(),
+		kw,
+		store.NewWrapper(ms),
+		repository.Maintenance{
+			Type: repository.MetadataMaintenance,
+		},
+		evmock.NewBus())
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = mo.Run(ctx)
+	assert.ErrorIs(t, err, kopia.ErrLeaseHeld, clues.ToCore(err))
+
+	rs, err = store.NewWrapper(ms).GetMaintenanceResults(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, rs, 2)
+
+	assert.Equal(t, Skipped.String(), rs[0].Status)
+	assert.NotEmpty(t, rs[0].Failure)
 }
 
 type MaintenanceOpNightlySuite struct {
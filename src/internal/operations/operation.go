@@ -16,7 +16,7 @@ import (
 // OpStatus describes the current status of an operation.
 // InProgress - the standard value for any process that has not
 // arrived at an end state.  The end states are Failed, Completed,
-// or NoData.
+// NoData, or Skipped.
 //
 // Failed - the operation was unable to begin processing data at all.
 // No items have been written by the consumer.
@@ -29,6 +29,10 @@ import (
 // For example, if a backup is requested for a specific user's
 // mail, but that account contains zero mail messages, the backup
 // contains No Data.
+//
+// Skipped - the operation didn't run because another operation held
+// the repository.  For example, maintenance is skipped while a backup
+// is running.
 type OpStatus int
 
 //go:generate stringer -type=OpStatus -linecomment
@@ -38,6 +42,7 @@ const (
 	Completed  OpStatus = 2 // Completed
 	Failed     OpStatus = 3 // Failed
 	NoData     OpStatus = 4 // No Data
+	Skipped    OpStatus = 5 // Skipped
 )
 
 // --------------------------------------------------------------------------------
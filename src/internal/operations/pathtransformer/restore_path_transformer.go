@@ -143,6 +143,11 @@ func makeRestorePathsForEntry(
 	switch true {
 	case ent.Exchange != nil ||
 		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsChannelMessage) ||
+		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsConversationPost) ||
+		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsPlannerTask) ||
+		ent.TeamsChats != nil ||
+		ent.OneNote != nil ||
+		ent.EntraID != nil ||
 		(ent.SharePoint != nil && ent.SharePoint.ItemType == details.SharePointList):
 		// TODO(ashmrtn): Eventually make Events have it's own function to handle
 		// setting the restore destination properly.
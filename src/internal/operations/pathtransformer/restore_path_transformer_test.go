@@ -281,6 +281,30 @@ func (suite *RestorePathTransformerUnitSuite) TestGetPaths() {
 				},
 			},
 		},
+		{
+			name:          "Groups Conversation Post",
+			backupVersion: version.Groups9Update,
+			input: []*details.Entry{
+				{
+					RepoRef:     testdata.GroupsConversationPostPath.RR.String(),
+					LocationRef: testdata.GroupsConversationPostPath.Loc.String(),
+					ItemInfo: details.ItemInfo{
+						Groups: &details.GroupsInfo{
+							ItemType: details.GroupsConversationPost,
+						},
+					},
+				},
+			},
+			expectErr: assert.NoError,
+			expected: []expectPaths{
+				{
+					storage: testdata.GroupsConversationPostPath.RR.String(),
+					restore: toRestore(
+						testdata.GroupsConversationPostPath.RR,
+						testdata.GroupsConversationPostPath.Loc.Elements()...),
+				},
+			},
+		},
 		{
 			name:          "Exchange Email, extra / in path",
 			backupVersion: version.All8MigrateUserPNToID,
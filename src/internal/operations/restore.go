@@ -222,6 +222,7 @@ func (op *RestoreOperation) do(
 		op.BackupID,
 		op.store,
 		detailsStore,
+		op.Selectors.DetailsFilter(),
 		op.Errors)
 	if err != nil {
 		return nil, clues.Wrap(err, "getting backup and details")
@@ -362,14 +363,18 @@ func chooseRestoreResource(
 	restoreCfg control.RestoreConfig,
 	orig idname.Provider,
 ) (idname.Provider, error) {
-	if len(restoreCfg.ProtectedResource) == 0 {
-		return orig, nil
+	target := restoreCfg.ProtectedResource
+
+	if len(target) == 0 {
+		mapped, ok := restoreCfg.ResourceMapping.Get(orig.ID(), orig.Name())
+		if !ok {
+			return orig, nil
+		}
+
+		target = mapped
 	}
 
-	resource, err := pprian.PopulateProtectedResourceIDAndName(
-		ctx,
-		restoreCfg.ProtectedResource,
-		nil)
+	resource, err := pprian.PopulateProtectedResourceIDAndName(ctx, target, nil)
 
 	return resource, clues.Stack(err).OrNil()
 }
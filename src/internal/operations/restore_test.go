@@ -146,6 +146,12 @@ func (suite *RestoreOpUnitSuite) TestChooseRestoreResource() {
 
 	cfgWithPR.ProtectedResource = "cfgid"
 
+	cfgWithMapping := control.DefaultRestoreConfig(dttm.HumanReadable)
+	cfgWithMapping.ResourceMapping = control.ResourceMapping{"oname": "mapped"}
+
+	cfgWithPRAndMapping := cfgWithMapping
+	cfgWithPRAndMapping.ProtectedResource = "cfgid"
+
 	table := []struct {
 		name           string
 		cfg            control.RestoreConfig
@@ -180,6 +186,42 @@ func (suite *RestoreOpUnitSuite) TestChooseRestoreResource() {
 			expectID:   id,
 			expectName: name,
 		},
+		{
+			name: "look up mapped resource",
+			cfg:  cfgWithMapping,
+			ctrl: &mock.Controller{
+				ProtectedResourceID:   id,
+				ProtectedResourceName: name,
+			},
+			orig:       idname.NewProvider("oid", "oname"),
+			expectErr:  assert.NoError,
+			expectID:   id,
+			expectName: name,
+		},
+		{
+			name: "unmapped resource uses original",
+			cfg:  cfgWithMapping,
+			ctrl: &mock.Controller{
+				ProtectedResourceID:   id,
+				ProtectedResourceName: name,
+			},
+			orig:       idname.NewProvider("otherid", "othername"),
+			expectErr:  assert.NoError,
+			expectID:   "otherid",
+			expectName: "othername",
+		},
+		{
+			name: "protected resource overrides mapping",
+			cfg:  cfgWithPRAndMapping,
+			ctrl: &mock.Controller{
+				ProtectedResourceID:   id,
+				ProtectedResourceName: name,
+			},
+			orig:       idname.NewProvider("oid", "oname"),
+			expectErr:  assert.NoError,
+			expectID:   id,
+			expectName: name,
+		},
 		{
 			name: "error looking up protected resource",
 			cfg:  cfgWithPR,
@@ -0,0 +1,188 @@
+package operations
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/streamstore"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/search"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// indexCollections wraps each collection so that the content of its items
+// gets added to the search index as it's uploaded.  Returns the collections
+// unchanged if there's no index to build.
+func indexCollections(
+	cs []data.BackupCollection,
+	sb *search.Builder,
+) []data.BackupCollection {
+	if sb == nil {
+		return cs
+	}
+
+	res := make([]data.BackupCollection, 0, len(cs))
+
+	for _, c := range cs {
+		ic := indexedCollection{
+			BackupCollection: c,
+			sb:               sb,
+		}
+
+		// kopia checks for these interfaces on the collection, so the wrapper
+		// must implement exactly the same set as the collection it wraps.
+		switch c.(type) {
+		case data.PreviousLocationPather:
+			res = append(res, indexedPrevLocCollection{ic})
+		case data.LocationPather:
+			res = append(res, indexedLocCollection{ic})
+		default:
+			res = append(res, ic)
+		}
+	}
+
+	return res
+}
+
+// readBaseSearchIndex reads the terms of the items carried over from the
+// base backup out of the base's search index, so that they keep the terms
+// of their content.  If the base has no index, or it can't be read, the
+// carried items are only indexed by their details values.
+func readBaseSearchIndex(
+	ctx context.Context,
+	sb *search.Builder,
+	bup *backup.Backup,
+	detailsStore streamstore.Reader,
+) {
+	if sb == nil {
+		return
+	}
+
+	br := sb.BaseReader(string(bup.ID))
+
+	if !bup.HasSearchIndex || len(bup.StreamStoreID) == 0 {
+		return
+	}
+
+	// the index is an optional addition to the backup, so failing to read
+	// it isn't reported as an error in this backup.
+	err := detailsStore.Read(
+		ctx,
+		bup.StreamStoreID,
+		streamstore.SearchIndexReader(br),
+		fault.New(true))
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("reading search index of base backup")
+	}
+}
+
+type indexedCollection struct {
+	data.BackupCollection
+	sb *search.Builder
+}
+
+// Items forwards the items of the wrapped collection.  Items that produce
+// details entries get their readers wrapped by the search index.
+func (c indexedCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	var (
+		res   = make(chan data.Item)
+		items = c.BackupCollection.Items(ctx, errs)
+	)
+
+	go func() {
+		defer close(res)
+
+		for item := range items {
+			res <- c.wrap(ctx, item)
+		}
+	}()
+
+	return res
+}
+
+func (c indexedCollection) wrap(ctx context.Context, item data.Item) data.Item {
+	if item.Deleted() {
+		return item
+	}
+
+	// metadata files don't appear in details, and have nothing to search.
+	infoer, ok := item.(data.ItemInfo)
+	if !ok {
+		return item
+	}
+
+	modTimer, ok := item.(data.ItemModTime)
+	if !ok {
+		return item
+	}
+
+	fp := c.FullPath()
+	if fp == nil {
+		return item
+	}
+
+	p, err := fp.AppendItem(item.ID())
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("skipping search indexing of item")
+		return item
+	}
+
+	return indexedItem{
+		Item:     item,
+		infoer:   infoer,
+		modTimer: modTimer,
+		repoRef:  p.String(),
+		sb:       c.sb,
+	}
+}
+
+type indexedLocCollection struct {
+	indexedCollection
+}
+
+func (c indexedLocCollection) LocationPath() *path.Builder {
+	return c.BackupCollection.(data.LocationPather).LocationPath()
+}
+
+type indexedPrevLocCollection struct {
+	indexedCollection
+}
+
+func (c indexedPrevLocCollection) LocationPath() *path.Builder {
+	return c.BackupCollection.(data.PreviousLocationPather).LocationPath()
+}
+
+func (c indexedPrevLocCollection) PreviousLocationPath() details.LocationIDer {
+	return c.BackupCollection.(data.PreviousLocationPather).PreviousLocationPath()
+}
+
+// indexedItem hands the item's reader to the search index.  Only items
+// that implement both ItemInfo and ItemModTime get wrapped, since kopia
+// checks for each of them.
+type indexedItem struct {
+	data.Item
+	infoer   data.ItemInfo
+	modTimer data.ItemModTime
+	repoRef  string
+	sb       *search.Builder
+}
+
+func (i indexedItem) ToReader() io.ReadCloser {
+	return i.sb.Reader(i.repoRef, i.Item.ToReader())
+}
+
+func (i indexedItem) Info() (details.ItemInfo, error) {
+	return i.infoer.Info()
+}
+
+func (i indexedItem) ModTime() time.Time {
+	return i.modTimer.ModTime()
+}
@@ -0,0 +1,223 @@
+package operations
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/streamstore"
+	ssmock "github.com/alcionai/corso/src/internal/streamstore/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/backup/search"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type SearchIndexUnitSuite struct {
+	tester.Suite
+}
+
+func TestSearchIndexUnitSuite(t *testing.T) {
+	suite.Run(t, &SearchIndexUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SearchIndexUnitSuite) TestIndexCollections() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	fp, err := path.Build("tid", "uid", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		sb   = search.NewBuilder()
+		coll = dataMock.Collection{
+			Path: fp,
+			Loc:  path.Builder{}.Append("inbox"),
+			ItemData: []data.Item{
+				&dataMock.Item{
+					ItemID: "m1",
+					Reader: io.NopCloser(bytes.NewBufferString("project falcon")),
+				},
+				&dataMock.Item{
+					ItemID:      "m2",
+					DeletedFlag: true,
+				},
+			},
+		}
+	)
+
+	cs := indexCollections([]data.BackupCollection{coll}, nil)
+	assert.Equal(t, coll, cs[0], "collections aren't wrapped without an index")
+
+	cs = indexCollections([]data.BackupCollection{coll}, sb)
+	require.Len(t, cs, 1)
+
+	_, ok := cs[0].(data.LocationPather)
+	assert.True(t, ok, "wrapped collection is a location pather")
+
+	_, ok = cs[0].(data.PreviousLocationPather)
+	assert.False(t, ok, "wrapped collection is not a previous location pather")
+
+	for item := range cs[0].Items(ctx, fault.New(true)) {
+		if item.Deleted() {
+			continue
+		}
+
+		_, ok := item.(data.ItemInfo)
+		assert.True(t, ok, "wrapped item has info")
+
+		_, ok = item.(data.ItemModTime)
+		assert.True(t, ok, "wrapped item has a mod time")
+
+		_, err := io.Copy(io.Discard, item.ToReader())
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	repoRef, err := fp.AppendItem("m1")
+	require.NoError(t, err, clues.ToCore(err))
+
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{{
+				RepoRef: repoRef.String(),
+				ItemInfo: details.ItemInfo{
+					Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+				},
+			}},
+		},
+	}
+
+	assert.Equal(
+		t,
+		[]string{repoRef.String()},
+		searchShards(ctx, t, sb.Shards(deets, search.DefaultShardSize), "falcon"))
+}
+
+func (suite *SearchIndexUnitSuite) TestMergeDetails_carriesSearchTerms() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		prefix    = []string{"tid", path.ExchangeService.String(), "uid", path.EmailCategory.String()}
+		unchanged = makePath(t, append(append([]string{}, prefix...), "work", "m1"), true)
+		moved     = makePath(t, append(append([]string{}, prefix...), "work", "m2"), true)
+		movedTo   = makePath(t, append(append([]string{}, prefix...), "archive", "m2"), true)
+		fresh     = makePath(t, append(append([]string{}, prefix...), "work", "m3"), true)
+		workLoc   = path.Builder{}.Append("work")
+		archLoc   = path.Builder{}.Append("archive")
+
+		base = kopia.BackupBase{
+			Backup: &backup.Backup{
+				BaseModel:      model.BaseModel{ID: "bid1"},
+				StreamStoreID:  "ssid1",
+				HasSearchIndex: true,
+			},
+			Reasons: []identity.Reasoner{
+				identity.NewReason("", "uid", path.ExchangeService, path.EmailCategory),
+			},
+		}
+
+		baseDeets = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{
+					*makeDetailsEntry(t, unchanged, workLoc, 42, false),
+					*makeDetailsEntry(t, moved, workLoc, 42, false),
+				},
+			},
+		}
+	)
+
+	// the index of the base backup, as built when it ran.
+	baseSB := search.NewBuilder()
+	readAllContent(t, baseSB, unchanged.String(), "numbers for project falcon")
+	readAllContent(t, baseSB, moved.String(), "falcon team lunch")
+
+	mds := ssmock.Streamer{
+		Deets: map[string]*details.Details{"ssid1": baseDeets},
+		Indexes: map[string]*search.ShardWriter{
+			"ssid1": baseSB.Shards(baseDeets, search.DefaultShardSize),
+		},
+	}
+
+	// the incremental only reads the content of the new item.
+	var (
+		sb    = search.NewBuilder()
+		deets = &details.Builder{}
+		mdm   = newMockDetailsMergeInfoer()
+	)
+
+	readAllContent(t, sb, fresh.String(), "falcon sightings")
+
+	err := deets.Add(fresh, workLoc, details.ItemInfo{
+		Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+	})
+	require.NoError(t, err, clues.ToCore(err))
+
+	mdm.add(unchanged, unchanged, workLoc)
+	mdm.add(moved, movedTo, archLoc)
+
+	err = mergeDetails(
+		ctx,
+		mds,
+		kopia.NewMockBackupBases().WithMergeBases(base),
+		mdm,
+		deets,
+		&kopia.BackupStats{},
+		path.ExchangeService,
+		sb,
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	sw := sb.Shards(deets.Details(), search.DefaultShardSize)
+
+	assert.Equal(
+		t,
+		[]string{movedTo.String(), unchanged.String(), fresh.String()},
+		searchShards(ctx, t, sw, "falcon"),
+		"unchanged items still match their content")
+	assert.Equal(t, []string{unchanged.String()}, searchShards(ctx, t, sw, "project"))
+	assert.Equal(t, []string{movedTo.String()}, searchShards(ctx, t, sw, "lunch"))
+	assert.Equal(t, []string{fresh.String()}, searchShards(ctx, t, sw, "sightings"))
+}
+
+func searchShards(
+	ctx context.Context,
+	t *testing.T,
+	sw *search.ShardWriter,
+	query string,
+) []string {
+	var (
+		s   = search.NewSearcher(query)
+		mds = ssmock.Streamer{Indexes: map[string]*search.ShardWriter{"ssid": sw}}
+	)
+
+	err := mds.Read(ctx, "ssid", streamstore.SearchIndexReader(s), fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	return s.Results()
+}
+
+func readAllContent(t *testing.T, sb *search.Builder, repoRef, content string) {
+	rc := sb.Reader(repoRef, io.NopCloser(bytes.NewBufferString(content)))
+
+	_, err := io.Copy(io.Discard, rc)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NoError(t, rc.Close())
+}
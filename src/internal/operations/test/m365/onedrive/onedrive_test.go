@@ -321,7 +321,11 @@ func (suite *OneDriveBackupIntgSuite) TestBackup_Run_oneDriveOwnerMigration() {
 		ss    = streamstore.NewStreamer(bod.KW, creds.AzureTenantID, path.OneDriveService)
 	)
 
-	err = ss.Read(ctx, ssid, streamstore.DetailsReader(details.UnmarshalTo(&deets)), fault.New(true))
+	err = ss.Read(
+		ctx,
+		ssid,
+		streamstore.ChunkedDetailsReader(details.NewChunkReader(&deets, details.ChunkFilter{})),
+		fault.New(true))
 	require.NoError(t, err, clues.ToCore(err))
 
 	for _, ent := range deets.Entries {
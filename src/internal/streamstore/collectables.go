@@ -1,11 +1,13 @@
 package streamstore
 
 type Collectable struct {
-	mr       Marshaller
-	Unmr     Unmarshaller
-	itemName string
-	purpose  string
-	Type     string
+	mr        Marshaller
+	Unmr      Unmarshaller
+	chunkMr   ChunkMarshaller
+	ChunkUnmr ChunkUnmarshaller
+	itemName  string
+	purpose   string
+	Type      string
 }
 
 const (
@@ -16,6 +18,17 @@ const (
 	DetailsType     = "details"
 	detailsItemName = "details"
 	detailsPurpose  = "details"
+
+	// chunked details share the details folder.  The item name
+	// refers to the index; chunks are stored beside it.
+	ChunkedDetailsType     = "chunked_details"
+	chunkedDetailsItemName = "details_index"
+
+	// the item name of the search index refers to its shard index; the
+	// shards are stored beside it.
+	SearchIndexType     = "search_index"
+	searchIndexItemName = "search_index"
+	searchIndexPurpose  = "search_index"
 )
 
 // FaultErrorsCollector generates a collection of fault.Errors
@@ -40,6 +53,29 @@ func DetailsCollector(mr Marshaller) Collectable {
 	}
 }
 
+// ChunkedDetailsCollector generates a collection holding the index and
+// chunks of details.Details produced by the provided chunk marshaller.
+func ChunkedDetailsCollector(cm ChunkMarshaller) Collectable {
+	return Collectable{
+		chunkMr:  cm,
+		itemName: chunkedDetailsItemName,
+		purpose:  detailsPurpose,
+		Type:     ChunkedDetailsType,
+	}
+}
+
+// SearchIndexCollector generates a collection holding the shard index and
+// shards of a backup's search index produced by the provided chunk
+// marshaller.
+func SearchIndexCollector(cm ChunkMarshaller) Collectable {
+	return Collectable{
+		chunkMr:  cm,
+		itemName: searchIndexItemName,
+		purpose:  searchIndexPurpose,
+		Type:     SearchIndexType,
+	}
+}
+
 // FaultErrorsReader reads a collection of fault.Errors
 // entries using the provided unmarshaller.
 func FaultErrorsReader(unmr Unmarshaller) Collectable {
@@ -61,3 +97,25 @@ func DetailsReader(unmr Unmarshaller) Collectable {
 		Type:     DetailsType,
 	}
 }
+
+// ChunkedDetailsReader reads the index of chunked details.Details, and
+// then the chunks selected by the provided chunk unmarshaller.
+func ChunkedDetailsReader(cu ChunkUnmarshaller) Collectable {
+	return Collectable{
+		ChunkUnmr: cu,
+		itemName:  chunkedDetailsItemName,
+		purpose:   detailsPurpose,
+		Type:      ChunkedDetailsType,
+	}
+}
+
+// SearchIndexReader reads the shard index of a backup's search index, and
+// then the shards selected by the provided chunk unmarshaller.
+func SearchIndexReader(cu ChunkUnmarshaller) Collectable {
+	return Collectable{
+		ChunkUnmr: cu,
+		itemName:  searchIndexItemName,
+		purpose:   searchIndexPurpose,
+		Type:      SearchIndexType,
+	}
+}
@@ -237,3 +237,83 @@ func (suite *StreamStoreIntgSuite) TestStreamer() {
 		})
 	}
 }
+
+func (suite *StreamStoreIntgSuite) TestStreamer_chunkedDetails() {
+	// the streamer is only set up for subtests.
+	suite.Run("chunked details", func() {
+		var (
+			t            = suite.T()
+			ss           = suite.ss
+			deetsBuilder = &details.Builder{}
+		)
+
+		ctx, flush := tester.NewContext(t)
+		defer flush()
+
+		for _, ref := range []string{
+			"tenant-id/exchange/user-id/email/Inbox/folder1/foo",
+			"tenant-id/exchange/user-id/email/Inbox/folder1/bar",
+			"tenant-id/exchange/user-id/events/Calendar/baz",
+		} {
+			p, err := path.FromDataLayerPath(ref, true)
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = deetsBuilder.Add(
+				p,
+				path.Builder{}.Append(p.Folders()...),
+				details.ItemInfo{
+					Exchange: &details.ExchangeInfo{
+						ItemType: details.ExchangeMail,
+						Subject:  p.Item(),
+					},
+				})
+			require.NoError(t, err, clues.ToCore(err))
+		}
+
+		deets := deetsBuilder.Details()
+
+		err := ss.Collect(ctx, ChunkedDetailsCollector(details.NewChunkWriter(deets, 1)))
+		require.NoError(t, err, clues.ToCore(err))
+
+		snapid, err := ss.Write(ctx, fault.New(true))
+		require.NoError(t, err, clues.ToCore(err))
+		require.NotEmpty(t, snapid)
+
+		var all details.Details
+
+		err = ss.Read(
+			ctx,
+			snapid,
+			ChunkedDetailsReader(details.NewChunkReader(&all, details.ChunkFilter{})),
+			fault.New(true))
+		require.NoError(t, err, clues.ToCore(err))
+		assert.ElementsMatch(t, deets.Entries, all.Entries)
+
+		var emails details.Details
+
+		err = ss.Read(
+			ctx,
+			snapid,
+			ChunkedDetailsReader(details.NewChunkReader(
+				&emails,
+				details.ChunkFilter{Categories: []path.CategoryType{path.EmailCategory}})),
+			fault.New(true))
+		require.NoError(t, err, clues.ToCore(err))
+
+		for _, de := range emails.Entries {
+			assert.Contains(t, de.RepoRef, "/email/")
+		}
+
+		assert.Less(t, len(emails.Entries), len(all.Entries))
+
+		// legacy readers don't find chunked details.
+		var legacy details.Details
+
+		err = ss.Read(
+			ctx,
+			snapid,
+			DetailsReader(details.UnmarshalTo(&legacy)),
+			fault.New(true))
+		assert.Error(t, err, clues.ToCore(err))
+	})
+}
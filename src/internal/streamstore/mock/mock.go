@@ -9,14 +9,16 @@ import (
 
 	"github.com/alcionai/corso/src/internal/streamstore"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/search"
 	"github.com/alcionai/corso/src/pkg/fault"
 )
 
 var _ streamstore.Streamer = &Streamer{}
 
 type Streamer struct {
-	Deets  map[string]*details.Details
-	Errors map[string]*fault.Errors
+	Deets   map[string]*details.Details
+	Errors  map[string]*fault.Errors
+	Indexes map[string]*search.ShardWriter
 }
 
 func (ms Streamer) Collect(context.Context, streamstore.Collectable) error {
@@ -37,6 +39,22 @@ func (ms Streamer) Read(
 	var mr streamstore.Marshaller
 
 	switch col.Type {
+	case streamstore.ChunkedDetailsType:
+		deets := ms.Deets[snapshotID]
+		if deets == nil {
+			return clues.NewWC(ctx, "collectable "+col.Type+" has no marshaller")
+		}
+
+		return readChunks(details.NewChunkWriter(deets, details.DefaultChunkSize), col.ChunkUnmr)
+
+	case streamstore.SearchIndexType:
+		sw := ms.Indexes[snapshotID]
+		if sw == nil {
+			return clues.NewWC(ctx, "collectable "+col.Type+" has no marshaller")
+		}
+
+		return readChunks(sw, col.ChunkUnmr)
+
 	case streamstore.DetailsType:
 		mr = ms.Deets[snapshotID]
 	case streamstore.FaultErrorsType:
@@ -57,6 +75,31 @@ func (ms Streamer) Read(
 	return col.Unmr(io.NopCloser(bytes.NewReader(bs)))
 }
 
+func readChunks(cm streamstore.ChunkMarshaller, cu streamstore.ChunkUnmarshaller) error {
+	bs, err := cm.MarshalIndex()
+	if err != nil {
+		return err
+	}
+
+	names, err := cu.UnmarshalIndex(io.NopCloser(bytes.NewReader(bs)))
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		bs, err := cm.MarshalChunk(name)
+		if err != nil {
+			return err
+		}
+
+		if err := cu.UnmarshalChunk(name, io.NopCloser(bytes.NewReader(bs))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (ms Streamer) Write(context.Context, *fault.Bus) (string, error) {
 	return "", clues.New("not implemented")
 }
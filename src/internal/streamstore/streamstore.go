@@ -120,17 +120,34 @@ type Marshaller interface {
 // Unmarshallers are used to serialize the bytes in the store into the original struct.
 type Unmarshaller func(io.ReadCloser) error
 
+// ChunkMarshallers are used to persist large structs as an index plus a
+// set of chunks, so that readers can load only the chunks they need.
+type ChunkMarshaller interface {
+	MarshalIndex() ([]byte, error)
+	ChunkNames() []string
+	MarshalChunk(name string) ([]byte, error)
+}
+
+// ChunkUnmarshallers read the index produced by a ChunkMarshaller, pick
+// the chunks that need to be read, and then serialize each of those
+// chunks back into the original struct.
+type ChunkUnmarshaller interface {
+	// UnmarshalIndex returns the names of the chunks to read.
+	UnmarshalIndex(io.ReadCloser) ([]string, error)
+	UnmarshalChunk(name string, rc io.ReadCloser) error
+}
+
 // ---------------------------------------------------------------------------
 // collection
 // ---------------------------------------------------------------------------
 
 // streamCollection is a data.BackupCollection used to persist
-// a single data stream
+// a single data stream, or the index and chunks of a chunked stream.
 type streamCollection struct {
 	// folderPath indicates what level in the hierarchy this collection
 	// represents
 	folderPath path.Path
-	item       data.Item
+	items      []data.Item
 }
 
 func (dc *streamCollection) FullPath() path.Path {
@@ -149,12 +166,15 @@ func (dc *streamCollection) DoNotMergeItems() bool {
 	return false
 }
 
-// Items() always returns a channel with a single data.Item
-// representing the object to be persisted
+// Items() returns a channel with the data.Items representing the
+// object to be persisted
 func (dc *streamCollection) Items(context.Context, *fault.Bus) <-chan data.Item {
-	items := make(chan data.Item, 1)
+	items := make(chan data.Item, len(dc.items))
 	defer close(items)
-	items <- dc.item
+
+	for _, item := range dc.items {
+		items <- item
+	}
 
 	return items
 }
@@ -176,6 +196,15 @@ func collect(
 		return nil, clues.StackWC(ctx, err)
 	}
 
+	if col.chunkMr != nil {
+		items, err := collectChunks(ctx, col)
+		if err != nil {
+			return nil, err
+		}
+
+		return &streamCollection{folderPath: p, items: items}, nil
+	}
+
 	// TODO: We could use an io.Pipe here to avoid a double copy but that
 	// makes error handling a bit complicated
 	bs, err := col.mr.Marshal()
@@ -193,12 +222,57 @@ func collect(
 
 	dc := streamCollection{
 		folderPath: p,
-		item:       item,
+		items:      []data.Item{item},
 	}
 
 	return &dc, nil
 }
 
+// collectChunks produces one item for the index of a chunked collectable,
+// plus one item per chunk.
+func collectChunks(
+	ctx context.Context,
+	col Collectable,
+) ([]data.Item, error) {
+	bs, err := col.chunkMr.MarshalIndex()
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "marshalling index")
+	}
+
+	var (
+		names = col.chunkMr.ChunkNames()
+		items = make([]data.Item, 0, len(names)+1)
+		now   = time.Now()
+	)
+
+	index, err := data.NewPrefetchedItem(io.NopCloser(bytes.NewReader(bs)), col.itemName, now)
+	if err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	items = append(items, index)
+
+	for _, name := range names {
+		if name == col.itemName {
+			return nil, clues.NewWC(ctx, "chunk name collides with the index").With("chunk_name", name)
+		}
+
+		bs, err := col.chunkMr.MarshalChunk(name)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "marshalling chunk").With("chunk_name", name)
+		}
+
+		item, err := data.NewPrefetchedItem(io.NopCloser(bytes.NewReader(bs)), name, now)
+		if err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
 // write persists bytes to the store
 func write(
 	ctx context.Context,
@@ -235,17 +309,107 @@ func read(
 	rer inject.RestoreProducer,
 	errs *fault.Bus,
 ) error {
-	// construct the path of the container
-	p, err := path.Builder{}.
-		Append(col.itemName).
-		ToStreamStorePath(tenantID, col.purpose, service, true)
-	if err != nil {
-		return clues.StackWC(ctx, err)
+	if col.ChunkUnmr != nil {
+		return readChunked(ctx, snapshotID, tenantID, service, col, rer, errs)
 	}
 
-	pd, err := p.Dir()
+	return readItems(
+		ctx,
+		snapshotID,
+		tenantID,
+		service,
+		col.purpose,
+		[]string{col.itemName},
+		func(_ string, rc io.ReadCloser) error { return col.Unmr(rc) },
+		rer,
+		errs)
+}
+
+// readChunked reads the index of a chunked object, followed by the
+// chunks selected while reading the index.
+func readChunked(
+	ctx context.Context,
+	snapshotID string,
+	tenantID string,
+	service path.ServiceType,
+	col Collectable,
+	rer inject.RestoreProducer,
+	errs *fault.Bus,
+) error {
+	var chunks []string
+
+	err := readItems(
+		ctx,
+		snapshotID,
+		tenantID,
+		service,
+		col.purpose,
+		[]string{col.itemName},
+		func(_ string, rc io.ReadCloser) error {
+			names, err := col.ChunkUnmr.UnmarshalIndex(rc)
+			chunks = names
+
+			return err
+		},
+		rer,
+		errs)
 	if err != nil {
-		return clues.StackWC(ctx, err)
+		return clues.Wrap(err, "reading index")
+	}
+
+	ctx = clues.Add(ctx, "chunk_count", len(chunks))
+
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	err = readItems(
+		ctx,
+		snapshotID,
+		tenantID,
+		service,
+		col.purpose,
+		chunks,
+		col.ChunkUnmr.UnmarshalChunk,
+		rer,
+		errs)
+
+	return clues.Wrap(err, "reading chunks").OrNil()
+}
+
+// readItems retrieves the named items from the purpose's folder in the
+// store, handing each of them to the unmarshal func.
+func readItems(
+	ctx context.Context,
+	snapshotID string,
+	tenantID string,
+	service path.ServiceType,
+	purpose string,
+	itemNames []string,
+	unmarshal func(name string, rc io.ReadCloser) error,
+	rer inject.RestoreProducer,
+	errs *fault.Bus,
+) error {
+	rps := make([]path.RestorePaths, 0, len(itemNames))
+
+	for _, name := range itemNames {
+		// construct the path of the container
+		p, err := path.Builder{}.
+			Append(name).
+			ToStreamStorePath(tenantID, purpose, service, true)
+		if err != nil {
+			return clues.StackWC(ctx, err)
+		}
+
+		pd, err := p.Dir()
+		if err != nil {
+			return clues.StackWC(ctx, err)
+		}
+
+		rps = append(rps, path.RestorePaths{
+			StoragePath: p,
+			RestorePath: pd,
+		})
 	}
 
 	ctx = clues.Add(ctx, "snapshot_id", snapshotID)
@@ -253,12 +417,7 @@ func read(
 	cs, err := rer.ProduceRestoreCollections(
 		ctx,
 		snapshotID,
-		[]path.RestorePaths{
-			{
-				StoragePath: p,
-				RestorePath: pd,
-			},
-		},
+		rps,
 		&stats.ByteCounter{},
 		errs)
 	if err != nil {
@@ -273,7 +432,7 @@ func read(
 
 	var (
 		c     = cs[0]
-		found = false
+		found = 0
 		items = c.Items(ctx, errs)
 	)
 
@@ -284,18 +443,23 @@ func read(
 
 		case itemData, ok := <-items:
 			if !ok {
-				if !found {
+				if found == 0 {
 					return clues.NewWC(ctx, "no data found")
 				}
 
+				if found < len(itemNames) {
+					return clues.NewWC(ctx, "missing data").
+						With("expected_count", len(itemNames), "found_count", found)
+				}
+
 				return nil
 			}
 
-			if err := col.Unmr(itemData.ToReader()); err != nil {
+			if err := unmarshal(itemData.ID(), itemData.ToReader()); err != nil {
 				return clues.WrapWC(ctx, err, "unmarshalling data")
 			}
 
-			found = true
+			found++
 		}
 	}
 }
@@ -1,15 +1,11 @@
 package main
 
 import (
-	"context"
 	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/alcionai/corso/src/lambda/handler"
 )
 
 func main() {
-	lambda.StartWithOptions(
-		func(ctx context.Context) error {
-
-			return nil
-		},
-	)
+	lambda.Start(handler.Backup)
 }
@@ -0,0 +1,475 @@
+package handler
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// category names accepted by BackupEvent.Categories.  These match the
+// values of the cli's --data flag.
+const (
+	CategoryContacts      = "contacts"
+	CategoryEmail         = "email"
+	CategoryEvents        = "events"
+	CategoryLibraries     = "libraries"
+	CategoryMessages      = "messages"
+	CategoryConversations = "conversations"
+)
+
+var serviceCategories = map[path.ServiceType][]string{
+	path.ExchangeService:   {CategoryContacts, CategoryEmail, CategoryEvents},
+	path.OneDriveService:   {},
+	path.SharePointService: {CategoryLibraries},
+	path.GroupsService:     {CategoryLibraries, CategoryMessages, CategoryConversations},
+	path.TeamsChatsService: {},
+	path.EntraIDService:    {},
+}
+
+// BackupEvent is the payload accepted by the backup function.
+type BackupEvent struct {
+	// Service is one of: exchange, onedrive, sharepoint, groups, teamsChats,
+	// entraID.
+	Service string `json:"service"`
+	// Resources are the ids of the protected resources (users, sites,
+	// groups, or, for entraID, the tenant) to back up.  One backup is produced per resource.
+	Resources []string `json:"resources"`
+	// Categories limits the backup to the listed data categories.  If
+	// empty, all categories supported by the service are backed up.
+	Categories    []string              `json:"categories,omitempty"`
+	FailurePolicy control.FailurePolicy `json:"failurePolicy,omitempty"`
+	Repo          RepoConfig            `json:"repo"`
+	// Resumable makes the backups stop shortly before the invocation's
+	// deadline instead of timing out.  Whatever work remains is returned
+	// as a continuation token.  Not compatible with the fail-fast or
+	// best-effort failure policies.
+	Resumable bool `json:"resumable,omitempty"`
+	// CheckpointMarginSeconds is the time reserved at the end of a
+	// resumable invocation for persisting the backup in progress.
+	// Defaults to 180 seconds.
+	CheckpointMarginSeconds int `json:"checkpointMarginSeconds,omitempty"`
+	// ContinuationToken picks up the work left over by a previous
+	// resumable invocation.  The service, resources, and categories are
+	// read from the token, and must be omitted from the event.
+	ContinuationToken string `json:"continuationToken,omitempty"`
+}
+
+// BackupResponse is returned by the backup function.
+type BackupResponse struct {
+	Backups []BackupResult `json:"backups"`
+	// ContinuationToken is populated when a resumable invocation ended
+	// before backing up every resource.  Pass it in the next event to
+	// continue.
+	ContinuationToken string `json:"continuationToken,omitempty"`
+}
+
+// BackupResult records the outcome of the backup of a single resource.
+type BackupResult struct {
+	ResourceID string `json:"resourceID"`
+	BackupID   string `json:"backupID,omitempty"`
+	stats.ReadWrites
+	// RecoveredErrors is the number of recoverable errors that occurred
+	// during the backup.
+	RecoveredErrors int `json:"recoveredErrors"`
+	// SkippedItems is the number of items that were intentionally skipped.
+	SkippedItems int `json:"skippedItems"`
+	// Checkpointed is true if the backup stopped before the invocation's
+	// deadline.  The backup id refers to an assist backup that holds the
+	// data collected so far.
+	Checkpointed bool `json:"checkpointed,omitempty"`
+	// Failure holds the non-recoverable error, if one occurred.
+	Failure string `json:"failure,omitempty"`
+}
+
+func (ev BackupEvent) validate() error {
+	pst := path.ToServiceType(ev.Service)
+
+	cats, ok := serviceCategories[pst]
+	if !ok {
+		return clues.New("unsupported service: [" + ev.Service + "]")
+	}
+
+	if len(ev.Resources) == 0 {
+		return clues.New("one or more resource ids are required")
+	}
+
+	for _, r := range ev.Resources {
+		if len(r) == 0 || r == selectors.AnyTgt {
+			return clues.New("resources must be discrete ids; wildcards are not supported")
+		}
+	}
+
+	for _, c := range ev.Categories {
+		if !slices.Contains(cats, c) {
+			return clues.New(c + " is an unrecognized data type for service " + pst.HumanString())
+		}
+	}
+
+	if !validFailurePolicy(ev.FailurePolicy) {
+		return clues.New("unsupported failure policy: [" + string(ev.FailurePolicy) + "]")
+	}
+
+	if ev.Resumable &&
+		len(ev.FailurePolicy) > 0 &&
+		ev.FailurePolicy != control.FailAfterRecovery {
+		return clues.New("resumable backups require the " + string(control.FailAfterRecovery) + " failure policy")
+	}
+
+	if ev.CheckpointMarginSeconds < 0 {
+		return clues.New("checkpoint margin cannot be negative")
+	}
+
+	return nil
+}
+
+// resume replaces the event's work description with the one held in its
+// continuation token.  Returns the checkpoints recorded in the token.
+func (ev *BackupEvent) resume() (map[string]control.Resume, error) {
+	if len(ev.ContinuationToken) == 0 {
+		return nil, nil
+	}
+
+	if len(ev.Service) > 0 || len(ev.Resources) > 0 || len(ev.Categories) > 0 {
+		return nil, clues.New("service, resources, and categories cannot be combined with a continuation token")
+	}
+
+	c, err := decodeContinuation(ev.ContinuationToken)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	ev.Service = c.Service
+	ev.Resources = c.Resources
+	ev.Categories = c.Categories
+	ev.Resumable = true
+
+	return c.Checkpoints, nil
+}
+
+// continueWith produces the event which picks up the work that the event
+// left over in the continuation token.
+func (ev BackupEvent) continueWith(token string) BackupEvent {
+	return BackupEvent{
+		FailurePolicy:           ev.FailurePolicy,
+		Repo:                    ev.Repo,
+		CheckpointMarginSeconds: ev.CheckpointMarginSeconds,
+		ContinuationToken:       token,
+	}
+}
+
+// jobResources returns the resources covered by the event, which are read
+// from its continuation token if it has one.
+func (ev BackupEvent) jobResources() ([]string, error) {
+	if len(ev.ContinuationToken) == 0 {
+		return ev.Resources, nil
+	}
+
+	c, err := decodeContinuation(ev.ContinuationToken)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return c.Resources, nil
+}
+
+func (ev BackupEvent) checkpointMargin() time.Duration {
+	if ev.CheckpointMarginSeconds == 0 {
+		return defaultCheckpointMargin
+	}
+
+	return time.Duration(ev.CheckpointMarginSeconds) * time.Second
+}
+
+// backupSelectors produces one selector per resource in the event.
+func backupSelectors(ev BackupEvent) []selectors.Selector {
+	var (
+		sels []selectors.Selector
+		cats = ev.Categories
+	)
+
+	switch path.ToServiceType(ev.Service) {
+	case path.ExchangeService:
+		sel := selectors.NewExchangeBackup(ev.Resources)
+
+		if len(cats) == 0 {
+			cats = serviceCategories[path.ExchangeService]
+		}
+
+		for _, c := range cats {
+			switch c {
+			case CategoryContacts:
+				sel.Include(sel.ContactFolders(selectors.Any()))
+			case CategoryEmail:
+				sel.Include(sel.MailFolders(selectors.Any()))
+			case CategoryEvents:
+				sel.Include(sel.EventCalendars(selectors.Any()))
+			}
+		}
+
+		for _, s := range sel.SplitByResourceOwner(ev.Resources) {
+			sels = append(sels, s.Selector)
+		}
+
+	case path.OneDriveService:
+		sel := selectors.NewOneDriveBackup(ev.Resources)
+		sel.Include(sel.AllData())
+
+		for _, s := range sel.SplitByResourceOwner(ev.Resources) {
+			sels = append(sels, s.Selector)
+		}
+
+	case path.SharePointService:
+		sel := selectors.NewSharePointBackup(ev.Resources)
+		sel.Include(sel.LibraryFolders(selectors.Any()))
+
+		for _, s := range sel.SplitByResourceOwner(ev.Resources) {
+			sels = append(sels, s.Selector)
+		}
+
+	case path.GroupsService:
+		sel := selectors.NewGroupsBackup(ev.Resources)
+
+		if len(cats) == 0 {
+			sel.Include(sel.AllData())
+		}
+
+		for _, c := range cats {
+			switch c {
+			case CategoryLibraries:
+				sel.Include(sel.LibraryFolders(selectors.Any()))
+			case CategoryMessages:
+				sel.Include(sel.ChannelMessages(selectors.Any(), selectors.Any()))
+			case CategoryConversations:
+				sel.Include(sel.ConversationPosts(selectors.Any(), selectors.Any()))
+			}
+		}
+
+		for _, s := range sel.SplitByResourceOwner(ev.Resources) {
+			sels = append(sels, s.Selector)
+		}
+
+	case path.TeamsChatsService:
+		sel := selectors.NewTeamsChatsBackup(ev.Resources)
+		sel.Include(sel.AllData())
+
+		for _, s := range sel.SplitByResourceOwner(ev.Resources) {
+			sels = append(sels, s.Selector)
+		}
+
+	case path.EntraIDService:
+		sel := selectors.NewEntraIDBackup(ev.Resources)
+		sel.Include(sel.AllData())
+
+		for _, s := range sel.SplitByResourceOwner(ev.Resources) {
+			sels = append(sels, s.Selector)
+		}
+	}
+
+	for i := range sels {
+		sels[i].Configure(selectors.Config{OnlyMatchItemNames: true})
+	}
+
+	return sels
+}
+
+// Backup runs a backup of each resource in the event.  Failures of
+// individual resources are recorded in their result; an error is only
+// returned if the event is invalid or the repository is unavailable.
+func Backup(ctx context.Context, ev BackupEvent) (BackupResponse, error) {
+	ctx, flush := seed(ctx)
+	defer flush()
+
+	checkpoints, err := ev.resume()
+	if err != nil {
+		return BackupResponse{}, clues.Wrap(err, "resuming from continuation token")
+	}
+
+	if err := ev.validate(); err != nil {
+		return BackupResponse{}, clues.Wrap(err, "validating backup event")
+	}
+
+	var (
+		pst  = path.ToServiceType(ev.Service)
+		opts = ev.Repo.options(ev.FailurePolicy)
+	)
+
+	if ev.Resumable {
+		opts.CheckpointAt = checkpointAt(ctx, ev.checkpointMargin())
+	}
+
+	ctx = clues.Add(
+		ctx,
+		"service", pst.String(),
+		"resumable", ev.Resumable,
+		"checkpoint_at", opts.CheckpointAt)
+
+	r, err := connect(ctx, ev.Repo, opts, pst)
+	if err != nil {
+		return BackupResponse{}, clues.Stack(err)
+	}
+
+	defer closeRepo(ctx, r)
+
+	var (
+		resp      = BackupResponse{}
+		sels      = backupSelectors(ev)
+		remaining []string
+	)
+
+	for i, sel := range sels {
+		owner := sel.DiscreteOwner
+
+		if !opts.CheckpointAt.IsZero() && time.Now().After(opts.CheckpointAt) {
+			logger.Ctx(ctx).Infow("checkpoint reached before starting backup", "resource_owner_selected", owner)
+
+			remaining = ownersOf(sels[i:])
+
+			break
+		}
+
+		resume := checkpoints[owner]
+
+		if resume.Enabled() {
+			logger.Ctx(ctx).Infow(
+				"resuming backup from checkpoint",
+				"resource_owner_selected", owner,
+				"checkpoint_backup_id", resume.BackupID,
+				"completed_collections", len(resume.Completed))
+		}
+
+		res, progress := runBackup(ctx, r, sel, resume)
+		resp.Backups = append(resp.Backups, res)
+
+		delete(checkpoints, owner)
+
+		if res.Checkpointed {
+			if len(res.Failure) == 0 {
+				if checkpoints == nil {
+					checkpoints = map[string]control.Resume{}
+				}
+
+				checkpoints[owner] = control.Resume{
+					BackupID:  res.BackupID,
+					Completed: progress,
+				}
+			}
+
+			remaining = ownersOf(sels[i:])
+
+			break
+		}
+	}
+
+	if len(remaining) > 0 {
+		c := continuation{
+			Service:     ev.Service,
+			Categories:  ev.Categories,
+			Resources:   remaining,
+			Checkpoints: checkpoints,
+		}
+
+		resp.ContinuationToken, err = c.encode()
+		if err != nil {
+			return resp, clues.Stack(err)
+		}
+	}
+
+	return resp, nil
+}
+
+func ownersOf(sels []selectors.Selector) []string {
+	owners := make([]string, 0, len(sels))
+
+	for _, sel := range sels {
+		owners = append(owners, sel.DiscreteOwner)
+	}
+
+	return owners
+}
+
+// runBackup handles the backup of a single resource, resuming from the
+// checkpoint of an earlier backup if one is given.  Along with the result,
+// returns the collections which the backup completed if it stopped at its
+// checkpoint.
+func runBackup(
+	ctx context.Context,
+	r repository.Backuper,
+	sel selectors.Selector,
+	resume control.Resume,
+) (BackupResult, map[string]string) {
+	var (
+		owner = sel.DiscreteOwner
+		ictx  = clues.Add(ctx, "resource_owner_selected", owner)
+		res   = BackupResult{ResourceID: owner}
+	)
+
+	logger.Ctx(ictx).Info("setting up backup")
+
+	bo, err := r.NewBackupWithLookup(ictx, sel, nil)
+	if err != nil {
+		logger.CtxErr(ictx, err).Error("setting up backup")
+		res.Failure = err.Error()
+
+		return res, nil
+	}
+
+	bo.Options.Resume = resume
+
+	logger.Ctx(ictx).Info("running backup")
+
+	err = bo.Run(ictx)
+
+	res.BackupID = string(bo.Results.BackupID)
+	res.ReadWrites = bo.Results.ReadWrites
+
+	if bo.Errors != nil {
+		res.SkippedItems = len(bo.Errors.Skipped())
+
+		// the checkpoint is reported as a recoverable error, but it
+		// isn't a problem with the backup.
+		for _, rerr := range bo.Errors.Recovered() {
+			if errors.Is(rerr, core.ErrStoppedAtCheckpoint) {
+				res.Checkpointed = true
+			}
+		}
+
+		// neither are the reads of items that got cancelled when the backup
+		// stopped at its checkpoint.
+		for _, rerr := range bo.Errors.Recovered() {
+			if errors.Is(rerr, core.ErrStoppedAtCheckpoint) ||
+				(res.Checkpointed && errors.Is(rerr, context.Canceled)) {
+				continue
+			}
+
+			res.RecoveredErrors++
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, core.ErrServiceNotEnabled) {
+			logger.Ctx(ictx).Infow("service not enabled", "resource_owner_id", bo.ResourceOwner.ID())
+			return res, nil
+		}
+
+		if errors.Is(err, core.ErrStoppedAtCheckpoint) {
+			logger.Ctx(ictx).Info("backup stopped at checkpoint")
+			return res, bo.Progress()
+		}
+
+		logger.CtxErr(ictx, err).Error("running backup")
+		res.Failure = err.Error()
+	}
+
+	return res, bo.Progress()
+}
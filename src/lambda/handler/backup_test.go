@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type BackupUnitSuite struct {
+	tester.Suite
+}
+
+func TestBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &BackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *BackupUnitSuite) TestBackupEvent_Validate() {
+	table := []struct {
+		name      string
+		ev        BackupEvent
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "exchange, all categories",
+			ev: BackupEvent{
+				Service:   "exchange",
+				Resources: []string{"u1", "u2"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "exchange, some categories",
+			ev: BackupEvent{
+				Service:    "Exchange",
+				Resources:  []string{"u1"},
+				Categories: []string{CategoryEmail, CategoryEvents},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "groups, best effort",
+			ev: BackupEvent{
+				Service:       "groups",
+				Resources:     []string{"g1"},
+				Categories:    []string{CategoryMessages},
+				FailurePolicy: control.BestEffort,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "unknown service",
+			ev: BackupEvent{
+				Service:   "teams",
+				Resources: []string{"u1"},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "no resources",
+			ev: BackupEvent{
+				Service: "onedrive",
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "wildcard resource",
+			ev: BackupEvent{
+				Service:   "onedrive",
+				Resources: []string{"*"},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "category not in service",
+			ev: BackupEvent{
+				Service:    "sharepoint",
+				Resources:  []string{"s1"},
+				Categories: []string{CategoryEmail},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "bad failure policy",
+			ev: BackupEvent{
+				Service:       "exchange",
+				Resources:     []string{"u1"},
+				FailurePolicy: "fail-sometimes",
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "resumable",
+			ev: BackupEvent{
+				Service:                 "onedrive",
+				Resources:               []string{"u1"},
+				Resumable:               true,
+				CheckpointMarginSeconds: 60,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "resumable, best effort",
+			ev: BackupEvent{
+				Service:       "onedrive",
+				Resources:     []string{"u1"},
+				Resumable:     true,
+				FailurePolicy: control.BestEffort,
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "negative checkpoint margin",
+			ev: BackupEvent{
+				Service:                 "onedrive",
+				Resources:               []string{"u1"},
+				Resumable:               true,
+				CheckpointMarginSeconds: -1,
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.ev.validate()
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestBackupSelectors() {
+	table := []struct {
+		name       string
+		ev         BackupEvent
+		expectCats []path.CategoryType
+	}{
+		{
+			name: "exchange, all categories",
+			ev: BackupEvent{
+				Service:   "exchange",
+				Resources: []string{"u1", "u2"},
+			},
+			expectCats: []path.CategoryType{
+				path.ContactsCategory,
+				path.EmailCategory,
+				path.EventsCategory,
+			},
+		},
+		{
+			name: "exchange, email only",
+			ev: BackupEvent{
+				Service:    "exchange",
+				Resources:  []string{"u1"},
+				Categories: []string{CategoryEmail},
+			},
+			expectCats: []path.CategoryType{path.EmailCategory},
+		},
+		{
+			name: "onedrive",
+			ev: BackupEvent{
+				Service:   "onedrive",
+				Resources: []string{"u1", "u2", "u3"},
+			},
+			expectCats: []path.CategoryType{path.FilesCategory},
+		},
+		{
+			name: "sharepoint",
+			ev: BackupEvent{
+				Service:   "sharepoint",
+				Resources: []string{"s1"},
+			},
+			expectCats: []path.CategoryType{path.LibrariesCategory},
+		},
+		{
+			name: "groups, channel messages",
+			ev: BackupEvent{
+				Service:    "groups",
+				Resources:  []string{"g1"},
+				Categories: []string{CategoryMessages},
+			},
+			expectCats: []path.CategoryType{path.ChannelMessagesCategory},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			sels := backupSelectors(test.ev)
+			require.Len(t, sels, len(test.ev.Resources))
+
+			for i, sel := range sels {
+				assert.Equal(t, test.ev.Resources[i], sel.DiscreteOwner)
+				assert.Equal(t, path.ToServiceType(test.ev.Service), sel.PathService())
+				assert.True(t, sel.Cfg.OnlyMatchItemNames)
+
+				pcs, err := sel.PathCategories()
+				require.NoError(t, err, clues.ToCore(err))
+				assert.ElementsMatch(t, test.expectCats, pcs.Includes)
+			}
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestBackupEvent_Resume() {
+	c := continuation{
+		Service:    "exchange",
+		Categories: []string{CategoryEmail},
+		Resources:  []string{"u2", "u3"},
+		Checkpoints: map[string]control.Resume{
+			"u2": {
+				BackupID:  "bid",
+				Completed: map[string]string{"tid/exchange/u2/email/inbox": "delta"},
+			},
+		},
+	}
+
+	token, err := c.encode()
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name              string
+		ev                BackupEvent
+		expectEv          BackupEvent
+		expectCheckpoints map[string]control.Resume
+		expectErr         assert.ErrorAssertionFunc
+	}{
+		{
+			name: "no token",
+			ev: BackupEvent{
+				Service:   "onedrive",
+				Resources: []string{"u1"},
+			},
+			expectEv: BackupEvent{
+				Service:   "onedrive",
+				Resources: []string{"u1"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "token",
+			ev: BackupEvent{
+				ContinuationToken: token,
+			},
+			expectEv: BackupEvent{
+				Service:           "exchange",
+				Resources:         []string{"u2", "u3"},
+				Categories:        []string{CategoryEmail},
+				Resumable:         true,
+				ContinuationToken: token,
+			},
+			expectCheckpoints: c.Checkpoints,
+			expectErr:         assert.NoError,
+		},
+		{
+			name: "token and resources",
+			ev: BackupEvent{
+				Resources:         []string{"u1"},
+				ContinuationToken: token,
+			},
+			expectEv: BackupEvent{
+				Resources:         []string{"u1"},
+				ContinuationToken: token,
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "malformed token",
+			ev: BackupEvent{
+				ContinuationToken: "not a token",
+			},
+			expectEv: BackupEvent{
+				ContinuationToken: "not a token",
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ev := test.ev
+
+			cps, err := ev.resume()
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectEv, ev)
+			assert.Equal(t, test.expectCheckpoints, cps)
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestCheckpointAt() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	assert.True(t, checkpointAt(ctx, time.Minute).IsZero(), "no deadline")
+
+	deadline := time.Now().Add(15 * time.Minute)
+
+	dctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	assert.Equal(t, deadline.Add(-3*time.Minute), checkpointAt(dctx, 3*time.Minute))
+}
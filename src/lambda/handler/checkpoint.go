@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+// defaultCheckpointMargin is the time reserved at the end of an invocation
+// for the backup in progress to be persisted after it reaches its checkpoint.
+const defaultCheckpointMargin = 3 * time.Minute
+
+// continuation is the state carried between invocations of a resumable
+// backup.  It describes which work remains, and how far the backup of an
+// interrupted resource got.  The data backed up by earlier invocations is
+// kept in the repository as assist backups.
+type continuation struct {
+	Service    string   `json:"service"`
+	Categories []string `json:"categories,omitempty"`
+	// Resources are the resources that have not completed a backup, in
+	// the order they will be processed.  The first entry may have been
+	// interrupted at a checkpoint.
+	Resources []string `json:"resources"`
+	// Checkpoints maps resources to the progress of their backup at the
+	// last checkpoint: the assist backup it persisted, and the collections
+	// it completed along with their delta tokens.  The next backup of the
+	// resource resumes from there.
+	Checkpoints map[string]control.Resume `json:"checkpoints,omitempty"`
+}
+
+func (c continuation) encode() (string, error) {
+	bs, err := json.Marshal(c)
+	if err != nil {
+		return "", clues.Wrap(err, "marshalling continuation token")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bs), nil
+}
+
+func decodeContinuation(token string) (continuation, error) {
+	var c continuation
+
+	bs, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, clues.Wrap(err, "decoding continuation token")
+	}
+
+	if err := json.Unmarshal(bs, &c); err != nil {
+		return c, clues.Wrap(err, "unmarshalling continuation token")
+	}
+
+	return c, nil
+}
+
+// checkpointAt produces the time at which backups in this invocation
+// should stop, leaving margin before the invocation's deadline.  Returns
+// the zero time if the context has no deadline.
+func checkpointAt(ctx context.Context, margin time.Duration) time.Time {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return time.Time{}
+	}
+
+	return deadline.Add(-margin)
+}
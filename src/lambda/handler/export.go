@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// defaultExportPrefix is used to name the top level folder of an export
+// when the destination doesn't provide a prefix.
+const defaultExportPrefix = "Corso_Export_"
+
+// ExportEvent is the payload accepted by the export function.
+type ExportEvent struct {
+	BackupID string `json:"backupID"`
+	// Selector is a serialized selectors.Selector describing the data
+	// to export.  Its service must match the service of the backup.
+	Selector selectors.Selector `json:"selector"`
+	// Archive produces a single zip object instead of one object
+	// per exported item.
+	Archive bool `json:"archive,omitempty"`
+	// Format overrides the default export format of the service.
+	Format        control.FormatType    `json:"format,omitempty"`
+	Destination   S3Destination         `json:"destination"`
+	FailurePolicy control.FailurePolicy `json:"failurePolicy,omitempty"`
+	Repo          RepoConfig            `json:"repo"`
+}
+
+// S3Destination is the bucket and prefix that receives the exported
+// objects.  As with the repository, credentials come from the function's
+// execution role.
+type S3Destination struct {
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to every object key.  Defaults to
+	// "Corso_Export_<dttm>/".
+	Prefix         string `json:"prefix,omitempty"`
+	Endpoint       string `json:"endpoint,omitempty"`
+	Region         string `json:"region,omitempty"`
+	DoNotUseTLS    bool   `json:"doNotUseTLS,omitempty"`
+	DoNotVerifyTLS bool   `json:"doNotVerifyTLS,omitempty"`
+}
+
+// ExportResponse is returned by the export function.
+type ExportResponse struct {
+	Bucket string `json:"bucket"`
+	// Keys are the keys of every object written to the bucket.
+	Keys []string `json:"keys"`
+	// Stats are the items and bytes exported, by data category.
+	Stats map[string]ExportStats `json:"stats"`
+	// FailedItems are the items that could not be exported.
+	FailedItems []fault.Item `json:"failedItems"`
+	// RecoveredErrors is the number of recoverable errors that occurred
+	// outside of any individual item.
+	RecoveredErrors int `json:"recoveredErrors"`
+	// Failure holds the non-recoverable error, if one occurred.
+	Failure string `json:"failure,omitempty"`
+}
+
+type ExportStats struct {
+	Items int64 `json:"items"`
+	Bytes int64 `json:"bytes"`
+}
+
+// objectPutter writes a single object to the export destination.
+type objectPutter interface {
+	PutObject(ctx context.Context, key string, body io.Reader) error
+}
+
+func (ev ExportEvent) validate() error {
+	if len(ev.BackupID) == 0 {
+		return clues.New("a backup id is required")
+	}
+
+	if ev.Selector.Service == selectors.ServiceUnknown {
+		return clues.New("a selector with a known service is required")
+	}
+
+	if len(ev.Destination.Bucket) == 0 {
+		return clues.New("a destination bucket is required")
+	}
+
+	if !validFailurePolicy(ev.FailurePolicy) {
+		return clues.New("unsupported failure policy: [" + string(ev.FailurePolicy) + "]")
+	}
+
+	return nil
+}
+
+// prefix produces the normalized key prefix for the export.
+func (d S3Destination) prefix() string {
+	p := strings.Trim(d.Prefix, "/")
+	if len(p) == 0 {
+		p = defaultExportPrefix + dttm.FormatNow(dttm.HumanReadableDriveItem)
+	}
+
+	return p + "/"
+}
+
+// Export runs an export of the selected data from a single backup and
+// writes every exported item to the destination bucket.  Item failures
+// are reported in the response; an error is only returned if the export
+// could not be run at all.
+func Export(ctx context.Context, ev ExportEvent) (ExportResponse, error) {
+	ctx, flush := seed(ctx)
+	defer flush()
+
+	if err := ev.validate(); err != nil {
+		return ExportResponse{}, clues.Wrap(err, "validating export event")
+	}
+
+	var (
+		sel = ev.Selector
+		pst = sel.PathService()
+	)
+
+	ctx = clues.Add(ctx, "backup_id", ev.BackupID, "service", pst.String())
+
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+
+	dest, err := newS3Putter(ev.Destination)
+	if err != nil {
+		return ExportResponse{}, clues.Wrap(err, "connecting to export destination")
+	}
+
+	r, err := connect(ctx, ev.Repo, ev.Repo.options(ev.FailurePolicy), pst)
+	if err != nil {
+		return ExportResponse{}, clues.Stack(err)
+	}
+
+	defer closeRepo(ctx, r)
+
+	exportCfg := control.DefaultExportConfig()
+	exportCfg.Archive = ev.Archive
+	exportCfg.Format = ev.Format
+
+	eo, err := r.NewExport(ctx, ev.BackupID, sel, exportCfg)
+	if err != nil {
+		return ExportResponse{}, clues.Wrap(err, "initializing export")
+	}
+
+	logger.Ctx(ctx).Info("running export")
+
+	resp := ExportResponse{
+		Bucket: ev.Destination.Bucket,
+		Stats:  map[string]ExportStats{},
+	}
+
+	colls, err := eo.Run(ctx)
+	if err == nil {
+		resp.Keys, err = consumeExportCollections(
+			ctx,
+			dest,
+			ev.Destination.prefix(),
+			colls,
+			eo.Errors)
+	}
+
+	// stats are only complete once every item body has been read.
+	for cat, s := range eo.GetStats() {
+		resp.Stats[cat.String()] = ExportStats{
+			Items: s.ResourceCount,
+			Bytes: s.BytesRead,
+		}
+	}
+
+	if eo.Errors != nil {
+		items, recovered := eo.Errors.ItemsAndRecovered()
+		resp.FailedItems = items
+		resp.RecoveredErrors = len(recovered)
+	}
+
+	if err != nil {
+		logger.CtxErr(ctx, err).Error("running export")
+		resp.Failure = err.Error()
+	}
+
+	return resp, nil
+}
+
+// consumeExportCollections mirrors export.ConsumeExportCollections, but
+// streams each item to the object store instead of the local disk.
+// Returns the keys of all objects that were written.
+func consumeExportCollections(
+	ctx context.Context,
+	op objectPutter,
+	prefix string,
+	expColl []export.Collectioner,
+	errs *fault.Bus,
+) ([]string, error) {
+	var (
+		el   = errs.Local()
+		keys = []string{}
+	)
+
+	for _, col := range expColl {
+		if el.Failure() != nil {
+			break
+		}
+
+		// object keys always use '/', regardless of platform.
+		folder := path.Join(prefix, col.BasePath())
+		ictx := clues.Add(ctx, "dir_name", clues.Hide(folder))
+
+		for item := range col.Items(ictx) {
+			if item.Error != nil {
+				el.AddRecoverable(ictx, clues.Wrap(item.Error, "getting item"))
+				continue
+			}
+
+			key := path.Join(folder, item.Name)
+
+			if err := putItem(ictx, op, key, item); err != nil {
+				el.AddRecoverable(
+					ictx,
+					clues.Wrap(err, "writing item").With("file_name", clues.Hide(item.Name)))
+
+				continue
+			}
+
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, el.Failure()
+}
+
+func putItem(
+	ctx context.Context,
+	op objectPutter,
+	key string,
+	item export.Item,
+) error {
+	defer item.Body.Close()
+
+	return op.PutObject(ctx, key, item.Body)
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+type mockExportCollection struct {
+	path  string
+	items []export.Item
+}
+
+func (mec mockExportCollection) BasePath() string { return mec.path }
+func (mec mockExportCollection) Items(context.Context) <-chan export.Item {
+	ch := make(chan export.Item)
+
+	go func() {
+		defer close(ch)
+
+		for _, item := range mec.items {
+			ch <- item
+		}
+	}()
+
+	return ch
+}
+
+type mockPutter struct {
+	objects map[string]string
+	failOn  string
+}
+
+func (mp *mockPutter) PutObject(_ context.Context, key string, body io.Reader) error {
+	if key == mp.failOn {
+		return assert.AnError
+	}
+
+	bs, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	mp.objects[key] = string(bs)
+
+	return nil
+}
+
+func (suite *ExportUnitSuite) TestExportEvent_Validate() {
+	sel := selectors.NewOneDriveRestore([]string{"u1"})
+	sel.Include(sel.AllData())
+
+	valid := func() ExportEvent {
+		return ExportEvent{
+			BackupID:    "bid",
+			Selector:    sel.Selector,
+			Destination: S3Destination{Bucket: "bkt"},
+		}
+	}
+
+	table := []struct {
+		name      string
+		ev        func() ExportEvent
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "valid",
+			ev:        valid,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "no backup id",
+			ev: func() ExportEvent {
+				ev := valid()
+				ev.BackupID = ""
+				return ev
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "no selector",
+			ev: func() ExportEvent {
+				ev := valid()
+				ev.Selector = selectors.Selector{}
+				return ev
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "no bucket",
+			ev: func() ExportEvent {
+				ev := valid()
+				ev.Destination.Bucket = ""
+				return ev
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "bad failure policy",
+			ev: func() ExportEvent {
+				ev := valid()
+				ev.FailurePolicy = control.FailurePolicy("nope")
+				return ev
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.ev().validate()
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestS3Destination_Prefix() {
+	t := suite.T()
+
+	assert.Equal(t, "foo/bar/", S3Destination{Prefix: "/foo/bar/"}.prefix())
+	assert.Equal(t, "foo/", S3Destination{Prefix: "foo"}.prefix())
+
+	p := S3Destination{}.prefix()
+	assert.True(t, strings.HasPrefix(p, defaultExportPrefix), p)
+	assert.True(t, strings.HasSuffix(p, "/"), p)
+}
+
+func (suite *ExportUnitSuite) TestConsumeExportCollections() {
+	body := func(s string) io.ReadCloser {
+		return io.NopCloser(bytes.NewBufferString(s))
+	}
+
+	table := []struct {
+		name         string
+		cols         []export.Collectioner
+		failOn       string
+		expectKeys   []string
+		expectErrors int
+	}{
+		{
+			name: "all items written",
+			cols: []export.Collectioner{
+				mockExportCollection{
+					path: "Inbox",
+					items: []export.Item{
+						{ID: "1", Name: "a.eml", Body: body("a")},
+						{ID: "2", Name: "b.eml", Body: body("b")},
+					},
+				},
+				mockExportCollection{
+					path: "",
+					items: []export.Item{
+						{ID: "3", Name: "c.eml", Body: body("c")},
+					},
+				},
+			},
+			expectKeys:   []string{"pfx/Inbox/a.eml", "pfx/Inbox/b.eml", "pfx/c.eml"},
+			expectErrors: 0,
+		},
+		{
+			name: "item error",
+			cols: []export.Collectioner{
+				mockExportCollection{
+					path: "Inbox",
+					items: []export.Item{
+						{ID: "1", Error: assert.AnError},
+						{ID: "2", Name: "b.eml", Body: body("b")},
+					},
+				},
+			},
+			expectKeys:   []string{"pfx/Inbox/b.eml"},
+			expectErrors: 1,
+		},
+		{
+			name: "put error",
+			cols: []export.Collectioner{
+				mockExportCollection{
+					path: "Inbox",
+					items: []export.Item{
+						{ID: "1", Name: "a.eml", Body: body("a")},
+						{ID: "2", Name: "b.eml", Body: body("b")},
+					},
+				},
+			},
+			failOn:       "pfx/Inbox/a.eml",
+			expectKeys:   []string{"pfx/Inbox/b.eml"},
+			expectErrors: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				mp   = &mockPutter{objects: map[string]string{}, failOn: test.failOn}
+				errs = fault.New(false)
+			)
+
+			keys, err := consumeExportCollections(ctx, mp, "pfx/", test.cols, errs)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectKeys, keys)
+			assert.Len(t, errs.Recovered(), test.expectErrors)
+
+			for _, k := range keys {
+				assert.Contains(t, mp.objects, k)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Package handler contains the event processing used by the corso lambda
+// functions.  Each function's main package does nothing more than hand its
+// handler to the lambda runtime; all of the event parsing, repository
+// connection, and operation handling lives here so that it can be tested
+// alongside the rest of corso.
+package handler
+
+import (
+	"context"
+	"os"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// envvar consts
+const (
+	// LogLevel overrides the default (info) log level used by the functions.
+	LogLevel = "CORSO_LOG_LEVEL"
+)
+
+// seed prepares the context for a single lambda invocation.  Lambda
+// captures stderr into cloudwatch, so logs are written there as json.
+func seed(ctx context.Context) (context.Context, func()) {
+	set := logger.Settings{
+		File:        logger.Stderr,
+		Format:      logger.LFJSON,
+		Level:       logger.LLInfo,
+		PIIHandling: logger.PIIHash,
+	}
+
+	switch os.Getenv(LogLevel) {
+	case string(logger.LLDebug):
+		set.Level = logger.LLDebug
+	case string(logger.LLWarn):
+		set.Level = logger.LLWarn
+	case string(logger.LLError):
+		set.Level = logger.LLError
+	}
+
+	ctx, log := logger.Seed(ctx, set.EnsureDefaults())
+
+	return ctx, func() {
+		_ = log.Sync() // flush all logs in the buffer
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// MaintenanceEvent is the payload accepted by the maintenance function.
+// The scheduled rule in template.yaml sends it as the rule's input.
+type MaintenanceEvent struct {
+	// Type is the maintenance mode: "complete" or "metadata".  Defaults
+	// to "metadata".
+	Type string `json:"type,omitempty"`
+	// Force runs maintenance even if the function's user@host doesn't own
+	// maintenance for the repository.
+	Force bool `json:"force,omitempty"`
+	// MeasureStorage reports the space reclaimed by maintenance.  It lists
+	// every blob in the repository, so it's off by default.
+	MeasureStorage bool       `json:"measureStorage,omitempty"`
+	Repo           RepoConfig `json:"repo"`
+}
+
+// MaintenanceResponse is returned by the maintenance function.
+type MaintenanceResponse struct {
+	Type           string `json:"type"`
+	BlobsDeleted   int64  `json:"blobsDeleted"`
+	BytesReclaimed int64  `json:"bytesReclaimed"`
+	// Skipped is true if maintenance didn't run because a backup held the
+	// repository.  The next scheduled run will try again.
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+func (ev MaintenanceEvent) maintenanceType() (repository.MaintenanceType, error) {
+	if len(ev.Type) == 0 {
+		return repository.MetadataMaintenance, nil
+	}
+
+	mt, ok := repository.StringToMaintenanceType[ev.Type]
+	if !ok {
+		return mt, clues.New("unsupported maintenance type: [" + ev.Type + "]")
+	}
+
+	return mt, nil
+}
+
+// Maintenance runs repository maintenance.  Running into a backup that
+// holds the repository isn't a failure; the response is marked skipped
+// instead, so that the schedule doesn't retry it.
+func Maintenance(ctx context.Context, ev MaintenanceEvent) (MaintenanceResponse, error) {
+	ctx, flush := seed(ctx)
+	defer flush()
+
+	mt, err := ev.maintenanceType()
+	if err != nil {
+		return MaintenanceResponse{}, clues.Wrap(err, "validating maintenance event")
+	}
+
+	ctx = clues.Add(ctx, "maintenance_type", mt.String(), "force", ev.Force)
+
+	// Need to give it a valid service so it won't error out on us even though
+	// we don't need the graph client.
+	r, err := connect(ctx, ev.Repo, ev.Repo.options(""), path.OneDriveService)
+	if err != nil {
+		return MaintenanceResponse{}, clues.Stack(err)
+	}
+
+	defer closeRepo(ctx, r)
+
+	mo, err := r.NewMaintenance(
+		ctx,
+		repository.Maintenance{
+			Type:           mt,
+			Safety:         repository.FullMaintenanceSafety,
+			Force:          ev.Force,
+			MeasureStorage: ev.MeasureStorage,
+		})
+	if err != nil {
+		return MaintenanceResponse{}, clues.Wrap(err, "initializing maintenance")
+	}
+
+	logger.Ctx(ctx).Info("running maintenance")
+
+	resp := MaintenanceResponse{Type: mt.String()}
+
+	err = mo.Run(ctx)
+	if errors.Is(err, kopia.ErrLeaseHeld) {
+		logger.CtxErr(ctx, err).Info("skipping maintenance")
+
+		resp.Skipped = true
+
+		return resp, nil
+	}
+
+	if err != nil {
+		return MaintenanceResponse{}, clues.Wrap(err, "running maintenance")
+	}
+
+	resp.BlobsDeleted = mo.Results.BlobsDeleted
+	resp.BytesReclaimed = mo.Results.BytesReclaimed
+
+	return resp, nil
+}
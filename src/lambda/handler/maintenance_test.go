@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+)
+
+type MaintenanceUnitSuite struct {
+	tester.Suite
+}
+
+func TestMaintenanceUnitSuite(t *testing.T) {
+	suite.Run(t, &MaintenanceUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *MaintenanceUnitSuite) TestMaintenanceEvent_MaintenanceType() {
+	table := []struct {
+		name      string
+		ev        MaintenanceEvent
+		expect    repository.MaintenanceType
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "default",
+			ev:        MaintenanceEvent{},
+			expect:    repository.MetadataMaintenance,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "complete",
+			ev:        MaintenanceEvent{Type: "complete"},
+			expect:    repository.CompleteMaintenance,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "metadata",
+			ev:        MaintenanceEvent{Type: "metadata"},
+			expect:    repository.MetadataMaintenance,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "unknown",
+			ev:        MaintenanceEvent{Type: "quick"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			mt, err := test.ev.maintenanceType()
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err == nil {
+				assert.Equal(t, test.expect, mt)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/alcionai/clues"
+	"github.com/google/uuid"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// envvar consts
+const (
+	// JobsBucket is the bucket that receives the job list of each plan.
+	JobsBucket = "CORSO_JOBS_BUCKET"
+)
+
+const (
+	defaultBatchSize = 25
+	jobsKeyPrefix    = "corso-plans/"
+)
+
+// PlanEvent is the payload accepted by the plan function, which splits a
+// tenant-wide backup into backup jobs that can run in parallel.
+type PlanEvent struct {
+	// Service is one of: exchange, onedrive, sharepoint, groups, teamsChats,
+	// entraID.
+	Service string `json:"service"`
+	// Categories are copied into each job.  See BackupEvent.Categories.
+	Categories []string `json:"categories,omitempty"`
+	// Exclude are the ids of resources that should not be backed up.
+	Exclude []string `json:"exclude,omitempty"`
+	// BatchSize is the maximum number of resources in each job.  Defaults
+	// to 25.
+	BatchSize     int                   `json:"batchSize,omitempty"`
+	FailurePolicy control.FailurePolicy `json:"failurePolicy,omitempty"`
+	// Resumable is copied into each job.  See BackupEvent.Resumable.
+	Resumable bool `json:"resumable,omitempty"`
+	// Repo is copied into each job.  It can't hold any secret values,
+	// since every job shows up in the execution history of the run;
+	// reference them with a secret source instead.
+	Repo RepoConfig `json:"repo"`
+}
+
+// PlanResponse is returned by the plan function.  The jobs themselves
+// are too many to return in the state machine's payload, so they're
+// written to the jobs bucket, to be read by the Map state.
+type PlanResponse struct {
+	RunID     string  `json:"runID"`
+	Resources int     `json:"resources"`
+	JobCount  int     `json:"jobCount"`
+	Jobs      JobList `json:"jobs"`
+}
+
+// JobList locates the json array of jobs produced by a plan.  Each job is
+// a complete payload for the backup function.
+type JobList struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+}
+
+// idGetter matches the models returned by the api GetAll funcs.
+type idGetter interface {
+	GetId() *string
+}
+
+type getAller[T idGetter] interface {
+	GetAll(ctx context.Context, errs *fault.Bus) ([]T, error)
+}
+
+func (ev PlanEvent) validate() error {
+	if _, ok := serviceCategories[path.ToServiceType(ev.Service)]; !ok {
+		return clues.New("unsupported service: [" + ev.Service + "]")
+	}
+
+	if ev.BatchSize < 0 {
+		return clues.New("batch size cannot be negative")
+	}
+
+	if ev.Repo.holdsSecrets() {
+		return clues.New("plan events cannot hold secret values; reference them with a secret source")
+	}
+
+	// validate the job template with a placeholder resource, so that
+	// bad input fails here instead of in every job.
+	return ev.job([]string{"placeholder"}).validate()
+}
+
+// job produces the backup event for a single batch of resources.
+func (ev PlanEvent) job(resources []string) BackupEvent {
+	return BackupEvent{
+		Service:       ev.Service,
+		Resources:     resources,
+		Categories:    ev.Categories,
+		FailurePolicy: ev.FailurePolicy,
+		Resumable:     ev.Resumable,
+		Repo:          ev.Repo,
+	}
+}
+
+// Plan enumerates the resources in the tenant that are protected by the
+// event's service, and partitions them into backup jobs.
+func Plan(ctx context.Context, ev PlanEvent) (PlanResponse, error) {
+	ctx, flush := seed(ctx)
+	defer flush()
+
+	if err := ev.validate(); err != nil {
+		return PlanResponse{}, clues.Wrap(err, "validating plan event")
+	}
+
+	pst := path.ToServiceType(ev.Service)
+	ctx = clues.Add(ctx, "service", pst.String())
+
+	bucket := os.Getenv(JobsBucket)
+	if len(bucket) == 0 {
+		return PlanResponse{}, clues.New("$" + JobsBucket + " is not set")
+	}
+
+	if err := loadSecrets(ctx, ev.Repo); err != nil {
+		return PlanResponse{}, clues.Stack(err)
+	}
+
+	acct, err := ev.Repo.account()
+	if err != nil {
+		return PlanResponse{}, clues.Stack(err)
+	}
+
+	creds, err := acct.M365Config()
+	if err != nil {
+		return PlanResponse{}, clues.WrapWC(ctx, err, "getting m365 account creds")
+	}
+
+	ac, err := api.NewClient(creds, ev.Repo.options(ev.FailurePolicy), count.New())
+	if err != nil {
+		return PlanResponse{}, clues.WrapWC(ctx, err, "constructing api client")
+	}
+
+	ids, err := resourceIDs(ctx, ac, pst)
+	if err != nil {
+		return PlanResponse{}, clues.Wrap(err, "enumerating resources")
+	}
+
+	resp, jobs := planJobs(ev, ids)
+
+	dest, err := newS3Putter(S3Destination{Bucket: bucket})
+	if err != nil {
+		return PlanResponse{}, clues.Stack(err)
+	}
+
+	resp.Jobs = JobList{
+		Bucket: bucket,
+		Key:    jobsKeyPrefix + resp.RunID + "/jobs.json",
+	}
+
+	if err := writeJobs(ctx, dest, resp.Jobs.Key, jobs); err != nil {
+		return PlanResponse{}, clues.Stack(err)
+	}
+
+	logger.Ctx(ctx).Infow(
+		"planned backup run",
+		"run_id", resp.RunID,
+		"resources", resp.Resources,
+		"jobs", resp.JobCount)
+
+	return resp, nil
+}
+
+// writeJobs uploads the jobs as a json array.
+func writeJobs(
+	ctx context.Context,
+	op objectPutter,
+	key string,
+	jobs []BackupEvent,
+) error {
+	bs, err := json.Marshal(jobs)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "serializing jobs")
+	}
+
+	err = op.PutObject(ctx, key, bytes.NewReader(bs))
+
+	return clues.Wrap(err, "writing jobs").With("jobs_key", key).OrNil()
+}
+
+// resourceIDs lists the ids of all resources that can hold data for
+// the service.
+func resourceIDs(
+	ctx context.Context,
+	ac api.Client,
+	pst path.ServiceType,
+) ([]string, error) {
+	errs := fault.New(true)
+
+	switch pst {
+	case path.ExchangeService, path.OneDriveService, path.TeamsChatsService:
+		return getAllIDs(ctx, ac.Users(), errs)
+	case path.SharePointService:
+		return getAllIDs(ctx, ac.Sites(), errs)
+	case path.GroupsService:
+		return getAllIDs(ctx, ac.Groups(), errs)
+	case path.EntraIDService:
+		// the directory is backed up as a whole, under the tenant.
+		return []string{ac.Credentials.AzureTenantID}, nil
+	}
+
+	return nil, clues.NewWC(ctx, "unsupported service")
+}
+
+func getAllIDs[T idGetter](
+	ctx context.Context,
+	ga getAller[T],
+	errs *fault.Bus,
+) ([]string, error) {
+	rs, err := ga.GetAll(ctx, errs)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	ids := make([]string, 0, len(rs))
+
+	for _, r := range rs {
+		if id := ptr.Val(r.GetId()); len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, errs.Failure()
+}
+
+// planJobs drops excluded resources and partitions the rest into jobs.
+func planJobs(ev PlanEvent, ids []string) (PlanResponse, []BackupEvent) {
+	size := ev.BatchSize
+	if size == 0 {
+		size = defaultBatchSize
+	}
+
+	ids = slices.DeleteFunc(slices.Clone(ids), func(id string) bool {
+		return slices.Contains(ev.Exclude, id)
+	})
+
+	// sorting keeps the batches stable between runs.
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	var (
+		resp = PlanResponse{
+			RunID:     uuid.NewString(),
+			Resources: len(ids),
+		}
+		jobs = []BackupEvent{}
+	)
+
+	for len(ids) > 0 {
+		n := min(size, len(ids))
+		jobs = append(jobs, ev.job(ids[:n:n]))
+		ids = ids[n:]
+	}
+
+	resp.JobCount = len(jobs)
+
+	return resp, jobs
+}
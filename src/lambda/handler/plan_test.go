@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+type mockGetAller struct {
+	users []models.Userable
+	err   error
+}
+
+func (mg mockGetAller) GetAll(context.Context, *fault.Bus) ([]models.Userable, error) {
+	return mg.users, mg.err
+}
+
+func (suite *PlanUnitSuite) TestPlanEvent_Validate() {
+	table := []struct {
+		name      string
+		ev        PlanEvent
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "valid",
+			ev:        PlanEvent{Service: "exchange", Categories: []string{CategoryEmail}},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "unknown service",
+			ev:        PlanEvent{Service: "teams"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "negative batch size",
+			ev:        PlanEvent{Service: "onedrive", BatchSize: -1},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "bad category",
+			ev:        PlanEvent{Service: "onedrive", Categories: []string{CategoryEmail}},
+			expectErr: assert.Error,
+		},
+		{
+			name: "secret source",
+			ev: PlanEvent{
+				Service: "onedrive",
+				Repo:    RepoConfig{SecretSource: "ssm:///corso"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "inline passphrase",
+			ev: PlanEvent{
+				Service: "onedrive",
+				Repo:    RepoConfig{Passphrase: "pass"},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "inline client secret",
+			ev: PlanEvent{
+				Service: "onedrive",
+				Repo:    RepoConfig{M365: M365Config{ClientSecret: "secret"}},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "resumable best effort",
+			ev: PlanEvent{
+				Service:       "onedrive",
+				Resumable:     true,
+				FailurePolicy: control.BestEffort,
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.ev.validate()
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestGetAllIDs() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	u1 := models.NewUser()
+	u1.SetId(ptr.To("u1"))
+
+	u2 := models.NewUser()
+	u2.SetId(ptr.To("u2"))
+
+	ids, err := getAllIDs[models.Userable](
+		ctx,
+		mockGetAller{users: []models.Userable{u1, models.NewUser(), u2}},
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []string{"u1", "u2"}, ids)
+
+	_, err = getAllIDs[models.Userable](
+		ctx,
+		mockGetAller{err: assert.AnError},
+		fault.New(true))
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *PlanUnitSuite) TestPlanJobs() {
+	table := []struct {
+		name         string
+		ev           PlanEvent
+		ids          []string
+		expectedJobs [][]string
+	}{
+		{
+			name:         "no resources",
+			ev:           PlanEvent{Service: "exchange"},
+			expectedJobs: [][]string{},
+		},
+		{
+			name:         "default batch size",
+			ev:           PlanEvent{Service: "exchange"},
+			ids:          []string{"b", "a", "c"},
+			expectedJobs: [][]string{{"a", "b", "c"}},
+		},
+		{
+			name:         "partial last batch",
+			ev:           PlanEvent{Service: "exchange", BatchSize: 2},
+			ids:          []string{"e", "d", "c", "b", "a"},
+			expectedJobs: [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		},
+		{
+			name:         "exclusions and duplicates",
+			ev:           PlanEvent{Service: "exchange", BatchSize: 2, Exclude: []string{"b"}},
+			ids:          []string{"a", "b", "c", "a"},
+			expectedJobs: [][]string{{"a", "c"}},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			test.ev.Categories = []string{CategoryEmail}
+			test.ev.Resumable = true
+			test.ev.Repo = RepoConfig{SecretSource: "ssm:///corso"}
+
+			resp, jobs := planJobs(test.ev, test.ids)
+			assert.NotEmpty(t, resp.RunID)
+			assert.Equal(t, len(jobs), resp.JobCount)
+
+			batches := [][]string{}
+			total := 0
+
+			for _, j := range jobs {
+				batches = append(batches, j.Resources)
+				total += len(j.Resources)
+
+				assert.Equal(t, test.ev.Service, j.Service)
+				assert.Equal(t, test.ev.Categories, j.Categories)
+				assert.Equal(t, test.ev.Repo, j.Repo)
+				assert.True(t, j.Resumable)
+			}
+
+			assert.Equal(t, test.expectedJobs, batches)
+			assert.Equal(t, total, resp.Resources)
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestWriteJobs() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		mp   = &mockPutter{objects: map[string]string{}}
+		ev   = PlanEvent{Service: "exchange", BatchSize: 1}
+		_, j = planJobs(ev, []string{"a", "b"})
+	)
+
+	err := writeJobs(ctx, mp, "corso-plans/run/jobs.json", j)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Contains(t, mp.objects, "corso-plans/run/jobs.json")
+
+	written := []BackupEvent{}
+
+	err = json.Unmarshal([]byte(mp.objects["corso-plans/run/jobs.json"]), &written)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, j, written)
+
+	mp.failOn = "fail"
+
+	err = writeJobs(ctx, mp, "fail", j)
+	assert.Error(t, err, clues.ToCore(err))
+}
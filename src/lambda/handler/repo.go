@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/credentials/secrets"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+const (
+	defaultRepoUser = "corso"
+	defaultRepoHost = "lambda"
+)
+
+// loadedSecretSource is the uri of the secret source last read by the
+// function.  Lambda reuses the process between invocations, so a source
+// is only read again if an event references a different one.
+var loadedSecretSource string
+
+// RepoConfig describes the repository, and the m365 account that owns it,
+// used by a single invocation.  Any secret omitted from the event falls
+// back to the secret source, or to the matching environment variable of
+// the function.
+type RepoConfig struct {
+	// SecretSource is the uri of the source that holds the secrets (see
+	// the secrets package).  Falls back to $CORSO_SECRET_SOURCE.  Events
+	// that get recorded, like the jobs of a plan, reference their secrets
+	// through the source instead of holding the values.
+	SecretSource string `json:"secretSource,omitempty"`
+	// RepoID is the id of the repository.  Optional; if empty, the id
+	// is read from the repository after connecting.
+	RepoID string `json:"repoID,omitempty"`
+	// Provider is the storage provider name, as written to the corso
+	// config file (ex: "S3", "Filesystem", "Azure").
+	Provider string `json:"provider"`
+	// Passphrase falls back to $CORSO_PASSPHRASE.
+	Passphrase string `json:"passphrase,omitempty"`
+	// User and Host identify the kopia client.  Both default to
+	// corso-specific values, since neither can be read from the
+	// lambda environment.
+	User string `json:"user,omitempty"`
+	Host string `json:"host,omitempty"`
+
+	S3         S3Config         `json:"s3"`
+	Filesystem FilesystemConfig `json:"filesystem"`
+	Azure      AzureConfig      `json:"azure"`
+	M365       M365Config       `json:"m365"`
+}
+
+// S3Config holds the s3 storage details.  AWS credentials are not
+// accepted in the event; the function's execution role is used instead.
+type S3Config struct {
+	Bucket         string `json:"bucket"`
+	Endpoint       string `json:"endpoint,omitempty"`
+	Prefix         string `json:"prefix,omitempty"`
+	DoNotUseTLS    bool   `json:"doNotUseTLS,omitempty"`
+	DoNotVerifyTLS bool   `json:"doNotVerifyTLS,omitempty"`
+}
+
+// FilesystemConfig holds the filesystem storage details.  Only useful
+// when the function has an EFS volume mounted.
+type FilesystemConfig struct {
+	Path string `json:"path"`
+}
+
+// AzureConfig holds the azure blob storage details.  The account key and
+// SAS token fall back to $AZURE_STORAGE_KEY and $AZURE_STORAGE_SAS_TOKEN.
+// If neither is available, the storage account is accessed as the service
+// principal in the tenant and client ids, with the secret in
+// $AZURE_STORAGE_CLIENT_SECRET.
+type AzureConfig struct {
+	Container      string `json:"container"`
+	StorageAccount string `json:"storageAccount"`
+	Prefix         string `json:"prefix,omitempty"`
+	Endpoint       string `json:"endpoint,omitempty"`
+	TenantID       string `json:"tenantID,omitempty"`
+	ClientID       string `json:"clientID,omitempty"`
+}
+
+// M365Config holds the m365 account details.  The client id and secret
+// fall back to $AZURE_CLIENT_ID and $AZURE_CLIENT_SECRET, and the tenant
+// id to $AZURE_TENANT_ID.  If $CORSO_SECRET_SOURCE is set, the values held
+// by that source are used in place of the env vars.
+//
+// A client certificate or a federated token file can be used instead of
+// the secret.  They fall back to $AZURE_CLIENT_CERTIFICATE (or
+// $AZURE_CLIENT_CERTIFICATE_PATH), $AZURE_CLIENT_CERTIFICATE_PASSWORD,
+// and $AZURE_FEDERATED_TOKEN_FILE.
+type M365Config struct {
+	TenantID     string `json:"tenantID,omitempty"`
+	ClientID     string `json:"clientID,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	// ClientCertificate is PEM text, or base64 encoded PFX data.
+	ClientCertificate         string `json:"clientCertificate,omitempty"`
+	ClientCertificatePassword string `json:"clientCertificatePassword,omitempty"`
+	FederatedTokenFile        string `json:"federatedTokenFile,omitempty"`
+}
+
+// holdsSecrets is true if any secret value is written into the config.
+func (rc RepoConfig) holdsSecrets() bool {
+	return len(rc.Passphrase) > 0 ||
+		len(rc.M365.ClientSecret) > 0 ||
+		len(rc.M365.ClientCertificate) > 0 ||
+		len(rc.M365.ClientCertificatePassword) > 0
+}
+
+func (rc RepoConfig) provider() storage.ProviderType {
+	for k, v := range storage.StringToProviderType {
+		if strings.EqualFold(k, rc.Provider) {
+			return v
+		}
+	}
+
+	return storage.ProviderUnknown
+}
+
+// storage builds the storage.Storage described by the config.
+func (rc RepoConfig) storage() (storage.Storage, error) {
+	var (
+		provider = rc.provider()
+		sc       storage.Configurer
+	)
+
+	switch provider {
+	case storage.ProviderS3:
+		sc = &storage.S3Config{
+			Bucket:         rc.S3.Bucket,
+			Endpoint:       str.First(rc.S3.Endpoint, "s3.amazonaws.com"),
+			Prefix:         rc.S3.Prefix,
+			DoNotUseTLS:    rc.S3.DoNotUseTLS,
+			DoNotVerifyTLS: rc.S3.DoNotVerifyTLS,
+		}
+	case storage.ProviderFilesystem:
+		sc = &storage.FilesystemConfig{
+			Path: rc.Filesystem.Path,
+		}
+	case storage.ProviderAzure:
+		sc = &storage.AzureConfig{
+			AzureStorage: credentials.AzureStorage{
+				AccountKey:   credentials.Lookup(credentials.AzureStorageKey),
+				SASToken:     credentials.Lookup(credentials.AzureStorageSASToken),
+				ClientSecret: credentials.Lookup(credentials.AzureStorageClientSecret),
+			},
+			Container:      rc.Azure.Container,
+			StorageAccount: rc.Azure.StorageAccount,
+			Prefix:         rc.Azure.Prefix,
+			Endpoint:       rc.Azure.Endpoint,
+			TenantID:       rc.Azure.TenantID,
+			ClientID:       rc.Azure.ClientID,
+		}
+	default:
+		return storage.Storage{}, clues.New("unsupported storage provider: [" + rc.Provider + "]")
+	}
+
+	corso := credentials.Corso{
+		CorsoPassphrase: str.First(rc.Passphrase, credentials.Lookup(credentials.CorsoPassphrase)),
+	}
+
+	if err := corso.Validate(); err != nil {
+		return storage.Storage{}, clues.Wrap(err, "validating corso credentials")
+	}
+
+	// lambda only allows writes to the tmp directory, which is where
+	// kopia needs to keep its config and cache.
+	cCfg := storage.CommonConfig{
+		Corso:       corso,
+		KopiaCfgDir: os.TempDir(),
+	}
+
+	st, err := storage.NewStorage(provider, sc, cCfg)
+	if err != nil {
+		return storage.Storage{}, clues.Wrap(err, "configuring repository storage")
+	}
+
+	return st, nil
+}
+
+// account builds the account.Account described by the config.
+func (rc RepoConfig) account() (account.Account, error) {
+	m365Cfg := account.M365Config{
+		M365: credentials.M365{
+			AzureClientID:       str.First(rc.M365.ClientID, credentials.Lookup(credentials.AzureClientID)),
+			AzureClientSecret:   str.First(rc.M365.ClientSecret, credentials.Lookup(credentials.AzureClientSecret)),
+			AzureClientCert:     str.First(rc.M365.ClientCertificate, credentials.Lookup(credentials.AzureClientCert)),
+			AzureClientCertPath: credentials.Lookup(credentials.AzureClientCertPath),
+			AzureClientCertPassword: str.First(
+				rc.M365.ClientCertificatePassword,
+				credentials.Lookup(credentials.AzureClientCertPassword)),
+			AzureFederatedTokenFile: str.First(
+				rc.M365.FederatedTokenFile,
+				credentials.Lookup(credentials.AzureFederatedTokenFile)),
+		},
+		AzureTenantID: str.First(rc.M365.TenantID, credentials.Lookup(account.AzureTenantID)),
+	}
+
+	acct, err := account.NewAccount(account.ProviderM365, m365Cfg)
+	if err != nil {
+		return account.Account{}, clues.Wrap(err, "retrieving m365 account configuration")
+	}
+
+	return acct, nil
+}
+
+// options produces the control options shared by all operations within
+// an invocation.
+func (rc RepoConfig) options(fp control.FailurePolicy) control.Options {
+	opts := control.DefaultOptions()
+
+	if len(fp) > 0 {
+		opts.FailureHandling = fp
+	}
+
+	opts.Repo.User = str.First(rc.User, defaultRepoUser)
+	opts.Repo.Host = str.First(rc.Host, defaultRepoHost)
+
+	return opts
+}
+
+// loadSecrets reads the credentials held by the config's secret source,
+// or by the source in $CORSO_SECRET_SOURCE.  If neither is set, any
+// previously loaded secrets are dropped so that the env vars get used.
+func loadSecrets(ctx context.Context, rc RepoConfig) error {
+	uri := str.First(rc.SecretSource, os.Getenv(secrets.SourceEnv))
+	if uri == loadedSecretSource {
+		return nil
+	}
+
+	if len(uri) == 0 {
+		credentials.ClearSecrets()
+		loadedSecretSource = ""
+
+		return nil
+	}
+
+	src, err := secrets.FromURI(uri)
+	if err != nil {
+		return clues.Wrap(err, "configuring secret source")
+	}
+
+	if err := credentials.LoadSecrets(ctx, src, account.AzureTenantID); err != nil {
+		return clues.Stack(err)
+	}
+
+	loadedSecretSource = uri
+
+	return nil
+}
+
+// connect builds a repository from the config and connects to it.
+// Callers are expected to close the repository when done.
+func connect(
+	ctx context.Context,
+	rc RepoConfig,
+	opts control.Options,
+	pst path.ServiceType,
+) (repository.Repositoryer, error) {
+	if err := loadSecrets(ctx, rc); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	st, err := rc.storage()
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	acct, err := rc.account()
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	repoID := rc.RepoID
+	if len(repoID) == 0 {
+		repoID = events.RepoIDNotFound
+	}
+
+	r, err := repository.New(ctx, acct, st, opts, repoID)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating a repository controller")
+	}
+
+	if err := r.Connect(ctx, repository.ConnConfig{Service: pst}); err != nil {
+		return nil, clues.Wrap(err, "connecting to the "+st.Provider.String()+" repository")
+	}
+
+	return r, nil
+}
+
+func closeRepo(ctx context.Context, r repository.Repositoryer) {
+	if err := r.Close(ctx); err != nil {
+		logger.CtxErr(ctx, err).Info("closing repository")
+	}
+}
+
+func validFailurePolicy(fp control.FailurePolicy) bool {
+	switch fp {
+	case "", control.FailFast, control.FailAfterRecovery, control.BestEffort:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/credentials/secrets"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+type RepoConfigUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepoConfigUnitSuite(t *testing.T) {
+	suite.Run(t, &RepoConfigUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RepoConfigUnitSuite) TestStorage() {
+	table := []struct {
+		name      string
+		rc        RepoConfig
+		envPass   string
+		expect    storage.ProviderType
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "s3",
+			rc: RepoConfig{
+				Provider:   "S3",
+				Passphrase: "pass",
+				S3:         S3Config{Bucket: "bkt", Prefix: "pfx"},
+			},
+			expect:    storage.ProviderS3,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "s3, lowercase provider, passphrase from env",
+			rc: RepoConfig{
+				Provider: "s3",
+				S3:       S3Config{Bucket: "bkt"},
+			},
+			envPass:   "pass",
+			expect:    storage.ProviderS3,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "filesystem",
+			rc: RepoConfig{
+				Provider:   "filesystem",
+				Passphrase: "pass",
+				Filesystem: FilesystemConfig{Path: "/mnt/efs/corso"},
+			},
+			expect:    storage.ProviderFilesystem,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "azure",
+			rc: RepoConfig{
+				Provider:   "azure",
+				Passphrase: "pass",
+				Azure:      AzureConfig{Container: "ctr", StorageAccount: "acct"},
+			},
+			expect:    storage.ProviderAzure,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "azure, missing storage account",
+			rc: RepoConfig{
+				Provider:   "Azure",
+				Passphrase: "pass",
+				Azure:      AzureConfig{Container: "ctr"},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "s3, missing bucket",
+			rc: RepoConfig{
+				Provider:   "S3",
+				Passphrase: "pass",
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "missing passphrase",
+			rc: RepoConfig{
+				Provider: "S3",
+				S3:       S3Config{Bucket: "bkt"},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "unknown provider",
+			rc: RepoConfig{
+				Provider:   "tape",
+				Passphrase: "pass",
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Setenv(credentials.CorsoPassphrase, test.envPass)
+
+			st, err := test.rc.storage()
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expect, st.Provider)
+
+			cc, err := st.CommonConfig()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.NotEmpty(t, cc.CorsoPassphrase)
+			assert.NotEmpty(t, cc.KopiaCfgDir)
+		})
+	}
+}
+
+func (suite *RepoConfigUnitSuite) TestAccount() {
+	t := suite.T()
+
+	t.Setenv(credentials.AzureClientID, "env-cid")
+	t.Setenv(credentials.AzureClientSecret, "env-secret")
+	t.Setenv(account.AzureTenantID, "")
+
+	rc := RepoConfig{
+		M365: M365Config{
+			TenantID: "tid",
+			ClientID: "cid",
+		},
+	}
+
+	acct, err := rc.account()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "tid", acct.ID())
+
+	m365, err := acct.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "cid", m365.AzureClientID)
+	assert.Equal(t, "env-secret", m365.AzureClientSecret)
+
+	_, err = RepoConfig{}.account()
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *RepoConfigUnitSuite) TestAccount_WithoutSecret() {
+	t := suite.T()
+
+	t.Setenv(credentials.AzureClientID, "env-cid")
+	t.Setenv(credentials.AzureClientSecret, "")
+	t.Setenv(credentials.AzureClientCertPassword, "env-pw")
+	t.Setenv(credentials.AzureFederatedTokenFile, "/var/run/secrets/token")
+	t.Setenv(account.AzureTenantID, "env-tid")
+
+	rc := RepoConfig{
+		M365: M365Config{
+			ClientCertificate: "-----BEGIN CERTIFICATE-----",
+		},
+	}
+
+	acct, err := rc.account()
+	require.NoError(t, err, clues.ToCore(err))
+
+	m365, err := acct.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "env-cid", m365.AzureClientID)
+	assert.Empty(t, m365.AzureClientSecret)
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----", m365.AzureClientCert)
+	assert.Equal(t, "env-pw", m365.AzureClientCertPassword)
+	assert.Equal(t, "/var/run/secrets/token", m365.AzureFederatedTokenFile)
+}
+
+func (suite *RepoConfigUnitSuite) TestLoadSecrets() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	defer func() {
+		credentials.ClearSecrets()
+		loadedSecretSource = ""
+	}()
+
+	t.Setenv(secrets.SourceEnv, "")
+	t.Setenv(credentials.AzureClientSecret, "env-secret")
+
+	fn := filepath.Join(t.TempDir(), "secrets.json")
+	err := os.WriteFile(fn, []byte(`{"AZURE_CLIENT_SECRET": "file-secret"}`), 0o600)
+	require.NoError(t, err, clues.ToCore(err))
+
+	rc := RepoConfig{SecretSource: "file://" + fn}
+
+	err = loadSecrets(ctx, rc)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "file-secret", credentials.Lookup(credentials.AzureClientSecret))
+	assert.Equal(t, rc.SecretSource, loadedSecretSource)
+
+	// without a source, the env vars are used again.
+	err = loadSecrets(ctx, RepoConfig{})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "env-secret", credentials.Lookup(credentials.AzureClientSecret))
+
+	err = loadSecrets(ctx, RepoConfig{SecretSource: "vault://corso"})
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *RepoConfigUnitSuite) TestHoldsSecrets() {
+	t := suite.T()
+
+	assert.False(t, RepoConfig{SecretSource: "ssm:///corso", M365: M365Config{ClientID: "cid"}}.holdsSecrets())
+	assert.True(t, RepoConfig{Passphrase: "pass"}.holdsSecrets())
+	assert.True(t, RepoConfig{M365: M365Config{ClientSecret: "secret"}}.holdsSecrets())
+	assert.True(t, RepoConfig{M365: M365Config{ClientCertificatePassword: "pw"}}.holdsSecrets())
+}
+
+func (suite *RepoConfigUnitSuite) TestOptions() {
+	t := suite.T()
+
+	opts := RepoConfig{}.options("")
+	assert.Equal(t, control.FailAfterRecovery, opts.FailureHandling)
+	assert.Equal(t, defaultRepoUser, opts.Repo.User)
+	assert.Equal(t, defaultRepoHost, opts.Repo.Host)
+
+	opts = RepoConfig{User: "u", Host: "h"}.options(control.FailFast)
+	assert.Equal(t, control.FailFast, opts.FailureHandling)
+	assert.Equal(t, "u", opts.Repo.User)
+	assert.Equal(t, "h", opts.Repo.Host)
+}
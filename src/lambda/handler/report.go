@@ -0,0 +1,387 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// maxRunRounds bounds how many rounds of jobs a run goes through.  Each
+// round after the first resumes the backups that the previous one left at
+// a checkpoint.  Whatever remains after the last round is returned in the
+// report's continuation tokens.
+const maxRunRounds = 24
+
+// ReportEvent is the payload accepted by the report function.  It locates
+// the results of one round of backup jobs, which the Map state writes to
+// the jobs bucket.
+type ReportEvent struct {
+	RunID string `json:"runID"`
+	// Round is the number of rounds that ran before this one.
+	Round   int                 `json:"round"`
+	Results ResultWriterDetails `json:"results"`
+	// Previous is the report of the earlier rounds of the run, if any.
+	Previous *RunReport `json:"previous,omitempty"`
+}
+
+// ResultWriterDetails locates the manifest written by the ResultWriter of
+// a distributed Map state.
+type ResultWriterDetails struct {
+	Bucket string `json:"Bucket"`
+	Key    string `json:"Key"`
+}
+
+// ReportResponse is returned by the report function.
+type ReportResponse struct {
+	RunID  string    `json:"runID"`
+	Round  int       `json:"round"`
+	Report RunReport `json:"report"`
+	// Jobs locates the jobs which resume the backups that this round left
+	// at a checkpoint.  Nil once the run is done.
+	Jobs *JobList `json:"jobs,omitempty"`
+}
+
+// JobResult is the outcome of one backup job.  Exactly one of Response
+// or Error is expected to be populated.
+type JobResult struct {
+	// Resources are the resources assigned to the job.
+	Resources []string        `json:"resources"`
+	Response  *BackupResponse `json:"response,omitempty"`
+	// Error is populated when the backup function itself failed, which
+	// leaves every resource in the job without a result.
+	Error *JobError `json:"error,omitempty"`
+}
+
+// JobError matches the error output of a failed state machine task.
+type JobError struct {
+	Error string `json:"Error"`
+	Cause string `json:"Cause"`
+}
+
+// RunReport aggregates the results of every resource in a run.
+type RunReport struct {
+	RunID     string `json:"runID"`
+	Resources int    `json:"resources"`
+	// Succeeded counts resources that produced a complete backup.
+	Succeeded int `json:"succeeded"`
+	// Failed counts resources that have no backup, either because the
+	// backup failed or because the job running it failed.
+	Failed int `json:"failed"`
+	// Checkpointed counts resources whose backup stopped at a checkpoint
+	// in the last round.
+	Checkpointed int `json:"checkpointed"`
+	// NotEnabled counts resources that don't have the service enabled.
+	NotEnabled int `json:"notEnabled"`
+	// Pending counts resources that weren't started in the last round
+	// because their job ran out of time.  They are covered by the
+	// continuation tokens.
+	Pending       int   `json:"pending"`
+	BytesUploaded int64 `json:"bytesUploaded"`
+	// Backups holds the result of every backup in the run, including the
+	// checkpoints of backups that were resumed in a later round.
+	Backups []BackupResult `json:"backups"`
+	// ContinuationTokens hold the work left over by the last round.  They
+	// are only populated if the run ended before it could resume them.
+	ContinuationTokens []string `json:"continuationTokens,omitempty"`
+	// JobErrors are the errors of jobs that failed outright.
+	JobErrors []string `json:"jobErrors,omitempty"`
+}
+
+// objectGetter reads a single object.
+type objectGetter interface {
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// resultManifest is the manifest written by the ResultWriter of a
+// distributed Map state.  It lists the files holding the results of the
+// child executions, grouped by their status.
+type resultManifest struct {
+	ResultFiles struct {
+		Failed    []resultFile `json:"FAILED"`
+		Pending   []resultFile `json:"PENDING"`
+		Succeeded []resultFile `json:"SUCCEEDED"`
+	} `json:"ResultFiles"`
+}
+
+type resultFile struct {
+	Key string `json:"Key"`
+}
+
+// childExecution is a single entry in a result file.  The input and output
+// of the execution are json documents serialized as strings.
+type childExecution struct {
+	Input  string `json:"Input"`
+	Output string `json:"Output"`
+	Status string `json:"Status"`
+	Error  string `json:"Error"`
+	Cause  string `json:"Cause"`
+}
+
+// jobOutcome pairs a job with its result.
+type jobOutcome struct {
+	job    BackupEvent
+	result JobResult
+}
+
+// Report aggregates the per-job results of a round of a run, along with
+// the report of its earlier rounds.  Backups left at a checkpoint are
+// written to the jobs bucket as the jobs of the next round.  It only fails
+// if the results can't be read or the next round can't be written; a
+// malformed job result is counted against its resources.
+func Report(ctx context.Context, ev ReportEvent) (ReportResponse, error) {
+	ctx, flush := seed(ctx)
+	defer flush()
+
+	ctx = clues.Add(ctx, "run_id", ev.RunID, "round", ev.Round)
+
+	bucket := os.Getenv(JobsBucket)
+	if len(bucket) == 0 {
+		return ReportResponse{}, clues.New("$" + JobsBucket + " is not set")
+	}
+
+	og, err := newS3Getter(S3Destination{Bucket: ev.Results.Bucket})
+	if err != nil {
+		return ReportResponse{}, clues.Stack(err)
+	}
+
+	op, err := newS3Putter(S3Destination{Bucket: bucket})
+	if err != nil {
+		return ReportResponse{}, clues.Stack(err)
+	}
+
+	resp, err := reportRound(ctx, ev, og, op, bucket)
+	if err != nil {
+		return ReportResponse{}, clues.Stack(err)
+	}
+
+	logger.Ctx(ctx).Infow(
+		"backup run round complete",
+		"resources", resp.Report.Resources,
+		"succeeded", resp.Report.Succeeded,
+		"failed", resp.Report.Failed,
+		"checkpointed", resp.Report.Checkpointed,
+		"not_enabled", resp.Report.NotEnabled,
+		"pending", resp.Report.Pending,
+		"run_complete", resp.Jobs == nil)
+
+	return resp, nil
+}
+
+// reportRound reads the results of the round, and writes the jobs of the
+// next round into the bucket if any backups need to be resumed.
+func reportRound(
+	ctx context.Context,
+	ev ReportEvent,
+	og objectGetter,
+	op objectPutter,
+	bucket string,
+) (ReportResponse, error) {
+	outcomes, err := readResults(ctx, og, ev.Results.Key)
+	if err != nil {
+		return ReportResponse{}, clues.Stack(err)
+	}
+
+	var (
+		results = make([]JobResult, 0, len(outcomes))
+		next    []BackupEvent
+	)
+
+	for _, o := range outcomes {
+		results = append(results, o.result)
+
+		if o.result.Response != nil && len(o.result.Response.ContinuationToken) > 0 {
+			next = append(next, o.job.continueWith(o.result.Response.ContinuationToken))
+		}
+	}
+
+	resp := ReportResponse{
+		RunID:  ev.RunID,
+		Round:  ev.Round + 1,
+		Report: mergeReports(ev.Previous, buildReport(ev.RunID, results)),
+	}
+
+	if len(next) == 0 || resp.Round >= maxRunRounds {
+		return resp, nil
+	}
+
+	resp.Jobs = &JobList{
+		Bucket: bucket,
+		Key:    jobsKeyPrefix + ev.RunID + "/jobs-" + strconv.Itoa(resp.Round) + ".json",
+	}
+
+	if err := writeJobs(ctx, op, resp.Jobs.Key, next); err != nil {
+		return ReportResponse{}, clues.Stack(err)
+	}
+
+	// the tokens get resumed by the next round.
+	resp.Report.ContinuationTokens = nil
+
+	return resp, nil
+}
+
+// readResults reads the results of every job listed in the manifest.
+func readResults(
+	ctx context.Context,
+	og objectGetter,
+	manifestKey string,
+) ([]jobOutcome, error) {
+	var rm resultManifest
+
+	if err := readJSON(ctx, og, manifestKey, &rm); err != nil {
+		return nil, clues.Wrap(err, "reading result manifest")
+	}
+
+	var (
+		outcomes []jobOutcome
+		files    = append(
+			append(slices.Clone(rm.ResultFiles.Succeeded), rm.ResultFiles.Failed...),
+			rm.ResultFiles.Pending...)
+	)
+
+	for _, rf := range files {
+		var ces []childExecution
+
+		if err := readJSON(ctx, og, rf.Key, &ces); err != nil {
+			return nil, clues.Wrap(err, "reading job results")
+		}
+
+		for _, ce := range ces {
+			outcomes = append(outcomes, ce.outcome(ctx))
+		}
+	}
+
+	return outcomes, nil
+}
+
+func readJSON(ctx context.Context, og objectGetter, key string, v any) error {
+	rc, err := og.GetObject(ctx, key)
+	if err != nil {
+		return clues.Stack(err).With("object_key", key)
+	}
+
+	defer rc.Close()
+
+	if err := json.NewDecoder(rc).Decode(v); err != nil {
+		return clues.WrapWC(ctx, err, "decoding object").With("object_key", key)
+	}
+
+	return nil
+}
+
+// outcome produces the job and result of the execution.  Executions that
+// didn't succeed, or whose output can't be read, are reported as failed
+// jobs.
+func (ce childExecution) outcome(ctx context.Context) jobOutcome {
+	var o jobOutcome
+
+	if err := json.Unmarshal([]byte(ce.Input), &o.job); err != nil {
+		logger.CtxErr(ctx, err).Error("reading job input")
+	}
+
+	resources, err := o.job.jobResources()
+	if err != nil {
+		logger.CtxErr(ctx, err).Error("reading job resources")
+	}
+
+	if ce.Status == "SUCCEEDED" {
+		if err := json.Unmarshal([]byte(ce.Output), &o.result); err != nil {
+			logger.CtxErr(ctx, err).Error("reading job output")
+
+			o.result = JobResult{Error: &JobError{Error: "MalformedOutput", Cause: err.Error()}}
+		}
+	} else {
+		// executions that were aborted or never ran have no error of
+		// their own.
+		o.result.Error = &JobError{
+			Error: str.First(ce.Error, ce.Status),
+			Cause: ce.Cause,
+		}
+	}
+
+	o.result.Resources = resources
+
+	return o
+}
+
+func buildReport(runID string, results []JobResult) RunReport {
+	rr := RunReport{
+		RunID:   runID,
+		Backups: []BackupResult{},
+	}
+
+	for _, jr := range results {
+		rr.Resources += len(jr.Resources)
+
+		if jr.Response == nil {
+			rr.Failed += len(jr.Resources)
+
+			if jr.Error != nil {
+				rr.JobErrors = append(rr.JobErrors, jr.Error.Error+": "+jr.Error.Cause)
+			}
+
+			continue
+		}
+
+		started := make([]string, 0, len(jr.Response.Backups))
+
+		for _, br := range jr.Response.Backups {
+			started = append(started, br.ResourceID)
+			rr.BytesUploaded += br.BytesUploaded
+
+			switch {
+			case len(br.Failure) > 0:
+				rr.Failed++
+			case br.Checkpointed:
+				rr.Checkpointed++
+			case len(br.BackupID) == 0:
+				rr.NotEnabled++
+			default:
+				rr.Succeeded++
+			}
+		}
+
+		rr.Backups = append(rr.Backups, jr.Response.Backups...)
+
+		for _, r := range jr.Resources {
+			if !slices.Contains(started, r) {
+				rr.Pending++
+			}
+		}
+
+		if len(jr.Response.ContinuationToken) > 0 {
+			rr.ContinuationTokens = append(rr.ContinuationTokens, jr.Response.ContinuationToken)
+		}
+	}
+
+	return rr
+}
+
+// mergeReports adds the report of a round to the report of the rounds
+// before it.  A round only covers the resources left over by the previous
+// one, so the resource count comes from the first round, while the
+// checkpointed and pending counts only describe the latest round.
+func mergeReports(prev *RunReport, curr RunReport) RunReport {
+	if prev == nil {
+		return curr
+	}
+
+	rr := curr
+
+	rr.Resources = prev.Resources
+	rr.Succeeded += prev.Succeeded
+	rr.Failed += prev.Failed
+	rr.NotEnabled += prev.NotEnabled
+	rr.BytesUploaded += prev.BytesUploaded
+	rr.Backups = append(slices.Clone(prev.Backups), curr.Backups...)
+	rr.JobErrors = append(slices.Clone(prev.JobErrors), curr.JobErrors...)
+
+	return rr
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type ReportUnitSuite struct {
+	tester.Suite
+}
+
+func TestReportUnitSuite(t *testing.T) {
+	suite.Run(t, &ReportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ReportUnitSuite) TestBuildReport() {
+	t := suite.T()
+
+	results := []JobResult{
+		{
+			Resources: []string{"u1", "u2", "u3"},
+			Response: &BackupResponse{
+				Backups: []BackupResult{
+					{
+						ResourceID: "u1",
+						BackupID:   "b1",
+						ReadWrites: stats.ReadWrites{BytesUploaded: 10},
+					},
+					{
+						ResourceID:   "u2",
+						BackupID:     "b2",
+						ReadWrites:   stats.ReadWrites{BytesUploaded: 5},
+						Checkpointed: true,
+					},
+				},
+				ContinuationToken: "tkn",
+			},
+		},
+		{
+			Resources: []string{"u4", "u5"},
+			Response: &BackupResponse{
+				Backups: []BackupResult{
+					{ResourceID: "u4", Failure: "boom"},
+					{ResourceID: "u5"},
+				},
+			},
+		},
+		{
+			Resources: []string{"u6", "u7"},
+			Error:     &JobError{Error: "States.Timeout", Cause: "timed out"},
+		},
+	}
+
+	rr := buildReport("rid", results)
+
+	assert.Equal(t, "rid", rr.RunID)
+	assert.Equal(t, 7, rr.Resources)
+	assert.Equal(t, 1, rr.Succeeded)
+	assert.Equal(t, 1, rr.Checkpointed)
+	assert.Equal(t, 1, rr.Pending)
+	assert.Equal(t, 3, rr.Failed)
+	assert.Equal(t, 1, rr.NotEnabled)
+	assert.Equal(t, int64(15), rr.BytesUploaded)
+	assert.Len(t, rr.Backups, 4)
+	assert.Equal(t, []string{"tkn"}, rr.ContinuationTokens)
+	assert.Equal(t, []string{"States.Timeout: timed out"}, rr.JobErrors)
+}
+
+type mockGetter struct {
+	objects map[string]string
+}
+
+func (mg mockGetter) GetObject(_ context.Context, key string) (io.ReadCloser, error) {
+	obj, ok := mg.objects[key]
+	if !ok {
+		return nil, assert.AnError
+	}
+
+	return io.NopCloser(strings.NewReader(obj)), nil
+}
+
+func (suite *ReportUnitSuite) TestReportRound() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	marshal := func(v any) string {
+		bs, err := json.Marshal(v)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return string(bs)
+	}
+
+	c := continuation{
+		Service:   "exchange",
+		Resources: []string{"u2"},
+	}
+
+	tkn, err := c.encode()
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		repo = RepoConfig{Provider: "S3", S3: S3Config{Bucket: "repo"}}
+		job1 = BackupEvent{
+			Service:       "exchange",
+			Resources:     []string{"u1", "u2"},
+			Resumable:     true,
+			FailurePolicy: control.FailAfterRecovery,
+			Repo:          repo,
+		}
+		job2 = BackupEvent{
+			Service:   "exchange",
+			Resources: []string{"u3"},
+			Resumable: true,
+			Repo:      repo,
+		}
+		succeeded = []childExecution{
+			{
+				Status: "SUCCEEDED",
+				Input:  marshal(job1),
+				Output: marshal(JobResult{
+					Response: &BackupResponse{
+						Backups: []BackupResult{
+							{ResourceID: "u1", BackupID: "b1"},
+							{ResourceID: "u2", BackupID: "b2", Checkpointed: true},
+						},
+						ContinuationToken: tkn,
+					},
+				}),
+			},
+		}
+		failed = []childExecution{
+			{
+				Status: "TIMED_OUT",
+				Input:  marshal(job2),
+			},
+		}
+		mg = mockGetter{
+			objects: map[string]string{
+				"manifest.json": `{"ResultFiles": {
+					"SUCCEEDED": [{"Key": "SUCCEEDED_0.json"}],
+					"FAILED": [{"Key": "FAILED_0.json"}]}}`,
+				"SUCCEEDED_0.json": marshal(succeeded),
+				"FAILED_0.json":    marshal(failed),
+			},
+		}
+		mp = &mockPutter{objects: map[string]string{}}
+		ev = ReportEvent{
+			RunID:   "rid",
+			Results: ResultWriterDetails{Bucket: "jobs", Key: "manifest.json"},
+		}
+	)
+
+	resp, err := reportRound(ctx, ev, mg, mp, "jobs")
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, 1, resp.Round)
+	assert.Equal(t, 3, resp.Report.Resources)
+	assert.Equal(t, 1, resp.Report.Succeeded)
+	assert.Equal(t, 1, resp.Report.Checkpointed)
+	assert.Equal(t, 1, resp.Report.Failed)
+	assert.Equal(t, []string{"TIMED_OUT: "}, resp.Report.JobErrors)
+	assert.Empty(t, resp.Report.ContinuationTokens, "tokens are resumed by the next round")
+
+	require.NotNil(t, resp.Jobs, "checkpointed jobs loop into another round")
+	assert.Equal(t, "jobs", resp.Jobs.Bucket)
+
+	var next []BackupEvent
+
+	err = json.Unmarshal([]byte(mp.objects[resp.Jobs.Key]), &next)
+	require.NoError(t, err, clues.ToCore(err))
+
+	expect := []BackupEvent{
+		{
+			FailurePolicy:     control.FailAfterRecovery,
+			Repo:              repo,
+			ContinuationToken: tkn,
+		},
+	}
+
+	assert.Equal(t, expect, next)
+
+	// the next round resumes u2, and completes it.
+	mg.objects["SUCCEEDED_0.json"] = marshal([]childExecution{
+		{
+			Status: "SUCCEEDED",
+			Input:  marshal(next[0]),
+			Output: marshal(JobResult{
+				Response: &BackupResponse{
+					Backups: []BackupResult{{ResourceID: "u2", BackupID: "b3"}},
+				},
+			}),
+		},
+	})
+	mg.objects["manifest.json"] = `{"ResultFiles": {"SUCCEEDED": [{"Key": "SUCCEEDED_0.json"}]}}`
+
+	ev.Round = resp.Round
+	ev.Previous = &resp.Report
+
+	resp, err = reportRound(ctx, ev, mg, mp, "jobs")
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, 2, resp.Round)
+	assert.Nil(t, resp.Jobs, "run is complete")
+	assert.Equal(t, 3, resp.Report.Resources)
+	assert.Equal(t, 2, resp.Report.Succeeded)
+	assert.Equal(t, 0, resp.Report.Checkpointed)
+	assert.Equal(t, 1, resp.Report.Failed)
+	assert.Len(t, resp.Report.Backups, 3)
+}
+
+func (suite *ReportUnitSuite) TestReportRound_maxRounds() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	executions, err := json.Marshal([]childExecution{
+		{
+			Status: "SUCCEEDED",
+			Input:  `{"continuationToken": "e30"}`,
+			Output: `{"response": {"backups": [], "continuationToken": "tkn"}}`,
+		},
+	})
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		mg = mockGetter{
+			objects: map[string]string{
+				"manifest.json":    `{"ResultFiles": {"SUCCEEDED": [{"Key": "SUCCEEDED_0.json"}]}}`,
+				"SUCCEEDED_0.json": string(executions),
+			},
+		}
+		mp = &mockPutter{objects: map[string]string{}}
+		ev = ReportEvent{
+			RunID:    "rid",
+			Round:    maxRunRounds - 1,
+			Results:  ResultWriterDetails{Key: "manifest.json"},
+			Previous: &RunReport{RunID: "rid", Resources: 1},
+		}
+	)
+
+	resp, err := reportRound(ctx, ev, mg, mp, "jobs")
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Nil(t, resp.Jobs, "no more rounds")
+	assert.Empty(t, mp.objects)
+	assert.Equal(t, []string{"tkn"}, resp.Report.ContinuationTokens)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// RestoreEvent is the payload accepted by the restore function.
+type RestoreEvent struct {
+	BackupID string `json:"backupID"`
+	// Selector is a serialized selectors.Selector (ie: the same shape
+	// that is stored in the backup model) describing the data to restore.
+	// Its service must match the service of the backup.
+	Selector selectors.Selector `json:"selector"`
+	// RestoreConfig controls where and how data gets restored.  An empty
+	// location restores into a new "Corso_Restore_<dttm>" container; use
+	// "/" to restore in place.  The collision policy defaults to skip.
+	RestoreConfig control.RestoreConfig `json:"restoreConfig"`
+	FailurePolicy control.FailurePolicy `json:"failurePolicy,omitempty"`
+	Repo          RepoConfig            `json:"repo"`
+}
+
+// RestoreResponse is returned by the restore function.
+type RestoreResponse struct {
+	stats.ReadWrites
+	stats.StartAndEndTime
+	// ItemsRestored is the number of non-metadata items in the details
+	// produced by the restore.
+	ItemsRestored int `json:"itemsRestored"`
+	// CollisionsSkipped is the number of items that were not restored
+	// because they collided with an existing item.
+	CollisionsSkipped int64 `json:"collisionsSkipped"`
+	// FailedItems are the items that could not be restored.
+	FailedItems []fault.Item `json:"failedItems"`
+	// RecoveredErrors is the number of recoverable errors that occurred
+	// outside of any individual item.
+	RecoveredErrors int `json:"recoveredErrors"`
+	// Failure holds the non-recoverable error, if one occurred.
+	Failure string `json:"failure,omitempty"`
+}
+
+func (ev RestoreEvent) validate() error {
+	if len(ev.BackupID) == 0 {
+		return clues.New("a backup id is required")
+	}
+
+	if ev.Selector.Service == selectors.ServiceUnknown {
+		return clues.New("a selector with a known service is required")
+	}
+
+	cp := ev.RestoreConfig.OnCollision
+	if len(cp) > 0 && !control.IsValidCollisionPolicy(cp) {
+		return clues.New("invalid collision policy: [" + string(cp) + "]")
+	}
+
+	if !validFailurePolicy(ev.FailurePolicy) {
+		return clues.New("unsupported failure policy: [" + string(ev.FailurePolicy) + "]")
+	}
+
+	return nil
+}
+
+// restoreConfig fills in the defaults for any values that were left
+// empty in the event.
+func (ev RestoreEvent) restoreConfig() control.RestoreConfig {
+	rc := ev.RestoreConfig
+
+	if len(rc.OnCollision) == 0 {
+		rc.OnCollision = control.Skip
+	}
+
+	if len(rc.Location) == 0 {
+		rc.Location = control.DefaultRestoreContainerName(dttm.HumanReadable)
+	}
+
+	return rc
+}
+
+// Restore runs a restore of the selected data from a single backup.
+// Item failures are reported in the response; an error is only returned
+// if the restore could not be run at all.
+func Restore(ctx context.Context, ev RestoreEvent) (RestoreResponse, error) {
+	ctx, flush := seed(ctx)
+	defer flush()
+
+	if err := ev.validate(); err != nil {
+		return RestoreResponse{}, clues.Wrap(err, "validating restore event")
+	}
+
+	var (
+		sel = ev.Selector
+		pst = sel.PathService()
+	)
+
+	ctx = clues.Add(ctx, "backup_id", ev.BackupID, "service", pst.String())
+
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+
+	r, err := connect(ctx, ev.Repo, ev.Repo.options(ev.FailurePolicy), pst)
+	if err != nil {
+		return RestoreResponse{}, clues.Stack(err)
+	}
+
+	defer closeRepo(ctx, r)
+
+	ro, err := r.NewRestore(ctx, ev.BackupID, sel, ev.restoreConfig())
+	if err != nil {
+		return RestoreResponse{}, clues.Wrap(err, "initializing restore")
+	}
+
+	logger.Ctx(ctx).Info("running restore")
+
+	ds, err := ro.Run(ctx)
+
+	resp := RestoreResponse{
+		ReadWrites:        ro.Results.ReadWrites,
+		StartAndEndTime:   ro.Results.StartAndEndTime,
+		CollisionsSkipped: ro.Counter.Get(count.CollisionSkip),
+	}
+
+	if ds != nil {
+		resp.ItemsRestored = len(ds.Items())
+	}
+
+	if ro.Errors != nil {
+		items, recovered := ro.Errors.ItemsAndRecovered()
+		resp.FailedItems = items
+		resp.RecoveredErrors = len(recovered)
+	}
+
+	if err != nil {
+		logger.CtxErr(ctx, err).Error("running restore")
+		resp.Failure = err.Error()
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RestoreUnitSuite) TestRestoreEvent_Unmarshal() {
+	t := suite.T()
+
+	sel := selectors.NewExchangeRestore([]string{"u1"})
+	sel.Include(sel.MailFolders([]string{"Inbox"}))
+
+	bs, err := json.Marshal(sel.Selector)
+	require.NoError(t, err, clues.ToCore(err))
+
+	raw := `{
+		"backupID": "bid",
+		"selector": ` + string(bs) + `,
+		"restoreConfig": {"onCollision": "replace", "location": "/"},
+		"repo": {"provider": "S3", "s3": {"bucket": "bkt"}}
+	}`
+
+	var ev RestoreEvent
+
+	err = json.Unmarshal([]byte(raw), &ev)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "bid", ev.BackupID)
+	assert.Equal(t, path.ExchangeService, ev.Selector.PathService())
+	assert.Equal(t, "u1", ev.Selector.DiscreteOwner)
+	assert.Equal(t, control.Replace, ev.RestoreConfig.OnCollision)
+	assert.Equal(t, "/", ev.RestoreConfig.Location)
+	assert.Equal(t, "bkt", ev.Repo.S3.Bucket)
+
+	pcs, err := ev.Selector.PathCategories()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []path.CategoryType{path.EmailCategory}, pcs.Includes)
+}
+
+func (suite *RestoreUnitSuite) TestRestoreEvent_Validate() {
+	sel := selectors.NewOneDriveRestore([]string{"u1"})
+	sel.Include(sel.AllData())
+
+	table := []struct {
+		name      string
+		ev        RestoreEvent
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "valid",
+			ev: RestoreEvent{
+				BackupID: "bid",
+				Selector: sel.Selector,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "valid with config",
+			ev: RestoreEvent{
+				BackupID: "bid",
+				Selector: sel.Selector,
+				RestoreConfig: control.RestoreConfig{
+					OnCollision:        control.Copy,
+					ProtectedResource:  "u2",
+					IncludePermissions: true,
+				},
+				FailurePolicy: control.FailFast,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "missing backup id",
+			ev: RestoreEvent{
+				Selector: sel.Selector,
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "missing selector",
+			ev: RestoreEvent{
+				BackupID: "bid",
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "bad collision policy",
+			ev: RestoreEvent{
+				BackupID:      "bid",
+				Selector:      sel.Selector,
+				RestoreConfig: control.RestoreConfig{OnCollision: "overwrite"},
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.ev.validate()
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestRestoreEvent_RestoreConfig() {
+	t := suite.T()
+
+	rc := RestoreEvent{}.restoreConfig()
+	assert.Equal(t, control.Skip, rc.OnCollision)
+	assert.True(t, strings.HasPrefix(rc.Location, control.DefaultRestoreLocation), rc.Location)
+
+	rc = RestoreEvent{
+		RestoreConfig: control.RestoreConfig{
+			OnCollision: control.Replace,
+			Location:    "/",
+			Drive:       "d",
+		},
+	}.restoreConfig()
+	assert.Equal(t, control.Replace, rc.OnCollision)
+	assert.Equal(t, "/", rc.Location)
+	assert.Equal(t, "d", rc.Drive)
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/alcionai/corso/src/lambda/handler"
+)
+
+func main() {
+	lambda.Start(handler.Maintenance)
+}
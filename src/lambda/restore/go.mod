@@ -1,5 +0,0 @@
-module github.com/nolotz/corso-serverless/lambda/corso-restore
-
-go 1.21
-
-require github.com/aws/aws-lambda-go v1.45.0
@@ -26,6 +26,9 @@ const (
 	AzureTenantIDKey       = "azure_tenantid"
 	AzureClientID          = "azure_client_id"
 	AzureSecret            = "azure_secret"
+	AzureClientCertPath    = "azure_client_cert_path"
+	AzureClientCertPass    = "azure_client_cert_password"
+	AzureFederatedToken    = "azure_federated_token_file"
 )
 
 // Account defines an account provider, along with any credentials
@@ -19,7 +19,7 @@ const (
 var excludedM365ConfigFieldsForHashing = []string{"AzureClientSecret"}
 
 type M365Config struct {
-	credentials.M365 // requires: ClientID, ClientSecret
+	credentials.M365 // requires: ClientID, and one of ClientSecret, ClientCert, or FederatedTokenFile
 	AzureTenantID    string
 }
 
@@ -28,6 +28,11 @@ const (
 	keyAzureClientID     = "azure_clientid"
 	keyAzureClientSecret = "azure_clientSecret"
 	keyAzureTenantID     = "azure_tenantid"
+
+	keyAzureClientCert         = "azure_clientCert"
+	keyAzureClientCertPath     = "azure_clientCertPath"
+	keyAzureClientCertPassword = "azure_clientCertPassword"
+	keyAzureFederatedTokenFile = "azure_federatedTokenFile"
 )
 
 // StringConfig transforms a m365Config struct into a plain
@@ -40,6 +45,19 @@ func (c M365Config) StringConfig() (map[string]string, error) {
 		keyAzureTenantID:     c.AzureTenantID,
 	}
 
+	optional := map[string]string{
+		keyAzureClientCert:         c.AzureClientCert,
+		keyAzureClientCertPath:     c.AzureClientCertPath,
+		keyAzureClientCertPassword: c.AzureClientCertPassword,
+		keyAzureFederatedTokenFile: c.AzureFederatedTokenFile,
+	}
+
+	for k, v := range optional {
+		if len(v) > 0 {
+			cfg[k] = v
+		}
+	}
+
 	return cfg, c.validate()
 }
 
@@ -70,6 +88,10 @@ func (a Account) M365Config() (M365Config, error) {
 		c.AzureClientID = a.Config[keyAzureClientID]
 		c.AzureClientSecret = a.Config[keyAzureClientSecret]
 		c.AzureTenantID = a.Config[keyAzureTenantID]
+		c.AzureClientCert = a.Config[keyAzureClientCert]
+		c.AzureClientCertPath = a.Config[keyAzureClientCertPath]
+		c.AzureClientCertPassword = a.Config[keyAzureClientCertPassword]
+		c.AzureFederatedTokenFile = a.Config[keyAzureFederatedTokenFile]
 	}
 
 	return c, c.validate()
@@ -90,16 +112,12 @@ func createFilteredM365ConfigForHashing(source M365Config) map[string]any {
 }
 
 func (c M365Config) validate() error {
-	check := map[string]string{
-		credentials.AzureClientID:     c.AzureClientID,
-		credentials.AzureClientSecret: c.AzureClientSecret,
-		AzureTenantID:                 c.AzureTenantID,
+	if len(c.AzureTenantID) == 0 {
+		return clues.Stack(errMissingRequired, clues.New(AzureTenantID))
 	}
 
-	for k, v := range check {
-		if len(v) == 0 {
-			return clues.Stack(errMissingRequired, clues.New(k))
-		}
+	if err := c.M365.Validate(); err != nil {
+		return clues.Stack(errMissingRequired, err)
 	}
 
 	return nil
@@ -61,6 +61,50 @@ func (suite *M365CfgSuite) TestAccount_M365Config() {
 	assert.Equal(t, in.AzureTenantID, out.AzureTenantID)
 }
 
+func (suite *M365CfgSuite) TestAccount_M365Config_WithoutSecret() {
+	table := []struct {
+		name string
+		m365 credentials.M365
+	}{
+		{
+			name: "certificate",
+			m365: credentials.M365{
+				AzureClientID:           "cid",
+				AzureClientCert:         "-----BEGIN CERTIFICATE-----",
+				AzureClientCertPassword: "pw",
+			},
+		},
+		{
+			name: "certificate path",
+			m365: credentials.M365{
+				AzureClientID:       "cid",
+				AzureClientCertPath: "/certs/corso.pem",
+			},
+		},
+		{
+			name: "federated token",
+			m365: credentials.M365{
+				AzureClientID:           "cid",
+				AzureFederatedTokenFile: "/var/run/secrets/token",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			in := account.M365Config{M365: test.m365, AzureTenantID: "tid"}
+
+			a, err := account.NewAccount(account.ProviderM365, in)
+			require.NoError(t, err, clues.ToCore(err))
+
+			out, err := a.M365Config()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, in, out)
+		})
+	}
+}
+
 func makeTestM365Cfg(cid, cs, tid string) account.M365Config {
 	return account.M365Config{
 		M365: credentials.M365{
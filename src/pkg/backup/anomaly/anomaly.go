@@ -0,0 +1,287 @@
+// Package anomaly spots backups whose churn departs sharply from the
+// history of the protected resource, such as the mass encryption or
+// deletion of data by ransomware.
+package anomaly
+
+import (
+	"fmt"
+)
+
+// kinds of findings.
+const (
+	MassChange           = "mass_change"
+	MassDeletion         = "mass_deletion"
+	HighEntropy          = "high_entropy"
+	RansomwareExtensions = "ransomware_extensions"
+)
+
+// smoothing is the weight a backup carries when it's folded into the
+// baseline.  Higher values make the baseline follow recent backups more
+// closely.
+const smoothing = 0.2
+
+// Stats summarizes the churn within a single backup.
+type Stats struct {
+	// Items is the count of items in the backup, including the ones
+	// carried forward from its bases.
+	Items int64 `json:"items"`
+	// Changed is the count of new or modified items retrieved during
+	// the backup.
+	Changed int64 `json:"changed"`
+	// Deleted is the count of items removed since the previous backup.
+	Deleted int64 `json:"deleted"`
+	// Sampled is the count of items whose content got inspected for
+	// entropy.  Only drive files are sampled.
+	Sampled          int64 `json:"sampled"`
+	HighEntropy      int64 `json:"highEntropy"`
+	RansomExtensions int64 `json:"ransomExtensions"`
+}
+
+// ChangedRate is the fraction of items in the backup that changed.
+func (s Stats) ChangedRate() float64 {
+	return rate(s.Changed, s.Items)
+}
+
+// DeletedRate is the fraction of the previous backup's items that were
+// deleted.
+func (s Stats) DeletedRate() float64 {
+	return rate(s.Deleted, s.Items+s.Deleted)
+}
+
+// HighEntropyRate is the fraction of sampled items whose content looks
+// encrypted.
+func (s Stats) HighEntropyRate() float64 {
+	return rate(s.HighEntropy, s.Sampled)
+}
+
+func rate(n, of int64) float64 {
+	if of <= 0 {
+		return 0
+	}
+
+	return float64(n) / float64(of)
+}
+
+// Baseline is the rolling average of the churn in prior incremental
+// backups of the same protected resource and category.
+type Baseline struct {
+	// Backups is the count of backups folded into the baseline.
+	Backups         int     `json:"backups"`
+	ChangedRate     float64 `json:"changedRate"`
+	DeletedRate     float64 `json:"deletedRate"`
+	HighEntropyRate float64 `json:"highEntropyRate"`
+}
+
+// Fold produces the baseline that results from adding the stats of a
+// backup to this one, as an exponentially weighted moving average.
+func (b Baseline) Fold(s Stats) Baseline {
+	if b.Backups == 0 {
+		return Baseline{
+			Backups:         1,
+			ChangedRate:     s.ChangedRate(),
+			DeletedRate:     s.DeletedRate(),
+			HighEntropyRate: s.HighEntropyRate(),
+		}
+	}
+
+	res := Baseline{
+		Backups:         b.Backups + 1,
+		ChangedRate:     ewma(b.ChangedRate, s.ChangedRate()),
+		DeletedRate:     ewma(b.DeletedRate, s.DeletedRate()),
+		HighEntropyRate: b.HighEntropyRate,
+	}
+
+	// backups without drive files have nothing to say about entropy.
+	if s.Sampled > 0 {
+		res.HighEntropyRate = ewma(b.HighEntropyRate, s.HighEntropyRate())
+	}
+
+	return res
+}
+
+func ewma(prev, curr float64) float64 {
+	return smoothing*curr + (1-smoothing)*prev
+}
+
+// MergeBaselines averages the baselines, weighted by the count of backups
+// in each.  Used when a backup has more than one base, such as one base
+// per category.
+func MergeBaselines(bs ...Baseline) Baseline {
+	var res Baseline
+
+	for _, b := range bs {
+		if b.Backups == 0 {
+			continue
+		}
+
+		w := float64(b.Backups)
+
+		res.ChangedRate += b.ChangedRate * w
+		res.DeletedRate += b.DeletedRate * w
+		res.HighEntropyRate += b.HighEntropyRate * w
+		res.Backups += b.Backups
+	}
+
+	if res.Backups == 0 {
+		return Baseline{}
+	}
+
+	n := float64(res.Backups)
+
+	res.ChangedRate /= n
+	res.DeletedRate /= n
+	res.HighEntropyRate /= n
+
+	return res
+}
+
+// Thresholds control how far a backup can stray from its baseline before
+// it's reported as anomalous.
+type Thresholds struct {
+	// MinBackups is the count of backups the baseline needs before the
+	// churn gets compared against it.
+	MinBackups int
+	// MinItems skips the churn checks on backups with fewer items, where
+	// a handful of changes produces large rates.
+	MinItems int64
+	// Multiplier is how many times the baseline rate the churn must
+	// exceed to be reported.
+	Multiplier float64
+	// MinChangedRate and MinDeletedRate are the lowest rates that get
+	// reported, regardless of the baseline.
+	MinChangedRate float64
+	MinDeletedRate float64
+	// MinSampled skips the entropy check when fewer items were sampled.
+	MinSampled int64
+	// HighEntropyRate is the lowest fraction of high entropy items that
+	// gets reported, regardless of the baseline.
+	HighEntropyRate float64
+	// MinRansomExtensions is the count of items named with known
+	// ransomware extensions that gets reported.
+	MinRansomExtensions int64
+}
+
+// DefaultThresholds provides the thresholds used by backup operations.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		MinBackups:          3,
+		MinItems:            100,
+		Multiplier:          5,
+		MinChangedRate:      0.2,
+		MinDeletedRate:      0.2,
+		MinSampled:          20,
+		HighEntropyRate:     0.5,
+		MinRansomExtensions: 10,
+	}
+}
+
+// Finding describes a single way in which a backup is anomalous.
+type Finding struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	// Observed is the rate or count seen in the backup.
+	Observed float64 `json:"observed"`
+	// Expected is the threshold the observed value exceeded.
+	Expected float64 `json:"expected"`
+}
+
+// Detect compares the stats of a backup against its baseline, and
+// returns the thresholds that were exceeded.  Churn is only compared once
+// the baseline holds enough backups.
+func Detect(s Stats, b Baseline, th Thresholds) []Finding {
+	var (
+		fs          []Finding
+		hasBaseline = b.Backups >= th.MinBackups
+	)
+
+	if hasBaseline && s.Items+s.Deleted >= th.MinItems {
+		limit := max(th.MinChangedRate, b.ChangedRate*th.Multiplier)
+
+		if r := s.ChangedRate(); r > limit {
+			fs = append(fs, Finding{
+				Kind: MassChange,
+				Message: fmt.Sprintf(
+					"%d of %d items changed (%.0f%%), against a typical %.1f%%",
+					s.Changed, s.Items, r*100, b.ChangedRate*100),
+				Observed: r,
+				Expected: limit,
+			})
+		}
+
+		limit = max(th.MinDeletedRate, b.DeletedRate*th.Multiplier)
+
+		if r := s.DeletedRate(); r > limit {
+			fs = append(fs, Finding{
+				Kind: MassDeletion,
+				Message: fmt.Sprintf(
+					"%d of %d items deleted (%.0f%%), against a typical %.1f%%",
+					s.Deleted, s.Items+s.Deleted, r*100, b.DeletedRate*100),
+				Observed: r,
+				Expected: limit,
+			})
+		}
+	}
+
+	if s.Sampled >= th.MinSampled {
+		limit := th.HighEntropyRate
+		if hasBaseline {
+			limit = max(limit, b.HighEntropyRate*th.Multiplier)
+		}
+
+		if r := s.HighEntropyRate(); r > limit {
+			fs = append(fs, Finding{
+				Kind: HighEntropy,
+				Message: fmt.Sprintf(
+					"%d of %d sampled files look encrypted (%.0f%%)",
+					s.HighEntropy, s.Sampled, r*100),
+				Observed: r,
+				Expected: limit,
+			})
+		}
+	}
+
+	if s.RansomExtensions >= th.MinRansomExtensions {
+		fs = append(fs, Finding{
+			Kind: RansomwareExtensions,
+			Message: fmt.Sprintf(
+				"%d files are named with extensions used by ransomware",
+				s.RansomExtensions),
+			Observed: float64(s.RansomExtensions),
+			Expected: float64(th.MinRansomExtensions),
+		})
+	}
+
+	return fs
+}
+
+// Record is the outcome of anomaly detection on a backup, which gets
+// stored in the backup model.
+type Record struct {
+	Stats Stats `json:"stats"`
+	// Baseline is the baseline the backup was compared against.
+	Baseline Baseline `json:"baseline"`
+	// Incremental is false for backups that retrieved every item, whose
+	// churn says nothing about the protected resource.
+	Incremental bool      `json:"incremental"`
+	Findings    []Finding `json:"findings,omitempty"`
+}
+
+// Anomalous is true if any threshold was exceeded.
+func (r *Record) Anomalous() bool {
+	return r != nil && len(r.Findings) > 0
+}
+
+// Next produces the baseline for the backups that use this one as their
+// base.  Anomalous and full backups are left out of the baseline so that
+// they don't skew it.
+func (r *Record) Next() Baseline {
+	if r == nil {
+		return Baseline{}
+	}
+
+	if !r.Incremental || r.Anomalous() {
+		return r.Baseline
+	}
+
+	return r.Baseline.Fold(r.Stats)
+}
@@ -0,0 +1,304 @@
+package anomaly
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type AnomalyUnitSuite struct {
+	tester.Suite
+}
+
+func TestAnomalyUnitSuite(t *testing.T) {
+	suite.Run(t, &AnomalyUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func kinds(fs []Finding) []string {
+	ks := []string{}
+
+	for _, f := range fs {
+		ks = append(ks, f.Kind)
+	}
+
+	return ks
+}
+
+func (suite *AnomalyUnitSuite) TestDetect() {
+	steady := Baseline{
+		Backups:         10,
+		ChangedRate:     0.02,
+		DeletedRate:     0.01,
+		HighEntropyRate: 0.05,
+	}
+
+	table := []struct {
+		name     string
+		stats    Stats
+		baseline Baseline
+		expect   []string
+	}{
+		{
+			name:     "typical churn",
+			stats:    Stats{Items: 1000, Changed: 30, Deleted: 10, Sampled: 30, HighEntropy: 2},
+			baseline: steady,
+			expect:   []string{},
+		},
+		{
+			name:     "mass change",
+			stats:    Stats{Items: 1000, Changed: 900},
+			baseline: steady,
+			expect:   []string{MassChange},
+		},
+		{
+			name:     "mass deletion",
+			stats:    Stats{Items: 400, Deleted: 600},
+			baseline: steady,
+			expect:   []string{MassDeletion},
+		},
+		{
+			name:     "under the minimum rate",
+			stats:    Stats{Items: 1000, Changed: 150},
+			baseline: steady,
+			expect:   []string{},
+		},
+		{
+			name:     "churn without enough history",
+			stats:    Stats{Items: 1000, Changed: 900, Deleted: 500},
+			baseline: Baseline{Backups: 2},
+			expect:   []string{},
+		},
+		{
+			name:     "churn in a small resource",
+			stats:    Stats{Items: 50, Changed: 50},
+			baseline: steady,
+			expect:   []string{},
+		},
+		{
+			name:     "high entropy",
+			stats:    Stats{Items: 1000, Sampled: 100, HighEntropy: 80},
+			baseline: steady,
+			expect:   []string{HighEntropy},
+		},
+		{
+			name:     "high entropy without history",
+			stats:    Stats{Items: 1000, Sampled: 100, HighEntropy: 80},
+			baseline: Baseline{},
+			expect:   []string{HighEntropy},
+		},
+		{
+			name:     "high entropy in too few samples",
+			stats:    Stats{Items: 1000, Sampled: 10, HighEntropy: 10},
+			baseline: steady,
+			expect:   []string{},
+		},
+		{
+			name:     "ransomware extensions",
+			stats:    Stats{Items: 1000, RansomExtensions: 12},
+			baseline: Baseline{},
+			expect:   []string{RansomwareExtensions},
+		},
+		{
+			name: "everything",
+			stats: Stats{
+				Items:            1000,
+				Changed:          1000,
+				Deleted:          2000,
+				Sampled:          1000,
+				HighEntropy:      990,
+				RansomExtensions: 1000,
+			},
+			baseline: steady,
+			expect:   []string{MassChange, MassDeletion, HighEntropy, RansomwareExtensions},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			fs := Detect(test.stats, test.baseline, DefaultThresholds())
+			assert.Equal(suite.T(), test.expect, kinds(fs))
+		})
+	}
+}
+
+func (suite *AnomalyUnitSuite) TestRecord_Next() {
+	var (
+		base  = Baseline{Backups: 4, ChangedRate: 0.1}
+		stats = Stats{Items: 100, Changed: 20}
+	)
+
+	table := []struct {
+		name   string
+		record *Record
+		expect Baseline
+	}{
+		{
+			name:   "nil",
+			record: nil,
+			expect: Baseline{},
+		},
+		{
+			name:   "first incremental",
+			record: &Record{Stats: stats, Incremental: true},
+			expect: Baseline{Backups: 1, ChangedRate: 0.2},
+		},
+		{
+			name:   "incremental",
+			record: &Record{Stats: stats, Baseline: base, Incremental: true},
+			expect: Baseline{Backups: 5, ChangedRate: 0.12},
+		},
+		{
+			name:   "full backup",
+			record: &Record{Stats: stats, Baseline: base},
+			expect: base,
+		},
+		{
+			name: "anomalous",
+			record: &Record{
+				Stats:       stats,
+				Baseline:    base,
+				Incremental: true,
+				Findings:    []Finding{{Kind: MassChange}},
+			},
+			expect: base,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result := test.record.Next()
+			assert.Equal(t, test.expect.Backups, result.Backups)
+			assert.InDelta(t, test.expect.ChangedRate, result.ChangedRate, 0.0001)
+		})
+	}
+}
+
+func (suite *AnomalyUnitSuite) TestMergeBaselines() {
+	t := suite.T()
+
+	result := MergeBaselines(
+		Baseline{Backups: 3, ChangedRate: 0.1, DeletedRate: 0.4},
+		Baseline{},
+		Baseline{Backups: 1, ChangedRate: 0.5})
+
+	assert.Equal(t, 4, result.Backups)
+	assert.InDelta(t, 0.2, result.ChangedRate, 0.0001)
+	assert.InDelta(t, 0.3, result.DeletedRate, 0.0001)
+
+	assert.Equal(t, Baseline{}, MergeBaselines())
+}
+
+func (suite *AnomalyUnitSuite) TestEntropy() {
+	random := make([]byte, sampleSize)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	assert.Zero(suite.T(), Entropy(nil))
+	assert.Zero(suite.T(), Entropy(bytes.Repeat([]byte("a"), 100)))
+	assert.InDelta(suite.T(), 1, Entropy([]byte("abababab")), 0.0001)
+	assert.Greater(suite.T(), Entropy(random), highEntropy)
+}
+
+func (suite *AnomalyUnitSuite) TestSampler() {
+	random := make([]byte, 2*sampleSize)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	zipped := append([]byte("PK\x03\x04"), random[:4096]...)
+
+	table := []struct {
+		name        string
+		itemName    string
+		content     []byte
+		readAll     bool
+		sampled     int64
+		highEntropy int64
+		ransom      int64
+	}{
+		{
+			name:     "text",
+			itemName: "notes.txt",
+			content:  bytes.Repeat([]byte("the quick brown fox "), 500),
+			readAll:  true,
+			sampled:  1,
+		},
+		{
+			name:        "encrypted",
+			itemName:    "budget.xlsx.locked",
+			content:     random,
+			readAll:     true,
+			sampled:     1,
+			highEntropy: 1,
+			ransom:      1,
+		},
+		{
+			name:        "encrypted, closed before EOF",
+			itemName:    "budget.xlsx",
+			content:     random,
+			sampled:     1,
+			highEntropy: 1,
+		},
+		{
+			name:     "compressed",
+			itemName: "archive.zip",
+			content:  zipped,
+			readAll:  true,
+			sampled:  1,
+		},
+		{
+			name:     "too small",
+			itemName: "photo.LOCKED",
+			content:  random[:100],
+			readAll:  true,
+			ransom:   1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				s    = NewSampler()
+				info = details.ItemInfo{
+					OneDrive: &details.OneDriveInfo{
+						ItemType: details.OneDriveItem,
+						ItemName: test.itemName,
+					},
+				}
+			)
+
+			rc, err := s.CreateItemExtension(
+				ctx,
+				io.NopCloser(bytes.NewReader(test.content)),
+				info,
+				&details.ExtensionData{})
+			require.NoError(t, err)
+
+			if test.readAll {
+				bs, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				assert.Equal(t, test.content, bs, "content is unchanged")
+			} else {
+				_, err := rc.Read(make([]byte, 4*minSampleSize))
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, rc.Close())
+
+			stats := s.Stats(Stats{Items: 1})
+			assert.Equal(t, int64(1), stats.Items)
+			assert.Equal(t, test.sampled, stats.Sampled, "sampled")
+			assert.Equal(t, test.highEntropy, stats.HighEntropy, "high entropy")
+			assert.Equal(t, test.ransom, stats.RansomExtensions, "ransom extensions")
+		})
+	}
+}
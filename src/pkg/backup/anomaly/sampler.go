@@ -0,0 +1,226 @@
+package anomaly
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/extensions"
+)
+
+const (
+	// sampleSize is the count of leading bytes of each item that get
+	// inspected.
+	sampleSize = 64 * 1024
+	// minSampleSize skips items too small to produce a meaningful entropy.
+	minSampleSize = 1024
+	// highEntropy is the lowest entropy, in bits per byte, of content
+	// that looks encrypted.
+	highEntropy = 7.5
+)
+
+// ransomExtensions are the file extensions appended by common strains of
+// ransomware.
+var ransomExtensions = map[string]struct{}{
+	".cerber":    {},
+	".crypt":     {},
+	".crypted":   {},
+	".crypto":    {},
+	".enc":       {},
+	".encrypted": {},
+	".locked":    {},
+	".lockbit":   {},
+	".locky":     {},
+	".ryk":       {},
+	".wncry":     {},
+	".zepto":     {},
+}
+
+// compressedHeaders are the leading bytes of compressed file formats,
+// whose content has a high entropy without being encrypted.
+var compressedHeaders = [][]byte{
+	[]byte("PK\x03\x04"),         // zip, and office documents
+	[]byte("\x1f\x8b"),           // gzip
+	[]byte("7z\xbc\xaf\x27\x1c"), // 7z
+	[]byte("Rar!"),               // rar
+	[]byte("\xff\xd8\xff"),       // jpeg
+	[]byte("\x89PNG"),            // png
+	[]byte("GIF8"),               // gif
+	[]byte("%PDF"),               // pdf
+	[]byte("ID3"),                // mp3
+	[]byte("\x1a\x45\xdf\xa3"),   // webm, mkv
+	[]byte("RIFF"),               // wav, avi, webp
+	[]byte("\xd0\xcf\x11\xe0"),   // legacy office documents
+	[]byte("\x28\xb5\x2f\xfd"),   // zstd
+	[]byte("BZh"),                // bzip2
+	[]byte("\xfd7zXZ\x00"),       // xz
+	[]byte("MSCF"),               // cab
+}
+
+var _ extensions.CreateItemExtensioner = &Sampler{}
+
+// Sampler inspects the backed up items for signs of ransomware: names
+// with extensions used by ransomware, and content with the entropy of
+// encrypted data.  It's added to the item extensions of a backup, so
+// only the services which support extensions, ie: drive files, are
+// sampled.  Safe for concurrent use.
+type Sampler struct {
+	sampled          int64
+	highEntropy      int64
+	ransomExtensions int64
+}
+
+// NewSampler produces a Sampler with no samples.
+func NewSampler() *Sampler {
+	return &Sampler{}
+}
+
+// CreateItemExtension wraps the item's reader so that its content gets
+// sampled as it's read.
+func (s *Sampler) CreateItemExtension(
+	_ context.Context,
+	rc io.ReadCloser,
+	info details.ItemInfo,
+	_ *details.ExtensionData,
+) (io.ReadCloser, error) {
+	if hasRansomExtension(itemName(info)) {
+		atomic.AddInt64(&s.ransomExtensions, 1)
+	}
+
+	return &sampleReader{ReadCloser: rc, s: s}, nil
+}
+
+// Stats adds the sampled counts to the stats.
+func (s *Sampler) Stats(st Stats) Stats {
+	st.Sampled = atomic.LoadInt64(&s.sampled)
+	st.HighEntropy = atomic.LoadInt64(&s.highEntropy)
+	st.RansomExtensions = atomic.LoadInt64(&s.ransomExtensions)
+
+	return st
+}
+
+func (s *Sampler) record(sample []byte) {
+	if len(sample) < minSampleSize {
+		return
+	}
+
+	atomic.AddInt64(&s.sampled, 1)
+
+	if isCompressed(sample) {
+		return
+	}
+
+	if Entropy(sample) >= highEntropy {
+		atomic.AddInt64(&s.highEntropy, 1)
+	}
+}
+
+type sampleReader struct {
+	io.ReadCloser
+	s    *Sampler
+	buf  []byte
+	done bool
+}
+
+func (r *sampleReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+
+	if !r.done {
+		if room := sampleSize - len(r.buf); room > 0 {
+			r.buf = append(r.buf, p[:min(n, room)]...)
+		}
+
+		if len(r.buf) == sampleSize || errors.Is(err, io.EOF) {
+			r.finish()
+		}
+	}
+
+	return n, err
+}
+
+func (r *sampleReader) Close() error {
+	// items can be closed without being read to EOF, eg: when the content
+	// matches a previous upload.
+	if !r.done {
+		r.finish()
+	}
+
+	return r.ReadCloser.Close()
+}
+
+func (r *sampleReader) finish() {
+	r.done = true
+	r.s.record(r.buf)
+	r.buf = nil
+}
+
+// Entropy is the shannon entropy of the data, in bits per byte.  Ranges
+// from 0, for repetitions of a single byte, to 8, for uniformly random
+// data.
+func Entropy(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+
+	var counts [256]int
+
+	for _, b := range data {
+		counts[b]++
+	}
+
+	var (
+		e = 0.0
+		n = float64(len(data))
+	)
+
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+
+		p := float64(c) / n
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
+
+func isCompressed(sample []byte) bool {
+	// iso media, eg: mp4, mov, and heic, start with the size of a box
+	// followed by its type.
+	if len(sample) >= 8 && bytes.Equal(sample[4:8], []byte("ftyp")) {
+		return true
+	}
+
+	for _, h := range compressedHeaders {
+		if bytes.HasPrefix(sample, h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasRansomExtension(name string) bool {
+	_, ok := ransomExtensions[strings.ToLower(filepath.Ext(name))]
+	return ok
+}
+
+func itemName(info details.ItemInfo) string {
+	switch {
+	case info.OneDrive != nil:
+		return info.OneDrive.ItemName
+	case info.SharePoint != nil:
+		return info.SharePoint.ItemName
+	case info.Groups != nil:
+		return info.Groups.ItemName
+	}
+
+	return ""
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/backup/anomaly"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/selectors"
@@ -31,6 +32,23 @@ type Backup struct {
 	// Used to read backup.Details and fault.Errors from the streamstore.
 	StreamStoreID string `json:"streamStoreID"`
 
+	// DetailsFormat identifies how the details are laid out in the
+	// streamstore.  See details.LegacyFormat and details.ChunkedFormat.
+	DetailsFormat int `json:"detailsFormat,omitempty"`
+
+	// HasSearchIndex is true if the sharded search index of the backup's
+	// items was persisted in the streamstore alongside the details.
+	HasSearchIndex bool `json:"hasSearchIndex,omitempty"`
+
+	// Anomaly records the churn of the backup, and how it compared to
+	// prior backups.  Nil unless anomaly detection was enabled.
+	Anomaly *anomaly.Record `json:"anomaly,omitempty"`
+
+	// Suspect marks a backup whose data may have been tampered with, such
+	// as by ransomware.  Retention never prunes the newest backup that
+	// isn't suspect.
+	Suspect bool `json:"suspect,omitempty"`
+
 	// Status of the operation, eg: completed, failed, etc
 	Status string `json:"status"`
 
@@ -283,6 +301,10 @@ func (b Backup) Values(skipID bool) []string {
 		status += (")")
 	}
 
+	if b.Suspect {
+		status += " [suspect]"
+	}
+
 	name := str.First(
 		b.ProtectedResourceName,
 		b.ResourceOwnerName,
@@ -306,6 +306,15 @@ func (suite *BackupUnitSuite) TestBackup_Values_statusVariations() {
 			},
 			expect: "test (42 errors, 1 skipped: 1 malware, 1 invalid OneNote file)",
 		},
+		{
+			name: "suspect",
+			bup: backup.Backup{
+				Status:     "test",
+				ErrorCount: 42,
+				Suspect:    true,
+			},
+			expect: "test (42 errors) [suspect]",
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
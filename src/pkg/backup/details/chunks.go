@@ -0,0 +1,493 @@
+package details
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/filters"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const (
+	// LegacyFormat details are stored as a single json document.
+	LegacyFormat = 0
+	// ChunkedFormat details are stored as an index plus a set of chunks,
+	// each holding a range of entries sorted by RepoRef.
+	ChunkedFormat = 1
+
+	// DefaultChunkSize is the max number of entries held in each chunk.
+	DefaultChunkSize = 5000
+
+	chunkNamePrefix = "chunk_"
+)
+
+// --------------------------------------------------------------------------------
+// index
+// --------------------------------------------------------------------------------
+
+// ChunkIndex describes the chunks of a backup's details.  It's small
+// enough to be read in full, and is used to pick out the chunks that
+// need to be read for a given lookup.
+type ChunkIndex struct {
+	Chunks []ChunkInfo `json:"chunks"`
+	Total  int         `json:"total"`
+	// Folders holds the location of each folder that contains items.
+	// Nil in indexes written before folders were recorded.
+	Folders []ChunkFolder `json:"folders,omitempty"`
+}
+
+// ChunkFolder maps the RepoRef of a folder to the values that selectors
+// compare when matching the folder of an item within it.
+type ChunkFolder struct {
+	RepoRef   string   `json:"repoRef"`
+	Locations []string `json:"locations"`
+}
+
+// ChunkInfo summarizes the entries held in a single chunk.
+type ChunkInfo struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	// entries are sorted by RepoRef, so the first and last RepoRef
+	// bound every path in the chunk.
+	FirstRepoRef string   `json:"firstRepoRef"`
+	LastRepoRef  string   `json:"lastRepoRef"`
+	Categories   []string `json:"categories"`
+	// the range of modification times of the items in the chunk.  Zero
+	// if none of the entries has a modification time.
+	ModifiedMin time.Time `json:"modifiedMin"`
+	ModifiedMax time.Time `json:"modifiedMax"`
+}
+
+// --------------------------------------------------------------------------------
+// filter
+// --------------------------------------------------------------------------------
+
+// ChunkFilter narrows down the chunks, and the entries within them, that
+// get read from chunked details.  The zero value matches everything.
+// Filters are only a pre-selection; they never replace selector reduction.
+type ChunkFilter struct {
+	// Categories limits the entries to those in one of the categories.
+	Categories []path.CategoryType
+	// RepoRefPrefixes limits the entries to those whose RepoRef starts
+	// with one of the prefixes.
+	RepoRefPrefixes []string
+	// Folders limits the entries to those directly within a folder whose
+	// location passes one of the filters.  Folders get resolved to RepoRef
+	// prefixes using the index, so that chunks outside of them are skipped.
+	Folders []filters.Filter
+	// ModifiedAfter and ModifiedBefore skip chunks that don't hold any
+	// item modified within the range.  Entries in a matching chunk aren't
+	// filtered by time, since folders don't have modification times.
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+
+	// the RepoRef prefixes of the folders that pass the folder filters.
+	// Only populated once the index is read.
+	folderPrefixes []string
+	byFolder       bool
+}
+
+// resolveFolders produces the RepoRef prefixes of the folders in the index
+// that pass the folder filters.  Indexes without any folders can't be
+// resolved, in which case entries aren't filtered by folder.
+func (f *ChunkFilter) resolveFolders(index ChunkIndex) {
+	if len(f.Folders) == 0 || index.Folders == nil {
+		return
+	}
+
+	f.byFolder = true
+
+	for _, cf := range index.Folders {
+		for _, ff := range f.Folders {
+			if ff.CompareAny(cf.Locations...) {
+				f.folderPrefixes = append(f.folderPrefixes, cf.RepoRef+"/")
+				break
+			}
+		}
+	}
+}
+
+func (f ChunkFilter) matchesChunk(ci ChunkInfo) bool {
+	if len(f.Categories) > 0 {
+		found := false
+
+		for _, c := range f.Categories {
+			if slices.Contains(ci.Categories, c.String()) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.RepoRefPrefixes) > 0 {
+		found := false
+
+		for _, p := range f.RepoRefPrefixes {
+			if rangeHasPrefix(ci.FirstRepoRef, ci.LastRepoRef, p) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if f.byFolder {
+		found := false
+
+		for _, p := range f.folderPrefixes {
+			if rangeHasPrefix(ci.FirstRepoRef, ci.LastRepoRef, p) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	// chunks without any modification times can't be ruled out.
+	if ci.ModifiedMax.IsZero() {
+		return true
+	}
+
+	if !f.ModifiedAfter.IsZero() && ci.ModifiedMax.Before(f.ModifiedAfter) {
+		return false
+	}
+
+	if !f.ModifiedBefore.IsZero() && ci.ModifiedMin.After(f.ModifiedBefore) {
+		return false
+	}
+
+	return true
+}
+
+func (f ChunkFilter) matchesEntry(de Entry) bool {
+	if len(f.Categories) > 0 {
+		cat := entryCategory(de)
+
+		// entries with unparsable paths are always kept.
+		if cat != path.UnknownCategory && !slices.Contains(f.Categories, cat) {
+			return false
+		}
+	}
+
+	if len(f.RepoRefPrefixes) > 0 && !hasAnyPrefix(de.RepoRef, f.RepoRefPrefixes) {
+		return false
+	}
+
+	if f.byFolder && !hasAnyPrefix(de.RepoRef, f.folderPrefixes) {
+		return false
+	}
+
+	return true
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rangeHasPrefix is true if any string within the sorted range
+// [first, last] can start with the prefix.
+func rangeHasPrefix(first, last, prefix string) bool {
+	if strings.HasPrefix(first, prefix) || strings.HasPrefix(last, prefix) {
+		return true
+	}
+
+	return first < prefix && prefix < last
+}
+
+// folderLocations produces the values that selectors compare against the
+// folder of the entry: its location, the parent path of drive items, and
+// the folders in its RepoRef for entries without a location.
+func folderLocations(de Entry) []string {
+	var locs []string
+
+	if len(de.LocationRef) > 0 {
+		locs = append(locs, de.LocationRef)
+	} else if p, err := path.FromDataLayerPath(de.RepoRef, true); err == nil {
+		locs = append(locs, p.Folder(true))
+	}
+
+	var pp string
+
+	switch {
+	case de.OneDrive != nil:
+		pp = de.OneDrive.ParentPath
+	case de.SharePoint != nil:
+		pp = de.SharePoint.ParentPath
+	case de.Groups != nil:
+		pp = de.Groups.ParentPath
+	}
+
+	if len(pp) > 0 && !slices.Contains(locs, pp) {
+		locs = append(locs, pp)
+	}
+
+	return locs
+}
+
+func entryCategory(de Entry) path.CategoryType {
+	p, err := path.FromDataLayerPath(de.RepoRef, false)
+	if err != nil {
+		return path.UnknownCategory
+	}
+
+	return p.Category()
+}
+
+// --------------------------------------------------------------------------------
+// writer
+// --------------------------------------------------------------------------------
+
+// ChunkWriter splits details into an index and a set of chunks.  It
+// complies with the chunk marshaller interface in streamStore.
+type ChunkWriter struct {
+	index  ChunkIndex
+	chunks map[string][]Entry
+}
+
+// NewChunkWriter sorts the entries of the details by RepoRef and groups
+// them into chunks of at most size entries.  The details themselves are
+// left unmodified.
+func NewChunkWriter(d *Details, size int) *ChunkWriter {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+
+	entries := slices.Clone(d.Entries)
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return strings.Compare(a.RepoRef, b.RepoRef)
+	})
+
+	cw := &ChunkWriter{
+		index: ChunkIndex{
+			Total:   len(entries),
+			Folders: chunkFolders(entries),
+		},
+		chunks: map[string][]Entry{},
+	}
+
+	for i := 0; i < len(entries); i += size {
+		chunk := entries[i:min(i+size, len(entries))]
+		ci := summarizeChunk(fmt.Sprintf("%s%06d", chunkNamePrefix, len(cw.index.Chunks)), chunk)
+
+		cw.index.Chunks = append(cw.index.Chunks, ci)
+		cw.chunks[ci.Name] = chunk
+	}
+
+	return cw
+}
+
+// chunkFolders records the locations of the folder of each item.  Entries
+// are sorted by RepoRef, so the items of a folder are next to each other.
+func chunkFolders(entries []Entry) []ChunkFolder {
+	folders := []ChunkFolder{}
+
+	for _, de := range entries {
+		if de.Folder != nil {
+			continue
+		}
+
+		i := strings.LastIndex(de.RepoRef, "/")
+		if i < 0 {
+			continue
+		}
+
+		dir := de.RepoRef[:i]
+
+		if len(folders) == 0 || folders[len(folders)-1].RepoRef != dir {
+			folders = append(folders, ChunkFolder{RepoRef: dir})
+		}
+
+		cf := &folders[len(folders)-1]
+
+		for _, loc := range folderLocations(de) {
+			if !slices.Contains(cf.Locations, loc) {
+				cf.Locations = append(cf.Locations, loc)
+			}
+		}
+	}
+
+	return folders
+}
+
+func summarizeChunk(name string, chunk []Entry) ChunkInfo {
+	ci := ChunkInfo{
+		Name:         name,
+		Count:        len(chunk),
+		FirstRepoRef: chunk[0].RepoRef,
+		LastRepoRef:  chunk[len(chunk)-1].RepoRef,
+	}
+
+	cats := map[string]struct{}{}
+
+	for _, de := range chunk {
+		cats[entryCategory(de).String()] = struct{}{}
+
+		mod := de.Modified()
+		if mod.IsZero() {
+			continue
+		}
+
+		if ci.ModifiedMin.IsZero() || mod.Before(ci.ModifiedMin) {
+			ci.ModifiedMin = mod
+		}
+
+		if mod.After(ci.ModifiedMax) {
+			ci.ModifiedMax = mod
+		}
+	}
+
+	for c := range cats {
+		ci.Categories = append(ci.Categories, c)
+	}
+
+	slices.Sort(ci.Categories)
+
+	return ci
+}
+
+// Index returns the index of the chunks.
+func (cw *ChunkWriter) Index() ChunkIndex {
+	return cw.index
+}
+
+// MarshalIndex serializes the chunk index.
+func (cw *ChunkWriter) MarshalIndex() ([]byte, error) {
+	bs, err := json.Marshal(cw.index)
+	return bs, clues.Stack(err).OrNil()
+}
+
+// ChunkNames lists the names of all chunks, in RepoRef order.
+func (cw *ChunkWriter) ChunkNames() []string {
+	names := make([]string, 0, len(cw.index.Chunks))
+
+	for _, ci := range cw.index.Chunks {
+		names = append(names, ci.Name)
+	}
+
+	return names
+}
+
+// MarshalChunk serializes the entries of the named chunk.
+func (cw *ChunkWriter) MarshalChunk(name string) ([]byte, error) {
+	chunk, ok := cw.chunks[name]
+	if !ok {
+		return nil, clues.New("unknown details chunk").With("chunk_name", name)
+	}
+
+	bs, err := json.Marshal(chunk)
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// --------------------------------------------------------------------------------
+// reader
+// --------------------------------------------------------------------------------
+
+// ChunkReader populates details from an index and the chunks it selects.
+// It complies with the chunk unmarshaller interface in streamStore.
+type ChunkReader struct {
+	d      *Details
+	filter ChunkFilter
+	names  []string
+	read   map[string]struct{}
+}
+
+// NewChunkReader produces a reader that adds the entries matching the
+// filter to d.  Entries are added as each chunk gets read, and are sorted
+// by RepoRef once every selected chunk has been read.
+func NewChunkReader(d *Details, filter ChunkFilter) *ChunkReader {
+	return &ChunkReader{
+		d:      d,
+		filter: filter,
+		read:   map[string]struct{}{},
+	}
+}
+
+// UnmarshalIndex reads the chunk index, and returns the names of the
+// chunks that need to be read.
+func (cr *ChunkReader) UnmarshalIndex(rc io.ReadCloser) ([]string, error) {
+	defer rc.Close()
+
+	var index ChunkIndex
+
+	if err := json.NewDecoder(rc).Decode(&index); err != nil {
+		return nil, clues.Wrap(err, "decoding details index")
+	}
+
+	cr.filter.resolveFolders(index)
+
+	for _, ci := range index.Chunks {
+		if cr.filter.matchesChunk(ci) {
+			cr.names = append(cr.names, ci.Name)
+		}
+	}
+
+	return slices.Clone(cr.names), nil
+}
+
+// UnmarshalChunk reads the entries of a single chunk.  Chunks can be
+// read in any order.  Entries are decoded one at a time, so only those
+// matching the filter are held in memory.
+func (cr *ChunkReader) UnmarshalChunk(name string, rc io.ReadCloser) error {
+	defer rc.Close()
+
+	if !slices.Contains(cr.names, name) {
+		return clues.New("unexpected details chunk").With("chunk_name", name)
+	}
+
+	dec := json.NewDecoder(rc)
+
+	if _, err := dec.Token(); err != nil {
+		return clues.Wrap(err, "decoding details chunk").With("chunk_name", name)
+	}
+
+	for dec.More() {
+		var de Entry
+
+		if err := dec.Decode(&de); err != nil {
+			return clues.Wrap(err, "decoding details entry").With("chunk_name", name)
+		}
+
+		if cr.filter.matchesEntry(de) {
+			cr.d.Entries = append(cr.d.Entries, de)
+		}
+	}
+
+	cr.read[name] = struct{}{}
+
+	// chunks hold sorted ranges of entries, so the entries only need
+	// sorting if the chunks were read out of order.
+	if len(cr.read) == len(cr.names) {
+		byRepoRef := func(a, b Entry) int {
+			return strings.Compare(a.RepoRef, b.RepoRef)
+		}
+
+		if !slices.IsSortedFunc(cr.d.Entries, byRepoRef) {
+			slices.SortStableFunc(cr.d.Entries, byRepoRef)
+		}
+	}
+
+	return nil
+}
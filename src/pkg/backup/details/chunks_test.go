@@ -0,0 +1,341 @@
+package details
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/filters"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ChunksUnitSuite struct {
+	tester.Suite
+}
+
+func TestChunksUnitSuite(t *testing.T) {
+	suite.Run(t, &ChunksUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	chunkTestOld = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	chunkTestNew = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func chunkTestEntry(repoRef string, mod time.Time) Entry {
+	return Entry{
+		RepoRef: repoRef,
+		ItemInfo: ItemInfo{
+			Exchange: &ExchangeInfo{
+				ItemType: ExchangeMail,
+				Modified: mod,
+			},
+		},
+	}
+}
+
+// entries are deliberately out of order, to check that chunks get sorted.
+func chunkTestDetails() *Details {
+	return &Details{
+		DetailsModel: DetailsModel{
+			Entries: []Entry{
+				chunkTestEntry("tid/exchange/uid/events/cal/e1", chunkTestNew),
+				chunkTestEntry("tid/exchange/uid/email/inbox/m2", chunkTestOld),
+				chunkTestEntry("tid/exchange/uid/contacts/cfld/c1", chunkTestOld),
+				chunkTestEntry("tid/exchange/uid/email/inbox/m1", chunkTestOld),
+				chunkTestEntry("tid/exchange/uid/email/sent/m3", chunkTestOld),
+			},
+		},
+	}
+}
+
+func readChunks(
+	t *testing.T,
+	cw *ChunkWriter,
+	cr *ChunkReader,
+	reverse bool,
+) []string {
+	bs, err := cw.MarshalIndex()
+	require.NoError(t, err, clues.ToCore(err))
+
+	names, err := cr.UnmarshalIndex(io.NopCloser(bytes.NewReader(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+
+	order := append([]string{}, names...)
+
+	if reverse {
+		for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+			order[i], order[j] = order[j], order[i]
+		}
+	}
+
+	for _, name := range order {
+		bs, err := cw.MarshalChunk(name)
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = cr.UnmarshalChunk(name, io.NopCloser(bytes.NewReader(bs)))
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	return names
+}
+
+func repoRefs(d *Details) []string {
+	rrs := []string{}
+
+	for _, de := range d.Entries {
+		rrs = append(rrs, de.RepoRef)
+	}
+
+	return rrs
+}
+
+func (suite *ChunksUnitSuite) TestNewChunkWriter() {
+	t := suite.T()
+
+	d := chunkTestDetails()
+	cw := NewChunkWriter(d, 2)
+	index := cw.Index()
+
+	assert.Equal(t, 5, index.Total)
+	require.Len(t, index.Chunks, 3)
+	assert.Equal(t, []string{"chunk_000000", "chunk_000001", "chunk_000002"}, cw.ChunkNames())
+
+	first := index.Chunks[0]
+	assert.Equal(t, 2, first.Count)
+	assert.Equal(t, "tid/exchange/uid/contacts/cfld/c1", first.FirstRepoRef)
+	assert.Equal(t, "tid/exchange/uid/email/inbox/m1", first.LastRepoRef)
+	assert.Equal(t, []string{path.ContactsCategory.String(), path.EmailCategory.String()}, first.Categories)
+	assert.Equal(t, chunkTestOld, first.ModifiedMin)
+	assert.Equal(t, chunkTestOld, first.ModifiedMax)
+
+	last := index.Chunks[2]
+	assert.Equal(t, 1, last.Count)
+	assert.Equal(t, []string{path.EventsCategory.String()}, last.Categories)
+	assert.Equal(t, chunkTestNew, last.ModifiedMax)
+
+	assert.Equal(
+		t,
+		[]ChunkFolder{
+			{RepoRef: "tid/exchange/uid/contacts/cfld", Locations: []string{"cfld"}},
+			{RepoRef: "tid/exchange/uid/email/inbox", Locations: []string{"inbox"}},
+			{RepoRef: "tid/exchange/uid/email/sent", Locations: []string{"sent"}},
+			{RepoRef: "tid/exchange/uid/events/cal", Locations: []string{"cal"}},
+		},
+		index.Folders)
+
+	assert.Equal(
+		t,
+		"tid/exchange/uid/events/cal/e1",
+		d.Entries[0].RepoRef,
+		"source details are not reordered")
+
+	_, err := cw.MarshalChunk("nope")
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *ChunksUnitSuite) TestChunkReader() {
+	table := []struct {
+		name         string
+		filter       ChunkFilter
+		expectChunks []string
+		expectRefs   []string
+	}{
+		{
+			name:         "no filter",
+			filter:       ChunkFilter{},
+			expectChunks: []string{"chunk_000000", "chunk_000001", "chunk_000002"},
+			expectRefs: []string{
+				"tid/exchange/uid/contacts/cfld/c1",
+				"tid/exchange/uid/email/inbox/m1",
+				"tid/exchange/uid/email/inbox/m2",
+				"tid/exchange/uid/email/sent/m3",
+				"tid/exchange/uid/events/cal/e1",
+			},
+		},
+		{
+			name:         "category",
+			filter:       ChunkFilter{Categories: []path.CategoryType{path.EmailCategory}},
+			expectChunks: []string{"chunk_000000", "chunk_000001"},
+			expectRefs: []string{
+				"tid/exchange/uid/email/inbox/m1",
+				"tid/exchange/uid/email/inbox/m2",
+				"tid/exchange/uid/email/sent/m3",
+			},
+		},
+		{
+			name:         "prefix",
+			filter:       ChunkFilter{RepoRefPrefixes: []string{"tid/exchange/uid/email/sent"}},
+			expectChunks: []string{"chunk_000001"},
+			expectRefs:   []string{"tid/exchange/uid/email/sent/m3"},
+		},
+		{
+			name:         "prefix within a chunk's range",
+			filter:       ChunkFilter{RepoRefPrefixes: []string{"tid/exchange/uid/email/inbox/m2"}},
+			expectChunks: []string{"chunk_000001"},
+			expectRefs:   []string{"tid/exchange/uid/email/inbox/m2"},
+		},
+		{
+			name:         "modified after",
+			filter:       ChunkFilter{ModifiedAfter: chunkTestNew.Add(-time.Hour)},
+			expectChunks: []string{"chunk_000002"},
+			expectRefs:   []string{"tid/exchange/uid/events/cal/e1"},
+		},
+		{
+			name:         "modified before",
+			filter:       ChunkFilter{ModifiedBefore: chunkTestOld.Add(time.Hour)},
+			expectChunks: []string{"chunk_000000", "chunk_000001"},
+			expectRefs: []string{
+				"tid/exchange/uid/contacts/cfld/c1",
+				"tid/exchange/uid/email/inbox/m1",
+				"tid/exchange/uid/email/inbox/m2",
+				"tid/exchange/uid/email/sent/m3",
+			},
+		},
+		{
+			name:         "folder",
+			filter:       ChunkFilter{Folders: []filters.Filter{filters.PathContains([]string{"sent"})}},
+			expectChunks: []string{"chunk_000001"},
+			expectRefs:   []string{"tid/exchange/uid/email/sent/m3"},
+		},
+		{
+			name: "folders across chunks",
+			filter: ChunkFilter{Folders: []filters.Filter{
+				filters.PathContains([]string{"inbox"}),
+				filters.PathPrefix([]string{"cal"}),
+			}},
+			expectChunks: []string{"chunk_000000", "chunk_000001", "chunk_000002"},
+			expectRefs: []string{
+				"tid/exchange/uid/email/inbox/m1",
+				"tid/exchange/uid/email/inbox/m2",
+				"tid/exchange/uid/events/cal/e1",
+			},
+		},
+		{
+			name:         "folder without matches",
+			filter:       ChunkFilter{Folders: []filters.Filter{filters.PathContains([]string{"drafts"})}},
+			expectChunks: nil,
+			expectRefs:   []string{},
+		},
+		{
+			name:         "no matches",
+			filter:       ChunkFilter{Categories: []path.CategoryType{path.FilesCategory}},
+			expectChunks: nil,
+			expectRefs:   []string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			var (
+				cw = NewChunkWriter(chunkTestDetails(), 2)
+				d  = &Details{}
+				cr = NewChunkReader(d, test.filter)
+			)
+
+			names := readChunks(t, cw, cr, false)
+			assert.Equal(t, test.expectChunks, names)
+			assert.Equal(t, test.expectRefs, repoRefs(d))
+		})
+	}
+}
+
+func (suite *ChunksUnitSuite) TestChunkReader_outOfOrder() {
+	t := suite.T()
+
+	var (
+		cw = NewChunkWriter(chunkTestDetails(), 2)
+		d  = &Details{}
+		cr = NewChunkReader(d, ChunkFilter{})
+	)
+
+	readChunks(t, cw, cr, true)
+
+	expect := []string{
+		"tid/exchange/uid/contacts/cfld/c1",
+		"tid/exchange/uid/email/inbox/m1",
+		"tid/exchange/uid/email/inbox/m2",
+		"tid/exchange/uid/email/sent/m3",
+		"tid/exchange/uid/events/cal/e1",
+	}
+	assert.Equal(t, expect, repoRefs(d), "entries keep index order")
+
+	err := cr.UnmarshalChunk("chunk_000009", io.NopCloser(bytes.NewReader([]byte("[]"))))
+	assert.Error(t, err, "chunks outside the index are rejected", clues.ToCore(err))
+}
+
+func (suite *ChunksUnitSuite) TestChunkReader_addsEntriesPerChunk() {
+	t := suite.T()
+
+	var (
+		cw = NewChunkWriter(chunkTestDetails(), 2)
+		d  = &Details{}
+		cr = NewChunkReader(d, ChunkFilter{})
+	)
+
+	bs, err := cw.MarshalIndex()
+	require.NoError(t, err, clues.ToCore(err))
+
+	names, err := cr.UnmarshalIndex(io.NopCloser(bytes.NewReader(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, names, 3)
+
+	bs, err = cw.MarshalChunk(names[1])
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = cr.UnmarshalChunk(names[1], io.NopCloser(bytes.NewReader(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(
+		t,
+		[]string{"tid/exchange/uid/email/inbox/m2", "tid/exchange/uid/email/sent/m3"},
+		repoRefs(d),
+		"entries are added before the remaining chunks are read")
+}
+
+func (suite *ChunksUnitSuite) TestChunkReader_indexWithoutFolders() {
+	t := suite.T()
+
+	var (
+		cw    = NewChunkWriter(chunkTestDetails(), 2)
+		index = cw.Index()
+		d     = &Details{}
+		cr    = NewChunkReader(d, ChunkFilter{
+			Folders: []filters.Filter{filters.PathContains([]string{"sent"})},
+		})
+	)
+
+	index.Folders = nil
+
+	bs, err := json.Marshal(index)
+	require.NoError(t, err, clues.ToCore(err))
+
+	names, err := cr.UnmarshalIndex(io.NopCloser(bytes.NewReader(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Len(t, names, 3, "folders can't be resolved, so every chunk is read")
+}
+
+func (suite *ChunksUnitSuite) TestNewChunkWriter_empty() {
+	t := suite.T()
+
+	var (
+		cw = NewChunkWriter(&Details{}, 0)
+		d  = &Details{}
+		cr = NewChunkReader(d, ChunkFilter{})
+	)
+
+	assert.Empty(t, cw.ChunkNames())
+
+	names := readChunks(t, cw, cr, false)
+	assert.Empty(t, names)
+	assert.Empty(t, d.Entries)
+}
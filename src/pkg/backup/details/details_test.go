@@ -115,6 +115,24 @@ func (suite *DetailsUnitSuite) TestDetailsEntry_HeadersValues() {
 			expectHs: []string{"ID", "Sender", "Folder", "Subject", "Received"},
 			expectVs: []string{"deadbeef", "sender", "Parent", "subject", nowStr},
 		},
+		{
+			name: "exchange setting info",
+			entry: Entry{
+				RepoRef:     "reporef",
+				ShortRef:    "deadbeef",
+				LocationRef: "locationref",
+				ItemRef:     "itemref",
+				ItemInfo: ItemInfo{
+					Exchange: &ExchangeInfo{
+						ItemType:       ExchangeSetting,
+						Setting:        ExchangeInboxRules,
+						SettingEntries: 3,
+					},
+				},
+			},
+			expectHs: []string{"ID", "Setting", "Entries"},
+			expectVs: []string{"deadbeef", "inboxRules", "3"},
+		},
 		{
 			name: "sharepoint library info",
 			entry: Entry{
@@ -0,0 +1,386 @@
+package details
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// DiffChange describes how an item differs between two backups.
+type DiffChange string
+
+const (
+	DiffAdded    DiffChange = "added"
+	DiffRemoved  DiffChange = "removed"
+	DiffModified DiffChange = "modified"
+	DiffMoved    DiffChange = "moved"
+)
+
+// ItemDiff is a single difference between two backups of the same
+// protected resource.
+type ItemDiff struct {
+	Change   DiffChange `json:"change"`
+	Category string     `json:"category"`
+	ItemType ItemType   `json:"itemType"`
+	ItemRef  string     `json:"itemRef"`
+	// ShortRef identifies the item within the backup that holds it: the
+	// older backup for removed items, and the newer one otherwise.
+	ShortRef string `json:"shortRef"`
+	Name     string `json:"name,omitempty"`
+	Location string `json:"location,omitempty"`
+	// PreviousLocation is only populated for moved items.
+	PreviousLocation string `json:"previousLocation,omitempty"`
+	// Modified is nil for items without a modification time.
+	Modified *time.Time `json:"modified,omitempty"`
+}
+
+// DiffCounts tallies the differences within a single category.
+type DiffCounts struct {
+	Category string `json:"category"`
+	Added    int    `json:"added"`
+	Removed  int    `json:"removed"`
+	Modified int    `json:"modified"`
+	Moved    int    `json:"moved"`
+}
+
+func (dc *DiffCounts) add(c DiffChange) {
+	switch c {
+	case DiffAdded:
+		dc.Added++
+	case DiffRemoved:
+		dc.Removed++
+	case DiffModified:
+		dc.Modified++
+	case DiffMoved:
+		dc.Moved++
+	}
+}
+
+// DiffReport holds the differences between two backups of the same
+// protected resource, from the older backup to the newer one.
+type DiffReport struct {
+	FromBackupID string       `json:"fromBackupID,omitempty"`
+	ToBackupID   string       `json:"toBackupID,omitempty"`
+	Counts       []DiffCounts `json:"counts"`
+	Items        []ItemDiff   `json:"items"`
+}
+
+// Total sums the counts of every category.
+func (r DiffReport) Total() DiffCounts {
+	total := DiffCounts{Category: "Total"}
+
+	for _, c := range r.Counts {
+		total.Added += c.Added
+		total.Removed += c.Removed
+		total.Modified += c.Modified
+		total.Moved += c.Moved
+	}
+
+	return total
+}
+
+// Diff compares the items in two backups.  Items are matched by category
+// and item ID.  An item whose location changed is reported as moved, and
+// an item whose modification time or size changed is reported as
+// modified; an item can be both.  Items that get a new ID when they move,
+// such as exchange mail without immutable IDs, are reported as a removal
+// and an addition.  The results are sorted by category, location, name,
+// and change.
+func Diff(from, to *Details) DiffReport {
+	var (
+		before = diffableItems(from)
+		after  = diffableItems(to)
+		items  = []ItemDiff{}
+	)
+
+	for k, prev := range before {
+		curr, ok := after[k]
+		if !ok {
+			items = append(items, newItemDiff(DiffRemoved, k, prev))
+			continue
+		}
+
+		if prev.LocationRef != curr.LocationRef {
+			d := newItemDiff(DiffMoved, k, curr)
+			d.PreviousLocation = prev.LocationRef
+
+			items = append(items, d)
+		}
+
+		if itemChanged(prev.ItemInfo, curr.ItemInfo) {
+			items = append(items, newItemDiff(DiffModified, k, curr))
+		}
+	}
+
+	for k, curr := range after {
+		if _, ok := before[k]; !ok {
+			items = append(items, newItemDiff(DiffAdded, k, curr))
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		if a.ItemRef != b.ItemRef {
+			return a.ItemRef < b.ItemRef
+		}
+
+		return a.Change < b.Change
+	})
+
+	var (
+		counts = []DiffCounts{}
+		byCat  = map[string]int{}
+	)
+
+	for _, d := range items {
+		i, ok := byCat[d.Category]
+		if !ok {
+			i = len(counts)
+			byCat[d.Category] = i
+			counts = append(counts, DiffCounts{Category: d.Category})
+		}
+
+		counts[i].add(d.Change)
+	}
+
+	return DiffReport{
+		Counts: counts,
+		Items:  items,
+	}
+}
+
+type diffKey struct {
+	category path.CategoryType
+	id       string
+}
+
+// diffableItems maps the category and ID of each item in the details to
+// its entry.  Folder entries are ignored.
+func diffableItems(deets *Details) map[diffKey]*Entry {
+	items := map[diffKey]*Entry{}
+
+	if deets == nil {
+		return items
+	}
+
+	for _, ent := range deets.Items() {
+		rr, err := path.FromDataLayerPath(ent.RepoRef, true)
+		if err != nil {
+			continue
+		}
+
+		id := ent.ItemRef
+		if len(id) == 0 {
+			id = rr.Item()
+		}
+
+		items[diffKey{rr.Category(), id}] = ent
+	}
+
+	return items
+}
+
+func newItemDiff(c DiffChange, k diffKey, ent *Entry) ItemDiff {
+	d := ItemDiff{
+		Change:   c,
+		Category: k.category.HumanString(),
+		ItemType: ent.infoType(),
+		ItemRef:  k.id,
+		ShortRef: ent.ShortRef,
+		Name:     itemName(ent.ItemInfo),
+		Location: ent.LocationRef,
+	}
+
+	if mod := ent.Modified(); !mod.IsZero() {
+		d.Modified = &mod
+	}
+
+	return d
+}
+
+func itemChanged(prev, curr ItemInfo) bool {
+	if !prev.Modified().Equal(curr.Modified()) || prev.size() != curr.size() {
+		return true
+	}
+
+	return prev.EntraID != nil && curr.EntraID != nil && prev.EntraID.Hash != curr.EntraID.Hash
+}
+
+// itemName produces the most recognizable name of the item.
+func itemName(i ItemInfo) string {
+	switch {
+	case i.Exchange != nil:
+		switch i.Exchange.ItemType {
+		case ExchangeContact:
+			return i.Exchange.ContactName
+		case ExchangeSetting:
+			return i.Exchange.Setting
+		}
+
+		return i.Exchange.Subject
+
+	case i.OneDrive != nil:
+		return i.OneDrive.ItemName
+
+	case i.SharePoint != nil:
+		return i.SharePoint.ItemName
+
+	case i.Groups != nil:
+		switch i.Groups.ItemType {
+		case GroupsChannelMessage:
+			return i.Groups.Message.Subject
+		case GroupsConversationPost:
+			return i.Groups.Post.Topic
+		case GroupsPlannerTask:
+			return i.Groups.Task.Title
+		}
+
+		return i.Groups.ItemName
+
+	case i.TeamsChats != nil:
+		return i.TeamsChats.Chat.Name
+
+	case i.OneNote != nil:
+		return i.OneNote.ItemName
+
+	case i.EntraID != nil:
+		return i.EntraID.ItemName
+	}
+
+	return ""
+}
+
+// --------------------------------------------------------------------------------
+// CLI Output
+// --------------------------------------------------------------------------------
+
+// interface compliance checks
+var (
+	_ print.Printable = &DiffReport{}
+	_ print.Printable = &DiffCounts{}
+	_ print.Printable = &ItemDiff{}
+)
+
+// Print writes the report to stdout, in the requested output format.
+// Tables list the counts per category, followed by each difference.
+func (r DiffReport) Print(ctx context.Context) {
+	if print.DisplayJSONFormat() {
+		print.Item(ctx, r)
+		return
+	}
+
+	if len(r.Items) == 0 {
+		print.Info(ctx, "No differences between the backups")
+		return
+	}
+
+	ps := []print.Printable{}
+	for _, c := range r.Counts {
+		ps = append(ps, c)
+	}
+
+	print.All(ctx, append(ps, r.Total())...)
+	print.Out(ctx, "")
+
+	ps = []print.Printable{}
+	for _, d := range r.Items {
+		ps = append(ps, d)
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the DiffReport to its minimally printable details.
+func (r DiffReport) MinimumPrintable() any {
+	return r
+}
+
+// Headers returns the human-readable names of properties in a DiffReport
+// for printing out to a terminal in a columnar display.  Only the totals
+// of the report are printed as a table.
+func (r DiffReport) Headers(skipID bool) []string {
+	return r.Total().Headers(skipID)
+}
+
+// Values returns the values matching the Headers list.
+func (r DiffReport) Values(skipID bool) []string {
+	return r.Total().Values(skipID)
+}
+
+// MinimumPrintable reduces the DiffCounts to its minimally printable details.
+func (dc DiffCounts) MinimumPrintable() any {
+	return dc
+}
+
+// Headers returns the human-readable names of properties in a DiffCounts
+// for printing out to a terminal in a columnar display.
+func (dc DiffCounts) Headers(bool) []string {
+	return []string{"Category", "Added", "Removed", "Modified", "Moved"}
+}
+
+// Values returns the values matching the Headers list.
+func (dc DiffCounts) Values(bool) []string {
+	return []string{
+		dc.Category,
+		strconv.Itoa(dc.Added),
+		strconv.Itoa(dc.Removed),
+		strconv.Itoa(dc.Modified),
+		strconv.Itoa(dc.Moved),
+	}
+}
+
+// MinimumPrintable reduces the ItemDiff to its minimally printable details.
+func (d ItemDiff) MinimumPrintable() any {
+	return d
+}
+
+// Headers returns the human-readable names of properties in an ItemDiff
+// for printing out to a terminal in a columnar display.
+func (d ItemDiff) Headers(skipID bool) []string {
+	hs := []string{"Change", "Category", "Name", "Location", "Modified"}
+
+	if skipID {
+		return hs
+	}
+
+	return append([]string{"ID"}, hs...)
+}
+
+// Values returns the values matching the Headers list.
+func (d ItemDiff) Values(skipID bool) []string {
+	loc := d.Location
+	if d.Change == DiffMoved {
+		loc = d.PreviousLocation + " -> " + d.Location
+	}
+
+	var mod string
+	if d.Modified != nil {
+		mod = dttm.FormatToTabularDisplay(*d.Modified)
+	}
+
+	vs := []string{string(d.Change), d.Category, d.Name, loc, mod}
+
+	if skipID {
+		return vs
+	}
+
+	return append([]string{d.ShortRef}, vs...)
+}
@@ -0,0 +1,183 @@
+package details
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type DiffUnitSuite struct {
+	tester.Suite
+}
+
+func TestDiffUnitSuite(t *testing.T) {
+	suite.Run(t, &DiffUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func diffTestMail(id, folder, subject string, mod time.Time) Entry {
+	return Entry{
+		RepoRef:     "tid/exchange/uid/email/" + folder + "/" + id,
+		ShortRef:    "short-" + id,
+		LocationRef: folder,
+		ItemRef:     id,
+		ItemInfo: ItemInfo{
+			Exchange: &ExchangeInfo{
+				ItemType: ExchangeMail,
+				Subject:  subject,
+				Modified: mod,
+				Size:     10,
+			},
+		},
+	}
+}
+
+func diffTestFile(id, name string, mod time.Time) Entry {
+	return Entry{
+		RepoRef:     "tid/onedrive/uid/files/drives/d1/root:/" + id,
+		ShortRef:    "short-" + id,
+		LocationRef: "root:",
+		ItemRef:     id,
+		ItemInfo: ItemInfo{
+			OneDrive: &OneDriveInfo{
+				ItemType: OneDriveItem,
+				ItemName: name,
+				Modified: mod,
+				Size:     10,
+			},
+		},
+	}
+}
+
+func (suite *DiffUnitSuite) TestDiff() {
+	var (
+		t    = suite.T()
+		then = time.Now().Add(-time.Hour).UTC()
+		now  = time.Now().UTC()
+		from = &Details{DetailsModel: DetailsModel{Entries: []Entry{
+			diffTestMail("m1", "inbox", "unchanged", then),
+			diffTestMail("m2", "inbox", "removed", then),
+			diffTestMail("m3", "inbox", "moved", then),
+			diffTestFile("f1", "report.docx", then),
+			{
+				RepoRef:  "tid/exchange/uid/email/inbox",
+				ItemInfo: ItemInfo{Folder: &FolderInfo{ItemType: FolderItem, DisplayName: "inbox"}},
+			},
+		}}}
+		to = &Details{DetailsModel: DetailsModel{Entries: []Entry{
+			diffTestMail("m1", "inbox", "unchanged", then),
+			diffTestMail("m3", "archive", "moved", now),
+			diffTestMail("m4", "inbox", "added", now),
+			diffTestFile("f1", "report.docx", now),
+		}}}
+	)
+
+	r := Diff(from, to)
+
+	expect := []ItemDiff{
+		{
+			Change:   DiffModified,
+			Category: "Emails",
+			ItemType: ExchangeMail,
+			ItemRef:  "m3",
+			ShortRef: "short-m3",
+			Name:     "moved",
+			Location: "archive",
+			Modified: &now,
+		},
+		{
+			Change:           DiffMoved,
+			Category:         "Emails",
+			ItemType:         ExchangeMail,
+			ItemRef:          "m3",
+			ShortRef:         "short-m3",
+			Name:             "moved",
+			Location:         "archive",
+			PreviousLocation: "inbox",
+			Modified:         &now,
+		},
+		{
+			Change:   DiffAdded,
+			Category: "Emails",
+			ItemType: ExchangeMail,
+			ItemRef:  "m4",
+			ShortRef: "short-m4",
+			Name:     "added",
+			Location: "inbox",
+			Modified: &now,
+		},
+		{
+			Change:   DiffRemoved,
+			Category: "Emails",
+			ItemType: ExchangeMail,
+			ItemRef:  "m2",
+			ShortRef: "short-m2",
+			Name:     "removed",
+			Location: "inbox",
+			Modified: &then,
+		},
+		{
+			Change:   DiffModified,
+			Category: "Files",
+			ItemType: OneDriveItem,
+			ItemRef:  "f1",
+			ShortRef: "short-f1",
+			Name:     "report.docx",
+			Location: "root:",
+			Modified: &now,
+		},
+	}
+
+	assert.Equal(t, expect, r.Items)
+	assert.Equal(
+		t,
+		[]DiffCounts{
+			{Category: "Emails", Added: 1, Removed: 1, Modified: 1, Moved: 1},
+			{Category: "Files", Modified: 1},
+		},
+		r.Counts)
+	assert.Equal(
+		t,
+		DiffCounts{Category: "Total", Added: 1, Removed: 1, Modified: 2, Moved: 1},
+		r.Total())
+
+	assert.Empty(t, Diff(to, to).Items, "no differences with itself")
+	assert.Len(t, Diff(nil, to).Items, 4, "everything added")
+}
+
+func (suite *DiffUnitSuite) TestItemDiff_Values() {
+	t := suite.T()
+
+	d := ItemDiff{
+		Change:           DiffMoved,
+		Category:         "Emails",
+		ShortRef:         "short",
+		Name:             "subject",
+		Location:         "archive",
+		PreviousLocation: "inbox",
+	}
+
+	assert.Equal(t, len(d.Headers(false)), len(d.Values(false)))
+	assert.Equal(t, len(d.Headers(true)), len(d.Values(true)))
+	assert.Equal(t, []string{"short", "moved", "Emails", "subject", "inbox -> archive", ""}, d.Values(false))
+}
+
+func (suite *DiffUnitSuite) TestItemDiff_JSON() {
+	t := suite.T()
+
+	bs, err := json.Marshal(ItemDiff{Change: DiffAdded, ItemRef: "id"})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.NotContains(t, string(bs), "modified", "no modification time")
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	bs, err = json.Marshal(ItemDiff{Change: DiffAdded, ItemRef: "id", Modified: &now})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Contains(t, string(bs), `"modified":"`+now.Format(time.RFC3339)+`"`)
+}
@@ -0,0 +1,122 @@
+package details
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// Directory objects are grouped into a folder per kind of object.
+const (
+	EntraIDUsersFolder             = "users"
+	EntraIDGroupsFolder            = "groups"
+	EntraIDApplicationsFolder      = "applications"
+	EntraIDServicePrincipalsFolder = "servicePrincipals"
+)
+
+// NewEntraIDLocationIDer builds a LocationIDer for directory objects.
+// Objects are grouped by their kind (users, groups, etc), which is the
+// only folder level in the hierarchy.
+func NewEntraIDLocationIDer(escapedFolders ...string) uniqueLoc {
+	pb := path.Builder{}.
+		Append(path.DirectoryObjectsCategory.String()).
+		Append(escapedFolders...)
+
+	return uniqueLoc{
+		pb:          pb,
+		prefixElems: 1,
+	}
+}
+
+// EntraIDInfo describes a directory object (user, group, application, or
+// service principal) in the tenant's Entra ID directory.
+type EntraIDInfo struct {
+	Created    time.Time `json:"created,omitempty"`
+	ItemName   string    `json:"itemName,omitempty"`
+	ItemType   ItemType  `json:"itemType,omitempty"`
+	Modified   time.Time `json:"modified,omitempty"`
+	ParentPath string    `json:"parentPath,omitempty"`
+	Size       int64     `json:"size,omitempty"`
+
+	// PrincipalName is the most stable human-readable identifier of the
+	// object: the UPN of a user, the mail nickname of a group, or the
+	// appId of an application or service principal.
+	PrincipalName string `json:"principalName,omitempty"`
+
+	// Hash is a digest of the object's properties, excluding any that
+	// change without user intervention.  Comparing the hash between two
+	// backups tells us whether the object changed.
+	Hash string `json:"hash,omitempty"`
+
+	// Members holds the IDs of a group's direct members.  Only populated
+	// for groups.
+	Members []string `json:"members,omitempty"`
+}
+
+// Headers returns the human-readable names of properties in an EntraIDInfo
+// for printing out to a terminal in a columnar display.
+func (i EntraIDInfo) Headers() []string {
+	switch i.ItemType {
+	case EntraIDGroup:
+		return []string{"Name", "Principal", "Members", "Created"}
+	}
+
+	return []string{"Name", "Principal", "Created"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (i EntraIDInfo) Values() []string {
+	switch i.ItemType {
+	case EntraIDGroup:
+		return []string{
+			i.ItemName,
+			i.PrincipalName,
+			strconv.Itoa(len(i.Members)),
+			dttm.FormatToTabularDisplay(i.Created),
+		}
+	}
+
+	return []string{
+		i.ItemName,
+		i.PrincipalName,
+		dttm.FormatToTabularDisplay(i.Created),
+	}
+}
+
+func (i *EntraIDInfo) UpdateParentPath(newLocPath *path.Builder) {
+	i.ParentPath = newLocPath.String()
+}
+
+func (i *EntraIDInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
+	if !isEntraIDType(i.ItemType) {
+		return nil, clues.New("unsupported ItemType for EntraIDInfo").With("item_type", i.ItemType)
+	}
+
+	loc := NewEntraIDLocationIDer(baseLoc.Elements()...)
+
+	return &loc, nil
+}
+
+func (i *EntraIDInfo) updateFolder(f *FolderInfo) error {
+	f.DataType = i.ItemType
+
+	if isEntraIDType(i.ItemType) {
+		return nil
+	}
+
+	return clues.New("unsupported ItemType for EntraIDInfo").With("item_type", i.ItemType)
+}
+
+func isEntraIDType(it ItemType) bool {
+	switch it {
+	case EntraIDUser, EntraIDGroup, EntraIDApplication, EntraIDServicePrincipal:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,268 @@
+package details
+
+import (
+	"context"
+	"sort"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// EntraIDChange describes how a directory object differs between two
+// backups.
+type EntraIDChange string
+
+const (
+	EntraIDAdded         EntraIDChange = "added"
+	EntraIDRemoved       EntraIDChange = "removed"
+	EntraIDModified      EntraIDChange = "modified"
+	EntraIDMemberAdded   EntraIDChange = "member added"
+	EntraIDMemberRemoved EntraIDChange = "member removed"
+)
+
+// EntraIDDiff is a single difference between two backups of the same
+// directory.
+type EntraIDDiff struct {
+	Change   EntraIDChange `json:"change"`
+	ItemType ItemType      `json:"itemType"`
+	ID       string        `json:"id"`
+	Name     string        `json:"name,omitempty"`
+
+	// MemberID and MemberName are only populated for membership changes,
+	// in which case ID and Name describe the group.
+	MemberID   string `json:"memberID,omitempty"`
+	MemberName string `json:"memberName,omitempty"`
+}
+
+type EntraIDDiffs []EntraIDDiff
+
+// PrintEntries prints the differences to the terminal.
+func (ds EntraIDDiffs) PrintEntries(ctx context.Context) {
+	ps := make([]print.Printable, 0, len(ds))
+	for _, d := range ds {
+		ps = append(ps, d)
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the diff to its minimally printable details.
+func (d EntraIDDiff) MinimumPrintable() any {
+	return d
+}
+
+// Headers returns the human-readable names of properties in an EntraIDDiff
+// for printing out to a terminal in a columnar display.
+func (d EntraIDDiff) Headers(skipID bool) []string {
+	hs := []string{"Change", "Type", "Name", "Member"}
+
+	if skipID {
+		return hs
+	}
+
+	return append([]string{"ID"}, hs...)
+}
+
+// Values returns the values matching the Headers list.
+func (d EntraIDDiff) Values(skipID bool) []string {
+	member := d.MemberName
+	if len(member) == 0 {
+		member = d.MemberID
+	}
+
+	vs := []string{string(d.Change), entraIDTypeName(d.ItemType), d.Name, member}
+
+	if skipID {
+		return vs
+	}
+
+	return append([]string{d.ID}, vs...)
+}
+
+func entraIDTypeName(it ItemType) string {
+	switch it {
+	case EntraIDUser:
+		return "user"
+	case EntraIDGroup:
+		return "group"
+	case EntraIDApplication:
+		return "application"
+	case EntraIDServicePrincipal:
+		return "service principal"
+	}
+
+	return "unknown"
+}
+
+// DiffEntraID compares the directory objects in two backups.  Objects
+// are matched by ID, so renamed objects are reported as modified, not
+// as a removal and an addition.  The results are sorted by type, name,
+// and change.
+func DiffEntraID(from, to *Details) EntraIDDiffs {
+	var (
+		before = entraIDObjects(from)
+		after  = entraIDObjects(to)
+		diffs  = EntraIDDiffs{}
+	)
+
+	nameOf := func(id string) string {
+		if info, ok := after[id]; ok {
+			return info.ItemName
+		}
+
+		if info, ok := before[id]; ok {
+			return info.ItemName
+		}
+
+		return ""
+	}
+
+	for id, prev := range before {
+		curr, ok := after[id]
+		if !ok {
+			diffs = append(diffs, EntraIDDiff{
+				Change:   EntraIDRemoved,
+				ItemType: prev.ItemType,
+				ID:       id,
+				Name:     prev.ItemName,
+			})
+
+			continue
+		}
+
+		if prev.Hash != curr.Hash {
+			diffs = append(diffs, EntraIDDiff{
+				Change:   EntraIDModified,
+				ItemType: curr.ItemType,
+				ID:       id,
+				Name:     curr.ItemName,
+			})
+		}
+
+		added, removed := diffMembers(prev.Members, curr.Members)
+
+		for _, mid := range added {
+			diffs = append(diffs, EntraIDDiff{
+				Change:     EntraIDMemberAdded,
+				ItemType:   curr.ItemType,
+				ID:         id,
+				Name:       curr.ItemName,
+				MemberID:   mid,
+				MemberName: nameOf(mid),
+			})
+		}
+
+		for _, mid := range removed {
+			diffs = append(diffs, EntraIDDiff{
+				Change:     EntraIDMemberRemoved,
+				ItemType:   curr.ItemType,
+				ID:         id,
+				Name:       curr.ItemName,
+				MemberID:   mid,
+				MemberName: nameOf(mid),
+			})
+		}
+	}
+
+	for id, curr := range after {
+		if _, ok := before[id]; ok {
+			continue
+		}
+
+		diffs = append(diffs, EntraIDDiff{
+			Change:   EntraIDAdded,
+			ItemType: curr.ItemType,
+			ID:       id,
+			Name:     curr.ItemName,
+		})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		a, b := diffs[i], diffs[j]
+
+		if a.ItemType != b.ItemType {
+			return a.ItemType < b.ItemType
+		}
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+
+		if a.Change != b.Change {
+			return a.Change < b.Change
+		}
+
+		return a.MemberID < b.MemberID
+	})
+
+	return diffs
+}
+
+// entraIDObjects maps the ID of each directory object in the details
+// to its info.  Folder entries and other services are ignored.
+func entraIDObjects(deets *Details) map[string]*EntraIDInfo {
+	objs := map[string]*EntraIDInfo{}
+
+	if deets == nil {
+		return objs
+	}
+
+	for _, ent := range deets.Entries {
+		if ent.EntraID == nil {
+			continue
+		}
+
+		id := ent.ItemRef
+
+		if len(id) == 0 {
+			rr, err := path.FromDataLayerPath(ent.RepoRef, true)
+			if err != nil {
+				continue
+			}
+
+			id = rr.Item()
+		}
+
+		objs[id] = ent.EntraID
+	}
+
+	return objs
+}
+
+// diffMembers returns the sorted member IDs only found in curr (added)
+// and only found in prev (removed).
+func diffMembers(prev, curr []string) ([]string, []string) {
+	var (
+		added   = []string{}
+		removed = []string{}
+		inPrev  = map[string]struct{}{}
+		inCurr  = map[string]struct{}{}
+	)
+
+	for _, id := range prev {
+		inPrev[id] = struct{}{}
+	}
+
+	for _, id := range curr {
+		inCurr[id] = struct{}{}
+
+		if _, ok := inPrev[id]; !ok {
+			added = append(added, id)
+		}
+	}
+
+	for _, id := range prev {
+		if _, ok := inCurr[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed
+}
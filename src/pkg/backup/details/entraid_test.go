@@ -0,0 +1,153 @@
+package details_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+type EntraIDUnitSuite struct {
+	tester.Suite
+}
+
+func TestEntraIDUnitSuite(t *testing.T) {
+	suite.Run(t, &EntraIDUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *EntraIDUnitSuite) TestEntraIDPrintable() {
+	now := time.Now()
+
+	table := []struct {
+		name         string
+		info         details.EntraIDInfo
+		expectHeader []string
+		expectValues []string
+	}{
+		{
+			name: "user",
+			info: details.EntraIDInfo{
+				ItemType:      details.EntraIDUser,
+				ItemName:      "Adele Vance",
+				PrincipalName: "adele@contoso.com",
+				Created:       now,
+				Modified:      now,
+			},
+			expectHeader: []string{"Name", "Principal", "Created"},
+			expectValues: []string{
+				"Adele Vance",
+				"adele@contoso.com",
+				dttm.FormatToTabularDisplay(now),
+			},
+		},
+		{
+			name: "group",
+			info: details.EntraIDInfo{
+				ItemType:      details.EntraIDGroup,
+				ItemName:      "Marketing",
+				PrincipalName: "marketing",
+				Members:       []string{"u1", "u2"},
+				Created:       now,
+				Modified:      now,
+			},
+			expectHeader: []string{"Name", "Principal", "Members", "Created"},
+			expectValues: []string{
+				"Marketing",
+				"marketing",
+				"2",
+				dttm.FormatToTabularDisplay(now),
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			hs := test.info.Headers()
+			vs := test.info.Values()
+
+			assert.Equal(t, len(hs), len(vs))
+			assert.Equal(t, test.expectHeader, hs)
+			assert.Equal(t, test.expectValues, vs)
+		})
+	}
+}
+
+func entraIDEntry(
+	id string,
+	it details.ItemType,
+	name, hash string,
+	members ...string,
+) details.Entry {
+	return details.Entry{
+		RepoRef: "tid/entraID/tid/directoryObjects/kind/" + id,
+		ItemRef: id,
+		ItemInfo: details.ItemInfo{
+			EntraID: &details.EntraIDInfo{
+				ItemType: it,
+				ItemName: name,
+				Hash:     hash,
+				Members:  members,
+			},
+		},
+	}
+}
+
+func (suite *EntraIDUnitSuite) TestDiffEntraID() {
+	t := suite.T()
+
+	from := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				entraIDEntry("u1", details.EntraIDUser, "Adele", "a"),
+				entraIDEntry("u2", details.EntraIDUser, "Megan", "b"),
+				entraIDEntry("u3", details.EntraIDUser, "Isaiah", "c"),
+				entraIDEntry("g1", details.EntraIDGroup, "Marketing", "d", "u1", "u2"),
+			},
+		},
+	}
+
+	to := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				entraIDEntry("u1", details.EntraIDUser, "Adele", "a"),
+				entraIDEntry("u2", details.EntraIDUser, "Megan Bowen", "changed"),
+				entraIDEntry("u4", details.EntraIDUser, "Lidia", "e"),
+				entraIDEntry("g1", details.EntraIDGroup, "Marketing", "d", "u1", "u4"),
+				entraIDEntry("a1", details.EntraIDApplication, "App", "f"),
+			},
+		},
+	}
+
+	expect := details.EntraIDDiffs{
+		{Change: details.EntraIDRemoved, ItemType: details.EntraIDUser, ID: "u3", Name: "Isaiah"},
+		{Change: details.EntraIDAdded, ItemType: details.EntraIDUser, ID: "u4", Name: "Lidia"},
+		{Change: details.EntraIDModified, ItemType: details.EntraIDUser, ID: "u2", Name: "Megan Bowen"},
+		{
+			Change:     details.EntraIDMemberAdded,
+			ItemType:   details.EntraIDGroup,
+			ID:         "g1",
+			Name:       "Marketing",
+			MemberID:   "u4",
+			MemberName: "Lidia",
+		},
+		{
+			Change:     details.EntraIDMemberRemoved,
+			ItemType:   details.EntraIDGroup,
+			ID:         "g1",
+			Name:       "Marketing",
+			MemberID:   "u2",
+			MemberName: "Megan Bowen",
+		},
+		{Change: details.EntraIDAdded, ItemType: details.EntraIDApplication, ID: "a1", Name: "App"},
+	}
+
+	assert.Equal(t, expect, details.DiffEntraID(from, to))
+	assert.Empty(t, details.DiffEntraID(to, to), "no changes")
+	assert.Len(t, details.DiffEntraID(nil, to), 5, "everything is added")
+}
@@ -87,7 +87,7 @@ func (de Entry) ToLocationIDer(backupVersion int) (LocationIDer, error) {
 		}
 
 		fallthrough
-	case ExchangeMail, ExchangeContact:
+	case ExchangeMail, ExchangeContact, ExchangeSetting:
 		baseLoc = path.Builder{}.Append(rr.Folders()...)
 
 	case OneDriveItem, SharePointLibrary:
@@ -163,6 +163,18 @@ func (de Entry) Headers(skipID bool) []string {
 		hs = de.ItemInfo.Groups.Headers()
 	}
 
+	if de.ItemInfo.TeamsChats != nil {
+		hs = de.ItemInfo.TeamsChats.Headers()
+	}
+
+	if de.ItemInfo.OneNote != nil {
+		hs = de.ItemInfo.OneNote.Headers()
+	}
+
+	if de.ItemInfo.EntraID != nil {
+		hs = de.ItemInfo.EntraID.Headers()
+	}
+
 	if skipID {
 		return hs
 	}
@@ -194,6 +206,18 @@ func (de Entry) Values(skipID bool) []string {
 		vs = de.ItemInfo.Groups.Values()
 	}
 
+	if de.ItemInfo.TeamsChats != nil {
+		vs = de.ItemInfo.TeamsChats.Values()
+	}
+
+	if de.ItemInfo.OneNote != nil {
+		vs = de.ItemInfo.OneNote.Values()
+	}
+
+	if de.ItemInfo.EntraID != nil {
+		vs = de.ItemInfo.EntraID.Values()
+	}
+
 	if skipID {
 		return vs
 	}
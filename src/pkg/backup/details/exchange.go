@@ -10,6 +10,33 @@ import (
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
+// The names of the mailbox settings items.  Each one is its own item in
+// the mailbox settings collection of a user.
+const (
+	ExchangeMailboxSettings  = "mailboxSettings"
+	ExchangeAutomaticReplies = "automaticReplies"
+	ExchangeInboxRules       = "inboxRules"
+	ExchangeCategories       = "categories"
+)
+
+// ExchangeSettingNames are the mailbox settings items, in the order
+// they're backed up.
+var ExchangeSettingNames = []string{
+	ExchangeMailboxSettings,
+	ExchangeAutomaticReplies,
+	ExchangeInboxRules,
+	ExchangeCategories,
+}
+
+// The root locations of the mail folder trees that live outside of the
+// primary mailbox's folder hierarchy.  Mail from these trees is placed
+// under the root, keeping it apart from folders of the same name in the
+// primary mailbox.
+const (
+	ExchangeArchiveFolder          = "Online Archive"
+	ExchangeRecoverableItemsFolder = "Recoverable Items"
+)
+
 // NewExchangeLocationIDer builds a LocationIDer for the given category and
 // folder path. The path denoted by the folders should be unique within the
 // category.
@@ -45,6 +72,10 @@ type ExchangeInfo struct {
 	Created     time.Time `json:"created,omitempty"`
 	Modified    time.Time `json:"modified,omitempty"`
 	Size        int64     `json:"size,omitempty"`
+	// Setting is the name of a mailbox settings item, and SettingEntries
+	// the number of rules or categories it holds.
+	Setting        string `json:"setting,omitempty"`
+	SettingEntries int    `json:"settingEntries,omitempty"`
 }
 
 // Headers returns the human-readable names of properties in an ExchangeInfo
@@ -59,6 +90,9 @@ func (i ExchangeInfo) Headers() []string {
 
 	case ExchangeMail:
 		return []string{"Sender", "Folder", "Subject", "Received"}
+
+	case ExchangeSetting:
+		return []string{"Setting", "Entries"}
 	}
 
 	return []string{}
@@ -85,6 +119,9 @@ func (i ExchangeInfo) Values() []string {
 			i.Sender, i.ParentPath, i.Subject,
 			dttm.FormatToTabularDisplay(i.Received),
 		}
+
+	case ExchangeSetting:
+		return []string{i.Setting, strconv.Itoa(i.SettingEntries)}
 	}
 
 	return []string{}
@@ -104,6 +141,8 @@ func (i *ExchangeInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error)
 		category = path.ContactsCategory
 	case ExchangeMail:
 		category = path.EmailCategory
+	case ExchangeSetting:
+		category = path.MailboxSettingsCategory
 	}
 
 	loc, err := NewExchangeLocationIDer(category, baseLoc.Elements()...)
@@ -115,7 +154,7 @@ func (i *ExchangeInfo) updateFolder(f *FolderInfo) error {
 	// Use a switch instead of a rather large if-statement. Just make sure it's an
 	// Exchange type. If it's not return an error.
 	switch i.ItemType {
-	case ExchangeContact, ExchangeEvent, ExchangeMail:
+	case ExchangeContact, ExchangeEvent, ExchangeMail, ExchangeSetting:
 	default:
 		return clues.New("unsupported non-Exchange ItemType").
 			With("item_type", i.ItemType)
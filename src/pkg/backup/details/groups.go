@@ -48,6 +48,9 @@ type GroupsInfo struct {
 	// Conversations Specific
 	Post ConversationPostInfo `json:"post,omitempty"`
 
+	// Planner Specific
+	Task PlannerTaskInfo `json:"task,omitempty"`
+
 	// SharePoint specific
 	Created    time.Time `json:"created,omitempty"`
 	DriveName  string    `json:"driveName,omitempty"`
@@ -69,6 +72,19 @@ type ConversationPostInfo struct {
 	Topic      string    `json:"topic,omitempty"`
 }
 
+type PlannerTaskInfo struct {
+	Assignees       []string  `json:"assignees,omitempty"`
+	Bucket          string    `json:"bucket,omitempty"`
+	CompletedAt     time.Time `json:"completedAt,omitempty"`
+	CreatedAt       time.Time `json:"createdAt,omitempty"`
+	Creator         string    `json:"creator,omitempty"`
+	DueAt           time.Time `json:"dueAt,omitempty"`
+	PercentComplete int       `json:"percentComplete"`
+	Plan            string    `json:"plan,omitempty"`
+	Size            int64     `json:"size,omitempty"`
+	Title           string    `json:"title,omitempty"`
+}
+
 type ChannelMessageInfo struct {
 	AttachmentNames []string  `json:"attachmentNames,omitempty"`
 	CreatedAt       time.Time `json:"createdAt,omitempty"`
@@ -89,6 +105,8 @@ func (i GroupsInfo) Headers() []string {
 		return []string{"Message", "Channel", "Subject", "Replies", "Creator", "Created", "Last Reply"}
 	case GroupsConversationPost:
 		return []string{"Post", "Conversation", "Sender", "Created"}
+	case GroupsPlannerTask:
+		return []string{"Task", "Plan", "Bucket", "Progress", "Due", "Created"}
 	}
 
 	return []string{}
@@ -132,6 +150,20 @@ func (i GroupsInfo) Values() []string {
 			i.Post.Creator,
 			dttm.FormatToTabularDisplay(i.Post.CreatedAt),
 		}
+	case GroupsPlannerTask:
+		due := dttm.FormatToTabularDisplay(i.Task.DueAt)
+		if i.Task.DueAt.IsZero() {
+			due = ""
+		}
+
+		return []string{
+			i.Task.Title,
+			i.Task.Plan,
+			i.Task.Bucket,
+			strconv.Itoa(i.Task.PercentComplete) + "%",
+			due,
+			dttm.FormatToTabularDisplay(i.Task.CreatedAt),
+		}
 	}
 
 	return []string{}
@@ -158,6 +190,8 @@ func (i *GroupsInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
 		loc, err = NewGroupsLocationIDer(path.ChannelMessagesCategory, "", baseLoc.Elements()...)
 	case GroupsConversationPost:
 		loc, err = NewGroupsLocationIDer(path.ConversationPostsCategory, "", baseLoc.Elements()...)
+	case GroupsPlannerTask:
+		loc, err = NewGroupsLocationIDer(path.PlannerTasksCategory, "", baseLoc.Elements()...)
 	}
 
 	return &loc, err
@@ -169,7 +203,7 @@ func (i *GroupsInfo) updateFolder(f *FolderInfo) error {
 	switch i.ItemType {
 	case SharePointLibrary:
 		return updateFolderWithinDrive(SharePointLibrary, i.DriveName, i.DriveID, f)
-	case GroupsChannelMessage, GroupsConversationPost:
+	case GroupsChannelMessage, GroupsConversationPost, GroupsPlannerTask:
 		return nil
 	}
 
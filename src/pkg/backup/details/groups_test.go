@@ -76,6 +76,29 @@ func (suite *GroupsUnitSuite) TestGroupsPrintable() {
 				dttm.FormatToTabularDisplay(now),
 			},
 		},
+		{
+			name: "planner task",
+			info: details.GroupsInfo{
+				ItemType: details.GroupsPlannerTask,
+				Task: details.PlannerTaskInfo{
+					Title:           "title",
+					Plan:            "plan",
+					Bucket:          "bucket",
+					PercentComplete: 50,
+					DueAt:           then,
+					CreatedAt:       now,
+				},
+			},
+			expectHs: []string{"Task", "Plan", "Bucket", "Progress", "Due", "Created"},
+			expectVs: []string{
+				"title",
+				"plan",
+				"bucket",
+				"50%",
+				dttm.FormatToTabularDisplay(then),
+				dttm.FormatToTabularDisplay(now),
+			},
+		},
 		{
 			name: "sharepoint library",
 			info: details.GroupsInfo{
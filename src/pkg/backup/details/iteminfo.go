@@ -26,6 +26,7 @@ const (
 	ExchangeContact ItemType = 1
 	ExchangeEvent   ItemType = 2
 	ExchangeMail    ItemType = 3
+	ExchangeSetting ItemType = 4
 
 	// SharePoint (10x)
 	SharePointLibrary ItemType = 101 // also used for groups
@@ -41,6 +42,19 @@ const (
 	// Groups/Teams(40x)
 	GroupsChannelMessage   ItemType = 401
 	GroupsConversationPost ItemType = 402
+	GroupsPlannerTask      ItemType = 403
+
+	// Teams Chats (50x)
+	TeamsChatMessage ItemType = 501
+
+	// OneNote (60x)
+	OneNotePage ItemType = 601
+
+	// Entra ID (70x)
+	EntraIDUser             ItemType = 701
+	EntraIDGroup            ItemType = 702
+	EntraIDApplication      ItemType = 703
+	EntraIDServicePrincipal ItemType = 704
 )
 
 func UpdateItem(item *ItemInfo, newLocPath *path.Builder) {
@@ -62,6 +76,12 @@ func UpdateItem(item *ItemInfo, newLocPath *path.Builder) {
 		item.OneDrive.UpdateParentPath(newLocPath)
 	} else if item.Groups != nil {
 		item.Groups.UpdateParentPath(newLocPath)
+	} else if item.TeamsChats != nil {
+		item.TeamsChats.UpdateParentPath(newLocPath)
+	} else if item.OneNote != nil {
+		item.OneNote.UpdateParentPath(newLocPath)
+	} else if item.EntraID != nil {
+		item.EntraID.UpdateParentPath(newLocPath)
 	}
 }
 
@@ -73,6 +93,9 @@ type ItemInfo struct {
 	SharePoint *SharePointInfo `json:"sharePoint,omitempty"`
 	OneDrive   *OneDriveInfo   `json:"oneDrive,omitempty"`
 	Groups     *GroupsInfo     `json:"groups,omitempty"`
+	TeamsChats *TeamsChatsInfo `json:"teamsChats,omitempty"`
+	OneNote    *OneNoteInfo    `json:"oneNote,omitempty"`
+	EntraID    *EntraIDInfo    `json:"entraID,omitempty"`
 	// Optional item extension data
 	Extension *ExtensionData `json:"extension,omitempty"`
 }
@@ -99,6 +122,15 @@ func (i ItemInfo) infoType() ItemType {
 
 	case i.Groups != nil:
 		return i.Groups.ItemType
+
+	case i.TeamsChats != nil:
+		return i.TeamsChats.ItemType
+
+	case i.OneNote != nil:
+		return i.OneNote.ItemType
+
+	case i.EntraID != nil:
+		return i.EntraID.ItemType
 	}
 
 	return UnknownType
@@ -118,6 +150,15 @@ func (i ItemInfo) size() int64 {
 	case i.Groups != nil:
 		return i.Groups.Size
 
+	case i.TeamsChats != nil:
+		return i.TeamsChats.Message.Size
+
+	case i.OneNote != nil:
+		return i.OneNote.Size
+
+	case i.EntraID != nil:
+		return i.EntraID.Size
+
 	case i.Folder != nil:
 		return i.Folder.Size
 	}
@@ -139,6 +180,15 @@ func (i ItemInfo) Modified() time.Time {
 	case i.Groups != nil:
 		return i.Groups.Modified
 
+	case i.TeamsChats != nil:
+		return i.TeamsChats.Modified
+
+	case i.OneNote != nil:
+		return i.OneNote.Modified
+
+	case i.EntraID != nil:
+		return i.EntraID.Modified
+
 	case i.Folder != nil:
 		return i.Folder.Modified
 	}
@@ -160,6 +210,15 @@ func (i ItemInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
 	case i.Groups != nil:
 		return i.Groups.uniqueLocation(baseLoc)
 
+	case i.TeamsChats != nil:
+		return i.TeamsChats.uniqueLocation(baseLoc)
+
+	case i.OneNote != nil:
+		return i.OneNote.uniqueLocation(baseLoc)
+
+	case i.EntraID != nil:
+		return i.EntraID.uniqueLocation(baseLoc)
+
 	default:
 		return nil, clues.New("unsupported type")
 	}
@@ -179,6 +238,15 @@ func (i ItemInfo) updateFolder(f *FolderInfo) error {
 	case i.Groups != nil:
 		return i.Groups.updateFolder(f)
 
+	case i.TeamsChats != nil:
+		return i.TeamsChats.updateFolder(f)
+
+	case i.OneNote != nil:
+		return i.OneNote.updateFolder(f)
+
+	case i.EntraID != nil:
+		return i.EntraID.updateFolder(f)
+
 	default:
 		return clues.New("unsupported type")
 	}
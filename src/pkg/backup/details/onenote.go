@@ -0,0 +1,81 @@
+package details
+
+import (
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// NewOneNoteLocationIDer builds a LocationIDer for a notebook section.
+// Notebooks are backed up the same way for users and sites, so the
+// location is identical for both.
+func NewOneNoteLocationIDer(escapedFolders ...string) uniqueLoc {
+	pb := path.Builder{}.
+		Append(path.NotebooksCategory.String()).
+		Append(escapedFolders...)
+
+	return uniqueLoc{
+		pb:          pb,
+		prefixElems: 1,
+	}
+}
+
+// OneNoteInfo describes a page in one of a user's or site's notebooks.
+type OneNoteInfo struct {
+	Created    time.Time `json:"created,omitempty"`
+	ItemName   string    `json:"itemName,omitempty"`
+	ItemType   ItemType  `json:"itemType,omitempty"`
+	Modified   time.Time `json:"modified,omitempty"`
+	Notebook   string    `json:"notebook,omitempty"`
+	ParentPath string    `json:"parentPath,omitempty"`
+	Section    string    `json:"section,omitempty"`
+	Size       int64     `json:"size,omitempty"`
+	WebURL     string    `json:"webURL,omitempty"`
+}
+
+// Headers returns the human-readable names of properties in a OneNoteInfo
+// for printing out to a terminal in a columnar display.
+func (i OneNoteInfo) Headers() []string {
+	return []string{"Page", "Notebook", "Section", "Size", "Created", "Modified"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (i OneNoteInfo) Values() []string {
+	return []string{
+		i.ItemName,
+		i.Notebook,
+		i.Section,
+		humanize.Bytes(uint64(i.Size)),
+		dttm.FormatToTabularDisplay(i.Created),
+		dttm.FormatToTabularDisplay(i.Modified),
+	}
+}
+
+func (i *OneNoteInfo) UpdateParentPath(newLocPath *path.Builder) {
+	i.ParentPath = newLocPath.String()
+}
+
+func (i *OneNoteInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
+	if i.ItemType != OneNotePage {
+		return nil, clues.New("unsupported ItemType for OneNoteInfo").With("item_type", i.ItemType)
+	}
+
+	loc := NewOneNoteLocationIDer(baseLoc.Elements()...)
+
+	return &loc, nil
+}
+
+func (i *OneNoteInfo) updateFolder(f *FolderInfo) error {
+	f.DataType = i.ItemType
+
+	if i.ItemType == OneNotePage {
+		return nil
+	}
+
+	return clues.New("unsupported ItemType for OneNoteInfo").With("item_type", i.ItemType)
+}
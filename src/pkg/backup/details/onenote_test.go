@@ -0,0 +1,92 @@
+package details_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type OneNoteUnitSuite struct {
+	tester.Suite
+}
+
+func TestOneNoteUnitSuite(t *testing.T) {
+	suite.Run(t, &OneNoteUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *OneNoteUnitSuite) TestOneNotePrintable() {
+	t := suite.T()
+	now := time.Now()
+	then := now.Add(time.Minute)
+
+	info := details.OneNoteInfo{
+		ItemType:   details.OneNotePage,
+		ItemName:   "page",
+		Notebook:   "notebook",
+		Section:    "section",
+		ParentPath: "notebook/group/section",
+		Size:       1000,
+		Created:    now,
+		Modified:   then,
+	}
+
+	hs := info.Headers()
+	vs := info.Values()
+
+	assert.Equal(t, len(hs), len(vs))
+	assert.Equal(t, []string{"Page", "Notebook", "Section", "Size", "Created", "Modified"}, hs)
+	assert.Equal(
+		t,
+		[]string{
+			"page",
+			"notebook",
+			"section",
+			"1.0 kB",
+			dttm.FormatToTabularDisplay(now),
+			dttm.FormatToTabularDisplay(then),
+		},
+		vs)
+}
+
+func (suite *OneNoteUnitSuite) TestOneNoteFolderEntries() {
+	t := suite.T()
+
+	rr, err := path.Build("t", "u", path.OneDriveService, path.NotebooksCategory, true, "nb", "sec", "page")
+	require.NoError(t, err, clues.ToCore(err))
+
+	loc := path.Builder{}.Append("notebook", "section")
+	deets := &details.Builder{}
+
+	err = deets.Add(
+		rr,
+		loc,
+		details.ItemInfo{
+			OneNote: &details.OneNoteInfo{
+				ItemType: details.OneNotePage,
+				ItemName: "page",
+				Modified: time.Now(),
+			},
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	ents := deets.Details().Entries
+	require.Len(t, ents, 3, "page, section and notebook entries")
+
+	for _, ent := range ents {
+		if ent.Folder == nil {
+			assert.Equal(t, "notebook/section", ent.LocationRef)
+			continue
+		}
+
+		assert.Equal(t, details.OneNotePage, ent.Folder.DataType)
+	}
+}
@@ -0,0 +1,111 @@
+package details
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// NewTeamsChatsLocationIDer builds a LocationIDer for teams chats.
+func NewTeamsChatsLocationIDer(
+	category path.CategoryType,
+	escapedFolders ...string,
+) (uniqueLoc, error) {
+	if err := path.ValidateServiceAndCategory(path.TeamsChatsService, category); err != nil {
+		return uniqueLoc{}, clues.Wrap(err, "making teams chats LocationIDer")
+	}
+
+	pb := path.Builder{}.Append(category.String()).Append(escapedFolders...)
+
+	return uniqueLoc{pb, 1}, nil
+}
+
+// TeamsChatsInfo describes a chat message in one of a user's teams chats.
+type TeamsChatsInfo struct {
+	ItemType   ItemType  `json:"itemType,omitempty"`
+	Modified   time.Time `json:"modified,omitempty"`
+	ParentPath string    `json:"parentPath,omitempty"`
+
+	Chat    ChatInfo        `json:"chat,omitempty"`
+	Message ChatMessageInfo `json:"message,omitempty"`
+}
+
+// ChatInfo describes the chat that holds the message.
+type ChatInfo struct {
+	ChatType      string    `json:"chatType,omitempty"`
+	CreatedAt     time.Time `json:"createdAt,omitempty"`
+	LastMessageAt time.Time `json:"lastMessageAt,omitempty"`
+	Members       []string  `json:"members,omitempty"`
+	Name          string    `json:"name,omitempty"`
+}
+
+type ChatMessageInfo struct {
+	AttachmentNames []string  `json:"attachmentNames,omitempty"`
+	CreatedAt       time.Time `json:"createdAt,omitempty"`
+	Creator         string    `json:"creator,omitempty"`
+	Preview         string    `json:"preview,omitempty"`
+	Size            int64     `json:"size,omitempty"`
+}
+
+// Headers returns the human-readable names of properties in a TeamsChatsInfo
+// for printing out to a terminal in a columnar display.
+func (i TeamsChatsInfo) Headers() []string {
+	switch i.ItemType {
+	case TeamsChatMessage:
+		return []string{"Message", "Chat", "Members", "Creator", "Created", "Last Message"}
+	}
+
+	return []string{}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (i TeamsChatsInfo) Values() []string {
+	switch i.ItemType {
+	case TeamsChatMessage:
+		lastMessage := dttm.FormatToTabularDisplay(i.Chat.LastMessageAt)
+		if i.Chat.LastMessageAt.IsZero() {
+			lastMessage = ""
+		}
+
+		return []string{
+			// html parsing may produce newlines, which we'll want to avoid
+			strings.ReplaceAll(i.Message.Preview, "\n", "\\n"),
+			i.ParentPath,
+			strings.Join(i.Chat.Members, ", "),
+			i.Message.Creator,
+			dttm.FormatToTabularDisplay(i.Message.CreatedAt),
+			lastMessage,
+		}
+	}
+
+	return []string{}
+}
+
+func (i *TeamsChatsInfo) UpdateParentPath(newLocPath *path.Builder) {
+	i.ParentPath = newLocPath.String()
+}
+
+func (i *TeamsChatsInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
+	if i.ItemType != TeamsChatMessage {
+		return nil, clues.New("unsupported ItemType for TeamsChatsInfo").With("item_type", i.ItemType)
+	}
+
+	loc, err := NewTeamsChatsLocationIDer(path.ChatsCategory, baseLoc.Elements()...)
+
+	return &loc, err
+}
+
+func (i *TeamsChatsInfo) updateFolder(f *FolderInfo) error {
+	f.DataType = i.ItemType
+
+	if i.ItemType == TeamsChatMessage {
+		return nil
+	}
+
+	return clues.New("unsupported ItemType for TeamsChatsInfo").With("item_type", i.ItemType)
+}
@@ -0,0 +1,92 @@
+package details_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+type TeamsChatsUnitSuite struct {
+	tester.Suite
+}
+
+func TestTeamsChatsUnitSuite(t *testing.T) {
+	suite.Run(t, &TeamsChatsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *TeamsChatsUnitSuite) TestTeamsChatsPrintable() {
+	now := time.Now()
+	then := now.Add(time.Minute)
+
+	table := []struct {
+		name     string
+		info     details.TeamsChatsInfo
+		expectHs []string
+		expectVs []string
+	}{
+		{
+			name: "chat message",
+			info: details.TeamsChatsInfo{
+				ItemType:   details.TeamsChatMessage,
+				ParentPath: "parentpath",
+				Chat: details.ChatInfo{
+					LastMessageAt: then,
+					Members:       []string{"adele", "megan"},
+				},
+				Message: details.ChatMessageInfo{
+					Preview:   "preview\nline",
+					Creator:   "creator",
+					CreatedAt: now,
+				},
+			},
+			expectHs: []string{"Message", "Chat", "Members", "Creator", "Created", "Last Message"},
+			expectVs: []string{
+				"preview\\nline",
+				"parentpath",
+				"adele, megan",
+				"creator",
+				dttm.FormatToTabularDisplay(now),
+				dttm.FormatToTabularDisplay(then),
+			},
+		},
+		{
+			name: "chat message without last message time",
+			info: details.TeamsChatsInfo{
+				ItemType:   details.TeamsChatMessage,
+				ParentPath: "parentpath",
+				Message: details.ChatMessageInfo{
+					Preview:   "preview",
+					Creator:   "creator",
+					CreatedAt: now,
+				},
+			},
+			expectHs: []string{"Message", "Chat", "Members", "Creator", "Created", "Last Message"},
+			expectVs: []string{
+				"preview",
+				"parentpath",
+				"",
+				"creator",
+				dttm.FormatToTabularDisplay(now),
+				"",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			hs := test.info.Headers()
+			vs := test.info.Values()
+
+			assert.Equal(t, len(hs), len(vs))
+			assert.Equal(t, test.expectHs, hs)
+			assert.Equal(t, test.expectVs, vs)
+		})
+	}
+}
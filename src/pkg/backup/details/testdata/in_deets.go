@@ -389,12 +389,16 @@ func GetDeetsInBackup(
 	ssid := bup.StreamStoreID
 	require.NotEmpty(t, ssid, "stream store ID")
 
-	var deets details.Details
-	err = ssr.Read(
-		ctx,
-		ssid,
-		streamstore.DetailsReader(details.UnmarshalTo(&deets)),
-		fault.New(true))
+	var (
+		deets details.Details
+		umt   = streamstore.DetailsReader(details.UnmarshalTo(&deets))
+	)
+
+	if bup.DetailsFormat == details.ChunkedFormat {
+		umt = streamstore.ChunkedDetailsReader(details.NewChunkReader(&deets, details.ChunkFilter{}))
+	}
+
+	err = ssr.Read(ctx, ssid, umt, fault.New(true))
 	require.NoError(t, err, clues.ToCore(err))
 
 	id := NewInDeets(path.Builder{}.Append(tid, service.String(), resourceOwner).String())
@@ -744,6 +744,9 @@ var (
 
 	GroupsRootPath = mustPathRep("tenant-id/groups/group-id/libraries/sites/site-id/drives/foo/root:", false, false)
 
+	GroupsConversationPath     = mustPathRep("tenant-id/groups/group-id/conversationPosts/conversation", false, false)
+	GroupsConversationPostPath = GroupsConversationPath.MustAppend(ItemName1, true)
+
 	SharePointRootPath    = mustPathRep("tenant-id/sharepoint/site-id/libraries/drives/foo/root:", false, false)
 	SharePointLibraryPath = SharePointRootPath.MustAppend("library", false)
 	SharePointListPath    = mustPathRep("tenant-id/sharepoint/site-id/lists", false, true)
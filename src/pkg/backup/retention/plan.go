@@ -0,0 +1,409 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// reasons recorded on each decision.
+const (
+	ReasonNoPolicy        = "no policy"
+	ReasonLast            = "last"
+	ReasonDaily           = "daily"
+	ReasonWeekly          = "weekly"
+	ReasonMonthly         = "monthly"
+	ReasonWithinMaxAge    = "within max age"
+	ReasonLatestMergeBase = "latest merge base"
+	ReasonLatestClean     = "latest clean backup"
+	ReasonExpired         = "older than max age"
+	ReasonNotRetained     = "not retained"
+)
+
+// Decision records whether a single backup is kept or pruned, and why.
+type Decision struct {
+	BackupID              model.StableID   `json:"backupID"`
+	Service               path.ServiceType `json:"service"`
+	ProtectedResourceID   string           `json:"protectedResourceID"`
+	ProtectedResourceName string           `json:"protectedResourceName,omitempty"`
+	CreatedAt             time.Time        `json:"createdAt"`
+	Keep                  bool             `json:"keep"`
+	Reasons               []string         `json:"reasons"`
+}
+
+// interface compliance checks
+var _ print.Printable = &Decision{}
+
+// Report is the result of applying the retention policies to the backups
+// in a repository.
+type Report struct {
+	DryRun    bool       `json:"dryRun"`
+	Decisions []Decision `json:"decisions"`
+}
+
+// Pruned returns the ids of all backups which are not retained.
+func (r Report) Pruned() []string {
+	var ids []string
+
+	for _, d := range r.Decisions {
+		if !d.Keep {
+			ids = append(ids, string(d.BackupID))
+		}
+	}
+
+	return ids
+}
+
+// Plan decides which of the backups are retained according to the
+// policies.  Policies are applied separately to each series of backups
+// that share their reasons: the service, protected resource, and set of
+// categories they include.  That way a resource backed up on different
+// schedules for different categories keeps the counts of each schedule.
+// Backups that match no policy, or an empty policy, are always kept.  Regardless of policy, the most recent merge backup of each
+// protected resource and category is kept, since incremental backups
+// depend on it.  So is the most recent merge backup that isn't marked as
+// suspect, so that a copy of the data from before an anomaly survives.
+//
+// Assist backups are expected to be filtered out by the caller; those are
+// garbage collected during repository maintenance.
+func Plan(policies []*Policy, bups []*backup.Backup, now time.Time) Report {
+	type groupKey struct {
+		service    path.ServiceType
+		pr         string
+		categories string
+	}
+
+	groups := map[groupKey][]*backup.Backup{}
+
+	for _, b := range bups {
+		k := groupKey{serviceOf(b), protectedResourceOf(b), categoryKey(b)}
+		groups[k] = append(groups[k], b)
+	}
+
+	keys := make([]groupKey, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].service != keys[j].service {
+			return keys[i].service < keys[j].service
+		}
+
+		if keys[i].pr != keys[j].pr {
+			return keys[i].pr < keys[j].pr
+		}
+
+		return keys[i].categories < keys[j].categories
+	})
+
+	r := Report{}
+
+	for _, k := range keys {
+		gbups := groups[k]
+
+		// newest first
+		sort.Slice(gbups, func(i, j int) bool {
+			ti, tj := createdAt(gbups[i]), createdAt(gbups[j])
+			if !ti.Equal(tj) {
+				return ti.After(tj)
+			}
+
+			return gbups[i].ID > gbups[j].ID
+		})
+
+		reasons := apply(Select(policies, k.service, k.pr), gbups, now)
+
+		latest := latestMergeBases(gbups, false)
+
+		for id := range latest {
+			if !keeps(reasons[id]) {
+				reasons[id] = nil
+			}
+
+			reasons[id] = append(reasons[id], ReasonLatestMergeBase)
+		}
+
+		for id := range latestMergeBases(gbups, true) {
+			if _, ok := latest[id]; ok {
+				continue
+			}
+
+			if !keeps(reasons[id]) {
+				reasons[id] = nil
+			}
+
+			reasons[id] = append(reasons[id], ReasonLatestClean)
+		}
+
+		for _, b := range gbups {
+			d := Decision{
+				BackupID:              b.ID,
+				Service:               k.service,
+				ProtectedResourceID:   k.pr,
+				ProtectedResourceName: str.First(b.ProtectedResourceName, b.ResourceOwnerName),
+				CreatedAt:             createdAt(b),
+				Reasons:               reasons[b.ID],
+			}
+
+			d.Keep = keeps(d.Reasons)
+
+			r.Decisions = append(r.Decisions, d)
+		}
+	}
+
+	return r
+}
+
+// apply runs the policy over the backups, which must be sorted newest
+// first, and returns the reasons each backup is kept or dropped.
+func apply(
+	p *Policy,
+	bups []*backup.Backup,
+	now time.Time,
+) map[model.StableID][]string {
+	reasons := make(map[model.StableID][]string, len(bups))
+
+	if p == nil || p.IsEmpty() {
+		for _, b := range bups {
+			reasons[b.ID] = []string{ReasonNoPolicy}
+		}
+
+		return reasons
+	}
+
+	buckets := []struct {
+		reason    string
+		remaining int
+		last      string
+		key       func(time.Time) string
+	}{
+		{reason: ReasonDaily, remaining: p.KeepDaily, key: dayKey},
+		{reason: ReasonWeekly, remaining: p.KeepWeekly, key: weekKey},
+		{reason: ReasonMonthly, remaining: p.KeepMonthly, key: monthKey},
+	}
+
+	for i, b := range bups {
+		t := createdAt(b).UTC()
+
+		if p.MaxAge > 0 && now.Sub(t) > p.MaxAge {
+			reasons[b.ID] = []string{ReasonExpired}
+			continue
+		}
+
+		if !p.hasCounts() {
+			reasons[b.ID] = []string{ReasonWithinMaxAge}
+			continue
+		}
+
+		var rs []string
+
+		if i < p.KeepLast {
+			rs = append(rs, ReasonLast)
+		}
+
+		for j := range buckets {
+			bk := &buckets[j]
+
+			if bk.remaining == 0 {
+				continue
+			}
+
+			// the newest backup in each period is the one retained.
+			if k := bk.key(t); k != bk.last {
+				bk.last = k
+				bk.remaining--
+
+				rs = append(rs, bk.reason)
+			}
+		}
+
+		if len(rs) == 0 {
+			rs = []string{ReasonNotRetained}
+		}
+
+		reasons[b.ID] = rs
+	}
+
+	return reasons
+}
+
+// latestMergeBases returns the ids of the newest merge backup for each
+// category in the backups, which must be sorted newest first.  If
+// cleanOnly is true, backups marked as suspect are skipped.
+func latestMergeBases(
+	bups []*backup.Backup,
+	cleanOnly bool,
+) map[model.StableID]struct{} {
+	var (
+		seen = map[path.CategoryType]struct{}{}
+		ids  = map[model.StableID]struct{}{}
+	)
+
+	for _, b := range bups {
+		if b.Type() != model.MergeBackup || (cleanOnly && b.Suspect) {
+			continue
+		}
+
+		for _, cat := range categoriesOf(b) {
+			if _, ok := seen[cat]; ok {
+				continue
+			}
+
+			seen[cat] = struct{}{}
+			ids[b.ID] = struct{}{}
+		}
+	}
+
+	return ids
+}
+
+func keeps(reasons []string) bool {
+	for _, r := range reasons {
+		switch r {
+		case ReasonExpired, ReasonNotRetained:
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+// ---------------------------------------------------------------------------
+// backup accessors
+// ---------------------------------------------------------------------------
+
+func serviceOf(b *backup.Backup) path.ServiceType {
+	if s := b.Selector.PathService(); s != path.UnknownService {
+		return s
+	}
+
+	return path.ToServiceType(b.Tags[model.ServiceTag])
+}
+
+func protectedResourceOf(b *backup.Backup) string {
+	return str.First(b.ProtectedResourceID, b.ResourceOwnerID, b.Selector.DiscreteOwner)
+}
+
+// categoriesOf returns the categories included in the backup.  Backups
+// whose selector can't be interpreted are treated as a single, unknown
+// category.
+func categoriesOf(b *backup.Backup) []path.CategoryType {
+	pcs, err := b.Selector.PathCategories()
+	if err != nil || len(pcs.Includes) == 0 {
+		return []path.CategoryType{path.UnknownCategory}
+	}
+
+	return pcs.Includes
+}
+
+// categoryKey identifies the set of categories included in the backup,
+// regardless of the order their scopes were added to the selector.
+func categoryKey(b *backup.Backup) string {
+	cats := categoriesOf(b)
+	names := make([]string, 0, len(cats))
+
+	for _, cat := range cats {
+		names = append(names, cat.String())
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
+
+func createdAt(b *backup.Backup) time.Time {
+	if !b.CreationTime.IsZero() {
+		return b.CreationTime
+	}
+
+	return b.StartedAt
+}
+
+func dayKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func weekKey(t time.Time) string {
+	y, w := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", y, w)
+}
+
+func monthKey(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// --------------------------------------------------------------------------------
+// CLI Output
+// --------------------------------------------------------------------------------
+
+// Print writes the Report to StdOut, in the format requested by the caller.
+func (r Report) Print(ctx context.Context) {
+	if len(r.Decisions) == 0 {
+		print.Info(ctx, "No backups available")
+		return
+	}
+
+	ps := []print.Printable{}
+	for _, d := range r.Decisions {
+		ps = append(ps, print.Printable(d))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the Decision to its minimally printable details.
+func (d Decision) MinimumPrintable() any {
+	return d
+}
+
+// Headers returns the human-readable names of properties in a Decision
+// for printing out to a terminal in a columnar display.
+func (d Decision) Headers(skipID bool) []string {
+	headers := []string{
+		"Created At",
+		"Service",
+		"Resource Owner",
+		"Action",
+		"Reason",
+	}
+
+	if skipID {
+		return headers
+	}
+
+	return append([]string{"ID"}, headers...)
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (d Decision) Values(skipID bool) []string {
+	action := "prune"
+	if d.Keep {
+		action = "keep"
+	}
+
+	values := []string{
+		dttm.FormatToTabularDisplay(d.CreatedAt),
+		d.Service.HumanString(),
+		str.First(d.ProtectedResourceName, d.ProtectedResourceID),
+		action,
+		strings.Join(d.Reasons, ", "),
+	}
+
+	if skipID {
+		return values
+	}
+
+	return append([]string{string(d.BackupID)}, values...)
+}
@@ -0,0 +1,334 @@
+package retention
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// a sunday, which closes out ISO week 13.
+var now = time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+func makeBackup(
+	id, owner string,
+	created time.Time,
+	backupType string,
+	cats ...path.CategoryType,
+) *backup.Backup {
+	sel := selectors.NewExchangeBackup([]string{owner})
+
+	for _, cat := range cats {
+		switch cat {
+		case path.EmailCategory:
+			sel.Include(sel.MailFolders(selectors.Any()))
+		case path.ContactsCategory:
+			sel.Include(sel.ContactFolders(selectors.Any()))
+		case path.EventsCategory:
+			sel.Include(sel.EventCalendars(selectors.Any()))
+		}
+	}
+
+	return &backup.Backup{
+		BaseModel: model.BaseModel{
+			ID: model.StableID(id),
+			Tags: map[string]string{
+				model.ServiceTag:    path.ExchangeService.String(),
+				model.BackupTypeTag: backupType,
+			},
+		},
+		CreationTime:        created,
+		Selector:            sel.Selector,
+		ProtectedResourceID: owner,
+	}
+}
+
+func decisionsByID(r Report) map[model.StableID]Decision {
+	res := map[model.StableID]Decision{}
+
+	for _, d := range r.Decisions {
+		res[d.BackupID] = d
+	}
+
+	return res
+}
+
+func kept(r Report) []model.StableID {
+	var ids []model.StableID
+
+	for _, d := range r.Decisions {
+		if d.Keep {
+			ids = append(ids, d.BackupID)
+		}
+	}
+
+	return ids
+}
+
+func (suite *PlanUnitSuite) TestPlan_noPolicy() {
+	t := suite.T()
+
+	bups := []*backup.Backup{
+		makeBackup("b1", "u1", now.Add(-48*time.Hour), model.MergeBackup, path.EmailCategory),
+		makeBackup("b2", "u1", now, model.MergeBackup, path.EmailCategory),
+	}
+
+	r := Plan(
+		[]*Policy{{Service: path.OneDriveService, KeepLast: 1}},
+		bups,
+		now)
+
+	require.Len(t, r.Decisions, 2)
+	assert.Empty(t, r.Pruned())
+
+	// newest first
+	assert.Equal(t, model.StableID("b2"), r.Decisions[0].BackupID)
+	assert.Equal(t, []string{ReasonNoPolicy, ReasonLatestMergeBase}, r.Decisions[0].Reasons)
+	assert.Equal(t, []string{ReasonNoPolicy}, r.Decisions[1].Reasons)
+}
+
+func (suite *PlanUnitSuite) TestPlan_keepLast() {
+	t := suite.T()
+
+	var bups []*backup.Backup
+
+	for i, id := range []string{"b0", "b1", "b2", "b3", "b4"} {
+		bups = append(
+			bups,
+			makeBackup(id, "u1", now.Add(-time.Duration(i)*time.Hour), model.MergeBackup, path.EmailCategory))
+	}
+
+	r := Plan([]*Policy{{KeepLast: 2}}, bups, now)
+
+	assert.Equal(t, []model.StableID{"b0", "b1"}, kept(r))
+	assert.ElementsMatch(t, []string{"b2", "b3", "b4"}, r.Pruned())
+
+	ds := decisionsByID(r)
+	assert.Equal(t, []string{ReasonLast, ReasonLatestMergeBase}, ds["b0"].Reasons)
+	assert.Equal(t, []string{ReasonLast}, ds["b1"].Reasons)
+	assert.Equal(t, []string{ReasonNotRetained}, ds["b4"].Reasons)
+}
+
+func (suite *PlanUnitSuite) TestPlan_grandfatherFatherSon() {
+	t := suite.T()
+
+	var bups []*backup.Backup
+
+	// two backups a day, going back 100 days.
+	for i := 0; i < 200; i++ {
+		bups = append(
+			bups,
+			makeBackup(
+				"b"+strconv.Itoa(i),
+				"u1",
+				now.Add(-time.Duration(i)*12*time.Hour),
+				model.MergeBackup,
+				path.EmailCategory))
+	}
+
+	policy := &Policy{
+		KeepDaily:   7,
+		KeepWeekly:  4,
+		KeepMonthly: 3,
+	}
+
+	r := Plan([]*Policy{policy}, bups, now)
+
+	expect := []model.StableID{}
+
+	// daily: the noon backups from the 31st back to the 25th.
+	// weekly: the 24th, 17th and 10th, which close out weeks 12, 11, and 10.
+	// monthly: the last backups in february and january.
+	for _, i := range []int{0, 2, 4, 6, 8, 10, 12, 14, 28, 42, 62, 120} {
+		expect = append(expect, bups[i].ID)
+	}
+
+	assert.Equal(t, expect, kept(r))
+	assert.Len(t, r.Pruned(), 200-len(expect))
+
+	ds := decisionsByID(r)
+	assert.Equal(
+		t,
+		[]string{ReasonDaily, ReasonWeekly, ReasonMonthly, ReasonLatestMergeBase},
+		ds[bups[0].ID].Reasons)
+	assert.Equal(t, []string{ReasonWeekly}, ds[bups[14].ID].Reasons)
+	assert.Equal(t, []string{ReasonMonthly}, ds[bups[62].ID].Reasons)
+}
+
+func (suite *PlanUnitSuite) TestPlan_maxAge() {
+	var bups []*backup.Backup
+
+	for i, id := range []string{"b0", "b1", "b2", "b3", "b4"} {
+		bups = append(
+			bups,
+			makeBackup(id, "u1", now.Add(-time.Duration(i)*24*time.Hour), model.MergeBackup, path.EmailCategory))
+	}
+
+	table := []struct {
+		name   string
+		policy *Policy
+		expect []model.StableID
+	}{
+		{
+			name:   "max age only",
+			policy: &Policy{MaxAge: 48 * time.Hour},
+			expect: []model.StableID{"b0", "b1", "b2"},
+		},
+		{
+			name:   "max age overrides counts",
+			policy: &Policy{KeepLast: 10, MaxAge: 24 * time.Hour},
+			expect: []model.StableID{"b0", "b1"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			r := Plan([]*Policy{test.policy}, bups, now)
+			assert.Equal(t, test.expect, kept(r))
+			assert.Equal(t, []string{ReasonExpired}, decisionsByID(r)["b4"].Reasons)
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestPlan_keepsLatestMergeBase() {
+	t := suite.T()
+
+	bups := []*backup.Backup{
+		makeBackup("preview", "u1", now, model.PreviewBackup, path.EmailCategory),
+		makeBackup("mail", "u1", now.Add(-24*time.Hour), model.MergeBackup, path.EmailCategory),
+		makeBackup("contacts", "u1", now.Add(-48*time.Hour), model.MergeBackup, path.ContactsCategory),
+		makeBackup("old-mail", "u1", now.Add(-72*time.Hour), model.MergeBackup, path.EmailCategory),
+	}
+
+	r := Plan([]*Policy{{KeepLast: 1}}, bups, now)
+
+	assert.ElementsMatch(t, []model.StableID{"preview", "mail", "contacts"}, kept(r))
+	assert.Equal(t, []string{"old-mail"}, r.Pruned())
+
+	ds := decisionsByID(r)
+	assert.Equal(t, []string{ReasonLast}, ds["preview"].Reasons)
+	assert.Equal(t, []string{ReasonLatestMergeBase}, ds["mail"].Reasons)
+	assert.Equal(t, []string{ReasonLast, ReasonLatestMergeBase}, ds["contacts"].Reasons)
+}
+
+func (suite *PlanUnitSuite) TestPlan_groupsByCategories() {
+	t := suite.T()
+
+	var bups []*backup.Backup
+
+	// mail is backed up daily, while contacts and events are backed up
+	// together every hour.
+	for i := 0; i < 3; i++ {
+		bups = append(
+			bups,
+			makeBackup(
+				"mail-"+strconv.Itoa(i),
+				"u1",
+				now.Add(-time.Duration(i)*24*time.Hour),
+				model.MergeBackup,
+				path.EmailCategory))
+	}
+
+	for i := 0; i < 3; i++ {
+		cats := []path.CategoryType{path.ContactsCategory, path.EventsCategory}
+
+		// the order of the scopes doesn't change the series.
+		if i%2 == 1 {
+			cats = []path.CategoryType{path.EventsCategory, path.ContactsCategory}
+		}
+
+		bups = append(
+			bups,
+			makeBackup(
+				"pim-"+strconv.Itoa(i),
+				"u1",
+				now.Add(-time.Duration(i)*time.Hour),
+				model.MergeBackup,
+				cats...))
+	}
+
+	r := Plan([]*Policy{{KeepLast: 2}}, bups, now)
+
+	assert.ElementsMatch(
+		t,
+		[]model.StableID{"mail-0", "mail-1", "pim-0", "pim-1"},
+		kept(r),
+		"each series keeps its own last backups")
+	assert.ElementsMatch(t, []string{"mail-2", "pim-2"}, r.Pruned())
+
+	ds := decisionsByID(r)
+	assert.Equal(t, []string{ReasonLast}, ds["mail-1"].Reasons)
+	assert.Equal(t, []string{ReasonLast, ReasonLatestMergeBase}, ds["pim-0"].Reasons)
+}
+
+func (suite *PlanUnitSuite) TestPlan_keepsLatestClean() {
+	t := suite.T()
+
+	bups := []*backup.Backup{
+		makeBackup("suspect", "u1", now, model.MergeBackup, path.EmailCategory),
+		makeBackup("also-suspect", "u1", now.Add(-24*time.Hour), model.MergeBackup, path.EmailCategory),
+		makeBackup("clean", "u1", now.Add(-48*time.Hour), model.MergeBackup, path.EmailCategory),
+		makeBackup("old-clean", "u1", now.Add(-72*time.Hour), model.MergeBackup, path.EmailCategory),
+	}
+
+	bups[0].Suspect = true
+	bups[1].Suspect = true
+
+	r := Plan([]*Policy{{KeepLast: 1}}, bups, now)
+
+	assert.Equal(t, []model.StableID{"suspect", "clean"}, kept(r))
+	assert.ElementsMatch(t, []string{"also-suspect", "old-clean"}, r.Pruned())
+
+	ds := decisionsByID(r)
+	assert.Equal(t, []string{ReasonLast, ReasonLatestMergeBase}, ds["suspect"].Reasons)
+	assert.Equal(t, []string{ReasonLatestClean}, ds["clean"].Reasons)
+}
+
+func (suite *PlanUnitSuite) TestPlan_scopedPolicies() {
+	t := suite.T()
+
+	var bups []*backup.Backup
+
+	for _, owner := range []string{"u1", "u2"} {
+		for i := 0; i < 4; i++ {
+			bups = append(
+				bups,
+				makeBackup(
+					owner+"-"+strconv.Itoa(i),
+					owner,
+					now.Add(-time.Duration(i)*time.Hour),
+					model.MergeBackup,
+					path.EmailCategory))
+		}
+	}
+
+	policies := []*Policy{
+		{KeepLast: 3},
+		{Service: path.ExchangeService, ProtectedResourceID: "u2", KeepLast: 1},
+	}
+
+	r := Plan(policies, bups, now)
+
+	assert.Equal(
+		t,
+		[]model.StableID{"u1-0", "u1-1", "u1-2", "u2-0"},
+		kept(r))
+}
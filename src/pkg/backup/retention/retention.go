@@ -0,0 +1,204 @@
+package retention
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const anyScope = "*"
+
+// Policy describes which backups are kept when the repository is pruned.
+// Policies are scoped to a service, a protected resource, both, or
+// neither.  When more than one policy matches a backup, the most specific
+// one is applied.
+//
+// The keep counts follow the grandfather-father-son pattern: a backup is
+// retained if it is one of the KeepLast most recent backups, or if it is
+// the most recent backup within one of the KeepDaily most recent days
+// (or weeks, or months) that contain a backup.  MaxAge overrides the
+// counts; backups older than it are always dropped.
+type Policy struct {
+	model.BaseModel
+
+	// Service limits the policy to backups of that service.  The
+	// UnknownService matches all services.
+	Service path.ServiceType `json:"service"`
+	// ProtectedResourceID limits the policy to backups of that resource.
+	// An empty value matches all resources.
+	ProtectedResourceID string `json:"protectedResourceID,omitempty"`
+
+	KeepLast    int `json:"keepLast"`
+	KeepDaily   int `json:"keepDaily"`
+	KeepWeekly  int `json:"keepWeekly"`
+	KeepMonthly int `json:"keepMonthly"`
+
+	// MaxAge drops any backup older than the duration.  Zero disables
+	// the age limit.
+	MaxAge time.Duration `json:"maxAge"`
+}
+
+// interface compliance checks
+var _ print.Printable = &Policy{}
+
+// PolicyID produces the stable id of the policy with the given scope.
+// There can only be one policy per scope.
+func PolicyID(service path.ServiceType, protectedResourceID string) model.StableID {
+	svc := anyScope
+	if service != path.UnknownService {
+		svc = service.String()
+	}
+
+	pr := anyScope
+	if len(protectedResourceID) > 0 {
+		pr = protectedResourceID
+	}
+
+	return model.StableID("retention-" + svc + "-" + pr)
+}
+
+// Validate ensures the policy holds sensible values.
+func (p Policy) Validate() error {
+	if p.KeepLast < 0 || p.KeepDaily < 0 || p.KeepWeekly < 0 || p.KeepMonthly < 0 {
+		return clues.New("retention counts cannot be negative")
+	}
+
+	if p.MaxAge < 0 {
+		return clues.New("retention max age cannot be negative")
+	}
+
+	return nil
+}
+
+// IsEmpty is true if the policy retains every backup.
+func (p Policy) IsEmpty() bool {
+	return !p.hasCounts() && p.MaxAge == 0
+}
+
+func (p Policy) hasCounts() bool {
+	return p.KeepLast+p.KeepDaily+p.KeepWeekly+p.KeepMonthly > 0
+}
+
+// specificity scores how closely the policy matches the service and
+// resource.  Negative values mean the policy doesn't apply at all.
+func (p Policy) specificity(service path.ServiceType, protectedResourceID string) int {
+	score := 0
+
+	switch p.Service {
+	case path.UnknownService:
+	case service:
+		score++
+	default:
+		return -1
+	}
+
+	switch p.ProtectedResourceID {
+	case "":
+	case protectedResourceID:
+		score += 2
+	default:
+		return -1
+	}
+
+	return score
+}
+
+// Select returns the most specific policy matching the service and
+// resource, or nil if none of the policies apply.
+func Select(
+	policies []*Policy,
+	service path.ServiceType,
+	protectedResourceID string,
+) *Policy {
+	var (
+		best      *Policy
+		bestScore = -1
+	)
+
+	for _, p := range policies {
+		if s := p.specificity(service, protectedResourceID); s > bestScore {
+			best, bestScore = p, s
+		}
+	}
+
+	return best
+}
+
+// --------------------------------------------------------------------------------
+// CLI Output
+// --------------------------------------------------------------------------------
+
+// Print writes the Policy to StdOut, in the format requested by the caller.
+func (p Policy) Print(ctx context.Context) {
+	print.Item(ctx, p)
+}
+
+// PrintAll writes the slice of Policies to StdOut, in the format requested
+// by the caller.
+func PrintAll(ctx context.Context, ps []*Policy) {
+	if len(ps) == 0 {
+		print.Info(ctx, "No retention policies set")
+		return
+	}
+
+	pps := []print.Printable{}
+	for _, p := range ps {
+		pps = append(pps, print.Printable(p))
+	}
+
+	print.All(ctx, pps...)
+}
+
+// MinimumPrintable reduces the Policy to its minimally printable details.
+func (p Policy) MinimumPrintable() any {
+	return p
+}
+
+// Headers returns the human-readable names of properties in a Policy
+// for printing out to a terminal in a columnar display.
+func (p Policy) Headers(skipID bool) []string {
+	return []string{
+		"Service",
+		"Protected Resource",
+		"Keep Last",
+		"Keep Daily",
+		"Keep Weekly",
+		"Keep Monthly",
+		"Max Age",
+	}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (p Policy) Values(skipID bool) []string {
+	svc := anyScope
+	if p.Service != path.UnknownService {
+		svc = p.Service.HumanString()
+	}
+
+	pr := anyScope
+	if len(p.ProtectedResourceID) > 0 {
+		pr = p.ProtectedResourceID
+	}
+
+	maxAge := ""
+	if p.MaxAge > 0 {
+		maxAge = p.MaxAge.String()
+	}
+
+	return []string{
+		svc,
+		pr,
+		strconv.Itoa(p.KeepLast),
+		strconv.Itoa(p.KeepDaily),
+		strconv.Itoa(p.KeepWeekly),
+		strconv.Itoa(p.KeepMonthly),
+		maxAge,
+	}
+}
@@ -0,0 +1,135 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type RetentionUnitSuite struct {
+	tester.Suite
+}
+
+func TestRetentionUnitSuite(t *testing.T) {
+	suite.Run(t, &RetentionUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RetentionUnitSuite) TestPolicyID() {
+	t := suite.T()
+
+	assert.Equal(t, model.StableID("retention-*-*"), PolicyID(path.UnknownService, ""))
+	assert.Equal(t, model.StableID("retention-exchange-*"), PolicyID(path.ExchangeService, ""))
+	assert.Equal(t, model.StableID("retention-*-u1"), PolicyID(path.UnknownService, "u1"))
+	assert.Equal(t, model.StableID("retention-exchange-u1"), PolicyID(path.ExchangeService, "u1"))
+}
+
+func (suite *RetentionUnitSuite) TestPolicy_Validate() {
+	table := []struct {
+		name      string
+		policy    Policy
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "empty",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "valid",
+			policy: Policy{
+				KeepLast:    1,
+				KeepDaily:   7,
+				KeepWeekly:  4,
+				KeepMonthly: 12,
+				MaxAge:      time.Hour,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "negative count",
+			policy:    Policy{KeepWeekly: -1},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "negative max age",
+			policy:    Policy{MaxAge: -time.Hour},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.policy.Validate()
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *RetentionUnitSuite) TestSelect() {
+	var (
+		all        = &Policy{KeepLast: 1}
+		exchange   = &Policy{Service: path.ExchangeService, KeepLast: 2}
+		user       = &Policy{ProtectedResourceID: "u1", KeepLast: 3}
+		userOnExch = &Policy{Service: path.ExchangeService, ProtectedResourceID: "u1", KeepLast: 4}
+		policies   = []*Policy{userOnExch, user, exchange, all}
+	)
+
+	table := []struct {
+		name     string
+		policies []*Policy
+		service  path.ServiceType
+		resource string
+		expect   *Policy
+	}{
+		{
+			name:     "no policies",
+			service:  path.ExchangeService,
+			resource: "u1",
+		},
+		{
+			name:     "no match",
+			policies: []*Policy{exchange},
+			service:  path.OneDriveService,
+			resource: "u1",
+		},
+		{
+			name:     "service and resource",
+			policies: policies,
+			service:  path.ExchangeService,
+			resource: "u1",
+			expect:   userOnExch,
+		},
+		{
+			name:     "resource",
+			policies: policies,
+			service:  path.OneDriveService,
+			resource: "u1",
+			expect:   user,
+		},
+		{
+			name:     "service",
+			policies: policies,
+			service:  path.ExchangeService,
+			resource: "u2",
+			expect:   exchange,
+		},
+		{
+			name:     "default",
+			policies: policies,
+			service:  path.OneDriveService,
+			resource: "u2",
+			expect:   all,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			result := Select(test.policies, test.service, test.resource)
+			assert.Equal(suite.T(), test.expect, result)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package search
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+// maxContentBytes is the amount of each item's content that gets
+// tokenized.  Anything past it is stored, but not searchable.
+const maxContentBytes = 1024 * 1024
+
+// Index is an inverted index of the terms found in a range of a backup's
+// items.  Each shard of a backup's search index is persisted as an Index.
+type Index struct {
+	// RepoRefs of the indexed items.
+	Items []string `json:"items"`
+	// Terms maps each term to the positions, within Items, of the items
+	// that hold it.
+	Terms map[string][]int `json:"terms"`
+}
+
+// Search returns the RepoRefs of the items that hold every term of the
+// query.  A term ending in `*` matches any term with that prefix.  The
+// RepoRefs are sorted.
+func (idx *Index) Search(query string) []string {
+	var matched map[int]struct{}
+
+	for _, qt := range strings.Fields(query) {
+		prefix := strings.HasSuffix(qt, "*")
+
+		terms := Tokenize(strings.TrimRight(qt, "*"))
+		if len(terms) == 0 {
+			continue
+		}
+
+		for _, t := range terms {
+			hits := idx.lookup(t, prefix)
+
+			if matched == nil {
+				matched = hits
+				continue
+			}
+
+			for i := range matched {
+				if _, ok := hits[i]; !ok {
+					delete(matched, i)
+				}
+			}
+		}
+	}
+
+	refs := make([]string, 0, len(matched))
+
+	for i := range matched {
+		refs = append(refs, idx.Items[i])
+	}
+
+	slices.Sort(refs)
+
+	return refs
+}
+
+func (idx *Index) lookup(term string, prefix bool) map[int]struct{} {
+	hits := map[int]struct{}{}
+
+	if !prefix {
+		for _, i := range idx.Terms[term] {
+			hits[i] = struct{}{}
+		}
+
+		return hits
+	}
+
+	for t, is := range idx.Terms {
+		if !strings.HasPrefix(t, term) {
+			continue
+		}
+
+		for _, i := range is {
+			hits[i] = struct{}{}
+		}
+	}
+
+	return hits
+}
+
+// ---------------------------------------------------------------------------
+// builder
+// ---------------------------------------------------------------------------
+
+// Builder gathers the terms found in item content during a backup.  It's
+// safe for concurrent use.
+type Builder struct {
+	mu      sync.Mutex
+	content map[string]map[string]struct{}
+	// the items carried over from a base backup, by their RepoRef in this
+	// backup.  Dropped once the base's index is read.
+	carried map[string]carriedItem
+}
+
+type carriedItem struct {
+	baseID  string
+	repoRef string
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		content: map[string]map[string]struct{}{},
+		carried: map[string]carriedItem{},
+	}
+}
+
+// Carry records that the item at newRepoRef is carried over from the item
+// at baseRepoRef in the base backup, such as when it's merged from the
+// base's details or cached by kopia.  Its content doesn't get read during
+// this backup, so it's indexed by the terms it held in the base's index,
+// once those are read with a BaseReader.
+func (b *Builder) Carry(baseID, baseRepoRef, newRepoRef string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.carried[newRepoRef] = carriedItem{baseID: baseID, repoRef: baseRepoRef}
+}
+
+// Reader wraps the item's reader.  The text read through it is tokenized
+// once the reader reaches the end of the item.  Binary content, such as
+// images, PDFs, or Office documents, isn't indexed, and neither is any
+// content past the first MiB.  Those items can still be found by the
+// values of their details entry.
+func (b *Builder) Reader(repoRef string, rc io.ReadCloser) io.ReadCloser {
+	return &contentReader{
+		ReadCloser: rc,
+		b:          b,
+		repoRef:    repoRef,
+	}
+}
+
+func (b *Builder) addContent(repoRef string, bs []byte) {
+	if len(bs) == 0 || !isText(bs) {
+		return
+	}
+
+	terms := map[string]struct{}{}
+	tokenize(string(bs), terms)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.content[repoRef] = terms
+}
+
+// index produces the Index of the entries.  Each item is indexed by the
+// terms of its content, as read during this backup or carried over from
+// the base's index, and by the values of its details entry, such as its
+// name, subject, or sender.  The base's terms also include those of the
+// item's details values at the time of the base.
+func (b *Builder) index(entries []*details.Entry) *Index {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	idx := &Index{Terms: map[string][]int{}}
+
+	for _, de := range entries {
+		terms := map[string]struct{}{}
+
+		for _, v := range de.Values(true) {
+			tokenize(v, terms)
+		}
+
+		for t := range b.content[de.RepoRef] {
+			terms[t] = struct{}{}
+		}
+
+		if len(terms) == 0 {
+			continue
+		}
+
+		pos := len(idx.Items)
+		idx.Items = append(idx.Items, de.RepoRef)
+
+		for t := range terms {
+			idx.Terms[t] = append(idx.Terms[t], pos)
+		}
+	}
+
+	return idx
+}
+
+// contentReader captures the leading content of an item as it gets read.
+type contentReader struct {
+	io.ReadCloser
+	b       *Builder
+	repoRef string
+	buf     []byte
+	done    bool
+}
+
+func (cr *contentReader) Read(p []byte) (int, error) {
+	n, err := cr.ReadCloser.Read(p)
+
+	if n > 0 && len(cr.buf) < maxContentBytes {
+		cr.buf = append(cr.buf, p[:min(n, maxContentBytes-len(cr.buf))]...)
+	}
+
+	if err == io.EOF && !cr.done {
+		cr.done = true
+		cr.b.addContent(cr.repoRef, cr.buf)
+		cr.buf = nil
+	}
+
+	return n, err
+}
@@ -0,0 +1,137 @@
+// Package search indexes the text of backed up items, so that items can
+// be found by their content across backups and protected resources.
+package search
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// Query describes a search across the indexed backups of a repository.
+type Query struct {
+	// Text holds the terms to look for.  Items must contain every term.
+	// Terms ending in `*` match by prefix.
+	Text string
+	// Service limits the search to backups of the service.  The
+	// UnknownService searches all services.
+	Service path.ServiceType
+	// ProtectedResources limits the search to backups of the resources,
+	// by ID or name.  An empty set searches all resources.
+	ProtectedResources []string
+	// BackupIDs limits the search to the backups.  An empty set searches
+	// all backups.
+	BackupIDs []string
+}
+
+// Validate ensures the query has something to search for.
+func (q Query) Validate() error {
+	if len(Tokenize(q.Text)) == 0 {
+		return clues.New("search requires at least one term of two or more letters or digits")
+	}
+
+	return nil
+}
+
+// MatchesResource is true if the query includes backups of the resource.
+func (q Query) MatchesResource(id, name string) bool {
+	if len(q.ProtectedResources) == 0 {
+		return true
+	}
+
+	for _, pr := range q.ProtectedResources {
+		if strings.EqualFold(pr, id) || strings.EqualFold(pr, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Result is a details entry that matched a search query, along with
+// the backup that holds it.  The backup ID and the entry's ShortRef are
+// enough to select the item for a restore or export.
+type Result struct {
+	BackupID              string           `json:"backupID"`
+	BackupCreatedAt       time.Time        `json:"backupCreatedAt"`
+	Service               path.ServiceType `json:"service"`
+	ProtectedResourceID   string           `json:"protectedResourceID"`
+	ProtectedResourceName string           `json:"protectedResourceName"`
+	Entry                 details.Entry    `json:"entry"`
+}
+
+// interface compliance checks
+var _ print.Printable = &Result{}
+
+// PrintAll writes the results to stdout, in the requested output format.
+func PrintAll(ctx context.Context, rs []Result) {
+	if len(rs) == 0 {
+		print.Info(ctx, "No matching items")
+		return
+	}
+
+	ps := []print.Printable{}
+	for _, r := range rs {
+		ps = append(ps, print.Printable(r))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the Result to its minimally printable details.
+func (r Result) MinimumPrintable() any {
+	return r
+}
+
+// Headers returns the human-readable names of properties in a Result
+// for printing out to a terminal in a columnar display.
+func (r Result) Headers(skipID bool) []string {
+	hs := []string{
+		"Backup ID",
+		"Service",
+		"Protected Resource",
+		"Location",
+		"Modified",
+	}
+
+	if skipID {
+		return hs
+	}
+
+	return append([]string{"ID"}, hs...)
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (r Result) Values(skipID bool) []string {
+	pr := r.ProtectedResourceName
+	if len(pr) == 0 {
+		pr = r.ProtectedResourceID
+	}
+
+	var mod string
+	if m := r.Entry.Modified(); !m.IsZero() {
+		mod = dttm.FormatToTabularDisplay(m)
+	}
+
+	vs := []string{
+		r.BackupID,
+		r.Service.HumanString(),
+		pr,
+		r.Entry.LocationRef,
+		mod,
+	}
+
+	if skipID {
+		return vs
+	}
+
+	return append([]string{r.Entry.ShortRef}, vs...)
+}
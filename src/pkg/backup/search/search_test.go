@@ -0,0 +1,310 @@
+package search
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type SearchUnitSuite struct {
+	tester.Suite
+}
+
+func TestSearchUnitSuite(t *testing.T) {
+	suite.Run(t, &SearchUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SearchUnitSuite) TestTokenize() {
+	table := []struct {
+		name   string
+		text   string
+		expect []string
+	}{
+		{
+			name:   "empty",
+			text:   "",
+			expect: []string{},
+		},
+		{
+			name:   "words",
+			text:   "Project X: the Budget, the plan.",
+			expect: []string{"project", "the", "budget", "plan"},
+		},
+		{
+			name:   "markup",
+			text:   `<div class="body"><p>Quarterly&nbsp;report</p></div>`,
+			expect: []string{"quarterly", "nbsp", "report"},
+		},
+		{
+			name:   "json escapes",
+			text:   `{"content":"<b>Bold</b> text\nnext"}`,
+			expect: []string{"content", "bold", "text", "next"},
+		},
+		{
+			name:   "unicode",
+			text:   "Grüße aus Köln 2024",
+			expect: []string{"grüße", "aus", "köln", "2024"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.ElementsMatch(suite.T(), test.expect, Tokenize(test.text))
+		})
+	}
+}
+
+func (suite *SearchUnitSuite) TestIsText() {
+	table := []struct {
+		name   string
+		bs     []byte
+		expect assert.BoolAssertionFunc
+	}{
+		{"text", []byte("hello world"), assert.True},
+		{"nul bytes", []byte("PK\x03\x04\x00\x00"), assert.False},
+		{"invalid utf8", []byte{0xff, 0xfe, 0xfd, 0xfc, 'a'}, assert.False},
+		{"split rune", append(bytes.Repeat([]byte("a"), sniffLen-1), []byte("é")...), assert.True},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), isText(test.bs))
+		})
+	}
+}
+
+func indexTestEntry(repoRef, subject string) details.Entry {
+	return details.Entry{
+		RepoRef:  repoRef,
+		ShortRef: repoRef,
+		ItemInfo: details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType: details.ExchangeMail,
+				Subject:  subject,
+				Modified: time.Now(),
+			},
+		},
+	}
+}
+
+// readShards passes the index and shards of the writer to the reader, and
+// returns the names of the shards that got read.
+func readShards(
+	t *testing.T,
+	sw *ShardWriter,
+	r interface {
+		UnmarshalIndex(rc io.ReadCloser) ([]string, error)
+		UnmarshalChunk(name string, rc io.ReadCloser) error
+	},
+) []string {
+	bs, err := sw.MarshalIndex()
+	require.NoError(t, err, clues.ToCore(err))
+
+	names, err := r.UnmarshalIndex(io.NopCloser(bytes.NewReader(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+
+	for _, name := range names {
+		bs, err := sw.MarshalChunk(name)
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = r.UnmarshalChunk(name, io.NopCloser(bytes.NewReader(bs)))
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	return names
+}
+
+func readAll(t *testing.T, rc io.ReadCloser) {
+	_, err := io.Copy(io.Discard, rc)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NoError(t, rc.Close())
+}
+
+func (suite *SearchUnitSuite) TestBuilder() {
+	t := suite.T()
+
+	var (
+		b     = NewBuilder()
+		deets = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{
+					indexTestEntry("tid/exchange/uid/email/inbox/m1", "Quarterly report"),
+					indexTestEntry("tid/exchange/uid/email/inbox/m2", "Lunch"),
+					indexTestEntry("tid/exchange/uid/email/inbox/m3", "Binary attachment"),
+					indexTestEntry("tid/exchange/uid/email/inbox/m4", "Carried over"),
+				},
+			},
+		}
+	)
+
+	readAll(t, b.Reader(
+		"tid/exchange/uid/email/inbox/m1",
+		io.NopCloser(bytes.NewBufferString("<p>Numbers for Project Falcon</p>"))))
+	readAll(t, b.Reader(
+		"tid/exchange/uid/email/inbox/m2",
+		io.NopCloser(bytes.NewBufferString("Falcon team lunch on friday"))))
+	readAll(t, b.Reader(
+		"tid/exchange/uid/email/inbox/m3",
+		io.NopCloser(bytes.NewReader([]byte("falcon\x00\x01\x02")))))
+
+	// a small shard size spreads the items across shards.
+	sw := b.Shards(deets, 2)
+	assert.Equal(t, []string{"shard_000000", "shard_000001"}, sw.ChunkNames())
+
+	table := []struct {
+		name   string
+		query  string
+		expect []string
+	}{
+		{
+			name:  "content term",
+			query: "falcon",
+			expect: []string{
+				"tid/exchange/uid/email/inbox/m1",
+				"tid/exchange/uid/email/inbox/m2",
+			},
+		},
+		{
+			name:   "all terms must match",
+			query:  "Falcon quarterly",
+			expect: []string{"tid/exchange/uid/email/inbox/m1"},
+		},
+		{
+			name:   "details values",
+			query:  "carried",
+			expect: []string{"tid/exchange/uid/email/inbox/m4"},
+		},
+		{
+			name:  "prefix",
+			query: "lun*",
+			expect: []string{
+				"tid/exchange/uid/email/inbox/m2",
+			},
+		},
+		{
+			name:   "no match",
+			query:  "falcon nope",
+			expect: []string{},
+		},
+		{
+			name:   "no terms",
+			query:  "* !",
+			expect: []string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			s := NewSearcher(test.query)
+
+			// round trip the index through its persisted form.
+			read := readShards(t, sw, s)
+			assert.Equal(t, sw.ChunkNames(), read, "every shard is searched")
+			assert.Equal(t, test.expect, s.Results())
+		})
+	}
+}
+
+func (suite *SearchUnitSuite) TestBaseReader() {
+	t := suite.T()
+
+	var (
+		base      = NewBuilder()
+		baseDeets = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{
+					indexTestEntry("tid/exchange/uid/email/inbox/m1", "Quarterly report"),
+					indexTestEntry("tid/exchange/uid/email/inbox/m2", "Lunch"),
+					indexTestEntry("tid/exchange/uid/email/inbox/m3", "Offsite"),
+					indexTestEntry("tid/exchange/uid/email/inbox/m4", "Offsite"),
+				},
+			},
+		}
+	)
+
+	readAll(t, base.Reader(
+		"tid/exchange/uid/email/inbox/m1",
+		io.NopCloser(bytes.NewBufferString("numbers for project falcon"))))
+	readAll(t, base.Reader(
+		"tid/exchange/uid/email/inbox/m3",
+		io.NopCloser(bytes.NewBufferString("falcon offsite agenda"))))
+	readAll(t, base.Reader(
+		"tid/exchange/uid/email/inbox/m4",
+		io.NopCloser(bytes.NewBufferString("falcon offsite travel"))))
+
+	var (
+		b     = NewBuilder()
+		deets = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{
+					indexTestEntry("tid/exchange/uid/email/archive/m3", "Offsite"),
+					indexTestEntry("tid/exchange/uid/email/inbox/m4", "Offsite"),
+				},
+			},
+		}
+	)
+
+	// m3 moved, and m4 got edited, so its content is read again.
+	b.Carry("bid", "tid/exchange/uid/email/inbox/m3", "tid/exchange/uid/email/archive/m3")
+	b.Carry("bid", "tid/exchange/uid/email/inbox/m4", "tid/exchange/uid/email/inbox/m4")
+	b.Carry("other", "tid/exchange/uid/email/inbox/m1", "tid/exchange/uid/email/inbox/m1")
+
+	readAll(t, b.Reader(
+		"tid/exchange/uid/email/inbox/m4",
+		io.NopCloser(bytes.NewBufferString("falcon offsite canceled"))))
+
+	read := readShards(t, base.Shards(baseDeets, 2), b.BaseReader("bid"))
+	assert.Equal(t, []string{"shard_000001"}, read, "only shards with carried items are read")
+
+	sw := b.Shards(deets, DefaultShardSize)
+
+	table := []struct {
+		name   string
+		query  string
+		expect []string
+	}{
+		{
+			name:   "carried content",
+			query:  "agenda",
+			expect: []string{"tid/exchange/uid/email/archive/m3"},
+		},
+		{
+			name:   "read content replaces carried content",
+			query:  "travel",
+			expect: []string{},
+		},
+		{
+			name:   "read content",
+			query:  "canceled",
+			expect: []string{"tid/exchange/uid/email/inbox/m4"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			s := NewSearcher(test.query)
+
+			readShards(t, sw, s)
+			assert.Equal(t, test.expect, s.Results())
+		})
+	}
+}
+
+func (suite *SearchUnitSuite) TestQuery() {
+	t := suite.T()
+
+	assert.Error(t, Query{}.Validate())
+	assert.Error(t, Query{Text: "* a"}.Validate())
+	assert.NoError(t, Query{Text: "falcon"}.Validate())
+
+	assert.True(t, Query{}.MatchesResource("id", "name"))
+	assert.True(t, Query{ProtectedResources: []string{"NAME"}}.MatchesResource("id", "name"))
+	assert.False(t, Query{ProtectedResources: []string{"other"}}.MatchesResource("id", "name"))
+}
@@ -0,0 +1,327 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+const (
+	// DefaultShardSize is the max number of items indexed by each shard.
+	DefaultShardSize = 5000
+
+	shardNamePrefix = "shard_"
+)
+
+// --------------------------------------------------------------------------------
+// shard index
+// --------------------------------------------------------------------------------
+
+// ShardIndex describes the shards of a backup's search index.  Each shard
+// indexes a range of the backup's items, sorted by RepoRef, so that the
+// index never needs to be held in memory all at once.
+type ShardIndex struct {
+	Shards []ShardInfo `json:"shards"`
+	Total  int         `json:"total"`
+}
+
+// ShardInfo summarizes the items covered by a single shard.
+type ShardInfo struct {
+	Name string `json:"name"`
+	// Count is the number of details entries in the shard's range.  Entries
+	// without any terms are left out of the shard's Index.
+	Count int `json:"count"`
+	// items are sorted by RepoRef, so the first and last RepoRef bound
+	// every item in the shard.
+	FirstRepoRef string `json:"firstRepoRef"`
+	LastRepoRef  string `json:"lastRepoRef"`
+}
+
+func decodeShardIndex(rc io.ReadCloser) (ShardIndex, error) {
+	defer rc.Close()
+
+	var index ShardIndex
+
+	err := json.NewDecoder(rc).Decode(&index)
+
+	return index, clues.Wrap(err, "decoding search index").OrNil()
+}
+
+func decodeShard(name string, rc io.ReadCloser) (*Index, error) {
+	defer rc.Close()
+
+	var idx Index
+
+	if err := json.NewDecoder(rc).Decode(&idx); err != nil {
+		return nil, clues.Wrap(err, "decoding search index shard").With("shard_name", name)
+	}
+
+	return &idx, nil
+}
+
+// --------------------------------------------------------------------------------
+// writer
+// --------------------------------------------------------------------------------
+
+// ShardWriter splits the search index of a backup into shards.  The Index
+// of each shard is only built as it gets marshalled.  It complies with the
+// chunk marshaller interface in streamStore.
+type ShardWriter struct {
+	b      *Builder
+	index  ShardIndex
+	shards map[string][]*details.Entry
+}
+
+// Shards sorts the items of the details by RepoRef and groups them into
+// shards of at most size items.
+func (b *Builder) Shards(deets *details.Details, size int) *ShardWriter {
+	if size <= 0 {
+		size = DefaultShardSize
+	}
+
+	entries := deets.Items()
+	slices.SortStableFunc(entries, func(a, b *details.Entry) int {
+		return strings.Compare(a.RepoRef, b.RepoRef)
+	})
+
+	sw := &ShardWriter{
+		b:      b,
+		index:  ShardIndex{Total: len(entries)},
+		shards: map[string][]*details.Entry{},
+	}
+
+	for i := 0; i < len(entries); i += size {
+		shard := entries[i:min(i+size, len(entries))]
+		si := ShardInfo{
+			Name:         fmt.Sprintf("%s%06d", shardNamePrefix, len(sw.index.Shards)),
+			Count:        len(shard),
+			FirstRepoRef: shard[0].RepoRef,
+			LastRepoRef:  shard[len(shard)-1].RepoRef,
+		}
+
+		sw.index.Shards = append(sw.index.Shards, si)
+		sw.shards[si.Name] = shard
+	}
+
+	return sw
+}
+
+// MarshalIndex serializes the shard index.
+func (sw *ShardWriter) MarshalIndex() ([]byte, error) {
+	bs, err := json.Marshal(sw.index)
+	return bs, clues.Stack(err).OrNil()
+}
+
+// ChunkNames lists the names of all shards, in RepoRef order.
+func (sw *ShardWriter) ChunkNames() []string {
+	names := make([]string, 0, len(sw.index.Shards))
+
+	for _, si := range sw.index.Shards {
+		names = append(names, si.Name)
+	}
+
+	return names
+}
+
+// MarshalChunk builds and serializes the Index of the named shard.
+func (sw *ShardWriter) MarshalChunk(name string) ([]byte, error) {
+	shard, ok := sw.shards[name]
+	if !ok {
+		return nil, clues.New("unknown search index shard").With("shard_name", name)
+	}
+
+	bs, err := json.Marshal(sw.b.index(shard))
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// --------------------------------------------------------------------------------
+// searcher
+// --------------------------------------------------------------------------------
+
+// Searcher looks up a query in each shard of a backup's search index, one
+// shard at a time.  It complies with the chunk unmarshaller interface in
+// streamStore.
+type Searcher struct {
+	query string
+	names []string
+	refs  []string
+}
+
+// NewSearcher produces a searcher for the query.  See Index.Search for
+// the query syntax.
+func NewSearcher(query string) *Searcher {
+	return &Searcher{
+		query: query,
+		refs:  []string{},
+	}
+}
+
+// UnmarshalIndex reads the shard index, and returns the names of the
+// shards to search.  Every item can hold any term, so all of them are.
+func (s *Searcher) UnmarshalIndex(rc io.ReadCloser) ([]string, error) {
+	index, err := decodeShardIndex(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, si := range index.Shards {
+		s.names = append(s.names, si.Name)
+	}
+
+	return slices.Clone(s.names), nil
+}
+
+// UnmarshalChunk searches a single shard.  Only the matching RepoRefs are
+// kept once it's been searched.
+func (s *Searcher) UnmarshalChunk(name string, rc io.ReadCloser) error {
+	if !slices.Contains(s.names, name) {
+		rc.Close()
+		return clues.New("unexpected search index shard").With("shard_name", name)
+	}
+
+	idx, err := decodeShard(name, rc)
+	if err != nil {
+		return err
+	}
+
+	s.refs = append(s.refs, idx.Search(s.query)...)
+
+	return nil
+}
+
+// Results returns the sorted RepoRefs of the items that matched the query
+// in the shards read so far.
+func (s *Searcher) Results() []string {
+	slices.Sort(s.refs)
+	return s.refs
+}
+
+// --------------------------------------------------------------------------------
+// base reader
+// --------------------------------------------------------------------------------
+
+// BaseReader adds the terms of the items carried over from a base backup
+// to the builder.  Only the shards of the base's index that hold carried
+// items get read, one at a time.  It complies with the chunk unmarshaller
+// interface in streamStore.
+type BaseReader struct {
+	b *Builder
+	// the RepoRefs in this backup of the carried items, by their RepoRef
+	// in the base.
+	carried map[string][]string
+	// the sorted keys of carried.
+	baseRefs []string
+	names    []string
+}
+
+// BaseReader produces a reader of the index of the base backup, for the
+// items carried over from it so far.  Those items are no longer tracked
+// by the builder afterwards, whether or not the index gets read, leaving
+// the items that can't be found in it to be indexed by their details
+// values alone.
+func (b *Builder) BaseReader(baseID string) *BaseReader {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := &BaseReader{
+		b:       b,
+		carried: map[string][]string{},
+	}
+
+	for newRef, ci := range b.carried {
+		if ci.baseID != baseID {
+			continue
+		}
+
+		delete(b.carried, newRef)
+
+		// content read during this backup supersedes the base's terms.
+		if _, ok := b.content[newRef]; ok {
+			continue
+		}
+
+		br.carried[ci.repoRef] = append(br.carried[ci.repoRef], newRef)
+	}
+
+	for ref := range br.carried {
+		br.baseRefs = append(br.baseRefs, ref)
+	}
+
+	slices.Sort(br.baseRefs)
+
+	return br
+}
+
+// UnmarshalIndex reads the shard index of the base, and returns the names
+// of the shards whose range holds a carried item.
+func (br *BaseReader) UnmarshalIndex(rc io.ReadCloser) ([]string, error) {
+	index, err := decodeShardIndex(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, si := range index.Shards {
+		i, _ := slices.BinarySearch(br.baseRefs, si.FirstRepoRef)
+
+		if i < len(br.baseRefs) && br.baseRefs[i] <= si.LastRepoRef {
+			br.names = append(br.names, si.Name)
+		}
+	}
+
+	return slices.Clone(br.names), nil
+}
+
+// UnmarshalChunk adds the terms that the carried items hold in a single
+// shard of the base's index.
+func (br *BaseReader) UnmarshalChunk(name string, rc io.ReadCloser) error {
+	if !slices.Contains(br.names, name) {
+		rc.Close()
+		return clues.New("unexpected search index shard").With("shard_name", name)
+	}
+
+	idx, err := decodeShard(name, rc)
+	if err != nil {
+		return err
+	}
+
+	// the terms of each carried item, by its position within the shard.
+	terms := map[int]map[string]struct{}{}
+
+	for i, rr := range idx.Items {
+		if _, ok := br.carried[rr]; ok {
+			terms[i] = map[string]struct{}{}
+		}
+	}
+
+	if len(terms) == 0 {
+		return nil
+	}
+
+	for t, is := range idx.Terms {
+		for _, i := range is {
+			if ts, ok := terms[i]; ok {
+				ts[t] = struct{}{}
+			}
+		}
+	}
+
+	br.b.mu.Lock()
+	defer br.b.mu.Unlock()
+
+	for i, ts := range terms {
+		for _, newRef := range br.carried[idx.Items[i]] {
+			if _, ok := br.b.content[newRef]; !ok {
+				br.b.content[newRef] = ts
+			}
+		}
+	}
+
+	return nil
+}
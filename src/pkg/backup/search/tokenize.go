@@ -0,0 +1,127 @@
+package search
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// terms outside these lengths are dropped from the index.
+	minTermLen = 2
+	maxTermLen = 64
+
+	// the number of leading bytes checked when deciding whether content
+	// holds text.
+	sniffLen = 8 * 1024
+)
+
+// Tokenize splits the text into its distinct, lowercased terms.  Markup
+// tags and json escape sequences are treated as separators, so that html
+// bodies and serialized items produce the terms of their text.
+func Tokenize(text string) []string {
+	set := map[string]struct{}{}
+	tokenize(text, set)
+
+	terms := make([]string, 0, len(set))
+	for t := range set {
+		terms = append(terms, t)
+	}
+
+	return terms
+}
+
+func tokenize(text string, into map[string]struct{}) {
+	var (
+		word  strings.Builder
+		inTag bool
+	)
+
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+
+		t := word.String()
+		word.Reset()
+
+		if l := utf8.RuneCountInString(t); l >= minTermLen && l <= maxTermLen {
+			into[t] = struct{}{}
+		}
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+
+		// decode json unicode escapes, and split on any other escape.
+		if r == '\\' && i < len(text) {
+			v, ok := unicodeEscape(text[i:])
+			if !ok {
+				i++
+
+				flush()
+
+				continue
+			}
+
+			r = v
+			i += 5
+		}
+
+		switch {
+		case r == '<':
+			inTag = true
+
+			flush()
+
+		case r == '>':
+			inTag = false
+
+		case inTag:
+
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(unicode.ToLower(r))
+
+		default:
+			flush()
+		}
+	}
+
+	flush()
+}
+
+// unicodeEscape decodes the `uXXXX` that follows a backslash.
+func unicodeEscape(s string) (rune, bool) {
+	if len(s) < 5 || s[0] != 'u' {
+		return 0, false
+	}
+
+	v, err := strconv.ParseUint(s[1:5], 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return rune(v), true
+}
+
+// isText reports whether the content looks like text.  Binary content
+// (images, archives, office documents) isn't worth tokenizing.
+func isText(bs []byte) bool {
+	sniff := bs[:min(len(bs), sniffLen)]
+
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return false
+	}
+
+	// the sniffed bytes may end partway through a rune.
+	for i := 0; i < utf8.UTFMax && i <= len(sniff); i++ {
+		if utf8.Valid(sniff[:len(sniff)-i]) {
+			return true
+		}
+	}
+
+	return false
+}
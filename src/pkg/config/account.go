@@ -1,8 +1,6 @@
 package config
 
 import (
-	"os"
-
 	"github.com/alcionai/clues"
 	"github.com/spf13/viper"
 
@@ -19,6 +17,9 @@ func m365ConfigsFromViper(vpr *viper.Viper) (account.M365Config, error) {
 	m365.AzureClientID = vpr.GetString(account.AzureClientID)
 	m365.AzureClientSecret = vpr.GetString(account.AzureSecret)
 	m365.AzureTenantID = vpr.GetString(account.AzureTenantIDKey)
+	m365.AzureClientCertPath = vpr.GetString(account.AzureClientCertPath)
+	m365.AzureClientCertPassword = vpr.GetString(account.AzureClientCertPass)
+	m365.AzureFederatedTokenFile = vpr.GetString(account.AzureFederatedToken)
 
 	return m365, nil
 }
@@ -77,15 +78,16 @@ func configureAccount(
 		AzureTenantID: str.First(
 			overrides[account.AzureTenantID],
 			flags.AzureClientTenantFV,
-			os.Getenv(account.AzureTenantID),
+			credentials.Lookup(account.AzureTenantID),
 			m365Cfg.AzureTenantID),
 	}
 
-	// ensure required properties are present
+	// ensure required properties are present.  The client secret is
+	// covered by m365.Validate(), since a certificate or federated token
+	// can be used in its place.
 	if err := requireProps(map[string]string{
-		credentials.AzureClientID:     m365Cfg.M365.AzureClientID,
-		credentials.AzureClientSecret: m365Cfg.M365.AzureClientSecret,
-		account.AzureTenantID:         m365Cfg.AzureTenantID,
+		credentials.AzureClientID: m365Cfg.M365.AzureClientID,
+		account.AzureTenantID:     m365Cfg.AzureTenantID,
 	}); err != nil {
 		return acct, err
 	}
@@ -103,15 +105,37 @@ func configureAccount(
 func GetM365(m365Cfg account.M365Config) credentials.M365 {
 	AzureClientID := str.First(
 		flags.AzureClientIDFV,
-		os.Getenv(credentials.AzureClientID),
+		credentials.Lookup(credentials.AzureClientID),
 		m365Cfg.AzureClientID)
 	AzureClientSecret := str.First(
 		flags.AzureClientSecretFV,
-		os.Getenv(credentials.AzureClientSecret),
+		credentials.Lookup(credentials.AzureClientSecret),
 		m365Cfg.AzureClientSecret)
 
+	// a certificate passed by flag is always a path; the env may hold
+	// either the certificate itself or its path.
+	certPath := str.First(
+		flags.AzureClientCertFV,
+		credentials.Lookup(credentials.AzureClientCertPath),
+		m365Cfg.AzureClientCertPath)
+	cert := ""
+
+	if len(flags.AzureClientCertFV) == 0 {
+		cert = credentials.Lookup(credentials.AzureClientCert)
+	}
+
 	return credentials.M365{
-		AzureClientID:     AzureClientID,
-		AzureClientSecret: AzureClientSecret,
+		AzureClientID:       AzureClientID,
+		AzureClientSecret:   AzureClientSecret,
+		AzureClientCert:     cert,
+		AzureClientCertPath: certPath,
+		AzureClientCertPassword: str.First(
+			flags.AzureClientCertPasswordFV,
+			credentials.Lookup(credentials.AzureClientCertPassword),
+			m365Cfg.AzureClientCertPassword),
+		AzureFederatedTokenFile: str.First(
+			flags.AzureFederatedTokenFileFV,
+			credentials.Lookup(credentials.AzureFederatedTokenFile),
+			m365Cfg.AzureFederatedTokenFile),
 	}
 }
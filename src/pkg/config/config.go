@@ -18,6 +18,8 @@ import (
 	"github.com/alcionai/corso/src/internal/common/str"
 	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/credentials/secrets"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/storage"
@@ -72,12 +74,16 @@ func init() {
 	}
 }
 
-// adds the persistent flag --config-file to the provided command.
+// adds the persistent flags --config-file and --secret-source to the provided command.
 func AddConfigFlags(cmd *cobra.Command) {
 	pf := cmd.PersistentFlags()
 	pf.StringVar(
 		&flags.ConfigFileFV,
 		flags.ConfigFileFN, displayDefaultFP, "config file location")
+	pf.StringVar(
+		&flags.SecretSourceFV,
+		flags.SecretSourceFN, "",
+		"where to read credentials from: env, file:///<path>, secretsmanager://<secret id>, or ssm://<parameter path>")
 }
 
 // ---------------------------------------------------------------------------------------------------------
@@ -87,10 +93,38 @@ func AddConfigFlags(cmd *cobra.Command) {
 // InitCmd provides a func that lazily initializes viper and
 // verifies that the configuration was able to read a file.
 func InitCmd(cmd *cobra.Command, args []string) error {
-	_, err := commonInit(cmd.Context(), flags.ConfigFileFV)
+	ctx := cmd.Context()
+
+	uri := str.First(flags.SecretSourceFV, os.Getenv(secrets.SourceEnv))
+	if err := LoadSecrets(ctx, uri); err != nil {
+		return clues.Stack(err)
+	}
+
+	_, err := commonInit(ctx, flags.ConfigFileFV)
+
 	return clues.Stack(err).OrNil()
 }
 
+// LoadSecrets reads the m365 and storage credentials from the secret
+// source described by the uri.  Credentials in the source override the
+// env vars of the same name.  The env source is a no-op.
+func LoadSecrets(ctx context.Context, uri string) error {
+	src, err := secrets.FromURI(uri)
+	if err != nil {
+		return clues.Wrap(err, "configuring secret source")
+	}
+
+	if _, ok := src.(secrets.Env); ok {
+		return nil
+	}
+
+	if err := credentials.LoadSecrets(ctx, src, account.AzureTenantID); err != nil {
+		return clues.Stack(err)
+	}
+
+	return nil
+}
+
 // InitConfig allows sdk consumers to initialize viper.
 func InitConfig(
 	ctx context.Context,
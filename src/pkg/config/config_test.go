@@ -200,6 +200,88 @@ func (suite *ConfigSuite) TestWriteReadConfig() {
 	assert.Equal(t, host, gotHost)
 }
 
+func (suite *ConfigSuite) TestWriteReadConfig_StorageProviders() {
+	table := []struct {
+		name     string
+		provider storage.ProviderType
+		cfg      storage.Configurer
+	}{
+		{
+			name:     "gcs",
+			provider: storage.ProviderGCS,
+			cfg: &storage.GCSConfig{
+				GCS:    credentials.GCS{CredentialsFile: "/keys/sa.json"},
+				Bucket: "bkt",
+				Prefix: "pre/",
+			},
+		},
+		{
+			name:     "webdav",
+			provider: storage.ProviderWebDAV,
+			cfg: &storage.WebDAVConfig{
+				WebDAV:                              credentials.WebDAV{Username: "usr"},
+				URL:                                 "https://nas.local:5006/corso",
+				TrustedServerCertificateFingerprint: "ab12",
+			},
+		},
+		{
+			name:     "sftp",
+			provider: storage.ProviderSFTP,
+			cfg: &storage.SFTPConfig{
+				Host:           "nas.local",
+				Port:           "2222",
+				Username:       "usr",
+				Path:           "/volume1/corso",
+				KeyFile:        "/home/usr/.ssh/id_ed25519",
+				KnownHostsFile: "/home/usr/.ssh/known_hosts",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var (
+				t                  = suite.T()
+				vpr                = viper.New()
+				testConfigFilePath = filepath.Join(t.TempDir(), "corso.toml")
+			)
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			for _, env := range []string{
+				credentials.GCSCredentialsFile,
+				credentials.GCSCredentialsJSON,
+				credentials.WebDAVUsername,
+				credentials.WebDAVPassword,
+				credentials.SFTPPassword,
+				credentials.SFTPKeyData,
+			} {
+				t.Setenv(env, "")
+			}
+
+			err := initWithViper(ctx, vpr, testConfigFilePath)
+			require.NoError(t, err, "initializing repo config", clues.ToCore(err))
+
+			m365 := account.M365Config{AzureTenantID: "tid"}
+
+			err = writeRepoConfigWithViper(vpr, test.cfg, m365, repository.Options{}, "repoid")
+			require.NoError(t, err, "writing repo config", clues.ToCore(err))
+
+			err = vpr.ReadInConfig()
+			require.NoError(t, err, "reading repo config", clues.ToCore(err))
+
+			assert.Equal(t, test.provider.String(), vpr.GetString(storage.StorageProviderTypeKey))
+
+			sc, err := storage.NewStorageConfig(test.provider)
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = sc.ApplyConfigOverrides(vpr, true, true, nil)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.cfg, sc)
+		})
+	}
+}
+
 func (suite *ConfigSuite) TestMustMatchConfig() {
 	var (
 		t   = suite.T()
@@ -393,6 +475,43 @@ func (suite *ConfigSuite) TestReadFromFlags() {
 	assert.Equal(t, flags.PassphraseFV, pass)
 }
 
+func (suite *ConfigSuite) TestGetM365_CertificateAndToken() {
+	t := suite.T()
+
+	t.Cleanup(func() {
+		flags.AzureClientCertFV = ""
+		flags.AzureClientCertPasswordFV = ""
+		flags.AzureFederatedTokenFileFV = ""
+	})
+
+	t.Setenv(credentials.AzureClientCert, "env-cert")
+	t.Setenv(credentials.AzureClientCertPassword, "env-pw")
+	t.Setenv(credentials.AzureFederatedTokenFile, "")
+
+	fromConfig := account.M365Config{
+		M365: credentials.M365{
+			AzureClientCertPath:     "/config/cert.pem",
+			AzureFederatedTokenFile: "/config/token",
+		},
+	}
+
+	m365 := GetM365(fromConfig)
+	assert.Equal(t, "env-cert", m365.AzureClientCert)
+	assert.Equal(t, "/config/cert.pem", m365.AzureClientCertPath)
+	assert.Equal(t, "env-pw", m365.AzureClientCertPassword)
+	assert.Equal(t, "/config/token", m365.AzureFederatedTokenFile)
+
+	flags.AzureClientCertFV = "/flag/cert.pfx"
+	flags.AzureClientCertPasswordFV = "flag-pw"
+	flags.AzureFederatedTokenFileFV = "/flag/token"
+
+	m365 = GetM365(fromConfig)
+	assert.Empty(t, m365.AzureClientCert, "flag path replaces the env certificate")
+	assert.Equal(t, "/flag/cert.pfx", m365.AzureClientCertPath)
+	assert.Equal(t, "flag-pw", m365.AzureClientCertPassword)
+	assert.Equal(t, "/flag/token", m365.AzureFederatedTokenFile)
+}
+
 // ------------------------------------------------------------
 // integration tests
 // ------------------------------------------------------------
@@ -2,7 +2,6 @@ package config
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 
 	"github.com/alcionai/clues"
@@ -78,7 +77,7 @@ func configureStorage(
 func GetAndInsertCorso(passphase string) credentials.Corso {
 	// fetch data from flag, env var or func param giving priority to func param
 	// Func param generally will be value fetched from config file using viper.
-	corsoPassph := str.First(flags.PassphraseFV, os.Getenv(credentials.CorsoPassphrase), passphase)
+	corsoPassph := str.First(flags.PassphraseFV, credentials.Lookup(credentials.CorsoPassphrase), passphase)
 
 	return credentials.Corso{
 		CorsoPassphrase: corsoPassph,
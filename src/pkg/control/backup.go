@@ -30,6 +30,19 @@ type BackupConfig struct {
 	Incrementals         IncrementalsConfig                 `json:"incrementalsConfig"`
 	M365                 BackupM365Config                   `json:"m365Config"`
 
+	// SearchIndex builds a full-text index of the backed up items, which
+	// gets stored alongside the backup details.
+	SearchIndex bool `json:"searchIndex,omitempty"`
+
+	// DetectAnomalies compares the churn of each backup against prior
+	// backups of the same resource, and alerts on mass changes, deletions,
+	// or signs of ransomware.
+	DetectAnomalies bool `json:"detectAnomalies,omitempty"`
+
+	// MarkSuspectBackups marks the backups where anomalies were detected
+	// as suspect.  Only applies when DetectAnomalies is set.
+	MarkSuspectBackups bool `json:"markSuspectBackups,omitempty"`
+
 	// PreviewLimits defines the number of items and/or amount of data to fetch on
 	// a best-effort basis for preview backups.
 	//
@@ -63,6 +76,15 @@ type BackupM365Config struct {
 	// incremental backups used immutable IDs or if a full backup is being done.
 	ExchangeImmutableIDs bool `json:"exchangeImmutableIDs,omitempty"`
 
+	// ExchangeArchive adds the user's online archive mailbox to exchange mail
+	// backups.
+	ExchangeArchive bool `json:"exchangeArchive,omitempty"`
+
+	// ExchangeRecoverableItems adds the Recoverable Items folders (deletions,
+	// purges, versions, and items held for litigation) to exchange mail
+	// backups.
+	ExchangeRecoverableItems bool `json:"exchangeRecoverableItems,omitempty"`
+
 	// see: https://github.com/alcionai/corso/issues/4688
 	UseOldDriveDeltaProcess bool `json:"useOldDriveDeltaProcess"`
 }
@@ -8,10 +8,6 @@ type ExportConfig struct {
 	// the archive.
 	Archive bool
 
-	// DataFormat
-	// TODO: Enable once we support outlook exports
-	// DataFormat string
-
 	// Format decides the format in which we return the data.
 	// ex: html vs pst vs other.
 	// Default format is decided on a per-service or per-data basis.
@@ -25,6 +21,10 @@ var (
 	DefaultFormat FormatType
 	// export the data as raw, unmodified json
 	JSONFormat FormatType = "json"
+	// export mail as one mbox file per folder
+	MBOXFormat FormatType = "mbox"
+	// export a mailbox as a single outlook data file
+	PSTFormat FormatType = "pst"
 )
 
 func DefaultExportConfig() ExportConfig {
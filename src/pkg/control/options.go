@@ -1,6 +1,8 @@
 package control
 
 import (
+	"time"
+
 	"github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/extensions"
 )
@@ -27,6 +29,35 @@ type Options struct {
 	// backup data until the set limits without paying attention to what the other
 	// had already backed up.
 	PreviewLimits PreviewItemLimits `json:"previewItemLimits"`
+	// CheckpointAt, when non-zero, makes a backup stop handing items to the
+	// repository once that time passes.  The items uploaded so far are
+	// persisted as an assist backup, which lets the next backup of the same
+	// resource skip over them.  Callers must leave enough time between the
+	// checkpoint and their own deadline for the backup to be persisted.
+	//
+	// Only compatible with the FailAfterRecovery failure policy.
+	CheckpointAt time.Time `json:"checkpointAt,omitempty"`
+	// Resume continues the work of an earlier backup of the same resource
+	// which stopped at its checkpoint.
+	Resume Resume `json:"resume"`
+}
+
+// Resume describes the progress of a backup which stopped at its
+// checkpoint.
+type Resume struct {
+	// BackupID is the assist backup persisted at the checkpoint.  The
+	// resumed backup uses it as its merge base.
+	BackupID string `json:"backupID,omitempty"`
+	// Completed maps the paths of the collections which the checkpointed
+	// backup finished to the delta tokens they were enumerated to.  Those
+	// collections continue from their tokens; all others get enumerated
+	// in full.
+	Completed map[string]string `json:"completed,omitempty"`
+}
+
+// Enabled is true if the backup resumes from a checkpoint.
+func (r Resume) Enabled() bool {
+	return len(r.BackupID) > 0
 }
 
 // RateLimiter is the set of options applied to any external service facing rate
@@ -89,6 +120,23 @@ type Toggles struct {
 	// immutable Exchange IDs. This is only safe to set if the previous backup for
 	// incremental backups used immutable IDs or if a full backup is being done.
 	ExchangeImmutableIDs bool `json:"exchangeImmutableIDs,omitempty"`
+	// ExchangeArchive adds the user's online archive mailbox to exchange
+	// mail backups.
+	ExchangeArchive bool `json:"exchangeArchive,omitempty"`
+	// ExchangeRecoverableItems adds the Recoverable Items folders (deletions,
+	// purges, versions, and items held for litigation) to exchange mail
+	// backups.
+	ExchangeRecoverableItems bool `json:"exchangeRecoverableItems,omitempty"`
+	// SearchIndex builds a full-text index of the backed up items, which
+	// gets stored alongside the backup details.
+	SearchIndex bool `json:"searchIndex,omitempty"`
+	// DetectAnomalies compares the churn of each backup against prior
+	// backups of the same resource, and alerts on mass changes, deletions,
+	// or signs of ransomware.
+	DetectAnomalies bool `json:"detectAnomalies,omitempty"`
+	// MarkSuspectBackups marks the backups where anomalies were detected
+	// as suspect.  Only applies when DetectAnomalies is set.
+	MarkSuspectBackups bool `json:"markSuspectBackups,omitempty"`
 
 	RunMigrations bool `json:"runMigrations"`
 
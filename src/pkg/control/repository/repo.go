@@ -19,6 +19,10 @@ type Maintenance struct {
 	Safety        MaintenanceSafety `json:"safety"`
 	Force         bool              `json:"force"`
 	CleanupBuffer *time.Duration
+	// MeasureStorage lists the blobs in the repository storage before and
+	// after maintenance to report the space it reclaimed.  Listing every
+	// blob is slow and costly for large repositories, so it's opt-in.
+	MeasureStorage bool `json:"measureStorage,omitempty"`
 }
 
 // ---------------------------------------------------------------------------
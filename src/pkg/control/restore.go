@@ -64,6 +64,56 @@ type RestoreConfig struct {
 	// IncludePermissions toggles whether the restore will include the original
 	// folder- and item-level permissions.
 	IncludePermissions bool `json:"includePermissions"`
+
+	// ResourceMapping translates the users, groups, and sites of the backed
+	// up tenant to their counterparts in the tenant receiving the restore.
+	// It picks the protected resource when ProtectedResource is empty, and
+	// translates the grantees of restored permissions.
+	// Defaults to empty.
+	ResourceMapping ResourceMapping `json:"resourceMapping,omitempty"`
+}
+
+// ResourceMapping maps the ID or name (ex: a user's principal name) of a
+// resource in the backed up tenant to the ID or name of a resource in the
+// restore tenant.  Keys are case-insensitive.
+type ResourceMapping map[string]string
+
+// Get returns the mapped value of the first key that's present in the
+// mapping.
+func (rm ResourceMapping) Get(keys ...string) (string, bool) {
+	for _, k := range keys {
+		if len(k) == 0 {
+			continue
+		}
+
+		if v, ok := rm[strings.ToLower(k)]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// normalized lowercases the keys of the mapping.
+func (rm ResourceMapping) normalized() ResourceMapping {
+	if len(rm) == 0 {
+		return nil
+	}
+
+	norm := make(ResourceMapping, len(rm))
+
+	for k, v := range rm {
+		k = strings.ToLower(strings.TrimSpace(k))
+		v = strings.TrimSpace(v)
+
+		if len(k) == 0 || len(v) == 0 {
+			continue
+		}
+
+		norm[k] = v
+	}
+
+	return norm
 }
 
 func DefaultRestoreConfig(timeFormat dttm.TimeFormat) RestoreConfig {
@@ -94,6 +144,7 @@ func EnsureRestoreConfigDefaults(
 	}
 
 	rc.Location = strings.TrimPrefix(strings.TrimSpace(rc.Location), "/")
+	rc.ResourceMapping = rc.ResourceMapping.normalized()
 
 	return rc
 }
@@ -124,9 +175,24 @@ func (rc RestoreConfig) concealed() RestoreConfig {
 		Location:           path.LoggableDir(rc.Location),
 		Drive:              clues.Conceal(rc.Drive),
 		IncludePermissions: rc.IncludePermissions,
+		ResourceMapping:    rc.ResourceMapping.concealed(),
 	}
 }
 
+func (rm ResourceMapping) concealed() ResourceMapping {
+	if len(rm) == 0 {
+		return nil
+	}
+
+	cm := make(ResourceMapping, len(rm))
+
+	for k, v := range rm {
+		cm[clues.Conceal(k)] = clues.Conceal(v)
+	}
+
+	return cm
+}
+
 // Conceal produces a concealed representation of the config, suitable for
 // logging, storing in errors, and other output.
 func (rc RestoreConfig) Conceal() string {
@@ -89,6 +89,22 @@ func (suite *RestoreUnitSuite) TestEnsureRestoreConfigDefaults() {
 				Drive:             "",
 			},
 		},
+		{
+			name: "resource mapping keys are normalized",
+			input: control.RestoreConfig{
+				OnCollision: control.Copy,
+				ResourceMapping: control.ResourceMapping{
+					" Batman@Wayne.com ": "bruce@justice.org",
+					"robin":              "",
+				},
+			},
+			expect: control.RestoreConfig{
+				OnCollision: control.Copy,
+				ResourceMapping: control.ResourceMapping{
+					"batman@wayne.com": "bruce@justice.org",
+				},
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -103,6 +119,51 @@ func (suite *RestoreUnitSuite) TestEnsureRestoreConfigDefaults() {
 	}
 }
 
+func (suite *RestoreUnitSuite) TestResourceMapping_Get() {
+	rm := control.ResourceMapping{
+		"oid":              "nid",
+		"batman@wayne.com": "bruce@justice.org",
+	}
+
+	table := []struct {
+		name   string
+		keys   []string
+		expect string
+		found  assert.BoolAssertionFunc
+	}{
+		{
+			name:   "by id",
+			keys:   []string{"oid", "nobody@wayne.com"},
+			expect: "nid",
+			found:  assert.True,
+		},
+		{
+			name:   "falls back to later keys",
+			keys:   []string{"", "missing", "Batman@Wayne.com"},
+			expect: "bruce@justice.org",
+			found:  assert.True,
+		},
+		{
+			name:  "not found",
+			keys:  []string{"missing"},
+			found: assert.False,
+		},
+		{
+			name:  "no keys",
+			found: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, ok := rm.Get(test.keys...)
+			test.found(t, ok)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
 func (suite *RestoreUnitSuite) TestRestoreConfig_piiHandling() {
 	p, err := path.Build("tid", "ro", path.ExchangeService, path.EmailCategory, true, "foo", "bar", "baz")
 	require.NoError(suite.T(), err, clues.ToCore(err))
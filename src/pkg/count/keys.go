@@ -37,6 +37,7 @@ const (
 // backup amounts reported by data providers
 const (
 	Channels                      Key = "channels"
+	Chats                         Key = "chats"
 	CollectionMoved               Key = "collection-moved"
 	CollectionNew                 Key = "collection-state-new"
 	CollectionNotMoved            Key = "collection-state-not-moved"
@@ -44,13 +45,19 @@ const (
 	Collections                   Key = "collections"
 	DeleteFolderMarker            Key = "delete-folder-marker"
 	DeleteItemMarker              Key = "delete-item-marker"
+	DirectoryApplications         Key = "directory-applications"
+	DirectoryGroups               Key = "directory-groups"
+	DirectoryServicePrincipals    Key = "directory-service-principals"
+	DirectoryUsers                Key = "directory-users"
 	Drives                        Key = "drives"
 	DriveTombstones               Key = "drive-tombstones"
 	Files                         Key = "files"
 	Folders                       Key = "folders"
+	InboxRules                    Key = "inbox-rules"
 	ItemsAdded                    Key = "items-added"
 	ItemsRemoved                  Key = "items-removed"
 	LazyDeletedInFlight           Key = "lazy-deleted-in-flight"
+	MailboxCategories             Key = "mailbox-categories"
 	Malware                       Key = "malware"
 	MetadataItems                 Key = "metadata-items"
 	MetadataBytes                 Key = "metadata-bytes"
@@ -58,8 +65,13 @@ const (
 	NewDeltas                     Key = "new-delta-tokens"
 	NewPrevPaths                  Key = "new-previous-paths"
 	NoDeltaQueries                Key = "cannot-make-delta-queries"
+	NotebookPages                 Key = "notebook-pages"
+	NotebookSections              Key = "notebook-sections"
+	OneNoteFilesDeferred          Key = "onenote-files-deferred"
 	Packages                      Key = "packages"
 	PagerResets                   Key = "pager-resets"
+	PlannerPlans                  Key = "planner-plans"
+	PlannerTasks                  Key = "planner-tasks"
 	PrevDeltas                    Key = "previous-deltas"
 	PrevPaths                     Key = "previous-paths"
 	PreviousPathMetadataCollision Key = "previous-path-metadata-collision"
@@ -127,4 +139,12 @@ const (
 	// non-meta item creation counting.  IE: use it specifically
 	// for counting new items (no collision) or copied items.
 	NewItemCreated Key = "new-item-created"
+	// count of directory objects restored from the directory's
+	// recycle bin.
+	DirectoryObjectsUndeleted Key = "directory-objects-undeleted"
+	// count of group memberships re-added during restore.
+	GroupMembersAdded Key = "group-members-added"
+	// count of group members that could not be re-added because the
+	// member no longer exists in the directory.
+	GroupMembersNotFound Key = "group-members-not-found"
 )
@@ -0,0 +1,17 @@
+package credentials
+
+// envvar consts
+const (
+	AzureStorageKey          = "AZURE_STORAGE_KEY"
+	AzureStorageSASToken     = "AZURE_STORAGE_SAS_TOKEN"
+	AzureStorageClientSecret = "AZURE_STORAGE_CLIENT_SECRET"
+)
+
+// AzureStorage aggregates azure storage account credentials from flag and
+// env_var values.  One of the account key, the SAS token, or the client
+// secret of a service principal must be provided.
+type AzureStorage struct {
+	AccountKey   string
+	SASToken     string
+	ClientSecret string
+}
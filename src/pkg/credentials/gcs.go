@@ -0,0 +1,18 @@
+package credentials
+
+// envvar consts
+const (
+	// GCSCredentialsFile is the standard google env var for the path to
+	// a service account key file.
+	GCSCredentialsFile = "GOOGLE_APPLICATION_CREDENTIALS"
+	// GCSCredentialsJSON holds the service account key itself.
+	GCSCredentialsJSON = "GCS_CREDENTIALS_JSON"
+)
+
+// GCS aggregates google cloud storage credentials from flag and env_var
+// values.  If neither is provided, the application default credentials
+// of the host are used.
+type GCS struct {
+	CredentialsFile string
+	CredentialsJSON string
+}
@@ -1,7 +1,9 @@
 package credentials
 
 import (
+	"encoding/base64"
 	"os"
+	"strings"
 
 	"github.com/alcionai/clues"
 )
@@ -10,37 +12,96 @@ import (
 const (
 	AzureClientID     = "AZURE_CLIENT_ID"
 	AzureClientSecret = "AZURE_CLIENT_SECRET"
+	// AzureClientCert holds the certificate itself, either as PEM text or
+	// as base64 encoded PFX data.  Preferred over the path when the
+	// certificate comes from a secret source.
+	AzureClientCert         = "AZURE_CLIENT_CERTIFICATE"
+	AzureClientCertPath     = "AZURE_CLIENT_CERTIFICATE_PATH"
+	AzureClientCertPassword = "AZURE_CLIENT_CERTIFICATE_PASSWORD"
+	AzureFederatedTokenFile = "AZURE_FEDERATED_TOKEN_FILE"
 )
 
 // M365 aggregates m365 credentials from flag and env_var values.
+// Exactly one of the client secret, the client certificate, or the
+// federated token file is used to authenticate the client.
 type M365 struct {
 	AzureClientID     string
 	AzureClientSecret string
+	// AzureClientCert is a PEM encoded certificate and private key, or
+	// base64 encoded PFX data.
+	AzureClientCert string `json:",omitempty"`
+	// AzureClientCertPath locates a PEM or PFX certificate file.  Only
+	// used when AzureClientCert is empty.
+	AzureClientCertPath     string `json:",omitempty"`
+	AzureClientCertPassword string `json:",omitempty"`
+	// AzureFederatedTokenFile locates a file holding a federated (workload
+	// identity) token.  The file is re-read whenever a new access token is
+	// needed, since the token gets rotated by its issuer.
+	AzureFederatedTokenFile string `json:",omitempty"`
 }
 
 // M365 is a helper for aggregating m365 secrets and credentials.
 func GetM365() M365 {
 	// check env and overide is flags found
 	// var AzureClientID, AzureClientSecret string
-	AzureClientID := os.Getenv(AzureClientID)
-	AzureClientSecret := os.Getenv(AzureClientSecret)
+	AzureClientID := Lookup(AzureClientID)
+	AzureClientSecret := Lookup(AzureClientSecret)
 
 	return M365{
-		AzureClientID:     AzureClientID,
-		AzureClientSecret: AzureClientSecret,
+		AzureClientID:           AzureClientID,
+		AzureClientSecret:       AzureClientSecret,
+		AzureClientCert:         Lookup(AzureClientCert),
+		AzureClientCertPath:     Lookup(AzureClientCertPath),
+		AzureClientCertPassword: Lookup(AzureClientCertPassword),
+		AzureFederatedTokenFile: Lookup(AzureFederatedTokenFile),
 	}
 }
 
+// HasCertificate is true if the credentials provide a client certificate.
+func (c M365) HasCertificate() bool {
+	return len(c.AzureClientCert) > 0 || len(c.AzureClientCertPath) > 0
+}
+
+// CertificateData produces the raw certificate bytes, PEM or PFX, either
+// from AzureClientCert or by reading the file at AzureClientCertPath.
+func (c M365) CertificateData() ([]byte, error) {
+	if len(c.AzureClientCert) > 0 {
+		if strings.Contains(c.AzureClientCert, "-----BEGIN") {
+			return []byte(c.AzureClientCert), nil
+		}
+
+		bs, err := base64.StdEncoding.DecodeString(strings.TrimSpace(c.AzureClientCert))
+		if err != nil {
+			return nil, clues.Wrap(err, "certificate must be PEM text or base64 encoded PFX data")
+		}
+
+		return bs, nil
+	}
+
+	if len(c.AzureClientCertPath) == 0 {
+		return nil, clues.Stack(errMissingRequired, clues.New(AzureClientCert))
+	}
+
+	bs, err := os.ReadFile(c.AzureClientCertPath)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading certificate file").
+			With("certificate_path", c.AzureClientCertPath)
+	}
+
+	return bs, nil
+}
+
 func (c M365) Validate() error {
-	check := map[string]string{
-		AzureClientID:     c.AzureClientID,
-		AzureClientSecret: c.AzureClientSecret,
+	if len(c.AzureClientID) == 0 {
+		return clues.Stack(errMissingRequired, clues.New(AzureClientID))
 	}
 
-	for k, v := range check {
-		if len(v) == 0 {
-			return clues.Stack(errMissingRequired, clues.New(k))
-		}
+	if len(c.AzureClientSecret) == 0 &&
+		!c.HasCertificate() &&
+		len(c.AzureFederatedTokenFile) == 0 {
+		return clues.Stack(
+			errMissingRequired,
+			clues.New(AzureClientSecret+", "+AzureClientCert+", or "+AzureFederatedTokenFile))
 	}
 
 	return nil
@@ -0,0 +1,139 @@
+package credentials_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type M365UnitSuite struct {
+	tester.Suite
+}
+
+func TestM365UnitSuite(t *testing.T) {
+	suite.Run(t, &M365UnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *M365UnitSuite) TestValidate() {
+	table := []struct {
+		name      string
+		m365      credentials.M365
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "secret",
+			m365:      credentials.M365{AzureClientID: "cid", AzureClientSecret: "cs"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "certificate",
+			m365:      credentials.M365{AzureClientID: "cid", AzureClientCert: "cert"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "certificate path",
+			m365:      credentials.M365{AzureClientID: "cid", AzureClientCertPath: "cert.pem"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "federated token",
+			m365:      credentials.M365{AzureClientID: "cid", AzureFederatedTokenFile: "token"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing client id",
+			m365:      credentials.M365{AzureClientSecret: "cs"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing all credentials",
+			m365:      credentials.M365{AzureClientID: "cid", AzureClientCertPassword: "pw"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.m365.Validate()
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *M365UnitSuite) TestCertificateData() {
+	var (
+		pemData = []byte("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n")
+		pfxData = []byte{0x30, 0x82, 0x01, 0x02}
+		dir     = suite.T().TempDir()
+		pemFile = filepath.Join(dir, "cert.pem")
+	)
+
+	err := os.WriteFile(pemFile, pemData, 0o600)
+	assert.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name      string
+		m365      credentials.M365
+		expect    []byte
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "pem",
+			m365:      credentials.M365{AzureClientCert: string(pemData)},
+			expect:    pemData,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "base64 pfx",
+			m365:      credentials.M365{AzureClientCert: base64.StdEncoding.EncodeToString(pfxData)},
+			expect:    pfxData,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "prefers the certificate over the path",
+			m365: credentials.M365{
+				AzureClientCert:     base64.StdEncoding.EncodeToString(pfxData),
+				AzureClientCertPath: pemFile,
+			},
+			expect:    pfxData,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "path",
+			m365:      credentials.M365{AzureClientCertPath: pemFile},
+			expect:    pemData,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "not base64",
+			m365:      credentials.M365{AzureClientCert: "not a certificate!"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing file",
+			m365:      credentials.M365{AzureClientCertPath: filepath.Join(dir, "missing.pem")},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no certificate",
+			m365:      credentials.M365{},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			bs, err := test.m365.CertificateData()
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, bs)
+		})
+	}
+}
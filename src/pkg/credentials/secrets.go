@@ -0,0 +1,77 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/credentials/secrets"
+)
+
+// SecretNames are the credentials read from a secret source by LoadSecrets.
+var SecretNames = []string{
+	AzureClientID,
+	AzureClientSecret,
+	AzureClientCert,
+	AzureClientCertPassword,
+	CorsoPassphrase,
+	AWSAccessKeyID,
+	AWSSecretAccessKey,
+	AWSSessionToken,
+	AzureStorageKey,
+	AzureStorageSASToken,
+	AzureStorageClientSecret,
+	GCSCredentialsJSON,
+	WebDAVPassword,
+	SFTPPassword,
+	SFTPKeyData,
+}
+
+var (
+	secretsMu     sync.RWMutex
+	loadedSecrets = map[string]string{}
+)
+
+// LoadSecrets reads the values in SecretNames, plus any additional names,
+// from the source.  Once loaded, those values take precedence over env
+// vars of the same name in Lookup.  Replaces any previously loaded values.
+func LoadSecrets(ctx context.Context, src secrets.Source, additional ...string) error {
+	names := append(slices.Clone(SecretNames), additional...)
+
+	vs, err := src.Load(ctx, names)
+	if err != nil {
+		return clues.Wrap(err, "loading secrets")
+	}
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	loadedSecrets = vs
+
+	return nil
+}
+
+// ClearSecrets drops all values added by LoadSecrets.
+func ClearSecrets() {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	loadedSecrets = map[string]string{}
+}
+
+// Lookup returns the value of the named credential.  Values loaded from
+// a secret source are preferred; otherwise the env var is used.
+func Lookup(name string) string {
+	secretsMu.RLock()
+	v, ok := loadedSecrets[name]
+	secretsMu.RUnlock()
+
+	if ok {
+		return v
+	}
+
+	return os.Getenv(name)
+}
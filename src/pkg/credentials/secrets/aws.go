@@ -0,0 +1,161 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// ssm accepts at most this many names in a single GetParameters call.
+const maxParametersPerCall = 10
+
+// newAWSSession uses the default aws credential and region chain, which
+// includes the env vars and execution role of a lambda function.
+func newAWSSession() (*session.Session, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, clues.Wrap(err, "creating aws session")
+	}
+
+	return sess, nil
+}
+
+// ---------------------------------------------------------------------------
+// secrets manager
+// ---------------------------------------------------------------------------
+
+type secretValueGetter interface {
+	GetSecretValueWithContext(
+		ctx aws.Context,
+		input *secretsmanager.GetSecretValueInput,
+		opts ...request.Option,
+	) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+var _ Source = &SecretsManager{}
+
+// SecretsManager reads secrets from a single AWS Secrets Manager secret,
+// whose value is a json object of secret names to values.
+type SecretsManager struct {
+	client   secretValueGetter
+	secretID string
+}
+
+func NewSecretsManager(secretID string) (*SecretsManager, error) {
+	sess, err := newAWSSession()
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return &SecretsManager{
+		client:   secretsmanager.New(sess),
+		secretID: secretID,
+	}, nil
+}
+
+func (sm *SecretsManager) Load(ctx context.Context, names []string) (map[string]string, error) {
+	ctx = clues.Add(ctx, "secret_id", clues.Hide(sm.secretID))
+
+	resp, err := sm.client.GetSecretValueWithContext(
+		ctx,
+		&secretsmanager.GetSecretValueInput{SecretId: aws.String(sm.secretID)})
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "getting secret value")
+	}
+
+	if resp.SecretString == nil {
+		return nil, clues.NewWC(ctx, "secret has no string value")
+	}
+
+	return pick(ctx, []byte(aws.StringValue(resp.SecretString)), names)
+}
+
+// ---------------------------------------------------------------------------
+// ssm parameter store
+// ---------------------------------------------------------------------------
+
+type parametersGetter interface {
+	GetParametersWithContext(
+		ctx aws.Context,
+		input *ssm.GetParametersInput,
+		opts ...request.Option,
+	) (*ssm.GetParametersOutput, error)
+}
+
+var _ Source = &ParameterStore{}
+
+// ParameterStore reads each secret from the SSM parameter named
+// <path>/<secret name>.  SecureString parameters are decrypted.
+type ParameterStore struct {
+	client parametersGetter
+	path   string
+}
+
+// NewParameterStore reads parameters under the path.  Hierarchical
+// parameter names always start with a "/", so the path gets one whether
+// or not it was provided (ex: both ssm://corso and ssm:///corso read
+// /corso/<secret name>).
+func NewParameterStore(path string) (*ParameterStore, error) {
+	sess, err := newAWSSession()
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return &ParameterStore{
+		client: ssm.New(sess),
+		path:   "/" + strings.Trim(path, "/"),
+	}, nil
+}
+
+func (ps *ParameterStore) paramName(name string) string {
+	return strings.TrimSuffix(ps.path, "/") + "/" + name
+}
+
+func (ps *ParameterStore) Load(ctx context.Context, names []string) (map[string]string, error) {
+	ctx = clues.Add(ctx, "parameter_path", clues.Hide(ps.path))
+
+	var (
+		res     = map[string]string{}
+		byParam = map[string]string{}
+		params  = make([]*string, 0, len(names))
+	)
+
+	for _, n := range names {
+		pn := ps.paramName(n)
+		byParam[pn] = n
+		params = append(params, aws.String(pn))
+	}
+
+	for len(params) > 0 {
+		batch := params[:min(maxParametersPerCall, len(params))]
+		params = params[len(batch):]
+
+		resp, err := ps.client.GetParametersWithContext(
+			ctx,
+			&ssm.GetParametersInput{
+				Names:          batch,
+				WithDecryption: aws.Bool(true),
+			})
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "getting parameters")
+		}
+
+		// parameters that don't exist are reported as invalid, which
+		// matches a missing secret in any other source.
+		for _, p := range resp.Parameters {
+			if n, ok := byParam[aws.StringValue(p.Name)]; ok {
+				res[n] = aws.StringValue(p.Value)
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/alcionai/corso/src/pkg/credentials/secrets"
+)
+
+var _ secrets.Source = &Source{}
+
+// Source is an offline secret source for use in tests.
+type Source struct {
+	Values map[string]string
+	// Err, when set, is returned from every Load call.
+	Err error
+	// Requested records the names passed to each Load call.
+	Requested [][]string
+}
+
+func (s *Source) Load(_ context.Context, names []string) (map[string]string, error) {
+	s.Requested = append(s.Requested, names)
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	res := map[string]string{}
+
+	for _, n := range names {
+		if v, ok := s.Values[n]; ok {
+			res[n] = v
+		}
+	}
+
+	return res, nil
+}
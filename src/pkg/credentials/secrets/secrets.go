@@ -0,0 +1,134 @@
+// Package secrets provides the sources from which corso can read its
+// credentials.  A source is selected by a uri:
+//
+//	env                         environment variables (the default)
+//	file:///path/to/file.json   a json object of secret names to values
+//	secretsmanager://<id|arn>   an AWS Secrets Manager secret holding a json
+//	                            object of secret names to values
+//	ssm://<path>                AWS SSM parameters named <path>/<secret name>,
+//	                            usually SecureStrings
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/alcionai/clues"
+)
+
+// envvar consts
+const (
+	// SourceEnv holds the uri of the secret source.
+	SourceEnv = "CORSO_SECRET_SOURCE"
+)
+
+const (
+	SchemeEnv            = "env"
+	SchemeFile           = "file"
+	SchemeSecretsManager = "secretsmanager"
+	SchemeSSM            = "ssm"
+)
+
+// Source retrieves secret values by name.
+type Source interface {
+	// Load returns the values of the named secrets.  Names that aren't
+	// held by the source are left out of the result; that is not an error.
+	Load(ctx context.Context, names []string) (map[string]string, error)
+}
+
+// FromURI produces the source described by the uri.  An empty uri
+// produces the env source.
+func FromURI(uri string) (Source, error) {
+	scheme, rest, _ := strings.Cut(uri, "://")
+
+	switch strings.ToLower(scheme) {
+	case "", SchemeEnv:
+		return Env{}, nil
+
+	case SchemeFile:
+		if len(rest) == 0 {
+			return nil, clues.New("file secret source requires a path")
+		}
+
+		return File{Path: rest}, nil
+
+	case SchemeSecretsManager:
+		if len(rest) == 0 {
+			return nil, clues.New("secrets manager secret source requires a secret id")
+		}
+
+		return NewSecretsManager(rest)
+
+	case SchemeSSM:
+		if len(strings.Trim(rest, "/")) == 0 {
+			return nil, clues.New("ssm secret source requires a parameter path")
+		}
+
+		return NewParameterStore(rest)
+	}
+
+	return nil, clues.New("unsupported secret source: [" + scheme + "]")
+}
+
+// ---------------------------------------------------------------------------
+// env
+// ---------------------------------------------------------------------------
+
+var _ Source = Env{}
+
+// Env reads secrets from environment variables of the same name.
+type Env struct{}
+
+func (Env) Load(_ context.Context, names []string) (map[string]string, error) {
+	res := map[string]string{}
+
+	for _, n := range names {
+		if v, ok := os.LookupEnv(n); ok {
+			res[n] = v
+		}
+	}
+
+	return res, nil
+}
+
+// ---------------------------------------------------------------------------
+// file
+// ---------------------------------------------------------------------------
+
+var _ Source = File{}
+
+// File reads secrets from a json file that holds an object of secret
+// names to values.
+type File struct {
+	Path string
+}
+
+func (f File) Load(ctx context.Context, names []string) (map[string]string, error) {
+	bs, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "reading secrets file")
+	}
+
+	return pick(ctx, bs, names)
+}
+
+// pick unmarshals a json object of secret values and keeps the named ones.
+func pick(ctx context.Context, bs []byte, names []string) (map[string]string, error) {
+	all := map[string]string{}
+
+	if err := json.Unmarshal(bs, &all); err != nil {
+		return nil, clues.WrapWC(ctx, err, "secrets must be a json object of string values")
+	}
+
+	res := map[string]string{}
+
+	for _, n := range names {
+		if v, ok := all[n]; ok {
+			res[n] = v
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,299 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type SecretsUnitSuite struct {
+	tester.Suite
+}
+
+func TestSecretsUnitSuite(t *testing.T) {
+	suite.Run(t, &SecretsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SecretsUnitSuite) TestFromURI() {
+	table := []struct {
+		name      string
+		uri       string
+		expect    Source
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "empty",
+			uri:       "",
+			expect:    Env{},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "env",
+			uri:       "env",
+			expect:    Env{},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "file",
+			uri:       "file:///tmp/secrets.json",
+			expect:    File{Path: "/tmp/secrets.json"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "file without path",
+			uri:       "file://",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "secrets manager without id",
+			uri:       "secretsmanager://",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "ssm without path",
+			uri:       "ssm://",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "ssm with root path",
+			uri:       "ssm:///",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "unknown scheme",
+			uri:       "vault://corso",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			src, err := FromURI(test.uri)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, src)
+		})
+	}
+}
+
+func (suite *SecretsUnitSuite) TestFromURI_parameterStorePath() {
+	table := []struct {
+		name   string
+		uri    string
+		expect string
+	}{
+		{
+			name:   "relative path",
+			uri:    "ssm://corso",
+			expect: "/corso/NAME",
+		},
+		{
+			name:   "absolute path",
+			uri:    "ssm:///corso",
+			expect: "/corso/NAME",
+		},
+		{
+			name:   "nested path with trailing slash",
+			uri:    "ssm:///corso/prod/",
+			expect: "/corso/prod/NAME",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			src, err := FromURI(test.uri)
+			require.NoError(t, err, clues.ToCore(err))
+			require.IsType(t, &ParameterStore{}, src)
+
+			assert.Equal(t, test.expect, src.(*ParameterStore).paramName("NAME"))
+		})
+	}
+}
+
+func (suite *SecretsUnitSuite) TestEnv() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	t.Setenv("CORSO_TEST_SECRET", "shh")
+
+	vs, err := Env{}.Load(ctx, []string{"CORSO_TEST_SECRET", "CORSO_TEST_MISSING"})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, map[string]string{"CORSO_TEST_SECRET": "shh"}, vs)
+}
+
+func (suite *SecretsUnitSuite) TestFile() {
+	var (
+		dir     = suite.T().TempDir()
+		good    = filepath.Join(dir, "good.json")
+		notJSON = filepath.Join(dir, "bad.json")
+	)
+
+	err := os.WriteFile(good, []byte(`{"A": "a", "B": "b"}`), 0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	err = os.WriteFile(notJSON, []byte(`A=a`), 0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name      string
+		path      string
+		expect    map[string]string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "good",
+			path:      good,
+			expect:    map[string]string{"A": "a"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "not json",
+			path:      notJSON,
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing file",
+			path:      filepath.Join(dir, "missing.json"),
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			vs, err := File{Path: test.path}.Load(ctx, []string{"A", "C"})
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, vs)
+		})
+	}
+}
+
+type mockSecretValueGetter struct {
+	value *string
+	err   error
+}
+
+func (m mockSecretValueGetter) GetSecretValueWithContext(
+	aws.Context,
+	*secretsmanager.GetSecretValueInput,
+	...request.Option,
+) (*secretsmanager.GetSecretValueOutput, error) {
+	return &secretsmanager.GetSecretValueOutput{SecretString: m.value}, m.err
+}
+
+func (suite *SecretsUnitSuite) TestSecretsManager() {
+	table := []struct {
+		name      string
+		client    mockSecretValueGetter
+		expect    map[string]string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "good",
+			client:    mockSecretValueGetter{value: aws.String(`{"A": "a", "B": "b"}`)},
+			expect:    map[string]string{"A": "a"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "binary secret",
+			client:    mockSecretValueGetter{},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "api error",
+			client:    mockSecretValueGetter{err: assert.AnError},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sm := &SecretsManager{client: test.client, secretID: "corso"}
+
+			vs, err := sm.Load(ctx, []string{"A", "C"})
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, vs)
+		})
+	}
+}
+
+type mockParametersGetter struct {
+	params map[string]string
+	calls  [][]string
+}
+
+func (m *mockParametersGetter) GetParametersWithContext(
+	_ context.Context,
+	input *ssm.GetParametersInput,
+	_ ...request.Option,
+) (*ssm.GetParametersOutput, error) {
+	var (
+		names = aws.StringValueSlice(input.Names)
+		resp  = &ssm.GetParametersOutput{}
+	)
+
+	m.calls = append(m.calls, names)
+
+	for _, n := range names {
+		v, ok := m.params[n]
+		if !ok {
+			resp.InvalidParameters = append(resp.InvalidParameters, aws.String(n))
+			continue
+		}
+
+		resp.Parameters = append(resp.Parameters, &ssm.Parameter{
+			Name:  aws.String(n),
+			Value: aws.String(v),
+		})
+	}
+
+	return resp, nil
+}
+
+func (suite *SecretsUnitSuite) TestParameterStore() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		client = &mockParametersGetter{
+			params: map[string]string{
+				"/corso/A": "a",
+				"/corso/L": "l",
+			},
+		}
+		ps    = &ParameterStore{client: client, path: "/corso/"}
+		names = []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L"}
+	)
+
+	vs, err := ps.Load(ctx, names)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, map[string]string{"A": "a", "L": "l"}, vs)
+
+	require.Len(t, client.calls, 2, "parameters are requested in batches")
+	assert.Len(t, client.calls[0], maxParametersPerCall)
+	assert.Equal(t, []string{"/corso/K", "/corso/L"}, client.calls[1])
+}
@@ -0,0 +1,64 @@
+package credentials_test
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/credentials/secrets/mock"
+)
+
+type SecretsUnitSuite struct {
+	tester.Suite
+}
+
+func TestSecretsUnitSuite(t *testing.T) {
+	suite.Run(t, &SecretsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SecretsUnitSuite) TestLoadSecrets() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	defer credentials.ClearSecrets()
+
+	t.Setenv(credentials.AzureClientID, "env-id")
+	t.Setenv(credentials.AzureClientSecret, "env-secret")
+	t.Setenv(credentials.CorsoPassphrase, "env-pass")
+
+	src := &mock.Source{
+		Values: map[string]string{
+			credentials.AzureClientSecret: "source-secret",
+			credentials.CorsoPassphrase:   "source-pass",
+			"EXTRA":                       "extra",
+		},
+	}
+
+	err := credentials.LoadSecrets(ctx, src, "EXTRA")
+	require.NoError(t, err, clues.ToCore(err))
+
+	require.Len(t, src.Requested, 1)
+	assert.Subset(t, src.Requested[0], append(credentials.SecretNames, "EXTRA"))
+
+	m365 := credentials.GetM365()
+	assert.Equal(t, "env-id", m365.AzureClientID, "falls back to env")
+	assert.Equal(t, "source-secret", m365.AzureClientSecret, "prefers the source")
+	assert.Equal(t, "source-pass", credentials.Lookup(credentials.CorsoPassphrase))
+	assert.Equal(t, "extra", credentials.Lookup("EXTRA"))
+
+	credentials.ClearSecrets()
+
+	assert.Equal(t, "env-pass", credentials.Lookup(credentials.CorsoPassphrase))
+
+	src.Err = assert.AnError
+
+	err = credentials.LoadSecrets(ctx, src)
+	assert.Error(t, err, clues.ToCore(err))
+}
@@ -0,0 +1,17 @@
+package credentials
+
+// envvar consts
+const (
+	SFTPPassword = "CORSO_SFTP_PASSWORD"
+	// SFTPKeyData holds a PEM encoded private key, used in place of a
+	// key file.
+	SFTPKeyData = "CORSO_SFTP_KEY_DATA"
+)
+
+// SFTP aggregates sftp server credentials from flag and env_var values.
+// Either the password or a private key is needed to authenticate.  The
+// key may also be provided as a file through the sftp storage config.
+type SFTP struct {
+	Password string
+	KeyData  string
+}
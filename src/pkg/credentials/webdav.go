@@ -0,0 +1,14 @@
+package credentials
+
+// envvar consts
+const (
+	WebDAVUsername = "CORSO_WEBDAV_USERNAME"
+	WebDAVPassword = "CORSO_WEBDAV_PASSWORD"
+)
+
+// WebDAV aggregates webdav server credentials from flag and env_var values.
+// Both values are optional; servers without authentication are allowed.
+type WebDAV struct {
+	Username string
+	Password string
+}
@@ -96,6 +96,10 @@ var (
 	// of this service (but may have purchased the use of other services in
 	// the same provider).
 	ErrServiceNotEnabled = &Err{msg: "service not enabled"}
+	// an operation that was given a checkpoint deliberately stopped processing
+	// when it reached that checkpoint.  The work completed up to that point
+	// was kept, and the operation can be run again to pick up the remainder.
+	ErrStoppedAtCheckpoint = &Err{msg: "stopped at checkpoint"}
 )
 
 // As is a quality-of-life wrapper around errors.As, to retrieve the core.Err
@@ -59,7 +59,9 @@ func writeItem(ctx context.Context, item Item, folder string) error {
 	defer item.Body.Close()
 	defer progReader.Close()
 
-	err := os.MkdirAll(folder, os.ModePerm)
+	// item names can include subfolders, ex: the resources of an
+	// exported notebook page.
+	err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm)
 	if err != nil {
 		return clues.WrapWC(ctx, err, "creating directory")
 	}
@@ -5,6 +5,7 @@ import (
 )
 
 const (
+	AlertBackupAnomaly         = "backup_anomaly"
 	AlertPreviousPathCollision = "previous_path_collision"
 )
 
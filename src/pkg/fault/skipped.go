@@ -36,6 +36,12 @@ const (
 	// SkipInvalidRecipients identifies that an email was skipped because Exchange
 	// believes it is not valid and fails any attempt to read it.
 	SkipInvalidRecipients skipCause = "invalid_recipients_email"
+
+	// SkipNotebooksInaccessible identifies that the OneNote notebooks of a
+	// user or site were skipped because graph denies access to all of them,
+	// either because the Notes.Read.All permission wasn't granted or because
+	// the resource isn't licensed for OneNote.
+	SkipNotebooksInaccessible skipCause = "inaccessible_notebooks"
 )
 
 var _ print.Printable = &Skipped{}
@@ -0,0 +1,123 @@
+package maintenance
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+// Result records a single run of repository maintenance.
+type Result struct {
+	model.BaseModel
+	stats.StartAndEndTime
+
+	Type  repository.MaintenanceType `json:"type"`
+	Force bool                       `json:"force,omitempty"`
+	// Status is the human readable status of the maintenance operation.
+	Status string `json:"status"`
+	// Failure holds the error that stopped maintenance, if any.
+	Failure string `json:"failure,omitempty"`
+
+	// StorageMeasured is true if the repository storage was measured over
+	// the run, in which case BlobsDeleted and BytesReclaimed are populated.
+	StorageMeasured bool `json:"storageMeasured,omitempty"`
+	// BlobsDeleted and BytesReclaimed are the net change in the blobs held
+	// by the repository storage over the run.  Backups which write to the
+	// repository while maintenance runs reduce both values, so they're a
+	// lower bound on the space actually reclaimed.
+	BlobsDeleted   int64 `json:"blobsDeleted"`
+	BytesReclaimed int64 `json:"bytesReclaimed"`
+}
+
+// interface compliance checks
+var _ print.Printable = &Result{}
+
+// Sort orders the results newest first.
+func Sort(rs []*Result) {
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].StartedAt.After(rs[j].StartedAt)
+	})
+}
+
+// --------------------------------------------------------------------------------
+// CLI Output
+// --------------------------------------------------------------------------------
+
+// PrintAll writes the slice of Results to StdOut, in the format requested
+// by the caller.
+func PrintAll(ctx context.Context, rs []*Result) {
+	if len(rs) == 0 {
+		print.Info(ctx, "No maintenance runs recorded")
+		return
+	}
+
+	ps := []print.Printable{}
+	for _, r := range rs {
+		ps = append(ps, print.Printable(r))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the Result to its minimally printable details.
+func (r Result) MinimumPrintable() any {
+	return r
+}
+
+// Headers returns the human-readable names of properties in a Result
+// for printing out to a terminal in a columnar display.
+func (r Result) Headers(skipID bool) []string {
+	headers := []string{
+		"Started At",
+		"Duration",
+		"Type",
+		"Status",
+		"Blobs Deleted",
+		"Bytes Reclaimed",
+	}
+
+	if skipID {
+		return headers
+	}
+
+	return append([]string{"ID"}, headers...)
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (r Result) Values(skipID bool) []string {
+	status := r.Status
+	if len(r.Failure) > 0 {
+		status += " (" + r.Failure + ")"
+	}
+
+	blobs, bytes := "-", "-"
+	if r.StorageMeasured {
+		blobs = strconv.FormatInt(r.BlobsDeleted, 10)
+		bytes = humanize.Bytes(uint64(max(r.BytesReclaimed, 0)))
+	}
+
+	values := []string{
+		dttm.FormatToTabularDisplay(r.StartedAt),
+		r.CompletedAt.Sub(r.StartedAt).Round(time.Second).String(),
+		r.Type.String(),
+		status,
+		blobs,
+		bytes,
+	}
+
+	if skipID {
+		return values
+	}
+
+	return append([]string{string(r.ID)}, values...)
+}
@@ -28,6 +28,11 @@ const (
 	DetailsCategory           CategoryType = 8  // details
 	ChannelMessagesCategory   CategoryType = 9  // channelMessages
 	ConversationPostsCategory CategoryType = 10 // conversationPosts
+	ChatsCategory             CategoryType = 11 // chats
+	NotebooksCategory         CategoryType = 12 // notebooks
+	PlannerTasksCategory      CategoryType = 13 // plannerTasks
+	DirectoryObjectsCategory  CategoryType = 14 // directoryObjects
+	MailboxSettingsCategory   CategoryType = 15 // mailboxSettings
 )
 
 var strToCat = map[string]CategoryType{
@@ -41,6 +46,11 @@ var strToCat = map[string]CategoryType{
 	strings.ToLower(DetailsCategory.String()):           DetailsCategory,
 	strings.ToLower(ChannelMessagesCategory.String()):   ChannelMessagesCategory,
 	strings.ToLower(ConversationPostsCategory.String()): ConversationPostsCategory,
+	strings.ToLower(ChatsCategory.String()):             ChatsCategory,
+	strings.ToLower(NotebooksCategory.String()):         NotebooksCategory,
+	strings.ToLower(PlannerTasksCategory.String()):      PlannerTasksCategory,
+	strings.ToLower(DirectoryObjectsCategory.String()):  DirectoryObjectsCategory,
+	strings.ToLower(MailboxSettingsCategory.String()):   MailboxSettingsCategory,
 }
 
 func ToCategoryType(s string) CategoryType {
@@ -63,6 +73,11 @@ var catToHuman = map[CategoryType]string{
 	DetailsCategory:           "Details",
 	ChannelMessagesCategory:   "Messages",
 	ConversationPostsCategory: "Posts",
+	ChatsCategory:             "Chats",
+	NotebooksCategory:         "Notebooks",
+	PlannerTasksCategory:      "Planner",
+	DirectoryObjectsCategory:  "Directory",
+	MailboxSettingsCategory:   "Mailbox Settings",
 }
 
 // HumanString produces a more human-readable string version of the category.
@@ -83,22 +98,32 @@ func (cat CategoryType) HumanString() string {
 // non-metadata paths.
 var serviceCategories = map[ServiceType]map[CategoryType]struct{}{
 	ExchangeService: {
-		EmailCategory:    {},
-		ContactsCategory: {},
-		EventsCategory:   {},
+		EmailCategory:           {},
+		ContactsCategory:        {},
+		EventsCategory:          {},
+		MailboxSettingsCategory: {},
 	},
 	OneDriveService: {
-		FilesCategory: {},
+		FilesCategory:     {},
+		NotebooksCategory: {},
 	},
 	SharePointService: {
 		LibrariesCategory: {},
 		ListsCategory:     {},
 		PagesCategory:     {},
+		NotebooksCategory: {},
 	},
 	GroupsService: {
 		ChannelMessagesCategory:   {},
 		ConversationPostsCategory: {},
 		LibrariesCategory:         {},
+		PlannerTasksCategory:      {},
+	},
+	TeamsChatsService: {
+		ChatsCategory: {},
+	},
+	EntraIDService: {
+		DirectoryObjectsCategory: {},
 	},
 }
 
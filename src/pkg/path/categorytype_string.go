@@ -19,11 +19,16 @@ func _() {
 	_ = x[DetailsCategory-8]
 	_ = x[ChannelMessagesCategory-9]
 	_ = x[ConversationPostsCategory-10]
+	_ = x[ChatsCategory-11]
+	_ = x[NotebooksCategory-12]
+	_ = x[PlannerTasksCategory-13]
+	_ = x[DirectoryObjectsCategory-14]
+	_ = x[MailboxSettingsCategory-15]
 }
 
-const _CategoryType_name = "UnknownCategoryemailcontactseventsfileslistslibrariespagesdetailschannelMessagesconversationPosts"
+const _CategoryType_name = "UnknownCategoryemailcontactseventsfileslistslibrariespagesdetailschannelMessagesconversationPostschatsnotebooksplannerTasksdirectoryObjectsmailboxSettings"
 
-var _CategoryType_index = [...]uint8{0, 15, 20, 28, 34, 39, 44, 53, 58, 65, 80, 97}
+var _CategoryType_index = [...]uint8{0, 15, 20, 28, 34, 39, 44, 53, 58, 65, 80, 97, 102, 111, 123, 139, 154}
 
 func (i CategoryType) String() string {
 	if i < 0 || i >= CategoryType(len(_CategoryType_index)-1) {
@@ -15,10 +15,14 @@ var piiSafePathElems = pii.MapWithPlurals(
 	OneDriveService.String(),
 	GroupsService.String(),
 	SharePointService.String(),
+	TeamsChatsService.String(),
+	EntraIDService.String(),
 	ExchangeMetadataService.String(),
 	OneDriveMetadataService.String(),
 	SharePointMetadataService.String(),
 	GroupsMetadataService.String(),
+	TeamsChatsMetadataService.String(),
+	EntraIDMetadataService.String(),
 
 	// categories
 	UnknownCategory.String(),
@@ -30,6 +34,11 @@ var piiSafePathElems = pii.MapWithPlurals(
 	LibrariesCategory.String(),
 	PagesCategory.String(),
 	DetailsCategory.String(),
+	ChatsCategory.String(),
+	NotebooksCategory.String(),
+	PlannerTasksCategory.String(),
+	DirectoryObjectsCategory.String(),
+	MailboxSettingsCategory.String(),
 
 	// other internal values
 	"fault_error", // streamstore.FaultErrorType causes an import cycle
@@ -102,6 +102,14 @@ func (suite *ServiceCategoryUnitSuite) TestValidateServiceAndCategory() {
 			expectedCategory: EventsCategory,
 			check:            assert.NoError,
 		},
+		{
+			name:             "ExchangeMailboxSettings",
+			service:          ExchangeService.String(),
+			category:         MailboxSettingsCategory.String(),
+			expectedService:  ExchangeService,
+			expectedCategory: MailboxSettingsCategory,
+			check:            assert.NoError,
+		},
 		{
 			name:             "OneDriveFiles",
 			service:          OneDriveService.String(),
@@ -110,6 +118,14 @@ func (suite *ServiceCategoryUnitSuite) TestValidateServiceAndCategory() {
 			expectedCategory: FilesCategory,
 			check:            assert.NoError,
 		},
+		{
+			name:             "OneDriveNotebooks",
+			service:          OneDriveService.String(),
+			category:         NotebooksCategory.String(),
+			expectedService:  OneDriveService,
+			expectedCategory: NotebooksCategory,
+			check:            assert.NoError,
+		},
 		{
 			name:             "SharePointLibraries",
 			service:          SharePointService.String(),
@@ -118,6 +134,38 @@ func (suite *ServiceCategoryUnitSuite) TestValidateServiceAndCategory() {
 			expectedCategory: LibrariesCategory,
 			check:            assert.NoError,
 		},
+		{
+			name:             "SharePointNotebooks",
+			service:          SharePointService.String(),
+			category:         NotebooksCategory.String(),
+			expectedService:  SharePointService,
+			expectedCategory: NotebooksCategory,
+			check:            assert.NoError,
+		},
+		{
+			name:             "GroupsPlannerTasks",
+			service:          GroupsService.String(),
+			category:         PlannerTasksCategory.String(),
+			expectedService:  GroupsService,
+			expectedCategory: PlannerTasksCategory,
+			check:            assert.NoError,
+		},
+		{
+			name:             "TeamsChatsChats",
+			service:          TeamsChatsService.String(),
+			category:         ChatsCategory.String(),
+			expectedService:  TeamsChatsService,
+			expectedCategory: ChatsCategory,
+			check:            assert.NoError,
+		},
+		{
+			name:             "EntraIDDirectoryObjects",
+			service:          EntraIDService.String(),
+			category:         DirectoryObjectsCategory.String(),
+			expectedService:  EntraIDService,
+			expectedCategory: DirectoryObjectsCategory,
+			check:            assert.NoError,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -209,6 +257,14 @@ func (suite *ServiceCategoryUnitSuite) TestToMetadata() {
 			input:  GroupsService,
 			expect: GroupsMetadataService,
 		},
+		{
+			input:  TeamsChatsService,
+			expect: TeamsChatsMetadataService,
+		},
+		{
+			input:  EntraIDService,
+			expect: EntraIDMetadataService,
+		},
 		{
 			input:  UnknownService,
 			expect: UnknownService,
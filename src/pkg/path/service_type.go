@@ -23,14 +23,18 @@ type ServiceType int
 //go:generate stringer -type=ServiceType -linecomment
 const (
 	UnknownService            ServiceType = 0
-	ExchangeService           ServiceType = 1 // exchange
-	OneDriveService           ServiceType = 2 // onedrive
-	SharePointService         ServiceType = 3 // sharepoint
-	ExchangeMetadataService   ServiceType = 4 // exchangeMetadata
-	OneDriveMetadataService   ServiceType = 5 // onedriveMetadata
-	SharePointMetadataService ServiceType = 6 // sharepointMetadata
-	GroupsService             ServiceType = 7 // groups
-	GroupsMetadataService     ServiceType = 8 // groupsMetadata
+	ExchangeService           ServiceType = 1  // exchange
+	OneDriveService           ServiceType = 2  // onedrive
+	SharePointService         ServiceType = 3  // sharepoint
+	ExchangeMetadataService   ServiceType = 4  // exchangeMetadata
+	OneDriveMetadataService   ServiceType = 5  // onedriveMetadata
+	SharePointMetadataService ServiceType = 6  // sharepointMetadata
+	GroupsService             ServiceType = 7  // groups
+	GroupsMetadataService     ServiceType = 8  // groupsMetadata
+	TeamsChatsService         ServiceType = 9  // teamsChats
+	TeamsChatsMetadataService ServiceType = 10 // teamsChatsMetadata
+	EntraIDService            ServiceType = 11 // entraID
+	EntraIDMetadataService    ServiceType = 12 // entraIDMetadata
 )
 
 func ToServiceType(service string) ServiceType {
@@ -45,6 +49,10 @@ func ToServiceType(service string) ServiceType {
 		return SharePointService
 	case strings.ToLower(GroupsService.String()):
 		return GroupsService
+	case strings.ToLower(TeamsChatsService.String()):
+		return TeamsChatsService
+	case strings.ToLower(EntraIDService.String()):
+		return EntraIDService
 	case strings.ToLower(ExchangeMetadataService.String()):
 		return ExchangeMetadataService
 	case strings.ToLower(OneDriveMetadataService.String()):
@@ -53,6 +61,10 @@ func ToServiceType(service string) ServiceType {
 		return SharePointMetadataService
 	case strings.ToLower(GroupsMetadataService.String()):
 		return GroupsMetadataService
+	case strings.ToLower(TeamsChatsMetadataService.String()):
+		return TeamsChatsMetadataService
+	case strings.ToLower(EntraIDMetadataService.String()):
+		return EntraIDMetadataService
 	default:
 		return UnknownService
 	}
@@ -63,6 +75,8 @@ var serviceToHuman = map[ServiceType]string{
 	OneDriveService:   "OneDrive",
 	SharePointService: "SharePoint",
 	GroupsService:     "Groups",
+	TeamsChatsService: "Chats",
+	EntraIDService:    "Entra ID",
 }
 
 // HumanString produces a more human-readable string version of the service.
@@ -85,6 +99,10 @@ func (svc ServiceType) ToMetadata() ServiceType {
 		return SharePointMetadataService
 	case GroupsService:
 		return GroupsMetadataService
+	case TeamsChatsService:
+		return TeamsChatsMetadataService
+	case EntraIDService:
+		return EntraIDMetadataService
 	}
 
 	return UnknownService
@@ -32,6 +32,11 @@ type BackupGetter interface {
 		ctx context.Context,
 		backupID string,
 	) (*details.Details, *backup.Backup, *fault.Bus)
+	GetFilteredBackupDetails(
+		ctx context.Context,
+		backupID string,
+		filter details.ChunkFilter,
+	) (*details.Details, *backup.Backup, *fault.Bus)
 	GetBackupErrors(
 		ctx context.Context,
 		backupID string,
@@ -177,6 +182,18 @@ func backupsByTag(
 func (r repository) GetBackupDetails(
 	ctx context.Context,
 	backupID string,
+) (*details.Details, *backup.Backup, *fault.Bus) {
+	return r.GetFilteredBackupDetails(ctx, backupID, details.ChunkFilter{})
+}
+
+// GetFilteredBackupDetails returns the entries of the specified
+// backup.Details that match the filter.  Only the parts of the details
+// that can hold matching entries are read.  Backups that predate chunked
+// details are read in full, and aren't filtered.
+func (r repository) GetFilteredBackupDetails(
+	ctx context.Context,
+	backupID string,
+	filter details.ChunkFilter,
 ) (*details.Details, *backup.Backup, *fault.Bus) {
 	errs := fault.New(false)
 
@@ -186,6 +203,7 @@ func (r repository) GetBackupDetails(
 		r.Account.ID(),
 		r.dataLayer,
 		store.NewWrapper(r.modelStore),
+		filter,
 		errs)
 
 	return deets, bup, errs.Fail(err)
@@ -197,6 +215,7 @@ func getBackupDetails(
 	backupID, tenantID string,
 	kw *kopia.Wrapper,
 	sw store.BackupGetter,
+	filter details.ChunkFilter,
 	errs *fault.Bus,
 ) (*details.Details, *backup.Backup, error) {
 	b, err := sw.GetBackup(ctx, model.StableID(backupID))
@@ -216,13 +235,14 @@ func getBackupDetails(
 	var (
 		sstore = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
 		deets  details.Details
+		umt    = streamstore.DetailsReader(details.UnmarshalTo(&deets))
 	)
 
-	err = sstore.Read(
-		ctx,
-		ssid,
-		streamstore.DetailsReader(details.UnmarshalTo(&deets)),
-		errs)
+	if b.DetailsFormat == details.ChunkedFormat {
+		umt = streamstore.ChunkedDetailsReader(details.NewChunkReader(&deets, filter))
+	}
+
+	err = sstore.Read(ctx, ssid, umt, errs)
 	if err != nil {
 		return nil, nil, err
 	}
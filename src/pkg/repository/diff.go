@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// BackupDiffer compares backups of the same protected resource.
+type BackupDiffer interface {
+	DiffBackups(
+		ctx context.Context,
+		backupID, otherBackupID string,
+	) (*details.DiffReport, *fault.Bus)
+}
+
+// DiffBackups reports the items that were added, removed, modified, or
+// moved between two backups of the same protected resource.  The backups
+// can be given in either order; the report always describes the changes
+// from the older backup to the newer one.
+func (r repository) DiffBackups(
+	ctx context.Context,
+	backupID, otherBackupID string,
+) (*details.DiffReport, *fault.Bus) {
+	errs := fault.New(false)
+
+	report, err := diffBackups(
+		ctx,
+		backupID, otherBackupID,
+		r.Account.ID(),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		errs)
+
+	return report, errs.Fail(err)
+}
+
+// diffBackups handles the processing for DiffBackups.
+func diffBackups(
+	ctx context.Context,
+	backupID, otherBackupID, tenantID string,
+	kw *kopia.Wrapper,
+	sw store.BackupGetter,
+	errs *fault.Bus,
+) (*details.DiffReport, error) {
+	if backupID == otherBackupID {
+		return nil, clues.NewWC(ctx, "can't compare a backup with itself")
+	}
+
+	ctx = clues.Add(ctx, "backup_id", backupID, "other_backup_id", otherBackupID)
+
+	deets, bup, err := getBackupDetails(ctx, backupID, tenantID, kw, sw, details.ChunkFilter{}, errs)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting backup details")
+	}
+
+	otherDeets, otherBup, err := getBackupDetails(ctx, otherBackupID, tenantID, kw, sw, details.ChunkFilter{}, errs)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting other backup details")
+	}
+
+	if bup.Selector.PathService() != otherBup.Selector.PathService() ||
+		bup.Selector.DiscreteOwner != otherBup.Selector.DiscreteOwner {
+		return nil, clues.NewWC(ctx, "backups are of different protected resources").
+			With(
+				"service", bup.Selector.PathService(),
+				"other_service", otherBup.Selector.PathService())
+	}
+
+	if otherBup.CreationTime.Before(bup.CreationTime) {
+		deets, otherDeets = otherDeets, deets
+		bup, otherBup = otherBup, bup
+	}
+
+	report := details.Diff(deets, otherDeets)
+	report.FromBackupID = string(bup.ID)
+	report.ToBackupID = string(otherBup.ID)
+
+	return &report, nil
+}
@@ -21,6 +21,7 @@ import (
 	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/maintenance"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/storage"
 	"github.com/alcionai/corso/src/pkg/store"
@@ -35,7 +36,10 @@ var (
 
 type Repositoryer interface {
 	Backuper
+	BackupDiffer
 	BackupGetter
+	BackupPruner
+	BackupSearcher
 	Restorer
 	Exporter
 	Debugger
@@ -56,6 +60,7 @@ type Repositoryer interface {
 		ctx context.Context,
 		mOpts ctrlRepo.Maintenance,
 	) (operations.MaintenanceOperation, error)
+	MaintenanceHistory(ctx context.Context) ([]*maintenance.Result, error)
 	NewRetentionConfig(
 		ctx context.Context,
 		rcOpts ctrlRepo.Retention,
@@ -274,6 +279,11 @@ func (r repository) NewMaintenance(
 		r.Bus)
 }
 
+// MaintenanceHistory lists the recorded maintenance runs, newest first.
+func (r repository) MaintenanceHistory(ctx context.Context) ([]*maintenance.Result, error) {
+	return store.NewWrapper(r.modelStore).GetMaintenanceResults(ctx)
+}
+
 func (r repository) NewRetentionConfig(
 	ctx context.Context,
 	rcOpts ctrlRepo.Retention,
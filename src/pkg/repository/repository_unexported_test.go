@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
@@ -23,6 +24,8 @@ import (
 	"github.com/alcionai/corso/src/internal/version"
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/retention"
+	"github.com/alcionai/corso/src/pkg/backup/search"
 	rep "github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -677,6 +680,108 @@ func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 	}
 }
 
+type mockPruneStore struct {
+	mockBackupList
+	policies []*retention.Policy
+	deleted  []manifest.ID
+}
+
+func (m *mockPruneStore) GetBackup(
+	_ context.Context,
+	id model.StableID,
+) (*backup.Backup, error) {
+	for _, b := range m.backups {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+
+	return nil, clues.Stack(data.ErrNotFound)
+}
+
+func (m *mockPruneStore) GetRetentionPolicies(
+	context.Context,
+) ([]*retention.Policy, error) {
+	return m.policies, nil
+}
+
+func (m *mockPruneStore) DeleteWithModelStoreIDs(
+	_ context.Context,
+	ids ...manifest.ID,
+) error {
+	m.deleted = append(m.deleted, ids...)
+	return nil
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestPruneBackups() {
+	now := time.Now()
+
+	makeBup := func(id string, age time.Duration, backupType string) *backup.Backup {
+		return &backup.Backup{
+			BaseModel: model.BaseModel{
+				ID:           model.StableID(id),
+				ModelStoreID: manifest.ID(id + "-msid"),
+				Tags: map[string]string{
+					model.ServiceTag:    path.ExchangeService.String(),
+					model.BackupTypeTag: backupType,
+				},
+			},
+			CreationTime:        now.Add(-age),
+			ProtectedResourceID: "u1",
+			SnapshotID:          id + "-dsid",
+			StreamStoreID:       id + "-ssid",
+		}
+	}
+
+	bups := []*backup.Backup{
+		makeBup("new", time.Hour, model.MergeBackup),
+		makeBup("assist", 2*time.Hour, model.AssistBackup),
+		makeBup("mid", 3*time.Hour, model.MergeBackup),
+		makeBup("old", 4*time.Hour, model.MergeBackup),
+	}
+
+	table := []struct {
+		name         string
+		dryRun       bool
+		expectDelete []manifest.ID
+	}{
+		{
+			name:   "dry run",
+			dryRun: true,
+		},
+		{
+			name: "prune",
+			expectDelete: []manifest.ID{
+				"old-msid",
+				"old-dsid",
+				"old-ssid",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			m := &mockPruneStore{
+				mockBackupList: mockBackupList{backups: bups},
+				policies:       []*retention.Policy{{KeepLast: 2}},
+			}
+
+			report, err := pruneBackups(ctx, m, test.dryRun, now)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.dryRun, report.DryRun)
+			// assist backups are left for maintenance to clean up.
+			assert.Len(t, report.Decisions, 3)
+			assert.Equal(t, []string{"old"}, report.Pruned())
+			assert.ElementsMatch(t, test.expectDelete, m.deleted)
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // integration
 // ---------------------------------------------------------------------------
@@ -795,17 +900,29 @@ func writeBackup(
 	sel selectors.Selector,
 	ownerID, ownerName string,
 	deets *details.Details,
+	detailsFormat int,
+	idx *search.ShardWriter,
 	fe *fault.Errors,
 	errs *fault.Bus,
 ) *backup.Backup {
 	var (
 		serv   = sel.PathService()
 		sstore = streamstore.NewStreamer(kw, tID, serv)
+		dc     = streamstore.DetailsCollector(deets)
 	)
 
-	err := sstore.Collect(ctx, streamstore.DetailsCollector(deets))
+	if detailsFormat == details.ChunkedFormat {
+		dc = streamstore.ChunkedDetailsCollector(details.NewChunkWriter(deets, details.DefaultChunkSize))
+	}
+
+	err := sstore.Collect(ctx, dc)
 	require.NoError(t, err, "collecting details in streamstore")
 
+	if idx != nil {
+		err = sstore.Collect(ctx, streamstore.SearchIndexCollector(idx))
+		require.NoError(t, err, "collecting search index in streamstore")
+	}
+
 	err = sstore.Collect(ctx, streamstore.FaultErrorsCollector(fe))
 	require.NoError(t, err, "collecting errors in streamstore")
 
@@ -828,6 +945,9 @@ func writeBackup(
 		fe,
 		tags)
 
+	b.DetailsFormat = detailsFormat
+	b.HasSearchIndex = idx != nil
+
 	err = sw.Put(ctx, model.BackupSchema, b)
 	require.NoError(t, err)
 
@@ -855,11 +975,12 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupDetails() {
 	require.NoError(suite.T(), builder.Add(repoPath, loc, info))
 
 	table := []struct {
-		name       string
-		writeBupID string
-		readBupID  string
-		deets      *details.Details
-		expectErr  require.ErrorAssertionFunc
+		name          string
+		writeBupID    string
+		readBupID     string
+		deets         *details.Details
+		detailsFormat int
+		expectErr     require.ErrorAssertionFunc
 	}{
 		{
 			name:       "good",
@@ -868,6 +989,14 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupDetails() {
 			deets:      builder.Details(),
 			expectErr:  require.NoError,
 		},
+		{
+			name:          "good chunked",
+			writeBupID:    "hedgehogs",
+			readBupID:     "hedgehogs",
+			deets:         builder.Details(),
+			detailsFormat: details.ChunkedFormat,
+			expectErr:     require.NoError,
+		},
 		{
 			name:       "missing backup",
 			writeBupID: "chipmunks",
@@ -892,10 +1021,19 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupDetails() {
 				selectors.NewExchangeBackup([]string{brunhilda}).Selector,
 				brunhilda, brunhilda,
 				test.deets,
+				test.detailsFormat,
+				nil,
 				&fault.Errors{},
 				fault.New(true))
 
-			rDeets, rBup, err := getBackupDetails(ctx, test.readBupID, tenantID, suite.kw, suite.sw, fault.New(true))
+			rDeets, rBup, err := getBackupDetails(
+				ctx,
+				test.readBupID,
+				tenantID,
+				suite.kw,
+				suite.sw,
+				details.ChunkFilter{},
+				fault.New(true))
 			test.expectErr(t, err)
 
 			if err != nil {
@@ -999,6 +1137,8 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupErrors() {
 				selectors.NewExchangeBackup([]string{brunhilda}).Selector,
 				brunhilda, brunhilda,
 				test.deets,
+				details.LegacyFormat,
+				nil,
 				test.errors,
 				fault.New(failFast))
 
@@ -1014,3 +1154,250 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupErrors() {
 		})
 	}
 }
+
+func (suite *RepositoryModelIntgSuite) TestSearchBackups() {
+	const (
+		brunhilda = "brunhilda"
+		tenantID  = "tenant"
+	)
+
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		builder = &details.Builder{}
+		sb      = search.NewBuilder()
+		sel     = selectors.NewExchangeBackup([]string{brunhilda}).Selector
+		refs    = []string{}
+	)
+
+	for _, subject := range []string{"falcon budget", "lunch"} {
+		repoPath, err := path.FromDataLayerPath(tenantID+"/exchange/user-id/email/inbox/"+uuid.NewString(), true)
+		require.NoError(t, err, clues.ToCore(err))
+
+		info := details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType: details.ExchangeMail,
+				Subject:  subject,
+			},
+		}
+
+		err = builder.Add(repoPath, path.Builder{}.Append(repoPath.Folders()...), info)
+		require.NoError(t, err, clues.ToCore(err))
+
+		refs = append(refs, repoPath.String())
+	}
+
+	deets := builder.Details()
+	// one item per shard, so that every shard gets searched.
+	idx := sb.Shards(deets, 1)
+
+	indexed := writeBackup(
+		t,
+		ctx,
+		suite.kw,
+		suite.sw,
+		tenantID, "snapID", uuid.NewString(),
+		sel,
+		brunhilda, brunhilda,
+		deets,
+		details.ChunkedFormat,
+		idx,
+		&fault.Errors{},
+		fault.New(true))
+
+	unindexed := writeBackup(
+		t,
+		ctx,
+		suite.kw,
+		suite.sw,
+		tenantID, "snapID", uuid.NewString(),
+		sel,
+		brunhilda, brunhilda,
+		deets,
+		details.ChunkedFormat,
+		nil,
+		&fault.Errors{},
+		fault.New(true))
+
+	table := []struct {
+		name       string
+		query      search.Query
+		expectRefs []string
+		expectErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "match",
+			query: search.Query{
+				Text:      "FALCON",
+				BackupIDs: []string{string(indexed.ID), string(unindexed.ID)},
+			},
+			expectRefs: []string{refs[0]},
+			expectErr:  assert.NoError,
+		},
+		{
+			name: "no match",
+			query: search.Query{
+				Text:      "falcon lunch",
+				BackupIDs: []string{string(indexed.ID)},
+			},
+			expectRefs: []string{},
+			expectErr:  assert.NoError,
+		},
+		{
+			name: "other resource",
+			query: search.Query{
+				Text:               "falcon",
+				BackupIDs:          []string{string(indexed.ID)},
+				ProtectedResources: []string{"siegfried"},
+			},
+			expectRefs: []string{},
+			expectErr:  assert.NoError,
+		},
+		{
+			name: "no terms",
+			query: search.Query{
+				BackupIDs: []string{string(indexed.ID)},
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			rs, err := searchBackups(ctx, test.query, tenantID, suite.kw, suite.sw, fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			rRefs := []string{}
+
+			for _, r := range rs {
+				assert.Equal(t, string(indexed.ID), r.BackupID)
+				rRefs = append(rRefs, r.Entry.RepoRef)
+			}
+
+			assert.ElementsMatch(t, test.expectRefs, rRefs)
+		})
+	}
+}
+
+func (suite *RepositoryModelIntgSuite) TestDiffBackups() {
+	const (
+		brunhilda = "brunhilda"
+		siegfried = "siegfried"
+		tenantID  = "tenant"
+	)
+
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	repoPath, err := path.FromDataLayerPath(tenantID+"/exchange/user-id/email/inbox/"+uuid.NewString(), true)
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		builder = &details.Builder{}
+		sel     = selectors.NewExchangeBackup([]string{brunhilda}).Selector
+		info    = details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType: details.ExchangeMail,
+				Subject:  "falcon",
+			},
+		}
+	)
+
+	err = builder.Add(repoPath, path.Builder{}.Append(repoPath.Folders()...), info)
+	require.NoError(t, err, clues.ToCore(err))
+
+	write := func(sel selectors.Selector, deets *details.Details) string {
+		b := writeBackup(
+			t,
+			ctx,
+			suite.kw,
+			suite.sw,
+			tenantID, "snapID", uuid.NewString(),
+			sel,
+			sel.DiscreteOwner, sel.DiscreteOwner,
+			deets,
+			details.ChunkedFormat,
+			nil,
+			&fault.Errors{},
+			fault.New(true))
+
+		return string(b.ID)
+	}
+
+	var (
+		empty = write(sel, &details.Details{})
+		full  = write(sel, builder.Details())
+		other = write(selectors.NewExchangeBackup([]string{siegfried}).Selector, builder.Details())
+	)
+
+	table := []struct {
+		name          string
+		ids           []string
+		expectAdded   int
+		expectRemoved int
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "older first",
+			ids:         []string{empty, full},
+			expectAdded: 1,
+			expectErr:   assert.NoError,
+		},
+		{
+			name:        "newer first",
+			ids:         []string{full, empty},
+			expectAdded: 1,
+			expectErr:   assert.NoError,
+		},
+		{
+			name:      "same backup",
+			ids:       []string{full, full},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "different resources",
+			ids:       []string{full, other},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing backup",
+			ids:       []string{full, "weasels"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			report, err := diffBackups(ctx, test.ids[0], test.ids[1], tenantID, suite.kw, suite.sw, fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, empty, report.FromBackupID)
+			assert.Equal(t, full, report.ToBackupID)
+
+			total := report.Total()
+			assert.Equal(t, test.expectAdded, total.Added)
+			assert.Equal(t, test.expectRemoved, total.Removed)
+		})
+	}
+}
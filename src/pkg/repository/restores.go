@@ -5,10 +5,14 @@ import (
 
 	"github.com/alcionai/clues"
 
+	"github.com/alcionai/corso/src/internal/m365"
 	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/store"
 )
@@ -20,6 +24,13 @@ type Restorer interface {
 		sel selectors.Selector,
 		restoreCfg control.RestoreConfig,
 	) (operations.RestoreOperation, error)
+	NewRestoreToAccount(
+		ctx context.Context,
+		backupID string,
+		sel selectors.Selector,
+		restoreCfg control.RestoreConfig,
+		restoreAcct account.Account,
+	) (operations.RestoreOperation, error)
 }
 
 // NewRestore generates a restoreOperation runner.
@@ -47,3 +58,67 @@ func (r repository) NewRestore(
 		r.Bus,
 		count.New())
 }
+
+// NewRestoreToAccount generates a restoreOperation runner that restores
+// the backup into the tenant of restoreAcct instead of the tenant the
+// repository was connected with.  Use the restoreCfg.ResourceMapping to
+// translate the users, groups and sites of the backed up tenant into
+// those of the restore tenant.
+func (r repository) NewRestoreToAccount(
+	ctx context.Context,
+	backupID string,
+	sel selectors.Selector,
+	restoreCfg control.RestoreConfig,
+	restoreAcct account.Account,
+) (operations.RestoreOperation, error) {
+	ctx = clues.Add(ctx, "restore_tenant_id", clues.Hide(restoreAcct.ID()))
+
+	if restoreAcct.Provider != account.ProviderM365 {
+		return operations.RestoreOperation{}, clues.NewWC(ctx, "unrecognized restore account provider")
+	}
+
+	// directory objects are recovered from the tenant's recycle bin,
+	// so they can't be moved to another tenant.
+	if sel.PathService() == path.EntraIDService && restoreAcct.ID() != r.Account.ID() {
+		return operations.RestoreOperation{}, clues.NewWC(
+			ctx,
+			"directory objects can only be restored to the tenant they were backed up from")
+	}
+
+	progressMessage := observe.MessageWithCompletion(ctx, observe.DefaultCfg(), "Connecting to the restore tenant")
+	defer close(progressMessage)
+
+	ctrl, err := m365.NewController(
+		ctx,
+		restoreAcct,
+		sel.PathService(),
+		r.Opts,
+		r.counter)
+	if err != nil {
+		return operations.RestoreOperation{}, clues.Wrap(err, "creating restore tenant client controller")
+	}
+
+	if err := ctrl.VerifyAccess(ctx); err != nil {
+		return operations.RestoreOperation{}, clues.Wrap(err, "verifying restore tenant account connection")
+	}
+
+	handler, err := ctrl.NewServiceHandler(sel.PathService())
+	if err != nil {
+		return operations.RestoreOperation{}, clues.Stack(err)
+	}
+
+	// the repository account is kept on the operation, since the
+	// backup details are stored under the backed up tenant.
+	return operations.NewRestoreOperation(
+		ctx,
+		r.Opts,
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		handler,
+		r.Account,
+		model.StableID(backupID),
+		sel,
+		restoreCfg,
+		r.Bus,
+		count.New())
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/retention"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// BackupPruner manages the retention policies of the repository, and
+// removes the backups which those policies no longer retain.
+type BackupPruner interface {
+	RetentionPolicies(ctx context.Context) ([]*retention.Policy, error)
+	SetRetentionPolicy(ctx context.Context, p retention.Policy) error
+	DeleteRetentionPolicy(
+		ctx context.Context,
+		service path.ServiceType,
+		protectedResourceID string,
+	) error
+	PruneBackups(ctx context.Context, dryRun bool) (*retention.Report, error)
+}
+
+// RetentionPolicies lists all retention policies in the repository.
+func (r repository) RetentionPolicies(ctx context.Context) ([]*retention.Policy, error) {
+	return store.NewWrapper(r.modelStore).GetRetentionPolicies(ctx)
+}
+
+// SetRetentionPolicy stores the policy in the repository.  Any existing
+// policy with the same service and protected resource is replaced.
+func (r repository) SetRetentionPolicy(ctx context.Context, p retention.Policy) error {
+	if err := p.Validate(); err != nil {
+		return clues.Stack(err)
+	}
+
+	return store.NewWrapper(r.modelStore).PutRetentionPolicy(ctx, &p)
+}
+
+// DeleteRetentionPolicy removes the policy with the given scope from the
+// repository.  Deleting a policy that doesn't exist is a no-op.
+func (r repository) DeleteRetentionPolicy(
+	ctx context.Context,
+	service path.ServiceType,
+	protectedResourceID string,
+) error {
+	return store.NewWrapper(r.modelStore).DeleteRetentionPolicy(
+		ctx,
+		retention.PolicyID(service, protectedResourceID))
+}
+
+// PruneBackups applies the repository's retention policies to its backups
+// and deletes every backup which isn't retained.  When dryRun is true, the
+// report is produced without deleting anything.
+func (r repository) PruneBackups(ctx context.Context, dryRun bool) (*retention.Report, error) {
+	return pruneBackups(ctx, store.NewWrapper(r.modelStore), dryRun, time.Now())
+}
+
+type backupPruneStore interface {
+	store.BackupWrapper
+	store.ModelDeleter
+	GetRetentionPolicies(ctx context.Context) ([]*retention.Policy, error)
+}
+
+// pruneBackups handles the processing for PruneBackups.
+func pruneBackups(
+	ctx context.Context,
+	sw backupPruneStore,
+	dryRun bool,
+	now time.Time,
+) (*retention.Report, error) {
+	policies, err := sw.GetRetentionPolicies(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting retention policies")
+	}
+
+	bups, err := backupsByTag(ctx, sw, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing backups")
+	}
+
+	report := retention.Plan(policies, bups, now)
+	report.DryRun = dryRun
+
+	pruned := report.Pruned()
+
+	ctx = clues.Add(ctx, "num_backups", len(bups), "num_pruned_backups", len(pruned))
+
+	if dryRun || len(pruned) == 0 {
+		return &report, nil
+	}
+
+	// backups which disappeared since they were listed have nothing left
+	// to prune, so they aren't treated as an error.
+	if err := deleteBackups(ctx, sw, false, pruned...); err != nil {
+		return nil, clues.Wrap(err, "deleting pruned backups")
+	}
+
+	return &report, nil
+}
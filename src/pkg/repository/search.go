@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/streamstore"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/search"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// BackupSearcher finds backed up items by their content.
+type BackupSearcher interface {
+	SearchBackups(ctx context.Context, q search.Query) ([]search.Result, *fault.Bus)
+}
+
+// SearchBackups looks up the query in the search index of each backup
+// within the query's scope.  Backups made without a search index are
+// skipped.  Returns as many results as possible, with errors for the
+// backups that couldn't be searched.
+func (r repository) SearchBackups(
+	ctx context.Context,
+	q search.Query,
+) ([]search.Result, *fault.Bus) {
+	errs := fault.New(false)
+
+	rs, err := searchBackups(
+		ctx,
+		q,
+		r.Account.ID(),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		errs)
+
+	return rs, errs.Fail(err)
+}
+
+// searchBackups handles the processing for SearchBackups.
+func searchBackups(
+	ctx context.Context,
+	q search.Query,
+	tenantID string,
+	kw *kopia.Wrapper,
+	sw store.BackupWrapper,
+	errs *fault.Bus,
+) ([]search.Result, error) {
+	if err := q.Validate(); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bups, err := searchedBackups(ctx, q, sw, errs)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing backups")
+	}
+
+	var (
+		el  = errs.Local()
+		res = []search.Result{}
+	)
+
+	for _, b := range bups {
+		if el.Failure() != nil {
+			break
+		}
+
+		if !b.HasSearchIndex ||
+			!q.MatchesResource(b.Selector.DiscreteOwner, b.Selector.DiscreteOwnerName) {
+			continue
+		}
+
+		bctx := clues.Add(ctx, "backup_id", b.ID)
+
+		rs, err := searchBackup(bctx, q, b, tenantID, kw, sw, errs)
+		if err != nil {
+			el.AddRecoverable(bctx, clues.Wrap(err, "searching backup"))
+			continue
+		}
+
+		res = append(res, rs...)
+	}
+
+	return res, el.Failure()
+}
+
+// searchedBackups lists the backups within the scope of the query.
+func searchedBackups(
+	ctx context.Context,
+	q search.Query,
+	sw store.BackupWrapper,
+	errs *fault.Bus,
+) ([]*backup.Backup, error) {
+	if len(q.BackupIDs) == 0 {
+		var fs []store.FilterOption
+
+		if q.Service != path.UnknownService {
+			fs = append(fs, store.Service(q.Service))
+		}
+
+		return backupsByTag(ctx, sw, fs)
+	}
+
+	bups := make([]*backup.Backup, 0, len(q.BackupIDs))
+
+	for _, id := range q.BackupIDs {
+		b, err := sw.GetBackup(ctx, model.StableID(id))
+		if err != nil {
+			errs.AddRecoverable(ctx, clues.StackWC(ctx, errWrapper(err)).With("backup_id", id))
+			continue
+		}
+
+		if q.Service != path.UnknownService && b.Selector.PathService() != q.Service {
+			continue
+		}
+
+		bups = append(bups, b)
+	}
+
+	return bups, nil
+}
+
+// searchBackup looks up the query in the backup's search index, and
+// returns the details entries of the matching items.
+func searchBackup(
+	ctx context.Context,
+	q search.Query,
+	b *backup.Backup,
+	tenantID string,
+	kw *kopia.Wrapper,
+	sw store.BackupGetter,
+	errs *fault.Bus,
+) ([]search.Result, error) {
+	ssid := b.StreamStoreID
+	if len(ssid) == 0 {
+		return nil, clues.NewWC(ctx, "no streamstore id in backup")
+	}
+
+	var (
+		sstore   = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
+		searcher = search.NewSearcher(q.Text)
+	)
+
+	// shards are searched one at a time, so only the matches of each are
+	// held on to.
+	err := sstore.Read(ctx, ssid, streamstore.SearchIndexReader(searcher), errs)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading search index")
+	}
+
+	refs := searcher.Results()
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	// only the parts of the details that hold the matched items are read.
+	deets, _, err := getBackupDetails(
+		ctx,
+		string(b.ID),
+		tenantID,
+		kw,
+		sw,
+		details.ChunkFilter{RepoRefPrefixes: refs},
+		errs)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading details of matched items")
+	}
+
+	rs := []search.Result{}
+
+	for _, de := range deets.Items() {
+		// prefix filtering can also let through items whose RepoRef
+		// extends one of the matches.
+		if _, found := slices.BinarySearch(refs, de.RepoRef); !found {
+			continue
+		}
+
+		rs = append(rs, search.Result{
+			BackupID:              string(b.ID),
+			BackupCreatedAt:       b.CreationTime,
+			Service:               b.Selector.PathService(),
+			ProtectedResourceID:   b.Selector.DiscreteOwner,
+			ProtectedResourceName: b.Selector.DiscreteOwnerName,
+			Entry:                 *de,
+		})
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,521 @@
+package selectors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/filters"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// ---------------------------------------------------------------------------
+// Selectors
+// ---------------------------------------------------------------------------
+
+type (
+	// entraID provides an api for selecting
+	// data scopes applicable to the Entra ID service.
+	entraID struct {
+		Selector
+	}
+
+	// EntraIDBackup provides an api for selecting
+	// data scopes applicable to the Entra ID service,
+	// plus backup-specific methods.
+	EntraIDBackup struct {
+		entraID
+	}
+
+	// EntraIDRestore provides an api for selecting
+	// data scopes applicable to the Entra ID service,
+	// plus restore-specific methods.
+	EntraIDRestore struct {
+		entraID
+	}
+)
+
+var (
+	_ Reducer        = &EntraIDRestore{}
+	_ pathCategorier = &EntraIDRestore{}
+	_ reasoner       = &EntraIDRestore{}
+)
+
+// NewEntraIDBackup produces a new Selector with the service set to ServiceEntraID.
+// The protected resource of the Entra ID service is the tenant itself.
+func NewEntraIDBackup(tenants []string) *EntraIDBackup {
+	src := EntraIDBackup{
+		entraID{
+			newSelector(ServiceEntraID, tenants),
+		},
+	}
+
+	return &src
+}
+
+// ToEntraIDBackup transforms the generic selector into an EntraIDBackup.
+// Errors if the service defined by the selector is not ServiceEntraID.
+func (s Selector) ToEntraIDBackup() (*EntraIDBackup, error) {
+	if s.Service != ServiceEntraID {
+		return nil, badCastErr(ServiceEntraID, s.Service)
+	}
+
+	src := EntraIDBackup{entraID{s}}
+
+	return &src, nil
+}
+
+func (s EntraIDBackup) SplitByResourceOwner(tenants []string) []EntraIDBackup {
+	sels := splitByProtectedResource[EntraIDScope](s.Selector, tenants, EntraIDTenant)
+
+	ss := make([]EntraIDBackup, 0, len(sels))
+	for _, sel := range sels {
+		ss = append(ss, EntraIDBackup{entraID{sel}})
+	}
+
+	return ss
+}
+
+// NewEntraIDRestore produces a new Selector with the service set to ServiceEntraID.
+func NewEntraIDRestore(tenants []string) *EntraIDRestore {
+	src := EntraIDRestore{
+		entraID{
+			newSelector(ServiceEntraID, tenants),
+		},
+	}
+
+	return &src
+}
+
+// ToEntraIDRestore transforms the generic selector into an EntraIDRestore.
+// Errors if the service defined by the selector is not ServiceEntraID.
+func (s Selector) ToEntraIDRestore() (*EntraIDRestore, error) {
+	if s.Service != ServiceEntraID {
+		return nil, badCastErr(ServiceEntraID, s.Service)
+	}
+
+	src := EntraIDRestore{entraID{s}}
+
+	return &src, nil
+}
+
+func (s EntraIDRestore) SplitByResourceOwner(tenants []string) []EntraIDRestore {
+	sels := splitByProtectedResource[EntraIDScope](s.Selector, tenants, EntraIDTenant)
+
+	ss := make([]EntraIDRestore, 0, len(sels))
+	for _, sel := range sels {
+		ss = append(ss, EntraIDRestore{entraID{sel}})
+	}
+
+	return ss
+}
+
+// PathCategories produces the aggregation of discrete tenants described by each type of scope.
+func (s entraID) PathCategories() selectorPathCategories {
+	return selectorPathCategories{
+		Excludes: pathCategoriesIn[EntraIDScope, entraIDCategory](s.Excludes),
+		Filters:  pathCategoriesIn[EntraIDScope, entraIDCategory](s.Filters),
+		Includes: pathCategoriesIn[EntraIDScope, entraIDCategory](s.Includes),
+	}
+}
+
+// detailsFilter produces the chunk filter for the selector.  Folders
+// are matched by the elements of the RepoRef, which the details index
+// doesn't hold, so only categories get filtered.
+func (s entraID) detailsFilter() details.ChunkFilter {
+	return detailsFilterFor[EntraIDScope, entraIDCategory](s.Selector, false, EntraIDCategoryUnknown, EntraIDCategoryUnknown)
+}
+
+// Reasons returns a deduplicated set of the backup reasons produced
+// using the selector's discrete owner and each scopes' service and
+// category types.
+func (s entraID) Reasons(tenantID string, useOwnerNameForID bool) []identity.Reasoner {
+	return reasonsFor(s, tenantID, useOwnerNameForID)
+}
+
+// ---------------------------------------------------------------------------
+// Stringers and Concealers
+// ---------------------------------------------------------------------------
+
+func (s EntraIDScope) Conceal() string             { return conceal(s) }
+func (s EntraIDScope) Format(fs fmt.State, r rune) { format(s, fs, r) }
+func (s EntraIDScope) String() string              { return conceal(s) }
+func (s EntraIDScope) PlainString() string         { return plainString(s) }
+
+// -------------------
+// Scope Factories
+
+// Include appends the provided scopes to the selector's inclusion set.
+// Data is included if it matches ANY inclusion.
+// The inclusion set is later filtered (all included data must pass ALL
+// filters) and excluded (all included data must not match ANY exclusion).
+// Data is included if it matches ANY inclusion (of the same data category).
+//
+// All parts of the scope must match for data to be included.
+// Ex: Groups(g1) => only includes the group ID'd or named g1.  Use
+// selectors.Any() to wildcard a scope value. No value will match if
+// selectors.None() is provided.
+func (s *entraID) Include(scopes ...[]EntraIDScope) {
+	s.Includes = appendScopes(s.Includes, scopes...)
+}
+
+// Exclude appends the provided scopes to the selector's exclusion set.
+// Every Exclusion scope applies globally, affecting all inclusion scopes.
+// Data is excluded if it matches ANY exclusion.
+//
+// All parts of the scope must match for data to be excluded.
+// Ex: Groups(g1) => only excludes the group ID'd or named g1.  Use
+// selectors.Any() to wildcard a scope value. No value will match if
+// selectors.None() is provided.
+func (s *entraID) Exclude(scopes ...[]EntraIDScope) {
+	s.Excludes = appendScopes(s.Excludes, scopes...)
+}
+
+// Filter appends the provided scopes to the selector's filters set.
+// A selector with >0 filters and 0 inclusions will include any data
+// that passes all filters.
+// A selector with >0 filters and >0 inclusions will reduce the
+// inclusion set to only the data that passes all filters.
+// Data is retained if it passes ALL filters.
+func (s *entraID) Filter(scopes ...[]EntraIDScope) {
+	s.Filters = appendScopes(s.Filters, scopes...)
+}
+
+// Scopes retrieves the list of entraIDScopes in the selector.
+func (s *entraID) Scopes() []EntraIDScope {
+	return scopes[EntraIDScope](s.Selector)
+}
+
+// -------------------
+// Scope Factories
+
+// AllData produces a scope that selects every kind of directory object.
+func (s *entraID) AllData() []EntraIDScope {
+	return []EntraIDScope{makeScope[EntraIDScope](EntraIDObjectType, Any())}
+}
+
+// ObjectTypes produces one or more directory object type scopes, where
+// the type is one of the details.EntraID*Folder values.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *entraID) ObjectTypes(types []string, opts ...option) []EntraIDScope {
+	return []EntraIDScope{makeScope[EntraIDScope](EntraIDObjectType, types, opts...)}
+}
+
+// Users produces one or more user scopes, matched by ID, display name,
+// or user principal name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *entraID) Users(users []string, opts ...option) []EntraIDScope {
+	return s.objects(details.EntraIDUsersFolder, users, opts...)
+}
+
+// Groups produces one or more group scopes, matched by ID, display name,
+// or mail nickname.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *entraID) Groups(groups []string, opts ...option) []EntraIDScope {
+	return s.objects(details.EntraIDGroupsFolder, groups, opts...)
+}
+
+// Applications produces one or more application scopes, matched by ID,
+// display name, or app ID.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *entraID) Applications(apps []string, opts ...option) []EntraIDScope {
+	return s.objects(details.EntraIDApplicationsFolder, apps, opts...)
+}
+
+// ServicePrincipals produces one or more service principal scopes,
+// matched by ID, display name, or app ID.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *entraID) ServicePrincipals(sps []string, opts ...option) []EntraIDScope {
+	return s.objects(details.EntraIDServicePrincipalsFolder, sps, opts...)
+}
+
+func (s *entraID) objects(objectType string, objects []string, opts ...option) []EntraIDScope {
+	return []EntraIDScope{
+		makeScope[EntraIDScope](EntraIDObject, objects, append(defaultItemOptions(s.Cfg), opts...)...).
+			set(EntraIDObjectType, []string{objectType}),
+	}
+}
+
+// -------------------
+// ItemInfo Factories
+
+// DisplayName produces one or more directory object info scopes.
+// Matches any object whose display name contains the provided name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *EntraIDRestore) DisplayName(name string) []EntraIDScope {
+	return []EntraIDScope{
+		makeInfoScope[EntraIDScope](
+			EntraIDObject,
+			EntraIDInfoDisplayName,
+			[]string{name},
+			filters.In),
+	}
+}
+
+// Member produces one or more group member info scopes.
+// Matches any group where one of the direct members has the provided ID.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *EntraIDRestore) Member(memberID string) []EntraIDScope {
+	return []EntraIDScope{
+		makeInfoScope[EntraIDScope](
+			EntraIDObject,
+			EntraIDInfoMember,
+			[]string{memberID},
+			filters.Equal),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Categories
+// ---------------------------------------------------------------------------
+
+// entraIDCategory enumerates the type of the lowest level
+// of data () in a scope.
+type entraIDCategory string
+
+// interface compliance checks
+var _ categorizer = EntraIDCategoryUnknown
+
+const (
+	EntraIDCategoryUnknown entraIDCategory = ""
+
+	// types of data in entra id
+	EntraIDTenant     entraIDCategory = "EntraIDTenant"
+	EntraIDObjectType entraIDCategory = "EntraIDObjectType"
+	EntraIDObject     entraIDCategory = "EntraIDObject"
+
+	// data contained within details.ItemInfo
+	EntraIDInfoDisplayName entraIDCategory = "EntraIDInfoDisplayName"
+	EntraIDInfoMember      entraIDCategory = "EntraIDInfoMember"
+)
+
+// entraIDLeafProperties describes common metadata of the leaf categories
+var entraIDLeafProperties = map[categorizer]leafProperty{
+	EntraIDObject: {
+		pathKeys: []categorizer{EntraIDObjectType, EntraIDObject},
+		pathType: path.DirectoryObjectsCategory,
+	},
+	EntraIDTenant: { // the root category must be represented, even though it isn't a leaf
+		pathKeys: []categorizer{EntraIDTenant},
+		pathType: path.UnknownCategory,
+	},
+}
+
+func (c entraIDCategory) String() string {
+	return string(c)
+}
+
+// leafCat returns the leaf category of the receiver.
+// If the receiver category has multiple leaves (ex: User) or no leaves,
+// (ex: Unknown), the receiver itself is returned.
+// Ex: ServiceTypeFolder.leafCat() => ServiceTypeItem
+// Ex: ServiceUser.leafCat() => ServiceUser
+func (c entraIDCategory) leafCat() categorizer {
+	switch c {
+	case EntraIDObjectType, EntraIDObject,
+		EntraIDInfoDisplayName, EntraIDInfoMember:
+		return EntraIDObject
+	}
+
+	return c
+}
+
+// rootCat returns the root category type.
+func (c entraIDCategory) rootCat() categorizer {
+	return EntraIDTenant
+}
+
+// unknownCat returns the unknown category type.
+func (c entraIDCategory) unknownCat() categorizer {
+	return EntraIDCategoryUnknown
+}
+
+// isUnion returns true if c is a tenant
+func (c entraIDCategory) isUnion() bool {
+	return c == c.rootCat()
+}
+
+// isLeaf is true if the category is an EntraIDObject category.
+func (c entraIDCategory) isLeaf() bool {
+	return c == c.leafCat()
+}
+
+// pathValues transforms the two paths to maps of identified properties.
+//
+// Example:
+// [tenantID, service, tenantID, category, objectType, objectID]
+// => {objectType: objectType, object: [objectID, displayName, principalName]}
+func (c entraIDCategory) pathValues(
+	repo path.Path,
+	ent details.Entry,
+	cfg Config,
+) (map[categorizer][]string, error) {
+	if ent.EntraID == nil {
+		return nil, clues.New("no EntraID ItemInfo in details")
+	}
+
+	item := ent.ItemRef
+	if len(item) == 0 {
+		item = repo.Item()
+	}
+
+	objectType := ent.EntraID.ParentPath
+	if folders := repo.Folders(); len(folders) > 0 {
+		objectType = folders[len(folders)-1]
+	}
+
+	result := map[categorizer][]string{
+		EntraIDObjectType: {objectType},
+		EntraIDObject: {
+			item,
+			ent.ShortRef,
+			ent.EntraID.ItemName,
+			ent.EntraID.PrincipalName,
+		},
+	}
+
+	return result, nil
+}
+
+// pathKeys returns the path keys recognized by the receiver's leaf type.
+func (c entraIDCategory) pathKeys() []categorizer {
+	return entraIDLeafProperties[c.leafCat()].pathKeys
+}
+
+// PathType converts the category's leaf type into the matching path.CategoryType.
+func (c entraIDCategory) PathType() path.CategoryType {
+	return entraIDLeafProperties[c.leafCat()].pathType
+}
+
+// ---------------------------------------------------------------------------
+// Scopes
+// ---------------------------------------------------------------------------
+
+// EntraIDScope specifies the data available
+// when interfacing with the Entra ID service.
+type EntraIDScope scope
+
+// interface compliance checks
+var _ scoper = &EntraIDScope{}
+
+// Category describes the type of the data in scope.
+func (s EntraIDScope) Category() entraIDCategory {
+	return entraIDCategory(getCategory(s))
+}
+
+// categorizer type is a generic wrapper around Category.
+// Primarily used by scopes.go to for abstract comparisons.
+func (s EntraIDScope) categorizer() categorizer {
+	return s.Category()
+}
+
+// Matches returns true if the category is included in the scope's
+// data type, and the target string matches that category's comparator.
+func (s EntraIDScope) Matches(cat entraIDCategory, target string) bool {
+	return matches(s, cat, target)
+}
+
+// InfoCategory returns the category enum of the scope info.
+// If the scope is not an info type, returns EntraIDCategoryUnknown.
+func (s EntraIDScope) InfoCategory() entraIDCategory {
+	return entraIDCategory(getInfoCategory(s))
+}
+
+// IncludeCategory checks whether the scope includes a
+// certain category of data.
+// Ex: to check if the scope includes directory objects:
+// s.IncludesCategory(selector.EntraIDObject)
+func (s EntraIDScope) IncludesCategory(cat entraIDCategory) bool {
+	return categoryMatches(s.Category(), cat)
+}
+
+// returns true if the category is included in the scope's data type,
+// and the value is set to Any().
+func (s EntraIDScope) IsAny(cat entraIDCategory) bool {
+	return IsAnyTarget(s, cat)
+}
+
+// Get returns the data category in the scope.  If the scope
+// contains all data types for a tenant, it'll return the
+// EntraIDTenant category.
+func (s EntraIDScope) Get(cat entraIDCategory) []string {
+	return getCatValue(s, cat)
+}
+
+// sets a value by category to the scope.  Only intended for internal use.
+func (s EntraIDScope) set(cat entraIDCategory, v []string, opts ...option) EntraIDScope {
+	return set(s, cat, v, opts...)
+}
+
+// setDefaults ensures that tenant scopes express `AnyTgt` for their child category types.
+func (s EntraIDScope) setDefaults() {
+	switch s.Category() {
+	case EntraIDTenant:
+		s[EntraIDObjectType.String()] = passAny
+		s[EntraIDObject.String()] = passAny
+	case EntraIDObjectType:
+		s[EntraIDObject.String()] = passAny
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Backup Details Filtering
+// ---------------------------------------------------------------------------
+
+// Reduce filters the entries in a details struct to only those that match the
+// inclusions, filters, and exclusions in the selector.
+func (s entraID) Reduce(
+	ctx context.Context,
+	deets *details.Details,
+	errs *fault.Bus,
+) *details.Details {
+	return reduce[EntraIDScope](
+		ctx,
+		deets,
+		s.Selector,
+		map[path.CategoryType]entraIDCategory{
+			path.DirectoryObjectsCategory: EntraIDObject,
+		},
+		errs)
+}
+
+// matchesInfo handles the standard behavior when comparing a scope and an
+// EntraIDInfo.  Returns true if the scope and info match for the provided
+// category.
+func (s EntraIDScope) matchesInfo(dii details.ItemInfo) bool {
+	var (
+		infoCat = s.InfoCategory()
+		info    = dii.EntraID
+	)
+
+	if info == nil {
+		return false
+	}
+
+	switch infoCat {
+	case EntraIDInfoDisplayName:
+		return s.Matches(infoCat, info.ItemName)
+	case EntraIDInfoMember:
+		return matchesAny(s, EntraIDInfoMember, info.Members)
+	}
+
+	return false
+}
@@ -0,0 +1,222 @@
+package selectors
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type EntraIDSelectorSuite struct {
+	tester.Suite
+}
+
+func TestEntraIDSelectorSuite(t *testing.T) {
+	suite.Run(t, &EntraIDSelectorSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *EntraIDSelectorSuite) TestToEntraIDBackup() {
+	t := suite.T()
+	eb := NewEntraIDBackup(nil)
+	s := eb.Selector
+	eb, err := s.ToEntraIDBackup()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, eb.Service, ServiceEntraID)
+	assert.NotZero(t, eb.Scopes())
+
+	_, err = NewGroupsBackup(nil).Selector.ToEntraIDBackup()
+	assert.Error(t, err, "wrong service")
+}
+
+func (suite *EntraIDSelectorSuite) TestToEntraIDRestore() {
+	t := suite.T()
+	er := NewEntraIDRestore(nil)
+	s := er.Selector
+	er, err := s.ToEntraIDRestore()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, er.Service, ServiceEntraID)
+	assert.NotZero(t, er.Scopes())
+}
+
+func (suite *EntraIDSelectorSuite) TestEntraIDRestore_Reduce() {
+	var (
+		user1 = stubRepoRef(path.EntraIDService, path.DirectoryObjectsCategory, "tid", details.EntraIDUsersFolder, "u1")
+		user2 = stubRepoRef(path.EntraIDService, path.DirectoryObjectsCategory, "tid", details.EntraIDUsersFolder, "u2")
+		group = stubRepoRef(path.EntraIDService, path.DirectoryObjectsCategory, "tid", details.EntraIDGroupsFolder, "g1")
+	)
+
+	entry := func(rr, id, objectType, name string, it details.ItemType, members ...string) details.Entry {
+		return details.Entry{
+			RepoRef:     rr,
+			ItemRef:     id,
+			LocationRef: objectType,
+			ItemInfo: details.ItemInfo{
+				EntraID: &details.EntraIDInfo{
+					ItemType:   it,
+					ItemName:   name,
+					ParentPath: objectType,
+					Members:    members,
+				},
+			},
+		}
+	}
+
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				entry(user1, "u1", details.EntraIDUsersFolder, "Adele Vance", details.EntraIDUser),
+				entry(user2, "u2", details.EntraIDUsersFolder, "Megan Bowen", details.EntraIDUser),
+				entry(group, "g1", details.EntraIDGroupsFolder, "Marketing", details.EntraIDGroup, "u1"),
+			},
+		},
+	}
+
+	arr := func(s ...string) []string {
+		return s
+	}
+
+	table := []struct {
+		name         string
+		makeSelector func() *EntraIDRestore
+		expect       []string
+	}{
+		{
+			name: "all",
+			makeSelector: func() *EntraIDRestore {
+				sel := NewEntraIDRestore(Any())
+				sel.Include(sel.AllData())
+				return sel
+			},
+			expect: arr(user1, user2, group),
+		},
+		{
+			name: "object type",
+			makeSelector: func() *EntraIDRestore {
+				sel := NewEntraIDRestore(Any())
+				sel.Include(sel.ObjectTypes([]string{details.EntraIDUsersFolder}))
+				return sel
+			},
+			expect: arr(user1, user2),
+		},
+		{
+			name: "group by name",
+			makeSelector: func() *EntraIDRestore {
+				sel := NewEntraIDRestore(Any())
+				sel.Include(sel.Groups([]string{"Marketing"}))
+				return sel
+			},
+			expect: arr(group),
+		},
+		{
+			name: "user by id",
+			makeSelector: func() *EntraIDRestore {
+				sel := NewEntraIDRestore(Any())
+				sel.Include(sel.Users([]string{"u2"}))
+				return sel
+			},
+			expect: arr(user2),
+		},
+		{
+			name: "groups with member",
+			makeSelector: func() *EntraIDRestore {
+				sel := NewEntraIDRestore(Any())
+				sel.Filter(sel.Member("u1"))
+				return sel
+			},
+			expect: arr(group),
+		},
+		{
+			name: "exclude user",
+			makeSelector: func() *EntraIDRestore {
+				sel := NewEntraIDRestore(Any())
+				sel.Include(sel.AllData())
+				sel.Exclude(sel.Users([]string{"Adele Vance"}))
+				return sel
+			},
+			expect: arr(user2, group),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := test.makeSelector()
+			results := sel.Reduce(ctx, deets, fault.New(true))
+			paths := results.Paths()
+			assert.Equal(t, test.expect, paths)
+		})
+	}
+}
+
+func (suite *EntraIDSelectorSuite) TestEntraIDScope_MatchesInfo() {
+	sel := NewEntraIDRestore(Any())
+
+	type expectation func(t assert.TestingT, value bool, msg string, args ...any) bool
+
+	table := []struct {
+		name   string
+		scope  []EntraIDScope
+		expect expectation
+	}{
+		{"display name", sel.DisplayName("Marketing"), assert.Truef},
+		{"display name partial", sel.DisplayName("market"), assert.Truef},
+		{"other display name", sel.DisplayName("Sales"), assert.Falsef},
+		{"member", sel.Member("u1"), assert.Truef},
+		{"not a member", sel.Member("u3"), assert.Falsef},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			itemInfo := details.ItemInfo{
+				EntraID: &details.EntraIDInfo{
+					ItemType: details.EntraIDGroup,
+					ItemName: "Marketing",
+					Members:  []string{"u1", "u2"},
+				},
+			}
+
+			scopes := setScopesToDefault(test.scope)
+			for _, scope := range scopes {
+				test.expect(
+					t,
+					scope.matchesInfo(itemInfo),
+					"not matching:\nscope:\n\t%+v\ninfo:\n\t%+v",
+					scope,
+					itemInfo.EntraID)
+			}
+		})
+	}
+}
+
+func (suite *EntraIDSelectorSuite) TestCategory_PathType() {
+	table := []struct {
+		cat      entraIDCategory
+		pathType path.CategoryType
+	}{
+		{EntraIDCategoryUnknown, path.UnknownCategory},
+		{EntraIDTenant, path.UnknownCategory},
+		{EntraIDObjectType, path.DirectoryObjectsCategory},
+		{EntraIDObject, path.DirectoryObjectsCategory},
+		{EntraIDInfoDisplayName, path.DirectoryObjectsCategory},
+		{EntraIDInfoMember, path.DirectoryObjectsCategory},
+	}
+	for _, test := range table {
+		suite.Run(test.cat.String(), func() {
+			assert.Equal(
+				suite.T(),
+				test.pathType.String(),
+				test.cat.PathType().String())
+		})
+	}
+}
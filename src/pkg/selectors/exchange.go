@@ -124,6 +124,13 @@ func (s exchange) PathCategories() selectorPathCategories {
 	}
 }
 
+// detailsFilter produces the chunk filter for the selector.  Exchange
+// info filters don't compare the modified times of items, so chunks
+// aren't bounded by time.
+func (s exchange) detailsFilter() details.ChunkFilter {
+	return detailsFilterFor[ExchangeScope, exchangeCategory](s.Selector, true, ExchangeCategoryUnknown, ExchangeCategoryUnknown)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -318,6 +325,23 @@ func (s *exchange) MailFolders(folders []string, opts ...option) []ExchangeScope
 	return scopes
 }
 
+// Settings produces one or more exchange mailbox settings scopes, where
+// each setting is one of the mailbox settings items (ex: inboxRules).
+// Settings aren't part of AllData, since they need their own permissions
+// and overwrite the current settings of the mailbox when restored.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *exchange) Settings(settings []string, opts ...option) []ExchangeScope {
+	scopes := []ExchangeScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[ExchangeScope](ExchangeSettings, settings, opts...))
+
+	return scopes
+}
+
 // Retrieves all exchange data.
 // Each user id generates three scopes, one for each data type: contact, event, and mail.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -504,6 +528,7 @@ const (
 	ExchangeEventCalendar exchangeCategory = "ExchangeEventCalendar"
 	ExchangeMail          exchangeCategory = "ExchangeMail"
 	ExchangeMailFolder    exchangeCategory = "ExchangeMailFolder"
+	ExchangeSettings      exchangeCategory = "ExchangeSettings"
 	ExchangeUser          exchangeCategory = "ExchangeUser"
 
 	// data contained within details.ItemInfo
@@ -533,6 +558,10 @@ var exchangeLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{ExchangeMailFolder, ExchangeMail},
 		pathType: path.EmailCategory,
 	},
+	ExchangeSettings: {
+		pathKeys: []categorizer{ExchangeSettings},
+		pathType: path.MailboxSettingsCategory,
+	},
 	ExchangeUser: { // the root category must be represented, even though it isn't a leaf
 		pathKeys: []categorizer{ExchangeUser},
 		pathType: path.UnknownCategory,
@@ -563,6 +592,9 @@ func (ec exchangeCategory) leafCat() categorizer {
 	case ExchangeMail, ExchangeMailFolder, ExchangeInfoMailReceivedAfter,
 		ExchangeInfoMailReceivedBefore, ExchangeInfoMailSender, ExchangeInfoMailSubject:
 		return ExchangeMail
+
+	case ExchangeSettings:
+		return ExchangeSettings
 	}
 
 	return ec
@@ -610,6 +642,18 @@ func (ec exchangeCategory) pathValues(
 	case ExchangeMail:
 		folderCat, itemCat = ExchangeMailFolder, ExchangeMail
 
+	case ExchangeSettings:
+		// settings aren't held in folders, and are always identified
+		// by their name.
+		item := ent.ItemRef
+		if len(item) == 0 {
+			item = repo.Item()
+		}
+
+		return map[categorizer][]string{
+			ExchangeSettings: {ent.ShortRef, item},
+		}, nil
+
 	default:
 		return nil, clues.New("bad exchanageCategory").With("category", ec)
 	}
@@ -736,6 +780,7 @@ func (s ExchangeScope) setDefaults() {
 		s[ExchangeEvent.String()] = passAny
 		s[ExchangeMailFolder.String()] = passAny
 		s[ExchangeMail.String()] = passAny
+		s[ExchangeSettings.String()] = passAny
 	}
 }
 
@@ -755,9 +800,10 @@ func (s exchange) Reduce(
 		deets,
 		s.Selector,
 		map[path.CategoryType]exchangeCategory{
-			path.ContactsCategory: ExchangeContact,
-			path.EventsCategory:   ExchangeEvent,
-			path.EmailCategory:    ExchangeMail,
+			path.ContactsCategory:        ExchangeContact,
+			path.EventsCategory:          ExchangeEvent,
+			path.EmailCategory:           ExchangeMail,
+			path.MailboxSettingsCategory: ExchangeSettings,
 		},
 		errs)
 }
@@ -812,6 +858,8 @@ func categoryFromItemType(pct details.ItemType) exchangeCategory {
 		return ExchangeMail
 	case details.ExchangeEvent:
 		return ExchangeEvent
+	case details.ExchangeSetting:
+		return ExchangeSettings
 	}
 
 	return ExchangeCategoryUnknown
@@ -787,9 +787,24 @@ func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce() {
 			"uid",
 			[]string{"cfld1/cfld2", "cid"},
 			path.ContactsCategory)
+		rules = stubPath(
+			suite.T(),
+			"uid",
+			[]string{details.ExchangeInboxRules},
+			path.MailboxSettingsCategory)
+		categories = stubPath(
+			suite.T(),
+			"uid",
+			[]string{details.ExchangeCategories},
+			path.MailboxSettingsCategory)
 	)
 
 	toRR := func(p path.Path) string {
+		// settings sit at the root of their category.
+		if len(p.Folders()) == 0 {
+			return p.String()
+		}
+
 		newElems := []string{}
 
 		for _, e := range p.Folders() {
@@ -818,6 +833,8 @@ func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce() {
 				itype = details.ExchangeEvent
 			case mail:
 				itype = details.ExchangeMail
+			case rules, categories:
+				itype = details.ExchangeSetting
 			}
 
 			deets.Entries = append(deets.Entries, details.Entry{
@@ -891,6 +908,36 @@ func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce() {
 			},
 			[]string{toRR(contact), toRR(event), toRR(mail)},
 		},
+		{
+			"all data excludes settings",
+			makeDeets(contact, event, mail, rules, categories),
+			func() *ExchangeRestore {
+				er := NewExchangeRestore(Any())
+				er.Include(er.AllData())
+				return er
+			},
+			[]string{toRR(contact), toRR(event), toRR(mail)},
+		},
+		{
+			"all settings",
+			makeDeets(contact, event, mail, rules, categories),
+			func() *ExchangeRestore {
+				er := NewExchangeRestore(Any())
+				er.Include(er.Settings(Any()))
+				return er
+			},
+			[]string{toRR(rules), toRR(categories)},
+		},
+		{
+			"only match setting",
+			makeDeets(contact, event, mail, rules, categories),
+			func() *ExchangeRestore {
+				er := NewExchangeRestore([]string{"uid"})
+				er.Include(er.Settings([]string{details.ExchangeInboxRules}))
+				return er
+			},
+			[]string{toRR(rules)},
+		},
 		{
 			"only match contact",
 			makeDeets(contact, event, mail),
@@ -1046,6 +1093,8 @@ func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce_locationRef() {
 		eventLocation   = "cal/my_cal"
 		mail            = stubRepoRef(path.ExchangeService, path.EmailCategory, "uid", "id3/id4", "mid")
 		mailLocation    = "inbx/my_mail"
+		archive         = stubRepoRef(path.ExchangeService, path.EmailCategory, "uid", "arc/id7", "aid")
+		archiveLocation = details.ExchangeArchiveFolder + "/Inbox"
 	)
 
 	makeDeets := func(refs ...string) *details.Details {
@@ -1071,6 +1120,9 @@ func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce_locationRef() {
 			case mail:
 				itype = details.ExchangeMail
 				location = mailLocation
+			case archive:
+				itype = details.ExchangeMail
+				location = archiveLocation
 			}
 
 			deets.Entries = append(deets.Entries, details.Entry{
@@ -1177,6 +1229,16 @@ func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce_locationRef() {
 			},
 			arr(mail),
 		},
+		{
+			"only match archive mail",
+			makeDeets(contact, event, mail, archive),
+			func() *ExchangeRestore {
+				er := NewExchangeRestore([]string{"uid"})
+				er.Include(er.MailFolders([]string{details.ExchangeArchiveFolder}, PrefixMatch()))
+				return er
+			},
+			arr(archive),
+		},
 		{
 			"exclude contact",
 			makeDeets(contact, event, mail),
@@ -123,6 +123,11 @@ func (s groups) PathCategories() selectorPathCategories {
 	}
 }
 
+// detailsFilter produces the chunk filter for the selector.
+func (s groups) detailsFilter() details.ChunkFilter {
+	return detailsFilterFor[GroupsScope, groupsCategory](s.Selector, true, GroupsInfoLibraryItemModifiedAfter, GroupsInfoLibraryItemModifiedBefore)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -218,7 +223,8 @@ func (s *groups) AllData() []GroupsScope {
 		scopes,
 		makeScope[GroupsScope](GroupsLibraryFolder, Any()),
 		makeScope[GroupsScope](GroupsChannel, Any()),
-		makeScope[GroupsScope](GroupsConversation, Any()))
+		makeScope[GroupsScope](GroupsConversation, Any()),
+		makeScope[GroupsScope](GroupsPlan, Any()))
 
 	return scopes
 }
@@ -295,6 +301,42 @@ func (s *groups) ConversationPosts(conversations, posts []string, opts ...option
 	return scopes
 }
 
+// Plans produces one or more Groups planner plan scopes, where the plan
+// matches with a given plan by ID or title.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *groups) Plans(plans []string, opts ...option) []GroupsScope {
+	var (
+		scopes = []GroupsScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsPlan, plans, os...))
+
+	return scopes
+}
+
+// PlannerTasks produces one or more Groups planner task scopes.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *groups) PlannerTasks(plans, tasks []string, opts ...option) []GroupsScope {
+	var (
+		scopes = []GroupsScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsPlannerTask, tasks, os...).
+			set(GroupsPlan, plans, opts...))
+
+	return scopes
+}
+
 // Sites produces one or more Groups site scopes, where the site
 // matches upon a given site by ID or URL.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -536,6 +578,76 @@ func (s *GroupsRestore) MessageLastReplyBefore(timeStrings string) []GroupsScope
 	}
 }
 
+// ConversationTopic produces a conversation topic info scope.
+// Matches any post in a conversation whose topic contains the string.
+// If the input equals selectors.Any, the scope will match all topics.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) ConversationTopic(topic string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsConversationPost,
+			GroupsInfoConversationTopic,
+			[]string{topic},
+			filters.Contains),
+	}
+}
+
+// PostCreatedAfter produces a conversation post created-after info scope.
+// Matches any post where the creation time is after the timestring.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) PostCreatedAfter(timeStrings string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsConversationPost,
+			GroupsInfoConversationPostCreatedAfter,
+			[]string{timeStrings},
+			filters.Less),
+	}
+}
+
+// PostCreatedBefore produces a conversation post created-before info scope.
+// Matches any post where the creation time is before the timestring.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) PostCreatedBefore(timeStrings string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsConversationPost,
+			GroupsInfoConversationPostCreatedBefore,
+			[]string{timeStrings},
+			filters.Greater),
+	}
+}
+
+// PlannerBucket produces a planner task bucket info scope.
+// Matches any task in a bucket whose name equals the string.
+// If the input equals selectors.Any, the scope will match all buckets.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) PlannerBucket(bucket string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsPlannerTask,
+			GroupsInfoPlannerBucket,
+			[]string{bucket},
+			filters.Equal),
+	}
+}
+
+// PlannerTaskTitle produces a planner task title info scope.
+// Matches any task whose title contains the string.
+// If the input equals selectors.Any, the scope will match all tasks.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) PlannerTaskTitle(title string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsPlannerTask,
+			GroupsInfoPlannerTaskTitle,
+			[]string{title},
+			filters.Contains),
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Categories
 // ---------------------------------------------------------------------------
@@ -562,6 +674,8 @@ const (
 	GroupsListItem         groupsCategory = "GroupsListItem"
 	GroupsPageFolder       groupsCategory = "GroupsPageFolder"
 	GroupsPage             groupsCategory = "GroupsPage"
+	GroupsPlan             groupsCategory = "GroupsPlan"
+	GroupsPlannerTask      groupsCategory = "GroupsPlannerTask"
 
 	// details.itemInfo comparables
 	GroupsInfoLibraryItemCreatedAfter   groupsCategory = "GroupsInfoLibraryItemCreatedAfter"
@@ -579,6 +693,11 @@ const (
 	GroupsInfoChannelMessageCreator         groupsCategory = "GroupsInfoChannelMessageCreator"
 	GroupsInfoChannelMessageLastReplyAfter  groupsCategory = "GroupsInfoChannelMessageLastReplyAfter"
 	GroupsInfoChannelMessageLastReplyBefore groupsCategory = "GroupsInfoChannelMessageLastReplyBefore"
+	GroupsInfoConversationTopic             groupsCategory = "GroupsInfoConversationTopic"
+	GroupsInfoConversationPostCreatedAfter  groupsCategory = "GroupsInfoConversationPostCreatedAfter"
+	GroupsInfoConversationPostCreatedBefore groupsCategory = "GroupsInfoConversationPostCreatedBefore"
+	GroupsInfoPlannerBucket                 groupsCategory = "GroupsInfoPlannerBucket"
+	GroupsInfoPlannerTaskTitle              groupsCategory = "GroupsInfoPlannerTaskTitle"
 )
 
 // groupsLeafProperties describes common metadata of the leaf categories
@@ -595,6 +714,10 @@ var groupsLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{GroupsLibraryFolder, GroupsLibraryItem},
 		pathType: path.LibrariesCategory,
 	},
+	GroupsPlannerTask: {
+		pathKeys: []categorizer{GroupsPlan, GroupsPlannerTask},
+		pathType: path.PlannerTasksCategory,
+	},
 	GroupsGroup: { // the root category must be represented, even though it isn't a leaf
 		pathKeys: []categorizer{GroupsGroup},
 		pathType: path.UnknownCategory,
@@ -616,8 +739,11 @@ func (c groupsCategory) leafCat() categorizer {
 		GroupsInfoChannelMessageCreatedAfter, GroupsInfoChannelMessageCreatedBefore, GroupsInfoChannelMessageCreator,
 		GroupsInfoChannelMessageLastReplyAfter, GroupsInfoChannelMessageLastReplyBefore:
 		return GroupsChannelMessage
-	case GroupsConversation, GroupsConversationPost:
+	case GroupsConversation, GroupsConversationPost,
+		GroupsInfoConversationTopic, GroupsInfoConversationPostCreatedAfter, GroupsInfoConversationPostCreatedBefore:
 		return GroupsConversationPost
+	case GroupsPlan, GroupsPlannerTask, GroupsInfoPlannerBucket, GroupsInfoPlannerTaskTitle:
+		return GroupsPlannerTask
 	case GroupsLibraryFolder, GroupsLibraryItem, GroupsInfoSite, GroupsInfoSiteLibraryDrive,
 		GroupsInfoLibraryItemCreatedAfter, GroupsInfoLibraryItemCreatedBefore,
 		GroupsInfoLibraryItemModifiedAfter, GroupsInfoLibraryItemModifiedBefore:
@@ -675,6 +801,9 @@ func (c groupsCategory) pathValues(
 	case GroupsConversation, GroupsConversationPost:
 		folderCat, itemCat = GroupsConversation, GroupsConversationPost
 		rFld = ent.Groups.ParentPath
+	case GroupsPlan, GroupsPlannerTask:
+		folderCat, itemCat = GroupsPlan, GroupsPlannerTask
+		rFld = ent.Groups.ParentPath
 	case GroupsLibraryFolder, GroupsLibraryItem:
 		folderCat, itemCat = GroupsLibraryFolder, GroupsLibraryItem
 		rFld = ent.Groups.ParentPath
@@ -773,7 +902,7 @@ func (s GroupsScope) set(cat groupsCategory, v []string, opts ...option) GroupsS
 	os := []option{}
 
 	switch cat {
-	case GroupsChannel, GroupsConversation, GroupsLibraryFolder:
+	case GroupsChannel, GroupsConversation, GroupsLibraryFolder, GroupsPlan:
 		os = append(os, pathComparator())
 	}
 
@@ -790,12 +919,16 @@ func (s GroupsScope) setDefaults() {
 		s[GroupsConversationPost.String()] = passAny
 		s[GroupsLibraryFolder.String()] = passAny
 		s[GroupsLibraryItem.String()] = passAny
+		s[GroupsPlan.String()] = passAny
+		s[GroupsPlannerTask.String()] = passAny
 	case GroupsChannel:
 		s[GroupsChannelMessage.String()] = passAny
 	case GroupsLibraryFolder:
 		s[GroupsLibraryItem.String()] = passAny
 	case GroupsConversation:
 		s[GroupsConversationPost.String()] = passAny
+	case GroupsPlan:
+		s[GroupsPlannerTask.String()] = passAny
 	}
 }
 
@@ -818,6 +951,7 @@ func (s groups) Reduce(
 			path.ChannelMessagesCategory:   GroupsChannelMessage,
 			path.ConversationPostsCategory: GroupsConversationPost,
 			path.LibrariesCategory:         GroupsLibraryItem,
+			path.PlannerTasksCategory:      GroupsPlannerTask,
 		},
 		errs)
 }
@@ -844,6 +978,8 @@ func (s GroupsScope) matchesInfo(dii details.ItemInfo) bool {
 		acceptableItemType = int(details.GroupsChannelMessage)
 	case GroupsConversationPost:
 		acceptableItemType = int(details.GroupsConversationPost)
+	case GroupsPlannerTask:
+		acceptableItemType = int(details.GroupsPlannerTask)
 	}
 
 	switch infoCat {
@@ -885,6 +1021,14 @@ func (s GroupsScope) matchesInfo(dii details.ItemInfo) bool {
 		}
 
 		i = dttm.Format(info.LastReply.CreatedAt)
+	case GroupsInfoConversationTopic:
+		i = info.Post.Topic
+	case GroupsInfoConversationPostCreatedAfter, GroupsInfoConversationPostCreatedBefore:
+		i = dttm.Format(info.Post.CreatedAt)
+	case GroupsInfoPlannerBucket:
+		i = info.Task.Bucket
+	case GroupsInfoPlannerTaskTitle:
+		i = info.Task.Title
 	}
 
 	return s.Matches(infoCat, i) && int(info.ItemType) == acceptableItemType
@@ -115,6 +115,8 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 		convItem  = toRR(path.ConversationPostsCategory, "gid", slices.Clone(itemElems1), "convitem")
 		convItem2 = toRR(path.ConversationPostsCategory, "gid", slices.Clone(itemElems2), "convitem2")
 		convItem3 = toRR(path.ConversationPostsCategory, "gid", slices.Clone(itemElems3), "convitem3")
+		taskItem  = toRR(path.PlannerTasksCategory, "gid", []string{"planA"}, "taskitem")
+		taskItem2 = toRR(path.PlannerTasksCategory, "gid", []string{"planB"}, "taskitem2")
 	)
 
 	deets := &details.Details{
@@ -222,6 +224,28 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 						},
 					},
 				},
+				{
+					RepoRef:     taskItem,
+					ItemRef:     "taskitem",
+					LocationRef: "planA",
+					ItemInfo: details.ItemInfo{
+						Groups: &details.GroupsInfo{
+							ItemType:   details.GroupsPlannerTask,
+							ParentPath: "planA",
+						},
+					},
+				},
+				{
+					RepoRef:     taskItem2,
+					ItemRef:     "taskitem2",
+					LocationRef: "planB",
+					ItemInfo: details.ItemInfo{
+						Groups: &details.GroupsInfo{
+							ItemType:   details.GroupsPlannerTask,
+							ParentPath: "planB",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -246,7 +270,8 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 			expect: arr(
 				libItem, libItem2, libItem3,
 				chanItem, chanItem2, chanItem3,
-				convItem, convItem2, convItem3),
+				convItem, convItem2, convItem3,
+				taskItem, taskItem2),
 		},
 		{
 			name: "only match library item",
@@ -323,6 +348,24 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 			},
 			expect: arr(convItem2),
 		},
+		{
+			name: "only match planner task",
+			makeSelector: func() *GroupsRestore {
+				sel := NewGroupsRestore(Any())
+				sel.Include(sel.PlannerTasks(Any(), []string{"taskitem2"}))
+				return sel
+			},
+			expect: arr(taskItem2),
+		},
+		{
+			name: "only match plan",
+			makeSelector: func() *GroupsRestore {
+				sel := NewGroupsRestore(Any())
+				sel.Include(sel.Plans([]string{"planA"}))
+				return sel
+			},
+			expect: arr(taskItem),
+		},
 		{
 			name: "conversation id doesn't match name",
 			makeSelector: func() *GroupsRestore {
@@ -389,6 +432,17 @@ func (suite *GroupsSelectorSuite) TestGroupsCategory_PathValues() {
 			},
 			cfg: Config{},
 		},
+		{
+			name:      "Groups Planner Tasks",
+			sc:        GroupsPlannerTask,
+			pathElems: elems,
+			locRef:    "",
+			expected: map[categorizer][]string{
+				GroupsPlan:        {""},
+				GroupsPlannerTask: {itemID, shortRef},
+			},
+			cfg: Config{},
+		},
 	}
 
 	for _, test := range table {
@@ -436,6 +490,8 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 		mod    = now.Add(15 * time.Minute)
 		future = now.Add(45 * time.Minute)
 		dgcm   = details.GroupsChannelMessage
+		dgcp   = details.GroupsConversationPost
+		dgpt   = details.GroupsPlannerTask
 		dspl   = details.SharePointLibrary
 	)
 
@@ -500,6 +556,22 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 		{"chan msg last reply before future", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(future)), assert.Truef},
 		{"chan msg last reply before now", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(now)), assert.Falsef},
 		{"chan msg last reply before epoch", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(now)), assert.Falsef},
+
+		{"conversation topic", dgcp, user, sel.ConversationTopic("planning"), assert.Truef},
+		{"conversation topic wrong type", dgcm, user, sel.ConversationTopic("planning"), assert.Falsef},
+		{"not conversation topic", dgcp, user, sel.ConversationTopic("budget"), assert.Falsef},
+		{"post create after the epoch", dgcp, user, sel.PostCreatedAfter(dttm.Format(epoch)), assert.Truef},
+		{"post create after the epoch wrong type", dgcm, user, sel.PostCreatedAfter(dttm.Format(epoch)), assert.Falsef},
+		{"post create after now", dgcp, user, sel.PostCreatedAfter(dttm.Format(now)), assert.Falsef},
+		{"post create after later", dgcp, user, sel.PostCreatedAfter(dttm.Format(future)), assert.Falsef},
+		{"post create before future", dgcp, user, sel.PostCreatedBefore(dttm.Format(future)), assert.Truef},
+		{"post create before now", dgcp, user, sel.PostCreatedBefore(dttm.Format(now)), assert.Falsef},
+
+		{"planner bucket", dgpt, user, sel.PlannerBucket("To do"), assert.Truef},
+		{"planner bucket wrong type", dgcp, user, sel.PlannerBucket("To do"), assert.Falsef},
+		{"not planner bucket", dgpt, user, sel.PlannerBucket("Done"), assert.Falsef},
+		{"planner task title", dgpt, user, sel.PlannerTaskTitle("launch"), assert.Truef},
+		{"not planner task title", dgpt, user, sel.PlannerTaskTitle("retro"), assert.Falsef},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -521,6 +593,15 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 					LastReply: details.ChannelMessageInfo{
 						CreatedAt: mod,
 					},
+					Post: details.ConversationPostInfo{
+						Creator:   test.creator,
+						CreatedAt: now,
+						Topic:     "Quarterly planning",
+					},
+					Task: details.PlannerTaskInfo{
+						Bucket: "To do",
+						Title:  "Prepare the launch",
+					},
 				},
 			}
 
@@ -552,10 +633,17 @@ func (suite *GroupsSelectorSuite) TestCategory_PathType() {
 		{GroupsInfoChannelMessageCreatedBefore, path.ChannelMessagesCategory},
 		{GroupsInfoChannelMessageLastReplyAfter, path.ChannelMessagesCategory},
 		{GroupsInfoChannelMessageLastReplyBefore, path.ChannelMessagesCategory},
+		{GroupsInfoConversationTopic, path.ConversationPostsCategory},
+		{GroupsInfoConversationPostCreatedAfter, path.ConversationPostsCategory},
+		{GroupsInfoConversationPostCreatedBefore, path.ConversationPostsCategory},
 		{GroupsLibraryFolder, path.LibrariesCategory},
 		{GroupsLibraryItem, path.LibrariesCategory},
 		{GroupsInfoSiteLibraryDrive, path.LibrariesCategory},
 		{GroupsInfoSite, path.LibrariesCategory},
+		{GroupsPlan, path.PlannerTasksCategory},
+		{GroupsPlannerTask, path.PlannerTasksCategory},
+		{GroupsInfoPlannerBucket, path.PlannerTasksCategory},
+		{GroupsInfoPlannerTaskTitle, path.PlannerTasksCategory},
 	}
 	for _, test := range table {
 		suite.Run(test.cat.String(), func() {
@@ -123,6 +123,11 @@ func (s oneDrive) PathCategories() selectorPathCategories {
 	}
 }
 
+// detailsFilter produces the chunk filter for the selector.
+func (s oneDrive) detailsFilter() details.ChunkFilter {
+	return detailsFilterFor[OneDriveScope, oneDriveCategory](s.Selector, true, FileInfoModifiedAfter, FileInfoModifiedBefore)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -214,7 +219,10 @@ func (s *oneDrive) Scopes() []OneDriveScope {
 func (s *oneDrive) AllData() []OneDriveScope {
 	scopes := []OneDriveScope{}
 
-	scopes = append(scopes, makeScope[OneDriveScope](OneDriveFolder, Any()))
+	scopes = append(
+		scopes,
+		makeScope[OneDriveScope](OneDriveFolder, Any()),
+		makeScope[OneDriveScope](OneDriveNotebookSection, Any()))
 
 	return scopes
 }
@@ -253,6 +261,41 @@ func (s *oneDrive) Items(folders, items []string, opts ...option) []OneDriveScop
 	return scopes
 }
 
+// NotebookSections produces one or more OneDrive notebook section scopes.
+// Sections are matched by their location: notebook name, followed by any
+// section groups, followed by the section name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *oneDrive) NotebookSections(sections []string, opts ...option) []OneDriveScope {
+	var (
+		scopes = []OneDriveScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[OneDriveScope](OneDriveNotebookSection, sections, os...))
+
+	return scopes
+}
+
+// NotebookPages produces one or more OneDrive notebook page scopes.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+// options are only applied to the section scopes.
+func (s *oneDrive) NotebookPages(sections, pages []string, opts ...option) []OneDriveScope {
+	scopes := []OneDriveScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[OneDriveScope](OneDriveNotebookPage, pages, defaultItemOptions(s.Cfg)...).
+			set(OneDriveNotebookSection, sections, opts...))
+
+	return scopes
+}
+
 // -------------------
 // Filter Factories
 
@@ -331,6 +374,9 @@ const (
 	OneDriveItem   oneDriveCategory = "OneDriveItem"
 	OneDriveFolder oneDriveCategory = "OneDriveFolder"
 
+	OneDriveNotebookSection oneDriveCategory = "OneDriveNotebookSection"
+	OneDriveNotebookPage    oneDriveCategory = "OneDriveNotebookPage"
+
 	// details.ItemInfo comparables
 	FileInfoCreatedAfter   oneDriveCategory = "FileInfoCreatedAfter"
 	FileInfoCreatedBefore  oneDriveCategory = "FileInfoCreatedBefore"
@@ -344,6 +390,10 @@ var oneDriveLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{OneDriveFolder, OneDriveItem},
 		pathType: path.FilesCategory,
 	},
+	OneDriveNotebookPage: {
+		pathKeys: []categorizer{OneDriveNotebookSection, OneDriveNotebookPage},
+		pathType: path.NotebooksCategory,
+	},
 	OneDriveUser: { // the root category must be represented, even though it isn't a leaf
 		pathKeys: []categorizer{OneDriveUser},
 		pathType: path.UnknownCategory,
@@ -365,6 +415,8 @@ func (c oneDriveCategory) leafCat() categorizer {
 		FileInfoCreatedAfter, FileInfoCreatedBefore,
 		FileInfoModifiedAfter, FileInfoModifiedBefore:
 		return OneDriveItem
+	case OneDriveNotebookSection, OneDriveNotebookPage:
+		return OneDriveNotebookPage
 	}
 
 	return c
@@ -385,10 +437,9 @@ func (c oneDriveCategory) isUnion() bool {
 	return c == c.rootCat()
 }
 
-// isLeaf is true if the category is a OneDriveItem category.
+// isLeaf is true if the category is a OneDriveItem or OneDriveNotebookPage category.
 func (c oneDriveCategory) isLeaf() bool {
-	// return c == c.leafCat()??
-	return c == OneDriveItem
+	return c == OneDriveItem || c == OneDriveNotebookPage
 }
 
 // pathValues transforms the two paths to maps of identified properties.
@@ -401,6 +452,10 @@ func (c oneDriveCategory) pathValues(
 	ent details.Entry,
 	cfg Config,
 ) (map[categorizer][]string, error) {
+	if c.leafCat() == OneDriveNotebookPage {
+		return oneNotePathValues(repo, ent, cfg, OneDriveNotebookSection, OneDriveNotebookPage)
+	}
+
 	if ent.OneDrive == nil {
 		return nil, clues.New("no OneDrive ItemInfo in details")
 	}
@@ -429,6 +484,34 @@ func (c oneDriveCategory) pathValues(
 	return result, nil
 }
 
+// oneNotePathValues produces the path values of a notebook page.  Sections
+// match on their location (notebook/section groups/section), pages on their
+// ID or title.  Shared by every service that backs up notebooks.
+func oneNotePathValues(
+	repo path.Path,
+	ent details.Entry,
+	cfg Config,
+	sectionCat, pageCat categorizer,
+) (map[categorizer][]string, error) {
+	if ent.OneNote == nil {
+		return nil, clues.New("no OneNote ItemInfo in details")
+	}
+
+	item := ent.ItemRef
+	if len(item) == 0 {
+		item = repo.Item()
+	}
+
+	if cfg.OnlyMatchItemNames {
+		item = ent.OneNote.ItemName
+	}
+
+	return map[categorizer][]string{
+		sectionCat: {ent.LocationRef},
+		pageCat:    {item, ent.ShortRef},
+	}, nil
+}
+
 // pathKeys returns the path keys recognized by the receiver's leaf type.
 func (c oneDriveCategory) pathKeys() []categorizer {
 	return oneDriveLeafProperties[c.leafCat()].pathKeys
@@ -497,7 +580,8 @@ func (s OneDriveScope) Get(cat oneDriveCategory) []string {
 // sets a value by category to the scope.  Only intended for internal use.
 func (s OneDriveScope) set(cat oneDriveCategory, v []string, opts ...option) OneDriveScope {
 	os := []option{}
-	if cat == OneDriveFolder {
+	switch cat {
+	case OneDriveFolder, OneDriveNotebookSection:
 		os = append(os, pathComparator())
 	}
 
@@ -510,8 +594,12 @@ func (s OneDriveScope) setDefaults() {
 	case OneDriveUser:
 		s[OneDriveFolder.String()] = passAny
 		s[OneDriveItem.String()] = passAny
+		s[OneDriveNotebookSection.String()] = passAny
+		s[OneDriveNotebookPage.String()] = passAny
 	case OneDriveFolder:
 		s[OneDriveItem.String()] = passAny
+	case OneDriveNotebookSection:
+		s[OneDriveNotebookPage.String()] = passAny
 	}
 }
 
@@ -531,7 +619,8 @@ func (s oneDrive) Reduce(
 		deets,
 		s.Selector,
 		map[path.CategoryType]oneDriveCategory{
-			path.FilesCategory: OneDriveItem,
+			path.FilesCategory:     OneDriveItem,
+			path.NotebooksCategory: OneDriveNotebookPage,
 		},
 		errs)
 }
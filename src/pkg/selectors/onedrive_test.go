@@ -66,15 +66,12 @@ func (suite *OneDriveSelectorSuite) TestOneDriveSelector_AllData() {
 		suite.Run(test.name, func() {
 			t := suite.T()
 
-			require.Len(t, test.scopesToCheck, 1)
+			require.Len(t, test.scopesToCheck, 2)
 			for _, scope := range test.scopesToCheck {
 				scopeMustHave(
 					t,
 					OneDriveScope(scope),
-					map[categorizer][]string{
-						OneDriveItem:   Any(),
-						OneDriveFolder: Any(),
-					})
+					allDataScopeValues(OneDriveScope(scope)))
 			}
 		})
 	}
@@ -96,16 +93,13 @@ func (suite *OneDriveSelectorSuite) TestOneDriveSelector_Include_AllData() {
 
 	sel.Include(allScopes)
 	scopes := sel.Includes
-	require.Len(t, scopes, 1)
+	require.Len(t, scopes, 2)
 
 	for _, sc := range scopes {
 		scopeMustHave(
 			t,
 			OneDriveScope(sc),
-			map[categorizer][]string{
-				OneDriveItem:   Any(),
-				OneDriveFolder: Any(),
-			})
+			allDataScopeValues(OneDriveScope(sc)))
 	}
 }
 
@@ -125,16 +119,29 @@ func (suite *OneDriveSelectorSuite) TestOneDriveSelector_Exclude_AllData() {
 
 	sel.Exclude(allScopes)
 	scopes := sel.Excludes
-	require.Len(t, scopes, 1)
+	require.Len(t, scopes, 2)
 
 	for _, sc := range scopes {
 		scopeMustHave(
 			t,
 			OneDriveScope(sc),
-			map[categorizer][]string{
-				OneDriveItem:   Any(),
-				OneDriveFolder: Any(),
-			})
+			allDataScopeValues(OneDriveScope(sc)))
+	}
+}
+
+// allDataScopeValues returns the values expected in each of the
+// scopes produced by AllData().
+func allDataScopeValues(sc OneDriveScope) map[categorizer][]string {
+	if sc.Category() == OneDriveNotebookSection {
+		return map[categorizer][]string{
+			OneDriveNotebookSection: Any(),
+			OneDriveNotebookPage:    Any(),
+		}
+	}
+
+	return map[categorizer][]string{
+		OneDriveItem:   Any(),
+		OneDriveFolder: Any(),
 	}
 }
 
@@ -178,6 +185,12 @@ func (suite *OneDriveSelectorSuite) TestOneDriveRestore_Reduce() {
 			"drive/driveID/root:/folderD.d/folderE.d",
 			"file3")
 		fileParent3 = "folderD/folderE"
+		page        = stubRepoRef(
+			path.OneDriveService,
+			path.NotebooksCategory,
+			"uid",
+			"notebookID/sectionID",
+			"page")
 	)
 
 	deets := &details.Details{
@@ -216,6 +229,19 @@ func (suite *OneDriveSelectorSuite) TestOneDriveRestore_Reduce() {
 						},
 					},
 				},
+				{
+					RepoRef:     page,
+					ItemRef:     "page",
+					LocationRef: "Notebook/Section",
+					ItemInfo: details.ItemInfo{
+						OneNote: &details.OneNoteInfo{
+							ItemType: details.OneNotePage,
+							ItemName: "pageName",
+							Notebook: "Notebook",
+							Section:  "Section",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -237,7 +263,7 @@ func (suite *OneDriveSelectorSuite) TestOneDriveRestore_Reduce() {
 				odr.Include(odr.AllData())
 				return odr
 			},
-			expect: arr(file, file2, file3),
+			expect: arr(file, file2, file3, page),
 		},
 		{
 			name: "only match file",
@@ -286,6 +312,25 @@ func (suite *OneDriveSelectorSuite) TestOneDriveRestore_Reduce() {
 			},
 			expect: arr(file, file2),
 		},
+		{
+			name: "only match notebook section",
+			makeSelector: func() *OneDriveRestore {
+				odr := NewOneDriveRestore([]string{"uid"})
+				odr.Include(odr.NotebookSections([]string{"Notebook"}, PrefixMatch()))
+				return odr
+			},
+			expect: arr(page),
+		},
+		{
+			name: "only match notebook page name",
+			makeSelector: func() *OneDriveRestore {
+				odr := NewOneDriveRestore(Any())
+				odr.Include(odr.NotebookPages(Any(), []string{"pageName"}))
+				return odr
+			},
+			expect: arr(page),
+			cfg:    Config{OnlyMatchItemNames: true},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -438,6 +483,8 @@ func (suite *OneDriveSelectorSuite) TestCategory_PathType() {
 		{FileInfoCreatedBefore, path.FilesCategory},
 		{FileInfoModifiedAfter, path.FilesCategory},
 		{FileInfoModifiedBefore, path.FilesCategory},
+		{OneDriveNotebookSection, path.NotebooksCategory},
+		{OneDriveNotebookPage, path.NotebooksCategory},
 	}
 	for _, test := range table {
 		suite.Run(test.cat.String(), func() {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/alcionai/clues"
 	"golang.org/x/exp/maps"
@@ -12,6 +13,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/filters"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -26,6 +28,8 @@ const (
 	ServiceOneDrive   service = 2 // OneDrive
 	ServiceSharePoint service = 3 // SharePoint
 	ServiceGroups     service = 4 // Groups
+	ServiceTeamsChats service = 5 // TeamsChats
+	ServiceEntraID    service = 6 // EntraID
 )
 
 var serviceToPathType = map[service]path.ServiceType{
@@ -34,6 +38,8 @@ var serviceToPathType = map[service]path.ServiceType{
 	ServiceOneDrive:   path.OneDriveService,
 	ServiceSharePoint: path.SharePointService,
 	ServiceGroups:     path.GroupsService,
+	ServiceTeamsChats: path.TeamsChatsService,
+	ServiceEntraID:    path.EntraIDService,
 }
 
 var (
@@ -90,6 +96,10 @@ type pathCategorier interface {
 	PathCategories() selectorPathCategories
 }
 
+type detailsFilterer interface {
+	detailsFilter() details.ChunkFilter
+}
+
 type pathServicer interface {
 	PathService() path.ServiceType
 }
@@ -294,6 +304,19 @@ func (s Selector) PathCategories() (selectorPathCategories, error) {
 	return ro.PathCategories(), nil
 }
 
+// DetailsFilter produces a details.ChunkFilter that pre-selects the
+// details entries the selector can match: those within the included
+// categories and folders, and within the bounds of any modified-time
+// filters.  Selectors must still Reduce the details read using the filter.
+func (s Selector) DetailsFilter() details.ChunkFilter {
+	df, err := selectorAsIface[detailsFilterer](s)
+	if err != nil {
+		return details.ChunkFilter{}
+	}
+
+	return df.detailsFilter()
+}
+
 // AllHumanPathCategories returns the sets of include and filter path categories
 // across all scope sets. This is good for logging because it returns the
 // string version of the categories and sorts the slice so the category set is
@@ -353,6 +376,12 @@ func selectorAsIface[T any](s Selector) (T, error) {
 	case ServiceGroups:
 		a, err = func() (any, error) { return s.ToGroupsRestore() }()
 		t = a.(T)
+	case ServiceTeamsChats:
+		a, err = func() (any, error) { return s.ToTeamsChatsRestore() }()
+		t = a.(T)
+	case ServiceEntraID:
+		a, err = func() (any, error) { return s.ToEntraIDRestore() }()
+		t = a.(T)
 	default:
 		err = clues.Stack(ErrorUnrecognizedService, clues.New(s.Service.String()))
 	}
@@ -442,6 +471,103 @@ func (ls loggableSelector) marshal() string {
 // helpers
 // ---------------------------------------------------------------------------
 
+// detailsFilterFor produces the details.ChunkFilter for the selector's
+// scopes.  Folders are only filtered if byFolder is set and every
+// inclusion limits the folders of the items it matches.  Filters on the
+// modifiedAfter and modifiedBefore info categories bound the modified
+// times of the chunks that get read.
+func detailsFilterFor[T scopeT, C categoryT](
+	s Selector,
+	byFolder bool,
+	modifiedAfter, modifiedBefore C,
+) details.ChunkFilter {
+	var cf details.ChunkFilter
+
+	for _, sc := range s.Filters {
+		t := T(sc)
+
+		ic := getInfoCategory(t)
+		if len(ic) == 0 {
+			continue
+		}
+
+		tm, ok := filterTime(t[ic])
+		if !ok {
+			continue
+		}
+
+		// filters are all-match, so the narrowest bounds apply.
+		switch ic {
+		case modifiedAfter.String():
+			if tm.After(cf.ModifiedAfter) {
+				cf.ModifiedAfter = tm
+			}
+		case modifiedBefore.String():
+			if cf.ModifiedBefore.IsZero() || tm.Before(cf.ModifiedBefore) {
+				cf.ModifiedBefore = tm
+			}
+		}
+	}
+
+	cats := pathCategoriesIn[T, C](s.Includes)
+	if len(cats) == 0 || slices.Contains(cats, path.UnknownCategory) {
+		return cf
+	}
+
+	cf.Categories = cats
+
+	if byFolder {
+		cf.Folders = folderFiltersIn[T](s.Includes)
+	}
+
+	return cf
+}
+
+// folderFiltersIn produces the folder filters of the scopes.  Returns nil
+// if any scope matches items regardless of their folder.
+func folderFiltersIn[T scopeT](ss []scope) []filters.Filter {
+	fs := []filters.Filter{}
+
+	for _, sc := range ss {
+		t := T(sc)
+
+		if len(getInfoCategory(t)) > 0 {
+			return nil
+		}
+
+		keys := t.categorizer().leafCat().pathKeys()
+		if len(keys) < 2 {
+			return nil
+		}
+
+		// the folder precedes the item in the path keys.
+		filt, ok := t[keys[len(keys)-2].String()]
+		if !ok || filt.Comparator == filters.Passes {
+			return nil
+		}
+
+		// scopes that match no folders can't add to the matches.
+		if filt.Comparator == filters.Fails {
+			continue
+		}
+
+		fs = append(fs, filt)
+	}
+
+	return fs
+}
+
+// filterTime produces the time targeted by an info filter.
+func filterTime(f filters.Filter) (time.Time, bool) {
+	if len(f.Targets) != 1 {
+		return time.Time{}, false
+	}
+
+	tm, err := dttm.ParseTime(f.Targets[0])
+
+	return tm, err == nil
+}
+
 // produces the discrete set of path categories in the slice of scopes.
 func pathCategoriesIn[T scopeT, C categoryT](ss []scope) []path.CategoryType {
 	m := map[path.CategoryType]struct{}{}
@@ -3,12 +3,14 @@ package selectors
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/filters"
 	"github.com/alcionai/corso/src/pkg/path"
 )
@@ -367,6 +369,151 @@ func (suite *SelectorSuite) TestPathCategories_includes() {
 	}
 }
 
+func (suite *SelectorSuite) TestDetailsFilter() {
+	var (
+		users   = []string{"someuser@onmicrosoft.com"}
+		earlier = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		later   = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	table := []struct {
+		name          string
+		sel           func() Selector
+		expectCats    []path.CategoryType
+		expectFolders []string
+		expectAfter   time.Time
+		expectBefore  time.Time
+	}{
+		{
+			name: "empty",
+			sel:  func() Selector { return Selector{} },
+		},
+		{
+			name: "no includes",
+			sel: func() Selector {
+				return NewExchangeRestore(users).Selector
+			},
+		},
+		{
+			name: "mail",
+			sel: func() Selector {
+				sel := NewExchangeRestore(users)
+				sel.Include(sel.MailFolders([]string{"Inbox"}))
+
+				return sel.Selector
+			},
+			expectCats:    []path.CategoryType{path.EmailCategory},
+			expectFolders: []string{"Inbox"},
+		},
+		{
+			name: "mail and events",
+			sel: func() Selector {
+				sel := NewExchangeRestore(users)
+				sel.Include(
+					sel.MailFolders([]string{"Inbox"}),
+					sel.EventCalendars([]string{"July"}))
+
+				return sel.Selector
+			},
+			expectCats:    []path.CategoryType{path.EmailCategory, path.EventsCategory},
+			expectFolders: []string{"Inbox", "July"},
+		},
+		{
+			name: "all mail",
+			sel: func() Selector {
+				sel := NewExchangeRestore(users)
+				sel.Include(
+					sel.MailFolders([]string{"Inbox"}),
+					sel.MailFolders(Any()))
+
+				return sel.Selector
+			},
+			expectCats: []path.CategoryType{path.EmailCategory},
+		},
+		{
+			name: "drive folder",
+			sel: func() Selector {
+				sel := NewOneDriveRestore(users)
+				sel.Include(sel.Folders([]string{"a/b"}, PrefixMatch()))
+
+				return sel.Selector
+			},
+			expectCats:    []path.CategoryType{path.FilesCategory},
+			expectFolders: []string{"a/b"},
+		},
+		{
+			name: "drive items in any folder",
+			sel: func() Selector {
+				sel := NewOneDriveRestore(users)
+				sel.Include(sel.Items(Any(), []string{"file"}))
+
+				return sel.Selector
+			},
+			expectCats: []path.CategoryType{path.FilesCategory},
+		},
+		{
+			name: "drive folder modified within a range",
+			sel: func() Selector {
+				sel := NewOneDriveRestore(users)
+				sel.Include(sel.Folders([]string{"a"}))
+				sel.Filter(
+					sel.ModifiedAfter(dttm.Format(earlier)),
+					sel.ModifiedBefore(dttm.Format(later)))
+
+				return sel.Selector
+			},
+			expectCats:    []path.CategoryType{path.FilesCategory},
+			expectFolders: []string{"a"},
+			expectAfter:   earlier,
+			expectBefore:  later,
+		},
+		{
+			name: "modified filter without includes",
+			sel: func() Selector {
+				sel := NewOneDriveRestore(users)
+				sel.Filter(sel.ModifiedAfter(dttm.Format(earlier)))
+
+				return sel.Selector
+			},
+			expectAfter: earlier,
+		},
+		{
+			name: "sharepoint, matching item names",
+			sel: func() Selector {
+				sel := NewSharePointRestore([]string{"site"})
+				sel.Include(sel.LibraryFolders([]string{"a"}))
+				sel.Configure(Config{OnlyMatchItemNames: true})
+
+				return sel.Selector
+			},
+			expectCats: []path.CategoryType{path.LibrariesCategory},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			filter := test.sel().DetailsFilter()
+			assert.ElementsMatch(t, test.expectCats, filter.Categories)
+			assert.Empty(t, filter.RepoRefPrefixes)
+			assert.True(t, test.expectAfter.Equal(filter.ModifiedAfter), "modified after")
+			assert.True(t, test.expectBefore.Equal(filter.ModifiedBefore), "modified before")
+
+			folders := []string{}
+
+			for _, f := range filter.Folders {
+				folders = append(folders, f.Targets...)
+			}
+
+			if len(test.expectFolders) == 0 {
+				assert.Empty(t, filter.Folders)
+			} else {
+				assert.ElementsMatch(t, test.expectFolders, folders)
+			}
+		})
+	}
+}
+
 func (suite *SelectorSuite) TestSelector_pii() {
 	table := []struct {
 		name        string
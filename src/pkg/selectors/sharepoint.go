@@ -123,6 +123,13 @@ func (s sharePoint) PathCategories() selectorPathCategories {
 	}
 }
 
+// detailsFilter produces the chunk filter for the selector.  List folders
+// are matched by the list name when only matching item names, which the
+// details index doesn't hold, so folders are only filtered otherwise.
+func (s sharePoint) detailsFilter() details.ChunkFilter {
+	return detailsFilterFor[SharePointScope, sharePointCategory](s.Selector, !s.Cfg.OnlyMatchItemNames, SharePointInfoModifiedAfter, SharePointInfoModifiedBefore)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -253,7 +260,8 @@ func (s *sharePoint) AllData() []SharePointScope {
 		scopes,
 		makeScope[SharePointScope](SharePointLibraryFolder, Any()),
 		makeScope[SharePointScope](SharePointList, Any()),
-		makeScope[SharePointScope](SharePointPageFolder, Any()))
+		makeScope[SharePointScope](SharePointPageFolder, Any()),
+		makeScope[SharePointScope](SharePointNotebookSection, Any()))
 
 	return scopes
 }
@@ -367,6 +375,39 @@ func (s *sharePoint) PageItems(pages, items []string, opts ...option) []SharePoi
 	return scopes
 }
 
+// NotebookSections produces one or more SharePoint notebook section scopes.
+// Sections are matched by their location: notebook name, followed by any
+// section groups, followed by the section name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *sharePoint) NotebookSections(sections []string, opts ...option) []SharePointScope {
+	var (
+		scopes = []SharePointScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(scopes, makeScope[SharePointScope](SharePointNotebookSection, sections, os...))
+
+	return scopes
+}
+
+// NotebookPages produces one or more SharePoint notebook page scopes.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+// options are only applied to the section scopes.
+func (s *sharePoint) NotebookPages(sections, pages []string, opts ...option) []SharePointScope {
+	scopes := []SharePointScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[SharePointScope](SharePointNotebookPage, pages, defaultItemOptions(s.Cfg)...).
+			set(SharePointNotebookSection, sections, opts...))
+
+	return scopes
+}
+
 // -------------------
 // ItemInfo Factories
 
@@ -474,6 +515,9 @@ const (
 	SharePointPageFolder    sharePointCategory = "SharePointPageFolder"
 	SharePointPage          sharePointCategory = "SharePointPage"
 
+	SharePointNotebookSection sharePointCategory = "SharePointNotebookSection"
+	SharePointNotebookPage    sharePointCategory = "SharePointNotebookPage"
+
 	// details.itemInfo comparables
 	SharePointInfoCreatedAfter   sharePointCategory = "SharePointInfoCreatedAfter"
 	SharePointInfoCreatedBefore  sharePointCategory = "SharePointInfoCreatedBefore"
@@ -503,6 +547,10 @@ var sharePointLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{SharePointPageFolder, SharePointPage},
 		pathType: path.PagesCategory,
 	},
+	SharePointNotebookPage: {
+		pathKeys: []categorizer{SharePointNotebookSection, SharePointNotebookPage},
+		pathType: path.NotebooksCategory,
+	},
 	SharePointSite: { // the root category must be represented, even though it isn't a leaf
 		pathKeys: []categorizer{SharePointSite},
 		pathType: path.UnknownCategory,
@@ -530,6 +578,8 @@ func (c sharePointCategory) leafCat() categorizer {
 		return SharePointListItem
 	case SharePointPage, SharePointPageFolder:
 		return SharePointPage
+	case SharePointNotebookSection, SharePointNotebookPage:
+		return SharePointNotebookPage
 	}
 
 	return c
@@ -598,6 +648,9 @@ func (c sharePointCategory) pathValues(
 		rFld = ent.LocationRef
 		itemName = ent.ItemInfo.SharePoint.ItemName
 
+	case SharePointNotebookSection, SharePointNotebookPage:
+		return oneNotePathValues(repo, ent, cfg, SharePointNotebookSection, SharePointNotebookPage)
+
 	default:
 		return nil, clues.New("unrecognized sharePointCategory").With("category", c)
 	}
@@ -697,7 +750,7 @@ func (s SharePointScope) set(cat sharePointCategory, v []string, opts ...option)
 	// 1.there is no nested folders -> there cannot be lists within other lists
 	// 2. list itself is the item -> so container and item are the same
 	// since there is no path involved here, we do not need any path filters.
-	case SharePointLibraryFolder, SharePointPage:
+	case SharePointLibraryFolder, SharePointPage, SharePointNotebookSection:
 		os = append(os, pathComparator())
 	}
 
@@ -714,12 +767,16 @@ func (s SharePointScope) setDefaults() {
 		s[SharePointListItem.String()] = passAny
 		s[SharePointPageFolder.String()] = passAny
 		s[SharePointPage.String()] = passAny
+		s[SharePointNotebookSection.String()] = passAny
+		s[SharePointNotebookPage.String()] = passAny
 	case SharePointLibraryFolder:
 		s[SharePointLibraryItem.String()] = passAny
 	case SharePointList:
 		s[SharePointListItem.String()] = passAny
 	case SharePointPageFolder:
 		s[SharePointPage.String()] = passAny
+	case SharePointNotebookSection:
+		s[SharePointNotebookPage.String()] = passAny
 	}
 }
 
@@ -742,6 +799,7 @@ func (s sharePoint) Reduce(
 			path.LibrariesCategory: SharePointLibraryItem,
 			path.ListsCategory:     SharePointListItem,
 			path.PagesCategory:     SharePointPage,
+			path.NotebooksCategory: SharePointNotebookPage,
 		},
 		errs)
 }
@@ -596,6 +596,8 @@ func (suite *SharePointSelectorSuite) TestCategory_PathType() {
 		{SharePointLibraryFolder, path.LibrariesCategory},
 		{SharePointLibraryItem, path.LibrariesCategory},
 		{SharePointList, path.ListsCategory},
+		{SharePointNotebookSection, path.NotebooksCategory},
+		{SharePointNotebookPage, path.NotebooksCategory},
 	}
 	for _, test := range table {
 		suite.Run(test.cat.String(), func() {
@@ -0,0 +1,553 @@
+package selectors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/filters"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// ---------------------------------------------------------------------------
+// Selectors
+// ---------------------------------------------------------------------------
+
+type (
+	// teamsChats provides an api for selecting
+	// data scopes applicable to the teams chats service.
+	teamsChats struct {
+		Selector
+	}
+
+	// TeamsChatsBackup provides an api for selecting
+	// data scopes applicable to the teams chats service,
+	// plus backup-specific methods.
+	TeamsChatsBackup struct {
+		teamsChats
+	}
+
+	// TeamsChatsRestore provides an api for selecting
+	// data scopes applicable to the teams chats service,
+	// plus restore-specific methods.
+	TeamsChatsRestore struct {
+		teamsChats
+	}
+)
+
+var (
+	_ Reducer        = &TeamsChatsRestore{}
+	_ pathCategorier = &TeamsChatsRestore{}
+	_ reasoner       = &TeamsChatsRestore{}
+)
+
+// NewTeamsChatsBackup produces a new Selector with the service set to ServiceTeamsChats.
+func NewTeamsChatsBackup(users []string) *TeamsChatsBackup {
+	src := TeamsChatsBackup{
+		teamsChats{
+			newSelector(ServiceTeamsChats, users),
+		},
+	}
+
+	return &src
+}
+
+// ToTeamsChatsBackup transforms the generic selector into a TeamsChatsBackup.
+// Errors if the service defined by the selector is not ServiceTeamsChats.
+func (s Selector) ToTeamsChatsBackup() (*TeamsChatsBackup, error) {
+	if s.Service != ServiceTeamsChats {
+		return nil, badCastErr(ServiceTeamsChats, s.Service)
+	}
+
+	src := TeamsChatsBackup{teamsChats{s}}
+
+	return &src, nil
+}
+
+func (s TeamsChatsBackup) SplitByResourceOwner(users []string) []TeamsChatsBackup {
+	sels := splitByProtectedResource[TeamsChatsScope](s.Selector, users, TeamsChatsUser)
+
+	ss := make([]TeamsChatsBackup, 0, len(sels))
+	for _, sel := range sels {
+		ss = append(ss, TeamsChatsBackup{teamsChats{sel}})
+	}
+
+	return ss
+}
+
+// NewTeamsChatsRestore produces a new Selector with the service set to ServiceTeamsChats.
+func NewTeamsChatsRestore(users []string) *TeamsChatsRestore {
+	src := TeamsChatsRestore{
+		teamsChats{
+			newSelector(ServiceTeamsChats, users),
+		},
+	}
+
+	return &src
+}
+
+// ToTeamsChatsRestore transforms the generic selector into a TeamsChatsRestore.
+// Errors if the service defined by the selector is not ServiceTeamsChats.
+func (s Selector) ToTeamsChatsRestore() (*TeamsChatsRestore, error) {
+	if s.Service != ServiceTeamsChats {
+		return nil, badCastErr(ServiceTeamsChats, s.Service)
+	}
+
+	src := TeamsChatsRestore{teamsChats{s}}
+
+	return &src, nil
+}
+
+func (s TeamsChatsRestore) SplitByResourceOwner(users []string) []TeamsChatsRestore {
+	sels := splitByProtectedResource[TeamsChatsScope](s.Selector, users, TeamsChatsUser)
+
+	ss := make([]TeamsChatsRestore, 0, len(sels))
+	for _, sel := range sels {
+		ss = append(ss, TeamsChatsRestore{teamsChats{sel}})
+	}
+
+	return ss
+}
+
+// PathCategories produces the aggregation of discrete users described by each type of scope.
+func (s teamsChats) PathCategories() selectorPathCategories {
+	return selectorPathCategories{
+		Excludes: pathCategoriesIn[TeamsChatsScope, teamsChatsCategory](s.Excludes),
+		Filters:  pathCategoriesIn[TeamsChatsScope, teamsChatsCategory](s.Filters),
+		Includes: pathCategoriesIn[TeamsChatsScope, teamsChatsCategory](s.Includes),
+	}
+}
+
+// detailsFilter produces the chunk filter for the selector.  Folders
+// are matched by the elements of the RepoRef, which the details index
+// doesn't hold, so only categories get filtered.
+func (s teamsChats) detailsFilter() details.ChunkFilter {
+	return detailsFilterFor[TeamsChatsScope, teamsChatsCategory](s.Selector, false, TeamsChatsCategoryUnknown, TeamsChatsCategoryUnknown)
+}
+
+// Reasons returns a deduplicated set of the backup reasons produced
+// using the selector's discrete owner and each scopes' service and
+// category types.
+func (s teamsChats) Reasons(tenantID string, useOwnerNameForID bool) []identity.Reasoner {
+	return reasonsFor(s, tenantID, useOwnerNameForID)
+}
+
+// ---------------------------------------------------------------------------
+// Stringers and Concealers
+// ---------------------------------------------------------------------------
+
+func (s TeamsChatsScope) Conceal() string             { return conceal(s) }
+func (s TeamsChatsScope) Format(fs fmt.State, r rune) { format(s, fs, r) }
+func (s TeamsChatsScope) String() string              { return conceal(s) }
+func (s TeamsChatsScope) PlainString() string         { return plainString(s) }
+
+// -------------------
+// Scope Factories
+
+// Include appends the provided scopes to the selector's inclusion set.
+// Data is included if it matches ANY inclusion.
+// The inclusion set is later filtered (all included data must pass ALL
+// filters) and excluded (all included data must not match ANY exclusion).
+// Data is included if it matches ANY inclusion (of the same data category).
+//
+// All parts of the scope must match for data to be exclucded.
+// Ex: ChatMessages(c1, m1) => only excludes a message if it is located
+// in chat c1, and ID'd as m1.  Use selectors.Any() to wildcard a scope
+// value. No value will match if selectors.None() is provided.
+//
+// Group-level scopes will automatically apply the Any() wildcard to
+// child properties.
+// ex: Chats(c1) automatically cascades to all messages in c1.
+func (s *teamsChats) Include(scopes ...[]TeamsChatsScope) {
+	s.Includes = appendScopes(s.Includes, scopes...)
+}
+
+// Exclude appends the provided scopes to the selector's exclusion set.
+// Every Exclusion scope applies globally, affecting all inclusion scopes.
+// Data is excluded if it matches ANY exclusion.
+//
+// All parts of the scope must match for data to be exclucded.
+// Ex: ChatMessages(c1, m1) => only excludes a message if it is located
+// in chat c1, and ID'd as m1.  Use selectors.Any() to wildcard a scope
+// value. No value will match if selectors.None() is provided.
+//
+// Group-level scopes will automatically apply the Any() wildcard to
+// child properties.
+// ex: Chats(c1) automatically cascades to all messages in c1.
+func (s *teamsChats) Exclude(scopes ...[]TeamsChatsScope) {
+	s.Excludes = appendScopes(s.Excludes, scopes...)
+}
+
+// Filter appends the provided scopes to the selector's filters set.
+// A selector with >0 filters and 0 inclusions will include any data
+// that passes all filters.
+// A selector with >0 filters and >0 inclusions will reduce the
+// inclusion set to only the data that passes all filters.
+// Data is retained if it passes ALL filters.
+//
+// All parts of the scope must match for data to be exclucded.
+// Ex: ChatMessages(c1, m1) => only excludes a message if it is located
+// in chat c1, and ID'd as m1.  Use selectors.Any() to wildcard a scope
+// value. No value will match if selectors.None() is provided.
+//
+// Group-level scopes will automatically apply the Any() wildcard to
+// child properties.
+// ex: Chats(c1) automatically cascades to all messages in c1.
+func (s *teamsChats) Filter(scopes ...[]TeamsChatsScope) {
+	s.Filters = appendScopes(s.Filters, scopes...)
+}
+
+// Scopes retrieves the list of teamsChatsScopes in the selector.
+func (s *teamsChats) Scopes() []TeamsChatsScope {
+	return scopes[TeamsChatsScope](s.Selector)
+}
+
+// -------------------
+// Scope Factories
+
+// AllData produces a scope that selects every chat, and every message
+// within those chats.
+func (s *teamsChats) AllData() []TeamsChatsScope {
+	return []TeamsChatsScope{makeScope[TeamsChatsScope](TeamsChatsChat, Any())}
+}
+
+// Chats produces one or more teams chat scopes, where the chat matches
+// upon a given chat by ID or name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *teamsChats) Chats(chats []string, opts ...option) []TeamsChatsScope {
+	var (
+		scopes = []TeamsChatsScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(scopes, makeScope[TeamsChatsScope](TeamsChatsChat, chats, os...))
+
+	return scopes
+}
+
+// ChatMessages produces one or more teams chat message scopes.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *teamsChats) ChatMessages(chats, messages []string, opts ...option) []TeamsChatsScope {
+	scopes := []TeamsChatsScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[TeamsChatsScope](TeamsChatsChatMessage, messages, defaultItemOptions(s.Cfg)...).
+			set(TeamsChatsChat, chats, opts...))
+
+	return scopes
+}
+
+// -------------------
+// ItemInfo Factories
+
+// ChatMember produces one or more teams chat member info scopes.
+// Matches any message in a chat where one of the members matches the
+// provided name or email address.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *TeamsChatsRestore) ChatMember(member string) []TeamsChatsScope {
+	return []TeamsChatsScope{
+		makeInfoScope[TeamsChatsScope](
+			TeamsChatsChatMessage,
+			TeamsChatsInfoChatMember,
+			[]string{member},
+			filters.In),
+	}
+}
+
+// MessageCreator produces one or more teams chat message info scopes.
+// Matches any chat message created by the specified user.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *TeamsChatsRestore) MessageCreator(creator string) []TeamsChatsScope {
+	return []TeamsChatsScope{
+		makeInfoScope[TeamsChatsScope](
+			TeamsChatsChatMessage,
+			TeamsChatsInfoMessageCreator,
+			[]string{creator},
+			filters.In),
+	}
+}
+
+// MessageCreatedAfter produces a chat message created-after info scope.
+// Matches any message where the creation time is after the timestring.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *TeamsChatsRestore) MessageCreatedAfter(timeStrings string) []TeamsChatsScope {
+	return []TeamsChatsScope{
+		makeInfoScope[TeamsChatsScope](
+			TeamsChatsChatMessage,
+			TeamsChatsInfoMessageCreatedAfter,
+			[]string{timeStrings},
+			filters.Less),
+	}
+}
+
+// MessageCreatedBefore produces a chat message created-before info scope.
+// Matches any message where the creation time is before the timestring.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *TeamsChatsRestore) MessageCreatedBefore(timeStrings string) []TeamsChatsScope {
+	return []TeamsChatsScope{
+		makeInfoScope[TeamsChatsScope](
+			TeamsChatsChatMessage,
+			TeamsChatsInfoMessageCreatedBefore,
+			[]string{timeStrings},
+			filters.Greater),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Categories
+// ---------------------------------------------------------------------------
+
+// teamsChatsCategory enumerates the type of the lowest level
+// of data () in a scope.
+type teamsChatsCategory string
+
+// interface compliance checks
+var _ categorizer = TeamsChatsCategoryUnknown
+
+const (
+	TeamsChatsCategoryUnknown teamsChatsCategory = ""
+
+	// types of data in teams chats
+	TeamsChatsUser        teamsChatsCategory = "TeamsChatsUser"
+	TeamsChatsChat        teamsChatsCategory = "TeamsChatsChat"
+	TeamsChatsChatMessage teamsChatsCategory = "TeamsChatsChatMessage"
+
+	// data contained within details.ItemInfo
+	TeamsChatsInfoChatMember           teamsChatsCategory = "TeamsChatsInfoChatMember"
+	TeamsChatsInfoMessageCreator       teamsChatsCategory = "TeamsChatsInfoMessageCreator"
+	TeamsChatsInfoMessageCreatedAfter  teamsChatsCategory = "TeamsChatsInfoMessageCreatedAfter"
+	TeamsChatsInfoMessageCreatedBefore teamsChatsCategory = "TeamsChatsInfoMessageCreatedBefore"
+)
+
+// teamsChatsLeafProperties describes common metadata of the leaf categories
+var teamsChatsLeafProperties = map[categorizer]leafProperty{
+	TeamsChatsChatMessage: {
+		pathKeys: []categorizer{TeamsChatsChat, TeamsChatsChatMessage},
+		pathType: path.ChatsCategory,
+	},
+	TeamsChatsUser: { // the root category must be represented, even though it isn't a leaf
+		pathKeys: []categorizer{TeamsChatsUser},
+		pathType: path.UnknownCategory,
+	},
+}
+
+func (c teamsChatsCategory) String() string {
+	return string(c)
+}
+
+// leafCat returns the leaf category of the receiver.
+// If the receiver category has multiple leaves (ex: User) or no leaves,
+// (ex: Unknown), the receiver itself is returned.
+// Ex: ServiceTypeFolder.leafCat() => ServiceTypeItem
+// Ex: ServiceUser.leafCat() => ServiceUser
+func (c teamsChatsCategory) leafCat() categorizer {
+	switch c {
+	case TeamsChatsChat, TeamsChatsChatMessage,
+		TeamsChatsInfoChatMember, TeamsChatsInfoMessageCreator,
+		TeamsChatsInfoMessageCreatedAfter, TeamsChatsInfoMessageCreatedBefore:
+		return TeamsChatsChatMessage
+	}
+
+	return c
+}
+
+// rootCat returns the root category type.
+func (c teamsChatsCategory) rootCat() categorizer {
+	return TeamsChatsUser
+}
+
+// unknownCat returns the unknown category type.
+func (c teamsChatsCategory) unknownCat() categorizer {
+	return TeamsChatsCategoryUnknown
+}
+
+// isUnion returns true if c is a user
+func (c teamsChatsCategory) isUnion() bool {
+	return c == c.rootCat()
+}
+
+// isLeaf is true if the category is a TeamsChatsChatMessage category.
+func (c teamsChatsCategory) isLeaf() bool {
+	return c == c.leafCat()
+}
+
+// pathValues transforms the two paths to maps of identified properties.
+//
+// Example:
+// [tenantID, service, userID, category, chatID, messageID]
+// => {chat: chatName, message: messageID}
+func (c teamsChatsCategory) pathValues(
+	repo path.Path,
+	ent details.Entry,
+	cfg Config,
+) (map[categorizer][]string, error) {
+	if ent.TeamsChats == nil {
+		return nil, clues.New("no TeamsChats ItemInfo in details")
+	}
+
+	item := ent.ItemRef
+	if len(item) == 0 {
+		item = repo.Item()
+	}
+
+	result := map[categorizer][]string{
+		TeamsChatsChat:        {ent.TeamsChats.ParentPath},
+		TeamsChatsChatMessage: {item, ent.ShortRef},
+	}
+
+	if folders := repo.Folders(); len(folders) > 0 {
+		// chats can also be selected by their ID.
+		result[TeamsChatsChat] = append(result[TeamsChatsChat], folders[len(folders)-1])
+	}
+
+	return result, nil
+}
+
+// pathKeys returns the path keys recognized by the receiver's leaf type.
+func (c teamsChatsCategory) pathKeys() []categorizer {
+	return teamsChatsLeafProperties[c.leafCat()].pathKeys
+}
+
+// PathType converts the category's leaf type into the matching path.CategoryType.
+func (c teamsChatsCategory) PathType() path.CategoryType {
+	return teamsChatsLeafProperties[c.leafCat()].pathType
+}
+
+// ---------------------------------------------------------------------------
+// Scopes
+// ---------------------------------------------------------------------------
+
+// TeamsChatsScope specifies the data available
+// when interfacing with the teams chats service.
+type TeamsChatsScope scope
+
+// interface compliance checks
+var _ scoper = &TeamsChatsScope{}
+
+// Category describes the type of the data in scope.
+func (s TeamsChatsScope) Category() teamsChatsCategory {
+	return teamsChatsCategory(getCategory(s))
+}
+
+// categorizer type is a generic wrapper around Category.
+// Primarily used by scopes.go to for abstract comparisons.
+func (s TeamsChatsScope) categorizer() categorizer {
+	return s.Category()
+}
+
+// Matches returns true if the category is included in the scope's
+// data type, and the target string matches that category's comparator.
+func (s TeamsChatsScope) Matches(cat teamsChatsCategory, target string) bool {
+	return matches(s, cat, target)
+}
+
+// InfoCategory returns the category enum of the scope info.
+// If the scope is not an info type, returns TeamsChatsCategoryUnknown.
+func (s TeamsChatsScope) InfoCategory() teamsChatsCategory {
+	return teamsChatsCategory(getInfoCategory(s))
+}
+
+// IncludeCategory checks whether the scope includes a
+// certain category of data.
+// Ex: to check if the scope includes chat messages:
+// s.IncludesCategory(selector.TeamsChatsChatMessage)
+func (s TeamsChatsScope) IncludesCategory(cat teamsChatsCategory) bool {
+	return categoryMatches(s.Category(), cat)
+}
+
+// returns true if the category is included in the scope's data type,
+// and the value is set to Any().
+func (s TeamsChatsScope) IsAny(cat teamsChatsCategory) bool {
+	return IsAnyTarget(s, cat)
+}
+
+// Get returns the data category in the scope.  If the scope
+// contains all data types for a user, it'll return the
+// TeamsChatsUser category.
+func (s TeamsChatsScope) Get(cat teamsChatsCategory) []string {
+	return getCatValue(s, cat)
+}
+
+// sets a value by category to the scope.  Only intended for internal use.
+func (s TeamsChatsScope) set(cat teamsChatsCategory, v []string, opts ...option) TeamsChatsScope {
+	os := []option{}
+	if cat == TeamsChatsChat {
+		os = append(os, pathComparator())
+	}
+
+	return set(s, cat, v, append(os, opts...)...)
+}
+
+// setDefaults ensures that user scopes express `AnyTgt` for their child category types.
+func (s TeamsChatsScope) setDefaults() {
+	switch s.Category() {
+	case TeamsChatsUser:
+		s[TeamsChatsChat.String()] = passAny
+		s[TeamsChatsChatMessage.String()] = passAny
+	case TeamsChatsChat:
+		s[TeamsChatsChatMessage.String()] = passAny
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Backup Details Filtering
+// ---------------------------------------------------------------------------
+
+// Reduce filters the entries in a details struct to only those that match the
+// inclusions, filters, and exclusions in the selector.
+func (s teamsChats) Reduce(
+	ctx context.Context,
+	deets *details.Details,
+	errs *fault.Bus,
+) *details.Details {
+	return reduce[TeamsChatsScope](
+		ctx,
+		deets,
+		s.Selector,
+		map[path.CategoryType]teamsChatsCategory{
+			path.ChatsCategory: TeamsChatsChatMessage,
+		},
+		errs)
+}
+
+// matchesInfo handles the standard behavior when comparing a scope and a
+// teamsChatsInfo.  Returns true if the scope and info match for the provided
+// category.
+func (s TeamsChatsScope) matchesInfo(dii details.ItemInfo) bool {
+	var (
+		infoCat = s.InfoCategory()
+		i       = ""
+		info    = dii.TeamsChats
+	)
+
+	if info == nil || info.ItemType != details.TeamsChatMessage {
+		return false
+	}
+
+	switch infoCat {
+	case TeamsChatsInfoChatMember:
+		return matchesAny(s, TeamsChatsInfoChatMember, info.Chat.Members)
+	case TeamsChatsInfoMessageCreator:
+		i = info.Message.Creator
+	case TeamsChatsInfoMessageCreatedAfter, TeamsChatsInfoMessageCreatedBefore:
+		i = dttm.Format(info.Message.CreatedAt)
+	}
+
+	return s.Matches(infoCat, i)
+}
@@ -0,0 +1,256 @@
+package selectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type TeamsChatsSelectorSuite struct {
+	tester.Suite
+}
+
+func TestTeamsChatsSelectorSuite(t *testing.T) {
+	suite.Run(t, &TeamsChatsSelectorSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *TeamsChatsSelectorSuite) TestNewTeamsChatsBackup() {
+	t := suite.T()
+	ob := NewTeamsChatsBackup(nil)
+	assert.Equal(t, ob.Service, ServiceTeamsChats)
+	assert.NotZero(t, ob.Scopes())
+}
+
+func (suite *TeamsChatsSelectorSuite) TestToTeamsChatsBackup() {
+	t := suite.T()
+	ob := NewTeamsChatsBackup(nil)
+	s := ob.Selector
+	ob, err := s.ToTeamsChatsBackup()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, ob.Service, ServiceTeamsChats)
+	assert.NotZero(t, ob.Scopes())
+
+	_, err = NewGroupsBackup(nil).Selector.ToTeamsChatsBackup()
+	assert.Error(t, err, "wrong service")
+}
+
+func (suite *TeamsChatsSelectorSuite) TestToTeamsChatsRestore() {
+	t := suite.T()
+	eb := NewTeamsChatsRestore(nil)
+	s := eb.Selector
+	or, err := s.ToTeamsChatsRestore()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, or.Service, ServiceTeamsChats)
+	assert.NotZero(t, or.Scopes())
+}
+
+func (suite *TeamsChatsSelectorSuite) TestTeamsChatsRestore_Reduce() {
+	var (
+		msg1 = stubRepoRef(path.TeamsChatsService, path.ChatsCategory, "uid", "chat1", "msg1")
+		msg2 = stubRepoRef(path.TeamsChatsService, path.ChatsCategory, "uid", "chat1", "msg2")
+		msg3 = stubRepoRef(path.TeamsChatsService, path.ChatsCategory, "uid", "chat2", "msg3")
+	)
+
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				{
+					RepoRef:     msg1,
+					ItemRef:     "msg1",
+					LocationRef: "Planning",
+					ItemInfo: details.ItemInfo{
+						TeamsChats: &details.TeamsChatsInfo{
+							ItemType:   details.TeamsChatMessage,
+							ParentPath: "Planning",
+						},
+					},
+				},
+				{
+					RepoRef:     msg2,
+					LocationRef: "Planning",
+					// ItemRef intentionally blank to test fallback case
+					ItemInfo: details.ItemInfo{
+						TeamsChats: &details.TeamsChatsInfo{
+							ItemType:   details.TeamsChatMessage,
+							ParentPath: "Planning",
+						},
+					},
+				},
+				{
+					RepoRef:     msg3,
+					ItemRef:     "msg3",
+					LocationRef: "Adele Vance",
+					ItemInfo: details.ItemInfo{
+						TeamsChats: &details.TeamsChatsInfo{
+							ItemType:   details.TeamsChatMessage,
+							ParentPath: "Adele Vance",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	arr := func(s ...string) []string {
+		return s
+	}
+
+	table := []struct {
+		name         string
+		makeSelector func() *TeamsChatsRestore
+		expect       []string
+	}{
+		{
+			name: "all",
+			makeSelector: func() *TeamsChatsRestore {
+				sel := NewTeamsChatsRestore(Any())
+				sel.Include(sel.AllData())
+				return sel
+			},
+			expect: arr(msg1, msg2, msg3),
+		},
+		{
+			name: "chat by name",
+			makeSelector: func() *TeamsChatsRestore {
+				sel := NewTeamsChatsRestore(Any())
+				sel.Include(sel.Chats([]string{"Planning"}))
+				return sel
+			},
+			expect: arr(msg1, msg2),
+		},
+		{
+			name: "chat by id",
+			makeSelector: func() *TeamsChatsRestore {
+				sel := NewTeamsChatsRestore(Any())
+				sel.Include(sel.Chats([]string{"chat2"}))
+				return sel
+			},
+			expect: arr(msg3),
+		},
+		{
+			name: "only match message",
+			makeSelector: func() *TeamsChatsRestore {
+				sel := NewTeamsChatsRestore(Any())
+				sel.Include(sel.ChatMessages(Any(), []string{"msg2"}))
+				return sel
+			},
+			expect: arr(msg2),
+		},
+		{
+			name: "exclude chat",
+			makeSelector: func() *TeamsChatsRestore {
+				sel := NewTeamsChatsRestore(Any())
+				sel.Include(sel.AllData())
+				sel.Exclude(sel.Chats([]string{"Planning"}))
+				return sel
+			},
+			expect: arr(msg3),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := test.makeSelector()
+			results := sel.Reduce(ctx, deets, fault.New(true))
+			paths := results.Paths()
+			assert.Equal(t, test.expect, paths)
+		})
+	}
+}
+
+func (suite *TeamsChatsSelectorSuite) TestTeamsChatsScope_MatchesInfo() {
+	var (
+		sel    = NewTeamsChatsRestore(Any())
+		user   = "adele@contoso.com"
+		epoch  = time.Time{}
+		now    = time.Now()
+		future = now.Add(45 * time.Minute)
+		dtcm   = details.TeamsChatMessage
+	)
+
+	type expectation func(t assert.TestingT, value bool, msg string, args ...any) bool
+
+	table := []struct {
+		name     string
+		itemType details.ItemType
+		scope    []TeamsChatsScope
+		expect   expectation
+	}{
+		{"member", dtcm, sel.ChatMember(user), assert.Truef},
+		{"member partial", dtcm, sel.ChatMember("adele"), assert.Truef},
+		{"not a member", dtcm, sel.ChatMember("megan"), assert.Falsef},
+		{"creator", dtcm, sel.MessageCreator(user), assert.Truef},
+		{"not the creator", dtcm, sel.MessageCreator("megan"), assert.Falsef},
+		{"created after the epoch", dtcm, sel.MessageCreatedAfter(dttm.Format(epoch)), assert.Truef},
+		{"created after the epoch wrong type", details.UnknownType, sel.MessageCreatedAfter(dttm.Format(epoch)), assert.Falsef},
+		{"created after now", dtcm, sel.MessageCreatedAfter(dttm.Format(now)), assert.Falsef},
+		{"created before future", dtcm, sel.MessageCreatedBefore(dttm.Format(future)), assert.Truef},
+		{"created before now", dtcm, sel.MessageCreatedBefore(dttm.Format(now)), assert.Falsef},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			itemInfo := details.ItemInfo{
+				TeamsChats: &details.TeamsChatsInfo{
+					ItemType: test.itemType,
+					Chat: details.ChatInfo{
+						Members: []string{"megan.bowen@contoso.com", user},
+					},
+					Message: details.ChatMessageInfo{
+						Creator:   user,
+						CreatedAt: now,
+					},
+				},
+			}
+
+			scopes := setScopesToDefault(test.scope)
+			for _, scope := range scopes {
+				test.expect(
+					t,
+					scope.matchesInfo(itemInfo),
+					"not matching:\nscope:\n\t%+v\ninfo:\n\t%+v",
+					scope,
+					itemInfo.TeamsChats)
+			}
+		})
+	}
+}
+
+func (suite *TeamsChatsSelectorSuite) TestCategory_PathType() {
+	table := []struct {
+		cat      teamsChatsCategory
+		pathType path.CategoryType
+	}{
+		{TeamsChatsCategoryUnknown, path.UnknownCategory},
+		{TeamsChatsUser, path.UnknownCategory},
+		{TeamsChatsChat, path.ChatsCategory},
+		{TeamsChatsChatMessage, path.ChatsCategory},
+		{TeamsChatsInfoChatMember, path.ChatsCategory},
+		{TeamsChatsInfoMessageCreator, path.ChatsCategory},
+		{TeamsChatsInfoMessageCreatedAfter, path.ChatsCategory},
+		{TeamsChatsInfoMessageCreatedBefore, path.ChatsCategory},
+	}
+	for _, test := range table {
+		suite.Run(test.cat.String(), func() {
+			assert.Equal(
+				suite.T(),
+				test.pathType.String(),
+				test.cat.PathType().String())
+		})
+	}
+}
@@ -2,11 +2,12 @@ package api
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 )
@@ -24,45 +25,30 @@ type Access struct {
 	Client
 }
 
-// GetToken retrieves a m365 application auth token using client id and secret credentials.
-// This token is not normally needed in order for corso to function, and is implemented
-// primarily as a way to exercise the validity of those credentials without need of specific
-// permissions.
+// GetToken retrieves a m365 application auth token using the client credentials:
+// a secret, a certificate, or a federated token.  This token is not normally needed
+// in order for corso to function, and is implemented primarily as a way to exercise
+// the validity of those credentials without need of specific permissions.
 func (c Access) GetToken(
 	ctx context.Context,
 ) error {
-	var (
-		//nolint:lll
-		// https://learn.microsoft.com/en-us/graph/connecting-external-content-connectors-api-postman#step-5-get-an-authentication-token
-		rawURL = fmt.Sprintf(
-			"https://login.microsoftonline.com/%s/oauth2/v2.0/token",
-			c.Credentials.AzureTenantID)
-		headers = map[string]string{
-			"Content-Type": "application/x-www-form-urlencoded",
-		}
-		body = strings.NewReader(fmt.Sprintf(
-			"client_id=%s"+
-				"&client_secret=%s"+
-				"&scope=https://graph.microsoft.com/.default"+
-				"&grant_type=client_credentials",
-			c.Credentials.AzureClientID,
-			c.Credentials.AzureClientSecret))
-	)
-
-	resp, err := c.Post(ctx, rawURL, headers, body)
+	cred, err := graph.NewCredential(c.Credentials)
 	if err != nil {
-		return graph.Stack(ctx, err)
+		return clues.StackWC(ctx, err)
 	}
 
-	if resp.StatusCode == http.StatusBadRequest {
-		return clues.New("incorrect tenant or application parameters")
+	_, err = cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{graph.DefaultScope}})
+	if err == nil {
+		return nil
 	}
 
-	if resp.StatusCode/100 == 4 || resp.StatusCode/100 == 5 {
-		return clues.New("non-2xx response: " + resp.Status)
-	}
+	var afe *azidentity.AuthenticationFailedError
 
-	defer resp.Body.Close()
+	if errors.As(err, &afe) &&
+		afe.RawResponse != nil &&
+		afe.RawResponse.StatusCode == http.StatusBadRequest {
+		return clues.NewWC(ctx, "incorrect tenant or application parameters")
+	}
 
-	return nil
+	return clues.WrapWC(ctx, err, "getting m365 token")
 }
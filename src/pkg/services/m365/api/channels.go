@@ -99,10 +99,107 @@ func (c Channels) GetChannelByName(
 	return cal, nil
 }
 
+// CreateMigrationChannel creates a standard channel in migration mode.
+// Channels in migration mode accept messages with their original authors
+// and timestamps, but can't be used by anyone until the migration is
+// completed.  createdAt must be earlier than any message imported into
+// the channel.
+func (c Channels) CreateMigrationChannel(
+	ctx context.Context,
+	teamID, displayName string,
+	createdAt time.Time,
+) (models.Channelable, error) {
+	ctx = clues.Add(ctx, "channel_name", displayName)
+
+	body := models.NewChannel()
+	body.SetDisplayName(ptr.To(displayName))
+	body.SetCreatedDateTime(ptr.To(createdAt))
+	body.SetMembershipType(ptr.To(models.STANDARD_CHANNELMEMBERSHIPTYPE))
+	body.SetAdditionalData(map[string]any{
+		"@microsoft.graph.channelCreationMode": "migration",
+	})
+
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating migration channel")
+	}
+
+	return resp, nil
+}
+
+// CompleteChannelMigration ends migration mode for the channel, after
+// which it's open to the team's members.
+func (c Channels) CompleteChannelMigration(
+	ctx context.Context,
+	teamID, channelID string,
+) error {
+	err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		ByChannelId(channelID).
+		CompleteMigration().
+		Post(ctx, nil)
+
+	return graph.Wrap(ctx, err, "completing channel migration").OrNil()
+}
+
 // ---------------------------------------------------------------------------
 // message
 // ---------------------------------------------------------------------------
 
+// PostMigrationMessage imports a message into a channel that's in
+// migration mode.
+func (c Channels) PostMigrationMessage(
+	ctx context.Context,
+	teamID, channelID string,
+	body models.ChatMessageable,
+) (models.ChatMessageable, error) {
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		ByChannelId(channelID).
+		Messages().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "importing channel message")
+	}
+
+	return resp, nil
+}
+
+// PostMigrationReply imports a reply to a message in a channel that's
+// in migration mode.
+func (c Channels) PostMigrationReply(
+	ctx context.Context,
+	teamID, channelID, messageID string,
+	body models.ChatMessageable,
+) (models.ChatMessageable, error) {
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		ByChannelId(channelID).
+		Messages().
+		ByChatMessageId(messageID).
+		Replies().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "importing channel message reply")
+	}
+
+	return resp, nil
+}
+
 func (c Channels) GetChannelMessage(
 	ctx context.Context,
 	teamID, channelID, messageID string,
@@ -127,7 +224,7 @@ func (c Channels) GetChannelMessage(
 
 	message.SetReplies(replies)
 
-	info := channelMessageInfo(message)
+	info := ChannelMessageInfo(message)
 
 	return message, info, nil
 }
@@ -136,7 +233,51 @@ func (c Channels) GetChannelMessage(
 // Helpers
 // ---------------------------------------------------------------------------
 
-func channelMessageInfo(
+func BytesToChatMessageable(body []byte) (models.ChatMessageable, error) {
+	v, err := CreateFromBytes(body, models.CreateChatMessageFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	msg, ok := v.(models.ChatMessageable)
+	if !ok {
+		return nil, clues.New("deserialized item is not a chat message")
+	}
+
+	return msg, nil
+}
+
+// ReplaceChatMessageAttachmentMarkup swaps the markup of each of the
+// attachments in the message content for a plain text reference to the
+// attachment.  Markup of any other attachment is left in place.
+func ReplaceChatMessageAttachmentMarkup(
+	content string,
+	attachments []models.ChatMessageAttachmentable,
+) string {
+	attMap := map[string]string{}
+
+	for _, att := range attachments {
+		attMap[ptr.Val(att.GetId())] = ptr.Val(att.GetName())
+	}
+
+	return attachmentMarkupRE.ReplaceAllStringFunc(content, func(sub string) string {
+		sm := attachmentMarkupRE.FindStringSubmatch(sub)
+		if len(sm) < 2 {
+			return sub
+		}
+
+		name, ok := attMap[sm[1]]
+		if !ok {
+			return sub
+		}
+
+		return fmt.Sprintf("[attachment:%s]", name)
+	})
+}
+
+// ChannelMessageInfo produces the details entry info for the message and
+// its replies.
+func ChannelMessageInfo(
 	msg models.ChatMessageable,
 ) *details.GroupsInfo {
 	var (
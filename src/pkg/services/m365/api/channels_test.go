@@ -549,7 +549,7 @@ func (suite *ChannelsAPIUnitSuite) TestChannelMessageInfo() {
 			t := suite.T()
 
 			chMsg, expected := test.msgAndInfo()
-			result := channelMessageInfo(chMsg)
+			result := ChannelMessageInfo(chMsg)
 
 			ma := result.Message.AttachmentNames
 			result.Message.AttachmentNames = nil
@@ -718,3 +718,57 @@ func (suite *ChannelsAPIUnitSuite) TestStripChatMessageContent() {
 		})
 	}
 }
+
+func (suite *ChannelsAPIUnitSuite) TestReplaceChatMessageAttachmentMarkup() {
+	attach1 := models.NewChatMessageAttachment()
+	attach1.SetId(ptr.To("id1"))
+	attach1.SetName(ptr.To("a1"))
+
+	attachML := func(id string) string {
+		return fmt.Sprintf(`<attachment id="%s"></attachment>`, id)
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		attachments []models.ChatMessageAttachmentable
+		expect      string
+	}{
+		{
+			name:        "empty content",
+			content:     "",
+			attachments: []models.ChatMessageAttachmentable{attach1},
+			expect:      "",
+		},
+		{
+			name:        "listed attachment",
+			content:     "<p>text</p>" + attachML("id1"),
+			attachments: []models.ChatMessageAttachmentable{attach1},
+			expect:      "<p>text</p>[attachment:a1]",
+		},
+		{
+			name:        "unlisted attachment is kept",
+			content:     "<p>text</p>" + attachML("id2"),
+			attachments: []models.ChatMessageAttachmentable{attach1},
+			expect:      "<p>text</p>" + attachML("id2"),
+		},
+		{
+			name:        "listed and unlisted",
+			content:     attachML("id1") + attachML("id2"),
+			attachments: []models.ChatMessageAttachmentable{attach1},
+			expect:      "[attachment:a1]" + attachML("id2"),
+		},
+		{
+			name:        "no attachments",
+			content:     attachML("id1"),
+			attachments: nil,
+			expect:      attachML("id1"),
+		},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			result := ReplaceChatMessageAttachmentMarkup(test.content, test.attachments)
+			assert.Equal(suite.T(), test.expect, result)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/chats"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) Chats() Chats {
+	return Chats{c}
+}
+
+// Chats is an interface-compliant provider of the client.
+type Chats struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// containers
+// ---------------------------------------------------------------------------
+
+// GetChat fetches the chat, along with its members and a preview of its
+// most recent message.
+func (c Chats) GetChat(
+	ctx context.Context,
+	chatID string,
+) (models.Chatable, error) {
+	config := &chats.ChatItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &chats.ChatItemRequestBuilderGetQueryParameters{
+			Expand: []string{"members", "lastMessagePreview"},
+		},
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Chats().
+		ByChatId(chatID).
+		Get(ctx, config)
+
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+// GetFirstChat fetches one of the user's chats, or nil if the user
+// isn't a member of any chat.  Used as a cheap check that the user is
+// able to use teams chats at all.
+func (c Chats) GetFirstChat(
+	ctx context.Context,
+	userID string,
+) (models.Chatable, error) {
+	config := &users.ItemChatsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemChatsRequestBuilderGetQueryParameters{
+			Top: ptr.To[int32](1),
+		},
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Chats().
+		Get(ctx, config)
+	if err != nil {
+		return nil, graph.Stack(ctx, err)
+	}
+
+	if len(resp.GetValue()) == 0 {
+		return nil, nil
+	}
+
+	return resp.GetValue()[0], nil
+}
+
+// ---------------------------------------------------------------------------
+// items
+// ---------------------------------------------------------------------------
+
+// GetChatMessage retrieves a single message from the chat.  Unlike
+// channel messages, chat messages have no replies.
+func (c Chats) GetChatMessage(
+	ctx context.Context,
+	chatID, messageID string,
+) (models.ChatMessageable, *details.TeamsChatsInfo, error) {
+	message, err := c.Stable.
+		Client().
+		Chats().
+		ByChatId(chatID).
+		Messages().
+		ByChatMessageId(messageID).
+		Get(ctx, nil)
+	if err != nil {
+		return nil, nil, graph.Stack(ctx, err)
+	}
+
+	return message, ChatMessageInfo(message), nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// ChatMessageInfo produces the details entry info for the message.  The
+// chat properties are owned by the chat, and need to be added separately
+// with ChatInfo.
+func ChatMessageInfo(msg models.ChatMessageable) *details.TeamsChatsInfo {
+	preview, contentLen, err := getChatMessageContentPreview(msg)
+	if err != nil {
+		preview = "malformed or unparseable html" + preview
+	}
+
+	return &details.TeamsChatsInfo{
+		ItemType: details.TeamsChatMessage,
+		Modified: ptr.OrNow(msg.GetLastModifiedDateTime()),
+		Message: details.ChatMessageInfo{
+			AttachmentNames: GetChatMessageAttachmentNames(msg),
+			CreatedAt:       ptr.Val(msg.GetCreatedDateTime()),
+			Creator:         GetChatMessageFrom(msg),
+			Preview:         preview,
+			Size:            contentLen,
+		},
+	}
+}
+
+// ChatInfo produces the details entry info describing the chat.
+func ChatInfo(chat models.Chatable) details.ChatInfo {
+	var (
+		chatType      string
+		lastMessageAt = ptr.Val(chat.GetLastUpdatedDateTime())
+	)
+
+	if chat.GetChatType() != nil {
+		chatType = chat.GetChatType().String()
+	}
+
+	if lmp := chat.GetLastMessagePreview(); lmp != nil && lmp.GetCreatedDateTime() != nil {
+		lastMessageAt = ptr.Val(lmp.GetCreatedDateTime())
+	}
+
+	return details.ChatInfo{
+		ChatType:      chatType,
+		CreatedAt:     ptr.Val(chat.GetCreatedDateTime()),
+		LastMessageAt: lastMessageAt,
+		Members:       ChatMembers(chat),
+		Name:          ChatDisplayName(chat),
+	}
+}
+
+// ChatMembers produces the email address of each member of the chat,
+// falling back to their display name for members without an address.
+func ChatMembers(chat models.Chatable) []string {
+	members := make([]string, 0, len(chat.GetMembers()))
+
+	for _, m := range chat.GetMembers() {
+		name := ptr.Val(m.GetDisplayName())
+
+		if um, ok := m.(models.AadUserConversationMemberable); ok && len(ptr.Val(um.GetEmail())) > 0 {
+			name = ptr.Val(um.GetEmail())
+		}
+
+		if len(name) > 0 {
+			members = append(members, name)
+		}
+	}
+
+	sort.Strings(members)
+
+	return members
+}
+
+// ChatDisplayName produces a human readable name for the chat.  Only
+// group chats can be given a topic; other chats are named after their
+// members, the same way the teams client does it.
+func ChatDisplayName(chat models.Chatable) string {
+	if topic := ptr.Val(chat.GetTopic()); len(topic) > 0 {
+		return topic
+	}
+
+	names := make([]string, 0, len(chat.GetMembers()))
+
+	for _, m := range chat.GetMembers() {
+		if name := ptr.Val(m.GetDisplayName()); len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return ptr.Val(chat.GetId())
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+func BytesToChatable(body []byte) (models.Chatable, error) {
+	v, err := CreateFromBytes(body, models.CreateChatFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	chat, ok := v.(models.Chatable)
+	if !ok {
+		return nil, clues.New("deserialized item is not a chat")
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/chats"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// chat message pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.ChatMessageable] = &chatMessagePageCtrl{}
+
+type chatMessagePageCtrl struct {
+	chatID  string
+	gs      graph.Servicer
+	builder *chats.ItemMessagesRequestBuilder
+	options *chats.ItemMessagesRequestBuilderGetRequestConfiguration
+}
+
+func (p *chatMessagePageCtrl) SetNextLink(nextLink string) {
+	p.builder = chats.NewItemMessagesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *chatMessagePageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.ChatMessageable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *chatMessagePageCtrl) ValidModTimes() bool {
+	return true
+}
+
+func (c Chats) NewChatMessagePager(
+	chatID string,
+	cc CallConfig,
+) *chatMessagePageCtrl {
+	builder := c.Stable.
+		Client().
+		Chats().
+		ByChatId(chatID).
+		Messages()
+
+	options := &chats.ItemMessagesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &chats.ItemMessagesRequestBuilderGetQueryParameters{},
+		Headers:         newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	if len(cc.Select) > 0 {
+		options.QueryParameters.Select = cc.Select
+	}
+
+	if len(cc.Expand) > 0 {
+		options.QueryParameters.Expand = cc.Expand
+	}
+
+	return &chatMessagePageCtrl{
+		chatID:  chatID,
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetChatMessages fetches all messages in the chat.
+func (c Chats) GetChatMessages(
+	ctx context.Context,
+	chatID string,
+	cc CallConfig,
+) ([]models.ChatMessageable, error) {
+	ctx = clues.Add(ctx, "chat_id", chatID)
+	pager := c.NewChatMessagePager(chatID, cc)
+	items, err := pagers.BatchEnumerateItems[models.ChatMessageable](ctx, pager)
+
+	return items, graph.Stack(ctx, err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// chat message delta pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.DeltaHandler[models.ChatMessageable] = &chatMessageDeltaPageCtrl{}
+
+type chatMessageDeltaPageCtrl struct {
+	chatID  string
+	gs      graph.Servicer
+	builder *chats.ItemMessagesDeltaRequestBuilder
+	options *chats.ItemMessagesDeltaRequestBuilderGetRequestConfiguration
+}
+
+func (p *chatMessageDeltaPageCtrl) SetNextLink(nextLink string) {
+	p.builder = chats.NewItemMessagesDeltaRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *chatMessageDeltaPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.DeltaLinkValuer[models.ChatMessageable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *chatMessageDeltaPageCtrl) Reset(context.Context) {
+	p.builder = p.gs.
+		Client().
+		Chats().
+		ByChatId(p.chatID).
+		Messages().
+		Delta()
+}
+
+func (p *chatMessageDeltaPageCtrl) ValidModTimes() bool {
+	return true
+}
+
+func (c Chats) NewChatMessageDeltaPager(
+	chatID, prevDelta string,
+	selectProps ...string,
+) *chatMessageDeltaPageCtrl {
+	builder := c.Stable.
+		Client().
+		Chats().
+		ByChatId(chatID).
+		Messages().
+		Delta()
+
+	if len(prevDelta) > 0 {
+		builder = chats.NewItemMessagesDeltaRequestBuilder(prevDelta, c.Stable.Adapter())
+	}
+
+	options := &chats.ItemMessagesDeltaRequestBuilderGetRequestConfiguration{
+		QueryParameters: &chats.ItemMessagesDeltaRequestBuilderGetQueryParameters{},
+		Headers:         newPreferHeaders(preferPageSize(maxDeltaPageSize)),
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	return &chatMessageDeltaPageCtrl{
+		chatID:  chatID,
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetChatMessageIDs fetches a delta of all messages in the chat.
+// returns two maps: addedItems, deletedItems
+func (c Chats) GetChatMessageIDs(
+	ctx context.Context,
+	chatID, prevDeltaLink string,
+	cc CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	aar, err := pagers.GetAddedAndRemovedItemIDs[models.ChatMessageable](
+		ctx,
+		c.NewChatMessagePager(chatID, CallConfig{}),
+		c.NewChatMessageDeltaPager(chatID, prevDeltaLink),
+		prevDeltaLink,
+		cc.CanMakeDeltaQueries,
+		0,
+		pagers.AddedAndRemovedByDeletedDateTime[models.ChatMessageable],
+		IsNotSystemMessage)
+
+	return aar, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// chat pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Chatable] = &chatPageCtrl{}
+
+type chatPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemChatsRequestBuilder
+	options *users.ItemChatsRequestBuilderGetRequestConfiguration
+}
+
+func (p *chatPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemChatsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *chatPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Chatable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *chatPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Chats) NewChatPager(
+	userID string,
+) *chatPageCtrl {
+	requestConfig := &users.ItemChatsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemChatsRequestBuilderGetQueryParameters{
+			Expand: []string{"members", "lastMessagePreview"},
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	res := &chatPageCtrl{
+		gs:      c.Stable,
+		options: requestConfig,
+		builder: c.Stable.
+			Client().
+			Users().
+			ByUserId(userID).
+			Chats(),
+	}
+
+	return res
+}
+
+// GetChats fetches all chats the user is a member of, along with the
+// members of each chat.
+func (c Chats) GetChats(
+	ctx context.Context,
+	userID string,
+) ([]models.Chatable, error) {
+	return pagers.BatchEnumerateItems[models.Chatable](ctx, c.NewChatPager(userID))
+}
@@ -107,9 +107,7 @@ func NewService(
 	opts ...graph.Option,
 ) (*graph.Service, error) {
 	a, err := graph.CreateAdapter(
-		creds.AzureTenantID,
-		creds.AzureClientID,
-		creds.AzureClientSecret,
+		creds,
 		counter,
 		opts...)
 	if err != nil {
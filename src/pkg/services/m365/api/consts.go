@@ -12,6 +12,13 @@ const (
 	MailInbox       = "Inbox"
 	MsgFolderRoot   = "msgfolderroot"
 
+	// ArchiveMsgFolderRoot is the root of the user's online archive
+	// mailbox.
+	ArchiveMsgFolderRoot = "archivemsgfolderroot"
+	// RecoverableItemsRoot holds the deleted, purged, and held items of
+	// the primary mailbox.  It sits outside of the msgfolderroot tree.
+	RecoverableItemsRoot = "recoverableitemsroot"
+
 	// Kiota JSON invalid JSON error message.
 	invalidJSON = "invalid json type"
 )
@@ -98,6 +98,72 @@ func (c Conversations) GetConversationPost(
 	return post, conversationPostInfo(post, contentLen, preview), graph.Stack(ctx, err).OrNil()
 }
 
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+// CreateConversationThread starts a new conversation in the group, with
+// the thread's topic and its first post.
+func (c Conversations) CreateConversationThread(
+	ctx context.Context,
+	groupID, topic string,
+	post models.Postable,
+) (models.ConversationThreadable, error) {
+	body := models.NewConversationThread()
+	body.SetTopic(ptr.To(topic))
+	body.SetPosts([]models.Postable{post})
+
+	resp, err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Threads().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating conversation thread")
+	}
+
+	return resp, nil
+}
+
+// ReplyToConversationThread adds the post to the end of the thread.
+func (c Conversations) ReplyToConversationThread(
+	ctx context.Context,
+	groupID, threadID string,
+	post models.Postable,
+) error {
+	body := groups.NewItemThreadsItemReplyPostRequestBody()
+	body.SetPost(post)
+
+	err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Threads().
+		ByConversationThreadId(threadID).
+		Reply().
+		Post(ctx, body, nil)
+
+	return graph.Wrap(ctx, err, "replying to conversation thread").OrNil()
+}
+
+// DeleteConversation deletes the conversation, along with all of its
+// threads and posts.
+func (c Conversations) DeleteConversation(
+	ctx context.Context,
+	groupID, conversationID string,
+) error {
+	err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Conversations().
+		ByConversationId(conversationID).
+		Delete(ctx, nil)
+
+	return graph.Wrap(ctx, err, "deleting conversation").OrNil()
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
@@ -192,3 +258,33 @@ func (c Conversations) getAttachments(
 
 	return result, totalSize, nil
 }
+
+func BytesToPostable(body []byte) (models.Postable, error) {
+	v, err := CreateFromBytes(body, models.CreatePostFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	post, ok := v.(models.Postable)
+	if !ok {
+		return nil, clues.New("deserialized item is not a post")
+	}
+
+	return post, nil
+}
+
+// ConversationPostInfo produces the details for a post restored into the
+// conversation with the given topic.
+func ConversationPostInfo(post models.Postable, topic string) *details.GroupsInfo {
+	preview, size, err := getConversationPostContentPreview(post)
+	if err != nil {
+		preview = ""
+	}
+
+	info := conversationPostInfo(post, size, preview)
+	if info != nil {
+		info.Post.Topic = topic
+	}
+
+	return info
+}
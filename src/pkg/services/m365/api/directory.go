@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/organization"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// directoryObjectURLPrefix is the prefix of the reference used to add a
+// directory object to a collection, such as the members of a group.
+const directoryObjectURLPrefix = "https://graph.microsoft.com/v1.0/directoryObjects/"
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) Directory() Directory {
+	return Directory{c}
+}
+
+// Directory is an interface-compliant provider of the client.
+type Directory struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// Tenant
+// ---------------------------------------------------------------------------
+
+// GetIDAndName looks up the tenant (organization) matching the given ID,
+// and returns its canonical ID and display name.
+func (c Directory) GetIDAndName(
+	ctx context.Context,
+	tenantID string,
+	_ CallConfig,
+) (string, string, error) {
+	options := &organization.OrganizationItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &organization.OrganizationItemRequestBuilderGetQueryParameters{
+			Select: idAnd(displayName),
+		},
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Organization().
+		ByOrganizationId(tenantID).
+		Get(ctx, options)
+	if err != nil {
+		return "", "", graph.Wrap(ctx, err, "getting organization")
+	}
+
+	return ptr.Val(resp.GetId()), ptr.Val(resp.GetDisplayName()), nil
+}
+
+// ---------------------------------------------------------------------------
+// Items
+// ---------------------------------------------------------------------------
+
+// GetDirectoryObject fetches the object with the given ID, regardless of
+// its type.  Primarily used to check whether the object still exists.
+func (c Directory) GetDirectoryObject(
+	ctx context.Context,
+	objectID string,
+) (models.DirectoryObjectable, error) {
+	resp, err := c.Stable.
+		Client().
+		DirectoryObjects().
+		ByDirectoryObjectId(objectID).
+		Get(ctx, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "getting directory object")
+	}
+
+	return resp, nil
+}
+
+// GetGroupMemberIDs produces the IDs of the direct members of the group.
+func (c Directory) GetGroupMemberIDs(
+	ctx context.Context,
+	groupID string,
+) ([]string, error) {
+	members, err := c.GetGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(members))
+
+	for _, m := range members {
+		ids = append(ids, ptr.Val(m.GetId()))
+	}
+
+	return ids, nil
+}
+
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+// RestoreDeletedItem restores a soft-deleted directory object from the
+// directory's recycle bin.  Objects are only retained in the recycle bin
+// for 30 days after deletion.
+func (c Directory) RestoreDeletedItem(
+	ctx context.Context,
+	objectID string,
+) (models.DirectoryObjectable, error) {
+	resp, err := c.Stable.
+		Client().
+		Directory().
+		DeletedItems().
+		ByDirectoryObjectId(objectID).
+		Restore().
+		Post(ctx, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "restoring deleted directory object")
+	}
+
+	return resp, nil
+}
+
+// AddGroupMember adds the directory object as a direct member of the group.
+func (c Directory) AddGroupMember(
+	ctx context.Context,
+	groupID, memberID string,
+) error {
+	body := models.NewReferenceCreate()
+	body.SetOdataId(ptr.To(directoryObjectURLPrefix + memberID))
+
+	err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Members().
+		Ref().
+		Post(ctx, body, nil)
+
+	return graph.Wrap(ctx, err, "adding group member").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// DirectoryObjectHash produces a digest of the serialized directory object.
+// The object is re-encoded before hashing so that the digest doesn't depend
+// on the order of its properties.
+func DirectoryObjectHash(body []byte) (string, error) {
+	var v any
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "", clues.Wrap(err, "decoding directory object")
+	}
+
+	// encoding/json sorts map keys, which gives us a stable encoding.
+	canon, err := json.Marshal(v)
+	if err != nil {
+		return "", clues.Wrap(err, "encoding directory object")
+	}
+
+	sum := sha256.Sum256(canon)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DirectoryObjectInfo produces the details info of a directory object.
+// The body is the serialized object, which is used to produce its hash.
+func DirectoryObjectInfo(
+	obj models.DirectoryObjectable,
+	body []byte,
+	members []string,
+	size int64,
+) (*details.EntraIDInfo, error) {
+	if obj == nil {
+		return nil, clues.New("nil directory object")
+	}
+
+	hash, err := DirectoryObjectHash(body)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	info := &details.EntraIDInfo{
+		Hash: hash,
+		Size: size,
+	}
+
+	switch o := obj.(type) {
+	case models.Userable:
+		info.ItemType = details.EntraIDUser
+		info.ItemName = ptr.Val(o.GetDisplayName())
+		info.PrincipalName = ptr.Val(o.GetUserPrincipalName())
+		info.Created = ptr.Val(o.GetCreatedDateTime())
+	case models.Groupable:
+		info.ItemType = details.EntraIDGroup
+		info.ItemName = ptr.Val(o.GetDisplayName())
+		info.PrincipalName = ptr.Val(o.GetMailNickname())
+		info.Created = ptr.Val(o.GetCreatedDateTime())
+		info.Members = members
+	case models.Applicationable:
+		info.ItemType = details.EntraIDApplication
+		info.ItemName = ptr.Val(o.GetDisplayName())
+		info.PrincipalName = ptr.Val(o.GetAppId())
+		info.Created = ptr.Val(o.GetCreatedDateTime())
+	case models.ServicePrincipalable:
+		info.ItemType = details.EntraIDServicePrincipal
+		info.ItemName = ptr.Val(o.GetDisplayName())
+		info.PrincipalName = ptr.Val(o.GetAppId())
+	default:
+		return nil, clues.New("unsupported directory object type")
+	}
+
+	// directory objects don't track modification times.  Creation is the
+	// only change graph reports.
+	info.Modified = info.Created
+
+	return info, nil
+}
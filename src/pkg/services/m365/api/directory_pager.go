@@ -0,0 +1,333 @@
+package api
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/applications"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// The properties selected for each kind of directory object.  Properties
+// that change without user intervention (ex: sign-in activity) are left
+// out so that they don't show up as changes between backups.
+var (
+	directoryUserProps = idAnd(
+		"accountEnabled",
+		"businessPhones",
+		"createdDateTime",
+		"department",
+		displayName,
+		"employeeId",
+		"givenName",
+		"jobTitle",
+		"mail",
+		"mailNickname",
+		"officeLocation",
+		"otherMails",
+		"surname",
+		"usageLocation",
+		userPrincipalName,
+		"userType")
+
+	directoryGroupProps = idAnd(
+		"classification",
+		"createdDateTime",
+		"description",
+		displayName,
+		"groupTypes",
+		"isAssignableToRole",
+		"mail",
+		"mailEnabled",
+		"mailNickname",
+		"membershipRule",
+		"membershipRuleProcessingState",
+		"securityEnabled",
+		"visibility")
+
+	directoryApplicationProps = idAnd(
+		"api",
+		"appId",
+		"appRoles",
+		"createdDateTime",
+		"description",
+		displayName,
+		"identifierUris",
+		"keyCredentials",
+		"passwordCredentials",
+		"publicClient",
+		"requiredResourceAccess",
+		"signInAudience",
+		"spa",
+		"tags",
+		"web")
+
+	directoryServicePrincipalProps = idAnd(
+		"accountEnabled",
+		"appId",
+		"appOwnerOrganizationId",
+		"appRoleAssignmentRequired",
+		"appRoles",
+		"description",
+		displayName,
+		"oauth2PermissionScopes",
+		"servicePrincipalNames",
+		"servicePrincipalType",
+		"tags")
+)
+
+// ---------------------------------------------------------------------------
+// user pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Userable] = &directoryUserPageCtrl{}
+
+type directoryUserPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.UsersRequestBuilder
+	options *users.UsersRequestBuilderGetRequestConfiguration
+}
+
+func (p *directoryUserPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewUsersRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *directoryUserPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Userable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *directoryUserPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewUserPager() *directoryUserPageCtrl {
+	options := &users.UsersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.UsersRequestBuilderGetQueryParameters{
+			Select: directoryUserProps,
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	return &directoryUserPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Users(),
+	}
+}
+
+// GetUsers fetches every user in the directory, including guests.
+func (c Directory) GetUsers(ctx context.Context) ([]models.Userable, error) {
+	return pagers.BatchEnumerateItems[models.Userable](ctx, c.NewUserPager())
+}
+
+// ---------------------------------------------------------------------------
+// group pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Groupable] = &directoryGroupPageCtrl{}
+
+type directoryGroupPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.GroupsRequestBuilder
+	options *groups.GroupsRequestBuilderGetRequestConfiguration
+}
+
+func (p *directoryGroupPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewGroupsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *directoryGroupPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Groupable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *directoryGroupPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewGroupPager() *directoryGroupPageCtrl {
+	options := &groups.GroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupsRequestBuilderGetQueryParameters{
+			Select: directoryGroupProps,
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	return &directoryGroupPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Groups(),
+	}
+}
+
+// GetGroups fetches every group in the directory.
+func (c Directory) GetGroups(ctx context.Context) ([]models.Groupable, error) {
+	return pagers.BatchEnumerateItems[models.Groupable](ctx, c.NewGroupPager())
+}
+
+// ---------------------------------------------------------------------------
+// group member pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.DirectoryObjectable] = &groupMemberPageCtrl{}
+
+type groupMemberPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.ItemMembersRequestBuilder
+	options *groups.ItemMembersRequestBuilderGetRequestConfiguration
+}
+
+func (p *groupMemberPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemMembersRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *groupMemberPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.DirectoryObjectable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *groupMemberPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewGroupMemberPager(groupID string) *groupMemberPageCtrl {
+	options := &groups.ItemMembersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemMembersRequestBuilderGetQueryParameters{
+			Select: idAnd(),
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	return &groupMemberPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Groups().
+			ByGroupId(groupID).
+			Members(),
+	}
+}
+
+// GetGroupMembers fetches the direct members of the group.  Members may
+// be users, groups, devices, or service principals.
+func (c Directory) GetGroupMembers(
+	ctx context.Context,
+	groupID string,
+) ([]models.DirectoryObjectable, error) {
+	return pagers.BatchEnumerateItems[models.DirectoryObjectable](ctx, c.NewGroupMemberPager(groupID))
+}
+
+// ---------------------------------------------------------------------------
+// application pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Applicationable] = &applicationPageCtrl{}
+
+type applicationPageCtrl struct {
+	gs      graph.Servicer
+	builder *applications.ApplicationsRequestBuilder
+	options *applications.ApplicationsRequestBuilderGetRequestConfiguration
+}
+
+func (p *applicationPageCtrl) SetNextLink(nextLink string) {
+	p.builder = applications.NewApplicationsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *applicationPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Applicationable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *applicationPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewApplicationPager() *applicationPageCtrl {
+	options := &applications.ApplicationsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &applications.ApplicationsRequestBuilderGetQueryParameters{
+			Select: directoryApplicationProps,
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	return &applicationPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Applications(),
+	}
+}
+
+// GetApplications fetches every application registered in the directory.
+func (c Directory) GetApplications(ctx context.Context) ([]models.Applicationable, error) {
+	return pagers.BatchEnumerateItems[models.Applicationable](ctx, c.NewApplicationPager())
+}
+
+// ---------------------------------------------------------------------------
+// service principal pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.ServicePrincipalable] = &servicePrincipalPageCtrl{}
+
+type servicePrincipalPageCtrl struct {
+	gs      graph.Servicer
+	builder *serviceprincipals.ServicePrincipalsRequestBuilder
+	options *serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration
+}
+
+func (p *servicePrincipalPageCtrl) SetNextLink(nextLink string) {
+	p.builder = serviceprincipals.NewServicePrincipalsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *servicePrincipalPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.ServicePrincipalable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *servicePrincipalPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewServicePrincipalPager() *servicePrincipalPageCtrl {
+	options := &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Select: directoryServicePrincipalProps,
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	return &servicePrincipalPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			ServicePrincipals(),
+	}
+}
+
+// GetServicePrincipals fetches every service principal in the directory.
+func (c Directory) GetServicePrincipals(ctx context.Context) ([]models.ServicePrincipalable, error) {
+	return pagers.BatchEnumerateItems[models.ServicePrincipalable](ctx, c.NewServicePrincipalPager())
+}
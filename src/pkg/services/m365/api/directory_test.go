@@ -0,0 +1,147 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type DirectoryAPIUnitSuite struct {
+	tester.Suite
+}
+
+func TestDirectoryAPIUnitSuite(t *testing.T) {
+	suite.Run(t, &DirectoryAPIUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DirectoryAPIUnitSuite) TestDirectoryObjectHash() {
+	t := suite.T()
+
+	a, err := DirectoryObjectHash([]byte(`{"id":"1","displayName":"name","tags":["a","b"]}`))
+	require.NoError(t, err, clues.ToCore(err))
+
+	b, err := DirectoryObjectHash([]byte(`{"tags":["a","b"], "displayName":"name", "id":"1"}`))
+	require.NoError(t, err, clues.ToCore(err))
+
+	c, err := DirectoryObjectHash([]byte(`{"id":"1","displayName":"other","tags":["a","b"]}`))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, a, b, "property order doesn't change the hash")
+	assert.NotEqual(t, a, c, "property values change the hash")
+
+	_, err = DirectoryObjectHash([]byte("not json"))
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *DirectoryAPIUnitSuite) TestDirectoryObjectInfo() {
+	var (
+		created = time.Now().Add(-time.Hour)
+		body    = []byte(`{"id":"1"}`)
+	)
+
+	user := models.NewUser()
+	user.SetDisplayName(ptr.To("Adele Vance"))
+	user.SetUserPrincipalName(ptr.To("adele@contoso.com"))
+	user.SetCreatedDateTime(ptr.To(created))
+
+	group := models.NewGroup()
+	group.SetDisplayName(ptr.To("Marketing"))
+	group.SetMailNickname(ptr.To("marketing"))
+	group.SetCreatedDateTime(ptr.To(created))
+
+	app := models.NewApplication()
+	app.SetDisplayName(ptr.To("app"))
+	app.SetAppId(ptr.To("app-id"))
+
+	sp := models.NewServicePrincipal()
+	sp.SetDisplayName(ptr.To("sp"))
+	sp.SetAppId(ptr.To("sp-app-id"))
+
+	table := []struct {
+		name          string
+		obj           models.DirectoryObjectable
+		members       []string
+		expectType    details.ItemType
+		expectName    string
+		expectPrinc   string
+		expectCreated time.Time
+		expectMembers []string
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "user",
+			obj:           user,
+			members:       []string{"ignored"},
+			expectType:    details.EntraIDUser,
+			expectName:    "Adele Vance",
+			expectPrinc:   "adele@contoso.com",
+			expectCreated: created,
+			expectErr:     assert.NoError,
+		},
+		{
+			name:          "group",
+			obj:           group,
+			members:       []string{"u1", "u2"},
+			expectType:    details.EntraIDGroup,
+			expectName:    "Marketing",
+			expectPrinc:   "marketing",
+			expectCreated: created,
+			expectMembers: []string{"u1", "u2"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:        "application",
+			obj:         app,
+			expectType:  details.EntraIDApplication,
+			expectName:  "app",
+			expectPrinc: "app-id",
+			expectErr:   assert.NoError,
+		},
+		{
+			name:        "service principal",
+			obj:         sp,
+			expectType:  details.EntraIDServicePrincipal,
+			expectName:  "sp",
+			expectPrinc: "sp-app-id",
+			expectErr:   assert.NoError,
+		},
+		{
+			name:      "unsupported",
+			obj:       models.NewDevice(),
+			expectErr: assert.Error,
+		},
+		{
+			name:      "nil",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			info, err := DirectoryObjectInfo(test.obj, body, test.members, 42)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expectType, info.ItemType)
+			assert.Equal(t, test.expectName, info.ItemName)
+			assert.Equal(t, test.expectPrinc, info.PrincipalName)
+			assert.Equal(t, test.expectCreated, info.Created)
+			assert.Equal(t, test.expectMembers, info.Members)
+			assert.Equal(t, int64(42), info.Size)
+			assert.NotEmpty(t, info.Hash)
+		})
+	}
+}
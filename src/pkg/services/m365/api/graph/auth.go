@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/account"
+)
+
+// DefaultScope requests all of the application permissions granted to the
+// client in the graph api.
+const DefaultScope = "https://graph.microsoft.com/.default"
+
+type credentialOptions struct {
+	azcore.ClientOptions
+	// disableInstanceDiscovery skips validation of the authority host,
+	// which is needed when that host isn't a known azure cloud.
+	disableInstanceDiscovery bool
+}
+
+// NewCredential produces the azure identity credential for the account.
+// A client certificate is preferred over a federated token, and both
+// are preferred over the client secret.
+func NewCredential(creds account.M365Config) (azcore.TokenCredential, error) {
+	return newCredential(creds, credentialOptions{})
+}
+
+func newCredential(
+	creds account.M365Config,
+	opts credentialOptions,
+) (azcore.TokenCredential, error) {
+	if len(creds.AzureTenantID) == 0 {
+		return nil, clues.New("missing tenant id")
+	}
+
+	switch {
+	case creds.HasCertificate():
+		data, err := creds.CertificateData()
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		var pass []byte
+		if len(creds.AzureClientCertPassword) > 0 {
+			pass = []byte(creds.AzureClientCertPassword)
+		}
+
+		certs, key, err := azidentity.ParseCertificates(data, pass)
+		if err != nil {
+			return nil, clues.Wrap(err, "parsing client certificate")
+		}
+
+		cred, err := azidentity.NewClientCertificateCredential(
+			creds.AzureTenantID,
+			creds.AzureClientID,
+			certs,
+			key,
+			&azidentity.ClientCertificateCredentialOptions{
+				ClientOptions:            opts.ClientOptions,
+				DisableInstanceDiscovery: opts.disableInstanceDiscovery,
+			})
+		if err != nil {
+			return nil, clues.Wrap(err, "creating m365 client certificate identity")
+		}
+
+		return cred, nil
+
+	case len(creds.AzureFederatedTokenFile) > 0:
+		tokenFile := creds.AzureFederatedTokenFile
+
+		cred, err := azidentity.NewClientAssertionCredential(
+			creds.AzureTenantID,
+			creds.AzureClientID,
+			func(ctx context.Context) (string, error) {
+				return readFederatedToken(ctx, tokenFile)
+			},
+			&azidentity.ClientAssertionCredentialOptions{
+				ClientOptions:            opts.ClientOptions,
+				DisableInstanceDiscovery: opts.disableInstanceDiscovery,
+			})
+		if err != nil {
+			return nil, clues.Wrap(err, "creating m365 federated identity")
+		}
+
+		return cred, nil
+	}
+
+	// Client Provider: Uses Secret for access to tenant-level data
+	cred, err := azidentity.NewClientSecretCredential(
+		creds.AzureTenantID,
+		creds.AzureClientID,
+		creds.AzureClientSecret,
+		&azidentity.ClientSecretCredentialOptions{
+			ClientOptions:            opts.ClientOptions,
+			DisableInstanceDiscovery: opts.disableInstanceDiscovery,
+		})
+	if err != nil {
+		return nil, clues.Wrap(err, "creating m365 client identity")
+	}
+
+	return cred, nil
+}
+
+// readFederatedToken reads the token on every call, since the issuer
+// rotates the file's contents before the token expires.
+func readFederatedToken(ctx context.Context, path string) (string, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return "", clues.WrapWC(ctx, err, "reading federated token file").
+			With("token_file", path)
+	}
+
+	token := strings.TrimSpace(string(bs))
+	if len(token) == 0 {
+		return "", clues.NewWC(ctx, "federated token file is empty").
+			With("token_file", path)
+	}
+
+	return token, nil
+}
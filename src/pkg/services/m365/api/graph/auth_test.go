@@ -0,0 +1,294 @@
+package graph
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+type AuthUnitSuite struct {
+	tester.Suite
+}
+
+func TestAuthUnitSuite(t *testing.T) {
+	suite.Run(t, &AuthUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// tokenEndpoint stubs the parts of the microsoft identity platform used
+// to acquire a client credentials token.
+type tokenEndpoint struct {
+	srv *httptest.Server
+
+	mu    sync.Mutex
+	forms []url.Values
+}
+
+func newTokenEndpoint(t *testing.T, tenant string) *tokenEndpoint {
+	te := &tokenEndpoint{}
+
+	te.srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			base = te.srv.URL + "/" + tenant
+			resp any
+		)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/.well-known/openid-configuration"):
+			resp = map[string]string{
+				"authorization_endpoint": base + "/oauth2/v2.0/authorize",
+				"token_endpoint":         base + "/oauth2/v2.0/token",
+				"issuer":                 base + "/v2.0",
+			}
+
+		case strings.HasSuffix(r.URL.Path, "/oauth2/v2.0/token"):
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			te.mu.Lock()
+			te.forms = append(te.forms, r.PostForm)
+			te.mu.Unlock()
+
+			resp = map[string]any{
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+				"access_token": "stub-token",
+			}
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err := json.NewEncoder(w).Encode(resp)
+		require.NoError(t, err, clues.ToCore(err))
+	}))
+
+	t.Cleanup(te.srv.Close)
+
+	return te
+}
+
+func (te *tokenEndpoint) options() credentialOptions {
+	return credentialOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud:     cloud.Configuration{ActiveDirectoryAuthorityHost: te.srv.URL},
+			Transport: te.srv.Client(),
+		},
+		disableInstanceDiscovery: true,
+	}
+}
+
+// selfSignedCert produces a PEM encoded certificate and private key.
+func selfSignedCert(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, clues.ToCore(err))
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "corso-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	bs = append(bs, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})...)
+
+	return bs
+}
+
+func (suite *AuthUnitSuite) TestNewCredential() {
+	var (
+		dir       = suite.T().TempDir()
+		cert      = selfSignedCert(suite.T())
+		certFile  = filepath.Join(dir, "cert.pem")
+		tokenFile = filepath.Join(dir, "token")
+	)
+
+	err := os.WriteFile(certFile, cert, 0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	err = os.WriteFile(tokenFile, []byte("federated-token\n"), 0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name         string
+		m365         credentials.M365
+		expectForm   map[string]string
+		expectSigned bool
+	}{
+		{
+			name: "secret",
+			m365: credentials.M365{AzureClientID: "cid", AzureClientSecret: "cs"},
+			expectForm: map[string]string{
+				"client_id":     "cid",
+				"client_secret": "cs",
+				"grant_type":    "client_credentials",
+			},
+		},
+		{
+			name: "certificate",
+			m365: credentials.M365{AzureClientID: "cid", AzureClientCert: string(cert)},
+			expectForm: map[string]string{
+				"client_id":             "cid",
+				"client_assertion_type": clientAssertionType,
+			},
+			expectSigned: true,
+		},
+		{
+			name: "certificate path",
+			m365: credentials.M365{
+				AzureClientID:       "cid",
+				AzureClientSecret:   "unused",
+				AzureClientCertPath: certFile,
+			},
+			expectForm: map[string]string{
+				"client_id":             "cid",
+				"client_assertion_type": clientAssertionType,
+			},
+			expectSigned: true,
+		},
+		{
+			name: "federated token",
+			m365: credentials.M365{AzureClientID: "cid", AzureFederatedTokenFile: tokenFile},
+			expectForm: map[string]string{
+				"client_id":             "cid",
+				"client_assertion_type": clientAssertionType,
+				"client_assertion":      "federated-token",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			te := newTokenEndpoint(t, "tid")
+			creds := account.M365Config{M365: test.m365, AzureTenantID: "tid"}
+
+			cred, err := newCredential(creds, te.options())
+			require.NoError(t, err, clues.ToCore(err))
+
+			tok, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{DefaultScope}})
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, "stub-token", tok.Token)
+
+			require.Len(t, te.forms, 1)
+
+			form := te.forms[0]
+
+			for k, v := range test.expectForm {
+				assert.Equal(t, v, form.Get(k), k)
+			}
+
+			assert.Equal(t, DefaultScope, form.Get("scope"))
+
+			if test.expectSigned {
+				// a signed jwt has a header, a payload, and a signature
+				assert.Len(t, strings.Split(form.Get("client_assertion"), "."), 3)
+				assert.Empty(t, form.Get("client_secret"), "certificate is preferred over the secret")
+			}
+		})
+	}
+}
+
+func (suite *AuthUnitSuite) TestNewCredential_Errors() {
+	dir := suite.T().TempDir()
+
+	table := []struct {
+		name  string
+		creds account.M365Config
+	}{
+		{
+			name: "missing tenant",
+			creds: account.M365Config{
+				M365: credentials.M365{AzureClientID: "cid", AzureClientSecret: "cs"},
+			},
+		},
+		{
+			name: "bad certificate",
+			creds: account.M365Config{
+				M365:          credentials.M365{AzureClientID: "cid", AzureClientCert: "-----BEGIN nonsense"},
+				AzureTenantID: "tid",
+			},
+		},
+		{
+			name: "missing certificate file",
+			creds: account.M365Config{
+				M365: credentials.M365{
+					AzureClientID:       "cid",
+					AzureClientCertPath: filepath.Join(dir, "missing.pem"),
+				},
+				AzureTenantID: "tid",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			_, err := NewCredential(test.creds)
+			assert.Error(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *AuthUnitSuite) TestNewCredential_MissingTokenFile() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	te := newTokenEndpoint(t, "tid")
+	creds := account.M365Config{
+		M365: credentials.M365{
+			AzureClientID:           "cid",
+			AzureFederatedTokenFile: filepath.Join(t.TempDir(), "missing"),
+		},
+		AzureTenantID: "tid",
+	}
+
+	// the token file isn't read until a token is requested.
+	cred, err := newCredential(creds, te.options())
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{DefaultScope}})
+	assert.Error(t, err, clues.ToCore(err))
+	assert.Empty(t, te.forms)
+}
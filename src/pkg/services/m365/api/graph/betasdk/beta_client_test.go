@@ -44,9 +44,7 @@ func (suite *BetaClientSuite) SetupSuite() {
 func (suite *BetaClientSuite) TestCreateBetaClient() {
 	t := suite.T()
 	adpt, err := graph.CreateAdapter(
-		suite.credentials.AzureTenantID,
-		suite.credentials.AzureClientID,
-		suite.credentials.AzureClientSecret,
+		suite.credentials,
 		count.New())
 
 	require.NoError(t, err, clues.ToCore(err))
@@ -66,9 +64,7 @@ func (suite *BetaClientSuite) TestBasicClientGetFunctionality() {
 	defer flush()
 
 	adpt, err := graph.CreateAdapter(
-		suite.credentials.AzureTenantID,
-		suite.credentials.AzureClientID,
-		suite.credentials.AzureClientSecret,
+		suite.credentials,
 		count.New())
 	require.NoError(t, err, clues.ToCore(err))
 
@@ -96,6 +96,7 @@ const (
 	ParameterDeltaTokenNotSupported errorMessage = "Parameter 'DeltaToken' not supported for this request"
 	usersCannotBeResolved           errorMessage = "One or more users could not be resolved"
 	requestedSiteCouldNotBeFound    errorMessage = "Requested site could not be found"
+	objectReferencesAlreadyExist    errorMessage = "One or more added object references already exist"
 )
 
 const (
@@ -291,6 +292,12 @@ func IsErrSharingDisabled(err error) bool {
 	return parseODataErr(err).hasErrorCode(err, sharingDisabled)
 }
 
+// IsErrObjectReferenceExists is true if a directory object was added to a
+// collection (ex: group members) that already contains it.
+func IsErrObjectReferenceExists(err error) bool {
+	return parseODataErr(err).hasErrorMessage(err, objectReferencesAlreadyExist)
+}
+
 // ---------------------------------------------------------------------------
 // quality of life wrappers
 // ---------------------------------------------------------------------------
@@ -720,6 +720,42 @@ func (suite *GraphErrorsUnitSuite) TestIsErrFolderExists() {
 	}
 }
 
+func (suite *GraphErrorsUnitSuite) TestIsErrObjectReferenceExists() {
+	table := []struct {
+		name   string
+		err    error
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "nil",
+			err:    nil,
+			expect: assert.False,
+		},
+		{
+			name:   "non-matching",
+			err:    assert.AnError,
+			expect: assert.False,
+		},
+		{
+			name:   "non-matching oDataErr",
+			err:    graphTD.ODataErrWithMsg("Request_BadRequest", "invalid reference"),
+			expect: assert.False,
+		},
+		{
+			name: "matching oDataErr msg",
+			err: graphTD.ODataErrWithMsg(
+				"Request_BadRequest",
+				string(objectReferencesAlreadyExist)+" for the following modified properties: 'members'."),
+			expect: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), IsErrObjectReferenceExists(test.err))
+		})
+	}
+}
+
 func (suite *GraphErrorsUnitSuite) TestIsErrUsersCannotBeResolved() {
 	table := []struct {
 		name   string
@@ -100,10 +100,7 @@ func mockAdapter(
 	mw khttp.Middleware,
 	cc *clientConfig,
 ) (*msgraphsdkgo.GraphRequestAdapter, error) {
-	auth, err := GetAuth(
-		creds.AzureTenantID,
-		creds.AzureClientID,
-		creds.AzureClientSecret)
+	auth, err := GetAuth(creds)
 	if err != nil {
 		return nil, err
 	}
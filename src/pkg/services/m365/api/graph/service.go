@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/alcionai/clues"
 	abstractions "github.com/microsoft/kiota-abstractions-go"
 	"github.com/microsoft/kiota-abstractions-go/serialization"
@@ -18,6 +17,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/crash"
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/errs/core"
@@ -104,11 +104,11 @@ func (s Service) Serialize(object serialization.Parsable) ([]byte, error) {
 // with Azure identity package. An adapter object is a necessary to component
 // to create a graph api client connection.
 func CreateAdapter(
-	tenant, client, secret string,
+	creds account.M365Config,
 	counter *count.Bus,
 	opts ...Option,
 ) (abstractions.RequestAdapter, error) {
-	auth, err := GetAuth(tenant, client, secret)
+	auth, err := GetAuth(creds)
 	if err != nil {
 		return nil, err
 	}
@@ -126,16 +126,15 @@ func CreateAdapter(
 	return wrapAdapter(adpt, cc), nil
 }
 
-func GetAuth(tenant string, client string, secret string) (*kauth.AzureIdentityAuthenticationProvider, error) {
-	// Client Provider: Uses Secret for access to tenant-level data
-	cred, err := azidentity.NewClientSecretCredential(tenant, client, secret, nil)
+func GetAuth(creds account.M365Config) (*kauth.AzureIdentityAuthenticationProvider, error) {
+	cred, err := NewCredential(creds)
 	if err != nil {
-		return nil, clues.Wrap(err, "creating m365 client identity")
+		return nil, err
 	}
 
 	auth, err := kauth.NewAzureIdentityAuthenticationProviderWithScopes(
 		cred,
-		[]string{"https://graph.microsoft.com/.default"})
+		[]string{DefaultScope})
 	if err != nil {
 		return nil, clues.Wrap(err, "creating azure authentication")
 	}
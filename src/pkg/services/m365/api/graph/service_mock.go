@@ -16,9 +16,7 @@ func NewGockService(
 	opts ...Option,
 ) (*Service, error) {
 	a, err := CreateGockAdapter(
-		creds.AzureTenantID,
-		creds.AzureClientID,
-		creds.AzureClientSecret,
+		creds,
 		counter,
 		opts...)
 	if err != nil {
@@ -31,11 +29,11 @@ func NewGockService(
 // CreateGockAdapter is similar to graph.CreateAdapter, but with option to
 // enable interceptions via gock to make it mockable.
 func CreateGockAdapter(
-	tenant, client, secret string,
+	creds account.M365Config,
 	counter *count.Bus,
 	opts ...Option,
 ) (abstractions.RequestAdapter, error) {
-	auth, err := GetAuth(tenant, client, secret)
+	auth, err := GetAuth(creds)
 	if err != nil {
 		return nil, err
 	}
@@ -65,9 +65,7 @@ func (suite *GraphIntgSuite) SetupSuite() {
 func (suite *GraphIntgSuite) TestCreateAdapter() {
 	t := suite.T()
 	adpt, err := CreateAdapter(
-		suite.fakeCredentials.AzureTenantID,
-		suite.fakeCredentials.AzureClientID,
-		suite.fakeCredentials.AzureClientSecret,
+		suite.fakeCredentials,
 		count.New())
 
 	assert.NoError(t, err, clues.ToCore(err))
@@ -166,9 +164,7 @@ func (suite *GraphIntgSuite) TestHTTPClient() {
 func (suite *GraphIntgSuite) TestSerializationEndPoint() {
 	t := suite.T()
 	adpt, err := CreateAdapter(
-		suite.fakeCredentials.AzureTenantID,
-		suite.fakeCredentials.AzureClientID,
-		suite.fakeCredentials.AzureClientSecret,
+		suite.fakeCredentials,
 		count.New())
 	require.NoError(t, err, clues.ToCore(err))
 
@@ -199,9 +195,7 @@ func (suite *GraphIntgSuite) TestAdapterWrap_catchesPanic() {
 	}
 
 	adpt, err := CreateAdapter(
-		suite.credentials.AzureTenantID,
-		suite.credentials.AzureClientID,
-		suite.credentials.AzureClientSecret,
+		suite.credentials,
 		count.New(),
 		appendMiddleware(&alwaysPanicMiddleware))
 	require.NoError(t, err, clues.ToCore(err))
@@ -239,9 +233,7 @@ func (suite *GraphIntgSuite) TestAdapterWrap_retriesConnectionClose() {
 	}
 
 	adpt, err := CreateAdapter(
-		suite.credentials.AzureTenantID,
-		suite.credentials.AzureClientID,
-		suite.credentials.AzureClientSecret,
+		suite.credentials,
 		count.New(),
 		appendMiddleware(&alwaysECONNRESET),
 		// Configure retry middlewares so that they don't retry on connection reset.
@@ -297,9 +289,7 @@ func (suite *GraphIntgSuite) TestAdapterWrap_retriesBadJWTToken() {
 	}
 
 	adpt, err := CreateAdapter(
-		suite.credentials.AzureTenantID,
-		suite.credentials.AzureClientID,
-		suite.credentials.AzureClientSecret,
+		suite.credentials,
 		count.New(),
 		appendMiddleware(&alwaysBadJWT))
 	require.NoError(t, err, clues.ToCore(err))
@@ -375,9 +365,7 @@ func (suite *GraphIntgSuite) TestAdapterWrap_retriesInvalidRequest() {
 	}
 
 	adpt, err := CreateAdapter(
-		suite.credentials.AzureTenantID,
-		suite.credentials.AzureClientID,
-		suite.credentials.AzureClientSecret,
+		suite.credentials,
 		count.New(),
 		appendMiddleware(&returnsGraphResp))
 	require.NoError(t, err, clues.ToCore(err))
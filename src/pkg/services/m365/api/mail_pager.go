@@ -73,6 +73,92 @@ func (c Mail) EnumerateContainers(
 	return containers, graph.Stack(ctx, err).OrNil()
 }
 
+var _ pagers.NonDeltaHandler[models.MailFolderable] = &mailChildFoldersPageCtrl{}
+
+type mailChildFoldersPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemMailFoldersItemChildFoldersRequestBuilder
+	options *users.ItemMailFoldersItemChildFoldersRequestBuilderGetRequestConfiguration
+}
+
+func (c Mail) NewMailChildFoldersPager(
+	userID, containerID string,
+	selectProps ...string,
+) pagers.NonDeltaHandler[models.MailFolderable] {
+	options := &users.ItemMailFoldersItemChildFoldersRequestBuilderGetRequestConfiguration{
+		Headers: newPreferHeaders(
+			preferPageSize(maxNonDeltaPageSize),
+			preferImmutableIDs(c.options.ToggleFeatures.ExchangeImmutableIDs)),
+		QueryParameters: &users.ItemMailFoldersItemChildFoldersRequestBuilderGetQueryParameters{},
+		// do NOT set Top.  It limits the total items received.
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	builder := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailFolders().
+		ByMailFolderId(containerID).
+		ChildFolders()
+
+	return &mailChildFoldersPageCtrl{c.Stable, builder, options}
+}
+
+func (p *mailChildFoldersPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.MailFolderable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *mailChildFoldersPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemMailFoldersItemChildFoldersRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *mailChildFoldersPageCtrl) ValidModTimes() bool {
+	return true
+}
+
+// EnumerateContainerTree retrieves every folder nested under the base
+// container.  Unlike EnumerateContainers, which only lists the folders
+// under the primary mailbox's msgfolderroot, this can walk any folder
+// tree, such as the online archive or the recoverable items folders.
+// The base container itself is not included in the results.
+func (c Mail) EnumerateContainerTree(
+	ctx context.Context,
+	userID, baseContainerID string,
+) ([]models.MailFolderable, error) {
+	var (
+		containers = []models.MailFolderable{}
+		parents    = []string{baseContainerID}
+	)
+
+	for len(parents) > 0 {
+		parentID := parents[0]
+		parents = parents[1:]
+
+		children, err := pagers.BatchEnumerateItems(ctx, c.NewMailChildFoldersPager(userID, parentID))
+		if err != nil {
+			return nil, graph.Wrap(ctx, err, "enumerating child folders").
+				With("parent_container_id", parentID)
+		}
+
+		for _, child := range children {
+			containers = append(containers, child)
+
+			if ptr.Val(child.GetChildFolderCount()) > 0 {
+				parents = append(parents, ptr.Val(child.GetId()))
+			}
+		}
+	}
+
+	return containers, nil
+}
+
 // ---------------------------------------------------------------------------
 // item pager
 // ---------------------------------------------------------------------------
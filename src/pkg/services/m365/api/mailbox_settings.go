@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) MailboxSettings() MailboxSettings {
+	return MailboxSettings{c}
+}
+
+// MailboxSettings is an interface-compliant provider of the client.
+type MailboxSettings struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// settings
+// ---------------------------------------------------------------------------
+
+// GetSettings fetches the mailbox settings of the user, including the
+// automatic replies configuration.
+func (c MailboxSettings) GetSettings(
+	ctx context.Context,
+	userID string,
+) (models.MailboxSettingsable, error) {
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailboxSettings().
+		Get(ctx, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "getting mailbox settings")
+	}
+
+	return resp, nil
+}
+
+// PatchSettings updates the mailbox settings of the user.  Only the
+// properties set in the body are changed.
+func (c MailboxSettings) PatchSettings(
+	ctx context.Context,
+	userID string,
+	body models.MailboxSettingsable,
+) error {
+	_, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailboxSettings().
+		Patch(ctx, body, nil)
+
+	return graph.Wrap(ctx, err, "updating mailbox settings").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// inbox rules
+// ---------------------------------------------------------------------------
+
+// PostInboxRule creates the rule in the user's inbox.
+func (c MailboxSettings) PostInboxRule(
+	ctx context.Context,
+	userID string,
+	body models.MessageRuleable,
+) (models.MessageRuleable, error) {
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailFolders().
+		ByMailFolderId(MailInbox).
+		MessageRules().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating inbox rule")
+	}
+
+	return resp, nil
+}
+
+// DeleteInboxRule deletes the rule from the user's inbox.
+func (c MailboxSettings) DeleteInboxRule(
+	ctx context.Context,
+	userID, ruleID string,
+) error {
+	err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailFolders().
+		ByMailFolderId(MailInbox).
+		MessageRules().
+		ByMessageRuleId(ruleID).
+		Delete(ctx, nil)
+
+	return graph.Wrap(ctx, err, "deleting inbox rule").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// categories
+// ---------------------------------------------------------------------------
+
+// PostCategory adds the category to the user's master category list.
+func (c MailboxSettings) PostCategory(
+	ctx context.Context,
+	userID string,
+	body models.OutlookCategoryable,
+) (models.OutlookCategoryable, error) {
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Outlook().
+		MasterCategories().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating category")
+	}
+
+	return resp, nil
+}
+
+// PatchCategoryColor changes the color of the category.  The display
+// name of a category can't be changed once created.
+func (c MailboxSettings) PatchCategoryColor(
+	ctx context.Context,
+	userID, categoryID string,
+	color models.CategoryColor,
+) error {
+	body := models.NewOutlookCategory()
+	body.SetColor(ptr.To(color))
+
+	_, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Outlook().
+		MasterCategories().
+		ByOutlookCategoryId(categoryID).
+		Patch(ctx, body, nil)
+
+	return graph.Wrap(ctx, err, "updating category").OrNil()
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// inbox rule pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.MessageRuleable] = &inboxRulePageCtrl{}
+
+type inboxRulePageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemMailFoldersItemMessageRulesRequestBuilder
+	options *users.ItemMailFoldersItemMessageRulesRequestBuilderGetRequestConfiguration
+}
+
+func (p *inboxRulePageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemMailFoldersItemMessageRulesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *inboxRulePageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.MessageRuleable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *inboxRulePageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c MailboxSettings) NewInboxRulePager(
+	userID string,
+) *inboxRulePageCtrl {
+	options := &users.ItemMailFoldersItemMessageRulesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemMailFoldersItemMessageRulesRequestBuilderGetQueryParameters{},
+	}
+
+	return &inboxRulePageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Users().
+			ByUserId(userID).
+			MailFolders().
+			ByMailFolderId(MailInbox).
+			MessageRules(),
+	}
+}
+
+// GetInboxRules fetches all rules in the user's inbox.
+func (c MailboxSettings) GetInboxRules(
+	ctx context.Context,
+	userID string,
+) ([]models.MessageRuleable, error) {
+	return pagers.BatchEnumerateItems[models.MessageRuleable](ctx, c.NewInboxRulePager(userID))
+}
+
+// ---------------------------------------------------------------------------
+// category pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.OutlookCategoryable] = &categoryPageCtrl{}
+
+type categoryPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemOutlookMasterCategoriesRequestBuilder
+	options *users.ItemOutlookMasterCategoriesRequestBuilderGetRequestConfiguration
+}
+
+func (p *categoryPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemOutlookMasterCategoriesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *categoryPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.OutlookCategoryable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *categoryPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c MailboxSettings) NewCategoryPager(
+	userID string,
+) *categoryPageCtrl {
+	options := &users.ItemOutlookMasterCategoriesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemOutlookMasterCategoriesRequestBuilderGetQueryParameters{},
+	}
+
+	return &categoryPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Users().
+			ByUserId(userID).
+			Outlook().
+			MasterCategories(),
+	}
+}
+
+// GetCategories fetches the user's master category list.
+func (c MailboxSettings) GetCategories(
+	ctx context.Context,
+	userID string,
+) ([]models.OutlookCategoryable, error) {
+	return pagers.BatchEnumerateItems[models.OutlookCategoryable](ctx, c.NewCategoryPager(userID))
+}
@@ -0,0 +1,488 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/alcionai/clues"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+const (
+	userSectionGroupsURLTemplate = "https://graph.microsoft.com/v1.0/users/%s/onenote/sectionGroups/%s/sectionGroups"
+	siteSectionGroupsURLTemplate = "https://graph.microsoft.com/v1.0/sites/%s/onenote/sectionGroups/%s/sectionGroups"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) OneNote() OneNote {
+	return OneNote{c}
+}
+
+// OneNote is an interface-compliant provider of the client.
+// Notebooks can be owned by either a user or a site.  The graph sdk
+// builds distinct request types for each, so most calls come in a
+// User and a Site flavor.
+type OneNote struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// user notebooks
+// ---------------------------------------------------------------------------
+
+// GetUserPageContent fetches the html content of the page.
+func (c OneNote) GetUserPageContent(
+	ctx context.Context,
+	userID, pageID string,
+) ([]byte, error) {
+	bs, err := c.LargeItem.
+		Client().
+		Users().
+		ByUserId(userID).
+		Onenote().
+		Pages().
+		ByOnenotePageId(pageID).
+		Content().
+		Get(ctx, nil)
+
+	return bs, graph.Wrap(ctx, err, "getting page content").OrNil()
+}
+
+// GetUserResourceContent fetches the bytes of an image or file
+// embedded in one of the user's pages.
+func (c OneNote) GetUserResourceContent(
+	ctx context.Context,
+	userID, resourceID string,
+) ([]byte, error) {
+	bs, err := c.LargeItem.
+		Client().
+		Users().
+		ByUserId(userID).
+		Onenote().
+		Resources().
+		ByOnenoteResourceId(resourceID).
+		Content().
+		Get(ctx, nil)
+
+	return bs, graph.Wrap(ctx, err, "getting page resource content").OrNil()
+}
+
+// PostUserNotebook creates a notebook with the provided name.
+func (c OneNote) PostUserNotebook(
+	ctx context.Context,
+	userID, name string,
+) (models.Notebookable, error) {
+	body := models.NewNotebook()
+	body.SetDisplayName(ptr.To(name))
+
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Onenote().
+		Notebooks().
+		Post(ctx, body, nil)
+
+	return resp, graph.Wrap(ctx, err, "creating notebook").OrNil()
+}
+
+// PostUserSectionGroup creates a section group.  The group is created
+// in the parent section group, if one is provided, and at the root of
+// the notebook otherwise.
+func (c OneNote) PostUserSectionGroup(
+	ctx context.Context,
+	userID, notebookID, parentGroupID, name string,
+) (models.SectionGroupable, error) {
+	var (
+		err  error
+		resp models.SectionGroupable
+		body = models.NewSectionGroup()
+		on   = c.Stable.Client().Users().ByUserId(userID).Onenote()
+	)
+
+	body.SetDisplayName(ptr.To(name))
+
+	if len(parentGroupID) > 0 {
+		// the sdk can't post to the section groups of a section group, but
+		// the notebook's builder posts the same body to whichever url it's given.
+		resp, err = users.
+			NewItemOnenoteNotebooksItemSectionGroupsRequestBuilder(
+				fmt.Sprintf(userSectionGroupsURLTemplate, userID, parentGroupID),
+				c.Stable.Adapter()).
+			Post(ctx, body, nil)
+	} else {
+		resp, err = on.Notebooks().ByNotebookId(notebookID).SectionGroups().Post(ctx, body, nil)
+	}
+
+	return resp, graph.Wrap(ctx, err, "creating section group").OrNil()
+}
+
+// PostUserSection creates a section.  The section is created in the
+// parent section group, if one is provided, and at the root of the
+// notebook otherwise.
+func (c OneNote) PostUserSection(
+	ctx context.Context,
+	userID, notebookID, parentGroupID, name string,
+) (models.OnenoteSectionable, error) {
+	var (
+		err  error
+		resp models.OnenoteSectionable
+		body = models.NewOnenoteSection()
+		on   = c.Stable.Client().Users().ByUserId(userID).Onenote()
+	)
+
+	body.SetDisplayName(ptr.To(name))
+
+	if len(parentGroupID) > 0 {
+		resp, err = on.SectionGroups().BySectionGroupId(parentGroupID).Sections().Post(ctx, body, nil)
+	} else {
+		resp, err = on.Notebooks().ByNotebookId(notebookID).Sections().Post(ctx, body, nil)
+	}
+
+	return resp, graph.Wrap(ctx, err, "creating section").OrNil()
+}
+
+// PostUserPage creates a page in the section.  See PagePostBody for
+// the expected body.
+func (c OneNote) PostUserPage(
+	ctx context.Context,
+	userID, sectionID string,
+	body []byte,
+	contentType string,
+) (models.OnenotePageable, error) {
+	return c.postPage(
+		ctx,
+		"{+baseurl}/users/{user%2Did}/onenote/sections/{onenoteSection%2Did}/pages",
+		map[string]string{
+			"user%2Did":           userID,
+			"onenoteSection%2Did": sectionID,
+		},
+		body,
+		contentType)
+}
+
+// DeleteUserPage deletes the page.
+func (c OneNote) DeleteUserPage(
+	ctx context.Context,
+	userID, pageID string,
+) error {
+	err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Onenote().
+		Pages().
+		ByOnenotePageId(pageID).
+		Delete(ctx, nil)
+
+	return graph.Wrap(ctx, err, "deleting page").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// site notebooks
+// ---------------------------------------------------------------------------
+
+// GetSitePageContent fetches the html content of the page.
+func (c OneNote) GetSitePageContent(
+	ctx context.Context,
+	siteID, pageID string,
+) ([]byte, error) {
+	bs, err := c.LargeItem.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Onenote().
+		Pages().
+		ByOnenotePageId(pageID).
+		Content().
+		Get(ctx, nil)
+
+	return bs, graph.Wrap(ctx, err, "getting page content").OrNil()
+}
+
+// GetSiteResourceContent fetches the bytes of an image or file
+// embedded in one of the site's pages.
+func (c OneNote) GetSiteResourceContent(
+	ctx context.Context,
+	siteID, resourceID string,
+) ([]byte, error) {
+	bs, err := c.LargeItem.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Onenote().
+		Resources().
+		ByOnenoteResourceId(resourceID).
+		Content().
+		Get(ctx, nil)
+
+	return bs, graph.Wrap(ctx, err, "getting page resource content").OrNil()
+}
+
+// PostSiteNotebook creates a notebook with the provided name.
+func (c OneNote) PostSiteNotebook(
+	ctx context.Context,
+	siteID, name string,
+) (models.Notebookable, error) {
+	body := models.NewNotebook()
+	body.SetDisplayName(ptr.To(name))
+
+	resp, err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Onenote().
+		Notebooks().
+		Post(ctx, body, nil)
+
+	return resp, graph.Wrap(ctx, err, "creating notebook").OrNil()
+}
+
+// PostSiteSectionGroup creates a section group.  The group is created
+// in the parent section group, if one is provided, and at the root of
+// the notebook otherwise.
+func (c OneNote) PostSiteSectionGroup(
+	ctx context.Context,
+	siteID, notebookID, parentGroupID, name string,
+) (models.SectionGroupable, error) {
+	var (
+		err  error
+		resp models.SectionGroupable
+		body = models.NewSectionGroup()
+		on   = c.Stable.Client().Sites().BySiteId(siteID).Onenote()
+	)
+
+	body.SetDisplayName(ptr.To(name))
+
+	if len(parentGroupID) > 0 {
+		// the sdk can't post to the section groups of a section group, but
+		// the notebook's builder posts the same body to whichever url it's given.
+		resp, err = sites.
+			NewItemOnenoteNotebooksItemSectionGroupsRequestBuilder(
+				fmt.Sprintf(siteSectionGroupsURLTemplate, siteID, parentGroupID),
+				c.Stable.Adapter()).
+			Post(ctx, body, nil)
+	} else {
+		resp, err = on.Notebooks().ByNotebookId(notebookID).SectionGroups().Post(ctx, body, nil)
+	}
+
+	return resp, graph.Wrap(ctx, err, "creating section group").OrNil()
+}
+
+// PostSiteSection creates a section.  The section is created in the
+// parent section group, if one is provided, and at the root of the
+// notebook otherwise.
+func (c OneNote) PostSiteSection(
+	ctx context.Context,
+	siteID, notebookID, parentGroupID, name string,
+) (models.OnenoteSectionable, error) {
+	var (
+		err  error
+		resp models.OnenoteSectionable
+		body = models.NewOnenoteSection()
+		on   = c.Stable.Client().Sites().BySiteId(siteID).Onenote()
+	)
+
+	body.SetDisplayName(ptr.To(name))
+
+	if len(parentGroupID) > 0 {
+		resp, err = on.SectionGroups().BySectionGroupId(parentGroupID).Sections().Post(ctx, body, nil)
+	} else {
+		resp, err = on.Notebooks().ByNotebookId(notebookID).Sections().Post(ctx, body, nil)
+	}
+
+	return resp, graph.Wrap(ctx, err, "creating section").OrNil()
+}
+
+// PostSitePage creates a page in the section.  See PagePostBody for
+// the expected body.
+func (c OneNote) PostSitePage(
+	ctx context.Context,
+	siteID, sectionID string,
+	body []byte,
+	contentType string,
+) (models.OnenotePageable, error) {
+	return c.postPage(
+		ctx,
+		"{+baseurl}/sites/{site%2Did}/onenote/sections/{onenoteSection%2Did}/pages",
+		map[string]string{
+			"site%2Did":           siteID,
+			"onenoteSection%2Did": sectionID,
+		},
+		body,
+		contentType)
+}
+
+// DeleteSitePage deletes the page.
+func (c OneNote) DeleteSitePage(
+	ctx context.Context,
+	siteID, pageID string,
+) error {
+	err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Onenote().
+		Pages().
+		ByOnenotePageId(pageID).
+		Delete(ctx, nil)
+
+	return graph.Wrap(ctx, err, "deleting page").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// pages
+// ---------------------------------------------------------------------------
+
+// postPage sends a multipart page creation request.  The sdk only
+// posts pages as json, which graph rejects; pages need to be created
+// from a multipart body that holds the html and every embedded resource.
+func (c OneNote) postPage(
+	ctx context.Context,
+	urlTemplate string,
+	pathParams map[string]string,
+	body []byte,
+	contentType string,
+) (models.OnenotePageable, error) {
+	ri := abstractions.NewRequestInformation()
+	ri.Method = abstractions.POST
+	ri.UrlTemplate = urlTemplate
+	ri.PathParameters = pathParams
+	ri.Content = body
+	ri.Headers.Add("Accept", "application/json")
+	ri.Headers.Add("Content-Type", contentType)
+
+	errorMapping := abstractions.ErrorMappings{
+		"4XX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+		"5XX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+	}
+
+	resp, err := c.Stable.
+		Adapter().
+		Send(ctx, ri, models.CreateOnenotePageFromDiscriminatorValue, errorMapping)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating page")
+	}
+
+	page, ok := resp.(models.OnenotePageable)
+	if !ok {
+		return nil, clues.NewWC(ctx, "unexpected page creation response")
+	}
+
+	return page, nil
+}
+
+// PageResource is an image or file to upload alongside a page's html.
+// The html references the resource by its name: `name:<Name>`.
+type PageResource struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// PagePostBody produces the multipart body and content type used to
+// create a page from its html and embedded resources.
+func PagePostBody(
+	html string,
+	resources []PageResource,
+) ([]byte, string, error) {
+	var (
+		buf = &bytes.Buffer{}
+		mw  = multipart.NewWriter(buf)
+	)
+
+	pw, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="Presentation"`},
+		"Content-Type":        {"text/html"},
+	})
+	if err != nil {
+		return nil, "", clues.Wrap(err, "creating page presentation part")
+	}
+
+	if _, err := pw.Write([]byte(html)); err != nil {
+		return nil, "", clues.Wrap(err, "writing page presentation part")
+	}
+
+	for _, r := range resources {
+		ct := r.ContentType
+		if len(ct) == 0 {
+			ct = "application/octet-stream"
+		}
+
+		rw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {`form-data; name="` + r.Name + `"`},
+			"Content-Type":        {ct},
+		})
+		if err != nil {
+			return nil, "", clues.Wrap(err, "creating page resource part")
+		}
+
+		if _, err := rw.Write(r.Content); err != nil {
+			return nil, "", clues.Wrap(err, "writing page resource part")
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, "", clues.Wrap(err, "closing page body")
+	}
+
+	return buf.Bytes(), mw.FormDataContentType(), nil
+}
+
+// resourceURLRE matches the urls graph uses to reference the resources
+// of a page, ex: https://graph.microsoft.com/v1.0/users('id')/onenote/resources/{id}/$value
+var resourceURLRE = regexp.MustCompile(`https://[^"'\s]+/onenote/resources/([^/"'\s]+)/(?:\$value|content)`)
+
+// PageResourceURLs produces a map of resource url -> resource id for
+// every resource referenced by the page's html.
+func PageResourceURLs(html string) map[string]string {
+	var (
+		matches = resourceURLRE.FindAllStringSubmatch(html, -1)
+		result  = make(map[string]string, len(matches))
+	)
+
+	for _, m := range matches {
+		// html attributes escape ampersands, but resource urls shouldn't
+		// contain any query params.  Trim them just in case.
+		u := strings.SplitN(m[0], "?", 2)[0]
+		result[u] = m[1]
+	}
+
+	return result
+}
+
+// OneNotePageInfo produces the details info of the page.  Notebook and
+// section names are owned by the page's container, and are populated by
+// the caller.
+func OneNotePageInfo(page models.OnenotePageable, size int64) *details.OneNoteInfo {
+	var webURL string
+
+	if links := page.GetLinks(); links != nil && links.GetOneNoteWebUrl() != nil {
+		webURL = ptr.Val(links.GetOneNoteWebUrl().GetHref())
+	}
+
+	return &details.OneNoteInfo{
+		ItemType: details.OneNotePage,
+		ItemName: ptr.Val(page.GetTitle()),
+		Created:  ptr.Val(page.GetCreatedDateTime()),
+		Modified: ptr.Val(page.GetLastModifiedDateTime()),
+		Size:     size,
+		WebURL:   webURL,
+	}
+}
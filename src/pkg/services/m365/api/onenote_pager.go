@@ -0,0 +1,491 @@
+package api
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// graph caps onenote list calls at 100 results per page.
+const maxOneNotePageSize int32 = 100
+
+// section and section group lists expand their parents, so that the
+// notebook hierarchy can be rebuilt without a call per container.
+var oneNoteParentExpansions = []string{
+	"parentNotebook($select=id,displayName)",
+	"parentSectionGroup($select=id,displayName)",
+}
+
+var oneNotePageSelects = []string{
+	"id",
+	"title",
+	"createdDateTime",
+	"lastModifiedDateTime",
+	"level",
+	"order",
+	"links",
+}
+
+// ---------------------------------------------------------------------------
+// user notebook pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Notebookable] = &userNotebookPageCtrl{}
+
+type userNotebookPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemOnenoteNotebooksRequestBuilder
+	options *users.ItemOnenoteNotebooksRequestBuilderGetRequestConfiguration
+}
+
+func (p *userNotebookPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemOnenoteNotebooksRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *userNotebookPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Notebookable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *userNotebookPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c OneNote) NewUserNotebookPager(
+	userID string,
+) *userNotebookPageCtrl {
+	options := &users.ItemOnenoteNotebooksRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemOnenoteNotebooksRequestBuilderGetQueryParameters{
+			Top: ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &userNotebookPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Users().
+			ByUserId(userID).
+			Onenote().
+			Notebooks(),
+	}
+}
+
+// GetUserNotebooks fetches all notebooks owned by the user.
+func (c OneNote) GetUserNotebooks(
+	ctx context.Context,
+	userID string,
+) ([]models.Notebookable, error) {
+	return pagers.BatchEnumerateItems[models.Notebookable](ctx, c.NewUserNotebookPager(userID))
+}
+
+// ---------------------------------------------------------------------------
+// user section group pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.SectionGroupable] = &userSectionGroupPageCtrl{}
+
+type userSectionGroupPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemOnenoteSectionGroupsRequestBuilder
+	options *users.ItemOnenoteSectionGroupsRequestBuilderGetRequestConfiguration
+}
+
+func (p *userSectionGroupPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemOnenoteSectionGroupsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *userSectionGroupPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.SectionGroupable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *userSectionGroupPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c OneNote) NewUserSectionGroupPager(
+	userID string,
+) *userSectionGroupPageCtrl {
+	options := &users.ItemOnenoteSectionGroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemOnenoteSectionGroupsRequestBuilderGetQueryParameters{
+			Expand: oneNoteParentExpansions,
+			Top:    ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &userSectionGroupPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Users().
+			ByUserId(userID).
+			Onenote().
+			SectionGroups(),
+	}
+}
+
+// GetUserSectionGroups fetches all section groups, in every notebook owned by the user.
+func (c OneNote) GetUserSectionGroups(
+	ctx context.Context,
+	userID string,
+) ([]models.SectionGroupable, error) {
+	return pagers.BatchEnumerateItems[models.SectionGroupable](ctx, c.NewUserSectionGroupPager(userID))
+}
+
+// ---------------------------------------------------------------------------
+// user section pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.OnenoteSectionable] = &userSectionPageCtrl{}
+
+type userSectionPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemOnenoteSectionsRequestBuilder
+	options *users.ItemOnenoteSectionsRequestBuilderGetRequestConfiguration
+}
+
+func (p *userSectionPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemOnenoteSectionsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *userSectionPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.OnenoteSectionable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *userSectionPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c OneNote) NewUserSectionPager(
+	userID string,
+) *userSectionPageCtrl {
+	options := &users.ItemOnenoteSectionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemOnenoteSectionsRequestBuilderGetQueryParameters{
+			Expand: oneNoteParentExpansions,
+			Top:    ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &userSectionPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Users().
+			ByUserId(userID).
+			Onenote().
+			Sections(),
+	}
+}
+
+// GetUserSections fetches all sections, in every notebook owned by the user.
+func (c OneNote) GetUserSections(
+	ctx context.Context,
+	userID string,
+) ([]models.OnenoteSectionable, error) {
+	return pagers.BatchEnumerateItems[models.OnenoteSectionable](ctx, c.NewUserSectionPager(userID))
+}
+
+// ---------------------------------------------------------------------------
+// user page pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.OnenotePageable] = &userPagePageCtrl{}
+
+type userPagePageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemOnenoteSectionsItemPagesRequestBuilder
+	options *users.ItemOnenoteSectionsItemPagesRequestBuilderGetRequestConfiguration
+}
+
+func (p *userPagePageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemOnenoteSectionsItemPagesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *userPagePageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.OnenotePageable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *userPagePageCtrl) ValidModTimes() bool {
+	return true
+}
+
+func (c OneNote) NewUserPagePager(
+	userID, sectionID string,
+) *userPagePageCtrl {
+	options := &users.ItemOnenoteSectionsItemPagesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemOnenoteSectionsItemPagesRequestBuilderGetQueryParameters{
+			Select: oneNotePageSelects,
+			Top:    ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &userPagePageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Users().
+			ByUserId(userID).
+			Onenote().
+			Sections().
+			ByOnenoteSectionId(sectionID).
+			Pages(),
+	}
+}
+
+// GetUserPages fetches all pages in the user's section.
+func (c OneNote) GetUserPages(
+	ctx context.Context,
+	userID, sectionID string,
+) ([]models.OnenotePageable, error) {
+	return pagers.BatchEnumerateItems[models.OnenotePageable](ctx, c.NewUserPagePager(userID, sectionID))
+}
+
+// ---------------------------------------------------------------------------
+// site notebook pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Notebookable] = &siteNotebookPageCtrl{}
+
+type siteNotebookPageCtrl struct {
+	gs      graph.Servicer
+	builder *sites.ItemOnenoteNotebooksRequestBuilder
+	options *sites.ItemOnenoteNotebooksRequestBuilderGetRequestConfiguration
+}
+
+func (p *siteNotebookPageCtrl) SetNextLink(nextLink string) {
+	p.builder = sites.NewItemOnenoteNotebooksRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *siteNotebookPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Notebookable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *siteNotebookPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c OneNote) NewSiteNotebookPager(
+	siteID string,
+) *siteNotebookPageCtrl {
+	options := &sites.ItemOnenoteNotebooksRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.ItemOnenoteNotebooksRequestBuilderGetQueryParameters{
+			Top: ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &siteNotebookPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Sites().
+			BySiteId(siteID).
+			Onenote().
+			Notebooks(),
+	}
+}
+
+// GetSiteNotebooks fetches all notebooks owned by the site.
+func (c OneNote) GetSiteNotebooks(
+	ctx context.Context,
+	siteID string,
+) ([]models.Notebookable, error) {
+	return pagers.BatchEnumerateItems[models.Notebookable](ctx, c.NewSiteNotebookPager(siteID))
+}
+
+// ---------------------------------------------------------------------------
+// site section group pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.SectionGroupable] = &siteSectionGroupPageCtrl{}
+
+type siteSectionGroupPageCtrl struct {
+	gs      graph.Servicer
+	builder *sites.ItemOnenoteSectionGroupsRequestBuilder
+	options *sites.ItemOnenoteSectionGroupsRequestBuilderGetRequestConfiguration
+}
+
+func (p *siteSectionGroupPageCtrl) SetNextLink(nextLink string) {
+	p.builder = sites.NewItemOnenoteSectionGroupsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *siteSectionGroupPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.SectionGroupable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *siteSectionGroupPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c OneNote) NewSiteSectionGroupPager(
+	siteID string,
+) *siteSectionGroupPageCtrl {
+	options := &sites.ItemOnenoteSectionGroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.ItemOnenoteSectionGroupsRequestBuilderGetQueryParameters{
+			Expand: oneNoteParentExpansions,
+			Top:    ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &siteSectionGroupPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Sites().
+			BySiteId(siteID).
+			Onenote().
+			SectionGroups(),
+	}
+}
+
+// GetSiteSectionGroups fetches all section groups, in every notebook owned by the site.
+func (c OneNote) GetSiteSectionGroups(
+	ctx context.Context,
+	siteID string,
+) ([]models.SectionGroupable, error) {
+	return pagers.BatchEnumerateItems[models.SectionGroupable](ctx, c.NewSiteSectionGroupPager(siteID))
+}
+
+// ---------------------------------------------------------------------------
+// site section pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.OnenoteSectionable] = &siteSectionPageCtrl{}
+
+type siteSectionPageCtrl struct {
+	gs      graph.Servicer
+	builder *sites.ItemOnenoteSectionsRequestBuilder
+	options *sites.ItemOnenoteSectionsRequestBuilderGetRequestConfiguration
+}
+
+func (p *siteSectionPageCtrl) SetNextLink(nextLink string) {
+	p.builder = sites.NewItemOnenoteSectionsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *siteSectionPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.OnenoteSectionable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *siteSectionPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c OneNote) NewSiteSectionPager(
+	siteID string,
+) *siteSectionPageCtrl {
+	options := &sites.ItemOnenoteSectionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.ItemOnenoteSectionsRequestBuilderGetQueryParameters{
+			Expand: oneNoteParentExpansions,
+			Top:    ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &siteSectionPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Sites().
+			BySiteId(siteID).
+			Onenote().
+			Sections(),
+	}
+}
+
+// GetSiteSections fetches all sections, in every notebook owned by the site.
+func (c OneNote) GetSiteSections(
+	ctx context.Context,
+	siteID string,
+) ([]models.OnenoteSectionable, error) {
+	return pagers.BatchEnumerateItems[models.OnenoteSectionable](ctx, c.NewSiteSectionPager(siteID))
+}
+
+// ---------------------------------------------------------------------------
+// site page pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.OnenotePageable] = &sitePagePageCtrl{}
+
+type sitePagePageCtrl struct {
+	gs      graph.Servicer
+	builder *sites.ItemOnenoteSectionsItemPagesRequestBuilder
+	options *sites.ItemOnenoteSectionsItemPagesRequestBuilderGetRequestConfiguration
+}
+
+func (p *sitePagePageCtrl) SetNextLink(nextLink string) {
+	p.builder = sites.NewItemOnenoteSectionsItemPagesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *sitePagePageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.OnenotePageable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *sitePagePageCtrl) ValidModTimes() bool {
+	return true
+}
+
+func (c OneNote) NewSitePagePager(
+	siteID, sectionID string,
+) *sitePagePageCtrl {
+	options := &sites.ItemOnenoteSectionsItemPagesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.ItemOnenoteSectionsItemPagesRequestBuilderGetQueryParameters{
+			Select: oneNotePageSelects,
+			Top:    ptr.To(maxOneNotePageSize),
+		},
+	}
+
+	return &sitePagePageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Sites().
+			BySiteId(siteID).
+			Onenote().
+			Sections().
+			ByOnenoteSectionId(sectionID).
+			Pages(),
+	}
+}
+
+// GetSitePages fetches all pages in the site's section.
+func (c OneNote) GetSitePages(
+	ctx context.Context,
+	siteID, sectionID string,
+) ([]models.OnenotePageable, error) {
+	return pagers.BatchEnumerateItems[models.OnenotePageable](ctx, c.NewSitePagePager(siteID, sectionID))
+}
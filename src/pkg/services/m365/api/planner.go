@@ -0,0 +1,303 @@
+package api
+
+import (
+	"context"
+	"sort"
+
+	"github.com/alcionai/clues"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/planner"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// plannerETagKey is the additional data key holding the etag of a
+// planner resource.  Graph requires the etag on every update and delete.
+const plannerETagKey = "@odata.etag"
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) Planner() Planner {
+	return Planner{c}
+}
+
+// Planner is an interface-compliant provider of the client.
+type Planner struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// Item (task details)
+// ---------------------------------------------------------------------------
+
+// GetTaskDetails fetches the description, checklist and references
+// of the task.
+func (c Planner) GetTaskDetails(
+	ctx context.Context,
+	taskID string,
+) (models.PlannerTaskDetailsable, error) {
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		ByPlannerTaskId(taskID).
+		Details().
+		Get(ctx, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "getting task details")
+	}
+
+	return resp, nil
+}
+
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+// PostPlan creates a plan with the provided title in the group.
+func (c Planner) PostPlan(
+	ctx context.Context,
+	groupID, title string,
+) (models.PlannerPlanable, error) {
+	container := models.NewPlannerPlanContainer()
+	container.SetContainerId(ptr.To(groupID))
+	container.SetTypeEscaped(ptr.To(models.GROUP_PLANNERCONTAINERTYPE))
+
+	body := models.NewPlannerPlan()
+	body.SetTitle(ptr.To(title))
+	body.SetContainer(container)
+
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating plan")
+	}
+
+	return resp, nil
+}
+
+// PostBucket creates a bucket with the provided name in the plan.
+func (c Planner) PostBucket(
+	ctx context.Context,
+	planID, name, orderHint string,
+) (models.PlannerBucketable, error) {
+	body := models.NewPlannerBucket()
+	body.SetPlanId(ptr.To(planID))
+	body.SetName(ptr.To(name))
+
+	if len(orderHint) > 0 {
+		body.SetOrderHint(ptr.To(orderHint))
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Buckets().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating bucket")
+	}
+
+	return resp, nil
+}
+
+// PostTask creates the task.  The task must reference the plan, and
+// optionally the bucket, that will hold it.
+func (c Planner) PostTask(
+	ctx context.Context,
+	task models.PlannerTaskable,
+) (models.PlannerTaskable, error) {
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		Post(ctx, task, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating task")
+	}
+
+	return resp, nil
+}
+
+// PatchTaskDetails updates the details of the task.  Task details are
+// created alongside the task, so they can only be updated; the etag
+// must come from the current version of the details.
+func (c Planner) PatchTaskDetails(
+	ctx context.Context,
+	taskID, etag string,
+	body models.PlannerTaskDetailsable,
+) error {
+	config := &planner.TasksItemDetailsRequestBuilderPatchRequestConfiguration{
+		Headers: abstractions.NewRequestHeaders(),
+	}
+	config.Headers.Add("If-Match", etag)
+
+	_, err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		ByPlannerTaskId(taskID).
+		Details().
+		Patch(ctx, body, config)
+
+	return graph.Wrap(ctx, err, "updating task details").OrNil()
+}
+
+// DeleteTask deletes the task.  The etag must come from the current
+// version of the task.
+func (c Planner) DeleteTask(
+	ctx context.Context,
+	taskID, etag string,
+) error {
+	config := &planner.TasksPlannerTaskItemRequestBuilderDeleteRequestConfiguration{
+		Headers: abstractions.NewRequestHeaders(),
+	}
+	config.Headers.Add("If-Match", etag)
+
+	err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		ByPlannerTaskId(taskID).
+		Delete(ctx, config)
+
+	return graph.Wrap(ctx, err, "deleting task").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// PlannerETag produces the etag of a planner resource, if graph
+// returned one.
+func PlannerETag(v serialization.AdditionalDataHolder) string {
+	if v == nil {
+		return ""
+	}
+
+	etag, ok := v.GetAdditionalData()[plannerETagKey]
+	if !ok {
+		return ""
+	}
+
+	switch s := etag.(type) {
+	case *string:
+		return ptr.Val(s)
+	case string:
+		return s
+	}
+
+	return ""
+}
+
+func BytesToPlannerTaskable(body []byte) (models.PlannerTaskable, error) {
+	v, err := CreateFromBytes(body, models.CreatePlannerTaskFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	task, ok := v.(models.PlannerTaskable)
+	if !ok {
+		return nil, clues.New("deserialized item is not a planner task")
+	}
+
+	return task, nil
+}
+
+func BytesToPlannerTaskDetailsable(body []byte) (models.PlannerTaskDetailsable, error) {
+	v, err := CreateFromBytes(body, models.CreatePlannerTaskDetailsFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	td, ok := v.(models.PlannerTaskDetailsable)
+	if !ok {
+		return nil, clues.New("deserialized item is not a planner task details")
+	}
+
+	return td, nil
+}
+
+func BytesToPlannerBucketable(body []byte) (models.PlannerBucketable, error) {
+	v, err := CreateFromBytes(body, models.CreatePlannerBucketFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bucket, ok := v.(models.PlannerBucketable)
+	if !ok {
+		return nil, clues.New("deserialized item is not a planner bucket")
+	}
+
+	return bucket, nil
+}
+
+// PlannerTaskInfo produces the details info of the task.  Tasks only
+// reference their plan and bucket by ID, so the names of both are
+// provided by the caller.
+func PlannerTaskInfo(
+	task models.PlannerTaskable,
+	planTitle, bucketName string,
+	size int64,
+) *details.GroupsInfo {
+	if task == nil {
+		return nil
+	}
+
+	var (
+		created   = ptr.Val(task.GetCreatedDateTime())
+		completed = ptr.Val(task.GetCompletedDateTime())
+		modified  = created
+		creator   string
+		assignees = []string{}
+	)
+
+	// tasks don't expose a modification time.  Completion is the
+	// latest change graph tracks.
+	if completed.After(modified) {
+		modified = completed
+	}
+
+	if cb := task.GetCreatedBy(); cb != nil && cb.GetUser() != nil {
+		creator = ptr.Val(cb.GetUser().GetDisplayName())
+		if len(creator) == 0 {
+			creator = ptr.Val(cb.GetUser().GetId())
+		}
+	}
+
+	if as := task.GetAssignments(); as != nil {
+		for id := range as.GetAdditionalData() {
+			assignees = append(assignees, id)
+		}
+
+		sort.Strings(assignees)
+	}
+
+	return &details.GroupsInfo{
+		ItemType:   details.GroupsPlannerTask,
+		ItemName:   ptr.Val(task.GetTitle()),
+		Modified:   modified,
+		ParentPath: planTitle,
+		Task: details.PlannerTaskInfo{
+			Assignees:       assignees,
+			Bucket:          bucketName,
+			CompletedAt:     completed,
+			CreatedAt:       created,
+			Creator:         creator,
+			DueAt:           ptr.Val(task.GetDueDateTime()),
+			PercentComplete: int(ptr.Val(task.GetPercentComplete())),
+			Plan:            planTitle,
+			Size:            size,
+			Title:           ptr.Val(task.GetTitle()),
+		},
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/planner"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// plan pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerPlanable] = &planPageCtrl{}
+
+type planPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.ItemPlannerPlansRequestBuilder
+	options *groups.ItemPlannerPlansRequestBuilderGetRequestConfiguration
+}
+
+func (p *planPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemPlannerPlansRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *planPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerPlanable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *planPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewPlanPager(
+	groupID string,
+) *planPageCtrl {
+	options := &groups.ItemPlannerPlansRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemPlannerPlansRequestBuilderGetQueryParameters{},
+	}
+
+	return &planPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Groups().
+			ByGroupId(groupID).
+			Planner().
+			Plans(),
+	}
+}
+
+// GetPlans fetches all plans owned by the group.
+func (c Planner) GetPlans(
+	ctx context.Context,
+	groupID string,
+) ([]models.PlannerPlanable, error) {
+	return pagers.BatchEnumerateItems[models.PlannerPlanable](ctx, c.NewPlanPager(groupID))
+}
+
+// ---------------------------------------------------------------------------
+// bucket pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerBucketable] = &bucketPageCtrl{}
+
+type bucketPageCtrl struct {
+	gs      graph.Servicer
+	builder *planner.PlansItemBucketsRequestBuilder
+	options *planner.PlansItemBucketsRequestBuilderGetRequestConfiguration
+}
+
+func (p *bucketPageCtrl) SetNextLink(nextLink string) {
+	p.builder = planner.NewPlansItemBucketsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *bucketPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerBucketable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *bucketPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewBucketPager(
+	planID string,
+) *bucketPageCtrl {
+	options := &planner.PlansItemBucketsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &planner.PlansItemBucketsRequestBuilderGetQueryParameters{},
+	}
+
+	return &bucketPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Planner().
+			Plans().
+			ByPlannerPlanId(planID).
+			Buckets(),
+	}
+}
+
+// GetBuckets fetches all buckets in the plan.
+func (c Planner) GetBuckets(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerBucketable, error) {
+	return pagers.BatchEnumerateItems[models.PlannerBucketable](ctx, c.NewBucketPager(planID))
+}
+
+// ---------------------------------------------------------------------------
+// task pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerTaskable] = &taskPageCtrl{}
+
+type taskPageCtrl struct {
+	gs      graph.Servicer
+	builder *planner.PlansItemTasksRequestBuilder
+	options *planner.PlansItemTasksRequestBuilderGetRequestConfiguration
+}
+
+func (p *taskPageCtrl) SetNextLink(nextLink string) {
+	p.builder = planner.NewPlansItemTasksRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *taskPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerTaskable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, graph.Stack(ctx, err).OrNil()
+}
+
+func (p *taskPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewTaskPager(
+	planID string,
+) *taskPageCtrl {
+	options := &planner.PlansItemTasksRequestBuilderGetRequestConfiguration{
+		QueryParameters: &planner.PlansItemTasksRequestBuilderGetQueryParameters{},
+	}
+
+	return &taskPageCtrl{
+		gs:      c.Stable,
+		options: options,
+		builder: c.Stable.
+			Client().
+			Planner().
+			Plans().
+			ByPlannerPlanId(planID).
+			Tasks(),
+	}
+}
+
+// GetTasks fetches all tasks in the plan.
+func (c Planner) GetTasks(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerTaskable, error) {
+	return pagers.BatchEnumerateItems[models.PlannerTaskable](ctx, c.NewTaskPager(planID))
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type PlannerAPIUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlannerAPIUnitSuite(t *testing.T) {
+	suite.Run(t, &PlannerAPIUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlannerAPIUnitSuite) TestPlannerTaskInfo() {
+	var (
+		t         = suite.T()
+		created   = time.Now().Add(-time.Hour)
+		completed = time.Now()
+		due       = time.Now().Add(time.Hour)
+		task      = models.NewPlannerTask()
+		user      = models.NewIdentity()
+		createdBy = models.NewIdentitySet()
+		assigns   = models.NewPlannerAssignments()
+	)
+
+	user.SetDisplayName(ptr.To("creator"))
+	createdBy.SetUser(user)
+	assigns.SetAdditionalData(map[string]any{
+		"u2": map[string]any{"orderHint": " !"},
+		"u1": map[string]any{"orderHint": " !"},
+	})
+
+	task.SetTitle(ptr.To("title"))
+	task.SetCreatedDateTime(ptr.To(created))
+	task.SetCompletedDateTime(ptr.To(completed))
+	task.SetDueDateTime(ptr.To(due))
+	task.SetPercentComplete(ptr.To[int32](100))
+	task.SetCreatedBy(createdBy)
+	task.SetAssignments(assigns)
+
+	result := PlannerTaskInfo(task, "plan", "bucket", 42)
+	require.NotNil(t, result)
+
+	assert.Equal(t, details.GroupsPlannerTask, result.ItemType)
+	assert.Equal(t, "title", result.ItemName)
+	assert.Equal(t, "plan", result.ParentPath)
+	assert.Equal(t, completed, result.Modified, "completion is the latest change")
+	assert.Equal(
+		t,
+		details.PlannerTaskInfo{
+			Assignees:       []string{"u1", "u2"},
+			Bucket:          "bucket",
+			CompletedAt:     completed,
+			CreatedAt:       created,
+			Creator:         "creator",
+			DueAt:           due,
+			PercentComplete: 100,
+			Plan:            "plan",
+			Size:            42,
+			Title:           "title",
+		},
+		result.Task)
+}
+
+func (suite *PlannerAPIUnitSuite) TestPlannerETag() {
+	table := []struct {
+		name   string
+		data   map[string]any
+		expect string
+	}{
+		{
+			name:   "no etag",
+			data:   map[string]any{},
+			expect: "",
+		},
+		{
+			name:   "string pointer",
+			data:   map[string]any{plannerETagKey: ptr.To(`W/"abc"`)},
+			expect: `W/"abc"`,
+		},
+		{
+			name:   "string",
+			data:   map[string]any{plannerETagKey: `W/"abc"`},
+			expect: `W/"abc"`,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			task := models.NewPlannerTask()
+			task.SetAdditionalData(test.data)
+
+			assert.Equal(suite.T(), test.expect, PlannerETag(task))
+		})
+	}
+}
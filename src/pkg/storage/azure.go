@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cast"
+
+	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type AzureConfig struct {
+	credentials.AzureStorage
+	Container      string // required
+	StorageAccount string // required
+	Prefix         string
+	// Endpoint replaces the blob service domain, which otherwise defaults
+	// to blob.core.windows.net.  Used to reach sovereign clouds.  Accepts
+	// either the domain, or the https url of the storage account.
+	Endpoint string
+	// TenantID and ClientID identify the service principal that accesses
+	// the storage account.  Only used when neither an account key nor a
+	// SAS token is provided.
+	TenantID string
+	ClientID string
+}
+
+var excludedAzureConfigFieldsForHashing = []string{
+	"AzureStorage",
+	"TenantID",
+	"ClientID",
+}
+
+// config key consts
+const (
+	keyAzureAccountKey     = "azure_account_key"
+	keyAzureClientID       = "azure_client_id"
+	keyAzureClientSecret   = "azure_client_secret"
+	keyAzureContainer      = "azure_container"
+	keyAzureEndpoint       = "azure_endpoint"
+	keyAzurePrefix         = "azure_prefix"
+	keyAzureSASToken       = "azure_sas_token"
+	keyAzureStorageAccount = "azure_storage_account"
+	keyAzureTenantID       = "azure_tenant_id"
+)
+
+// config exported name consts
+const (
+	Container          = "container"
+	StorageAccount     = "storageaccount"
+	AzureStorageTenant = "azurestoragetenant"
+	AzureStorageClient = "azurestorageclient"
+)
+
+// config file keys
+const (
+	ContainerKey          = "container"
+	StorageAccountKey     = "storage_account"
+	AzureStorageTenantKey = "azure_storage_tenant_id"
+	AzureStorageClientKey = "azure_storage_client_id"
+
+	AzureStorageAccountKey   = "azure_storage_key"
+	AzureStorageSASToken     = "azure_storage_sas_token"
+	AzureStorageClientSecret = "azure_storage_client_secret"
+)
+
+var azureConstToTomlKeyMap = map[string]string{
+	Container:              ContainerKey,
+	StorageAccount:         StorageAccountKey,
+	Endpoint:               EndpointKey,
+	Prefix:                 PrefixKey,
+	StorageProviderTypeKey: StorageProviderTypeKey,
+}
+
+// add azure config key names that require path related validations
+var azurePathKeys = []string{}
+
+func (s Storage) ToAzureConfig() (*AzureConfig, error) {
+	return buildAzureConfigFromMap(s.Config)
+}
+
+func buildAzureConfigFromMap(config map[string]string) (*AzureConfig, error) {
+	c := &AzureConfig{}
+
+	if len(config) > 0 {
+		c.AccountKey = orEmptyString(config[keyAzureAccountKey])
+		c.SASToken = orEmptyString(config[keyAzureSASToken])
+		c.ClientSecret = orEmptyString(config[keyAzureClientSecret])
+
+		c.Container = orEmptyString(config[keyAzureContainer])
+		c.StorageAccount = orEmptyString(config[keyAzureStorageAccount])
+		c.Prefix = orEmptyString(config[keyAzurePrefix])
+		c.Endpoint = orEmptyString(config[keyAzureEndpoint])
+		c.TenantID = orEmptyString(config[keyAzureTenantID])
+		c.ClientID = orEmptyString(config[keyAzureClientID])
+	}
+
+	return c, c.validate()
+}
+
+func (c *AzureConfig) normalize() AzureConfig {
+	return AzureConfig{
+		AzureStorage: credentials.AzureStorage{
+			AccountKey:   c.AccountKey,
+			SASToken:     strings.TrimPrefix(c.SASToken, "?"),
+			ClientSecret: c.ClientSecret,
+		},
+		Container:      strings.Trim(c.Container, "/"),
+		StorageAccount: c.StorageAccount,
+		Prefix:         common.NormalizePrefix(c.Prefix),
+		Endpoint:       c.Endpoint,
+		TenantID:       c.TenantID,
+		ClientID:       c.ClientID,
+	}
+}
+
+// StringConfig transforms an azureConfig struct into a plain
+// map[string]string.  All values in the original struct which
+// serialize into the map are expected to be strings.
+func (c *AzureConfig) StringConfig() (map[string]string, error) {
+	cn := c.normalize()
+	cfg := map[string]string{
+		keyAzureAccountKey:     cn.AccountKey,
+		keyAzureClientID:       cn.ClientID,
+		keyAzureClientSecret:   cn.ClientSecret,
+		keyAzureContainer:      cn.Container,
+		keyAzureEndpoint:       cn.Endpoint,
+		keyAzurePrefix:         cn.Prefix,
+		keyAzureSASToken:       cn.SASToken,
+		keyAzureStorageAccount: cn.StorageAccount,
+		keyAzureTenantID:       cn.TenantID,
+	}
+
+	return cfg, cn.validate()
+}
+
+func (c AzureConfig) validate() error {
+	check := map[string]string{
+		Container:      c.Container,
+		StorageAccount: c.StorageAccount,
+	}
+	for k, v := range check {
+		if len(v) == 0 {
+			return clues.Stack(errMissingRequired, clues.New(k))
+		}
+	}
+
+	return nil
+}
+
+func (c AzureConfig) configHash() (string, error) {
+	filteredAzureConfig := createFilteredAzureConfigForHashing(c.normalize())
+
+	b, err := json.Marshal(filteredAzureConfig)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	return str.GenerateHash(b), nil
+}
+
+func createFilteredAzureConfigForHashing(source AzureConfig) map[string]any {
+	filteredAzureConfig := make(map[string]any)
+	sourceValue := reflect.ValueOf(source)
+
+	for i := 0; i < sourceValue.NumField(); i++ {
+		fieldName := sourceValue.Type().Field(i).Name
+		if !slices.Contains(excludedAzureConfigFieldsForHashing, fieldName) {
+			filteredAzureConfig[fieldName] = sourceValue.Field(i).Interface()
+		}
+	}
+
+	return filteredAzureConfig
+}
+
+func azureOverrides(in map[string]string) map[string]string {
+	return map[string]string{
+		Container:              in[Container],
+		StorageAccount:         in[StorageAccount],
+		Endpoint:               in[Endpoint],
+		Prefix:                 in[Prefix],
+		AzureStorageTenant:     in[AzureStorageTenant],
+		AzureStorageClient:     in[AzureStorageClient],
+		StorageProviderTypeKey: in[StorageProviderTypeKey],
+	}
+}
+
+func (c *AzureConfig) azureConfigsFromStore(kvg Getter) {
+	c.Container = cast.ToString(kvg.Get(ContainerKey))
+	c.StorageAccount = cast.ToString(kvg.Get(StorageAccountKey))
+	c.Endpoint = cast.ToString(kvg.Get(EndpointKey))
+	c.Prefix = cast.ToString(kvg.Get(PrefixKey))
+	c.TenantID = cast.ToString(kvg.Get(AzureStorageTenantKey))
+	c.ClientID = cast.ToString(kvg.Get(AzureStorageClientKey))
+}
+
+func (c *AzureConfig) azureCredsFromStore(kvg Getter) {
+	c.AccountKey = cast.ToString(kvg.Get(AzureStorageAccountKey))
+	c.SASToken = cast.ToString(kvg.Get(AzureStorageSASToken))
+	c.ClientSecret = cast.ToString(kvg.Get(AzureStorageClientSecret))
+}
+
+var _ Configurer = &AzureConfig{}
+
+func (c *AzureConfig) ApplyConfigOverrides(
+	kvg Getter,
+	readConfigFromStore bool,
+	matchFromConfig bool,
+	overrides map[string]string,
+) error {
+	if readConfigFromStore {
+		c.azureConfigsFromStore(kvg)
+
+		if p, ok := overrides[Prefix]; ok {
+			overrides[Prefix] = common.NormalizePrefix(p)
+		}
+
+		if matchFromConfig {
+			providerType := cast.ToString(kvg.Get(StorageProviderTypeKey))
+			if providerType != ProviderAzure.String() {
+				return clues.New("unsupported storage provider: [" + providerType + "]")
+			}
+
+			if err := mustMatchConfig(kvg, azureConstToTomlKeyMap, azureOverrides(overrides), azurePathKeys); err != nil {
+				return clues.Stack(err)
+			}
+		}
+	}
+
+	c.azureCredsFromStore(kvg)
+
+	c.AzureStorage = credentials.AzureStorage{
+		AccountKey: str.First(
+			overrides[credentials.AzureStorageKey],
+			credentials.Lookup(credentials.AzureStorageKey),
+			c.AccountKey),
+		SASToken: str.First(
+			overrides[credentials.AzureStorageSASToken],
+			credentials.Lookup(credentials.AzureStorageSASToken),
+			c.SASToken),
+		ClientSecret: str.First(
+			overrides[credentials.AzureStorageClientSecret],
+			credentials.Lookup(credentials.AzureStorageClientSecret),
+			c.ClientSecret),
+	}
+
+	c.Container = str.First(overrides[Container], c.Container)
+	c.StorageAccount = str.First(overrides[StorageAccount], c.StorageAccount)
+	c.Endpoint = str.First(overrides[Endpoint], c.Endpoint)
+	c.Prefix = str.First(overrides[Prefix], c.Prefix)
+	c.TenantID = str.First(overrides[AzureStorageTenant], c.TenantID)
+	c.ClientID = str.First(overrides[AzureStorageClient], c.ClientID)
+
+	return c.validate()
+}
+
+var _ WriteConfigToStorer = &AzureConfig{}
+
+func (c *AzureConfig) WriteConfigToStore(
+	kvs Setter,
+) {
+	azureConfig := c.normalize()
+
+	kvs.Set(StorageProviderTypeKey, ProviderAzure.String())
+	kvs.Set(ContainerKey, azureConfig.Container)
+	kvs.Set(StorageAccountKey, azureConfig.StorageAccount)
+	kvs.Set(EndpointKey, azureConfig.Endpoint)
+	kvs.Set(PrefixKey, azureConfig.Prefix)
+	kvs.Set(AzureStorageTenantKey, azureConfig.TenantID)
+	kvs.Set(AzureStorageClientKey, azureConfig.ClientID)
+}
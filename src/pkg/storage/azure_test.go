@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type AzureCfgUnitSuite struct {
+	tester.Suite
+}
+
+func TestAzureCfgUnitSuite(t *testing.T) {
+	suite.Run(t, &AzureCfgUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	goodAzureConfig = AzureConfig{
+		AzureStorage: credentials.AzureStorage{
+			AccountKey:   "key",
+			SASToken:     "sv=sas",
+			ClientSecret: "secret",
+		},
+		Container:      "ctr",
+		StorageAccount: "acct",
+		Prefix:         "pre/",
+		Endpoint:       "blob.core.usgovcloudapi.net",
+		TenantID:       "tid",
+		ClientID:       "cid",
+	}
+
+	goodAzureMap = map[string]string{
+		keyAzureAccountKey:     "key",
+		keyAzureClientID:       "cid",
+		keyAzureClientSecret:   "secret",
+		keyAzureContainer:      "ctr",
+		keyAzureEndpoint:       "blob.core.usgovcloudapi.net",
+		keyAzurePrefix:         "pre/",
+		keyAzureSASToken:       "sv=sas",
+		keyAzureStorageAccount: "acct",
+		keyAzureTenantID:       "tid",
+	}
+)
+
+func (suite *AzureCfgUnitSuite) TestAzureConfig_StringConfig() {
+	table := []struct {
+		name   string
+		input  AzureConfig
+		expect map[string]string
+	}{
+		{
+			name:   "standard",
+			input:  goodAzureConfig,
+			expect: goodAzureMap,
+		},
+		{
+			name: "normalized",
+			input: AzureConfig{
+				AzureStorage: credentials.AzureStorage{
+					AccountKey:   "key",
+					SASToken:     "?sv=sas",
+					ClientSecret: "secret",
+				},
+				Container:      "/ctr/",
+				StorageAccount: "acct",
+				Prefix:         "pre",
+				Endpoint:       goodAzureConfig.Endpoint,
+				TenantID:       "tid",
+				ClientID:       "cid",
+			},
+			expect: goodAzureMap,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := test.input.StringConfig()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *AzureCfgUnitSuite) TestStorage_AzureConfig() {
+	t := suite.T()
+	in := goodAzureConfig
+
+	s, err := NewStorage(ProviderAzure, &in)
+	require.NoError(t, err, clues.ToCore(err))
+
+	out, err := s.ToAzureConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, in, *out)
+
+	sc, err := s.StorageConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, &in, sc)
+}
+
+func (suite *AzureCfgUnitSuite) TestStorage_AzureConfig_InvalidCases() {
+	table := []struct {
+		name  string
+		amend func(Storage)
+	}{
+		{
+			"missing container",
+			func(s Storage) {
+				s.Config[keyAzureContainer] = ""
+			},
+		},
+		{
+			"missing storage account",
+			func(s Storage) {
+				s.Config[keyAzureStorageAccount] = ""
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			st, err := NewStorage(ProviderUnknown, &goodAzureConfig)
+			require.NoError(t, err, clues.ToCore(err))
+			test.amend(st)
+
+			_, err = st.ToAzureConfig()
+			assert.Error(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *AzureCfgUnitSuite) TestAzureConfig_ConfigHash() {
+	t := suite.T()
+
+	base := goodAzureConfig
+
+	baseHash, err := base.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+
+	// credentials and the identity used to reach the account don't
+	// change the repository being addressed.
+	creds := base
+	creds.AzureStorage = credentials.AzureStorage{SASToken: "sv=other"}
+	creds.TenantID = ""
+	creds.ClientID = ""
+
+	credsHash, err := creds.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, baseHash, credsHash)
+
+	unnormalized := base
+	unnormalized.Prefix = "pre"
+
+	unnormalizedHash, err := unnormalized.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, baseHash, unnormalizedHash)
+
+	container := base
+	container.Container = "other"
+
+	containerHash, err := container.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.NotEqual(t, baseHash, containerHash)
+}
+
+func (suite *AzureCfgUnitSuite) TestAzureConfig_ApplyConfigOverrides() {
+	t := suite.T()
+
+	t.Setenv(credentials.AzureStorageKey, "env-key")
+	t.Setenv(credentials.AzureStorageSASToken, "")
+	t.Setenv(credentials.AzureStorageClientSecret, "")
+
+	vpr := viper.New()
+	vpr.Set(StorageProviderTypeKey, ProviderAzure.String())
+	vpr.Set(ContainerKey, "ctr")
+	vpr.Set(StorageAccountKey, "acct")
+	vpr.Set(PrefixKey, "pre/")
+	vpr.Set(EndpointKey, "blob.core.usgovcloudapi.net")
+	vpr.Set(AzureStorageTenantKey, "tid")
+	vpr.Set(AzureStorageClientKey, "cid")
+	vpr.Set(AzureStorageSASToken, "file-sas")
+
+	c := &AzureConfig{}
+
+	err := c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey:               ProviderAzure.String(),
+			Container:                            "ctr",
+			Prefix:                               "pre",
+			Endpoint:                             "blob.core.usgovcloudapi.net",
+			AzureStorageClient:                   "other-cid",
+			credentials.AzureStorageClientSecret: "flag-secret",
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "ctr", c.Container)
+	assert.Equal(t, "acct", c.StorageAccount)
+	assert.Equal(t, "pre/", c.Prefix)
+	assert.Equal(t, "blob.core.usgovcloudapi.net", c.Endpoint)
+	assert.Equal(t, "tid", c.TenantID)
+	assert.Equal(t, "other-cid", c.ClientID, "identity isn't matched against the config file")
+	assert.Equal(t, "env-key", c.AccountKey)
+	assert.Equal(t, "file-sas", c.SASToken)
+	assert.Equal(t, "flag-secret", c.ClientSecret)
+
+	err = c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderAzure.String(),
+			Container:              "other",
+		})
+	assert.Error(t, err, "container doesn't match the config file", clues.ToCore(err))
+
+	vpr.Set(StorageProviderTypeKey, ProviderS3.String())
+
+	err = (&AzureConfig{}).ApplyConfigOverrides(vpr, true, true, map[string]string{})
+	assert.Error(t, err, "provider doesn't match the config file", clues.ToCore(err))
+}
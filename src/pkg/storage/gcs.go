@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cast"
+
+	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type GCSConfig struct {
+	credentials.GCS
+	Bucket string // required
+	Prefix string
+}
+
+var excludedGCSConfigFieldsForHashing = []string{"GCS"}
+
+// config key consts
+const (
+	keyGCSBucket          = "gcs_bucket"
+	keyGCSCredentialsFile = "gcs_credentials_file"
+	keyGCSCredentialsJSON = "gcs_credentials_json"
+	keyGCSPrefix          = "gcs_prefix"
+)
+
+// config file keys
+const (
+	GCSCredentialsFileKey = "gcs_credentials_file"
+)
+
+var gcsConstToTomlKeyMap = map[string]string{
+	Bucket:                 BucketNameKey,
+	Prefix:                 PrefixKey,
+	StorageProviderTypeKey: StorageProviderTypeKey,
+}
+
+// add gcs config key names that require path related validations
+var gcsPathKeys = []string{}
+
+func (s Storage) ToGCSConfig() (*GCSConfig, error) {
+	return buildGCSConfigFromMap(s.Config)
+}
+
+func buildGCSConfigFromMap(config map[string]string) (*GCSConfig, error) {
+	c := &GCSConfig{}
+
+	if len(config) > 0 {
+		c.CredentialsFile = orEmptyString(config[keyGCSCredentialsFile])
+		c.CredentialsJSON = orEmptyString(config[keyGCSCredentialsJSON])
+
+		c.Bucket = orEmptyString(config[keyGCSBucket])
+		c.Prefix = orEmptyString(config[keyGCSPrefix])
+	}
+
+	return c, c.validate()
+}
+
+func (c *GCSConfig) normalize() GCSConfig {
+	return GCSConfig{
+		GCS:    c.GCS,
+		Bucket: common.NormalizeGCSBucket(c.Bucket),
+		Prefix: common.NormalizePrefix(c.Prefix),
+	}
+}
+
+// StringConfig transforms a gcsConfig struct into a plain
+// map[string]string.  All values in the original struct which
+// serialize into the map are expected to be strings.
+func (c *GCSConfig) StringConfig() (map[string]string, error) {
+	cn := c.normalize()
+	cfg := map[string]string{
+		keyGCSBucket:          cn.Bucket,
+		keyGCSCredentialsFile: cn.CredentialsFile,
+		keyGCSCredentialsJSON: cn.CredentialsJSON,
+		keyGCSPrefix:          cn.Prefix,
+	}
+
+	return cfg, cn.validate()
+}
+
+func (c GCSConfig) validate() error {
+	check := map[string]string{
+		Bucket: c.Bucket,
+	}
+	for k, v := range check {
+		if len(v) == 0 {
+			return clues.Stack(errMissingRequired, clues.New(k))
+		}
+	}
+
+	return nil
+}
+
+func (c GCSConfig) configHash() (string, error) {
+	filteredGCSConfig := createFilteredGCSConfigForHashing(c.normalize())
+
+	b, err := json.Marshal(filteredGCSConfig)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	return str.GenerateHash(b), nil
+}
+
+func createFilteredGCSConfigForHashing(source GCSConfig) map[string]any {
+	filteredGCSConfig := make(map[string]any)
+	sourceValue := reflect.ValueOf(source)
+
+	for i := 0; i < sourceValue.NumField(); i++ {
+		fieldName := sourceValue.Type().Field(i).Name
+		if !slices.Contains(excludedGCSConfigFieldsForHashing, fieldName) {
+			filteredGCSConfig[fieldName] = sourceValue.Field(i).Interface()
+		}
+	}
+
+	return filteredGCSConfig
+}
+
+func gcsOverrides(in map[string]string) map[string]string {
+	return map[string]string{
+		Bucket:                 in[Bucket],
+		Prefix:                 in[Prefix],
+		StorageProviderTypeKey: in[StorageProviderTypeKey],
+	}
+}
+
+func (c *GCSConfig) gcsConfigsFromStore(kvg Getter) {
+	c.Bucket = cast.ToString(kvg.Get(BucketNameKey))
+	c.Prefix = cast.ToString(kvg.Get(PrefixKey))
+}
+
+func (c *GCSConfig) gcsCredsFromStore(kvg Getter) {
+	c.CredentialsFile = cast.ToString(kvg.Get(GCSCredentialsFileKey))
+}
+
+var _ Configurer = &GCSConfig{}
+
+func (c *GCSConfig) ApplyConfigOverrides(
+	kvg Getter,
+	readConfigFromStore bool,
+	matchFromConfig bool,
+	overrides map[string]string,
+) error {
+	if readConfigFromStore {
+		c.gcsConfigsFromStore(kvg)
+
+		if b, ok := overrides[Bucket]; ok {
+			overrides[Bucket] = common.NormalizeGCSBucket(b)
+		}
+
+		if p, ok := overrides[Prefix]; ok {
+			overrides[Prefix] = common.NormalizePrefix(p)
+		}
+
+		if matchFromConfig {
+			providerType := cast.ToString(kvg.Get(StorageProviderTypeKey))
+			if providerType != ProviderGCS.String() {
+				return clues.New("unsupported storage provider: [" + providerType + "]")
+			}
+
+			if err := mustMatchConfig(kvg, gcsConstToTomlKeyMap, gcsOverrides(overrides), gcsPathKeys); err != nil {
+				return clues.Stack(err)
+			}
+		}
+	}
+
+	c.gcsCredsFromStore(kvg)
+
+	c.GCS = credentials.GCS{
+		CredentialsFile: str.First(
+			overrides[credentials.GCSCredentialsFile],
+			credentials.Lookup(credentials.GCSCredentialsFile),
+			c.CredentialsFile),
+		CredentialsJSON: str.First(
+			overrides[credentials.GCSCredentialsJSON],
+			credentials.Lookup(credentials.GCSCredentialsJSON),
+			c.CredentialsJSON),
+	}
+
+	c.Bucket = str.First(overrides[Bucket], c.Bucket)
+	c.Prefix = str.First(overrides[Prefix], c.Prefix)
+
+	return c.validate()
+}
+
+var _ WriteConfigToStorer = &GCSConfig{}
+
+func (c *GCSConfig) WriteConfigToStore(
+	kvs Setter,
+) {
+	gcsConfig := c.normalize()
+
+	kvs.Set(StorageProviderTypeKey, ProviderGCS.String())
+	kvs.Set(BucketNameKey, gcsConfig.Bucket)
+	kvs.Set(PrefixKey, gcsConfig.Prefix)
+
+	// the key file path isn't a secret, and is needed to reconnect.
+	if len(gcsConfig.CredentialsFile) > 0 {
+		kvs.Set(GCSCredentialsFileKey, gcsConfig.CredentialsFile)
+	}
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type GCSCfgUnitSuite struct {
+	tester.Suite
+}
+
+func TestGCSCfgUnitSuite(t *testing.T) {
+	suite.Run(t, &GCSCfgUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	goodGCSConfig = GCSConfig{
+		GCS:    credentials.GCS{CredentialsFile: "/keys/sa.json", CredentialsJSON: "{}"},
+		Bucket: "bkt",
+		Prefix: "pre/",
+	}
+
+	goodGCSMap = map[string]string{
+		keyGCSBucket:          "bkt",
+		keyGCSCredentialsFile: "/keys/sa.json",
+		keyGCSCredentialsJSON: "{}",
+		keyGCSPrefix:          "pre/",
+	}
+)
+
+func (suite *GCSCfgUnitSuite) TestGCSConfig_StringConfig() {
+	table := []struct {
+		name   string
+		input  GCSConfig
+		expect map[string]string
+	}{
+		{
+			name:   "standard",
+			input:  goodGCSConfig,
+			expect: goodGCSMap,
+		},
+		{
+			name: "normalized",
+			input: GCSConfig{
+				GCS:    goodGCSConfig.GCS,
+				Bucket: "gs://bkt",
+				Prefix: "pre",
+			},
+			expect: goodGCSMap,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := test.input.StringConfig()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *GCSCfgUnitSuite) TestStorage_GCSConfig() {
+	t := suite.T()
+	in := goodGCSConfig
+
+	s, err := NewStorage(ProviderGCS, &in)
+	require.NoError(t, err, clues.ToCore(err))
+
+	out, err := s.ToGCSConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, in, *out)
+
+	sc, err := s.StorageConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, &in, sc)
+}
+
+func (suite *GCSCfgUnitSuite) TestStorage_GCSConfig_MissingBucket() {
+	t := suite.T()
+
+	st, err := NewStorage(ProviderGCS, &goodGCSConfig)
+	require.NoError(t, err, clues.ToCore(err))
+
+	st.Config[keyGCSBucket] = ""
+
+	_, err = st.ToGCSConfig()
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *GCSCfgUnitSuite) TestGCSConfig_ConfigHash() {
+	t := suite.T()
+
+	base := goodGCSConfig
+
+	baseHash, err := base.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+
+	creds := base
+	creds.GCS = credentials.GCS{}
+
+	credsHash, err := creds.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, baseHash, credsHash)
+
+	unnormalized := base
+	unnormalized.Bucket = "gs://bkt"
+
+	unnormalizedHash, err := unnormalized.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, baseHash, unnormalizedHash)
+
+	bucket := base
+	bucket.Bucket = "other"
+
+	bucketHash, err := bucket.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.NotEqual(t, baseHash, bucketHash)
+}
+
+func (suite *GCSCfgUnitSuite) TestGCSConfig_ApplyConfigOverrides() {
+	t := suite.T()
+
+	t.Setenv(credentials.GCSCredentialsFile, "")
+	t.Setenv(credentials.GCSCredentialsJSON, `{"type":"service_account"}`)
+
+	vpr := viper.New()
+	vpr.Set(StorageProviderTypeKey, ProviderGCS.String())
+	vpr.Set(BucketNameKey, "bkt")
+	vpr.Set(PrefixKey, "pre/")
+	vpr.Set(GCSCredentialsFileKey, "/keys/sa.json")
+
+	c := &GCSConfig{}
+
+	err := c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderGCS.String(),
+			Bucket:                 "gs://bkt",
+			Prefix:                 "pre",
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "bkt", c.Bucket)
+	assert.Equal(t, "pre/", c.Prefix)
+	assert.Equal(t, "/keys/sa.json", c.CredentialsFile)
+	assert.Equal(t, `{"type":"service_account"}`, c.CredentialsJSON)
+
+	err = c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderGCS.String(),
+			Bucket:                 "other",
+		})
+	assert.Error(t, err, "bucket doesn't match the config file", clues.ToCore(err))
+
+	vpr.Set(StorageProviderTypeKey, ProviderS3.String())
+
+	err = (&GCSConfig{}).ApplyConfigOverrides(vpr, true, true, map[string]string{})
+	assert.Error(t, err, "provider doesn't match the config file", clues.ToCore(err))
+}
+
+func (suite *GCSCfgUnitSuite) TestGCSConfig_WriteConfigToStore() {
+	t := suite.T()
+	vpr := viper.New()
+
+	goodGCSConfig.WriteConfigToStore(vpr)
+
+	assert.Equal(t, ProviderGCS.String(), vpr.GetString(StorageProviderTypeKey))
+	assert.Equal(t, "bkt", vpr.GetString(BucketNameKey))
+	assert.Equal(t, "pre/", vpr.GetString(PrefixKey))
+	assert.Equal(t, "/keys/sa.json", vpr.GetString(GCSCredentialsFileKey))
+	assert.False(t, vpr.IsSet(credentials.GCSCredentialsJSON), "credentials json is never persisted")
+}
@@ -11,11 +11,15 @@ func _() {
 	_ = x[ProviderUnknown-0]
 	_ = x[ProviderS3-1]
 	_ = x[ProviderFilesystem-2]
+	_ = x[ProviderAzure-3]
+	_ = x[ProviderGCS-4]
+	_ = x[ProviderWebDAV-5]
+	_ = x[ProviderSFTP-6]
 }
 
-const _ProviderType_name = "Unknown ProviderS3Filesystem"
+const _ProviderType_name = "Unknown ProviderS3FilesystemAzureGCSWebDAVSFTP"
 
-var _ProviderType_index = [...]uint8{0, 16, 18, 28}
+var _ProviderType_index = [...]uint8{0, 16, 18, 28, 33, 36, 42, 46}
 
 func (i ProviderType) String() string {
 	if i < 0 || i >= ProviderType(len(_ProviderType_index)-1) {
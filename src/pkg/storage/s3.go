@@ -2,7 +2,6 @@ package storage
 
 import (
 	"encoding/json"
-	"os"
 	"reflect"
 	"slices"
 	"strconv"
@@ -226,15 +225,15 @@ func (c *S3Config) ApplyConfigOverrides(
 	aws := credentials.AWS{
 		AccessKey: str.First(
 			overrides[credentials.AWSAccessKeyID],
-			os.Getenv(credentials.AWSAccessKeyID),
+			credentials.Lookup(credentials.AWSAccessKeyID),
 			c.AccessKey),
 		SecretKey: str.First(
 			overrides[credentials.AWSSecretAccessKey],
-			os.Getenv(credentials.AWSSecretAccessKey),
+			credentials.Lookup(credentials.AWSSecretAccessKey),
 			c.SecretKey),
 		SessionToken: str.First(
 			overrides[credentials.AWSSessionToken],
-			os.Getenv(credentials.AWSSessionToken),
+			credentials.Lookup(credentials.AWSSessionToken),
 			c.SessionToken),
 	}
 
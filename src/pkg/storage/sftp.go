@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cast"
+
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+const defaultSFTPPort = "22"
+
+type SFTPConfig struct {
+	credentials.SFTP
+	Host     string // required
+	Port     string
+	Username string // required
+	Path     string // required
+	// KeyFile is the path to a private key used to authenticate.
+	KeyFile string
+	// Exactly one of the known hosts file or data is required, so that
+	// the server's host key can be verified.
+	KnownHostsFile string
+	KnownHostsData string
+}
+
+var excludedSFTPConfigFieldsForHashing = []string{
+	"SFTP",
+	"Username",
+	"KeyFile",
+	"KnownHostsFile",
+	"KnownHostsData",
+}
+
+// config key consts
+const (
+	keySFTPHost           = "sftp_host"
+	keySFTPKeyData        = "sftp_key_data"
+	keySFTPKeyFile        = "sftp_key_file"
+	keySFTPKnownHostsData = "sftp_known_hosts_data"
+	keySFTPKnownHostsFile = "sftp_known_hosts_file"
+	keySFTPPassword       = "sftp_password"
+	keySFTPPath           = "sftp_path"
+	keySFTPPort           = "sftp_port"
+	keySFTPUsername       = "sftp_username"
+)
+
+// config exported name consts
+const (
+	SFTPHost           = "sftphost"
+	SFTPPort           = "sftpport"
+	SFTPUsername       = "sftpusername"
+	SFTPPath           = "sftppath"
+	SFTPKeyFile        = "sftpkeyfile"
+	SFTPKnownHostsFile = "sftpknownhostsfile"
+	SFTPKnownHostsData = "sftpknownhostsdata"
+)
+
+// config file keys
+const (
+	SFTPHostKey           = "sftp_host"
+	SFTPPortKey           = "sftp_port"
+	SFTPUsernameKey       = "sftp_username"
+	SFTPPathKey           = "sftp_path"
+	SFTPKeyFileKey        = "sftp_key_file"
+	SFTPKnownHostsFileKey = "sftp_known_hosts_file"
+	// known hosts hold the servers' public keys, so they're safe to store.
+	SFTPKnownHostsDataKey = "sftp_known_hosts_data"
+)
+
+var sftpConstToTomlKeyMap = map[string]string{
+	SFTPHost:               SFTPHostKey,
+	SFTPPort:               SFTPPortKey,
+	SFTPUsername:           SFTPUsernameKey,
+	SFTPPath:               SFTPPathKey,
+	StorageProviderTypeKey: StorageProviderTypeKey,
+}
+
+// add sftp config key names that require path related validations
+var sftpPathKeys = []string{SFTPPath}
+
+func (s Storage) ToSFTPConfig() (*SFTPConfig, error) {
+	return buildSFTPConfigFromMap(s.Config)
+}
+
+func buildSFTPConfigFromMap(config map[string]string) (*SFTPConfig, error) {
+	c := &SFTPConfig{}
+
+	if len(config) > 0 {
+		c.Password = orEmptyString(config[keySFTPPassword])
+		c.KeyData = orEmptyString(config[keySFTPKeyData])
+
+		c.Host = orEmptyString(config[keySFTPHost])
+		c.Port = orEmptyString(config[keySFTPPort])
+		c.Username = orEmptyString(config[keySFTPUsername])
+		c.Path = orEmptyString(config[keySFTPPath])
+		c.KeyFile = orEmptyString(config[keySFTPKeyFile])
+		c.KnownHostsFile = orEmptyString(config[keySFTPKnownHostsFile])
+		c.KnownHostsData = orEmptyString(config[keySFTPKnownHostsData])
+	}
+
+	return c, c.validate()
+}
+
+func (c *SFTPConfig) normalize() SFTPConfig {
+	cn := *c
+	cn.Port = str.First(c.Port, defaultSFTPPort)
+
+	return cn
+}
+
+// StringConfig transforms a sftpConfig struct into a plain
+// map[string]string.  All values in the original struct which
+// serialize into the map are expected to be strings.
+func (c *SFTPConfig) StringConfig() (map[string]string, error) {
+	cn := c.normalize()
+	cfg := map[string]string{
+		keySFTPHost:           cn.Host,
+		keySFTPKeyData:        cn.KeyData,
+		keySFTPKeyFile:        cn.KeyFile,
+		keySFTPKnownHostsData: cn.KnownHostsData,
+		keySFTPKnownHostsFile: cn.KnownHostsFile,
+		keySFTPPassword:       cn.Password,
+		keySFTPPath:           cn.Path,
+		keySFTPPort:           cn.Port,
+		keySFTPUsername:       cn.Username,
+	}
+
+	return cfg, cn.validate()
+}
+
+func (c SFTPConfig) validate() error {
+	check := map[string]string{
+		SFTPHost:     c.Host,
+		SFTPUsername: c.Username,
+		SFTPPath:     c.Path,
+	}
+	for k, v := range check {
+		if len(v) == 0 {
+			return clues.Stack(errMissingRequired, clues.New(k))
+		}
+	}
+
+	if len(c.KnownHostsFile) == 0 && len(c.KnownHostsData) == 0 {
+		return clues.Stack(errMissingRequired, clues.New(SFTPKnownHostsFile))
+	}
+
+	if len(c.Port) > 0 {
+		if _, err := strconv.ParseUint(c.Port, 10, 16); err != nil {
+			return clues.Wrap(err, "invalid sftp port").With("port", c.Port)
+		}
+	}
+
+	return nil
+}
+
+func (c SFTPConfig) configHash() (string, error) {
+	filteredSFTPConfig := createFilteredSFTPConfigForHashing(c.normalize())
+
+	b, err := json.Marshal(filteredSFTPConfig)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	return str.GenerateHash(b), nil
+}
+
+func createFilteredSFTPConfigForHashing(source SFTPConfig) map[string]any {
+	filteredSFTPConfig := make(map[string]any)
+	sourceValue := reflect.ValueOf(source)
+
+	for i := 0; i < sourceValue.NumField(); i++ {
+		fieldName := sourceValue.Type().Field(i).Name
+		if !slices.Contains(excludedSFTPConfigFieldsForHashing, fieldName) {
+			filteredSFTPConfig[fieldName] = sourceValue.Field(i).Interface()
+		}
+	}
+
+	return filteredSFTPConfig
+}
+
+func sftpOverrides(in map[string]string) map[string]string {
+	return map[string]string{
+		SFTPHost:               in[SFTPHost],
+		SFTPPort:               in[SFTPPort],
+		SFTPUsername:           in[SFTPUsername],
+		SFTPPath:               in[SFTPPath],
+		StorageProviderTypeKey: in[StorageProviderTypeKey],
+	}
+}
+
+func (c *SFTPConfig) sftpConfigsFromStore(kvg Getter) {
+	c.Host = cast.ToString(kvg.Get(SFTPHostKey))
+	c.Port = cast.ToString(kvg.Get(SFTPPortKey))
+	c.Username = cast.ToString(kvg.Get(SFTPUsernameKey))
+	c.Path = cast.ToString(kvg.Get(SFTPPathKey))
+	c.KeyFile = cast.ToString(kvg.Get(SFTPKeyFileKey))
+	c.KnownHostsFile = cast.ToString(kvg.Get(SFTPKnownHostsFileKey))
+	c.KnownHostsData = cast.ToString(kvg.Get(SFTPKnownHostsDataKey))
+}
+
+var _ Configurer = &SFTPConfig{}
+
+func (c *SFTPConfig) ApplyConfigOverrides(
+	kvg Getter,
+	readConfigFromStore bool,
+	matchFromConfig bool,
+	overrides map[string]string,
+) error {
+	if readConfigFromStore {
+		c.sftpConfigsFromStore(kvg)
+
+		if matchFromConfig {
+			providerType := cast.ToString(kvg.Get(StorageProviderTypeKey))
+			if providerType != ProviderSFTP.String() {
+				return clues.New("unsupported storage provider: [" + providerType + "]")
+			}
+
+			if err := mustMatchConfig(kvg, sftpConstToTomlKeyMap, sftpOverrides(overrides), sftpPathKeys); err != nil {
+				return clues.Stack(err)
+			}
+		}
+	}
+
+	c.SFTP = credentials.SFTP{
+		Password: str.First(
+			overrides[credentials.SFTPPassword],
+			credentials.Lookup(credentials.SFTPPassword)),
+		KeyData: str.First(
+			overrides[credentials.SFTPKeyData],
+			credentials.Lookup(credentials.SFTPKeyData)),
+	}
+
+	c.Host = str.First(overrides[SFTPHost], c.Host)
+	c.Port = str.First(overrides[SFTPPort], c.Port)
+	c.Username = str.First(overrides[SFTPUsername], c.Username)
+	c.Path = str.First(overrides[SFTPPath], c.Path)
+	c.KeyFile = str.First(overrides[SFTPKeyFile], c.KeyFile)
+	c.KnownHostsFile = str.First(overrides[SFTPKnownHostsFile], c.KnownHostsFile)
+	c.KnownHostsData = str.First(overrides[SFTPKnownHostsData], c.KnownHostsData)
+
+	return c.validate()
+}
+
+var _ WriteConfigToStorer = &SFTPConfig{}
+
+func (c *SFTPConfig) WriteConfigToStore(
+	kvs Setter,
+) {
+	sftpConfig := c.normalize()
+
+	kvs.Set(StorageProviderTypeKey, ProviderSFTP.String())
+	kvs.Set(SFTPHostKey, sftpConfig.Host)
+	kvs.Set(SFTPPortKey, sftpConfig.Port)
+	kvs.Set(SFTPUsernameKey, sftpConfig.Username)
+	kvs.Set(SFTPPathKey, sftpConfig.Path)
+	kvs.Set(SFTPKeyFileKey, sftpConfig.KeyFile)
+	kvs.Set(SFTPKnownHostsFileKey, sftpConfig.KnownHostsFile)
+	kvs.Set(SFTPKnownHostsDataKey, sftpConfig.KnownHostsData)
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type SFTPCfgUnitSuite struct {
+	tester.Suite
+}
+
+func TestSFTPCfgUnitSuite(t *testing.T) {
+	suite.Run(t, &SFTPCfgUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	goodSFTPConfig = SFTPConfig{
+		SFTP:           credentials.SFTP{Password: "pwd"},
+		Host:           "nas.local",
+		Port:           "2222",
+		Username:       "usr",
+		Path:           "/volume1/corso",
+		KnownHostsFile: "/home/usr/.ssh/known_hosts",
+	}
+
+	goodSFTPMap = map[string]string{
+		keySFTPHost:           "nas.local",
+		keySFTPKeyData:        "",
+		keySFTPKeyFile:        "",
+		keySFTPKnownHostsData: "",
+		keySFTPKnownHostsFile: "/home/usr/.ssh/known_hosts",
+		keySFTPPassword:       "pwd",
+		keySFTPPath:           "/volume1/corso",
+		keySFTPPort:           "2222",
+		keySFTPUsername:       "usr",
+	}
+)
+
+func (suite *SFTPCfgUnitSuite) TestSFTPConfig_StringConfig() {
+	t := suite.T()
+
+	result, err := goodSFTPConfig.StringConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, goodSFTPMap, result)
+
+	noPort := goodSFTPConfig
+	noPort.Port = ""
+
+	result, err = noPort.StringConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, defaultSFTPPort, result[keySFTPPort])
+}
+
+func (suite *SFTPCfgUnitSuite) TestStorage_SFTPConfig() {
+	t := suite.T()
+	in := goodSFTPConfig
+
+	s, err := NewStorage(ProviderSFTP, &in)
+	require.NoError(t, err, clues.ToCore(err))
+
+	out, err := s.ToSFTPConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, in, *out)
+}
+
+func (suite *SFTPCfgUnitSuite) TestStorage_SFTPConfig_InvalidCases() {
+	table := []struct {
+		name  string
+		amend func(Storage)
+	}{
+		{
+			"missing host",
+			func(s Storage) {
+				s.Config[keySFTPHost] = ""
+			},
+		},
+		{
+			"missing username",
+			func(s Storage) {
+				s.Config[keySFTPUsername] = ""
+			},
+		},
+		{
+			"missing path",
+			func(s Storage) {
+				s.Config[keySFTPPath] = ""
+			},
+		},
+		{
+			"missing known hosts",
+			func(s Storage) {
+				s.Config[keySFTPKnownHostsFile] = ""
+			},
+		},
+		{
+			"invalid port",
+			func(s Storage) {
+				s.Config[keySFTPPort] = "65536"
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			st, err := NewStorage(ProviderUnknown, &goodSFTPConfig)
+			require.NoError(t, err, clues.ToCore(err))
+			test.amend(st)
+
+			_, err = st.ToSFTPConfig()
+			assert.Error(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *SFTPCfgUnitSuite) TestSFTPConfig_ConfigHash() {
+	t := suite.T()
+
+	base := goodSFTPConfig
+
+	baseHash, err := base.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+
+	auth := base
+	auth.SFTP = credentials.SFTP{KeyData: "key"}
+	auth.Username = "other"
+	auth.KnownHostsFile = ""
+	auth.KnownHostsData = "nas.local ssh-ed25519 AAAA"
+
+	authHash, err := auth.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, baseHash, authHash)
+
+	path := base
+	path.Path = "/volume2/corso"
+
+	pathHash, err := path.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.NotEqual(t, baseHash, pathHash)
+}
+
+func (suite *SFTPCfgUnitSuite) TestSFTPConfig_ApplyConfigOverrides() {
+	t := suite.T()
+
+	t.Setenv(credentials.SFTPPassword, "")
+	t.Setenv(credentials.SFTPKeyData, "env-key")
+
+	vpr := viper.New()
+	goodSFTPConfig.WriteConfigToStore(vpr)
+
+	c := &SFTPConfig{}
+
+	err := c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderSFTP.String(),
+			SFTPHost:               "nas.local",
+			SFTPPath:               "/volume1/corso/",
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "nas.local", c.Host)
+	assert.Equal(t, "2222", c.Port)
+	assert.Equal(t, "usr", c.Username)
+	assert.Equal(t, "/volume1/corso/", c.Path)
+	assert.Equal(t, "/home/usr/.ssh/known_hosts", c.KnownHostsFile)
+	assert.Equal(t, "env-key", c.KeyData)
+	assert.Empty(t, c.Password)
+
+	err = c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderSFTP.String(),
+			SFTPHost:               "other.local",
+		})
+	assert.Error(t, err, "host doesn't match the config file", clues.ToCore(err))
+
+	err = c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderSFTP.String(),
+			SFTPUsername:           "other",
+		})
+	assert.Error(t, err, "username doesn't match the config file", clues.ToCore(err))
+}
+
+func (suite *SFTPCfgUnitSuite) TestSFTPConfig_ApplyConfigOverrides_knownHostsData() {
+	t := suite.T()
+
+	t.Setenv(credentials.SFTPPassword, "pass")
+	t.Setenv(credentials.SFTPKeyData, "")
+
+	cfg := goodSFTPConfig
+	cfg.KnownHostsFile = ""
+	cfg.KnownHostsData = "nas.local ssh-ed25519 AAAA"
+
+	vpr := viper.New()
+	cfg.WriteConfigToStore(vpr)
+
+	// reconnecting without the known hosts flag uses the stored data.
+	c := &SFTPConfig{}
+
+	err := c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{StorageProviderTypeKey: ProviderSFTP.String()})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "nas.local ssh-ed25519 AAAA", c.KnownHostsData)
+	assert.Empty(t, c.KnownHostsFile)
+}
@@ -20,12 +20,20 @@ const (
 	ProviderUnknown    ProviderType = 0 // Unknown Provider
 	ProviderS3         ProviderType = 1 // S3
 	ProviderFilesystem ProviderType = 2 // Filesystem
+	ProviderAzure      ProviderType = 3 // Azure
+	ProviderGCS        ProviderType = 4 // GCS
+	ProviderWebDAV     ProviderType = 5 // WebDAV
+	ProviderSFTP       ProviderType = 6 // SFTP
 )
 
 var StringToProviderType = map[string]ProviderType{
 	ProviderUnknown.String():    ProviderUnknown,
 	ProviderS3.String():         ProviderS3,
 	ProviderFilesystem.String(): ProviderFilesystem,
+	ProviderAzure.String():      ProviderAzure,
+	ProviderGCS.String():        ProviderGCS,
+	ProviderWebDAV.String():     ProviderWebDAV,
+	ProviderSFTP.String():       ProviderSFTP,
 }
 
 const (
@@ -112,6 +120,14 @@ func (s Storage) StorageConfig() (Configurer, error) {
 		return buildS3ConfigFromMap(s.Config)
 	case ProviderFilesystem:
 		return buildFilesystemConfigFromMap(s.Config)
+	case ProviderAzure:
+		return buildAzureConfigFromMap(s.Config)
+	case ProviderGCS:
+		return buildGCSConfigFromMap(s.Config)
+	case ProviderWebDAV:
+		return buildWebDAVConfigFromMap(s.Config)
+	case ProviderSFTP:
+		return buildSFTPConfigFromMap(s.Config)
 	}
 
 	return nil, errInvalidProvider.With("provider", s.Provider)
@@ -134,6 +150,38 @@ func (s Storage) GetStorageConfigHash() (string, error) {
 		}
 
 		return fsCnf.configHash()
+
+	case ProviderAzure:
+		azCnf, err := s.ToAzureConfig()
+		if err != nil {
+			return "", err
+		}
+
+		return azCnf.configHash()
+
+	case ProviderGCS:
+		gcsCnf, err := s.ToGCSConfig()
+		if err != nil {
+			return "", err
+		}
+
+		return gcsCnf.configHash()
+
+	case ProviderWebDAV:
+		webdavCnf, err := s.ToWebDAVConfig()
+		if err != nil {
+			return "", err
+		}
+
+		return webdavCnf.configHash()
+
+	case ProviderSFTP:
+		sftpCnf, err := s.ToSFTPConfig()
+		if err != nil {
+			return "", err
+		}
+
+		return sftpCnf.configHash()
 	}
 
 	return "", errInvalidProvider.With("provider", s.Provider)
@@ -145,6 +193,14 @@ func NewStorageConfig(provider ProviderType) (Configurer, error) {
 		return &S3Config{}, nil
 	case ProviderFilesystem:
 		return &FilesystemConfig{}, nil
+	case ProviderAzure:
+		return &AzureConfig{}, nil
+	case ProviderGCS:
+		return &GCSConfig{}, nil
+	case ProviderWebDAV:
+		return &WebDAVConfig{}, nil
+	case ProviderSFTP:
+		return &SFTPConfig{}, nil
 	}
 
 	return nil, errInvalidProvider.With("provider", provider)
@@ -80,6 +80,31 @@ func (suite *StorageUnitSuite) TestGetAccountConfigHash() {
 			provider: ProviderFilesystem,
 			config:   getTestFileSystemConfig("test/to/dir"),
 		},
+		{
+			name:     "azure storage",
+			provider: ProviderAzure,
+			config:   &AzureConfig{Container: "ctr", StorageAccount: "acct", Prefix: "test-prefix"},
+		},
+		{
+			name:     "gcs storage",
+			provider: ProviderGCS,
+			config:   &GCSConfig{Bucket: "test-bucket", Prefix: "test-prefix"},
+		},
+		{
+			name:     "webdav storage",
+			provider: ProviderWebDAV,
+			config:   &WebDAVConfig{URL: "https://nas.local/corso"},
+		},
+		{
+			name:     "sftp storage",
+			provider: ProviderSFTP,
+			config: &SFTPConfig{
+				Host:           "nas.local",
+				Username:       "usr",
+				Path:           "/volume1/corso",
+				KnownHostsFile: "known_hosts",
+			},
+		},
 		{
 			name:     "invalid account",
 			provider: ProviderUnknown,
@@ -124,6 +149,32 @@ func (suite *StorageUnitSuite) TestGetAccountConfigHash() {
 				require.NoError(t, err)
 				assert.True(t, len(hash) > 0)
 			}
+
+			if test.provider == ProviderAzure {
+				azCnf, ok := test.config.(*AzureConfig)
+				require.True(t, ok)
+
+				s, err := NewStorage(test.provider, azCnf)
+				require.NoError(t, err)
+
+				hash, err := s.GetStorageConfigHash()
+				require.NoError(t, err)
+				assert.True(t, len(hash) > 0)
+			}
+
+			if test.provider == ProviderGCS ||
+				test.provider == ProviderWebDAV ||
+				test.provider == ProviderSFTP {
+				cnf, ok := test.config.(Configurer)
+				require.True(t, ok)
+
+				s, err := NewStorage(test.provider, cnf)
+				require.NoError(t, err)
+
+				hash, err := s.GetStorageConfigHash()
+				require.NoError(t, err)
+				assert.True(t, len(hash) > 0)
+			}
 		})
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/require"
 
@@ -22,6 +24,43 @@ var AWSStorageCredEnvs = []string{
 	credentials.AWSSessionToken,
 }
 
+const (
+	// EnvAzureStorageAccount and EnvAzureContainer locate the azure blob
+	// container used by integration tests.  The account key is read from
+	// $AZURE_STORAGE_KEY.
+	EnvAzureStorageAccount = "CORSO_AZURE_STORAGE_ACCOUNT"
+	EnvAzureContainer      = "CORSO_AZURE_CONTAINER"
+)
+
+var AzureStorageEnvs = []string{
+	EnvAzureStorageAccount,
+	EnvAzureContainer,
+	credentials.AzureStorageKey,
+}
+
+const (
+	// EnvGCSEmulatorHost is the google storage client's own env var for
+	// reaching an emulator, ex: fake-gcs-server at http://localhost:4443.
+	// The emulator must already serve the test bucket.
+	EnvGCSEmulatorHost = "STORAGE_EMULATOR_HOST"
+	gcsEmulatorBucket  = "corso-integration-test"
+
+	// EnvSFTPTestHost, EnvSFTPTestPort and EnvSFTPTestKnownHosts locate a
+	// local sftp container, ex: docker run -p 2222:22 atmoz/sftp corso:corso:::upload
+	// The known hosts value is the output of `ssh-keyscan -p 2222 localhost`.
+	EnvSFTPTestHost       = "CORSO_SFTP_TEST_HOST"
+	EnvSFTPTestPort       = "CORSO_SFTP_TEST_PORT"
+	EnvSFTPTestKnownHosts = "CORSO_SFTP_TEST_KNOWN_HOSTS"
+	sftpTestUser          = "corso"
+	sftpTestPassword      = "corso"
+	sftpTestRoot          = "/upload"
+)
+
+var (
+	GCSEmulatorStorageEnvs = []string{EnvGCSEmulatorHost}
+	SFTPStorageEnvs        = []string{EnvSFTPTestHost, EnvSFTPTestPort, EnvSFTPTestKnownHosts}
+)
+
 // NewPrefixedS3Storage returns a storage.Storage object initialized with environment
 // variables used for integration tests that use S3. The prefix for the storage
 // path will be unique.
@@ -77,6 +116,119 @@ func NewFilesystemStorage(t tester.TestT) storage.Storage {
 	return st
 }
 
+// NewAzureStorage returns a storage.Storage object pointed at the container
+// in $CORSO_AZURE_CONTAINER.  The container is created if it doesn't already
+// exist, and the prefix for the storage path will be unique.
+func NewAzureStorage(t tester.TestT) storage.Storage {
+	var (
+		now       = tester.LogTimeOfTest(t)
+		account   = os.Getenv(EnvAzureStorageAccount)
+		container = os.Getenv(EnvAzureContainer)
+		key       = os.Getenv(credentials.AzureStorageKey)
+		prefix    = testRepoRootPrefix + t.Name() + "-" + now
+	)
+
+	require.NotEmpty(t, account, "azure storage account env var", EnvAzureStorageAccount)
+	require.NotEmpty(t, container, "azure container env var", EnvAzureContainer)
+	require.NotEmpty(t, key, "azure storage key env var", credentials.AzureStorageKey)
+
+	cred, err := azblob.NewSharedKeyCredential(account, key)
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := azblob.NewClientWithSharedKeyCredential(
+		"https://"+account+".blob.core.windows.net/",
+		cred,
+		nil)
+	require.NoError(t, err, clues.ToCore(err))
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	_, err = client.CreateContainer(ctx, container, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		require.NoError(t, err, "creating azure container", clues.ToCore(err))
+	}
+
+	t.Logf("testing at azure container [%s] prefix [%s]", container, prefix)
+
+	st, err := storage.NewStorage(
+		storage.ProviderAzure,
+		&storage.AzureConfig{
+			AzureStorage:   credentials.AzureStorage{AccountKey: key},
+			Container:      container,
+			StorageAccount: account,
+			Prefix:         prefix,
+		},
+		storage.CommonConfig{
+			Corso:       GetAndInsertCorso(""),
+			KopiaCfgDir: t.TempDir(),
+		})
+	require.NoError(t, err, "creating storage", clues.ToCore(err))
+
+	return st
+}
+
+// NewGCSEmulatorStorage returns a storage.Storage object pointed at the
+// gcs emulator in $STORAGE_EMULATOR_HOST.  The prefix for the storage path
+// will be unique.
+func NewGCSEmulatorStorage(t tester.TestT) storage.Storage {
+	var (
+		now    = tester.LogTimeOfTest(t)
+		prefix = testRepoRootPrefix + t.Name() + "-" + now
+	)
+
+	require.NotEmpty(t, os.Getenv(EnvGCSEmulatorHost), "gcs emulator env var", EnvGCSEmulatorHost)
+
+	t.Logf("testing at gcs bucket [%s] prefix [%s]", gcsEmulatorBucket, prefix)
+
+	st, err := storage.NewStorage(
+		storage.ProviderGCS,
+		&storage.GCSConfig{
+			Bucket: gcsEmulatorBucket,
+			Prefix: prefix,
+		},
+		storage.CommonConfig{
+			Corso:       GetAndInsertCorso(""),
+			KopiaCfgDir: t.TempDir(),
+		})
+	require.NoError(t, err, "creating storage", clues.ToCore(err))
+
+	return st
+}
+
+// NewSFTPStorage returns a storage.Storage object pointed at the sftp
+// server described by the CORSO_SFTP_TEST_* env vars.  Each test gets a
+// unique directory beneath the server's upload root.
+func NewSFTPStorage(t tester.TestT) storage.Storage {
+	var (
+		now  = tester.LogTimeOfTest(t)
+		host = os.Getenv(EnvSFTPTestHost)
+		dir  = sftpTestRoot + "/" + testRepoRootPrefix + t.Name() + "-" + now
+	)
+
+	require.NotEmpty(t, host, "sftp host env var", EnvSFTPTestHost)
+
+	t.Logf("testing at sftp host [%s] path [%s]", host, dir)
+
+	st, err := storage.NewStorage(
+		storage.ProviderSFTP,
+		&storage.SFTPConfig{
+			SFTP:           credentials.SFTP{Password: sftpTestPassword},
+			Host:           host,
+			Port:           os.Getenv(EnvSFTPTestPort),
+			Username:       sftpTestUser,
+			Path:           dir,
+			KnownHostsData: os.Getenv(EnvSFTPTestKnownHosts),
+		},
+		storage.CommonConfig{
+			Corso:       GetAndInsertCorso(""),
+			KopiaCfgDir: t.TempDir(),
+		})
+	require.NoError(t, err, "creating storage", clues.ToCore(err))
+
+	return st
+}
+
 // GetCorso is a helper for aggregating Corso secrets and credentials.
 func GetAndInsertCorso(passphase string) credentials.Corso {
 	// fetch data from flag, env var or func param giving priority to func param
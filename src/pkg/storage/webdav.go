@@ -0,0 +1,216 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cast"
+
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type WebDAVConfig struct {
+	credentials.WebDAV
+	URL string // required
+	// TrustedServerCertificateFingerprint pins the sha256 fingerprint of
+	// the server's tls certificate, allowing self-signed certificates on
+	// local NAS devices.
+	TrustedServerCertificateFingerprint string
+}
+
+var excludedWebDAVConfigFieldsForHashing = []string{
+	"WebDAV",
+	"TrustedServerCertificateFingerprint",
+}
+
+// config key consts
+const (
+	keyWebDAVFingerprint = "webdav_fingerprint"
+	keyWebDAVPassword    = "webdav_password"
+	keyWebDAVURL         = "webdav_url"
+	keyWebDAVUsername    = "webdav_username"
+)
+
+// config exported name consts
+const (
+	WebDAVURL                           = "webdavurl"
+	TrustedServerCertificateFingerprint = "trustedservercertificatefingerprint"
+)
+
+// config file keys
+const (
+	WebDAVURLKey                           = "webdav_url"
+	WebDAVUsernameKey                      = "webdav_username"
+	TrustedServerCertificateFingerprintKey = "trusted_server_certificate_fingerprint"
+)
+
+var webdavConstToTomlKeyMap = map[string]string{
+	WebDAVURL:              WebDAVURLKey,
+	StorageProviderTypeKey: StorageProviderTypeKey,
+}
+
+// add webdav config key names that require path related validations
+var webdavPathKeys = []string{}
+
+func (s Storage) ToWebDAVConfig() (*WebDAVConfig, error) {
+	return buildWebDAVConfigFromMap(s.Config)
+}
+
+func buildWebDAVConfigFromMap(config map[string]string) (*WebDAVConfig, error) {
+	c := &WebDAVConfig{}
+
+	if len(config) > 0 {
+		c.Username = orEmptyString(config[keyWebDAVUsername])
+		c.Password = orEmptyString(config[keyWebDAVPassword])
+
+		c.URL = orEmptyString(config[keyWebDAVURL])
+		c.TrustedServerCertificateFingerprint = orEmptyString(config[keyWebDAVFingerprint])
+	}
+
+	return c, c.validate()
+}
+
+// normalize drops any trailing slash from the url, and any colons
+// separating the bytes of the fingerprint, so that values copied from
+// a browser or openssl output compare equally.
+func (c *WebDAVConfig) normalize() WebDAVConfig {
+	return WebDAVConfig{
+		WebDAV: c.WebDAV,
+		URL:    strings.TrimRight(c.URL, "/"),
+		TrustedServerCertificateFingerprint: strings.ToLower(
+			strings.ReplaceAll(c.TrustedServerCertificateFingerprint, ":", "")),
+	}
+}
+
+// StringConfig transforms a webdavConfig struct into a plain
+// map[string]string.  All values in the original struct which
+// serialize into the map are expected to be strings.
+func (c *WebDAVConfig) StringConfig() (map[string]string, error) {
+	cn := c.normalize()
+	cfg := map[string]string{
+		keyWebDAVFingerprint: cn.TrustedServerCertificateFingerprint,
+		keyWebDAVPassword:    cn.Password,
+		keyWebDAVURL:         cn.URL,
+		keyWebDAVUsername:    cn.Username,
+	}
+
+	return cfg, cn.validate()
+}
+
+func (c WebDAVConfig) validate() error {
+	check := map[string]string{
+		WebDAVURL: c.URL,
+	}
+	for k, v := range check {
+		if len(v) == 0 {
+			return clues.Stack(errMissingRequired, clues.New(k))
+		}
+	}
+
+	return nil
+}
+
+func (c WebDAVConfig) configHash() (string, error) {
+	filteredWebDAVConfig := createFilteredWebDAVConfigForHashing(c.normalize())
+
+	b, err := json.Marshal(filteredWebDAVConfig)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	return str.GenerateHash(b), nil
+}
+
+func createFilteredWebDAVConfigForHashing(source WebDAVConfig) map[string]any {
+	filteredWebDAVConfig := make(map[string]any)
+	sourceValue := reflect.ValueOf(source)
+
+	for i := 0; i < sourceValue.NumField(); i++ {
+		fieldName := sourceValue.Type().Field(i).Name
+		if !slices.Contains(excludedWebDAVConfigFieldsForHashing, fieldName) {
+			filteredWebDAVConfig[fieldName] = sourceValue.Field(i).Interface()
+		}
+	}
+
+	return filteredWebDAVConfig
+}
+
+func webdavOverrides(in map[string]string) map[string]string {
+	return map[string]string{
+		WebDAVURL:              in[WebDAVURL],
+		StorageProviderTypeKey: in[StorageProviderTypeKey],
+	}
+}
+
+func (c *WebDAVConfig) webdavConfigsFromStore(kvg Getter) {
+	c.URL = cast.ToString(kvg.Get(WebDAVURLKey))
+	c.TrustedServerCertificateFingerprint = cast.ToString(kvg.Get(TrustedServerCertificateFingerprintKey))
+}
+
+func (c *WebDAVConfig) webdavCredsFromStore(kvg Getter) {
+	c.Username = cast.ToString(kvg.Get(WebDAVUsernameKey))
+}
+
+var _ Configurer = &WebDAVConfig{}
+
+func (c *WebDAVConfig) ApplyConfigOverrides(
+	kvg Getter,
+	readConfigFromStore bool,
+	matchFromConfig bool,
+	overrides map[string]string,
+) error {
+	if readConfigFromStore {
+		c.webdavConfigsFromStore(kvg)
+
+		if u, ok := overrides[WebDAVURL]; ok {
+			overrides[WebDAVURL] = strings.TrimRight(u, "/")
+		}
+
+		if matchFromConfig {
+			providerType := cast.ToString(kvg.Get(StorageProviderTypeKey))
+			if providerType != ProviderWebDAV.String() {
+				return clues.New("unsupported storage provider: [" + providerType + "]")
+			}
+
+			if err := mustMatchConfig(kvg, webdavConstToTomlKeyMap, webdavOverrides(overrides), webdavPathKeys); err != nil {
+				return clues.Stack(err)
+			}
+		}
+	}
+
+	c.webdavCredsFromStore(kvg)
+
+	c.WebDAV = credentials.WebDAV{
+		Username: str.First(
+			overrides[credentials.WebDAVUsername],
+			credentials.Lookup(credentials.WebDAVUsername),
+			c.Username),
+		Password: str.First(
+			overrides[credentials.WebDAVPassword],
+			credentials.Lookup(credentials.WebDAVPassword)),
+	}
+
+	c.URL = str.First(overrides[WebDAVURL], c.URL)
+	c.TrustedServerCertificateFingerprint = str.First(
+		overrides[TrustedServerCertificateFingerprint],
+		c.TrustedServerCertificateFingerprint)
+
+	return c.validate()
+}
+
+var _ WriteConfigToStorer = &WebDAVConfig{}
+
+func (c *WebDAVConfig) WriteConfigToStore(
+	kvs Setter,
+) {
+	webdavConfig := c.normalize()
+
+	kvs.Set(StorageProviderTypeKey, ProviderWebDAV.String())
+	kvs.Set(WebDAVURLKey, webdavConfig.URL)
+	kvs.Set(WebDAVUsernameKey, webdavConfig.Username)
+	kvs.Set(TrustedServerCertificateFingerprintKey, webdavConfig.TrustedServerCertificateFingerprint)
+}
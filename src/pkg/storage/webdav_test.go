@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type WebDAVCfgUnitSuite struct {
+	tester.Suite
+}
+
+func TestWebDAVCfgUnitSuite(t *testing.T) {
+	suite.Run(t, &WebDAVCfgUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	goodWebDAVConfig = WebDAVConfig{
+		WebDAV:                              credentials.WebDAV{Username: "usr", Password: "pwd"},
+		URL:                                 "https://nas.local:5006/corso",
+		TrustedServerCertificateFingerprint: "ab12cd",
+	}
+
+	goodWebDAVMap = map[string]string{
+		keyWebDAVFingerprint: "ab12cd",
+		keyWebDAVPassword:    "pwd",
+		keyWebDAVURL:         "https://nas.local:5006/corso",
+		keyWebDAVUsername:    "usr",
+	}
+)
+
+func (suite *WebDAVCfgUnitSuite) TestWebDAVConfig_StringConfig() {
+	table := []struct {
+		name   string
+		input  WebDAVConfig
+		expect map[string]string
+	}{
+		{
+			name:   "standard",
+			input:  goodWebDAVConfig,
+			expect: goodWebDAVMap,
+		},
+		{
+			name: "normalized",
+			input: WebDAVConfig{
+				WebDAV:                              goodWebDAVConfig.WebDAV,
+				URL:                                 "https://nas.local:5006/corso/",
+				TrustedServerCertificateFingerprint: "AB:12:CD",
+			},
+			expect: goodWebDAVMap,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := test.input.StringConfig()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *WebDAVCfgUnitSuite) TestStorage_WebDAVConfig() {
+	t := suite.T()
+	in := goodWebDAVConfig
+
+	s, err := NewStorage(ProviderWebDAV, &in)
+	require.NoError(t, err, clues.ToCore(err))
+
+	out, err := s.ToWebDAVConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, in, *out)
+
+	st, err := NewStorage(ProviderWebDAV, &in)
+	require.NoError(t, err, clues.ToCore(err))
+
+	st.Config[keyWebDAVURL] = ""
+
+	_, err = st.ToWebDAVConfig()
+	assert.Error(t, err, "missing url", clues.ToCore(err))
+}
+
+func (suite *WebDAVCfgUnitSuite) TestWebDAVConfig_ConfigHash() {
+	t := suite.T()
+
+	base := goodWebDAVConfig
+
+	baseHash, err := base.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+
+	// credentials and certificate pins can rotate without changing the
+	// repository being addressed.
+	rotated := base
+	rotated.WebDAV = credentials.WebDAV{Username: "other"}
+	rotated.TrustedServerCertificateFingerprint = "ef34"
+
+	rotatedHash, err := rotated.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, baseHash, rotatedHash)
+
+	url := base
+	url.URL = "https://nas.local:5006/other"
+
+	urlHash, err := url.configHash()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.NotEqual(t, baseHash, urlHash)
+}
+
+func (suite *WebDAVCfgUnitSuite) TestWebDAVConfig_ApplyConfigOverrides() {
+	t := suite.T()
+
+	t.Setenv(credentials.WebDAVUsername, "")
+	t.Setenv(credentials.WebDAVPassword, "env-pwd")
+
+	vpr := viper.New()
+	vpr.Set(StorageProviderTypeKey, ProviderWebDAV.String())
+	vpr.Set(WebDAVURLKey, "https://nas.local:5006/corso")
+	vpr.Set(WebDAVUsernameKey, "usr")
+	vpr.Set(TrustedServerCertificateFingerprintKey, "ab12cd")
+
+	c := &WebDAVConfig{}
+
+	err := c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderWebDAV.String(),
+			WebDAVURL:              "https://nas.local:5006/corso/",
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "https://nas.local:5006/corso", c.URL)
+	assert.Equal(t, "usr", c.Username)
+	assert.Equal(t, "env-pwd", c.Password)
+	assert.Equal(t, "ab12cd", c.TrustedServerCertificateFingerprint)
+
+	err = c.ApplyConfigOverrides(
+		vpr,
+		true,
+		true,
+		map[string]string{
+			StorageProviderTypeKey: ProviderWebDAV.String(),
+			WebDAVURL:              "https://nas.local:5006/other",
+		})
+	assert.Error(t, err, "url doesn't match the config file", clues.ToCore(err))
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/maintenance"
+)
+
+type MaintenanceResultWrapper interface {
+	GetMaintenanceResults(ctx context.Context) ([]*maintenance.Result, error)
+}
+
+// GetMaintenanceResults retrieves the results of every recorded maintenance
+// run in the model store, newest first.
+func (w wrapper) GetMaintenanceResults(ctx context.Context) ([]*maintenance.Result, error) {
+	bms, err := w.GetIDsForType(ctx, model.MaintenanceSchema, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing maintenance results")
+	}
+
+	rs := make([]*maintenance.Result, len(bms))
+
+	for i, bm := range bms {
+		r := &maintenance.Result{}
+
+		err := w.GetWithModelStoreID(ctx, model.MaintenanceSchema, bm.ModelStoreID, r)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting maintenance result")
+		}
+
+		rs[i] = r
+	}
+
+	maintenance.Sort(rs)
+
+	return rs, nil
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/backup/retention"
+)
+
+type RetentionPolicyWrapper interface {
+	GetRetentionPolicies(ctx context.Context) ([]*retention.Policy, error)
+	PutRetentionPolicy(ctx context.Context, p *retention.Policy) error
+	DeleteRetentionPolicy(ctx context.Context, id model.StableID) error
+}
+
+// GetRetentionPolicies retrieves all retention policies in the model store.
+func (w wrapper) GetRetentionPolicies(ctx context.Context) ([]*retention.Policy, error) {
+	bms, err := w.GetIDsForType(ctx, model.RetentionSchema, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing retention policies")
+	}
+
+	ps := make([]*retention.Policy, len(bms))
+
+	for i, bm := range bms {
+		p := &retention.Policy{}
+
+		err := w.GetWithModelStoreID(ctx, model.RetentionSchema, bm.ModelStoreID, p)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting retention policy")
+		}
+
+		ps[i] = p
+	}
+
+	return ps, nil
+}
+
+// PutRetentionPolicy adds the policy to the model store, replacing any
+// policy that already exists for the same scope.
+func (w wrapper) PutRetentionPolicy(ctx context.Context, p *retention.Policy) error {
+	p.ID = retention.PolicyID(p.Service, p.ProtectedResourceID)
+
+	ctx = clues.Add(ctx, "retention_policy_id", p.ID)
+
+	prev := &retention.Policy{}
+
+	err := w.Get(ctx, model.RetentionSchema, p.ID, prev)
+	if err != nil && !errors.Is(err, data.ErrNotFound) {
+		return clues.Wrap(err, "getting existing retention policy")
+	}
+
+	if err != nil {
+		return clues.Wrap(
+			w.Put(ctx, model.RetentionSchema, p),
+			"adding retention policy").OrNil()
+	}
+
+	p.ModelStoreID = prev.ModelStoreID
+
+	return clues.Wrap(
+		w.Update(ctx, model.RetentionSchema, p),
+		"updating retention policy").OrNil()
+}
+
+// DeleteRetentionPolicy removes the policy from the model store.
+func (w wrapper) DeleteRetentionPolicy(ctx context.Context, id model.StableID) error {
+	return w.Delete(ctx, model.RetentionSchema, id)
+}